@@ -52,9 +52,11 @@ func setupAutoDiscovery(confSearchPaths []string, wmeta workloadmeta.Component,
 
 	acTelemetryStore := ac.GetTelemetryStore()
 
+	// Watching the configuration files relies on the polling loop of the
+	// provider, which only collects them again when they change.
 	ac.AddConfigProvider(
 		providers.NewFileConfigProvider(acTelemetryStore),
-		pkgconfigsetup.Datadog().GetBool("autoconf_config_files_poll"),
+		pkgconfigsetup.Datadog().GetBool("autoconf_config_files_poll") || pkgconfigsetup.Datadog().GetBool("autoconf_config_files_watch"),
 		time.Duration(pkgconfigsetup.Datadog().GetInt("autoconf_config_files_poll_interval"))*time.Second,
 	)
 
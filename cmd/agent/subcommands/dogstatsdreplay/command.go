@@ -48,6 +48,11 @@ type cliParams struct {
 	dsdVerboseReplay    bool
 	dsdMmapReplay       bool
 	dsdReplayIterations int
+
+	dsdReplayMetricPrefixes []string
+	dsdReplayTags           []string
+	dsdReplayAnonymize      bool
+	dsdReplayOutputPath     string
 }
 
 // Commands returns a slice of subcommands for the 'agent' command.
@@ -74,6 +79,10 @@ func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 	dogstatsdReplayCmd.Flags().BoolVarP(&cliParams.dsdVerboseReplay, "verbose", "v", false, "Verbose replay.")
 	dogstatsdReplayCmd.Flags().BoolVarP(&cliParams.dsdMmapReplay, "mmap", "m", true, "Mmap file for replay. Set to false to load the entire file into memory instead")
 	dogstatsdReplayCmd.Flags().IntVarP(&cliParams.dsdReplayIterations, "loops", "l", defaultIterations, "Number of iterations to replay.")
+	dogstatsdReplayCmd.Flags().StringSliceVar(&cliParams.dsdReplayMetricPrefixes, "metric-prefix", nil, "Only replay the metrics starting with one of these prefixes, dropping events and service checks.")
+	dogstatsdReplayCmd.Flags().StringSliceVar(&cliParams.dsdReplayTags, "tag", nil, "Only replay the messages with one of these tags, given as key:value or as key to match any value.")
+	dogstatsdReplayCmd.Flags().BoolVar(&cliParams.dsdReplayAnonymize, "anonymize", false, "Replace the tag values with a deterministic hash.")
+	dogstatsdReplayCmd.Flags().StringVarP(&cliParams.dsdReplayOutputPath, "output", "o", "", "Write the filtered capture to this file instead of replaying it, to share it without leaking internal names.")
 
 	return []*cobra.Command{dogstatsdReplayCmd}
 }
@@ -92,6 +101,11 @@ func dogstatsdReplay(_ log.Component, config config.Component, cliParams *cliPar
 		done <- true
 	}()
 
+	filter := replay.NewPayloadFilter(cliParams.dsdReplayMetricPrefixes, cliParams.dsdReplayTags, cliParams.dsdReplayAnonymize)
+	if cliParams.dsdReplayOutputPath != "" {
+		return writeFilteredCapture(cliParams, filter)
+	}
+
 	fmt.Printf("Replaying dogstatsd traffic...\n\n")
 
 	md := metadata.MD{
@@ -156,7 +170,7 @@ func dogstatsdReplay(_ log.Component, config config.Component, cliParams *cliPar
 		fmt.Printf("Unable to load state from file, tag enrichment will be unavailable for this capture: %v\n", err)
 	}
 
-	resp, err := cli.DogstatsdSetTaggerState(ctx, taggerState)
+	resp, err := cli.DogstatsdSetTaggerState(ctx, filter.FilterState(taggerState))
 	if err != nil {
 		fmt.Printf("Unable to load state API error, tag enrichment will be unavailable for this capture: %v\n", err)
 	} else if !resp.GetLoaded() {
@@ -177,10 +191,15 @@ func dogstatsdReplay(_ log.Component, config config.Component, cliParams *cliPar
 		for {
 			select {
 			case msg := <-reader.Traffic:
+				payload := filter.Filter(msg.Payload[:msg.PayloadSize])
+				if len(payload) == 0 {
+					continue
+				}
+
 				// The cadence is enforced by the reader. The reader will only write to
 				// the traffic channel when it estimates the payload should be submitted.
 				n, oobn, err := conn.(*net.UnixConn).WriteMsgUnix(
-					msg.Payload[:msg.PayloadSize], replay.GetUcredsForPid(msg.Pid), addr)
+					payload, replay.GetUcredsForPid(msg.Pid), addr)
 				if err != nil {
 					return err
 				}
@@ -213,3 +232,29 @@ func dogstatsdReplay(_ log.Component, config config.Component, cliParams *cliPar
 	fmt.Println("replay done")
 	return err
 }
+
+// writeFilteredCapture writes the messages of the capture matching the filter
+// to a new capture file rather than replaying them.
+func writeFilteredCapture(cliParams *cliParams, filter *replay.PayloadFilter) error {
+	reader, err := replay.NewTrafficCaptureReader(cliParams.dsdReplayFilePath, 0, cliParams.dsdMmapReplay)
+	if reader != nil {
+		defer reader.Close()
+	}
+	if err != nil {
+		fmt.Printf("could not open: %s\n", cliParams.dsdReplayFilePath)
+		return err
+	}
+
+	f, err := os.Create(cliParams.dsdReplayOutputPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := replay.WriteFilteredCapture(reader, f, filter); err != nil {
+		return err
+	}
+
+	fmt.Printf("Filtered capture written to: %s\n", cliParams.dsdReplayOutputPath)
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -96,6 +97,12 @@ type cliParams struct {
 	withStreamLogs       time.Duration
 	logLevelDefaultOff   command.LogLevelDefaultOff
 	providerTimeout      time.Duration
+	includeSections      []string
+	excludeSections      []string
+	resendPath           string
+	listRemote           bool
+	approveRemote        string
+	denyRemote           string
 }
 
 // Commands returns a slice of subcommands for the 'agent' command.
@@ -191,7 +198,14 @@ func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 	flareCmd.Flags().IntVarP(&cliParams.profileBlockingRate, "profile-blocking-rate", "", 10000, "Set the fraction of goroutine blocking events that are reported in the blocking profile")
 	flareCmd.Flags().DurationVarP(&cliParams.withStreamLogs, "with-stream-logs", "L", 0*time.Second, "Add stream-logs data to the flare. It will collect logs for the amount of seconds passed to the flag")
 	flareCmd.Flags().DurationVarP(&cliParams.providerTimeout, "provider-timeout", "t", 0*time.Second, "Timeout to run each flare provider in seconds. This is not a global timeout for the flare creation process.")
+	flareCmd.Flags().StringSliceVarP(&cliParams.includeSections, "include", "", nil, fmt.Sprintf("Only add these sections to the flare, along with the files belonging to no section. Valid sections are: %s", strings.Join(helpers.FlareSections(), ", ")))
+	flareCmd.Flags().StringSliceVarP(&cliParams.excludeSections, "exclude", "", nil, "Do not add these sections to the flare. See --include for the valid sections")
+	flareCmd.Flags().StringVarP(&cliParams.resendPath, "resend", "", "", "Send an already generated flare archive instead of creating a new one, resuming its upload if it was interrupted")
+	flareCmd.Flags().BoolVarP(&cliParams.listRemote, "list-remote", "", false, "List the flares requested by remote-config waiting for a confirmation")
+	flareCmd.Flags().StringVarP(&cliParams.approveRemote, "approve-remote", "", "", "Approve the flare requested by remote-config with this request UUID, the agent creates and sends it")
+	flareCmd.Flags().StringVarP(&cliParams.denyRemote, "deny-remote", "", "", "Deny the flare requested by remote-config with this request UUID")
 	flareCmd.SetArgs([]string{"caseID"})
+	flareCmd.AddCommand(makeDiffCommand())
 
 	return []*cobra.Command{flareCmd}
 }
@@ -217,6 +231,27 @@ func makeFlare(flareComp flare.Component,
 		err     error
 	)
 
+	if cliParams.listRemote {
+		return listRemoteFlares(client)
+	}
+	if cliParams.approveRemote != "" {
+		return decideRemoteFlare(client, cliParams.approveRemote, "approved")
+	}
+	if cliParams.denyRemote != "" {
+		return decideRemoteFlare(client, cliParams.denyRemote, "denied")
+	}
+
+	if err := helpers.ValidateFlareSections(cliParams.includeSections); err != nil {
+		return err
+	}
+	if err := helpers.ValidateFlareSections(cliParams.excludeSections); err != nil {
+		return err
+	}
+	flareArgs := flaretypes.FlareArgs{
+		IncludeSections: cliParams.includeSections,
+		ExcludeSections: cliParams.excludeSections,
+	}
+
 	streamLogParams := streamlogs.CliParams{
 		FilePath: defaultpaths.StreamlogsLogFile,
 		Duration: cliParams.withStreamLogs,
@@ -248,6 +283,10 @@ func makeFlare(flareComp flare.Component,
 		}
 	}
 
+	if cliParams.resendPath != "" {
+		return sendArchive(flareComp, cliParams.resendPath, caseID, customerEmail, cliParams.autoconfirm)
+	}
+
 	if cliParams.profiling >= 30 {
 		c, err := common.NewSettingsClient(client)
 		if err != nil {
@@ -291,12 +330,12 @@ func makeFlare(flareComp flare.Component,
 
 	if cliParams.forceLocal {
 		diagnoseresult := runLocalDiagnose(diagnoseComponent, diagnose.Config{Verbose: true}, lc, senderManager, filterStore, wmeta, ac, secretResolver, tagger, config)
-		filePath, err = createArchive(flareComp, profile, cliParams.providerTimeout, nil, diagnoseresult)
+		filePath, err = createArchive(flareComp, flareArgs, profile, cliParams.providerTimeout, nil, diagnoseresult)
 	} else {
-		filePath, err = requestArchive(profile, client, cliParams.providerTimeout)
+		filePath, err = requestArchive(flareArgs, profile, client, cliParams.providerTimeout)
 		if err != nil {
 			diagnoseresult := runLocalDiagnose(diagnoseComponent, diagnose.Config{Verbose: true}, lc, senderManager, filterStore, wmeta, ac, secretResolver, tagger, config)
-			filePath, err = createArchive(flareComp, profile, cliParams.providerTimeout, err, diagnoseresult)
+			filePath, err = createArchive(flareComp, flareArgs, profile, cliParams.providerTimeout, err, diagnoseresult)
 		}
 	}
 
@@ -310,8 +349,18 @@ func makeFlare(flareComp flare.Component,
 		return err
 	}
 
+	return sendArchive(flareComp, filePath, caseID, customerEmail, cliParams.autoconfirm)
+}
+
+// sendArchive uploads the flare archive at filePath, after asking for confirmation unless autoconfirm is set.
+func sendArchive(flareComp flare.Component, filePath string, caseID string, customerEmail string, autoconfirm bool) error {
+	if _, err := os.Stat(filePath); err != nil {
+		fmt.Fprintln(color.Output, color.RedString(fmt.Sprintf("The flare zipfile \"%s\" does not exist.", filePath)))
+		return err
+	}
+
 	fmt.Fprintf(color.Output, "%s is going to be uploaded to Datadog\n", color.YellowString(filePath))
-	if !cliParams.autoconfirm {
+	if !autoconfirm {
 		confirmation := input.AskForConfirmation("Are you sure you want to upload a flare? [y/N]")
 		if !confirmation {
 			fmt.Fprintf(color.Output, "Aborting. (You can still use %s)\n", color.YellowString(filePath))
@@ -322,12 +371,82 @@ func makeFlare(flareComp flare.Component,
 	response, e := flareComp.Send(filePath, caseID, customerEmail, helpers.NewLocalFlareSource())
 	fmt.Println(response)
 	if e != nil {
+		resendCmd := "agent flare --resend " + filePath
+		if caseID != "" {
+			resendCmd = fmt.Sprintf("agent flare %s --resend %s", caseID, filePath)
+		}
+		fmt.Fprintf(color.Output, "You can send the flare again with: %s\n", color.YellowString(resendCmd))
 		return e
 	}
 	return nil
 }
 
-func requestArchive(pdata flaretypes.ProfileData, client ipc.HTTPClient, providerTimeout time.Duration) (string, error) {
+// remoteFlareURL returns the URL of the agent endpoint listing and deciding the flares requested by remote-config.
+func remoteFlareURL() (string, error) {
+	ipcAddress, err := pkgconfigsetup.GetIPCAddress(pkgconfigsetup.Datadog())
+	if err != nil {
+		return "", err
+	}
+	cmdport := pkgconfigsetup.Datadog().GetInt("cmd_port")
+	url := &url.URL{
+		Scheme: "https",
+		Host:   net.JoinHostPort(ipcAddress, strconv.Itoa(cmdport)),
+		Path:   "/agent/flare/remote",
+	}
+	return url.String(), nil
+}
+
+func listRemoteFlares(client ipc.HTTPClient) error {
+	urlstr, err := remoteFlareURL()
+	if err != nil {
+		return err
+	}
+
+	r, err := client.Get(urlstr)
+	if err != nil {
+		if r != nil && string(r) != "" {
+			return fmt.Errorf("error listing the remote flare requests: %s", r)
+		}
+		return fmt.Errorf("error listing the remote flare requests (is the agent running?): %w", err)
+	}
+
+	var requests []struct {
+		UUID       string    `json:"uuid"`
+		CaseID     string    `json:"case_id"`
+		UserHandle string    `json:"user_handle"`
+		ReceivedAt time.Time `json:"received_at"`
+	}
+	if err := json.Unmarshal(r, &requests); err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		fmt.Fprintln(color.Output, "No flare requested by remote-config is waiting for a confirmation.")
+		return nil
+	}
+	for _, req := range requests {
+		fmt.Fprintf(color.Output, "%s: case %s requested by %s at %s\n", color.YellowString(req.UUID), req.CaseID, req.UserHandle, req.ReceivedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func decideRemoteFlare(client ipc.HTTPClient, uuid string, decision string) error {
+	urlstr, err := remoteFlareURL()
+	if err != nil {
+		return err
+	}
+
+	r, err := client.PostForm(urlstr, url.Values{"uuid": {uuid}, "decision": {decision}})
+	if err != nil {
+		if r != nil && string(r) != "" {
+			return fmt.Errorf("error deciding the remote flare request: %s", r)
+		}
+		return fmt.Errorf("error deciding the remote flare request (is the agent running?): %w", err)
+	}
+	fmt.Fprintln(color.Output, color.GreenString(string(r)))
+	return nil
+}
+
+func requestArchive(flareArgs flaretypes.FlareArgs, pdata flaretypes.ProfileData, client ipc.HTTPClient, providerTimeout time.Duration) (string, error) {
 	fmt.Fprintln(color.Output, color.BlueString("Asking the agent to build the flare archive."))
 	ipcAddress, err := pkgconfigsetup.GetIPCAddress(pkgconfigsetup.Datadog())
 	if err != nil {
@@ -341,11 +460,17 @@ func requestArchive(pdata flaretypes.ProfileData, client ipc.HTTPClient, provide
 		Host:   net.JoinHostPort(ipcAddress, strconv.Itoa(cmdport)),
 		Path:   "/agent/flare",
 	}
+	q := url.Query()
 	if providerTimeout > 0 {
-		q := url.Query()
 		q.Set("provider_timeout", strconv.FormatInt(int64(providerTimeout), 10))
-		url.RawQuery = q.Encode()
 	}
+	if len(flareArgs.IncludeSections) > 0 {
+		q.Set("include", strings.Join(flareArgs.IncludeSections, ","))
+	}
+	if len(flareArgs.ExcludeSections) > 0 {
+		q.Set("exclude", strings.Join(flareArgs.ExcludeSections, ","))
+	}
+	url.RawQuery = q.Encode()
 
 	urlstr := url.String()
 
@@ -370,9 +495,9 @@ func requestArchive(pdata flaretypes.ProfileData, client ipc.HTTPClient, provide
 	return string(r), nil
 }
 
-func createArchive(flareComp flare.Component, pdata flaretypes.ProfileData, providerTimeout time.Duration, ipcError error, diagnoseResult []byte) (string, error) {
+func createArchive(flareComp flare.Component, flareArgs flaretypes.FlareArgs, pdata flaretypes.ProfileData, providerTimeout time.Duration, ipcError error, diagnoseResult []byte) (string, error) {
 	fmt.Fprintln(color.Output, color.YellowString("Initiating flare locally."))
-	filePath, err := flareComp.Create(pdata, providerTimeout, ipcError, diagnoseResult)
+	filePath, err := flareComp.CreateWithArgs(flareArgs, pdata, providerTimeout, ipcError, diagnoseResult)
 	if err != nil {
 		fmt.Printf("The flare zipfile failed to be created: %s\n", err)
 		return "", err
@@ -0,0 +1,271 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package flare
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// flareDiffCategory is a category of the files compared by 'agent flare diff'
+type flareDiffCategory struct {
+	name  string
+	match func(path string) bool
+}
+
+var flareDiffCategories = []flareDiffCategory{
+	{"config", func(p string) bool { return strings.HasPrefix(p, "etc/") }},
+	{"runtime settings", func(p string) bool { return strings.HasSuffix(p, "runtime_config_dump.yaml") }},
+	{"check schedules", func(p string) bool { return p == "config-check.log" }},
+	{"status", func(p string) bool { return p == "status.log" || strings.HasSuffix(p, "-status.log") }},
+}
+
+// flareDiff is the difference between two flares, by category
+type flareDiff struct {
+	Categories []categoryDiff `json:"categories"`
+}
+
+type categoryDiff struct {
+	Name  string     `json:"name"`
+	Files []fileDiff `json:"files"`
+}
+
+// fileDiff is the difference of a file between two flares. The YAML files are compared key by key, the other files
+// line by line.
+type fileDiff struct {
+	Path string `json:"path"`
+	// OnlyIn is 1 or 2 when the file is only in the first or the second flare
+	OnlyIn  int         `json:"only_in,omitempty"`
+	Keys    []keyChange `json:"keys,omitempty"`
+	Unified string      `json:"unified,omitempty"`
+}
+
+// keyChange is a YAML key, flattened with dots, whose value differs between two flares
+type keyChange struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Change is "added", "removed" or "changed"
+	Change string `json:"change"`
+}
+
+func makeDiffCommand() *cobra.Command {
+	var jsonOutput bool
+	diffCmd := &cobra.Command{
+		Use:   "diff <flare1.zip> <flare2.zip>",
+		Short: "Compare the configuration, runtime settings, check schedules and status of two flares",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			diff, err := diffFlares(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(diff)
+			}
+			printFlareDiff(cmd.OutOrStdout(), diff, args[0], args[1])
+			return nil
+		},
+	}
+	diffCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Print the diff as JSON")
+	return diffCmd
+}
+
+// diffFlares compares the files of the flare archives at path1 and path2.
+func diffFlares(path1, path2 string) (*flareDiff, error) {
+	files1, err := readFlareArchive(path1)
+	if err != nil {
+		return nil, err
+	}
+	files2, err := readFlareArchive(path2)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := slices.Collect(maps.Keys(files1))
+	for p := range files2 {
+		if _, ok := files1[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := &flareDiff{}
+	for _, category := range flareDiffCategories {
+		catDiff := categoryDiff{Name: category.name}
+		for _, p := range paths {
+			if !category.match(p) {
+				continue
+			}
+			content1, in1 := files1[p]
+			content2, in2 := files2[p]
+			switch {
+			case !in2:
+				catDiff.Files = append(catDiff.Files, fileDiff{Path: p, OnlyIn: 1})
+			case !in1:
+				catDiff.Files = append(catDiff.Files, fileDiff{Path: p, OnlyIn: 2})
+			default:
+				if fd, changed := diffFile(p, content1, content2); changed {
+					catDiff.Files = append(catDiff.Files, fd)
+				}
+			}
+		}
+		diff.Categories = append(diff.Categories, catDiff)
+	}
+	return diff, nil
+}
+
+// readFlareArchive returns the content of the files of a flare archive, by their path in the flare. The directory
+// named after the hostname, at the root of the archive, is removed from the paths.
+func readFlareArchive(archivePath string) (map[string][]byte, error) {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the flare %s: %w", archivePath, err)
+	}
+	defer r.Close()
+
+	files := make(map[string][]byte)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		_, p, found := strings.Cut(f.Name, "/")
+		if !found {
+			p = f.Name
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s in the flare %s: %w", f.Name, archivePath, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s in the flare %s: %w", f.Name, archivePath, err)
+		}
+		files[p] = content
+	}
+	return files, nil
+}
+
+// diffFile compares the two versions of a file, and returns false if they are identical.
+func diffFile(p string, content1, content2 []byte) (fileDiff, bool) {
+	fd := fileDiff{Path: p}
+	if string(content1) == string(content2) {
+		return fd, false
+	}
+
+	if ext := path.Ext(p); ext == ".yaml" || ext == ".yml" {
+		keys1, err1 := flattenYAML(content1)
+		keys2, err2 := flattenYAML(content2)
+		if err1 == nil && err2 == nil {
+			fd.Keys = diffKeys(keys1, keys2)
+			return fd, len(fd.Keys) > 0
+		}
+	}
+
+	fd.Unified, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:       difflib.SplitLines(string(content1)),
+		B:       difflib.SplitLines(string(content2)),
+		Context: 3,
+	})
+	return fd, true
+}
+
+// flattenYAML returns the values of a YAML document by their key, the keys of the nested maps being joined with dots.
+// The lists and the scalars are encoded as JSON.
+func flattenYAML(content []byte) (map[string]string, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]string)
+	flattenValue("", doc, keys)
+	return keys, nil
+}
+
+func flattenValue(prefix string, value interface{}, keys map[string]string) {
+	if m, ok := value.(map[string]interface{}); ok && len(m) > 0 {
+		for k, v := range m {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			flattenValue(k, v, keys)
+		}
+		return
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded = []byte(fmt.Sprint(value))
+	}
+	keys[prefix] = string(encoded)
+}
+
+func diffKeys(keys1, keys2 map[string]string) []keyChange {
+	var changes []keyChange
+	for k, v1 := range keys1 {
+		v2, ok := keys2[k]
+		if !ok {
+			changes = append(changes, keyChange{Key: k, Old: v1, Change: "removed"})
+		} else if v1 != v2 {
+			changes = append(changes, keyChange{Key: k, Old: v1, New: v2, Change: "changed"})
+		}
+	}
+	for k, v2 := range keys2 {
+		if _, ok := keys1[k]; !ok {
+			changes = append(changes, keyChange{Key: k, New: v2, Change: "added"})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func printFlareDiff(w io.Writer, diff *flareDiff, path1, path2 string) {
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", path1, path2)
+	for _, category := range diff.Categories {
+		fmt.Fprintf(w, "\n=== %s ===\n", color.BlueString(category.Name))
+		if len(category.Files) == 0 {
+			fmt.Fprintln(w, "No difference")
+			continue
+		}
+		for _, fd := range category.Files {
+			switch {
+			case fd.OnlyIn == 1:
+				fmt.Fprintf(w, "%s: only in %s\n", color.YellowString(fd.Path), path1)
+			case fd.OnlyIn == 2:
+				fmt.Fprintf(w, "%s: only in %s\n", color.YellowString(fd.Path), path2)
+			case len(fd.Keys) > 0:
+				fmt.Fprintf(w, "%s:\n", color.YellowString(fd.Path))
+				for _, k := range fd.Keys {
+					switch k.Change {
+					case "added":
+						fmt.Fprintln(w, color.GreenString("  + %s: %s", k.Key, k.New))
+					case "removed":
+						fmt.Fprintln(w, color.RedString("  - %s: %s", k.Key, k.Old))
+					default:
+						fmt.Fprintf(w, "  ~ %s: %s -> %s\n", k.Key, k.Old, k.New)
+					}
+				}
+			default:
+				fmt.Fprintf(w, "%s:\n%s", color.YellowString(fd.Path), fd.Unified)
+			}
+		}
+	}
+}
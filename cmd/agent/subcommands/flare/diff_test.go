@@ -0,0 +1,85 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package flare
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFlare(t *testing.T, name string, files map[string]string) string {
+	archivePath := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for p, content := range files {
+		fw, err := w.Create("my-host/" + p)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return archivePath
+}
+
+func TestDiffFlares(t *testing.T) {
+	flare1 := writeTestFlare(t, "flare1.zip", map[string]string{
+		"etc/datadog.yaml":             "api_key: \"********\"\nlogs_enabled: false\n",
+		"etc/conf.d/redis.d/conf.yaml": "instances: [{host: localhost}]\n",
+		"runtime_config_dump.yaml":     "logs_enabled: false\napm_config:\n  enabled: true\n  env: prod\n",
+		"config-check.log":             "=== redisdb check ===\nInstance ID: redisdb:1\n",
+		"status.log":                   "Agent (v7.70.0)\n",
+	})
+	flare2 := writeTestFlare(t, "flare2.zip", map[string]string{
+		"etc/datadog.yaml":         "api_key: \"********\"\nlogs_enabled: true\n",
+		"runtime_config_dump.yaml": "logs_enabled: true\napm_config:\n  enabled: true\n  debug: true\n",
+		"config-check.log":         "=== redisdb check ===\nInstance ID: redisdb:2\n",
+		"status.log":               "Agent (v7.70.0)\n",
+	})
+
+	diff, err := diffFlares(flare1, flare2)
+	require.NoError(t, err)
+	require.Len(t, diff.Categories, 4)
+
+	config := diff.Categories[0]
+	assert.Equal(t, "config", config.Name)
+	assert.Equal(t, []fileDiff{
+		{Path: "etc/conf.d/redis.d/conf.yaml", OnlyIn: 1},
+		{Path: "etc/datadog.yaml", Keys: []keyChange{{Key: "logs_enabled", Old: "false", New: "true", Change: "changed"}}},
+	}, config.Files)
+
+	runtimeSettings := diff.Categories[1]
+	assert.Equal(t, "runtime settings", runtimeSettings.Name)
+	require.Len(t, runtimeSettings.Files, 1)
+	assert.Equal(t, []keyChange{
+		{Key: "apm_config.debug", New: "true", Change: "added"},
+		{Key: "apm_config.env", Old: "\"prod\"", Change: "removed"},
+		{Key: "logs_enabled", Old: "false", New: "true", Change: "changed"},
+	}, runtimeSettings.Files[0].Keys)
+
+	checks := diff.Categories[2]
+	assert.Equal(t, "check schedules", checks.Name)
+	require.Len(t, checks.Files, 1)
+	assert.Contains(t, checks.Files[0].Unified, "-Instance ID: redisdb:1")
+	assert.Contains(t, checks.Files[0].Unified, "+Instance ID: redisdb:2")
+
+	status := diff.Categories[3]
+	assert.Equal(t, "status", status.Name)
+	assert.Empty(t, status.Files)
+}
+
+func TestDiffFlaresInvalidArchive(t *testing.T) {
+	flare := writeTestFlare(t, "flare.zip", map[string]string{})
+	_, err := diffFlares(flare, filepath.Join(t.TempDir(), "missing.zip"))
+	assert.Error(t, err)
+}
@@ -8,9 +8,13 @@ package remoteconfig
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/fx"
@@ -22,12 +26,14 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	ipc "github.com/DataDog/datadog-agent/comp/core/ipc/def"
 	ipcfx "github.com/DataDog/datadog-agent/comp/core/ipc/fx"
+	ipchttp "github.com/DataDog/datadog-agent/comp/core/ipc/httphelpers"
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
 	secretsnoopfx "github.com/DataDog/datadog-agent/comp/core/secrets/fx-noop"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	configUtils "github.com/DataDog/datadog-agent/pkg/config/utils"
 	"github.com/DataDog/datadog-agent/pkg/flare"
 	pbgo "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+	rcstate "github.com/DataDog/datadog-agent/pkg/remoteconfig/state"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 	agentgrpc "github.com/DataDog/datadog-agent/pkg/util/grpc"
 )
@@ -80,6 +86,24 @@ func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 			},
 			Hidden: true,
 		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show the remote configuration delivered to this host",
+			Long: `Show the remote configuration client state: the endpoint the agent connects to, the
+products subscribed by each client, the configurations they applied and the last errors.`,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return fxutil.OneShot(status,
+					fx.Supply(cliParams),
+					fx.Supply(core.BundleParams{
+						ConfigParams: config.NewAgentParams(globalParams.ConfFilePath, config.WithExtraConfFiles(globalParams.ExtraConfFilePath), config.WithFleetPoliciesDirPath(globalParams.FleetPoliciesDirPath)),
+						LogParams:    log.ForOneShot(command.LoggerName, "OFF", false),
+					}),
+					core.Bundle(),
+					secretsnoopfx.Module(),
+					ipcfx.ModuleReadOnly(),
+				)
+			},
+		},
 	)
 
 	return []*cobra.Command{remoteConfigCmd}
@@ -158,3 +182,146 @@ func state(_ *cliParams, config config.Component, ipc ipc.Component) error {
 
 	return nil
 }
+
+// rcStatus holds the remote configuration section of the agent status
+type rcStatus struct {
+	RemoteConfiguration struct {
+		OrgEnabled     string `json:"orgEnabled"`
+		APIKeyScoped   string `json:"apiKeyScoped"`
+		LastError      string `json:"lastError"`
+		DisabledReason string `json:"disabledReason"`
+	} `json:"remoteConfiguration"`
+	RemoteConfigStartup struct {
+		StartupFailureReason string `json:"startupFailureReason"`
+		Endpoint             string `json:"endpoint"`
+	} `json:"remoteConfigStartup"`
+}
+
+func status(_ *cliParams, config config.Component, ipc ipc.Component) error {
+	if !configUtils.IsRemoteConfigEnabled(config) {
+		return errors.New("remote configuration is not enabled")
+	}
+
+	endpoint, err := ipc.GetClient().NewIPCEndpoint("/agent/remote configuration/status")
+	if err != nil {
+		return err
+	}
+	res, err := endpoint.DoGet(ipchttp.WithValues(url.Values{"format": []string{"json"}}))
+	if err != nil {
+		return fmt.Errorf("couldn't get the remote configuration status: %w", err)
+	}
+	var rcs rcStatus
+	if err := json.Unmarshal(res, &rcs); err != nil {
+		return fmt.Errorf("couldn't decode the remote configuration status: %w", err)
+	}
+
+	ctx, closeFn := context.WithCancel(context.Background())
+	defer closeFn()
+	md := metadata.MD{
+		"authorization": []string{fmt.Sprintf("Bearer %s", ipc.GetAuthToken())},
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	ipcAddress, err := pkgconfigsetup.GetIPCAddress(pkgconfigsetup.Datadog())
+	if err != nil {
+		return err
+	}
+
+	cli, err := agentgrpc.GetDDAgentSecureClient(ctx, ipcAddress, pkgconfigsetup.GetIPCPort(), ipc.GetTLSClientConfig())
+	if err != nil {
+		return err
+	}
+
+	s, err := cli.GetConfigState(ctx, new(emptypb.Empty))
+	if err != nil {
+		return fmt.Errorf("couldn't get the repositories state: %w", err)
+	}
+
+	printStatus(os.Stdout, &rcs, s)
+	return nil
+}
+
+func printStatus(w io.Writer, rcs *rcStatus, state *pbgo.GetStateConfigResponse) {
+	fmt.Fprintln(w, "=== Remote configuration status ===")
+	fmt.Fprintln(w)
+	if reason := rcs.RemoteConfigStartup.StartupFailureReason; reason != "" {
+		fmt.Fprintf(w, "Startup failure reason: %s\n", reason)
+		return
+	}
+	if reason := rcs.RemoteConfiguration.DisabledReason; reason != "" {
+		fmt.Fprintf(w, "Remote configuration is disabled because %s\n", reason)
+		return
+	}
+	fmt.Fprintf(w, "Endpoint: %s\n", valueOrNone(rcs.RemoteConfigStartup.Endpoint))
+	fmt.Fprintf(w, "Organization enabled: %t\n", rcs.RemoteConfiguration.OrgEnabled == "true")
+	fmt.Fprintf(w, "API key authorized: %t\n", rcs.RemoteConfiguration.APIKeyScoped == "true")
+	fmt.Fprintf(w, "Last error: %s\n", valueOrNone(rcs.RemoteConfiguration.LastError))
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "=== Active clients ===")
+	if len(state.ActiveClients) == 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "No active clients")
+		return
+	}
+
+	for _, client := range state.ActiveClients {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "- Client %s (%s)\n", client.Id, clientDescription(client))
+		fmt.Fprintf(w, "    Products: %s\n", valueOrNone(strings.Join(client.Products, ", ")))
+
+		clientState := client.GetState()
+		fmt.Fprintf(w, "    Root version: %d, Targets version: %d\n", clientState.GetRootVersion(), clientState.GetTargetsVersion())
+		if clientState.GetHasError() {
+			fmt.Fprintf(w, "    Last error: %s\n", clientState.GetError())
+		}
+
+		if len(clientState.GetConfigStates()) == 0 {
+			fmt.Fprintln(w, "    Applied configs: None")
+			continue
+		}
+		fmt.Fprintln(w, "    Applied configs:")
+		for _, configState := range clientState.GetConfigStates() {
+			fmt.Fprintf(w, "      - %s/%s - Version: %d - State: %s", configState.Product, configState.Id, configState.Version, applyStateString(configState.ApplyState))
+			if configState.ApplyError != "" {
+				fmt.Fprintf(w, " - Error: %s", configState.ApplyError)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// clientDescription describes the kind of a remote configuration client
+func clientDescription(client *pbgo.Client) string {
+	switch {
+	case client.IsAgent:
+		return fmt.Sprintf("agent %s %s", client.GetClientAgent().GetName(), client.GetClientAgent().GetVersion())
+	case client.IsTracer:
+		tracer := client.GetClientTracer()
+		return fmt.Sprintf("tracer %s %s, service: %s, env: %s", tracer.GetLanguage(), tracer.GetTracerVersion(), tracer.GetService(), tracer.GetEnv())
+	case client.IsUpdater:
+		return "updater"
+	default:
+		return "unknown"
+	}
+}
+
+func applyStateString(state uint64) string {
+	switch rcstate.ApplyState(state) {
+	case rcstate.ApplyStateUnacknowledged:
+		return "unacknowledged"
+	case rcstate.ApplyStateAcknowledged:
+		return "acknowledged"
+	case rcstate.ApplyStateError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
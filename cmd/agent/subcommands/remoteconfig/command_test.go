@@ -6,10 +6,14 @@
 package remoteconfig
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/DataDog/datadog-agent/cmd/agent/command"
 	"github.com/DataDog/datadog-agent/comp/core"
+	pbgo "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 )
 
@@ -28,3 +32,62 @@ func TestResetCommand(t *testing.T) {
 		reset,
 		func(_ *cliParams, _ core.BundleParams) {})
 }
+
+func TestStatusCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"remote-config", "status"},
+		status,
+		func(_ *cliParams, _ core.BundleParams) {})
+}
+
+func TestPrintStatus(t *testing.T) {
+	var rcs rcStatus
+	rcs.RemoteConfigStartup.Endpoint = "https://config.datadoghq.com"
+	rcs.RemoteConfiguration.OrgEnabled = "true"
+	rcs.RemoteConfiguration.APIKeyScoped = "true"
+	rcs.RemoteConfiguration.LastError = "api: 503"
+
+	state := &pbgo.GetStateConfigResponse{
+		ActiveClients: []*pbgo.Client{
+			{
+				Id:          "client-1",
+				Products:    []string{"AGENT_CONFIG", "AGENT_TASK"},
+				IsAgent:     true,
+				ClientAgent: &pbgo.ClientAgent{Name: "core-agent", Version: "7.70.0"},
+				State: &pbgo.ClientState{
+					RootVersion:    1,
+					TargetsVersion: 42,
+					ConfigStates: []*pbgo.ConfigState{
+						{Id: "configuration_order", Version: 3, Product: "AGENT_CONFIG", ApplyState: 2},
+						{Id: "flare", Version: 1, Product: "AGENT_TASK", ApplyState: 3, ApplyError: "invalid task"},
+					},
+				},
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	printStatus(&b, &rcs, state)
+
+	out := b.String()
+	assert.Contains(t, out, "Endpoint: https://config.datadoghq.com")
+	assert.Contains(t, out, "Organization enabled: true")
+	assert.Contains(t, out, "Last error: api: 503")
+	assert.Contains(t, out, "- Client client-1 (agent core-agent 7.70.0)")
+	assert.Contains(t, out, "Products: AGENT_CONFIG, AGENT_TASK")
+	assert.Contains(t, out, "Root version: 1, Targets version: 42")
+	assert.Contains(t, out, "- AGENT_CONFIG/configuration_order - Version: 3 - State: acknowledged\n")
+	assert.Contains(t, out, "- AGENT_TASK/flare - Version: 1 - State: error - Error: invalid task\n")
+}
+
+func TestPrintStatusDisabled(t *testing.T) {
+	var rcs rcStatus
+	rcs.RemoteConfiguration.DisabledReason = "it is explicitly disabled in the agent configuration."
+
+	var b bytes.Buffer
+	printStatus(&b, &rcs, &pbgo.GetStateConfigResponse{})
+
+	assert.Contains(t, b.String(), "Remote configuration is disabled because it is explicitly disabled")
+	assert.NotContains(t, b.String(), "Active clients")
+}
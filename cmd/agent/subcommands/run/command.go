@@ -331,6 +331,12 @@ func run(log log.Component,
 		}
 	}()
 
+	// Reload the reloadable settings on SIGHUP and when the configuration
+	// file changes
+	reloadCtx, cancelReload := context.WithCancel(context.Background())
+	defer cancelReload()
+	go reloadConfig(reloadCtx, cfg, log)
+
 	if err := startAgent(
 		log,
 		flare,
@@ -0,0 +1,107 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package run
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+)
+
+// configReloadDebounce is the delay without any change on disk after which the
+// configuration file is reloaded, so that an editor writing the file in
+// several steps results in a single reload.
+var configReloadDebounce = 2 * time.Second
+
+// reloadConfig reloads the reloadable settings of the configuration file when
+// the agent receives SIGHUP and, with config_reload_on_change, when the file
+// changes on disk, until ctx is done.
+func reloadConfig(ctx context.Context, cfg config.Component, log log.Component) {
+	sighupCh := make(chan os.Signal, 1)
+	signal.Notify(sighupCh, syscall.SIGHUP)
+	defer signal.Stop(sighupCh)
+
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if path := cfg.ConfigFileUsed(); path != "" && cfg.GetBool("config_reload_on_change") {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			log.Warnf("Unable to watch the configuration file %s: %v", path, err)
+		} else {
+			defer func() {
+				if err := watcher.Close(); err != nil {
+					log.Debugf("Error closing the configuration file watcher: %v", err)
+				}
+			}()
+			// Editors and configuration management tools often replace the
+			// file instead of writing it, watch its directory.
+			if err := watcher.Add(filepath.Dir(path)); err != nil {
+				log.Warnf("Unable to watch the configuration file %s: %v", path, err)
+			}
+			events = watcher.Events
+			errs = watcher.Errors
+		}
+	}
+
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighupCh:
+			log.Info("Received SIGHUP, reloading the configuration file")
+			reloadConfigFile(cfg, log)
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(cfg.ConfigFileUsed()) || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if debounce == nil {
+				debounce = time.NewTimer(configReloadDebounce)
+			} else {
+				debounce.Reset(configReloadDebounce)
+			}
+			debounceC = debounce.C
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			log.Warnf("Error watching the configuration file: %v", err)
+		case <-debounceC:
+			debounceC = nil
+			log.Info("Configuration file changed, reloading it")
+			reloadConfigFile(cfg, log)
+		}
+	}
+}
+
+func reloadConfigFile(cfg config.Component, log log.Component) {
+	changed, err := pkgconfigsetup.ReloadConfigFile(cfg)
+	if err != nil {
+		log.Errorf("Unable to reload the configuration file: %v", err)
+		return
+	}
+	if len(changed) == 0 {
+		log.Info("No reloadable setting changed in the configuration file")
+		return
+	}
+	log.Infof("Reloaded settings from the configuration file: %v", changed)
+}
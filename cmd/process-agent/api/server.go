@@ -46,6 +46,8 @@ func SetupAPIServerHandlers(deps APIServerDeps, r *mux.Router) {
 	r.HandleFunc("/config/without-defaults", deps.Settings.GetFullConfigWithoutDefaults("process_config")).Methods("GET")
 	r.HandleFunc("/config/all", deps.Settings.GetFullConfig("")).Methods("GET") // Get all fields from process-agent Config object
 	r.HandleFunc("/config/list-runtime", deps.Settings.ListConfigurable).Methods("GET")
+	r.HandleFunc("/config/provenance", deps.Settings.GetFullConfigWithProvenance()).Methods("GET")
+	r.HandleFunc("/config/sources/{setting}", deps.Settings.GetValueSources).Methods("GET")
 	r.HandleFunc("/config/{setting}", deps.Settings.GetValue).Methods("GET")
 	r.HandleFunc("/config/{setting}", deps.Settings.SetValue).Methods("POST")
 
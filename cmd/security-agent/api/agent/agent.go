@@ -59,6 +59,8 @@ func (a *Agent) SetupHandlers(r *mux.Router) {
 	// FIXME: this returns the entire datadog.yaml and not just security-agent.yaml config
 	r.HandleFunc("/config/by-source", a.settings.GetFullConfigBySource()).Methods("GET")
 	r.HandleFunc("/config/list-runtime", a.settings.ListConfigurable).Methods("GET")
+	r.HandleFunc("/config/provenance", a.settings.GetFullConfigWithProvenance()).Methods("GET")
+	r.HandleFunc("/config/sources/{setting}", a.settings.GetValueSources).Methods("GET")
 	r.HandleFunc("/config/{setting}", a.settings.GetValue).Methods("GET")
 	r.HandleFunc("/config/{setting}", a.settings.SetValue).Methods("POST")
 	r.HandleFunc("/workload-list", func(w http.ResponseWriter, r *http.Request) {
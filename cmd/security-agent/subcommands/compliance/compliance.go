@@ -7,6 +7,7 @@ package compliance
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"net"
 	"net/http"
@@ -80,6 +81,16 @@ func StartCompliance(log log.Component,
 		enabledConfigurationsExporters = append(enabledConfigurationsExporters, compliance.DBExporter)
 	}
 
+	var customBenchmarksPublicKeys []ed25519.PublicKey
+	customBenchmarksEnabled := config.GetBool("compliance_config.custom_benchmarks.enabled")
+	if customBenchmarksEnabled {
+		customBenchmarksPublicKeys, err = compliance.ParseCustomBenchmarksPublicKeys(config.GetStringSlice("compliance_config.custom_benchmarks.public_keys"))
+		if err != nil {
+			log.Errorf("Custom benchmarks disabled: %v", err)
+			customBenchmarksEnabled = false
+		}
+	}
+
 	reporter := compliance.NewLogReporter(hostname, "compliance-agent", "compliance", endpoints, context, compression)
 	telemetrySender := telemetry.NewSimpleTelemetrySenderFromStatsd(statsdClient)
 
@@ -90,6 +101,8 @@ func StartCompliance(log log.Component,
 		CheckInterval:                 checkInterval,
 		EnabledConfigurationExporters: enabledConfigurationsExporters,
 		SysProbeClient:                sysProbeClient,
+		CustomBenchmarksEnabled:       customBenchmarksEnabled,
+		CustomBenchmarksPublicKeys:    customBenchmarksPublicKeys,
 	})
 	err = agent.Start()
 	if err != nil {
@@ -6,11 +6,15 @@
 package api
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/gorilla/mux"
 
 	"github.com/DataDog/datadog-agent/cmd/system-probe/modules"
 	"github.com/DataDog/datadog-agent/comp/core/settings"
 	"github.com/DataDog/datadog-agent/pkg/system-probe/config"
+	sysconfigtypes "github.com/DataDog/datadog-agent/pkg/system-probe/config/types"
 )
 
 // setupConfigHandlers adds the specific handlers for /config endpoints
@@ -19,6 +23,9 @@ func setupConfigHandlers(r *mux.Router, settings settings.Component) {
 	r.HandleFunc("/config/without-defaults", settings.GetFullConfigWithoutDefaults(getAggregatedNamespaces()...)).Methods("GET")
 	r.HandleFunc("/config/by-source", settings.GetFullConfigBySource()).Methods("GET")
 	r.HandleFunc("/config/list-runtime", settings.ListConfigurable).Methods("GET")
+	r.HandleFunc("/config/module/{module-name}", func(w http.ResponseWriter, r *http.Request) { moduleConfigHandler(w, r, settings) }).Methods("GET")
+	r.HandleFunc("/config/provenance", settings.GetFullConfigWithProvenance()).Methods("GET")
+	r.HandleFunc("/config/sources/{setting}", settings.GetValueSources).Methods("GET")
 	r.HandleFunc("/config/{setting}", settings.GetValue).Methods("GET")
 	r.HandleFunc("/config/{setting}", settings.SetValue).Methods("POST")
 }
@@ -32,3 +39,22 @@ func getAggregatedNamespaces() []string {
 	}
 	return namespaces
 }
+
+// moduleConfigHandler returns the settings of the config namespaces of a module
+func moduleConfigHandler(w http.ResponseWriter, r *http.Request, settings settings.Component) {
+	moduleName := sysconfigtypes.ModuleName(mux.Vars(r)["module-name"])
+
+	for _, m := range modules.All() {
+		if m.Name != moduleName {
+			continue
+		}
+		if len(m.ConfigNamespaces) == 0 {
+			http.Error(w, fmt.Sprintf("module %s has no config namespace", moduleName), http.StatusNotFound)
+			return
+		}
+		settings.GetFullConfig(m.ConfigNamespaces...)(w, r)
+		return
+	}
+
+	http.Error(w, "invalid module", http.StatusBadRequest)
+}
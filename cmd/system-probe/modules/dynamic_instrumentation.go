@@ -28,8 +28,8 @@ var godiProcessEventConsumer *consumers.ProcessConsumer
 // running Go services without restarts.
 var DynamicInstrumentation = &module.Factory{
 	Name:             config.DynamicInstrumentationModule,
-	ConfigNamespaces: []string{},
-	Fn: func(agentConfiguration *sysconfigtypes.Config, _ module.FactoryDependencies) (module.Module, error) {
+	ConfigNamespaces: []string{"dynamic_instrumentation"},
+	Fn: func(agentConfiguration *sysconfigtypes.Config, deps module.FactoryDependencies) (module.Module, error) {
 		if godiProcessEventConsumer == nil {
 			return nil, errors.New("process event consumer not initialized")
 		}
@@ -37,6 +37,7 @@ var DynamicInstrumentation = &module.Factory{
 		if err != nil {
 			return nil, fmt.Errorf("invalid dynamic instrumentation module configuration: %w", err)
 		}
+		config.Statsd = deps.Statsd
 		m, err := dimod.NewModule(config, godiProcessEventConsumer)
 		if err != nil {
 			if errors.Is(err, ebpf.ErrNotImplemented) {
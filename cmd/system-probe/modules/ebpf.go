@@ -10,10 +10,12 @@ package modules
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/ebpf/probe/ebpfcheck"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/ebpf/probe/ebpfcheck/model"
 	"github.com/DataDog/datadog-agent/pkg/ebpf"
 	"github.com/DataDog/datadog-agent/pkg/system-probe/api/module"
 	"github.com/DataDog/datadog-agent/pkg/system-probe/config"
@@ -48,19 +50,31 @@ var _ module.Module = &ebpfModule{}
 type ebpfModule struct {
 	*ebpfcheck.Probe
 	lastCheck atomic.Int64
+	// probeMu serializes the reads of the probe between the endpoints
+	probeMu sync.Mutex
 }
 
 func (o *ebpfModule) Register(httpMux *module.Router) error {
 	// Limit concurrency to one as the probe check is not thread safe (mainly in the entry count buffers)
 	httpMux.HandleFunc("/check", utils.WithConcurrencyLimit(1, func(w http.ResponseWriter, _ *http.Request) {
 		o.lastCheck.Store(time.Now().Unix())
-		stats := o.Probe.GetAndFlush()
-		utils.WriteAsJSON(w, stats, utils.CompactOutput)
+		utils.WriteAsJSON(w, o.getStats(), utils.CompactOutput)
+	}))
+
+	// /debug/stats returns the same stats as /check, for the flare, without being reported as a check run
+	httpMux.HandleFunc("/debug/stats", utils.WithConcurrencyLimit(1, func(w http.ResponseWriter, req *http.Request) {
+		utils.WriteAsJSON(w, o.getStats(), utils.GetPrettyPrintFromQueryParams(req))
 	}))
 
 	return nil
 }
 
+func (o *ebpfModule) getStats() model.EBPFStats {
+	o.probeMu.Lock()
+	defer o.probeMu.Unlock()
+	return o.Probe.GetAndFlush()
+}
+
 func (o *ebpfModule) GetStats() map[string]interface{} {
 	return map[string]interface{}{
 		"last_check": o.lastCheck.Load(),
@@ -0,0 +1,18 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/system-probe/api/module"
+)
+
+func TestConfigNamespacesAreIsolated(t *testing.T) {
+	assert.NoError(t, module.ValidateConfigNamespaces(All()))
+}
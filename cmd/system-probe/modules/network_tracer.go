@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -107,6 +108,21 @@ func (nt *networkTracer) Register(httpMux *module.Router) error {
 		io.WriteString(w, id)
 	}))
 
+	httpMux.HandleFunc("/udp_source_pids", utils.WithConcurrencyLimit(utils.DefaultMaxConcurrentRequests, func(w http.ResponseWriter, req *http.Request) {
+		port, err := strconv.ParseUint(req.URL.Query().Get("port"), 10, 16)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		pids, err := nt.tracer.GetUDPSourcePIDs(uint16(port))
+		if err != nil {
+			log.Errorf("unable to retrieve the pids of the udp sources: %s", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		utils.WriteAsJSON(w, pids, utils.CompactOutput)
+	}))
+
 	httpMux.HandleFunc("/register", utils.WithConcurrencyLimit(utils.DefaultMaxConcurrentRequests, func(w http.ResponseWriter, req *http.Request) {
 		id := utils.GetClientID(req)
 		err := nt.tracer.RegisterClient(id)
@@ -9,6 +9,7 @@
 package runtime
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -297,21 +298,21 @@ func diffCommands(globalParams *command.GlobalParams) []*cobra.Command {
 		&cliParams.file,
 		"origin",
 		"",
-		"path to the first activity dump file",
+		"path to the first activity dump or security profile file",
 	)
 
 	activityDumpDiffCmd.Flags().StringVar(
 		&cliParams.file2,
 		"target",
 		"",
-		"path to the second activity dump file",
+		"path to the second activity dump or security profile file",
 	)
 
 	activityDumpDiffCmd.Flags().StringVar(
 		&cliParams.format,
 		"format",
 		"json",
-		"output formeat",
+		"output format. Available options are json, protobuf, dot and drift.",
 	)
 
 	return []*cobra.Command{activityDumpDiffCmd}
@@ -410,6 +411,16 @@ func diffActivityDump(_ log.Component, _ config.Component, _ secrets.Component,
 		return err
 	}
 
+	if args.format == "drift" {
+		drift := activity_tree.ComputeDrift(p.ActivityTree, p2.ActivityTree)
+		buffer, err := json.MarshalIndent(drift, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buffer))
+		return nil
+	}
+
 	states := make(map[string]bool)
 	diff := computeActivityDumpDiff(p, p2, states)
 
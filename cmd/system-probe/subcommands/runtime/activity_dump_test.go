@@ -46,6 +46,14 @@ func TestDiffActivityDumpCommand(t *testing.T) {
 		func() {})
 }
 
+func TestDiffActivityDumpDriftCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"runtime", "activity-dump", "diff", "--origin", "dump1", "--target", "dump2", "--format", "drift"},
+		diffActivityDump,
+		func() {})
+}
+
 func TestDumpActivityDumpCommand(t *testing.T) {
 	fxutil.TestOneShotSubcommand(t,
 		Commands(&command.GlobalParams{}),
@@ -192,7 +192,8 @@ func newAutoConfig(deps dependencies) autodiscovery.Component {
 
 // createNewAutoConfig creates an AutoConfig instance (without starting).
 func createNewAutoConfig(schedulerController *scheduler.Controller, secretResolver secrets.Component, wmeta option.Option[workloadmeta.Component], taggerComp tagger.Component, logs logComp.Component, telemetryComp telemetry.Component, filterStore workloadfilter.Component) *AutoConfig {
-	cfgMgr := newReconcilingConfigManager(secretResolver)
+	telemetryStore := acTelemetry.NewStore(telemetryComp)
+	cfgMgr := newReconcilingConfigManager(secretResolver, telemetryStore)
 	ac := &AutoConfig{
 		configPollers:            make([]*configPoller, 0, 9),
 		listenerCandidates:       make(map[string]*listenerCandidate),
@@ -210,7 +211,7 @@ func createNewAutoConfig(schedulerController *scheduler.Controller, secretResolv
 		taggerComp:               taggerComp,
 		logs:                     logs,
 		filterStore:              filterStore,
-		telemetryStore:           acTelemetry.NewStore(telemetryComp),
+		telemetryStore:           telemetryStore,
 	}
 	return ac
 }
@@ -291,6 +292,7 @@ func (ac *AutoConfig) buildConfigCheckResponse(scrub bool) integration.ConfigChe
 
 	response.Configs = configResponses
 	response.ResolveWarnings = GetResolveWarnings()
+	response.ResolveErrors = GetResolveErrors()
 	response.ConfigErrors = GetConfigErrors()
 
 	if scrub {
@@ -680,6 +682,13 @@ func (ac *AutoConfig) GetAutodiscoveryErrors() map[string]map[string]providerTyp
 	return errors
 }
 
+// GetResolveErrors returns the template resolution failures counted since the
+// agent started, by config provider, kind of service and reason, with the last
+// error of each.
+func (ac *AutoConfig) GetResolveErrors() []integration.ResolveErrorStats {
+	return GetResolveErrors()
+}
+
 // applyChanges applies a configChanges object. This always unschedules first.
 func (ac *AutoConfig) applyChanges(changes integration.ConfigChanges) {
 	telemetryStorePresent := ac.telemetryStore != nil
@@ -130,6 +130,12 @@ func (cp *configPoller) poll(provider types.CollectingConfigProvider, ac *AutoCo
 	ticker := time.NewTicker(cp.pollInterval)
 	healthHandle := health.RegisterLiveness(fmt.Sprintf("ad-config-provider-%s", cp.provider.String()))
 
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	var changesCh <-chan struct{}
+	if watcher, ok := provider.(types.WatchingConfigProvider); ok {
+		changesCh = watcher.Watch(watchCtx)
+	}
+
 	cp.isRunning = true
 
 	for {
@@ -144,8 +150,12 @@ func (cp *configPoller) poll(provider types.CollectingConfigProvider, ac *AutoCo
 			}
 
 			cancel()
+			stopWatch()
 			ticker.Stop()
 			return
+		case <-changesCh:
+			log.Debugf("Configurations of %v configuration provider changed", cp.provider)
+			cp.collectOnce(ctx, provider, ac)
 		case <-ticker.C:
 			upToDate, err := provider.IsUpToDate(ctx)
 			if err != nil {
@@ -7,14 +7,18 @@ package autodiscoveryimpl
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/configresolver"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/listeners"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
+	acTelemetry "github.com/DataDog/datadog-agent/comp/core/autodiscovery/telemetry"
 	secrets "github.com/DataDog/datadog-agent/comp/core/secrets/def"
 	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
@@ -103,13 +107,18 @@ type reconcilingConfigManager struct {
 	// methods correspond exactly to changes in this map.
 	scheduledConfigs map[string]integration.Config
 
+	// maxConcurrentResolutions is the maximum number of templates resolved
+	// at the same time when a config or a service matches several others.
+	maxConcurrentResolutions int
+
 	secretResolver secrets.Component
+	telemetryStore *acTelemetry.Store
 }
 
 var _ configManager = &reconcilingConfigManager{}
 
 // newReconcilingConfigManager creates a new, empty reconcilingConfigManager.
-func newReconcilingConfigManager(secretResolver secrets.Component) configManager {
+func newReconcilingConfigManager(secretResolver secrets.Component, telemetryStore *acTelemetry.Store) configManager {
 	return &reconcilingConfigManager{
 		activeConfigs:      map[string]integration.Config{},
 		activeServices:     map[string]serviceAndADIDs{},
@@ -117,7 +126,12 @@ func newReconcilingConfigManager(secretResolver secrets.Component) configManager
 		servicesByADID:     newMultimap(),
 		serviceResolutions: map[string]map[string]string{},
 		scheduledConfigs:   map[string]integration.Config{},
-		secretResolver:     secretResolver,
+		// resolutions call the tagger, the cloud provider metadata and
+		// the secrets backend, bound the load a burst of services puts on
+		// them
+		maxConcurrentResolutions: max(1, pkgconfigsetup.Datadog().GetInt("ad_max_concurrent_resolutions")),
+		secretResolver:           secretResolver,
+		telemetryStore:           telemetryStore,
 	}
 }
 
@@ -212,9 +226,7 @@ func (cm *reconcilingConfigManager) processNewConfig(config integration.Config)
 			}
 		}
 		//  3. update serviceResolutions, generating changes
-		for svcID := range matchingServices {
-			changes.Merge(cm.reconcileService(svcID))
-		}
+		changes.Merge(cm.reconcileServices(slices.Collect(maps.Keys(matchingServices))))
 	} else {
 		// Secrets always need to be resolved (done in reconcileService if template)
 		decryptedConfig, err := decryptConfig(config, cm.secretResolver)
@@ -270,9 +282,7 @@ func (cm *reconcilingConfigManager) processDelConfigs(configs []integration.Conf
 			}
 
 			//  3. update serviceResolutions, generating changes
-			for svcID := range matchingServices {
-				changes.Merge(cm.reconcileService(svcID))
-			}
+			changes.Merge(cm.reconcileServices(slices.Collect(maps.Keys(matchingServices))))
 		} else {
 			// Secrets need to be resolved before being unscheduled as otherwise
 			// the computed hashes can be different from the ones computed at schedule time.
@@ -327,68 +337,128 @@ func (cm *reconcilingConfigManager) getActiveServices() map[string]listeners.Ser
 //
 // This method must be called with cm.m locked.
 func (cm *reconcilingConfigManager) reconcileService(svcID string) integration.ConfigChanges {
+	return cm.reconcileServices([]string{svcID})
+}
+
+// pendingResolution is a template to resolve for a service.
+type pendingResolution struct {
+	svcID          string
+	templateDigest string
+	tpl            integration.Config
+	svc            listeners.Service
+
+	resolved integration.Config
+	ok       bool
+}
+
+// reconcileServices reconciles each of the given services, like
+// reconcileService, resolving the new templates of all of them concurrently.
+//
+// This method must be called with cm.m locked.
+func (cm *reconcilingConfigManager) reconcileServices(svcIDs []string) integration.ConfigChanges {
 	var changes integration.ConfigChanges
+	var pending []*pendingResolution
+
+	for _, svcID := range svcIDs {
+		// note that this method can be called in a case where svcID is not in the
+		// activeServices: this occurs when the service is removed.
+		serviceAndADIDs := cm.activeServices[svcID]
+		adIDs := serviceAndADIDs.adIDs // nil slice if service is not defined
+		svc := serviceAndADIDs.svc     // nil if the service is not defined
+
+		// get the existing resolutions for this service
+		existingResolutions, found := cm.serviceResolutions[svcID]
+		if !found {
+			existingResolutions = map[string]string{}
+		}
 
-	// note that this method can be called in a case where svcID is not in the
-	// activeServices: this occurs when the service is removed.
-	serviceAndADIDs := cm.activeServices[svcID]
-	adIDs := serviceAndADIDs.adIDs // nil slice if service is not defined
-	svc := serviceAndADIDs.svc     // nil if the service is not defined
+		// determine the matching templates by template digest.  If the service
+		// has been removed, then this slice is empty.
+		expectedResolutions := map[string]integration.Config{}
+		for _, adID := range adIDs {
+			digests := cm.templatesByADID.get(adID)
+			for _, digest := range digests {
+				tpl := cm.activeConfigs[digest]
+				expectedResolutions[digest] = tpl
+			}
+		}
 
-	// get the existing resolutions for this service
-	existingResolutions, found := cm.serviceResolutions[svcID]
-	if !found {
-		existingResolutions = map[string]string{}
-	}
+		// allow the service to filter those templates, unless we are removing
+		// the service, in which case no resolutions are expected.
+		if svc != nil {
+			// Warning: this must be called with the configs stored in cm.activeConfigs
+			// which contain the compiled matchingProgram for the config template.
+			svc.FilterTemplates(expectedResolutions)
+		}
 
-	// determine the matching templates by template digest.  If the service
-	// has been removed, then this slice is empty.
-	expectedResolutions := map[string]integration.Config{}
-	for _, adID := range adIDs {
-		digests := cm.templatesByADID.get(adID)
-		for _, digest := range digests {
-			tpl := cm.activeConfigs[digest]
-			expectedResolutions[digest] = tpl
+		// compare existing to expected, generating changes and modifying
+		// existingResolutions in-place
+		for templateDigest, resolvedDigest := range existingResolutions {
+			if _, found = expectedResolutions[templateDigest]; !found {
+				changes.UnscheduleConfig(cm.scheduledConfigs[resolvedDigest])
+				delete(existingResolutions, templateDigest)
+			}
 		}
-	}
 
-	// allow the service to filter those templates, unless we are removing
-	// the service, in which case no resolutions are expected.
-	if svc != nil {
-		// Warning: this must be called with the configs stored in cm.activeConfigs
-		// which contain the compiled matchingProgram for the config template.
-		svc.FilterTemplates(expectedResolutions)
+		for digest, config := range expectedResolutions {
+			if _, found := existingResolutions[digest]; !found {
+				// at this point, there was at least one expected resolution, so
+				// svc must not be nil.
+				pending = append(pending, &pendingResolution{
+					svcID:          svcID,
+					templateDigest: digest,
+					tpl:            config,
+					svc:            svc,
+				})
+			}
+		}
+
+		cm.serviceResolutions[svcID] = existingResolutions
 	}
 
-	// compare existing to expected, generating changes and modifying
-	// existingResolutions in-place
-	for templateDigest, resolvedDigest := range existingResolutions {
-		if _, found = expectedResolutions[templateDigest]; !found {
-			changes.UnscheduleConfig(cm.scheduledConfigs[resolvedDigest])
-			delete(existingResolutions, templateDigest)
+	cm.resolvePending(pending)
+
+	for _, p := range pending {
+		if !p.ok {
+			continue
 		}
+		changes.ScheduleConfig(p.resolved)
+		cm.serviceResolutions[p.svcID][p.templateDigest] = p.resolved.Digest()
 	}
 
-	for digest, config := range expectedResolutions {
-		if _, found := existingResolutions[digest]; !found {
-			// at this point, there was at least one expected resolution, so
-			// svc must not be nil.
-			resolved, ok := cm.resolveTemplateForService(config, svc)
-			if !ok {
-				continue
-			}
-			changes.ScheduleConfig(resolved)
-			existingResolutions[digest] = resolved.Digest()
+	for _, svcID := range svcIDs {
+		if len(cm.serviceResolutions[svcID]) == 0 {
+			delete(cm.serviceResolutions, svcID)
 		}
 	}
 
-	if len(existingResolutions) == 0 {
-		delete(cm.serviceResolutions, svcID)
-	} else {
-		cm.serviceResolutions[svcID] = existingResolutions
+	return changes
+}
+
+// resolvePending resolves the pending templates, at most
+// cm.maxConcurrentResolutions at a time.
+func (cm *reconcilingConfigManager) resolvePending(pending []*pendingResolution) {
+	if cm.maxConcurrentResolutions <= 1 || len(pending) <= 1 {
+		for _, p := range pending {
+			p.resolved, p.ok = cm.resolveTemplateForService(p.tpl, p.svc)
+		}
+		return
 	}
 
-	return changes
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, cm.maxConcurrentResolutions)
+	for _, p := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			p.resolved, p.ok = cm.resolveTemplateForService(p.tpl, p.svc)
+		}()
+	}
+	wg.Wait()
 }
 
 // resolveTemplateForService resolves a template config for the given service,
@@ -400,18 +470,30 @@ func (cm *reconcilingConfigManager) resolveTemplateForService(tpl integration.Co
 		msg := fmt.Sprintf("error resolving template %s for service %s: %v", tpl.Name, svc.GetServiceID(), err)
 		log.Debug(msg)
 		errorStats.setResolveWarning(tpl.Name, msg)
+		cm.addResolveError(tpl, svc, resolveErrorTemplateVariables, msg)
 		return tpl, false
 	}
 	resolvedConfig, err := decryptConfig(config, cm.secretResolver)
 	if err != nil {
 		msg := fmt.Sprintf("error decrypting secrets in config %s for service %s: %v", config.Name, svc.GetServiceID(), err)
 		errorStats.setResolveWarning(tpl.Name, msg)
+		cm.addResolveError(tpl, svc, resolveErrorSecrets, msg)
 		return config, false
 	}
 	errorStats.removeResolveWarnings(tpl.Name)
 	return resolvedConfig, true
 }
 
+// addResolveError records a failure to resolve a template for a service in
+// errorStats and in the telemetry.
+func (cm *reconcilingConfigManager) addResolveError(tpl integration.Config, svc listeners.Service, reason string, msg string) {
+	kind := serviceKind(svc.GetServiceID())
+	errorStats.addResolveError(tpl.Provider, kind, reason, msg)
+	if cm.telemetryStore != nil {
+		cm.telemetryStore.ResolveErrors.Inc(tpl.Provider, kind, reason)
+	}
+}
+
 // applyChanges applies the given changes to cm.scheduledConfigs
 //
 // This method must be called with cm.m locked.
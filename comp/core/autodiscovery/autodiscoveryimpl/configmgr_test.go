@@ -542,7 +542,18 @@ func TestReconcilingConfigManagement(t *testing.T) {
 	mockResolver := MockSecretResolver{}
 	suite.Run(t, &ReconcilingConfigManagerSuite{
 		ConfigManagerSuite{factory: func() configManager {
-			return newReconcilingConfigManager(&mockResolver)
+			return newReconcilingConfigManager(&mockResolver, nil)
+		}},
+	})
+}
+
+func TestReconcilingConfigManagementConcurrentResolutions(t *testing.T) {
+	mockResolver := MockSecretResolver{}
+	suite.Run(t, &ReconcilingConfigManagerSuite{
+		ConfigManagerSuite{factory: func() configManager {
+			cm := newReconcilingConfigManager(&mockResolver, nil).(*reconcilingConfigManager)
+			cm.maxConcurrentResolutions = 4
+			return cm
 		}},
 	})
 }
@@ -8,9 +8,22 @@ package autodiscoveryimpl
 import (
 	"expvar"
 	"maps"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/mohae/deepcopy"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+)
+
+const (
+	// resolveErrorTemplateVariables is the reason of the resolution failures
+	// caused by template variables that cannot be resolved for a service.
+	resolveErrorTemplateVariables = "template_variables"
+	// resolveErrorSecrets is the reason of the resolution failures caused by
+	// secrets that cannot be decrypted.
+	resolveErrorSecrets = "secrets"
 )
 
 var (
@@ -25,20 +38,34 @@ func setupAcErrors() {
 	acErrors.Set("ResolveWarnings", expvar.Func(func() interface{} {
 		return errorStats.getResolveWarnings()
 	}))
+	acErrors.Set("ResolveErrors", expvar.Func(func() interface{} {
+		return errorStats.getResolveErrors()
+	}))
+}
+
+// resolveErrorKey identifies the resolution failures of a config provider for
+// a kind of service and a reason
+type resolveErrorKey struct {
+	provider    string
+	serviceKind string
+	reason      string
 }
 
 // loaderErrorStats holds the error objects
 type acErrorStats struct {
 	config  map[string]string   // config file name -> error
 	resolve map[string][]string // config file name -> errors
-	m       sync.RWMutex
+	// resolveErrors counts the resolution failures since the agent started
+	resolveErrors map[resolveErrorKey]*integration.ResolveErrorStats
+	m             sync.RWMutex
 }
 
 // newAcErrorStats returns an instance holding autoconfig errors stats
 func newAcErrorStats() *acErrorStats {
 	return &acErrorStats{
-		config:  make(map[string]string),
-		resolve: make(map[string][]string),
+		config:        make(map[string]string),
+		resolve:       make(map[string][]string),
+		resolveErrors: make(map[resolveErrorKey]*integration.ResolveErrorStats),
 	}
 }
 
@@ -93,6 +120,53 @@ func (es *acErrorStats) getResolveWarnings() map[string][]string {
 	return deepcopy.Copy(es.resolve).(map[string][]string)
 }
 
+// addResolveError will safely count a template resolution failure and keep
+// its error as the last one for the provider, service kind and reason
+func (es *acErrorStats) addResolveError(provider string, serviceKind string, reason string, err string) {
+	es.m.Lock()
+	defer es.m.Unlock()
+
+	key := resolveErrorKey{provider: provider, serviceKind: serviceKind, reason: reason}
+	stats, found := es.resolveErrors[key]
+	if !found {
+		stats = &integration.ResolveErrorStats{
+			Provider:    provider,
+			ServiceKind: serviceKind,
+			Reason:      reason,
+		}
+		es.resolveErrors[key] = stats
+	}
+	stats.Count++
+	stats.LastError = err
+}
+
+// getResolveErrors will safely get the resolution failures, sorted by
+// provider, service kind and reason
+func (es *acErrorStats) getResolveErrors() []integration.ResolveErrorStats {
+	es.m.RLock()
+	defer es.m.RUnlock()
+
+	resolveErrors := make([]integration.ResolveErrorStats, 0, len(es.resolveErrors))
+	for _, stats := range es.resolveErrors {
+		resolveErrors = append(resolveErrors, *stats)
+	}
+	slices.SortFunc(resolveErrors, func(a, b integration.ResolveErrorStats) int {
+		return strings.Compare(a.Provider+"/"+a.ServiceKind+"/"+a.Reason, b.Provider+"/"+b.ServiceKind+"/"+b.Reason)
+	})
+
+	return resolveErrors
+}
+
+// serviceKind returns the kind of a service from the scheme of its ID, like
+// "docker" or "kube_service", which identifies the listener that created it
+func serviceKind(svcID string) string {
+	kind, _, found := strings.Cut(svcID, "://")
+	if !found || kind == "" {
+		return "unknown"
+	}
+	return kind
+}
+
 // GetConfigErrors gets the config errors
 func GetConfigErrors() map[string]string {
 	return errorStats.getConfigErrors()
@@ -102,3 +176,9 @@ func GetConfigErrors() map[string]string {
 func GetResolveWarnings() map[string][]string {
 	return errorStats.getResolveWarnings()
 }
+
+// GetResolveErrors gets the template resolution failures by config provider,
+// kind of service and reason
+func GetResolveErrors() []integration.ResolveErrorStats {
+	return errorStats.getResolveErrors()
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 )
 
 func TestNewAcErrorStats(t *testing.T) {
@@ -42,3 +44,33 @@ func TestGetConfigErrors(t *testing.T) {
 
 	assert.Len(t, err, 1)
 }
+
+func TestAddResolveError(t *testing.T) {
+	s := newAcErrorStats()
+	s.addResolveError("kubernetes-container-allinone", "kubernetes_pod", resolveErrorSecrets, "anError")
+	s.addResolveError("container", "docker", resolveErrorTemplateVariables, "anError")
+	s.addResolveError("container", "docker", resolveErrorTemplateVariables, "anotherError")
+
+	assert.Equal(t, []integration.ResolveErrorStats{
+		{
+			Provider:    "container",
+			ServiceKind: "docker",
+			Reason:      resolveErrorTemplateVariables,
+			Count:       2,
+			LastError:   "anotherError",
+		},
+		{
+			Provider:    "kubernetes-container-allinone",
+			ServiceKind: "kubernetes_pod",
+			Reason:      resolveErrorSecrets,
+			Count:       1,
+			LastError:   "anError",
+		},
+	}, s.getResolveErrors())
+}
+
+func TestServiceKind(t *testing.T) {
+	assert.Equal(t, "docker", serviceKind("docker://abc"))
+	assert.Equal(t, "kube_service", serviceKind("kube_service://default/redis"))
+	assert.Equal(t, "unknown", serviceKind("abc"))
+}
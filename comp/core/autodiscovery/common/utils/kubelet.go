@@ -42,6 +42,14 @@ func ValidateAnnotationsMatching(annotations map[string]string, containerIdentif
 			}
 		}
 	}
+
+	// AD annotations v3 reference containers in their value
+	for _, idToValidate := range podChecksContainers(annotations) {
+		err := validateIdentifier(podChecksAnnotation, containerIdentifiers, idToValidate)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
 	return errors
 }
 
@@ -217,6 +217,28 @@ func TestValidateAnnotationsMatching(t *testing.T) {
 				errors.New("annotation ad.datadoghq.com/.check_names is invalid:  doesn't match a container identifier [nginx-custom]"),
 			},
 		},
+		{
+			name: "v3 annotations",
+			args: args{
+				annotations: map[string]string{
+					"ad.datadoghq.com/checks": `{
+						"http_check": {
+							"instances": [{"url": "http://%%host%%"}],
+							"containers": {"nginx": {}, "not-nginx": {}}
+						}
+					}`,
+				},
+				validIDs: map[string]struct{}{
+					"nginx": {},
+				},
+				containerNames: map[string]struct{}{
+					"nginx": {},
+				},
+			},
+			want: []error{
+				errors.New("annotation ad.datadoghq.com/checks is invalid: not-nginx doesn't match a container identifier [nginx]"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
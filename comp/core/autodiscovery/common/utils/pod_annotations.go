@@ -6,8 +6,12 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 )
@@ -23,6 +27,10 @@ const (
 	legacyPodAnnotationFormat = legacyPodAnnotationPrefix + "%s."
 
 	podAnnotationCheckIDFormat = podAnnotationFormat + checkIDPath
+
+	// podChecksAnnotation is the AD annotation v3, holding the check
+	// configurations of all the containers of a pod.
+	podChecksAnnotation = KubeAnnotationPrefix + checksPath
 )
 
 // ExtractCheckIDFromPodAnnotations returns whether there is a custom check ID for a given
@@ -38,7 +46,24 @@ func ExtractCheckIDFromPodAnnotations(annotations map[string]string, containerNa
 func ExtractCheckNamesFromPodAnnotations(annotations map[string]string, adIdentifier string) ([]string, error) {
 	prefix := fmt.Sprintf(podAnnotationFormat, adIdentifier)
 	legacyPrefix := fmt.Sprintf(legacyPodAnnotationFormat, adIdentifier)
-	return extractCheckNamesFromMap(annotations, prefix, legacyPrefix)
+	checkNames, err := extractCheckNamesFromMap(annotations, prefix, legacyPrefix)
+	if checkNames != nil || err != nil {
+		return checkNames, err
+	}
+
+	// AD annotations v3: "ad.datadoghq.com/checks". Errors are reported
+	// when extracting the templates.
+	value, found := annotations[podChecksAnnotation]
+	if !found {
+		return nil, nil
+	}
+	checks, _ := parsePodChecks(value)
+	for _, name := range sortedCheckNames(checks) {
+		if _, found := checks[name].Containers[adIdentifier]; found {
+			checkNames = append(checkNames, checks[name].checkName(name))
+		}
+	}
+	return checkNames, nil
 }
 
 // ExtractTemplatesFromAnnotations looks for autodiscovery configurations in
@@ -48,6 +73,11 @@ func ExtractTemplatesFromAnnotations(entityName string, annotations map[string]s
 	prefix := fmt.Sprintf(podAnnotationFormat, adIdentifier)
 	legacyPrefix := fmt.Sprintf(legacyPodAnnotationFormat, adIdentifier)
 	res, err := extractTemplatesFromMapWithV2(entityName, annotations, prefix, legacyPrefix)
+	if len(res) == 0 && len(err) == 0 {
+		// Annotations specific to the container take precedence over
+		// the AD annotations v3: "ad.datadoghq.com/checks"
+		res, err = extractTemplatesFromPodChecks(entityName, annotations, adIdentifier)
+	}
 	return res, err
 }
 
@@ -104,3 +134,236 @@ func parseChecksJSON(adIdentifier string, checksJSON string) ([]integration.Conf
 
 	return checks, nil
 }
+
+// AnnotationError is a validation error of an AD annotation. Path locates the
+// invalid value in the annotation, e.g. "redisdb.containers.redis.instances[0]".
+type AnnotationError struct {
+	Annotation string
+	Path       string
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *AnnotationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("annotation %s is invalid: %v", e.Annotation, e.Err)
+	}
+	return fmt.Sprintf("annotation %s is invalid at %s: %v", e.Annotation, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *AnnotationError) Unwrap() error {
+	return e.Err
+}
+
+// podCheck is a check configuration of an AD annotation v3
+// (ad.datadoghq.com/checks). The check is only scheduled on the containers
+// listed in Containers, each of which can override the configuration.
+type podCheck struct {
+	Name                    string                        `json:"name"`
+	InitConfig              json.RawMessage               `json:"init_config"`
+	Instances               []interface{}                 `json:"instances"`
+	Logs                    json.RawMessage               `json:"logs"`
+	IgnoreAutodiscoveryTags bool                          `json:"ignore_autodiscovery_tags"`
+	CheckTagCardinality     string                        `json:"check_tag_cardinality"`
+	Containers              map[string]*podCheckContainer `json:"containers"`
+}
+
+// podCheckContainer overrides a podCheck for a container, identified by its
+// name or custom check ID. When Ports is set, the instances are repeated for
+// each of the named ports, with %%port%% resolving to that port.
+type podCheckContainer struct {
+	InitConfig json.RawMessage `json:"init_config"`
+	Instances  []interface{}   `json:"instances"`
+	Logs       json.RawMessage `json:"logs"`
+	Ports      []string        `json:"ports"`
+}
+
+func (c *podCheck) checkName(name string) string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return name
+}
+
+// parsePodChecks parses an AD annotation v3. Invalid checks are reported and
+// left out of the result.
+func parsePodChecks(value string) (map[string]*podCheck, []error) {
+	var checks map[string]*podCheck
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&checks); err != nil {
+		return nil, []error{&AnnotationError{Annotation: podChecksAnnotation, Err: err}}
+	}
+
+	var errs []error
+	for _, name := range sortedCheckNames(checks) {
+		if checkErrs := checks[name].validate(name); len(checkErrs) > 0 {
+			errs = append(errs, checkErrs...)
+			delete(checks, name)
+		}
+	}
+	return checks, errs
+}
+
+func (c *podCheck) validate(path string) []error {
+	if c == nil {
+		return []error{annotationError(path, "expected a JSON object")}
+	}
+
+	errs := validateCheckFields(path, c.InitConfig, c.Instances, c.Logs)
+	if len(c.Containers) == 0 {
+		errs = append(errs, annotationError(path+".containers", "at least one container is required"))
+	}
+
+	containerNames := make([]string, 0, len(c.Containers))
+	for name := range c.Containers {
+		containerNames = append(containerNames, name)
+	}
+	sort.Strings(containerNames)
+
+	for _, name := range containerNames {
+		containerPath := path + ".containers." + name
+		container := c.Containers[name]
+		if container == nil {
+			// An empty override uses the configuration of the check as is.
+			container = &podCheckContainer{}
+			c.Containers[name] = container
+		}
+		errs = append(errs, validateCheckFields(containerPath, container.InitConfig, container.Instances, container.Logs)...)
+		for i, port := range container.Ports {
+			if port == "" {
+				errs = append(errs, annotationError(fmt.Sprintf("%s.ports[%d]", containerPath, i), "port name is empty"))
+			}
+		}
+
+		hasInstances := len(c.Instances) > 0 || len(container.Instances) > 0
+		hasLogs := !isJSONNull(c.Logs) || !isJSONNull(container.Logs)
+		if !hasInstances && !hasLogs {
+			errs = append(errs, annotationError(containerPath, "no instances or logs configured"))
+		}
+	}
+	return errs
+}
+
+func validateCheckFields(path string, initConfig json.RawMessage, instances []interface{}, logs json.RawMessage) []error {
+	var errs []error
+	if !isJSONNull(initConfig) && !bytes.HasPrefix(bytes.TrimSpace(initConfig), []byte("{")) {
+		errs = append(errs, annotationError(path+".init_config", "expected a JSON object"))
+	}
+	for i, instance := range instances {
+		if _, ok := instance.(map[string]interface{}); !ok {
+			errs = append(errs, annotationError(fmt.Sprintf("%s.instances[%d]", path, i), "expected a JSON object"))
+		}
+	}
+	if !isJSONNull(logs) && !bytes.HasPrefix(bytes.TrimSpace(logs), []byte("[")) {
+		errs = append(errs, annotationError(path+".logs", "expected a JSON array"))
+	}
+	return errs
+}
+
+func annotationError(path string, msg string) error {
+	return &AnnotationError{Annotation: podChecksAnnotation, Path: path, Err: errors.New(msg)}
+}
+
+func isJSONNull(value json.RawMessage) bool {
+	value = bytes.TrimSpace(value)
+	return len(value) == 0 || bytes.Equal(value, []byte("null"))
+}
+
+func sortedCheckNames(checks map[string]*podCheck) []string {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// extractTemplatesFromPodChecks returns the check configurations of an AD
+// annotation v3 that apply to the container with the given AD identifier.
+func extractTemplatesFromPodChecks(entityName string, annotations map[string]string, adIdentifier string) ([]integration.Config, []error) {
+	value, found := annotations[podChecksAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	checks, errs := parsePodChecks(value)
+	var configs []integration.Config
+	for _, name := range sortedCheckNames(checks) {
+		check := checks[name]
+		container, found := check.Containers[adIdentifier]
+		if !found {
+			continue
+		}
+		configs = append(configs, check.buildConfig(name, entityName, container))
+	}
+	return configs, errs
+}
+
+// buildConfig builds the configuration of a check for a container. The
+// check must have been validated.
+func (c *podCheck) buildConfig(name string, entityName string, container *podCheckContainer) integration.Config {
+	initConfig := c.InitConfig
+	if !isJSONNull(container.InitConfig) {
+		initConfig = container.InitConfig
+	}
+	if isJSONNull(initConfig) {
+		initConfig = json.RawMessage("{}")
+	}
+	instances := c.Instances
+	if len(container.Instances) > 0 {
+		instances = container.Instances
+	}
+	logs := c.Logs
+	if !isJSONNull(container.Logs) {
+		logs = container.Logs
+	}
+
+	config := integration.Config{
+		Name:                    c.checkName(name),
+		InitConfig:              integration.Data(initConfig),
+		ADIdentifiers:           []string{entityName},
+		IgnoreAutodiscoveryTags: c.IgnoreAutodiscoveryTags,
+		CheckTagCardinality:     c.CheckTagCardinality,
+	}
+	if !isJSONNull(logs) {
+		config.LogsConfig = integration.Data(logs)
+	}
+	for _, i := range instances {
+		instance, err := parseJSONObjToData(i)
+		if err != nil {
+			continue
+		}
+		if len(container.Ports) == 0 {
+			config.Instances = append(config.Instances, instance)
+			continue
+		}
+		for _, port := range container.Ports {
+			config.Instances = append(config.Instances, integration.Data(bytes.ReplaceAll(
+				instance, []byte("%%port%%"), []byte("%%port_"+port+"%%"),
+			)))
+		}
+	}
+	return config
+}
+
+// podChecksContainers returns the AD identifiers of the containers
+// referenced by a valid AD annotation v3.
+func podChecksContainers(annotations map[string]string) []string {
+	value, found := annotations[podChecksAnnotation]
+	if !found {
+		return nil
+	}
+	checks, _ := parsePodChecks(value)
+	var identifiers []string
+	for _, check := range checks {
+		for identifier := range check.Containers {
+			if !slices.Contains(identifiers, identifier) {
+				identifiers = append(identifiers, identifier)
+			}
+		}
+	}
+	sort.Strings(identifiers)
+	return identifiers
+}
@@ -67,6 +67,18 @@ func TestExtractCheckNamesFromAnnotations(t *testing.T) {
 			adIdentifier: "redis",
 			checkNames:   []string{"redisdb", "foobar"},
 		},
+		{
+			name: "v3 annotations",
+			annotations: map[string]string{
+				"ad.datadoghq.com/checks": `{
+					"redisdb": {"instances": [{}], "containers": {"redis": {}}},
+					"custom": {"name": "http_check", "instances": [{}], "containers": {"redis": {}}},
+					"apache": {"instances": [{}], "containers": {"apache": {}}}
+				}`,
+			},
+			adIdentifier: "redis",
+			checkNames:   []string{"redisdb", "http_check"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -473,6 +485,106 @@ func TestExtractTemplatesFromAnnotations(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "v3 annotations",
+			annotations: map[string]string{
+				"ad.datadoghq.com/checks": `{
+					"redisdb": {
+						"instances": [{"host": "%%host%%", "port": "%%port%%"}],
+						"containers": {
+							"foobar": {"ports": ["primary", "replica"]},
+							"other": {}
+						}
+					},
+					"http_check": {
+						"init_config": {"foo": "bar"},
+						"instances": [{"url": "http://%%host%%"}],
+						"containers": {
+							"foobar": {"instances": [{"url": "http://%%host%%/health"}]}
+						}
+					},
+					"apache": {
+						"instances": [{"apache_status_url": "http://%%host%%/server-status?auto"}],
+						"containers": {"other": {}}
+					}
+				}`,
+			},
+			adIdentifier: "foobar",
+			output: []integration.Config{
+				{
+					Name: "redisdb",
+					Instances: []integration.Data{
+						integration.Data(`{"host":"%%host%%","port":"%%port_primary%%"}`),
+						integration.Data(`{"host":"%%host%%","port":"%%port_replica%%"}`),
+					},
+					InitConfig:    integration.Data("{}"),
+					ADIdentifiers: []string{adID},
+				},
+				{
+					Name:          "http_check",
+					Instances:     []integration.Data{integration.Data(`{"url":"http://%%host%%/health"}`)},
+					InitConfig:    integration.Data(`{"foo": "bar"}`),
+					ADIdentifiers: []string{adID},
+				},
+			},
+		},
+		{
+			name: "v2 annotations take precedence over v3 annotations",
+			annotations: map[string]string{
+				"ad.datadoghq.com/foobar.checks": `{
+					"apache": {
+						"instances": [{"apache_status_url": "http://%%host%%/server-status?auto"}]
+					}
+				}`,
+				"ad.datadoghq.com/checks": `{
+					"redisdb": {
+						"instances": [{"host": "%%host%%"}],
+						"containers": {"foobar": {}}
+					}
+				}`,
+			},
+			adIdentifier: "foobar",
+			output: []integration.Config{
+				{
+					Name:          "apache",
+					Instances:     []integration.Data{integration.Data(`{"apache_status_url":"http://%%host%%/server-status?auto"}`)},
+					InitConfig:    integration.Data("{}"),
+					ADIdentifiers: []string{adID},
+				},
+			},
+		},
+		{
+			name: "v3 annotations with invalid checks",
+			annotations: map[string]string{
+				"ad.datadoghq.com/checks": `{
+					"redisdb": {
+						"instances": [{"host": "%%host%%"}],
+						"containers": {"foobar": {}}
+					},
+					"apache": {
+						"instances": ["http://%%host%%"],
+						"containers": {"foobar": {"logs": {}}}
+					},
+					"http_check": {
+						"instances": [{"url": "http://%%host%%"}]
+					}
+				}`,
+			},
+			adIdentifier: "foobar",
+			output: []integration.Config{
+				{
+					Name:          "redisdb",
+					Instances:     []integration.Data{integration.Data(`{"host":"%%host%%"}`)},
+					InitConfig:    integration.Data("{}"),
+					ADIdentifiers: []string{adID},
+				},
+			},
+			errs: []error{
+				&AnnotationError{Annotation: "ad.datadoghq.com/checks", Path: "apache.instances[0]", Err: errors.New("expected a JSON object")},
+				&AnnotationError{Annotation: "ad.datadoghq.com/checks", Path: "apache.containers.foobar.logs", Err: errors.New("expected a JSON array")},
+				&AnnotationError{Annotation: "ad.datadoghq.com/checks", Path: "http_check.containers", Err: errors.New("at least one container is required")},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -30,6 +30,7 @@ type Component interface {
 	RemoveScheduler(name string)
 	GetIDOfCheckWithEncryptedSecrets(checkID checkid.ID) checkid.ID
 	GetAutodiscoveryErrors() map[string]map[string]types.ErrorMsgSet
+	GetResolveErrors() []integration.ResolveErrorStats
 	GetProviderCatalog() map[string]types.ConfigProviderFactory
 	GetTelemetryStore() *telemetry.Store
 	// TODO (component): once cluster agent uses the API component remove this function
@@ -9,6 +9,7 @@
 package configresolver
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/listeners"
@@ -27,6 +29,8 @@ import (
 	apiutil "github.com/DataDog/datadog-agent/pkg/api/util"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 
+	"github.com/DataDog/datadog-agent/pkg/util/cache"
+	"github.com/DataDog/datadog-agent/pkg/util/cloudproviders"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 
 	yaml "gopkg.in/yaml.v2"
@@ -42,8 +46,22 @@ var templateVariables = map[string]variableGetter{
 	"env":      getEnvvar,
 	"extra":    getAdditionalTplVariables,
 	"kube":     getAdditionalTplVariables,
+	// Template variables are split on their first underscore, so
+	// %%availability_zone%% and %%instance_type%% are looked up by prefix.
+	"region":       getCloudMetadata("region"),
+	"availability": getCloudMetadata("availability"),
+	"instance":     getCloudMetadata("instance"),
 }
 
+// getInstanceMetadata is a variable to ease testing
+var getInstanceMetadata = cloudproviders.GetInstanceMetadata
+
+const cloudMetadataTimeout = 5 * time.Second
+
+// cloudMetadataCacheKey caches the metadata of the cloud instance, which does
+// not change during the lifetime of the agent
+var cloudMetadataCacheKey = cache.BuildAgentKey("autodiscovery", "configresolver", "cloud_metadata")
+
 // NoServiceError represents an error that indicates that there's a problem with a service
 type NoServiceError struct {
 	message string
@@ -647,6 +665,47 @@ func getEnvvar(envVar string, svc listeners.Service) (string, error) {
 	return value, nil
 }
 
+// getCloudMetadata returns the getter of the %%region%%,
+// %%availability_zone%% and %%instance_type%% template variables, which are
+// resolved from the metadata of the cloud instance the agent is running on.
+// The metadata is fetched once and cached.
+func getCloudMetadata(prefix string) variableGetter {
+	return func(key string, _ listeners.Service) (string, error) {
+		name := prefix
+		if key != "" {
+			name += "_" + key
+		}
+		switch name {
+		case "region", "availability_zone", "instance_type":
+		default:
+			return "", fmt.Errorf("invalid %%%%%s%%%% tag", name)
+		}
+
+		metadata, err := cache.Get[*cloudproviders.InstanceMetadata](cloudMetadataCacheKey, func() (*cloudproviders.InstanceMetadata, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), cloudMetadataTimeout)
+			defer cancel()
+			return getInstanceMetadata(ctx)
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get %s from the cloud provider: %w", name, err)
+		}
+
+		var value string
+		switch name {
+		case "region":
+			value = metadata.Region
+		case "availability_zone":
+			value = metadata.AvailabilityZone
+		case "instance_type":
+			value = metadata.InstanceType
+		}
+		if value == "" {
+			return "", fmt.Errorf("%s is not available on cloud provider %s", name, metadata.CloudProvider)
+		}
+		return value, nil
+	}
+}
+
 func allowEnvVar(envVar string) bool {
 	if pkgconfigsetup.Datadog().GetBool("ad_disable_env_var_resolution") {
 		return false
@@ -6,6 +6,8 @@
 package configresolver
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -18,6 +20,8 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
 	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
 	mockconfig "github.com/DataDog/datadog-agent/pkg/config/mock"
+	"github.com/DataDog/datadog-agent/pkg/util/cache"
+	"github.com/DataDog/datadog-agent/pkg/util/cloudproviders"
 
 	// we need some valid check in the catalog to run tests
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/system"
@@ -948,6 +952,82 @@ func TestResolve(t *testing.T) {
 	}
 }
 
+func TestResolveCloudMetadata(t *testing.T) {
+	svc := &dummyService{
+		ID:            "a5901276aed1",
+		ADIdentifiers: []string{"redis"},
+	}
+	tpl := integration.Config{
+		Name:          "cpu",
+		ADIdentifiers: []string{"redis"},
+		Instances:     []integration.Data{integration.Data("region: %%region%%\nzone: %%availability_zone%%\ntype: %%instance_type%%")},
+	}
+
+	t.Run("ec2", func(t *testing.T) {
+		mockconfig.New(t)
+		mockInstanceMetadata(t)
+		calls := 0
+		getInstanceMetadata = func(context.Context) (*cloudproviders.InstanceMetadata, error) {
+			calls++
+			return &cloudproviders.InstanceMetadata{
+				CloudProvider:    "AWS",
+				Region:           "us-east-1",
+				AvailabilityZone: "us-east-1a",
+				InstanceType:     "m5.large",
+			}, nil
+		}
+
+		cfg, err := Resolve(tpl, svc)
+		assert.NoError(t, err)
+		assert.Equal(t, []integration.Data{integration.Data("region: us-east-1\ntags:\n- foo:bar\ntype: m5.large\nzone: us-east-1a\n")}, cfg.Instances)
+
+		_, err = Resolve(tpl, svc)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		mockconfig.New(t)
+		mockInstanceMetadata(t)
+		getInstanceMetadata = func(context.Context) (*cloudproviders.InstanceMetadata, error) {
+			return &cloudproviders.InstanceMetadata{CloudProvider: "GCP", Region: "us-central1"}, nil
+		}
+
+		// the variables are resolved in map order, either one can be reported
+		_, err := Resolve(tpl, svc)
+		assert.Regexp(t, "^(availability_zone|instance_type) is not available on cloud provider GCP$", err)
+	})
+
+	t.Run("no cloud provider", func(t *testing.T) {
+		mockconfig.New(t)
+		mockInstanceMetadata(t)
+		getInstanceMetadata = func(context.Context) (*cloudproviders.InstanceMetadata, error) {
+			return nil, errors.New("no supported cloud provider detected")
+		}
+
+		// the variables are resolved in map order, any of them can be reported
+		_, err := Resolve(tpl, svc)
+		assert.Regexp(t, "^failed to get (region|availability_zone|instance_type) from the cloud provider: no supported cloud provider detected$", err)
+	})
+
+	t.Run("invalid variable", func(t *testing.T) {
+		mockconfig.New(t)
+		_, err := getCloudMetadata("instance")("id", svc)
+		assert.EqualError(t, err, "invalid %%instance_id%% tag")
+	})
+}
+
+// mockInstanceMetadata restores getInstanceMetadata and clears the cached
+// metadata at the end of the test
+func mockInstanceMetadata(t *testing.T) {
+	getter := getInstanceMetadata
+	cache.Cache.Delete(cloudMetadataCacheKey)
+	t.Cleanup(func() {
+		getInstanceMetadata = getter
+		cache.Cache.Delete(cloudMetadataCacheKey)
+	})
+}
+
 func newFakeContainerPorts() []listeners.ContainerPort {
 	return []listeners.ContainerPort{
 		{Port: 1, Name: "foo"},
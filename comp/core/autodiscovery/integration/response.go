@@ -26,10 +26,22 @@ type ServiceResponse struct {
 	FiltersLogs    bool              `json:"filters_logs"`
 }
 
+// ResolveErrorStats holds the template resolution failures of a config
+// provider for a kind of service, like "docker" or "kube_service", and a
+// reason, like "template_variables" or "secrets"
+type ResolveErrorStats struct {
+	Provider    string `json:"provider"`
+	ServiceKind string `json:"service_kind"`
+	Reason      string `json:"reason"`
+	Count       int    `json:"count"`
+	LastError   string `json:"last_error"`
+}
+
 // ConfigCheckResponse holds the config check response
 type ConfigCheckResponse struct {
 	Configs         []ConfigResponse    `json:"configs"`
 	ResolveWarnings map[string][]string `json:"resolve_warnings"`
+	ResolveErrors   []ResolveErrorStats `json:"resolve_errors,omitempty"`
 	ConfigErrors    map[string]string   `json:"config_errors"`
 	Unresolved      map[string]Config   `json:"unresolved"`
 	Services        []ServiceResponse   `json:"services,omitempty"`
@@ -40,16 +40,19 @@ type ContainerListener struct {
 	metricsFilter workloadfilter.FilterBundle
 	logsFilter    workloadfilter.FilterBundle
 	tagger        tagger.Component
+	// listenerFilter excludes workloads by namespace and labels.
+	listenerFilter *listenerFilter
 }
 
 // NewContainerListener returns a new ContainerListener.
 func NewContainerListener(options ServiceListernerDeps) (ServiceListener, error) {
 	const name = "ad-containerlistener"
 	l := &ContainerListener{
-		globalFilter:  options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.GlobalFilter),
-		metricsFilter: options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.MetricsFilter),
-		logsFilter:    options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.LogsFilter),
-		tagger:        options.Tagger,
+		globalFilter:   options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.GlobalFilter),
+		metricsFilter:  options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.MetricsFilter),
+		logsFilter:     options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.LogsFilter),
+		tagger:         options.Tagger,
+		listenerFilter: newListenerFilter(pkgconfigsetup.Datadog()),
 	}
 	filter := workloadmeta.NewFilterBuilder().
 		SetSource(workloadmeta.SourceAll).
@@ -79,6 +82,18 @@ func (l *ContainerListener) createContainerService(entity workloadmeta.Entity) {
 			log.Debugf("container %q belongs to a pod but was not found: %s", container.ID, err)
 		}
 	}
+
+	// Containers of pods are filtered on the namespace and labels of their
+	// pod, like in the kubelet listener.
+	namespace, workloadLabels := "", container.Labels
+	if pod != nil {
+		namespace, workloadLabels = pod.Namespace, pod.Labels
+	}
+	if l.listenerFilter.isExcluded(namespace, workloadLabels) {
+		log.Debugf("container %s filtered out: namespace %q", container.ID, namespace)
+		return
+	}
+
 	containerImg := container.Image
 	filterableContainer := workloadmetafilter.CreateContainer(container, workloadmetafilter.CreatePod(pod))
 
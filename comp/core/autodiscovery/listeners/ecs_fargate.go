@@ -0,0 +1,186 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"errors"
+	"maps"
+	"sort"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/common/utils"
+	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	workloadmetafilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/util/workloadmeta"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/util/containers"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// ECSFargateListener listens to ECS Fargate task and container creation
+// through a subscription to the workloadmeta store.
+type ECSFargateListener struct {
+	workloadmetaListener
+	globalFilter  workloadfilter.FilterBundle
+	metricsFilter workloadfilter.FilterBundle
+	logsFilter    workloadfilter.FilterBundle
+	tagger        tagger.Component
+}
+
+// NewECSFargateListener returns a new ECSFargateListener.
+func NewECSFargateListener(options ServiceListernerDeps) (ServiceListener, error) {
+	const name = "ad-ecsfargatelistener"
+
+	l := &ECSFargateListener{
+		globalFilter:  options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.GlobalFilter),
+		metricsFilter: options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.MetricsFilter),
+		logsFilter:    options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.LogsFilter),
+		tagger:        options.Tagger,
+	}
+	// Containers are watched as well as tasks, as a change of the state of a
+	// container does not necessarily change the task it belongs to.
+	wmetaFilter := workloadmeta.NewFilterBuilder().
+		SetSource(workloadmeta.SourceAll).
+		AddKind(workloadmeta.KindECSTask).
+		AddKind(workloadmeta.KindContainer).
+		Build()
+
+	wmetaInstance, ok := options.Wmeta.Get()
+	if !ok {
+		return nil, errors.New("workloadmeta store is not initialized")
+	}
+	var err error
+	l.workloadmetaListener, err = newWorkloadmetaListener(name, wmetaFilter, l.processEntity, wmetaInstance, options.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *ECSFargateListener) processEntity(entity workloadmeta.Entity) {
+	switch e := entity.(type) {
+	case *workloadmeta.ECSTask:
+		l.processTask(e)
+	case *workloadmeta.Container:
+		if e.Runtime != workloadmeta.ContainerRuntimeECSFargate {
+			return
+		}
+		task := l.findTask(e.ID)
+		if task == nil {
+			// The container will be processed along with its task.
+			log.Debugf("container %q does not belong to a known task yet", e.ID)
+			return
+		}
+		l.createContainerService(task, e)
+	}
+}
+
+func (l *ECSFargateListener) processTask(task *workloadmeta.ECSTask) {
+	if task.LaunchType != workloadmeta.ECSLaunchTypeFargate {
+		return
+	}
+
+	for _, taskContainer := range task.Containers {
+		container, err := l.Store().GetContainer(taskContainer.ID)
+		if err != nil {
+			log.Debugf("task %q has reference to non-existing container %q", task.ID, taskContainer.ID)
+			continue
+		}
+
+		l.createContainerService(task, container)
+	}
+}
+
+// findTask returns the task running a container, or nil if it is not known.
+func (l *ECSFargateListener) findTask(containerID string) *workloadmeta.ECSTask {
+	for _, task := range l.Store().ListECSTasks() {
+		if task.LaunchType != workloadmeta.ECSLaunchTypeFargate {
+			continue
+		}
+		for _, taskContainer := range task.Containers {
+			if taskContainer.ID == containerID {
+				return task
+			}
+		}
+	}
+	return nil
+}
+
+func (l *ECSFargateListener) createContainerService(
+	task *workloadmeta.ECSTask,
+	container *workloadmeta.Container,
+) {
+	filterableContainer := workloadmetafilter.CreateContainer(container, nil)
+
+	if l.globalFilter.IsExcluded(filterableContainer) {
+		log.Debugf("container %s filtered out: name %q image %q", container.ID, container.Name, container.Image.RawName)
+		return
+	}
+
+	// Containers are not restarted in Fargate, so only running containers
+	// are worth scheduling checks on.
+	if !container.State.Running {
+		return
+	}
+
+	ports := make([]ContainerPort, 0, len(container.Ports))
+	for _, port := range container.Ports {
+		ports = append(ports, ContainerPort{
+			Port: port.Port,
+			Name: port.Name,
+		})
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Port < ports[j].Port
+	})
+
+	// awsvpc does not assign an IP through the runtime, fall back to the
+	// hostname when no IP is known.
+	hosts := make(map[string]string)
+	maps.Copy(hosts, container.NetworkIPs)
+	if len(hosts) == 0 && len(container.Hostname) > 0 {
+		hosts["hostname"] = container.Hostname
+	}
+
+	checkNames, err := utils.ExtractCheckNamesFromContainerLabels(container.Labels)
+	if err != nil {
+		log.Errorf("error getting check names from labels on container %s: %v", container.ID, err)
+	}
+
+	svc := &WorkloadService{
+		entity:   container,
+		tagsHash: l.tagger.GetEntityHash(types.NewEntityID(types.ContainerID, container.ID), types.ChecksConfigCardinality),
+		adIdentifiers: computeContainerServiceIDs(
+			containers.BuildEntityName(string(container.Runtime), container.ID),
+			container.Image.RawName,
+			container.Labels,
+		),
+		hosts:      hosts,
+		ports:      ports,
+		hostname:   container.Hostname,
+		ready:      true,
+		checkNames: checkNames,
+		extraConfig: map[string]string{
+			"task_arn":     task.ID,
+			"task_family":  task.Family,
+			"task_version": task.Version,
+			"cluster_name": task.ClusterName,
+		},
+		metricsExcluded: l.metricsFilter.IsExcluded(filterableContainer),
+		logsExcluded:    l.logsFilter.IsExcluded(filterableContainer),
+		tagger:          l.tagger,
+		imageName:       container.Image.ShortName,
+		wmeta:           l.Store(),
+	}
+
+	svcID := buildSvcID(container.GetID())
+	taskSvcID := buildSvcID(task.GetID())
+	l.AddService(svcID, svc, taskSvcID)
+}
@@ -0,0 +1,137 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	taggerfxmock "github.com/DataDog/datadog-agent/comp/core/tagger/fx-mock"
+	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	workloadfilterfxmock "github.com/DataDog/datadog-agent/comp/core/workloadfilter/fx-mock"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	workloadmetamock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/mock"
+)
+
+const taskARN = "arn:aws:ecs:us-east-1:123456789012:task/cluster/abcdef"
+
+func newFargateTask(launchType workloadmeta.ECSLaunchType, containerIDs ...string) *workloadmeta.ECSTask {
+	task := &workloadmeta.ECSTask{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindECSTask,
+			ID:   taskARN,
+		},
+		ClusterName: "cluster",
+		Family:      "redis",
+		Version:     "3",
+		LaunchType:  launchType,
+	}
+	for _, id := range containerIDs {
+		task.Containers = append(task.Containers, workloadmeta.OrchestratorContainer{ID: id})
+	}
+	return task
+}
+
+func newFargateContainer(id string, running bool) *workloadmeta.Container {
+	return &workloadmeta.Container{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindContainer,
+			ID:   id,
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: "redis",
+			Labels: map[string]string{
+				"com.datadoghq.ad.check_names":  `["redisdb"]`,
+				"com.datadoghq.ad.init_configs": `[{}]`,
+				"com.datadoghq.ad.instances":    `[{"host": "%%host%%", "port": 6379}]`,
+			},
+		},
+		Image: workloadmeta.ContainerImage{
+			RawName:   "redis:latest",
+			ShortName: "redis",
+		},
+		NetworkIPs: map[string]string{"awsvpc": "10.0.0.1"},
+		Runtime:    workloadmeta.ContainerRuntimeECSFargate,
+		State: workloadmeta.ContainerState{
+			Running: running,
+		},
+	}
+}
+
+func TestECSFargateProcessTask(t *testing.T) {
+	taggerComponent := taggerfxmock.SetupFakeTagger(t)
+	listener, wlm := newECSFargateListener(t, taggerComponent)
+
+	running := newFargateContainer("running", true)
+	stopped := newFargateContainer("stopped", false)
+	wlm.Store().(workloadmetamock.Mock).Set(running)
+	wlm.Store().(workloadmetamock.Mock).Set(stopped)
+
+	listener.processTask(newFargateTask(workloadmeta.ECSLaunchTypeFargate, "running", "stopped", "missing"))
+
+	require.Len(t, wlm.services, 1)
+	svc, ok := wlm.services["container://running"]
+	require.True(t, ok)
+	assert.Equal(t, "ecs_task://"+taskARN, svc.parent)
+
+	workloadSvc := svc.service.(*WorkloadService)
+	assert.Equal(t, []string{"ecsfargate://running", "redis"}, workloadSvc.adIdentifiers)
+	assert.Equal(t, []string{"redisdb"}, workloadSvc.checkNames)
+	assert.Equal(t, map[string]string{"awsvpc": "10.0.0.1"}, workloadSvc.hosts)
+
+	family, err := workloadSvc.GetExtraConfig("task_family")
+	require.NoError(t, err)
+	assert.Equal(t, "redis", family)
+	arn, err := workloadSvc.GetExtraConfig("task_arn")
+	require.NoError(t, err)
+	assert.Equal(t, taskARN, arn)
+}
+
+func TestECSFargateProcessContainer(t *testing.T) {
+	taggerComponent := taggerfxmock.SetupFakeTagger(t)
+	listener, wlm := newECSFargateListener(t, taggerComponent)
+
+	container := newFargateContainer("running", true)
+	wlm.Store().(workloadmetamock.Mock).Set(container)
+
+	// Containers are ignored until their task is known.
+	listener.processEntity(container)
+	assert.Empty(t, wlm.services)
+
+	wlm.Store().(workloadmetamock.Mock).Set(newFargateTask(workloadmeta.ECSLaunchTypeFargate, "running"))
+	listener.processEntity(container)
+	require.Contains(t, wlm.services, "container://running")
+	assert.Equal(t, "ecs_task://"+taskARN, wlm.services["container://running"].parent)
+}
+
+func TestECSFargateIgnoresEC2Tasks(t *testing.T) {
+	taggerComponent := taggerfxmock.SetupFakeTagger(t)
+	listener, wlm := newECSFargateListener(t, taggerComponent)
+
+	container := newFargateContainer("running", true)
+	wlm.Store().(workloadmetamock.Mock).Set(container)
+
+	listener.processTask(newFargateTask(workloadmeta.ECSLaunchTypeEC2, "running"))
+	assert.Empty(t, wlm.services)
+}
+
+func newECSFargateListener(t *testing.T, tagger tagger.Component) (*ECSFargateListener, *testWorkloadmetaListener) {
+	wlm := newTestWorkloadmetaListener(t)
+	filterStore := workloadfilterfxmock.SetupMockFilter(t)
+
+	return &ECSFargateListener{
+		workloadmetaListener: wlm,
+		globalFilter:         filterStore.GetContainerAutodiscoveryFilters(workloadfilter.GlobalFilter),
+		metricsFilter:        filterStore.GetContainerAutodiscoveryFilters(workloadfilter.MetricsFilter),
+		logsFilter:           filterStore.GetContainerAutodiscoveryFilters(workloadfilter.LogsFilter),
+		tagger:               tagger,
+	}, wlm
+}
@@ -0,0 +1,366 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build clusterchecks && kubeapiserver
+
+package listeners
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	discv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	infov1 "k8s.io/client-go/informers/core/v1"
+	discinfov1 "k8s.io/client-go/informers/discovery/v1"
+	listv1 "k8s.io/client-go/listers/core/v1"
+	disclistv1 "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/common/types"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/telemetry"
+	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	"github.com/DataDog/datadog-agent/pkg/util/kubernetes/apiserver"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// KubeEndpointSlicesListener listens to kubernetes endpoint slices. It creates
+// the same services as the KubeEndpointsListener, but is not limited to the
+// first 1000 endpoints of a Kubernetes service, and the API server only sends
+// the slices that changed instead of the whole Endpoints object.
+//
+// A Kubernetes service can have several endpoint slices, its AD services are
+// built from all of them.
+type KubeEndpointSlicesListener struct {
+	endpointSliceInformer discinfov1.EndpointSliceInformer
+	endpointSliceLister   disclistv1.EndpointSliceLister
+	serviceInformer       infov1.ServiceInformer
+	serviceLister         listv1.ServiceLister
+	// endpoints are indexed by the namespace/name of their Kubernetes service
+	endpoints          map[string][]*KubeEndpointService
+	promInclAnnot      types.PrometheusAnnotations
+	newService         chan<- Service
+	delService         chan<- Service
+	targetAllEndpoints bool
+	m                  sync.Mutex
+	filterStore        workloadfilter.Component
+	telemetryStore     *telemetry.Store
+}
+
+func newKubeEndpointSlicesListener(ac *apiserver.APIClient, options ServiceListernerDeps) (ServiceListener, error) {
+	endpointSliceInformer := ac.InformerFactory.Discovery().V1().EndpointSlices()
+	if endpointSliceInformer == nil {
+		return nil, errors.New("cannot get endpoint slices informer")
+	}
+
+	serviceInformer := ac.InformerFactory.Core().V1().Services()
+	if serviceInformer == nil {
+		return nil, errors.New("cannot get service informer")
+	}
+
+	return &KubeEndpointSlicesListener{
+		endpoints:             make(map[string][]*KubeEndpointService),
+		endpointSliceInformer: endpointSliceInformer,
+		endpointSliceLister:   endpointSliceInformer.Lister(),
+		serviceInformer:       serviceInformer,
+		serviceLister:         serviceInformer.Lister(),
+		promInclAnnot:         getPrometheusIncludeAnnotations(),
+		targetAllEndpoints:    options.Config.IsProviderEnabled(names.KubeEndpointsFileRegisterName),
+		filterStore:           options.Filter,
+		telemetryStore:        options.Telemetry,
+	}, nil
+}
+
+// Listen starts watching service and endpoint slice events
+func (l *KubeEndpointSlicesListener) Listen(newSvc chan<- Service, delSvc chan<- Service) {
+	// setup the I/O channels
+	l.newService = newSvc
+	l.delService = delSvc
+
+	if _, err := l.endpointSliceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    l.endpointSliceAdded,
+		DeleteFunc: l.endpointSliceDeleted,
+		UpdateFunc: l.endpointSliceUpdated,
+	}); err != nil {
+		log.Errorf("cannot add event handler to endpoint slices informer: %s", err)
+	}
+
+	if _, err := l.serviceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: l.serviceUpdated,
+	}); err != nil {
+		log.Errorf("cannot add event handler to service informer: %s", err)
+	}
+
+	// Initial fill
+	slices, err := l.endpointSliceLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("Cannot list Kubernetes endpoint slices: %s", err)
+	}
+	synced := make(map[string]struct{})
+	for _, slice := range slices {
+		serviceName := slice.Labels[discv1.LabelServiceName]
+		key := slice.Namespace + "/" + serviceName
+		if _, found := synced[key]; found || serviceName == "" {
+			continue
+		}
+		synced[key] = struct{}{}
+		l.syncService(slice.Namespace, serviceName)
+	}
+}
+
+// Stop is a stub
+func (l *KubeEndpointSlicesListener) Stop() {
+	// We cannot deregister from the informer
+}
+
+func (l *KubeEndpointSlicesListener) endpointSliceAdded(obj interface{}) {
+	castedObj, ok := obj.(*discv1.EndpointSlice)
+	if !ok {
+		log.Errorf("Expected an *discv1.EndpointSlice type, got: %T", obj)
+		return
+	}
+	l.syncEndpointSlice(castedObj)
+}
+
+func (l *KubeEndpointSlicesListener) endpointSliceDeleted(obj interface{}) {
+	castedObj, ok := obj.(*discv1.EndpointSlice)
+	if !ok {
+		// It's possible that we got a DeletedFinalStateUnknown here
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Errorf("Received unexpected object: %T", obj)
+			return
+		}
+
+		castedObj, ok = deletedState.Obj.(*discv1.EndpointSlice)
+		if !ok {
+			log.Errorf("Expected DeletedFinalStateUnknown to contain *discv1.EndpointSlice, got: %T", deletedState.Obj)
+			return
+		}
+	}
+	l.syncEndpointSlice(castedObj)
+}
+
+func (l *KubeEndpointSlicesListener) endpointSliceUpdated(old, obj interface{}) {
+	castedObj, ok := obj.(*discv1.EndpointSlice)
+	if !ok {
+		log.Errorf("Expected an *discv1.EndpointSlice type, got: %T", obj)
+		return
+	}
+	castedOld, ok := old.(*discv1.EndpointSlice)
+	if ok && castedObj.ResourceVersion == castedOld.ResourceVersion {
+		return
+	}
+	l.syncEndpointSlice(castedObj)
+}
+
+func (l *KubeEndpointSlicesListener) serviceUpdated(old, obj interface{}) {
+	// Cast the updated object or return on failure
+	castedObj, ok := obj.(*v1.Service)
+	if !ok {
+		log.Errorf("Expected a *v1.Service type, got: %T", obj)
+		return
+	}
+
+	// Cast the old object, consider it an add on cast failure
+	castedOld, ok := old.(*v1.Service)
+	if !ok {
+		log.Errorf("Expected a *v1.Service type, got: %T", old)
+		l.syncService(castedObj.Namespace, castedObj.Name)
+		return
+	}
+
+	// Detect if new annotations are added, or changes of AD labels for
+	// standard tags if the Service is annotated
+	newlyAnnotated := !isServiceAnnotated(castedOld, kubeEndpointsID) && isServiceAnnotated(castedObj, kubeEndpointsID)
+	tagsChanged := isServiceAnnotated(castedObj, kubeEndpointsID) && standardTagsDigest(castedOld.GetLabels()) != standardTagsDigest(castedObj.GetLabels())
+	if newlyAnnotated || tagsChanged {
+		l.syncService(castedObj.Namespace, castedObj.Name)
+	}
+}
+
+// syncEndpointSlice updates the services of the Kubernetes service an
+// endpoint slice belongs to.
+func (l *KubeEndpointSlicesListener) syncEndpointSlice(slice *discv1.EndpointSlice) {
+	serviceName := slice.Labels[discv1.LabelServiceName]
+	if serviceName == "" {
+		log.Tracef("Ignoring endpoint slice %s/%s without %s label", slice.Namespace, slice.Name, discv1.LabelServiceName)
+		return
+	}
+	l.syncService(slice.Namespace, serviceName)
+}
+
+// syncService builds the services of a Kubernetes service from all its
+// endpoint slices and replaces the previous ones if they differ.
+func (l *KubeEndpointSlicesListener) syncService(namespace, name string) {
+	var eps []*KubeEndpointService
+
+	ksvc, err := l.serviceLister.Services(namespace).Get(name)
+	if err != nil {
+		log.Tracef("Cannot get Kubernetes service %s/%s: %s", namespace, name, err)
+	}
+
+	if l.targetAllEndpoints || l.isServiceMonitored(ksvc) {
+		slices, err := l.endpointSliceLister.EndpointSlices(namespace).List(labels.Set{discv1.LabelServiceName: name}.AsSelector())
+		if err != nil {
+			log.Warnf("Cannot list endpoint slices of Kubernetes service %s/%s: %s", namespace, name, err)
+			return
+		}
+
+		tags := []string{}
+		var annotations map[string]string
+		if ksvc != nil {
+			tags = getStandardTags(ksvc.GetLabels())
+			annotations = ksvc.GetAnnotations()
+		}
+
+		eps = processEndpointSlices(namespace, name, annotations, slices, tags, l.filterStore)
+	}
+
+	key := namespace + "/" + name
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	previous, found := l.endpoints[key]
+	if found && endpointServicesEqual(previous, eps) {
+		return
+	}
+
+	l.removeServicesLocked(key, previous, found)
+	if len(eps) == 0 {
+		return
+	}
+	l.endpoints[key] = eps
+
+	telemetryStorePresent := l.telemetryStore != nil
+	if telemetryStorePresent {
+		l.telemetryStore.WatchedResources.Inc(kubeEndpointsName, telemetry.ResourceKubeService)
+	}
+
+	for _, ep := range eps {
+		log.Debugf("Creating a new AD service: %s", ep.entity)
+		l.newService <- ep
+		if telemetryStorePresent {
+			l.telemetryStore.WatchedResources.Inc(kubeEndpointsName, telemetry.ResourceKubeEndpoint)
+		}
+	}
+}
+
+func (l *KubeEndpointSlicesListener) removeServicesLocked(key string, eps []*KubeEndpointService, found bool) {
+	if !found {
+		return
+	}
+	delete(l.endpoints, key)
+
+	telemetryStorePresent := l.telemetryStore != nil
+	if telemetryStorePresent {
+		l.telemetryStore.WatchedResources.Dec(kubeEndpointsName, telemetry.ResourceKubeService)
+	}
+
+	for _, ep := range eps {
+		log.Debugf("Deleting AD service: %s", ep.entity)
+		l.delService <- ep
+		if telemetryStorePresent {
+			l.telemetryStore.WatchedResources.Dec(kubeEndpointsName, telemetry.ResourceKubeEndpoint)
+		}
+	}
+}
+
+// isServiceMonitored returns true if the service has endpoints annotations.
+func (l *KubeEndpointSlicesListener) isServiceMonitored(ksvc *v1.Service) bool {
+	if ksvc == nil {
+		return false
+	}
+	return isServiceAnnotated(ksvc, kubeEndpointsID) || l.promInclAnnot.IsMatchingAnnotations(ksvc.GetAnnotations())
+}
+
+// processEndpointSlices parses the endpoint slices of a Kubernetes service and
+// returns a KubeEndpointService per ready address. The services are the same
+// as the ones processEndpoints creates from the Endpoints object.
+func processEndpointSlices(namespace, name string, annotations map[string]string, slices []*discv1.EndpointSlice, tags []string, filterStore workloadfilter.Component) []*KubeEndpointService {
+	var eps []*KubeEndpointService
+
+	filterableEndpoint := workloadfilter.CreateEndpoint(name, namespace, annotations)
+	metricsExcluded := filterStore.GetEndpointAutodiscoveryFilters(workloadfilter.MetricsFilter).IsExcluded(filterableEndpoint)
+	globalExcluded := filterStore.GetEndpointAutodiscoveryFilters(workloadfilter.GlobalFilter).IsExcluded(filterableEndpoint)
+
+	// An address can briefly be part of two slices while it is moved
+	seen := make(map[string]struct{})
+
+	for _, slice := range slices {
+		if slice.AddressType == discv1.AddressTypeFQDN {
+			continue
+		}
+
+		ports := []ContainerPort{}
+		for _, port := range slice.Ports {
+			if port.Port == nil {
+				continue
+			}
+			var portName string
+			if port.Name != nil {
+				portName = *port.Name
+			}
+			ports = append(ports, ContainerPort{int(*port.Port), portName})
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			// Same as the addresses of the Endpoints object, not ready
+			// endpoints are ignored
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			for _, ip := range endpoint.Addresses {
+				if _, found := seen[ip]; found {
+					continue
+				}
+				seen[ip] = struct{}{}
+
+				// create a separate AD service per host
+				ep := &KubeEndpointService{
+					entity:   apiserver.EntityForEndpoints(namespace, name, ip),
+					metadata: filterableEndpoint,
+					hosts:    map[string]string{"endpoint": ip},
+					ports:    ports,
+					tags: []string{
+						fmt.Sprintf("kube_service:%s", name),
+						fmt.Sprintf("kube_namespace:%s", namespace),
+						fmt.Sprintf("kube_endpoint_ip:%s", ip),
+					},
+					metricsExcluded: metricsExcluded,
+					globalExcluded:  globalExcluded,
+					namespace:       namespace,
+				}
+				ep.tags = append(ep.tags, tags...)
+				eps = append(eps, ep)
+			}
+		}
+	}
+	return eps
+}
+
+// endpointServicesEqual returns whether two lists of services are equal,
+// regardless of their order.
+func endpointServicesEqual(first, second []*KubeEndpointService) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	byEntity := make(map[string]*KubeEndpointService, len(first))
+	for _, ep := range first {
+		byEntity[ep.entity] = ep
+	}
+	for _, ep := range second {
+		other, found := byEntity[ep.entity]
+		if !found || !other.Equal(ep) || other.metricsExcluded != ep.metricsExcluded || other.globalExcluded != ep.globalExcluded {
+			return false
+		}
+	}
+	return true
+}
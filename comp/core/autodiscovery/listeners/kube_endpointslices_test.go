@@ -0,0 +1,177 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build clusterchecks && kubeapiserver
+
+package listeners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	discv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listv1 "k8s.io/client-go/listers/core/v1"
+	disclistv1 "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	workloadfilterfxmock "github.com/DataDog/datadog-agent/comp/core/workloadfilter/fx-mock"
+)
+
+func newEndpointSlice(name string, addressType discv1.AddressType, ready map[string]bool, port int32) *discv1.EndpointSlice {
+	slice := &discv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Labels:          map[string]string{discv1.LabelServiceName: "myservice"},
+		},
+		AddressType: addressType,
+		Ports: []discv1.EndpointPort{
+			{Name: ptr.To("http"), Port: ptr.To(port)},
+		},
+	}
+	for ip, isReady := range ready {
+		slice.Endpoints = append(slice.Endpoints, discv1.Endpoint{
+			Addresses:  []string{ip},
+			Conditions: discv1.EndpointConditions{Ready: ptr.To(isReady)},
+		})
+	}
+	return slice
+}
+
+func endpointServiceIDs(eps []*KubeEndpointService) []string {
+	var ids []string
+	for _, ep := range eps {
+		ids = append(ids, ep.GetServiceID())
+	}
+	return ids
+}
+
+func TestProcessEndpointSlices(t *testing.T) {
+	slices := []*discv1.EndpointSlice{
+		newEndpointSlice("myservice-abc", discv1.AddressTypeIPv4, map[string]bool{"10.0.0.1": true, "10.0.0.2": false}, 80),
+		newEndpointSlice("myservice-def", discv1.AddressTypeIPv4, map[string]bool{"10.0.0.1": true, "10.0.0.3": true}, 80),
+		newEndpointSlice("myservice-ghi", discv1.AddressTypeFQDN, map[string]bool{"myservice.example.com": true}, 80),
+	}
+
+	eps := processEndpointSlices("default", "myservice", nil, slices, []string{"foo:bar"}, workloadfilterfxmock.SetupMockFilter(t))
+
+	assert.ElementsMatch(t, []string{
+		"kube_endpoint_uid://default/myservice/10.0.0.1",
+		"kube_endpoint_uid://default/myservice/10.0.0.3",
+	}, endpointServiceIDs(eps))
+
+	for _, ep := range eps {
+		ip := ep.hosts["endpoint"]
+
+		ports, err := ep.GetPorts()
+		assert.NoError(t, err)
+		assert.Equal(t, []ContainerPort{{80, "http"}}, ports)
+
+		tags, err := ep.GetTags()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"kube_service:myservice", "kube_namespace:default", "kube_endpoint_ip:" + ip, "foo:bar"}, tags)
+
+		namespace, err := ep.GetExtraConfig("namespace")
+		assert.NoError(t, err)
+		assert.Equal(t, "default", namespace)
+	}
+}
+
+func TestKubeEndpointSlicesListenerSync(t *testing.T) {
+	serviceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	sliceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	newSvc := make(chan Service, 10)
+	delSvc := make(chan Service, 10)
+	l := &KubeEndpointSlicesListener{
+		endpoints:           make(map[string][]*KubeEndpointService),
+		endpointSliceLister: disclistv1.NewEndpointSliceLister(sliceIndexer),
+		serviceLister:       listv1.NewServiceLister(serviceIndexer),
+		newService:          newSvc,
+		delService:          delSvc,
+		filterStore:         workloadfilterfxmock.SetupMockFilter(t),
+	}
+
+	require.NoError(t, serviceIndexer.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myservice",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"ad.datadoghq.com/endpoints.checks": `{"http_check": {"instances": [{}]}}`,
+			},
+		},
+	}))
+	first := newEndpointSlice("myservice-abc", discv1.AddressTypeIPv4, map[string]bool{"10.0.0.1": true}, 80)
+	second := newEndpointSlice("myservice-def", discv1.AddressTypeIPv4, map[string]bool{"10.0.0.2": true}, 80)
+	require.NoError(t, sliceIndexer.Add(first))
+	require.NoError(t, sliceIndexer.Add(second))
+
+	// Services are created from all the slices of the Kubernetes service
+	l.endpointSliceAdded(first)
+	assert.Len(t, newSvc, 2)
+	assert.Len(t, delSvc, 0)
+	drainServices(newSvc)
+
+	// Nothing changes when a slice is processed again
+	l.endpointSliceAdded(second)
+	assert.Len(t, newSvc, 0)
+	assert.Len(t, delSvc, 0)
+
+	// Services are replaced when a slice changes
+	updated := newEndpointSlice("myservice-def", discv1.AddressTypeIPv4, map[string]bool{"10.0.0.2": false}, 80)
+	updated.ResourceVersion = "2"
+	require.NoError(t, sliceIndexer.Update(updated))
+	l.endpointSliceUpdated(second, updated)
+	assert.Len(t, delSvc, 2)
+	assert.Len(t, newSvc, 1)
+	assert.Equal(t, "kube_endpoint_uid://default/myservice/10.0.0.1", (<-newSvc).GetServiceID())
+	drainServices(delSvc)
+
+	// Services are removed with the last slice
+	require.NoError(t, sliceIndexer.Delete(first))
+	require.NoError(t, sliceIndexer.Delete(updated))
+	l.endpointSliceDeleted(cache.DeletedFinalStateUnknown{Key: "default/myservice-abc", Obj: first})
+	assert.Len(t, delSvc, 1)
+	assert.Len(t, newSvc, 0)
+	assert.Empty(t, l.endpoints)
+}
+
+func TestKubeEndpointSlicesListenerIgnoresUnannotatedServices(t *testing.T) {
+	serviceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	sliceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	newSvc := make(chan Service, 10)
+	l := &KubeEndpointSlicesListener{
+		endpoints:           make(map[string][]*KubeEndpointService),
+		endpointSliceLister: disclistv1.NewEndpointSliceLister(sliceIndexer),
+		serviceLister:       listv1.NewServiceLister(serviceIndexer),
+		newService:          newSvc,
+		filterStore:         workloadfilterfxmock.SetupMockFilter(t),
+	}
+
+	require.NoError(t, serviceIndexer.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "myservice", Namespace: "default"},
+	}))
+	slice := newEndpointSlice("myservice-abc", discv1.AddressTypeIPv4, map[string]bool{"10.0.0.1": true}, 80)
+	require.NoError(t, sliceIndexer.Add(slice))
+
+	l.endpointSliceAdded(slice)
+	assert.Len(t, newSvc, 0)
+
+	l.targetAllEndpoints = true
+	l.endpointSliceAdded(slice)
+	assert.Len(t, newSvc, 1)
+}
+
+func drainServices(ch chan Service) {
+	for len(ch) > 0 {
+		<-ch
+	}
+}
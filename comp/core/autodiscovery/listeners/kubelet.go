@@ -33,6 +33,8 @@ type KubeletListener struct {
 	metricsFilter workloadfilter.FilterBundle
 	logsFilter    workloadfilter.FilterBundle
 	tagger        tagger.Component
+	// listenerFilter excludes workloads by namespace and labels.
+	listenerFilter *listenerFilter
 }
 
 // NewKubeletListener returns a new KubeletListener.
@@ -40,10 +42,11 @@ func NewKubeletListener(options ServiceListernerDeps) (ServiceListener, error) {
 	const name = "ad-kubeletlistener"
 
 	l := &KubeletListener{
-		globalFilter:  options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.GlobalFilter),
-		metricsFilter: options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.MetricsFilter),
-		logsFilter:    options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.LogsFilter),
-		tagger:        options.Tagger,
+		globalFilter:   options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.GlobalFilter),
+		metricsFilter:  options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.MetricsFilter),
+		logsFilter:     options.Filter.GetContainerAutodiscoveryFilters(workloadfilter.LogsFilter),
+		tagger:         options.Tagger,
+		listenerFilter: newListenerFilter(pkgconfigsetup.Datadog()),
 	}
 	wmetaFilter := workloadmeta.NewFilterBuilder().
 		SetSource(workloadmeta.SourceAll).
@@ -66,6 +69,11 @@ func NewKubeletListener(options ServiceListernerDeps) (ServiceListener, error) {
 func (l *KubeletListener) processPod(entity workloadmeta.Entity) {
 	pod := entity.(*workloadmeta.KubernetesPod)
 
+	if l.listenerFilter.isExcluded(pod.Namespace, pod.Labels) {
+		log.Debugf("pod %s filtered out: namespace %q", pod.ID, pod.Namespace)
+		return
+	}
+
 	wlmContainers := pod.GetAllContainers()
 	containers := make([]*workloadmeta.Container, 0, len(wlmContainers))
 	for _, podContainer := range wlmContainers {
@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// listenerFilter excludes workloads from the container and kubelet listeners
+// based on their namespace and labels, before any service is created for
+// them. A nil listenerFilter excludes nothing.
+type listenerFilter struct {
+	includeNamespaces []*regexp.Regexp
+	excludeNamespaces []*regexp.Regexp
+	labelSelector     labels.Selector
+}
+
+// newListenerFilter returns the filter configured with the
+// ad_listeners_include_namespaces, ad_listeners_exclude_namespaces and
+// ad_listeners_label_selector options, or nil if none is set. Invalid
+// patterns are ignored.
+func newListenerFilter(cfg pkgconfigmodel.Reader) *listenerFilter {
+	f := &listenerFilter{
+		includeNamespaces: compileNamespacePatterns(cfg.GetStringSlice("ad_listeners_include_namespaces")),
+		excludeNamespaces: compileNamespacePatterns(cfg.GetStringSlice("ad_listeners_exclude_namespaces")),
+	}
+
+	if rawSelector := cfg.GetString("ad_listeners_label_selector"); rawSelector != "" {
+		selector, err := labels.Parse(rawSelector)
+		if err != nil {
+			log.Errorf("Ignoring invalid ad_listeners_label_selector %q: %v", rawSelector, err)
+		} else {
+			f.labelSelector = selector
+		}
+	}
+
+	if len(f.includeNamespaces) == 0 && len(f.excludeNamespaces) == 0 && f.labelSelector == nil {
+		return nil
+	}
+	return f
+}
+
+func compileNamespacePatterns(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Errorf("Ignoring invalid namespace pattern %q: %v", pattern, err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+// isExcluded returns whether a workload must be ignored. Workloads without a
+// namespace are only filtered on their labels.
+func (f *listenerFilter) isExcluded(namespace string, workloadLabels map[string]string) bool {
+	if f == nil {
+		return false
+	}
+
+	if namespace != "" {
+		if matchesAny(f.excludeNamespaces, namespace) {
+			return true
+		}
+		if len(f.includeNamespaces) > 0 && !matchesAny(f.includeNamespaces, namespace) {
+			return true
+		}
+	}
+
+	return f.labelSelector != nil && !f.labelSelector.Matches(labels.Set(workloadLabels))
+}
+
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, re := range patterns {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
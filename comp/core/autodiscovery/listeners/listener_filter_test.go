@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	taggerfxmock "github.com/DataDog/datadog-agent/comp/core/tagger/fx-mock"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
+)
+
+func TestListenerFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  map[string]interface{}
+		namespace string
+		labels    map[string]string
+		excluded  bool
+	}{
+		{
+			name:      "no filter",
+			namespace: "kube-system",
+		},
+		{
+			name:      "excluded namespace",
+			settings:  map[string]interface{}{"ad_listeners_exclude_namespaces": []string{"^kube-"}},
+			namespace: "kube-system",
+			excluded:  true,
+		},
+		{
+			name: "exclusion takes precedence",
+			settings: map[string]interface{}{
+				"ad_listeners_include_namespaces": []string{".*"},
+				"ad_listeners_exclude_namespaces": []string{"^kube-system$"},
+			},
+			namespace: "kube-system",
+			excluded:  true,
+		},
+		{
+			name:      "included namespace",
+			settings:  map[string]interface{}{"ad_listeners_include_namespaces": []string{"^team-"}},
+			namespace: "team-a",
+		},
+		{
+			name:      "namespace not included",
+			settings:  map[string]interface{}{"ad_listeners_include_namespaces": []string{"^team-"}},
+			namespace: "default",
+			excluded:  true,
+		},
+		{
+			name:     "no namespace",
+			settings: map[string]interface{}{"ad_listeners_include_namespaces": []string{"^team-"}},
+		},
+		{
+			name:     "matching labels",
+			settings: map[string]interface{}{"ad_listeners_label_selector": "tier=backend,app!=debug"},
+			labels:   map[string]string{"tier": "backend", "app": "redis"},
+		},
+		{
+			name:     "labels not matching",
+			settings: map[string]interface{}{"ad_listeners_label_selector": "tier=backend,app!=debug"},
+			labels:   map[string]string{"tier": "backend", "app": "debug"},
+			excluded: true,
+		},
+		{
+			name:     "invalid patterns are ignored",
+			settings: map[string]interface{}{"ad_listeners_exclude_namespaces": []string{"("}, "ad_listeners_label_selector": "=="},
+			labels:   map[string]string{"app": "redis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := configmock.New(t)
+			for k, v := range tt.settings {
+				cfg.SetWithoutSource(k, v)
+			}
+
+			assert.Equal(t, tt.excluded, newListenerFilter(cfg).isExcluded(tt.namespace, tt.labels))
+		})
+	}
+}
+
+func TestNewListenerFilterUnset(t *testing.T) {
+	assert.Nil(t, newListenerFilter(configmock.New(t)))
+}
+
+func TestKubeletListenerFilter(t *testing.T) {
+	cfg := configmock.New(t)
+	cfg.SetWithoutSource("ad_listeners_exclude_namespaces", []string{"^" + podNamespace + "$"})
+
+	listener, wlm := newKubeletListener(t, taggerfxmock.SetupFakeTagger(t))
+	listener.listenerFilter = newListenerFilter(cfg)
+	require.NotNil(t, listener.listenerFilter)
+
+	listener.processPod(&workloadmeta.KubernetesPod{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesPod,
+			ID:   podID,
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      podName,
+			Namespace: podNamespace,
+		},
+		IP: "127.0.0.1",
+	})
+
+	assert.Empty(t, wlm.services)
+}
@@ -9,10 +9,12 @@ package listeners
 const (
 	cloudFoundryBBSListenerName = "cloudfoundry-bbs"
 	containerListenerName       = "container"
+	ecsFargateListenerName      = "ecs_fargate"
 	environmentListenerName     = "environment"
 	kubeEndpointsListenerName   = "kube_endpoints"
 	kubeServicesListenerName    = "kube_services"
 	kubeletListenerName         = "kubelet"
+	processListenerName         = "process"
 	snmpListenerName            = "snmp"
 	staticConfigListenerName    = "static config"
 	dbmAuroraListenerName       = "database-monitoring-aurora"
@@ -24,10 +26,12 @@ func RegisterListeners(serviceListenerFactories map[string]ServiceListenerFactor
 	// register the available listeners
 	Register(cloudFoundryBBSListenerName, NewCloudFoundryListener, serviceListenerFactories)
 	Register(containerListenerName, NewContainerListener, serviceListenerFactories)
+	Register(ecsFargateListenerName, NewECSFargateListener, serviceListenerFactories)
 	Register(environmentListenerName, NewEnvironmentListener, serviceListenerFactories)
 	Register(kubeEndpointsListenerName, NewKubeEndpointsListener, serviceListenerFactories)
 	Register(kubeServicesListenerName, NewKubeServiceListener, serviceListenerFactories)
 	Register(kubeletListenerName, NewKubeletListener, serviceListenerFactories)
+	Register(processListenerName, NewProcessListener, serviceListenerFactories)
 	Register(snmpListenerName, NewSNMPListener, serviceListenerFactories)
 	Register(staticConfigListenerName, NewStaticConfigListener, serviceListenerFactories)
 	Register(dbmAuroraListenerName, NewDBMAuroraListener, serviceListenerFactories)
@@ -0,0 +1,115 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strconv"
+
+	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+// processHost is the host of the services of host processes, the checks
+// scheduled on them run on the same host.
+const processHost = "127.0.0.1"
+
+// ProcessListener creates a service for each host process listening on a TCP
+// or UDP port, so that templates can be scheduled on processes that do not run
+// in a container. The listening ports are detected by the service discovery
+// of the process collector of workloadmeta.
+type ProcessListener struct {
+	workloadmetaListener
+	tagger tagger.Component
+}
+
+// NewProcessListener returns a new ProcessListener.
+func NewProcessListener(options ServiceListernerDeps) (ServiceListener, error) {
+	const name = "ad-processlistener"
+	l := &ProcessListener{
+		tagger: options.Tagger,
+	}
+	filter := workloadmeta.NewFilterBuilder().
+		AddKindWithEntityFilter(workloadmeta.KindProcess, func(e workloadmeta.Entity) bool {
+			process, ok := e.(*workloadmeta.Process)
+			if !ok {
+				return false
+			}
+
+			// Processes running in containers are covered by the container
+			// listener.
+			return process.ContainerID == "" && process.Service != nil &&
+				(len(process.Service.TCPPorts) > 0 || len(process.Service.UDPPorts) > 0)
+		}).
+		Build()
+
+	wmetaInstance, ok := options.Wmeta.Get()
+	if !ok {
+		return nil, errors.New("workloadmeta store is not initialized")
+	}
+	var err error
+	l.workloadmetaListener, err = newWorkloadmetaListener(name, filter, l.createProcessService, wmetaInstance, options.Telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *ProcessListener) createProcessService(entity workloadmeta.Entity) {
+	process := entity.(*workloadmeta.Process)
+
+	svc := &WorkloadService{
+		entity:        process,
+		tagsHash:      l.tagger.GetEntityHash(types.NewEntityID(types.Process, process.ID), types.ChecksConfigCardinality),
+		adIdentifiers: computeProcessServiceIDs(process),
+		hosts:         map[string]string{"host": processHost},
+		ports:         processPorts(process.Service),
+		pid:           int(process.Pid),
+		ready:         true,
+		tagger:        l.tagger,
+		wmeta:         l.Store(),
+	}
+
+	l.AddService(buildSvcID(process.GetID()), svc, "")
+}
+
+// computeProcessServiceIDs returns the AD identifiers of a process: its name,
+// like "nginx", and the name of the service generated by the service
+// discovery if it is different.
+func computeProcessServiceIDs(process *workloadmeta.Process) []string {
+	var ids []string
+	for _, id := range []string{process.Name, process.Comm, process.Service.GeneratedName} {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// processPorts returns the ports a process listens on, sorted like the ports
+// of containers. The ports are named after their protocol and number, like
+// "tcp_8080", as processes do not name their ports.
+func processPorts(service *workloadmeta.Service) []ContainerPort {
+	ports := make([]ContainerPort, 0, len(service.TCPPorts)+len(service.UDPPorts))
+	for _, port := range service.TCPPorts {
+		ports = append(ports, ContainerPort{Port: int(port), Name: "tcp_" + strconv.Itoa(int(port))})
+	}
+	for _, port := range service.UDPPorts {
+		ports = append(ports, ContainerPort{Port: int(port), Name: "udp_" + strconv.Itoa(int(port))})
+	}
+
+	sort.SliceStable(ports, func(i, j int) bool {
+		return ports[i].Port < ports[j].Port
+	})
+
+	return ports
+}
@@ -3,4 +3,8 @@
 // This product includes software developed at Datadog (https://www.datadoghq.com/).
 // Copyright 2016-present Datadog, Inc.
 
-package ecsfargate
+//go:build serverless
+
+package listeners
+
+var NewProcessListener func(ServiceListernerDeps) (ServiceListener, error)
@@ -0,0 +1,84 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !serverless
+
+package listeners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	taggerfxmock "github.com/DataDog/datadog-agent/comp/core/tagger/fx-mock"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+func TestCreateProcessService(t *testing.T) {
+	listener, wlm := newProcessListener(t, taggerfxmock.SetupFakeTagger(t))
+
+	process := &workloadmeta.Process{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindProcess,
+			ID:   "1234",
+		},
+		Pid:  1234,
+		Name: "nginx",
+		Comm: "nginx",
+		Service: &workloadmeta.Service{
+			GeneratedName: "web",
+			TCPPorts:      []uint16{8080, 80},
+			UDPPorts:      []uint16{443},
+		},
+	}
+
+	listener.createProcessService(process)
+
+	require.Contains(t, wlm.services, "process://1234")
+	svc := wlm.services["process://1234"].service
+
+	assert.Equal(t, "process://1234", svc.GetServiceID())
+	assert.Equal(t, []string{"nginx", "web"}, svc.GetADIdentifiers())
+
+	hosts, err := svc.GetHosts()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"host": "127.0.0.1"}, hosts)
+
+	ports, err := svc.GetPorts()
+	assert.NoError(t, err)
+	assert.Equal(t, []ContainerPort{
+		{Port: 80, Name: "tcp_80"},
+		{Port: 443, Name: "udp_443"},
+		{Port: 8080, Name: "tcp_8080"},
+	}, ports)
+
+	pid, err := svc.GetPid()
+	assert.NoError(t, err)
+	assert.Equal(t, 1234, pid)
+	assert.True(t, svc.IsReady())
+}
+
+func TestComputeProcessServiceIDs(t *testing.T) {
+	assert.Equal(t, []string{"redis-server"}, computeProcessServiceIDs(&workloadmeta.Process{
+		Name:    "redis-server",
+		Comm:    "redis-server",
+		Service: &workloadmeta.Service{GeneratedName: "redis-server"},
+	}))
+	assert.Equal(t, []string{"java", "cassandra"}, computeProcessServiceIDs(&workloadmeta.Process{
+		Name:    "java",
+		Service: &workloadmeta.Service{GeneratedName: "cassandra"},
+	}))
+}
+
+func newProcessListener(t *testing.T, tagger tagger.Component) (*ProcessListener, *testWorkloadmetaListener) {
+	wlm := newTestWorkloadmetaListener(t)
+
+	return &ProcessListener{
+		workloadmetaListener: wlm,
+		tagger:               tagger,
+	}, wlm
+}
@@ -25,7 +25,7 @@ import (
 )
 
 // WorkloadService implements the Service interface and stores data collected from
-// workloadmeta.Store. Covers containers and kubernetes pods.
+// workloadmeta.Store. Covers containers, kubernetes pods and host processes.
 type WorkloadService struct {
 	entity          workloadmeta.Entity
 	tagsHash        string
@@ -71,6 +71,8 @@ func (s *WorkloadService) GetServiceID() string {
 		return containers.BuildEntityName(string(e.Runtime), e.ID)
 	case *workloadmeta.KubernetesPod:
 		return kubelet.PodUIDToEntityName(e.ID)
+	case *workloadmeta.Process:
+		return fmt.Sprintf("%s://%s", workloadmeta.KindProcess, e.ID)
 	default:
 		entityID := s.entity.GetID()
 		log.Errorf("cannot build AD entity ID for kind %q, ID %q", entityID.Kind, entityID.ID)
@@ -59,6 +59,10 @@ func (n *noopAutoConfig) GetAutodiscoveryErrors() map[string]map[string]types.Er
 	return map[string]map[string]types.ErrorMsgSet{}
 }
 
+func (n *noopAutoConfig) GetResolveErrors() []integration.ResolveErrorStats {
+	return []integration.ResolveErrorStats{}
+}
+
 func (n *noopAutoConfig) GetProviderCatalog() map[string]types.ConfigProviderFactory {
 	return map[string]types.ConfigProviderFactory{}
 }
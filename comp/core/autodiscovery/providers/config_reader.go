@@ -20,6 +20,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/configresolver"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	configUtils "github.com/DataDog/datadog-agent/pkg/config/utils"
 	"github.com/DataDog/datadog-agent/pkg/util/fargate"
@@ -160,6 +161,18 @@ func ReadConfigFiles(keep FilterFunc) ([]integration.Config, map[string]string,
 	return filterConfigs(configs, keep), errs, nil
 }
 
+// resetConfigFilesCache drops the cached configs so that the next call to
+// ReadConfigFiles reads the files again.
+func resetConfigFilesCache() {
+	if reader == nil {
+		return
+	}
+
+	reader.Lock()
+	defer reader.Unlock()
+	reader.cache.Flush()
+}
+
 // ReadConfigFormats returns the config formats read from config files
 func ReadConfigFormats() []ConfigFormatWrapper {
 	if reader == nil {
@@ -428,6 +441,10 @@ func GetIntegrationConfigFromFile(name, fpath string) (integration.Config, Confi
 		log.Warnf("reading config file %v: %v\n", fpath, strictErr)
 	}
 
+	if pkgconfigsetup.Datadog().GetBool(pkgconfigmodel.EnvVarExpansionSetting) {
+		expandEnvVars(&cf)
+	}
+
 	serializedConfigFormat, err := yaml.Marshal(cf)
 	if err != nil {
 		return conf, ConfigFormatWrapper{}, err
@@ -515,6 +532,19 @@ func GetIntegrationConfigFromFile(name, fpath string) (integration.Config, Confi
 	return conf, ConfigFormatWrapper{ConfigFormat: scrubbedConfigFormat, Filename: fpath, Hash: hex.EncodeToString(hash[:])}, err
 }
 
+// expandEnvVars expands the ${VAR} and ${VAR:-default} references to
+// environment variables in the values of a check configuration file.
+func expandEnvVars(cf *configFormat) {
+	cf.InitConfig, _ = pkgconfigmodel.ExpandEnvVarsInValue(cf.InitConfig)
+	cf.MetricConfig, _ = pkgconfigmodel.ExpandEnvVarsInValue(cf.MetricConfig)
+	cf.LogsConfig, _ = pkgconfigmodel.ExpandEnvVarsInValue(cf.LogsConfig)
+	for i, instance := range cf.Instances {
+		if expanded, changed := pkgconfigmodel.ExpandEnvVarsInValue(map[interface{}]interface{}(instance)); changed {
+			cf.Instances[i] = integration.RawMap(expanded.(map[interface{}]interface{}))
+		}
+	}
+}
+
 func containsString(slice []string, str string) bool {
 	return slices.Contains(slice, str)
 }
@@ -121,6 +121,44 @@ func TestGetIntegrationConfig(t *testing.T) {
 	assert.Empty(t, config.ServiceID)
 }
 
+func TestGetIntegrationConfigExpandsEnvVars(t *testing.T) {
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("config_env_var_expansion", true)
+	t.Setenv("TEST_CHECK_HOST", "localhost")
+	fpath := path.Join(t.TempDir(), "conf.yaml")
+	content := `init_config:
+  region: ${TEST_CHECK_REGION:-us1}
+instances:
+  - url: http://${TEST_CHECK_HOST}:8080
+    pattern: $${literal}
+    token: ${TEST_CHECK_UNSET}
+logs:
+  - type: file
+    path: /var/log/${TEST_CHECK_HOST}.log
+`
+	require.NoError(t, os.WriteFile(fpath, []byte(content), 0600))
+
+	config, configFormat, err := GetIntegrationConfigFromFile("foo", fpath)
+	require.NoError(t, err)
+	assert.Equal(t, "region: us1\n", string(config.InitConfig))
+	require.Len(t, config.Instances, 1)
+	assert.Equal(t, "pattern: ${literal}\ntoken: ${TEST_CHECK_UNSET}\nurl: http://localhost:8080\n", string(config.Instances[0]))
+	assert.Contains(t, string(config.LogsConfig), "path: /var/log/localhost.log")
+	assert.Contains(t, configFormat.ConfigFormat, "url: http://localhost:8080")
+}
+
+func TestGetIntegrationConfigEnvVarsDisabled(t *testing.T) {
+	configmock.New(t)
+	t.Setenv("TEST_CHECK_HOST", "localhost")
+	fpath := path.Join(t.TempDir(), "conf.yaml")
+	require.NoError(t, os.WriteFile(fpath, []byte("init_config:\ninstances:\n  - url: http://${TEST_CHECK_HOST}:8080\n"), 0600))
+
+	config, _, err := GetIntegrationConfigFromFile("foo", fpath)
+	require.NoError(t, err)
+	require.Len(t, config.Instances, 1)
+	assert.Equal(t, "url: http://${TEST_CHECK_HOST}:8080\n", string(config.Instances[0]))
+}
+
 func TestReadConfigFiles(t *testing.T) {
 	paths := []string{"tests"}
 	ResetReader(paths)
@@ -7,17 +7,21 @@ package providers
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/types"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/telemetry"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 )
 
 // FileConfigProvider collect configuration files from disk
 type FileConfigProvider struct {
 	Errors         map[string]string
 	telemetryStore *telemetry.Store
+	// watching is true while the configuration files are watched for changes.
+	watching atomic.Bool
 }
 
 // NewFileConfigProvider creates a new FileConfigProvider.
@@ -45,7 +49,12 @@ func (c *FileConfigProvider) Collect(_ context.Context) ([]integration.Config, e
 }
 
 // IsUpToDate is not implemented for the file Providers as the files are not meant to change very often.
+// While the files are watched, changes are notified by Watch instead, and the files are only read again
+// on polls if autoconf_config_files_poll is enabled.
 func (c *FileConfigProvider) IsUpToDate(_ context.Context) (bool, error) {
+	if c.watching.Load() && !pkgconfigsetup.Datadog().GetBool("autoconf_config_files_poll") {
+		return true, nil
+	}
 	return false, nil
 }
 
@@ -0,0 +1,170 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// fileWatchDebounce is the delay without any change on disk after which the
+// configuration files are reloaded, so that editing several files, or a
+// file in several writes, results in a single reload.
+var fileWatchDebounce = 2 * time.Second
+
+// Watch notifies the changes of the configuration files when
+// autoconf_config_files_watch is enabled. It returns a nil channel if the
+// files are not watched.
+func (c *FileConfigProvider) Watch(ctx context.Context) <-chan struct{} {
+	if !pkgconfigsetup.Datadog().GetBool("autoconf_config_files_watch") || reader == nil {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Warnf("Unable to watch the configuration files, falling back to polling: %v", err)
+		return nil
+	}
+
+	for _, path := range reader.paths {
+		addConfigDirWatches(watcher, path)
+	}
+
+	ch := make(chan struct{}, 1)
+	c.watching.Store(true)
+	go c.watch(ctx, watcher, ch)
+
+	return ch
+}
+
+func (c *FileConfigProvider) watch(ctx context.Context, watcher *fsnotify.Watcher, ch chan<- struct{}) {
+	defer func() {
+		c.watching.Store(false)
+		if err := watcher.Close(); err != nil {
+			log.Debugf("Error closing the configuration files watcher: %v", err)
+		}
+	}()
+
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !isConfigFileEvent(event) {
+				continue
+			}
+			log.Debugf("Configuration file changed: %s", event)
+
+			// Checks are configured from one level of subdirectories, which
+			// can be created after the watch started.
+			if event.Has(fsnotify.Create) && isConfigSearchPath(filepath.Dir(event.Name)) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watcher.Add(event.Name); err != nil {
+						log.Warnf("Unable to watch configuration directory %s: %v", event.Name, err)
+					}
+				}
+			}
+
+			if debounce == nil {
+				debounce = time.NewTimer(fileWatchDebounce)
+			} else {
+				debounce.Reset(fileWatchDebounce)
+			}
+			debounceC = debounce.C
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Warnf("Error watching the configuration files: %v", err)
+		case <-debounceC:
+			debounceC = nil
+			log.Infof("Reloading the configuration files after a change on disk")
+			resetConfigFilesCache()
+			if c.telemetryStore != nil {
+				c.telemetryStore.FileReloads.Inc()
+			}
+			select {
+			case ch <- struct{}{}:
+			default:
+				// A reload is already pending.
+			}
+		}
+	}
+}
+
+// addConfigDirWatches watches a configuration search path and its
+// subdirectories.
+func addConfigDirWatches(watcher *fsnotify.Watcher, path string) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		log.Debugf("Not watching configuration path %s: %v", path, err)
+		return
+	}
+
+	if err := watcher.Add(path); err != nil {
+		log.Warnf("Unable to watch configuration path %s: %v", path, err)
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(path, entry.Name())
+		if err := watcher.Add(dir); err != nil {
+			log.Warnf("Unable to watch configuration directory %s: %v", dir, err)
+		}
+	}
+}
+
+// isConfigSearchPath returns whether path is one of the configuration search
+// paths, as opposed to one of their subdirectories.
+func isConfigSearchPath(path string) bool {
+	for _, root := range reader.paths {
+		if filepath.Clean(root) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfigFileEvent filters out the changes of files that are not
+// configuration files, like the temporary files of editors.
+func isConfigFileEvent(event fsnotify.Event) bool {
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+		return false
+	}
+
+	switch filepath.Ext(event.Name) {
+	case ".yaml", ".yml", ".default":
+		return true
+	case "":
+		// Directories usually have no extension. They cannot be checked once
+		// removed, so any removal is considered.
+		if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+			return true
+		}
+		info, err := os.Stat(event.Name)
+		return err == nil && info.IsDir()
+	case ".d":
+		return true
+	default:
+		return false
+	}
+}
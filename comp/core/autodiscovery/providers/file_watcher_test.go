@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/config/mock"
+)
+
+func TestFileConfigProviderWatch(t *testing.T) {
+	cfg := mock.New(t)
+	cfg.SetWithoutSource("autoconf_config_files_watch", true)
+
+	defer func(d time.Duration) { fileWatchDebounce = d }(fileWatchDebounce)
+	fileWatchDebounce = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.yaml"), []byte("instances:\n  - {}\n"), 0644))
+	ResetReader([]string{dir})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	provider := NewFileConfigProvider(nil)
+	configs, err := provider.Collect(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, configNames(configs))
+
+	changes := provider.Watch(ctx)
+	require.NotNil(t, changes)
+	upToDate, err := provider.IsUpToDate(ctx)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "bar.d"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bar.d", "conf.yaml"), []byte("instances:\n  - {}\n"), 0644))
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "configuration files change not notified")
+	}
+
+	configs, err = provider.Collect(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"foo", "bar"}, configNames(configs))
+
+	cancel()
+	assert.Eventually(t, func() bool { return !provider.watching.Load() }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileConfigProviderWatchDisabled(t *testing.T) {
+	mock.New(t)
+	ResetReader([]string{t.TempDir()})
+
+	provider := NewFileConfigProvider(nil)
+	assert.Nil(t, provider.Watch(context.Background()))
+
+	upToDate, err := provider.IsUpToDate(context.Background())
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+}
+
+func TestIsConfigFileEvent(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.True(t, isConfigFileEvent(fsnotify.Event{Name: "conf.d/redisdb.d/conf.yaml", Op: fsnotify.Write}))
+	assert.True(t, isConfigFileEvent(fsnotify.Event{Name: "conf.d/redisdb.d/conf.yaml.default", Op: fsnotify.Remove}))
+	assert.True(t, isConfigFileEvent(fsnotify.Event{Name: "conf.d/redisdb.d", Op: fsnotify.Create}))
+	assert.True(t, isConfigFileEvent(fsnotify.Event{Name: dir, Op: fsnotify.Create}))
+	assert.False(t, isConfigFileEvent(fsnotify.Event{Name: "conf.d/redisdb.d/.conf.yaml.swp", Op: fsnotify.Write}))
+	assert.False(t, isConfigFileEvent(fsnotify.Event{Name: "conf.d/redisdb.d/conf.yaml", Op: fsnotify.Chmod}))
+}
+
+func configNames(configs []integration.Config) []string {
+	var names []string
+	for _, c := range configs {
+		names = append(names, c.Name)
+	}
+	return names
+}
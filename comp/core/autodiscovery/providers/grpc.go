@@ -0,0 +1,306 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/proto"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/names"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers/types"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/telemetry"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+	grpcutil "github.com/DataDog/datadog-agent/pkg/util/grpc"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const defaultGRPCGraceTime = time.Minute
+
+// GRPCConfigProvider streams templates from an external process serving the
+// AutodiscoveryStreamConfig RPC of the datadog.api.v1.AgentSecure gRPC
+// service, the same RPC the Agent serves to stream its own configs.
+//
+// The templates received are kept when the connection is lost. Once the
+// stream is established again, the templates that are not sent again by the
+// server within the grace time are unscheduled.
+type GRPCConfigProvider struct {
+	address   string
+	conn      *grpc.ClientConn
+	client    pb.AgentSecureClient
+	health    healthpb.HealthClient
+	graceTime time.Duration
+
+	telemetryStore *telemetry.Store
+
+	errMu     sync.RWMutex
+	streamErr error
+
+	// configs are the scheduled configs, indexed by digest. They are only
+	// accessed by the streaming goroutine.
+	configs     map[string]integration.Config
+	initialized bool
+}
+
+// NewGRPCConfigProvider creates a client connection to the gRPC server at
+// template_url and returns a new GRPCConfigProvider.
+func NewGRPCConfigProvider(providerConfig *pkgconfigsetup.ConfigurationProviders, telemetryStore *telemetry.Store) (types.ConfigProvider, error) {
+	if providerConfig == nil || providerConfig.TemplateURL == "" {
+		return nil, errors.New("template_url is required by the grpc config provider")
+	}
+
+	opts, err := grpcDialOptions(providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(providerConfig.TemplateURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the gRPC client for %s: %w", providerConfig.TemplateURL, err)
+	}
+
+	graceTime := defaultGRPCGraceTime
+	if providerConfig.GraceTimeSeconds > 0 {
+		graceTime = time.Duration(providerConfig.GraceTimeSeconds) * time.Second
+	}
+
+	return &GRPCConfigProvider{
+		address:        providerConfig.TemplateURL,
+		conn:           conn,
+		client:         pb.NewAgentSecureClient(conn),
+		health:         healthpb.NewHealthClient(conn),
+		graceTime:      graceTime,
+		telemetryStore: telemetryStore,
+		configs:        make(map[string]integration.Config),
+	}, nil
+}
+
+// grpcDialOptions returns the options of the connection to the server. TLS
+// is used as soon as a CA, a client certificate or a token is configured.
+func grpcDialOptions(providerConfig *pkgconfigsetup.ConfigurationProviders) ([]grpc.DialOption, error) {
+	if providerConfig.CAFile == "" && providerConfig.CertFile == "" && providerConfig.Token == "" {
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if providerConfig.CAFile != "" {
+		ca, err := os.ReadFile(providerConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in ca_file %s", providerConfig.CAFile)
+		}
+	}
+	if providerConfig.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(providerConfig.CertFile, providerConfig.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if providerConfig.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(grpcutil.NewBearerTokenAuth(providerConfig.Token)))
+	}
+	return opts, nil
+}
+
+// String returns a string representation of the GRPCConfigProvider
+func (p *GRPCConfigProvider) String() string {
+	return names.GRPC
+}
+
+// Stream streams the templates of the server until the context is cancelled.
+func (p *GRPCConfigProvider) Stream(ctx context.Context) <-chan integration.ConfigChanges {
+	ch := make(chan integration.ConfigChanges)
+	go p.run(ctx, ch)
+	return ch
+}
+
+func (p *GRPCConfigProvider) run(ctx context.Context, ch chan<- integration.ConfigChanges) {
+	defer p.conn.Close()
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = 500 * time.Millisecond
+	expBackoff.MaxInterval = time.Minute
+	expBackoff.MaxElapsedTime = 0
+
+	for {
+		err := p.stream(ctx, ch, expBackoff.Reset)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warnf("Error streaming templates from %s, retrying: %v", p.address, err)
+		p.setStreamErr(err)
+
+		// The config poller waits for the first changes of the provider, do
+		// not block it while the server is unreachable.
+		if !p.initialized && !p.send(ctx, ch, integration.ConfigChanges{}) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(expBackoff.NextBackOff()):
+		}
+	}
+}
+
+// stream opens a stream to the server and processes its responses until the
+// stream is interrupted.
+func (p *GRPCConfigProvider) stream(ctx context.Context, ch chan<- integration.ConfigChanges, connected func()) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resp, err := p.health.Check(streamCtx, &healthpb.HealthCheckRequest{})
+	if err == nil && resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("server is %s", resp.GetStatus())
+	}
+	// The health service is optional.
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+
+	stream, err := p.client.AutodiscoveryStreamConfig(streamCtx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+
+	responses := make(chan *pb.AutodiscoveryStreamResponse)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case responses <- resp:
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// The templates scheduled before the stream was established are stale
+	// until the server sends them again.
+	stale := make(map[string]struct{}, len(p.configs))
+	for digest := range p.configs {
+		stale[digest] = struct{}{}
+	}
+	grace := time.NewTimer(p.graceTime)
+	defer grace.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			return err
+		case resp := <-responses:
+			connected()
+			p.setStreamErr(nil)
+			changes := p.processResponse(resp, stale)
+			if (!changes.IsEmpty() || !p.initialized) && !p.send(ctx, ch, changes) {
+				return ctx.Err()
+			}
+		case <-grace.C:
+			var changes integration.ConfigChanges
+			for digest := range stale {
+				changes.UnscheduleConfig(p.configs[digest])
+				delete(p.configs, digest)
+			}
+			clear(stale)
+			if !changes.IsEmpty() && !p.send(ctx, ch, changes) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// processResponse returns the changes of a response of the server.
+func (p *GRPCConfigProvider) processResponse(resp *pb.AutodiscoveryStreamResponse, stale map[string]struct{}) integration.ConfigChanges {
+	var changes integration.ConfigChanges
+	for _, protobufConfig := range resp.GetConfigs() {
+		config := proto.AutodiscoveryConfigFromProtobufConfig(protobufConfig)
+		digest := config.Digest()
+
+		switch protobufConfig.GetEventType() {
+		case pb.ConfigEventType_SCHEDULE:
+			delete(stale, digest)
+			if _, found := p.configs[digest]; found {
+				continue
+			}
+			p.configs[digest] = config
+			changes.ScheduleConfig(config)
+		case pb.ConfigEventType_UNSCHEDULE:
+			delete(stale, digest)
+			if scheduled, found := p.configs[digest]; found {
+				delete(p.configs, digest)
+				changes.UnscheduleConfig(scheduled)
+			}
+		}
+	}
+	return changes
+}
+
+func (p *GRPCConfigProvider) send(ctx context.Context, ch chan<- integration.ConfigChanges, changes integration.ConfigChanges) bool {
+	select {
+	case ch <- changes:
+		p.initialized = true
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *GRPCConfigProvider) setStreamErr(err error) {
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+
+	p.streamErr = err
+	if p.telemetryStore != nil {
+		var count float64
+		if err != nil {
+			count = 1
+		}
+		p.telemetryStore.Errors.Set(count, names.GRPC)
+	}
+}
+
+// GetConfigErrors returns the error of the last attempt to stream the
+// templates, if any.
+func (p *GRPCConfigProvider) GetConfigErrors() map[string]types.ErrorMsgSet {
+	p.errMu.RLock()
+	defer p.errMu.RUnlock()
+
+	errs := make(map[string]types.ErrorMsgSet)
+	if p.streamErr != nil {
+		errs[p.address] = types.ErrorMsgSet{p.streamErr.Error(): struct{}{}}
+	}
+	return errs
+}
@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+type fakeConfigServer struct {
+	pb.UnimplementedAgentSecureServer
+	responses chan *pb.AutodiscoveryStreamResponse
+}
+
+func (s *fakeConfigServer) AutodiscoveryStreamConfig(_ *emptypb.Empty, out pb.AgentSecure_AutodiscoveryStreamConfigServer) error {
+	for {
+		select {
+		case resp := <-s.responses:
+			if resp == nil {
+				// Interrupt the stream
+				return nil
+			}
+			if err := out.Send(resp); err != nil {
+				return err
+			}
+		case <-out.Context().Done():
+			return nil
+		}
+	}
+}
+
+func startFakeConfigServer(t *testing.T, srv *fakeConfigServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	pb.RegisterAgentSecureServer(server, srv)
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func protobufConfig(name string, eventType pb.ConfigEventType) *pb.Config {
+	return &pb.Config{
+		Name:      name,
+		Instances: [][]byte{[]byte("foo: bar")},
+		EventType: eventType,
+	}
+}
+
+func receiveChanges(t *testing.T, ch <-chan integration.ConfigChanges) integration.ConfigChanges {
+	select {
+	case changes := <-ch:
+		return changes
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no config changes received")
+		return integration.ConfigChanges{}
+	}
+}
+
+func changedNames(configs []integration.Config) []string {
+	var names []string
+	for _, c := range configs {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestGRPCConfigProviderStream(t *testing.T) {
+	srv := &fakeConfigServer{responses: make(chan *pb.AutodiscoveryStreamResponse)}
+	address := startFakeConfigServer(t, srv)
+
+	provider, err := NewGRPCConfigProvider(&pkgconfigsetup.ConfigurationProviders{
+		TemplateURL:      address,
+		GraceTimeSeconds: 1,
+	}, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := provider.(*GRPCConfigProvider).Stream(ctx)
+
+	srv.responses <- &pb.AutodiscoveryStreamResponse{Configs: []*pb.Config{
+		protobufConfig("redisdb", pb.ConfigEventType_SCHEDULE),
+		protobufConfig("nginx", pb.ConfigEventType_SCHEDULE),
+	}}
+	changes := receiveChanges(t, ch)
+	assert.ElementsMatch(t, []string{"redisdb", "nginx"}, changedNames(changes.Schedule))
+	assert.Empty(t, changes.Unschedule)
+
+	srv.responses <- &pb.AutodiscoveryStreamResponse{Configs: []*pb.Config{
+		protobufConfig("nginx", pb.ConfigEventType_UNSCHEDULE),
+	}}
+	changes = receiveChanges(t, ch)
+	assert.Empty(t, changes.Schedule)
+	assert.Equal(t, []string{"nginx"}, changedNames(changes.Unschedule))
+
+	// The templates are kept while the stream is interrupted, and the ones
+	// that are not sent again are unscheduled after the grace time.
+	srv.responses <- nil
+	srv.responses <- &pb.AutodiscoveryStreamResponse{Configs: []*pb.Config{
+		protobufConfig("postgres", pb.ConfigEventType_SCHEDULE),
+	}}
+	changes = receiveChanges(t, ch)
+	assert.Equal(t, []string{"postgres"}, changedNames(changes.Schedule))
+	assert.Empty(t, changes.Unschedule)
+
+	changes = receiveChanges(t, ch)
+	assert.Empty(t, changes.Schedule)
+	assert.Equal(t, []string{"redisdb"}, changedNames(changes.Unschedule))
+}
+
+func TestGRPCConfigProviderUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	provider, err := NewGRPCConfigProvider(&pkgconfigsetup.ConfigurationProviders{TemplateURL: address}, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first changes are sent even though the server is unreachable, so
+	// that the config poller is not blocked.
+	changes := receiveChanges(t, provider.(*GRPCConfigProvider).Stream(ctx))
+	assert.True(t, changes.IsEmpty())
+	assert.Contains(t, provider.GetConfigErrors(), address)
+}
+
+func TestNewGRPCConfigProviderErrors(t *testing.T) {
+	_, err := NewGRPCConfigProvider(&pkgconfigsetup.ConfigurationProviders{}, nil)
+	assert.EqualError(t, err, "template_url is required by the grpc config provider")
+
+	_, err = NewGRPCConfigProvider(&pkgconfigsetup.ConfigurationProviders{
+		TemplateURL: "localhost:5000",
+		CAFile:      "/does/not/exist",
+	}, nil)
+	assert.ErrorContains(t, err, "unable to read ca_file")
+}
@@ -16,6 +16,7 @@ const (
 	EndpointsChecks         = "endpoints-checks"
 	Etcd                    = "etcd"
 	File                    = "file"
+	GRPC                    = "grpc"
 	KubeContainer           = "kubernetes-container-allinone"
 	Kubernetes              = "kubernetes"
 	KubeServices            = "kubernetes-services"
@@ -41,6 +42,7 @@ const (
 	ClusterChecksRegisterName      = "clusterchecks"
 	EndpointsChecksRegisterName    = "endpointschecks"
 	EtcdRegisterName               = "etcd"
+	GRPCRegisterName               = "grpc"
 	KubeletRegisterName            = "kubelet"
 	KubeContainerRegisterName      = "kubernetes-container-allinone"
 	KubeServicesRegisterName       = "kube_services"
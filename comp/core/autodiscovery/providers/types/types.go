@@ -54,6 +54,16 @@ type CollectingConfigProvider interface {
 	IsUpToDate(context.Context) (bool, error)
 }
 
+// WatchingConfigProvider is an interface optionally implemented by a
+// CollectingConfigProvider that is able to detect changes of its
+// configurations. When polling, the config poller collects the provider as
+// soon as a change is notified instead of waiting for the next poll.
+type WatchingConfigProvider interface {
+	// Watch notifies changes on the returned channel until the provided
+	// context is cancelled. A nil channel means changes are not watched.
+	Watch(context.Context) <-chan struct{}
+}
+
 // StreamingConfigProvider is an interface used together with ConfigProvider.
 // ConfigProviders that are able to use streaming should implement it, and the
 // config poller will use Stream instead of Collect to collect config changes.
@@ -6,6 +6,7 @@
 package scheduler
 
 import (
+	"math/rand/v2"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/common/types"
 	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/status/health"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
@@ -39,6 +41,11 @@ type Controller struct {
 	// a workqueue to process the config events
 	queue workqueue.TypedDelayingInterface[Digest]
 
+	// scheduleJitter is the maximum random delay before a resolved template
+	// is scheduled, so that the checks of services discovered at the same
+	// time, like the containers of a rebooted node, do not all start at once.
+	scheduleJitter time.Duration
+
 	started     bool
 	stopChannel chan struct{}
 
@@ -68,6 +75,7 @@ func NewController() *Controller {
 		queue: workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[Digest]{
 			Name: "ADSchedulerController",
 		}),
+		scheduleJitter:              time.Duration(pkgconfigsetup.Datadog().GetInt("ad_schedule_jitter")) * time.Millisecond,
 		stopChannel:                 make(chan struct{}),
 		configStateStore:            NewConfigStateStore(),
 		healthProbe:                 health.RegisterLiveness("ad-scheduler-controller"),
@@ -142,8 +150,24 @@ func (ms *Controller) Deregister(name string) {
 func (ms *Controller) ApplyChanges(changes integration.ConfigChanges) {
 	//update desired state immediately
 	digests := ms.configStateStore.UpdateDesiredState(changes)
+
+	// resolved templates are scheduled after a random delay, configs are
+	// unscheduled immediately
+	jittered := make(map[Digest]struct{})
+	if ms.scheduleJitter > 0 {
+		for _, config := range changes.Schedule {
+			if config.ServiceID != "" {
+				jittered[Digest(config.FastDigest())] = struct{}{}
+			}
+		}
+	}
+
 	//add digest to workqueue for processing later
 	for _, configDigest := range digests {
+		if _, found := jittered[configDigest]; found {
+			ms.queue.AddAfter(configDigest, rand.N(ms.scheduleJitter))
+			continue
+		}
 		ms.queue.Add(configDigest)
 	}
 }
@@ -128,3 +128,28 @@ func TestController(t *testing.T) {
 	ms.Stop()
 	s3.reset()
 }
+
+func TestControllerScheduleJitter(t *testing.T) {
+	ms := NewController()
+	ms.scheduleJitter = time.Second
+	ms.Start()
+	defer ms.Stop()
+
+	s := &scheduler{}
+	ms.Register("s", s, false)
+
+	resolved := makeConfig("resolved")
+	resolved.ServiceID = "docker://foo"
+	unscheduled := makeConfig("unscheduled")
+	unscheduled.ServiceID = "docker://bar"
+	ms.ApplyChanges(integration.ConfigChanges{Schedule: []integration.Config{makeConfig("static"), resolved, unscheduled}})
+	ms.ApplyChanges(integration.ConfigChanges{Unschedule: []integration.Config{unscheduled}})
+
+	// resolved templates are scheduled after the other configs, and not at
+	// all if they are unscheduled in the meantime
+	assert.EventuallyWithTf(t, func(c *assert.CollectT) {
+		s.mutex.Lock()
+		assert.Equal(c, []event{{true, "static"}, {true, "resolved"}}, s.events)
+		s.mutex.Unlock()
+	}, 5*time.Second, 10*time.Millisecond, "Failed to process configs before timeout")
+}
@@ -20,6 +20,7 @@ import (
 func populateStatus(ac autodiscovery.Component, wf workloadfilter.Component, stats map[string]interface{}) {
 	stats["adEnabledFeatures"] = env.GetDetectedFeatures()
 	stats["adConfigErrors"] = ac.GetAutodiscoveryErrors()
+	stats["adResolveErrors"] = ac.GetResolveErrors()
 	stats["filterErrors"] = getAutodiscoveryFilterErrors(wf)
 }
 
@@ -35,6 +35,12 @@ type Store struct {
 	Errors telemetry.Gauge
 	// PollDuration tracks the configs poll duration by AD providers.
 	PollDuration telemetry.Histogram
+	// FileReloads tracks the number of reloads of the configuration files
+	// triggered by a change on disk.
+	FileReloads telemetry.Counter
+	// ResolveErrors tracks the number of template resolution failures by
+	// AD providers, kind of service and reason.
+	ResolveErrors telemetry.Counter
 }
 
 // NewStore returns a new Store.
@@ -70,5 +76,19 @@ func NewStore(telemetryComp telemetry.Component) *Store {
 			prometheus.DefBuckets,
 			telemetry.Options{NoDoubleUnderscoreSep: true},
 		),
+		FileReloads: telemetryComp.NewCounterWithOpts(
+			subsystem,
+			"file_reloads",
+			[]string{},
+			"Number of reloads of the configuration files triggered by a change on disk.",
+			commonOpts,
+		),
+		ResolveErrors: telemetryComp.NewCounterWithOpts(
+			subsystem,
+			"resolve_errors",
+			[]string{"provider", "service_kind", "reason"},
+			"Number of template resolution failures by provider, kind of service and reason.",
+			commonOpts,
+		),
 	}
 }
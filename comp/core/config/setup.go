@@ -11,7 +11,6 @@ import (
 	"io/fs"
 	"path"
 	"runtime"
-	"strings"
 
 	secrets "github.com/DataDog/datadog-agent/comp/core/secrets/def"
 	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
@@ -34,7 +33,7 @@ func setupConfig(config pkgconfigmodel.BuildableConfig, secretComp secrets.Compo
 		// add that first so it's first in line
 		config.AddConfigPath(confFilePath)
 		// If they set a config file directly, let's try to honor that
-		if strings.HasSuffix(confFilePath, ".yaml") || strings.HasSuffix(confFilePath, ".yml") {
+		if pkgconfigmodel.HasConfigFileExtension(confFilePath) {
 			config.SetConfigFile(confFilePath)
 		}
 	}
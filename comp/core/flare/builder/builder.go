@@ -155,4 +155,9 @@ type FlareArgs struct {
 	ProfileDuration      time.Duration // Add performance profiling data to the flare. It will collect a heap profile and a CPU profile for the amount of seconds passed to the flag, with a minimum of 30s
 	ProfileMutexFraction int           // Set the fraction of mutex contention events that are reported in the mutex profile
 	ProfileBlockingRate  int           // Set the fraction of goroutine blocking events that are reported in the blocking profile
+	IncludeSections      []string      // Only add the files of these sections to the flare, the files belonging to no section are always added
+	ExcludeSections      []string      // Do not add the files of these sections to the flare
+	RedactionPatterns    []string      // Additional regular expressions whose matches are redacted from the files of the flare
+	RedactionKeys        []string      // Additional YAML keys whose values are redacted from the files of the flare
+	ExcludedFiles        []string      // Glob patterns of the paths in the flare, or of their base names, of the files not to add to the flare
 }
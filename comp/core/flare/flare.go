@@ -52,9 +52,10 @@ type dependencies struct {
 type provides struct {
 	fx.Out
 
-	Comp       Component
-	Endpoint   api.AgentEndpointProvider
-	RCListener rcclienttypes.TaskListenerProvider
+	Comp           Component
+	Endpoint       api.AgentEndpointProvider
+	RemoteEndpoint api.AgentEndpointProvider
+	RCListener     rcclienttypes.TaskListenerProvider
 }
 
 type flare struct {
@@ -62,6 +63,8 @@ type flare struct {
 	config    config.Component
 	params    Params
 	providers []*types.FlareFiller
+
+	remoteRequests remoteFlareRequests
 }
 
 func newFlare(deps dependencies) provides {
@@ -70,6 +73,9 @@ func newFlare(deps dependencies) provides {
 		config:    deps.Config,
 		params:    deps.Params,
 		providers: fxutil.GetAndFilterGroup(deps.Providers),
+		remoteRequests: remoteFlareRequests{
+			pending: make(map[string]*remoteFlareRequest),
+		},
 	}
 
 	// Adding legacy and internal providers. Registering then as Provider through FX create cycle dependencies.
@@ -87,9 +93,10 @@ func newFlare(deps dependencies) provides {
 	)
 
 	return provides{
-		Comp:       f,
-		Endpoint:   api.NewAgentEndpointProvider(f.createAndReturnFlarePath, "/flare", "POST"),
-		RCListener: rcclienttypes.NewTaskListener(f.onAgentTaskEvent),
+		Comp:           f,
+		Endpoint:       api.NewAgentEndpointProvider(f.createAndReturnFlarePath, "/flare", "POST"),
+		RemoteEndpoint: api.NewAgentEndpointProvider(f.handleRemoteFlareDecision, "/flare/remote", "GET", "POST"),
+		RCListener:     rcclienttypes.NewTaskListener(f.onAgentTaskEvent),
 	}
 }
 
@@ -129,15 +136,10 @@ func (f *flare) onAgentTaskEvent(taskType rcclienttypes.TaskType, task rcclientt
 		f.log.Infof("Unrecognized value passed via enable_streamlogs, creating flare without streamlogs enabled: %q", streamlogs)
 	}
 
-	filePath, err := f.CreateWithArgs(flareArgs, 0, nil, []byte{})
-	if err != nil {
-		return true, err
-	}
-
-	f.log.Infof("Flare was created by remote-config at %s", filePath)
+	flareArgs.IncludeSections, flareArgs.ExcludeSections = f.parseSections(task.Config.TaskArgs["include"], task.Config.TaskArgs["exclude"])
 
-	_, err = f.Send(filePath, caseID, userHandle, helpers.NewRemoteConfigFlareSource(task.Config.UUID))
-	return true, err
+	req := newRemoteFlareRequest(task.Config.UUID, caseID, userHandle, task.Config.TaskArgs, f.remoteFlarePolicy(), flareArgs)
+	return true, f.handleRemoteFlareRequest(req)
 }
 
 func (f *flare) createAndReturnFlarePath(w http.ResponseWriter, r *http.Request) {
@@ -168,6 +170,9 @@ func (f *flare) createAndReturnFlarePath(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	flareArgs := types.FlareArgs{}
+	flareArgs.IncludeSections, flareArgs.ExcludeSections = f.parseSections(r.URL.Query().Get("include"), r.URL.Query().Get("exclude"))
+
 	// Reset the `server_timeout` deadline for this connection as creating a flare can take some time
 	conn, ok := apiutils.GetConnection(r)
 	if ok {
@@ -176,7 +181,7 @@ func (f *flare) createAndReturnFlarePath(w http.ResponseWriter, r *http.Request)
 
 	var filePath string
 	f.log.Infof("Making a flare")
-	filePath, err := f.Create(profile, providerTimeout, nil, []byte{})
+	filePath, err := f.create(flareArgs, providerTimeout, nil, profile, []byte{}, nil)
 
 	if err != nil || filePath == "" {
 		if err != nil {
@@ -200,21 +205,44 @@ func (f *flare) Send(flarePath string, caseID string, email string, source helpe
 //
 // If providerTimeout is 0 or negative, the timeout from the configuration will be used.
 func (f *flare) Create(pdata types.ProfileData, providerTimeout time.Duration, ipcError error, diagnoseResult []byte) (string, error) {
-	return f.create(types.FlareArgs{}, providerTimeout, ipcError, pdata, diagnoseResult)
+	return f.create(types.FlareArgs{}, providerTimeout, ipcError, pdata, diagnoseResult, nil)
 }
 
-// Create creates a new flare and returns the path to the final archive file.
+// CreateWithArgs creates a new flare and returns the path to the final archive file.
 //
 // If providerTimeout is 0 or negative, the timeout from the configuration will be used.
-func (f *flare) CreateWithArgs(flareArgs types.FlareArgs, providerTimeout time.Duration, ipcError error, diagnoseResult []byte) (string, error) {
-	return f.create(flareArgs, providerTimeout, ipcError, types.ProfileData{}, diagnoseResult)
+func (f *flare) CreateWithArgs(flareArgs types.FlareArgs, pdata types.ProfileData, providerTimeout time.Duration, ipcError error, diagnoseResult []byte) (string, error) {
+	return f.create(flareArgs, providerTimeout, ipcError, pdata, diagnoseResult, nil)
 }
 
-func (f *flare) create(flareArgs types.FlareArgs, providerTimeout time.Duration, ipcError error, pdata types.ProfileData, diagnoseResult []byte) (string, error) {
+// parseSections parses the comma separated lists of the sections to include in and exclude from a flare, ignoring
+// the unknown sections.
+func (f *flare) parseSections(include string, exclude string) ([]string, []string) {
+	parse := func(value string) []string {
+		var sections []string
+		for _, section := range helpers.ParseFlareSections(value) {
+			if err := helpers.ValidateFlareSections([]string{section}); err != nil {
+				f.log.Warnf("Ignoring flare section: %s", err)
+				continue
+			}
+			sections = append(sections, section)
+		}
+		return sections
+	}
+	return parse(include), parse(exclude)
+}
+
+// create creates a new flare with extraFiles added to it and returns the path to the final archive file.
+func (f *flare) create(flareArgs types.FlareArgs, providerTimeout time.Duration, ipcError error, pdata types.ProfileData, diagnoseResult []byte, extraFiles map[string][]byte) (string, error) {
 	if providerTimeout <= 0 {
 		providerTimeout = f.config.GetDuration("flare_provider_timeout")
 	}
 
+	// the redaction rules of the configuration apply to all the flares
+	flareArgs.RedactionPatterns = f.config.GetStringSlice("flare.redaction.patterns")
+	flareArgs.RedactionKeys = f.config.GetStringSlice("flare.redaction.keys")
+	flareArgs.ExcludedFiles = f.config.GetStringSlice("flare.redaction.excluded_files")
+
 	fb, err := fbFactory(f.params.local, flareArgs)
 	if err != nil {
 		return "", err
@@ -239,6 +267,10 @@ func (f *flare) create(flareArgs types.FlareArgs, providerTimeout time.Duration,
 		fb.AddFile("diagnose.log", diagnoseResult)
 	}
 
+	for name, data := range extraFiles {
+		fb.AddFile(name, data) //nolint:errcheck
+	}
+
 	f.runProviders(fb, providerTimeout)
 
 	return fb.Save()
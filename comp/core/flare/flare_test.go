@@ -6,6 +6,10 @@
 package flare
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -47,6 +51,8 @@ type rcSettings struct {
 	blockRate        int
 	mutexFrac        int
 	enableStreamLogs bool
+	includeSections  []string
+	excludeSections  []string
 }
 
 func getFlare(t *testing.T, overrides map[string]interface{}, fillers ...fx.Option) *flare {
@@ -258,6 +264,41 @@ func TestAgentTaskFlareStreamLogsArgs(t *testing.T) {
 	})
 }
 
+func TestAgentTaskFlareSectionsArgs(t *testing.T) {
+	defer setupMockBuilder(t)()
+
+	testCfg := map[string]interface{}{
+		"site": "localhost", // Provide extra guarantees we don't try to send the flare off the box
+	}
+
+	scenarios := []struct {
+		name        string
+		task        string
+		expSettings rcSettings
+	}{
+		{
+			name:        "Test sections included and excluded",
+			task:        "{\"args\":{\"case_id\":\"22420\",\"include\":\"logs, otel\",\"exclude\":\"dbm\",\"user_handle\":\"no-reply@datadoghq.com\"},\"task_type\":\"flare\",\"uuid\":\"a_uuid\"}",
+			expSettings: rcSettings{includeSections: []string{"logs", "otel"}, excludeSections: []string{"dbm"}},
+		},
+		{
+			name:        "Test unknown sections",
+			task:        "{\"args\":{\"case_id\":\"22420\",\"include\":\"logs,unknown\",\"user_handle\":\"no-reply@datadoghq.com\"},\"task_type\":\"flare\",\"uuid\":\"a_uuid\"}",
+			expSettings: rcSettings{includeSections: []string{"logs"}},
+		},
+		{
+			name:        "Test sections not present",
+			task:        "{\"args\":{\"case_id\":\"22420\",\"user_handle\":\"no-reply@datadoghq.com\"},\"task_type\":\"flare\",\"uuid\":\"a_uuid\"}",
+			expSettings: rcSettings{},
+		},
+	}
+
+	runFlareTestScenarios(t, testCfg, scenarios, func(fb types.FlareBuilder, expSettings rcSettings) {
+		assert.Equal(t, expSettings.includeSections, fb.GetFlareArgs().IncludeSections)
+		assert.Equal(t, expSettings.excludeSections, fb.GetFlareArgs().ExcludeSections)
+	})
+}
+
 func runFlareTestScenarios(t *testing.T, testCfg map[string]interface{}, scenarios []struct {
 	name        string
 	task        string
@@ -280,3 +321,101 @@ func runFlareTestScenarios(t *testing.T, testCfg map[string]interface{}, scenari
 		})
 	}
 }
+
+func TestAgentTaskFlareApprovalPolicy(t *testing.T) {
+	defer setupMockBuilder(t)()
+
+	task := "{\"args\":{\"case_id\":\"22420\",\"user_handle\":\"no-reply@datadoghq.com\"},\"task_type\":\"flare\",\"uuid\":\"a_uuid\"}"
+	atc, err := rcclienttypes.ParseConfigAgentTask([]byte(task), state.Metadata{})
+	require.NoError(t, err)
+
+	newTestFlare := func(t *testing.T, policy string, timeout time.Duration) (*flare, chan struct{}) {
+		created := make(chan struct{}, 1)
+		flare := getFlare(t, map[string]interface{}{
+			"site":                      "localhost", // Provide extra guarantees we don't try to send the flare off the box
+			"flare.rc_approval.policy":  policy,
+			"flare.rc_approval.timeout": timeout,
+		})
+		flare.providers = []*types.FlareFiller{
+			types.NewFiller(func(fb types.FlareBuilder) error {
+				fb.(*helpers.FlareBuilderMock).AssertFileContentMatch(`"decision": "approved"`, remoteFlareAuditFile)
+				created <- struct{}{}
+				return nil
+			}),
+		}
+		return flare, created
+	}
+
+	decide := func(flare *flare, uuid string, decision string) int {
+		form := url.Values{"uuid": {uuid}, "decision": {decision}}
+		r := httptest.NewRequest(http.MethodPost, "/flare/remote", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		flare.handleRemoteFlareDecision(w, r)
+		return w.Code
+	}
+
+	t.Run("auto-approve", func(t *testing.T) {
+		flare, created := newTestFlare(t, remoteFlarePolicyAutoApprove, time.Hour)
+		flare.onAgentTaskEvent(rcclienttypes.TaskFlare, atc)
+		assert.Len(t, created, 1)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		flare, created := newTestFlare(t, remoteFlarePolicyDeny, time.Hour)
+		processed, err := flare.onAgentTaskEvent(rcclienttypes.TaskFlare, atc)
+		assert.True(t, processed)
+		assert.Error(t, err)
+		assert.Empty(t, created)
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		flare, created := newTestFlare(t, "unknown", time.Hour)
+		_, err := flare.onAgentTaskEvent(rcclienttypes.TaskFlare, atc)
+		assert.Error(t, err)
+		assert.Empty(t, created)
+	})
+
+	t.Run("require-confirmation approved", func(t *testing.T) {
+		flare, created := newTestFlare(t, remoteFlarePolicyRequireConfirmation, time.Hour)
+		processed, err := flare.onAgentTaskEvent(rcclienttypes.TaskFlare, atc)
+		assert.True(t, processed)
+		assert.NoError(t, err)
+		assert.Empty(t, created)
+
+		w := httptest.NewRecorder()
+		flare.handleRemoteFlareDecision(w, httptest.NewRequest(http.MethodGet, "/flare/remote", nil))
+		assert.Contains(t, w.Body.String(), `"uuid":"a_uuid"`)
+
+		assert.Equal(t, http.StatusBadRequest, decide(flare, "a_uuid", "maybe"))
+		assert.Equal(t, http.StatusNotFound, decide(flare, "another_uuid", remoteFlareDecisionApproved))
+		assert.Equal(t, http.StatusOK, decide(flare, "a_uuid", remoteFlareDecisionApproved))
+		select {
+		case <-created:
+		case <-time.After(10 * time.Second):
+			assert.Fail(t, "the flare was not created after its approval")
+		}
+		assert.Equal(t, http.StatusNotFound, decide(flare, "a_uuid", remoteFlareDecisionApproved))
+	})
+
+	t.Run("require-confirmation denied", func(t *testing.T) {
+		flare, created := newTestFlare(t, remoteFlarePolicyRequireConfirmation, time.Hour)
+		flare.onAgentTaskEvent(rcclienttypes.TaskFlare, atc)
+
+		assert.Equal(t, http.StatusOK, decide(flare, "a_uuid", remoteFlareDecisionDenied))
+		assert.Empty(t, created)
+		assert.Equal(t, http.StatusNotFound, decide(flare, "a_uuid", remoteFlareDecisionApproved))
+	})
+
+	t.Run("require-confirmation expired", func(t *testing.T) {
+		flare, created := newTestFlare(t, remoteFlarePolicyRequireConfirmation, 10*time.Millisecond)
+		flare.onAgentTaskEvent(rcclienttypes.TaskFlare, atc)
+
+		assert.Eventually(t, func() bool {
+			flare.remoteRequests.m.Lock()
+			defer flare.remoteRequests.m.Unlock()
+			return len(flare.remoteRequests.pending) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Empty(t, created)
+	})
+}
@@ -74,7 +74,7 @@ func newBuilder(root string, hostname string, localFlare bool, flareArgs types.F
 	fb.scrubber.AddReplacer(scrubber.SingleLine, apiKeyReplacer)
 	fb.encAwareScrubber.AddReplacer(scrubber.SingleLine, apiKeyReplacer)
 
-	logPath, err := fb.PrepareFilePath("flare_creation.log")
+	logPath, err := fb.prepareFilePath("flare_creation.log")
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +85,40 @@ func newBuilder(root string, hostname string, localFlare bool, flareArgs types.F
 	}
 	fb.logFile = f
 
+	fb.redactions = fb.newRedactions()
+
 	return fb, nil
 }
 
+// newRedactions returns the replacers redacting the patterns and the YAML keys of the flare args.
+func (fb *builder) newRedactions() []scrubber.Replacer {
+	var redactions []scrubber.Replacer
+	for _, pattern := range fb.flareArgs.RedactionPatterns {
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			_ = fb.logError("invalid flare redaction pattern '%s': %s", pattern, err)
+			continue
+		}
+		redactions = append(redactions, scrubber.Replacer{Regex: rx, Repl: []byte("********")})
+	}
+	if len(fb.flareArgs.RedactionKeys) > 0 {
+		redactions = append(redactions, scrubber.NewYAMLKeysReplacer(fb.flareArgs.RedactionKeys))
+	}
+	return redactions
+}
+
+// redact applies the redaction rules of the flare args to the content of destFile. Unlike the scrubbers, it keeps
+// the content as is apart from the matches. The pprof profiles are binary and are left untouched.
+func (fb *builder) redact(destFile string, content []byte) []byte {
+	if len(fb.redactions) == 0 || getFlareSection(destFile) == "profiles" {
+		return content
+	}
+	for _, r := range fb.redactions {
+		content = r.Regex.ReplaceAll(content, r.Repl)
+	}
+	return content
+}
+
 // NewFlareBuilder returns a new FlareBuilder ready to be used. You need to call the Save method to archive all the data
 // pushed to the flare as well as cleanup the temporary directories created. Not calling 'Save' after NewFlareBuilder
 // will leave temporary directory on the file system.
@@ -141,6 +172,10 @@ type builder struct {
 
 	// nonScrubbedFiles tracks files that were added without scrubbing
 	nonScrubbedFiles map[string]bool
+	// excludedFiles counts the files that were not added because of the sections included or excluded by flareArgs
+	excludedFiles int
+	// redactions are the redaction rules of flareArgs, applied to all the files, even the ones added without scrubbing
+	redactions []scrubber.Replacer
 }
 
 func getArchiveName() string {
@@ -192,6 +227,18 @@ func (fb *builder) Save() (string, error) {
 		return jsonData, nil
 	})
 
+	if len(fb.flareArgs.IncludeSections) > 0 || len(fb.flareArgs.ExcludeSections) > 0 {
+		_ = fb.AddFileFromFunc("flare_profile.json", func() ([]byte, error) {
+			fb.Lock()
+			defer fb.Unlock()
+			return json.MarshalIndent(map[string]interface{}{
+				"include":        fb.flareArgs.IncludeSections,
+				"exclude":        fb.flareArgs.ExcludeSections,
+				"excluded_files": fb.excludedFiles,
+			}, "", "  ")
+		})
+	}
+
 	_ = fb.logFile.Close()
 
 	fb.Lock()
@@ -256,7 +303,7 @@ func (fb *builder) AddFileFromFunc(destFile string, cb func() ([]byte, error)) e
 }
 
 func (fb *builder) addFile(shouldScrub bool, destFile string, content []byte) error {
-	if fb.closed() {
+	if fb.closed() || fb.skipExcluded(destFile) {
 		return nil
 	}
 
@@ -280,6 +327,8 @@ func (fb *builder) addFile(shouldScrub bool, destFile string, content []byte) er
 		fb.Unlock()
 	}
 
+	content = fb.redact(destFile, content)
+
 	fb.Lock()
 	defer fb.Unlock()
 
@@ -310,6 +359,17 @@ func (fb *builder) GetFlareArgs() types.FlareArgs {
 	return fb.flareArgs
 }
 
+// skipExcluded returns true, and counts the file as excluded, if the file at path must not be added to the flare.
+func (fb *builder) skipExcluded(path string) bool {
+	if !fb.isExcluded(path) {
+		return false
+	}
+	fb.Lock()
+	defer fb.Unlock()
+	fb.excludedFiles++
+	return true
+}
+
 func (fb *builder) closed() bool {
 	fb.Lock()
 	defer fb.Unlock()
@@ -317,7 +377,7 @@ func (fb *builder) closed() bool {
 }
 
 func (fb *builder) copyFileTo(shouldScrub bool, srcFile string, destFile string) error {
-	if fb.closed() {
+	if fb.closed() || fb.skipExcluded(destFile) {
 		return nil
 	}
 
@@ -345,6 +405,8 @@ func (fb *builder) copyFileTo(shouldScrub bool, srcFile string, destFile string)
 		fb.Unlock()
 	}
 
+	content = fb.redact(destFile, content)
+
 	fb.Lock()
 	defer fb.Unlock()
 
@@ -418,6 +480,9 @@ func (fb *builder) CopyDirTo(srcDir string, destDir string, shouldInclude func(s
 }
 
 func (fb *builder) PrepareFilePath(path string) (string, error) {
+	if fb.skipExcluded(path) {
+		return "", fmt.Errorf("'%s' is excluded from the flare", path)
+	}
 	fb.Lock()
 	defer fb.Unlock()
 	return fb.prepareFilePath(path)
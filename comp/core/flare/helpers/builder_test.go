@@ -136,6 +136,60 @@ func TestAddFile(t *testing.T) {
 	assertFileContent(t, fb, "api_key: \"********\"", "test/AddFile_scrubbed_api_key")
 }
 
+func TestAddFileExcludedSections(t *testing.T) {
+	f, err := NewFlareBuilder(false, flarebuilder.FlareArgs{
+		IncludeSections: []string{"logs", "otel"},
+		ExcludeSections: []string{"otel"},
+	})
+	require.NoError(t, err)
+	fb := f.(*builder)
+	defer fb.clean()
+
+	fb.AddFile(FromSlash("logs/agent.log"), []byte("some data"))
+	fb.AddFile(FromSlash("otel/otel-response.json"), []byte("some data"))
+	fb.AddFile(FromSlash("expvar/forwarder"), []byte("some data"))
+	fb.AddFile("status.log", []byte("some data"))
+	_, err = fb.PrepareFilePath(FromSlash("system-probe/dmesg.log"))
+	assert.Error(t, err)
+
+	assertFileContent(t, fb, "some data", "logs/agent.log")
+	assertFileContent(t, fb, "some data", "status.log")
+	assert.NoFileExists(t, filepath.Join(fb.flareDir, "otel", "otel-response.json"))
+	assert.NoFileExists(t, filepath.Join(fb.flareDir, "expvar", "forwarder"))
+	assert.Equal(t, 3, fb.excludedFiles)
+}
+
+func TestRedactionRules(t *testing.T) {
+	f, err := NewFlareBuilder(false, flarebuilder.FlareArgs{
+		RedactionPatterns: []string{`[a-z0-9-]+\.internal\.example\.com`, `(invalid`},
+		RedactionKeys:     []string{"internal_token"},
+		ExcludedFiles:     []string{"*.pem", "etc/confd/*"},
+	})
+	require.NoError(t, err)
+	fb := f.(*builder)
+	defer fb.clean()
+
+	fb.AddFile("status.log", []byte("host: db-1.internal.example.com"))
+	fb.AddFileWithoutScrubbing(FromSlash("logs/agent.log"), []byte("connecting to db-1.internal.example.com"))
+	fb.AddFile("test.log", []byte("internal_token: abcdef"))
+	fb.AddFileWithoutScrubbing(FromSlash("profiles/heap.pprof"), []byte("db-1.internal.example.com"))
+	fb.AddFile(FromSlash("certs/server.pem"), []byte("some data"))
+	fb.AddFile(FromSlash("etc/confd/postgres.yaml"), []byte("some data"))
+
+	assertFileContent(t, fb, "host: ********", "status.log")
+	assertFileContent(t, fb, "connecting to ********", "logs/agent.log")
+	assertFileContent(t, fb, "internal_token: \"********\"", "test.log")
+	assertFileContent(t, fb, "db-1.internal.example.com", "profiles/heap.pprof")
+	assert.NoFileExists(t, filepath.Join(fb.flareDir, "certs", "server.pem"))
+	assert.NoFileExists(t, filepath.Join(fb.flareDir, "etc", "confd", "postgres.yaml"))
+}
+
+func TestValidateFlareSections(t *testing.T) {
+	assert.NoError(t, ValidateFlareSections([]string{"logs", "system-probe"}))
+	assert.Error(t, ValidateFlareSections([]string{"logs", "unknown"}))
+	assert.Equal(t, []string{"logs", "otel"}, ParseFlareSections(" logs,,otel "))
+}
+
 func TestAddNonLocalFileFlare(t *testing.T) {
 	fb := getNewBuilder(t)
 	defer fb.clean()
@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helpers
+
+import (
+	"fmt"
+	pathpkg "path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// flareSections maps the sections of a flare, which can be included or excluded with the FlareArgs, to the paths of
+// their files in the flare. A path ending with a '/' matches all the files of a directory.
+var flareSections = map[string][]string{
+	"config":        {"etc/", "runtime_config_dump.yaml", "runtime_config_provenance.yaml"},
+	"dbm":           {"db-monitoring/"},
+	"eventlog":      {"eventlog/", "eventlogconfig.txt"},
+	"expvar":        {"expvar/"},
+	"k8s":           {"k8s/"},
+	"logs":          {"logs/"},
+	"metadata":      {"metadata/"},
+	"otel":          {"otel/"},
+	"profiles":      {"profiles/"},
+	"remote-config": {"remote-config.db", "remote-config.temp.db", "remote-config-state.log"},
+	"sbom":          {"sbom/", "host-sbom.json"},
+	"system-probe":  {"system-probe/", "system_probe_runtime_config_dump.yaml"},
+}
+
+// FlareSections returns the names of the sections that can be included in or excluded from a flare, sorted.
+func FlareSections() []string {
+	names := make([]string, 0, len(flareSections))
+	for name := range flareSections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateFlareSections returns an error if one of sections is not a known flare section.
+func ValidateFlareSections(sections []string) error {
+	for _, section := range sections {
+		if _, ok := flareSections[section]; !ok {
+			return fmt.Errorf("unknown flare section %q, valid sections are: %s", section, strings.Join(FlareSections(), ", "))
+		}
+	}
+	return nil
+}
+
+// ParseFlareSections parses a comma separated list of flare sections.
+func ParseFlareSections(value string) []string {
+	var sections []string
+	for _, section := range strings.Split(value, ",") {
+		if section = strings.TrimSpace(section); section != "" {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// getFlareSection returns the section the file at path belongs to, or "" if it belongs to no section.
+func getFlareSection(path string) string {
+	path = filepath.ToSlash(path)
+	for name, paths := range flareSections {
+		for _, p := range paths {
+			if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// isExcluded returns true if the file at path must not be added to the flare because of the files excluded, or the
+// sections included or excluded, by the FlareArgs.
+func (fb *builder) isExcluded(path string) bool {
+	if isExcludedFile(path, fb.flareArgs.ExcludedFiles) {
+		return true
+	}
+	if len(fb.flareArgs.IncludeSections) == 0 && len(fb.flareArgs.ExcludeSections) == 0 {
+		return false
+	}
+	section := getFlareSection(path)
+	if section == "" {
+		return false
+	}
+	if len(fb.flareArgs.IncludeSections) > 0 && !slices.Contains(fb.flareArgs.IncludeSections, section) {
+		return true
+	}
+	return slices.Contains(fb.flareArgs.ExcludeSections, section)
+}
+
+// isExcludedFile returns true if path, or its base name, matches one of the glob patterns.
+func isExcludedFile(path string, patterns []string) bool {
+	path = filepath.ToSlash(path)
+	for _, pattern := range patterns {
+		if ok, _ := pathpkg.Match(pattern, path); ok {
+			return true
+		}
+		if ok, _ := pathpkg.Match(pattern, pathpkg.Base(path)); ok {
+			return true
+		}
+	}
+	return false
+}
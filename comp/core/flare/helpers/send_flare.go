@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -61,7 +62,8 @@ func NewRemoteConfigFlareSource(rcTaskUUID string) FlareSource {
 	}
 }
 
-func getFlareReader(multipartBoundary, archivePath, caseID, email, hostname string, source FlareSource) io.ReadCloser {
+// getFlareReader returns the multipart body sending the flare archive, or only a chunk of it when chunk is not nil.
+func getFlareReader(multipartBoundary, archivePath, caseID, email, hostname string, source FlareSource, chunk *flareChunk) io.ReadCloser {
 	//No need to close the reader, http.Client does it for us
 	bodyReader, bodyWriter := io.Pipe()
 
@@ -87,6 +89,13 @@ func getFlareReader(multipartBoundary, archivePath, caseID, email, hostname stri
 			// UUID of the remote-config task sending the flare
 			writer.WriteField("rc_task_uuid", source.rcTaskUUID) //nolint:errcheck
 		}
+		if chunk != nil {
+			// the chunks of a flare share the same upload ID, the backend assembles them once the last one is received
+			writer.WriteField("upload_id", chunk.uploadID)                      //nolint:errcheck
+			writer.WriteField("chunk_index", strconv.Itoa(chunk.index))         //nolint:errcheck
+			writer.WriteField("chunk_count", strconv.Itoa(chunk.count))         //nolint:errcheck
+			writer.WriteField("flare_size", strconv.FormatInt(chunk.total, 10)) //nolint:errcheck
+		}
 
 		p, err := writer.CreateFormFile("flare_file", filepath.Base(archivePath))
 		if err != nil {
@@ -100,7 +109,11 @@ func getFlareReader(multipartBoundary, archivePath, caseID, email, hostname stri
 		}
 		defer file.Close()
 
-		_, err = io.Copy(p, file)
+		var content io.Reader = file
+		if chunk != nil {
+			content = io.NewSectionReader(file, chunk.offset, chunk.size)
+		}
+		_, err = io.Copy(p, content)
 		if err != nil {
 			bodyWriter.CloseWithError(err) //nolint:errcheck
 			return
@@ -114,7 +127,7 @@ func getFlareReader(multipartBoundary, archivePath, caseID, email, hostname stri
 	return bodyReader
 }
 
-func readAndPostFlareFile(archivePath, caseID, email, hostname, url string, source FlareSource, client *http.Client, apiKey string) (*http.Response, error) {
+func readAndPostFlareFile(archivePath, caseID, email, hostname, url string, source FlareSource, client *http.Client, apiKey string, chunk *flareChunk) (*http.Response, error) {
 	// Having resolved the POST URL, we do not expect to see further redirects, so do not
 	// handle them.
 	client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
@@ -136,7 +149,7 @@ func readAndPostFlareFile(archivePath, caseID, email, hostname, url string, sour
 
 	// Manually set the Body and ContentLenght. http.NewRequest doesn't do all of this
 	// for us, since a PipeReader is not one of the Reader types it knows how to handle.
-	request.Body = getFlareReader(boundaryWriter.Boundary(), archivePath, caseID, email, hostname, source)
+	request.Body = getFlareReader(boundaryWriter.Boundary(), archivePath, caseID, email, hostname, source, chunk)
 
 	// -1 here means 'unknown' and makes this a 'chunked' request. See https://github.com/golang/go/issues/18117
 	request.ContentLength = -1
@@ -267,12 +280,31 @@ func SendTo(cfg pkgconfigmodel.Reader, archivePath, caseID, email, apiKey, url s
 		return "", err
 	}
 
-	// Retry logic for the actual flare file posting
+	// Large archives are sent in chunks when the chunked upload is enabled, so that a failure only requires to send
+	// the remaining chunks again
+	chunkSize := cfg.GetInt64("flare.upload.chunk_size")
+	if info, err := os.Stat(archivePath); err == nil && chunkSize > 0 && info.Size() > chunkSize {
+		return sendChunks(archivePath, caseID, email, hostname, url, source, client, apiKey, info.Size(), chunkSize)
+	}
+
+	r, err := postFlareWithRetries(func() (*http.Response, error) {
+		return readAndPostFlareFile(archivePath, caseID, email, hostname, url, source, client, apiKey, nil)
+	})
+	if err != nil {
+		return "", err
+	}
+	defer r.Body.Close()
+	return analyzeResponse(r, apiKey)
+}
+
+// postFlareWithRetries calls post, which sends a flare or a chunk of a flare, until it succeeds or fails with an error
+// that is not retryable.
+func postFlareWithRetries(post func() (*http.Response, error)) (*http.Response, error) {
 	var lastErr error
 	var baseDelay = 1 * time.Second
 
 	for attempt := 3; attempt > 0; attempt-- {
-		r, err := readAndPostFlareFile(archivePath, caseID, email, hostname, url, source, client, apiKey)
+		r, err := post()
 		if err != nil {
 			// Always close the response body if it exists
 			statusCode := 0
@@ -283,18 +315,15 @@ func SendTo(cfg pkgconfigmodel.Reader, archivePath, caseID, email, apiKey, url s
 			lastErr = err
 
 			if !isRetryableFlareError(err, statusCode) {
-				return "", err
+				return nil, err
 			}
 			log.Warn("Failed to send flare, retrying in 1 second")
 			time.Sleep(baseDelay)
 			continue
 		}
-
-		// Success case - analyze the response
-		defer r.Body.Close()
-		return analyzeResponse(r, apiKey)
+		return r, nil
 	}
-	return "", fmt.Errorf("failed to send flare after 3 attempts: %w", lastErr)
+	return nil, fmt.Errorf("failed to send flare after 3 attempts: %w", lastErr)
 }
 
 func isRetryableFlareError(err error, statusCode int) bool {
@@ -0,0 +1,128 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// uploadStateSuffix is the suffix of the file, next to a flare archive, recording the chunks of the archive already
+// sent, so that an interrupted upload can be resumed
+const uploadStateSuffix = ".upload"
+
+// flareChunk is a chunk of a flare archive sent on its own
+type flareChunk struct {
+	uploadID string
+	index    int
+	count    int
+	offset   int64
+	size     int64
+	// total is the size of the whole archive
+	total int64
+}
+
+// flareUploadState is the progress of the chunked upload of a flare archive
+type flareUploadState struct {
+	UploadID   string `json:"upload_id"`
+	CaseID     string `json:"case_id"`
+	Size       int64  `json:"size"`
+	ChunkSize  int64  `json:"chunk_size"`
+	SentChunks int    `json:"sent_chunks"`
+}
+
+// loadUploadState returns the progress of a previous upload of the archive, if it matches the current one, or a new
+// upload otherwise.
+func loadUploadState(archivePath, caseID string, size, chunkSize int64) (*flareUploadState, error) {
+	state := &flareUploadState{}
+	if content, err := os.ReadFile(archivePath + uploadStateSuffix); err == nil {
+		if err := json.Unmarshal(content, state); err == nil &&
+			state.CaseID == caseID && state.Size == size && state.ChunkSize == chunkSize {
+			return state, nil
+		}
+		log.Infof("Ignoring the progress of a previous upload of %s, which does not match the current one", archivePath)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &flareUploadState{
+		UploadID:  hex.EncodeToString(id),
+		CaseID:    caseID,
+		Size:      size,
+		ChunkSize: chunkSize,
+	}, nil
+}
+
+func (s *flareUploadState) save(archivePath string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(archivePath+uploadStateSuffix, content, filePerm)
+}
+
+// sendChunks sends the archive in chunks of chunkSize bytes, starting after the chunks sent by a previous call. The
+// chunks sent are recorded next to the archive as they are acknowledged, so that the upload can be resumed by sending
+// the same archive again.
+func sendChunks(archivePath, caseID, email, hostname, url string, source FlareSource, client *http.Client, apiKey string, size, chunkSize int64) (string, error) {
+	state, err := loadUploadState(archivePath, caseID, size, chunkSize)
+	if err != nil {
+		return "", err
+	}
+
+	count := int((size + chunkSize - 1) / chunkSize)
+	if state.SentChunks > 0 {
+		log.Infof("Resuming the upload of %s after %d of its %d chunks", archivePath, state.SentChunks, count)
+	}
+
+	for index := state.SentChunks; index < count; index++ {
+		chunk := &flareChunk{
+			uploadID: state.UploadID,
+			index:    index,
+			count:    count,
+			offset:   int64(index) * chunkSize,
+			size:     min(chunkSize, size-int64(index)*chunkSize),
+			total:    size,
+		}
+		r, err := postFlareWithRetries(func() (*http.Response, error) {
+			return readAndPostFlareFile(archivePath, caseID, email, hostname, url, source, client, apiKey, chunk)
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to send chunk %d of %d of the flare, send the flare again to resume the upload: %w", index+1, count, err)
+		}
+
+		if index == count-1 {
+			defer r.Body.Close()
+			response, err := analyzeResponse(r, apiKey)
+			if err == nil {
+				_ = os.Remove(archivePath + uploadStateSuffix)
+			}
+			return response, err
+		}
+
+		if r.StatusCode != http.StatusOK {
+			defer r.Body.Close()
+			return analyzeResponse(r, apiKey)
+		}
+		_, _ = io.Copy(io.Discard, r.Body)
+		r.Body.Close()
+
+		state.SentChunks = index + 1
+		if err := state.save(archivePath); err != nil {
+			log.Warnf("Unable to record the progress of the upload of %s, it cannot be resumed: %v", archivePath, err)
+		}
+	}
+	return "", fmt.Errorf("the flare %s has no chunk left to send", archivePath)
+}
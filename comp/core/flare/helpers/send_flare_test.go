@@ -11,6 +11,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -410,6 +413,87 @@ func TestSendToRetryLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestSendToChunked(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("flare.upload.chunk_size", 4)
+
+	archivePath := filepath.Join(t.TempDir(), "flare.zip")
+	require.NoError(t, os.WriteFile(archivePath, []byte("0123456789"), 0644))
+
+	var received []byte
+	var uploadIDs []string
+	failChunk := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "HEAD" {
+			w.WriteHeader(200)
+			return
+		}
+		require.NoError(t, r.ParseMultipartForm(1000000))
+		index, err := strconv.Atoi(r.FormValue("chunk_index"))
+		require.NoError(t, err)
+		assert.Equal(t, "3", r.FormValue("chunk_count"))
+		assert.Equal(t, "10", r.FormValue("flare_size"))
+		if index == failChunk {
+			failChunk = -1
+			w.WriteHeader(400)
+			return
+		}
+
+		file, _, err := r.FormFile("flare_file")
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		received = append(received, content...)
+		uploadIDs = append(uploadIDs, r.FormValue("upload_id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if index == 2 {
+			io.WriteString(w, `{"case_id": 1234}`)
+		} else {
+			io.WriteString(w, "{}")
+		}
+	}))
+	defer server.Close()
+
+	// the second chunk fails, the progress is recorded next to the archive
+	_, err := SendTo(cfg, archivePath, "12345", "test@example.com", "test-api-key", server.URL, FlareSource{})
+	require.Error(t, err)
+	assert.Equal(t, []byte("0123"), received)
+	assert.FileExists(t, archivePath+uploadStateSuffix)
+
+	// sending the archive again resumes after the first chunk
+	result, err := SendTo(cfg, archivePath, "12345", "test@example.com", "test-api-key", server.URL, FlareSource{})
+	require.NoError(t, err)
+	assert.Contains(t, result, "Your logs were successfully uploaded")
+	assert.Equal(t, []byte("0123456789"), received)
+	assert.Equal(t, []string{uploadIDs[0], uploadIDs[0], uploadIDs[0]}, uploadIDs)
+	assert.NoFileExists(t, archivePath+uploadStateSuffix)
+}
+
+func TestSendToChunkedDisabledByDefault(t *testing.T) {
+	cfg := config.NewMock(t)
+
+	archivePath := filepath.Join(t.TempDir(), "flare.zip")
+	require.NoError(t, os.WriteFile(archivePath, []byte("0123456789"), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "HEAD" {
+			w.WriteHeader(200)
+			return
+		}
+		require.NoError(t, r.ParseMultipartForm(1000000))
+		assert.Empty(t, r.FormValue("chunk_index"))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"case_id": 1234}`)
+	}))
+	defer server.Close()
+
+	_, err := SendTo(cfg, archivePath, "12345", "test@example.com", "test-api-key", server.URL, FlareSource{})
+	require.NoError(t, err)
+	assert.NoFileExists(t, archivePath+uploadStateSuffix)
+}
+
 func TestIsRetryableFlareError(t *testing.T) {
 	testCases := []struct {
 		name      string
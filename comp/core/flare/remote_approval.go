@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package flare
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/core/flare/helpers"
+	"github.com/DataDog/datadog-agent/comp/core/flare/types"
+)
+
+const (
+	// remoteFlarePolicyAutoApprove creates the flares requested by remote-config right away
+	remoteFlarePolicyAutoApprove = "auto-approve"
+	// remoteFlarePolicyRequireConfirmation creates the flares requested by remote-config once an operator approves
+	// them with the CLI
+	remoteFlarePolicyRequireConfirmation = "require-confirmation"
+	// remoteFlarePolicyDeny rejects all the flares requested by remote-config
+	remoteFlarePolicyDeny = "deny"
+
+	remoteFlareDecisionApproved = "approved"
+	remoteFlareDecisionDenied   = "denied"
+	remoteFlareDecisionExpired  = "expired"
+
+	// remoteFlareAuditFile is the file of the flare recording the request and the decision of a remote flare
+	remoteFlareAuditFile = "remote_flare_request.json"
+)
+
+// remoteFlareRequest is the audit record of a flare requested by remote-config
+type remoteFlareRequest struct {
+	UUID       string            `json:"uuid"`
+	CaseID     string            `json:"case_id"`
+	UserHandle string            `json:"user_handle"`
+	Args       map[string]string `json:"args"`
+	ReceivedAt time.Time         `json:"received_at"`
+	Policy     string            `json:"policy"`
+	Decision   string            `json:"decision,omitempty"`
+	// DecidedBy is "policy", "operator" or "timeout"
+	DecidedBy string    `json:"decided_by,omitempty"`
+	DecidedAt time.Time `json:"decided_at,omitzero"`
+
+	flareArgs types.FlareArgs
+	expire    *time.Timer
+}
+
+// remoteFlareRequests are the flares requested by remote-config waiting for the confirmation of an operator
+type remoteFlareRequests struct {
+	m       sync.Mutex
+	pending map[string]*remoteFlareRequest
+}
+
+// remoteFlarePolicy returns the approval policy of the flares requested by remote-config. An unknown policy denies
+// all the requests.
+func (f *flare) remoteFlarePolicy() string {
+	policy := f.config.GetString("flare.rc_approval.policy")
+	switch policy {
+	case remoteFlarePolicyAutoApprove, remoteFlarePolicyRequireConfirmation, remoteFlarePolicyDeny:
+		return policy
+	default:
+		f.log.Warnf("Unknown flare.rc_approval.policy %q, denying the flares requested by remote-config", policy)
+		return remoteFlarePolicyDeny
+	}
+}
+
+// handleRemoteFlareRequest applies the approval policy to a flare requested by remote-config.
+func (f *flare) handleRemoteFlareRequest(req *remoteFlareRequest) error {
+	f.log.Infof("Received remote flare request %s for case %s from %s, applying the %q policy", req.UUID, req.CaseID, req.UserHandle, req.Policy)
+
+	switch req.Policy {
+	case remoteFlarePolicyAutoApprove:
+		f.decideRemoteFlare(req, remoteFlareDecisionApproved, "policy")
+		return f.sendRemoteFlare(req)
+	case remoteFlarePolicyRequireConfirmation:
+		timeout := f.config.GetDuration("flare.rc_approval.timeout")
+		f.remoteRequests.m.Lock()
+		f.remoteRequests.pending[req.UUID] = req
+		req.expire = time.AfterFunc(timeout, func() {
+			if req := f.takePendingRemoteFlare(req.UUID); req != nil {
+				f.decideRemoteFlare(req, remoteFlareDecisionExpired, "timeout")
+			}
+		})
+		f.remoteRequests.m.Unlock()
+		f.log.Warnf("Remote flare request %s for case %s from %s is waiting for a confirmation: run 'agent flare --approve-remote %s' or 'agent flare --deny-remote %s' within %s",
+			req.UUID, req.CaseID, req.UserHandle, req.UUID, req.UUID, timeout)
+		return nil
+	default:
+		f.decideRemoteFlare(req, remoteFlareDecisionDenied, "policy")
+		return fmt.Errorf("the flare request %s was denied by the local policy of the agent", req.UUID)
+	}
+}
+
+// decideRemoteFlare records the decision taken for a flare requested by remote-config and logs it.
+func (f *flare) decideRemoteFlare(req *remoteFlareRequest, decision string, decidedBy string) {
+	req.Decision = decision
+	req.DecidedBy = decidedBy
+	req.DecidedAt = time.Now()
+
+	audit, _ := json.Marshal(req)
+	f.log.Infof("Remote flare request %s was %s by %s: %s", req.UUID, decision, decidedBy, audit)
+}
+
+// takePendingRemoteFlare removes and returns the pending request with uuid, or nil if there is none.
+func (f *flare) takePendingRemoteFlare(uuid string) *remoteFlareRequest {
+	f.remoteRequests.m.Lock()
+	defer f.remoteRequests.m.Unlock()
+
+	req, ok := f.remoteRequests.pending[uuid]
+	if !ok {
+		return nil
+	}
+	delete(f.remoteRequests.pending, uuid)
+	req.expire.Stop()
+	return req
+}
+
+// sendRemoteFlare creates the flare of an approved request, with its audit record, and sends it.
+func (f *flare) sendRemoteFlare(req *remoteFlareRequest) error {
+	audit, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filePath, err := f.create(req.flareArgs, 0, nil, nil, []byte{}, map[string][]byte{remoteFlareAuditFile: audit})
+	if err != nil {
+		return err
+	}
+
+	f.log.Infof("Flare was created by remote-config at %s", filePath)
+
+	_, err = f.Send(filePath, req.CaseID, req.UserHandle, helpers.NewRemoteConfigFlareSource(req.UUID))
+	return err
+}
+
+// handleRemoteFlareDecision lists the pending remote flare requests on GET, and approves or denies one of them on
+// POST with the "uuid" and "decision" form values.
+func (f *flare) handleRemoteFlareDecision(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		f.remoteRequests.m.Lock()
+		pending := make([]*remoteFlareRequest, 0, len(f.remoteRequests.pending))
+		for _, req := range f.remoteRequests.pending {
+			pending = append(pending, req)
+		}
+		sort.Slice(pending, func(i, j int) bool { return pending[i].ReceivedAt.Before(pending[j].ReceivedAt) })
+		body, err := json.Marshal(pending)
+		f.remoteRequests.m.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+		return
+	}
+
+	uuid := r.FormValue("uuid")
+	decision := r.FormValue("decision")
+	if decision != remoteFlareDecisionApproved && decision != remoteFlareDecisionDenied {
+		http.Error(w, fmt.Sprintf("invalid decision %q, must be %q or %q", decision, remoteFlareDecisionApproved, remoteFlareDecisionDenied), http.StatusBadRequest)
+		return
+	}
+
+	req := f.takePendingRemoteFlare(uuid)
+	if req == nil {
+		http.Error(w, fmt.Sprintf("no remote flare request %q is waiting for a confirmation", uuid), http.StatusNotFound)
+		return
+	}
+	f.decideRemoteFlare(req, decision, "operator")
+
+	if decision == remoteFlareDecisionApproved {
+		// creating and sending the flare takes time, the CLI does not wait for it
+		go func() {
+			if err := f.sendRemoteFlare(req); err != nil {
+				f.log.Errorf("Unable to send the flare of the remote request %s: %s", req.UUID, err)
+			}
+		}()
+		fmt.Fprintf(w, "The flare of the request %s for case %s is being created and sent", req.UUID, req.CaseID)
+		return
+	}
+	fmt.Fprintf(w, "The flare request %s for case %s was denied", req.UUID, req.CaseID)
+}
+
+// newRemoteFlareRequest returns the audit record of an agent task requesting a flare.
+func newRemoteFlareRequest(uuid, caseID, userHandle string, args map[string]string, policy string, flareArgs types.FlareArgs) *remoteFlareRequest {
+	return &remoteFlareRequest{
+		UUID:       uuid,
+		CaseID:     caseID,
+		UserHandle: userHandle,
+		Args:       maps.Clone(args),
+		ReceivedAt: time.Now(),
+		Policy:     policy,
+		flareArgs:  flareArgs,
+	}
+}
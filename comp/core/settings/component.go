@@ -33,6 +33,22 @@ type RuntimeSettingResponse struct {
 	Hidden      bool
 }
 
+// SettingSourceValue is the value a source sets for a setting
+type SettingSourceValue struct {
+	Source string      `json:"source"`
+	Value  interface{} `json:"value"`
+}
+
+// SettingSourcesResponse is used to communicate the final value of a setting
+// with the source it comes from and the values set by every source, from the
+// lowest priority to the highest
+type SettingSourcesResponse struct {
+	Setting string               `json:"setting"`
+	Value   interface{}          `json:"value"`
+	Source  string               `json:"source"`
+	Sources []SettingSourceValue `json:"sources"`
+}
+
 // Params that the settings component need
 type Params struct {
 	// Settings define the runtime settings the component would understand
@@ -43,6 +59,14 @@ type Params struct {
 	Namespaces []string
 }
 
+// ConfigProvenanceResponse is the effective configuration along with the
+// source that sets each setting
+type ConfigProvenanceResponse struct {
+	// Layers are the sources of settings, from the lowest priority to the highest
+	Layers   []string                           `json:"layers"`
+	Settings map[string]model.SettingProvenance `json:"settings"`
+}
+
 // Component is the component type.
 type Component interface {
 	// RuntimeSettings returns the configurable settings
@@ -61,10 +85,16 @@ type Component interface {
 	GetFullConfigWithoutDefaults(namespaces ...string) http.HandlerFunc
 	// GetFullConfigBySource returns the full config by sources (config, default, env vars ...)
 	GetFullConfigBySource() http.HandlerFunc
+	// GetFullConfigWithProvenance returns the effective value of the settings
+	// along with the source that sets each of them
+	GetFullConfigWithProvenance() http.HandlerFunc
 	// GetValue allows to retrieve the runtime setting
 	GetValue(w http.ResponseWriter, r *http.Request)
 	// SetValue allows to modify the runtime setting
 	SetValue(w http.ResponseWriter, r *http.Request)
+	// GetValueSources returns the value of any setting and the values set by
+	// each of its sources
+	GetValueSources(w http.ResponseWriter, r *http.Request)
 	// ListConfigurable returns the list of configurable setting at runtime
 	ListConfigurable(w http.ResponseWriter, r *http.Request)
 }
@@ -85,5 +85,13 @@ func (m mock) GetValue(http.ResponseWriter, *http.Request) {}
 // SetValue allows to modify the runtime setting
 func (m mock) SetValue(http.ResponseWriter, *http.Request) {}
 
+// GetFullConfigWithProvenance returns the effective config with the source of each setting
+func (m mock) GetFullConfigWithProvenance() http.HandlerFunc {
+	return func(http.ResponseWriter, *http.Request) {}
+}
+
+// GetValueSources returns the value of a setting and the values set by each of its sources
+func (m mock) GetValueSources(http.ResponseWriter, *http.Request) {}
+
 // ListConfigurable returns the list of configurable setting at runtime
 func (m mock) ListConfigurable(http.ResponseWriter, *http.Request) {}
@@ -10,6 +10,8 @@ import (
 	"html"
 	"maps"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -44,6 +46,8 @@ type provides struct {
 	ListEndpoint                api.AgentEndpointProvider
 	GetEndpoint                 api.AgentEndpointProvider
 	SetEndpoint                 api.AgentEndpointProvider
+	SourcesEndpoint             api.AgentEndpointProvider
+	ProvenanceEndpoint          api.AgentEndpointProvider
 }
 
 type dependencies struct {
@@ -184,6 +188,36 @@ func (s *settingsRegistry) GetFullConfigBySource() http.HandlerFunc {
 	}
 }
 
+// GetFullConfigWithProvenance returns the settings that are not set to their
+// default value, or all of them with the include_defaults query parameter,
+// along with the source that sets each of them.
+func (s *settingsRegistry) GetFullConfigWithProvenance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		includeDefaults, _ := strconv.ParseBool(r.URL.Query().Get("include_defaults"))
+		resp := settings.ConfigProvenanceResponse{
+			Settings: model.ConfigProvenance(s.config, includeDefaults),
+		}
+		for _, source := range model.Sources {
+			resp.Layers = append(resp.Layers, source.String())
+		}
+		for setting, provenance := range resp.Settings {
+			provenance.Value = scrubSettingValue(setting, provenance.Value)
+			resp.Settings[setting] = provenance
+		}
+
+		body, err := json.Marshal(resp)
+		if err != nil {
+			s.log.Errorf("Unable to marshal config provenance: %s", err)
+			body, _ := json.Marshal(map[string]string{"error": err.Error()})
+			http.Error(w, string(body), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(body)
+	}
+}
+
 func (s *settingsRegistry) ListConfigurable(w http.ResponseWriter, _ *http.Request) {
 	configurableSettings := make(map[string]settings.RuntimeSettingResponse)
 	for name, setting := range s.RuntimeSettings() {
@@ -257,6 +291,55 @@ func (s *settingsRegistry) SetValue(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// GetValueSources returns the value of any setting, not only the runtime
+// settings, with the source it comes from and the values set by each source.
+func (s *settingsRegistry) GetValueSources(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	setting := vars["setting"]
+
+	if !s.config.IsKnown(setting) {
+		body, _ := json.Marshal(map[string]string{"error": (&settings.SettingNotFoundError{Name: setting}).Error()})
+		http.Error(w, string(body), http.StatusBadRequest)
+		return
+	}
+
+	resp := settings.SettingSourcesResponse{
+		Setting: setting,
+		Value:   scrubSettingValue(setting, s.config.Get(setting)),
+		Source:  s.config.GetSource(setting).String(),
+		Sources: []settings.SettingSourceValue{},
+	}
+	for _, sourceValue := range s.config.GetAllSources(setting) {
+		// sources that do not set the setting are not part of the chain
+		if sourceValue.Value == nil {
+			continue
+		}
+		resp.Sources = append(resp.Sources, settings.SettingSourceValue{
+			Source: sourceValue.Source.String(),
+			Value:  scrubSettingValue(setting, sourceValue.Value),
+		})
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		s.log.Errorf("Unable to marshal setting sources response: %s", err)
+		body, _ := json.Marshal(map[string]string{"error": err.Error()})
+		http.Error(w, string(body), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// scrubSettingValue scrubs the value of a setting. The value is scrubbed under
+// the last part of the setting name, so that values of sensitive settings like
+// api_key are scrubbed even when they are plain strings.
+func scrubSettingValue(setting string, value interface{}) interface{} {
+	name := setting[strings.LastIndex(setting, ".")+1:]
+	data := interface{}(map[string]interface{}{name: deepcopy.Copy(value)})
+	scrubber.ScrubDataObj(&data)
+	return data.(map[string]interface{})[name]
+}
+
 func newSettings(deps dependencies) provides {
 	s := &settingsRegistry{
 		settings: deps.Params.Settings,
@@ -270,5 +353,7 @@ func newSettings(deps dependencies) provides {
 		ListEndpoint:                api.NewAgentEndpointProvider(s.ListConfigurable, "/config/list-runtime", "GET"),
 		GetEndpoint:                 api.NewAgentEndpointProvider(s.GetValue, "/config/{setting}", "GET"),
 		SetEndpoint:                 api.NewAgentEndpointProvider(s.SetValue, "/config/{setting}", "POST"),
+		SourcesEndpoint:             api.NewAgentEndpointProvider(s.GetValueSources, "/config/sources/{setting}", "GET"),
+		ProvenanceEndpoint:          api.NewAgentEndpointProvider(s.GetFullConfigWithProvenance(), "/config/provenance", "GET"),
 	}
 }
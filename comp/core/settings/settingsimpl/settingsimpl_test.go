@@ -329,6 +329,86 @@ func TestRuntimeSettings(t *testing.T) {
 				assert.Equal(t, "{\"error\":\"setting non_existing not found\"}\n", string(body))
 			},
 		},
+		{
+			"GetValueSources",
+			func(t *testing.T, comp settings.Component) {
+				mockConfig := comp.(*settingsRegistry).config
+				mockConfig.Set("log_level", "warn", model.SourceFile)
+				mockConfig.Set("log_level", "debug", model.SourceEnvVar)
+				mockConfig.Set("api_key", "0123456789abcdef0123456789abcdef", model.SourceFile)
+
+				router := mux.NewRouter()
+				router.HandleFunc("/config/sources/{setting}", comp.GetValueSources).Methods("GET")
+				ts := httptest.NewServer(router)
+				defer ts.Close()
+
+				getSources := func(setting string) (int, []byte) {
+					request, err := http.NewRequest("GET", ts.URL+"/config/sources/"+setting, nil)
+					require.NoError(t, err)
+
+					resp, err := ts.Client().Do(request)
+					require.NoError(t, err)
+					body, _ := io.ReadAll(resp.Body)
+					resp.Body.Close()
+					return resp.StatusCode, body
+				}
+
+				code, body := getSources("log_level")
+				assert.Equal(t, 200, code)
+
+				var sources settings.SettingSourcesResponse
+				require.NoError(t, json.Unmarshal(body, &sources))
+				assert.Equal(t, settings.SettingSourcesResponse{
+					Setting: "log_level",
+					Value:   "debug",
+					Source:  "environment-variable",
+					Sources: []settings.SettingSourceValue{
+						{Source: "default", Value: "info"},
+						{Source: "file", Value: "warn"},
+						{Source: "environment-variable", Value: "debug"},
+					},
+				}, sources)
+
+				// secrets are scrubbed
+				code, body = getSources("api_key")
+				assert.Equal(t, 200, code)
+				assert.NotContains(t, string(body), "0123456789abcdef0123456789abcdef")
+
+				code, body = getSources("non_existing")
+				assert.Equal(t, 400, code)
+				assert.Equal(t, "{\"error\":\"setting non_existing not found\"}\n", string(body))
+			},
+		},
+		{
+			"GetFullConfigWithProvenance",
+			func(t *testing.T, comp settings.Component) {
+				mockConfig := comp.(*settingsRegistry).config
+				mockConfig.Set("log_level", "warn", model.SourceFile)
+				mockConfig.Set("log_level", "debug", model.SourceFleetPolicies)
+				mockConfig.Set("api_key", "0123456789abcdef0123456789abcdef", model.SourceFile)
+
+				getProvenance := func(url string) settings.ConfigProvenanceResponse {
+					responseRecorder := httptest.NewRecorder()
+					comp.GetFullConfigWithProvenance()(responseRecorder, httptest.NewRequest("GET", url, nil))
+					assert.Equal(t, 200, responseRecorder.Code)
+					assert.NotContains(t, responseRecorder.Body.String(), "0123456789abcdef0123456789abcdef")
+
+					var provenance settings.ConfigProvenanceResponse
+					require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &provenance))
+					return provenance
+				}
+
+				provenance := getProvenance("http://agent.host/config/provenance")
+				assert.Equal(t, "default", provenance.Layers[0])
+				assert.Contains(t, provenance.Layers, "fleet-policies")
+				assert.Equal(t, model.SettingProvenance{Value: "debug", Source: model.SourceFleetPolicies}, provenance.Settings["log_level"])
+				assert.Equal(t, model.SourceFile, provenance.Settings["api_key"].Source)
+				assert.NotContains(t, provenance.Settings, "hostname")
+
+				provenance = getProvenance("http://agent.host/config/provenance?include_defaults=true")
+				assert.Equal(t, model.SourceDefault, provenance.Settings["hostname"].Source)
+			},
+		},
 		{
 			"SetValue",
 			func(t *testing.T, comp settings.Component) {
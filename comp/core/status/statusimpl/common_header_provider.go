@@ -76,6 +76,7 @@ func (h *headerProvider) data() map[string]interface{} {
 	data["time_nano"] = nowFunc().UnixNano()
 	data["config"] = populateConfig(h.config)
 	data["fips_status"] = populateFIPSStatus(h.config)
+	data["deprecated_settings"] = populateDeprecatedSettings(h.config)
 	pythonVersion := h.params.PythonVersionGetFunc()
 	data["python_version"] = strings.Split(pythonVersion, " ")[0]
 	return data
@@ -107,6 +108,7 @@ func populateConfig(config config.Component) map[string]string {
 	conf := make(map[string]string)
 	conf["log_file"] = config.GetString("log_file")
 	conf["log_level"] = config.GetString("log_level")
+	conf["log_level_expires_at"] = config.GetString("remote_configuration.log_level_expires_at")
 	conf["confd_path"] = config.GetString("confd_path")
 	conf["additional_checksd"] = config.GetString("additional_checksd")
 
@@ -118,6 +120,18 @@ func populateConfig(config config.Component) map[string]string {
 	return conf
 }
 
+func populateDeprecatedSettings(config config.Component) []map[string]string {
+	deprecated := []map[string]string{}
+	for _, usage := range pkgconfigsetup.FindDeprecatedSettings(config) {
+		deprecated = append(deprecated, map[string]string{
+			"key":         usage.Key,
+			"replaced_by": usage.ReplacedBy,
+			"source":      string(usage.Source),
+		})
+	}
+	return deprecated
+}
+
 func populateFIPSStatus(config config.Component) string {
 	fipsStatus := fips.Status()
 	if fipsStatus == "not available" && config.GetString("fips.enabled") == "true" {
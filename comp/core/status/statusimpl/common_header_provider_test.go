@@ -157,6 +157,25 @@ func TestCommonHeaderProviderConfig(t *testing.T) {
 	assertLogLevel(t, provider, "warn")
 }
 
+func TestCommonHeaderProviderDeprecatedSettings(t *testing.T) {
+	config := config.NewMock(t)
+	provider := newCommonHeaderProvider(agentParams, config)
+
+	data := provider.(*headerProvider).data()
+	assert.Empty(t, data["deprecated_settings"])
+
+	config.Set("log_enabled", true, model.SourceFile)
+
+	data = provider.(*headerProvider).data()
+	assert.Equal(t, []map[string]string{
+		{"key": "log_enabled", "replaced_by": "logs_enabled", "source": "file"},
+	}, data["deprecated_settings"])
+
+	b := new(bytes.Buffer)
+	require.NoError(t, provider.Text(false, b))
+	assert.Contains(t, b.String(), "log_enabled (set by file): use logs_enabled instead")
+}
+
 func TestCommonHeaderProviderTextWithFipsInformation(t *testing.T) {
 	nowFunc = func() time.Time { return time.Unix(1515151515, 0) }
 	startTimeProvider = time.Unix(1515151515, 0)
@@ -0,0 +1,253 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package collectors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	taggerdef "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+	grpcutil "github.com/DataDog/datadog-agent/pkg/util/grpc"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	externalGRPCCollectorName = "external-grpc"
+	externalGRPCSource        = externalGRPCCollectorName
+)
+
+// ExternalGRPCCollector streams the tags of entities from an external
+// process, like a CMDB, serving the TaggerStreamEntities RPC of the
+// datadog.api.v1.AgentSecure gRPC service, the same RPC the Agent serves to
+// stream its own tags.
+//
+// The tags received are merged with the tags of the other collectors, with a
+// precedence lower or higher than all of them. They are kept when the
+// connection is lost. Once the stream is established again, the tags of the
+// entities that are not sent again by the server within the grace time are
+// removed.
+type ExternalGRPCCollector struct {
+	address      string
+	conn         *grpc.ClientConn
+	client       pb.AgentSecureClient
+	graceTime    time.Duration
+	tagProcessor taggerdef.Processor
+
+	// entities are the entities the server sent tags for. They are only
+	// accessed by the streaming goroutine.
+	entities map[types.EntityID]struct{}
+}
+
+// NewExternalGRPCCollector returns a new ExternalGRPCCollector streaming from
+// tagger.external_provider.address, or nil if no address is configured. It
+// registers the priority of the collector in CollectorPriorities, so it must be
+// called before the tag store is started.
+func NewExternalGRPCCollector(cfg config.Component, p taggerdef.Processor) (*ExternalGRPCCollector, error) {
+	address := cfg.GetString("tagger.external_provider.address")
+	if address == "" {
+		return nil, nil
+	}
+
+	switch precedence := cfg.GetString("tagger.external_provider.precedence"); precedence {
+	case "low":
+		CollectorPriorities[externalGRPCSource] = types.ExternalLowPriority
+	case "high":
+		CollectorPriorities[externalGRPCSource] = types.ExternalHighPriority
+	default:
+		return nil, fmt.Errorf("invalid tagger.external_provider.precedence %q, must be \"low\" or \"high\"", precedence)
+	}
+
+	opts, err := externalGRPCDialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the gRPC client for %s: %w", address, err)
+	}
+
+	return &ExternalGRPCCollector{
+		address:      address,
+		conn:         conn,
+		client:       pb.NewAgentSecureClient(conn),
+		graceTime:    cfg.GetDuration("tagger.external_provider.grace_time"),
+		tagProcessor: p,
+		entities:     make(map[types.EntityID]struct{}),
+	}, nil
+}
+
+// externalGRPCDialOptions returns the options of the connection to the
+// server. TLS is used as soon as a CA, a client certificate or a token is
+// configured.
+func externalGRPCDialOptions(cfg config.Component) ([]grpc.DialOption, error) {
+	caFile := cfg.GetString("tagger.external_provider.ca_file")
+	certFile := cfg.GetString("tagger.external_provider.cert_file")
+	token := cfg.GetString("tagger.external_provider.token")
+	if caFile == "" && certFile == "" && token == "" {
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read tagger.external_provider.ca_file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in tagger.external_provider.ca_file %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, cfg.GetString("tagger.external_provider.key_file"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate of the external tag provider: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(grpcutil.NewBearerTokenAuth(token)))
+	}
+	return opts, nil
+}
+
+// Run streams the tags of the server until the context is cancelled.
+func (c *ExternalGRPCCollector) Run(ctx context.Context) {
+	defer c.conn.Close()
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = 500 * time.Millisecond
+	expBackoff.MaxInterval = time.Minute
+	expBackoff.MaxElapsedTime = 0
+
+	log.Infof("external tag provider collector started, streaming from %s", c.address)
+
+	for {
+		err := c.stream(ctx, expBackoff.Reset)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warnf("Error streaming tags from the external tag provider %s, retrying: %v", c.address, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(expBackoff.NextBackOff()):
+		}
+	}
+}
+
+// stream opens a stream to the server and processes its responses until the
+// stream is interrupted.
+func (c *ExternalGRPCCollector) stream(ctx context.Context, connected func()) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.TaggerStreamEntities(streamCtx, &pb.StreamTagsRequest{
+		Cardinality: pb.TagCardinality_HIGH,
+		StreamingID: fmt.Sprintf("%s:%s", externalGRPCCollectorName, uuid.New().String()),
+	})
+	if err != nil {
+		return err
+	}
+
+	responses := make(chan *pb.StreamTagsResponse)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case responses <- resp:
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// The tags received before the stream was established are stale until
+	// the server sends them again.
+	stale := make(map[types.EntityID]struct{}, len(c.entities))
+	for entityID := range c.entities {
+		stale[entityID] = struct{}{}
+	}
+	grace := time.NewTimer(c.graceTime)
+	defer grace.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			if err == nil {
+				err = errors.New("stream closed by the server")
+			}
+			return err
+		case resp := <-responses:
+			connected()
+			c.tagProcessor.ProcessTagInfo(c.processResponse(resp, stale))
+		case <-grace.C:
+			tagInfos := make([]*types.TagInfo, 0, len(stale))
+			for entityID := range stale {
+				tagInfos = append(tagInfos, &types.TagInfo{Source: externalGRPCSource, EntityID: entityID, DeleteEntity: true})
+				delete(c.entities, entityID)
+			}
+			clear(stale)
+			if len(tagInfos) > 0 {
+				c.tagProcessor.ProcessTagInfo(tagInfos)
+			}
+		}
+	}
+}
+
+// processResponse returns the tag infos of a response of the server.
+func (c *ExternalGRPCCollector) processResponse(resp *pb.StreamTagsResponse, stale map[types.EntityID]struct{}) []*types.TagInfo {
+	tagInfos := make([]*types.TagInfo, 0, len(resp.GetEvents()))
+	for _, ev := range resp.GetEvents() {
+		entity := ev.GetEntity()
+		if entity.GetId() == nil {
+			continue
+		}
+		entityID := types.NewEntityID(types.EntityIDPrefix(entity.GetId().GetPrefix()), entity.GetId().GetUid())
+		delete(stale, entityID)
+
+		if ev.GetType() == pb.EventType_DELETED {
+			delete(c.entities, entityID)
+			tagInfos = append(tagInfos, &types.TagInfo{Source: externalGRPCSource, EntityID: entityID, DeleteEntity: true})
+			continue
+		}
+
+		c.entities[entityID] = struct{}{}
+		tagInfos = append(tagInfos, &types.TagInfo{
+			Source:               externalGRPCSource,
+			EntityID:             entityID,
+			HighCardTags:         entity.GetHighCardinalityTags(),
+			OrchestratorCardTags: entity.GetOrchestratorCardinalityTags(),
+			LowCardTags:          entity.GetLowCardinalityTags(),
+			StandardTags:         entity.GetStandardTags(),
+		})
+	}
+	return tagInfos
+}
@@ -0,0 +1,148 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package collectors
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+type fakeTagServer struct {
+	pb.UnimplementedAgentSecureServer
+	responses chan *pb.StreamTagsResponse
+}
+
+func (s *fakeTagServer) TaggerStreamEntities(_ *pb.StreamTagsRequest, out pb.AgentSecure_TaggerStreamEntitiesServer) error {
+	for {
+		select {
+		case resp := <-s.responses:
+			if resp == nil {
+				// Interrupt the stream
+				return nil
+			}
+			if err := out.Send(resp); err != nil {
+				return err
+			}
+		case <-out.Context().Done():
+			return nil
+		}
+	}
+}
+
+func startFakeTagServer(t *testing.T, srv *fakeTagServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	pb.RegisterAgentSecureServer(server, srv)
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func tagEvent(eventType pb.EventType, uid string, tags ...string) *pb.StreamTagsResponse {
+	return &pb.StreamTagsResponse{
+		Events: []*pb.StreamTagsEvent{{
+			Type: eventType,
+			Entity: &pb.Entity{
+				Id:                 &pb.EntityId{Prefix: string(types.ContainerID), Uid: uid},
+				LowCardinalityTags: tags,
+			},
+		}},
+	}
+}
+
+func receiveTagInfos(t *testing.T, ch chan []*types.TagInfo) []*types.TagInfo {
+	select {
+	case tagInfos := <-ch:
+		return tagInfos
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "no tag info received")
+		return nil
+	}
+}
+
+func TestNewExternalGRPCCollector(t *testing.T) {
+	collector, err := NewExternalGRPCCollector(config.NewMock(t), &fakeProcessor{})
+	require.NoError(t, err)
+	assert.Nil(t, collector)
+
+	_, err = NewExternalGRPCCollector(config.NewMockWithOverrides(t, map[string]interface{}{
+		"tagger.external_provider.address":    "127.0.0.1:9090",
+		"tagger.external_provider.precedence": "medium",
+	}), &fakeProcessor{})
+	assert.Error(t, err)
+
+	t.Cleanup(func() { delete(CollectorPriorities, externalGRPCSource) })
+	collector, err = NewExternalGRPCCollector(config.NewMockWithOverrides(t, map[string]interface{}{
+		"tagger.external_provider.address":    "127.0.0.1:9090",
+		"tagger.external_provider.precedence": "high",
+	}), &fakeProcessor{})
+	require.NoError(t, err)
+	require.NotNil(t, collector)
+	collector.conn.Close()
+	assert.Equal(t, types.ExternalHighPriority, CollectorPriorities[externalGRPCSource])
+}
+
+func TestExternalGRPCCollectorStream(t *testing.T) {
+	srv := &fakeTagServer{responses: make(chan *pb.StreamTagsResponse)}
+	address := startFakeTagServer(t, srv)
+
+	t.Cleanup(func() { delete(CollectorPriorities, externalGRPCSource) })
+	ch := make(chan []*types.TagInfo, 10)
+	collector, err := NewExternalGRPCCollector(config.NewMockWithOverrides(t, map[string]interface{}{
+		"tagger.external_provider.address":    address,
+		"tagger.external_provider.grace_time": 500 * time.Millisecond,
+	}), &fakeProcessor{ch: ch})
+	require.NoError(t, err)
+	require.NotNil(t, collector)
+	assert.Equal(t, types.ExternalLowPriority, CollectorPriorities[externalGRPCSource])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go collector.Run(ctx)
+
+	srv.responses <- tagEvent(pb.EventType_ADDED, "abc", "team:payments")
+	assert.Equal(t, []*types.TagInfo{{
+		Source:      externalGRPCSource,
+		EntityID:    types.NewEntityID(types.ContainerID, "abc"),
+		LowCardTags: []string{"team:payments"},
+	}}, receiveTagInfos(t, ch))
+
+	srv.responses <- tagEvent(pb.EventType_DELETED, "abc")
+	assert.Equal(t, []*types.TagInfo{{
+		Source:       externalGRPCSource,
+		EntityID:     types.NewEntityID(types.ContainerID, "abc"),
+		DeleteEntity: true,
+	}}, receiveTagInfos(t, ch))
+
+	srv.responses <- tagEvent(pb.EventType_ADDED, "def", "team:search")
+	receiveTagInfos(t, ch)
+	srv.responses <- tagEvent(pb.EventType_ADDED, "ghi", "team:ads")
+	receiveTagInfos(t, ch)
+
+	// Interrupt the stream, only "def" is sent again once it is established again
+	srv.responses <- nil
+	srv.responses <- tagEvent(pb.EventType_ADDED, "def", "team:search")
+	receiveTagInfos(t, ch)
+
+	assert.Equal(t, []*types.TagInfo{{
+		Source:       externalGRPCSource,
+		EntityID:     types.NewEntityID(types.ContainerID, "ghi"),
+		DeleteEntity: true,
+	}}, receiveTagInfos(t, ch))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
 	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	gpuutil "github.com/DataDog/datadog-agent/pkg/util/gpu"
 	"github.com/DataDog/datadog-agent/pkg/util/kubernetes"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
@@ -250,6 +251,13 @@ func (c *WorkloadMetaCollector) handleContainer(ev workloadmeta.Event) []*types.
 		tagList.AddLow(tags.KubeGPUVendor, gpuVendor)
 	}
 
+	// gpu tags from the devices allocated to the container
+	for _, gpu := range c.getAllocatedGPUs(container) {
+		tagList.AddLow(tags.KubeGPUVendor, strings.ToLower(gpu.Vendor))
+		tagList.AddLow(tags.KubeGPUDevice, strings.ToLower(strings.ReplaceAll(gpu.Device, " ", "_")))
+		tagList.AddLow(tags.KubeGPUUUID, strings.ToLower(gpu.ID))
+	}
+
 	// resize policy tags
 	if container.ResizePolicy.CPURestartPolicy != "" {
 		tagList.AddLow(tags.CPURestartPolicy, container.ResizePolicy.CPURestartPolicy)
@@ -587,8 +595,8 @@ func (c *WorkloadMetaCollector) handleECSTask(ev workloadmeta.Event) []*types.Ta
 	// Global tags only updated when a valid ClusterName is provided
 	// There exist edge cases in the metadata API returning a task without cluster info
 	if task.ClusterName != "" {
-		// add global cluster tags to EC2
-		if task.LaunchType == workloadmeta.ECSLaunchTypeEC2 {
+		// add global cluster tags to EC2 and external instances
+		if task.LaunchType != workloadmeta.ECSLaunchTypeFargate {
 			tagInfos = append(tagInfos, &types.TagInfo{
 				Source:               taskSource,
 				EntityID:             types.GetGlobalEntityID(),
@@ -730,6 +738,36 @@ func (c *WorkloadMetaCollector) handleGPU(ev workloadmeta.Event) []*types.TagInf
 	return tagInfos
 }
 
+// getAllocatedGPUs returns the GPUs of the store allocated to the container.
+// The NVIDIA device plugin identifies the allocated devices by their UUID,
+// the AMD one by their PCI bus ID.
+func (c *WorkloadMetaCollector) getAllocatedGPUs(container *workloadmeta.Container) []*workloadmeta.GPU {
+	var gpus []*workloadmeta.GPU
+	var storeGPUs []*workloadmeta.GPU
+	for _, resource := range container.ResolvedAllocatedResources {
+		if _, isGPU := gpuutil.ExtractSimpleGPUName(gpuutil.ResourceGPU(resource.Name)); !isGPU {
+			continue
+		}
+
+		if gpu, err := c.store.GetGPU(resource.ID); err == nil {
+			gpus = append(gpus, gpu)
+			continue
+		}
+
+		if storeGPUs == nil {
+			storeGPUs = c.store.ListGPUs()
+		}
+		for _, gpu := range storeGPUs {
+			if gpu.PCIBusID != "" && strings.EqualFold(gpu.PCIBusID, resource.ID) {
+				gpus = append(gpus, gpu)
+				break
+			}
+		}
+	}
+
+	return gpus
+}
+
 func (c *WorkloadMetaCollector) extractTagsFromPodLabels(pod *workloadmeta.KubernetesPod, tagList *taglist.TagList) {
 	for name, value := range pod.Labels {
 		switch name {
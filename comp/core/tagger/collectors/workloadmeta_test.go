@@ -2328,6 +2328,106 @@ func TestHandleContainer(t *testing.T) {
 	}
 }
 
+func TestHandleContainerAllocatedGPUs(t *testing.T) {
+	store := fxutil.Test[workloadmetamock.Mock](t, fx.Options(
+		fx.Provide(func() log.Component { return logmock.New(t) }),
+		fx.Provide(func() config.Component { return config.NewMock(t) }),
+		workloadmetafxmock.MockModule(workloadmeta.NewParams()),
+	))
+
+	store.Set(&workloadmeta.GPU{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindGPU,
+			ID:   "GPU-00000000-1234-1234-1234-123456789012",
+		},
+		Vendor: "nvidia",
+		Device: "Tesla T4",
+	})
+	store.Set(&workloadmeta.GPU{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindGPU,
+			ID:   "a1b2c3d4e5f60718",
+		},
+		Vendor:   "amd",
+		Device:   "AMD Instinct MI210",
+		PCIBusID: "0000:03:00.0",
+	})
+
+	entityID := workloadmeta.EntityID{
+		Kind: workloadmeta.KindContainer,
+		ID:   "foobar",
+	}
+	taggerEntityID := types.NewEntityID(types.ContainerID, entityID.ID)
+
+	tests := []struct {
+		name      string
+		resources []workloadmeta.ContainerAllocatedResource
+		expected  []string
+	}{
+		{
+			name: "nvidia gpu by uuid",
+			resources: []workloadmeta.ContainerAllocatedResource{
+				{Name: "nvidia.com/gpu", ID: "GPU-00000000-1234-1234-1234-123456789012"},
+			},
+			expected: []string{
+				"gpu_vendor:nvidia",
+				"gpu_device:tesla_t4",
+				"gpu_uuid:gpu-00000000-1234-1234-1234-123456789012",
+			},
+		},
+		{
+			name: "amd gpu by pci bus id",
+			resources: []workloadmeta.ContainerAllocatedResource{
+				{Name: "amd.com/gpu", ID: "0000:03:00.0"},
+			},
+			expected: []string{
+				"gpu_vendor:amd",
+				"gpu_device:amd_instinct_mi210",
+				"gpu_uuid:a1b2c3d4e5f60718",
+			},
+		},
+		{
+			name: "unknown gpu and non-gpu resources",
+			resources: []workloadmeta.ContainerAllocatedResource{
+				{Name: "nvidia.com/gpu", ID: "GPU-unknown"},
+				{Name: "example.com/device", ID: "GPU-00000000-1234-1234-1234-123456789012"},
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := NewWorkloadMetaCollector(context.Background(), configmock.New(t), store, nil)
+
+			actual := collector.handleContainer(workloadmeta.Event{
+				Type: workloadmeta.EventTypeSet,
+				Entity: &workloadmeta.Container{
+					EntityID: entityID,
+					EntityMeta: workloadmeta.EntityMeta{
+						Name: "foobar",
+					},
+					ResolvedAllocatedResources: tt.resources,
+				},
+			})
+
+			assertTagInfoListEqual(t, []*types.TagInfo{
+				{
+					Source:   containerSource,
+					EntityID: taggerEntityID,
+					HighCardTags: []string{
+						"container_name:foobar",
+						"container_id:foobar",
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags:          tt.expected,
+					StandardTags:         []string{},
+				},
+			}, actual)
+		})
+	}
+}
+
 func TestHandleContainerImage(t *testing.T) {
 	entityID := workloadmeta.EntityID{
 		Kind: workloadmeta.KindContainerImageMetadata,
@@ -54,6 +54,7 @@ const (
 
 var (
 	errTaggerStreamNotStarted = errors.New("tagger stream not started")
+	errSequenceGap            = errors.New("gap in the sequence of the tagger stream")
 )
 
 // Requires defines the dependencies for the remote tagger.
@@ -94,6 +95,11 @@ type remoteTagger struct {
 	streamCancel context.CancelFunc
 	filter       *types.Filter
 
+	// deltas is true when the MODIFIED events are requested as deltas.
+	// lastSequence is the sequence of the last response of the stream.
+	deltas       bool
+	lastSequence uint64
+
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -153,6 +159,7 @@ func newRemoteTagger(params tagger.RemoteParams, cfg config.Component, log log.C
 		log:            log,
 		tlsConfig:      ipc.GetTLSClientConfig(),
 		authToken:      ipc.GetAuthToken(),
+		deltas:         cfg.GetBool("remote_tagger.deltas_enabled"),
 	}
 
 	// Override the default TLS config and auth token if provided
@@ -499,6 +506,20 @@ func (t *remoteTagger) run() {
 		t.telemetryStore.Receives.Inc()
 
 		err = t.processResponse(response)
+		if errors.Is(err, errSequenceGap) {
+			// the deltas can no longer be applied, the store is
+			// resynced from the snapshot of a new stream
+			t.streamCancel()
+
+			t.telemetryStore.ClientStreamResyncs.Inc()
+
+			t.ready = false
+			t.stream = nil
+
+			t.log.Warnf("resyncing the remote tagger: %s", err)
+
+			continue
+		}
 		if err != nil {
 			t.log.Warnf("error processing event received from remote tagger: %s", err)
 			continue
@@ -514,6 +535,15 @@ func (t *remoteTagger) processResponse(response *pb.StreamTagsResponse) error {
 		return nil
 	}
 
+	// the server only numbers the responses when it streams deltas
+	deltas := response.Sequence != 0
+	if deltas {
+		if response.Sequence != t.lastSequence+1 {
+			return fmt.Errorf("%w: expected %d, received %d", errSequenceGap, t.lastSequence+1, response.Sequence)
+		}
+		t.lastSequence = response.Sequence
+	}
+
 	events := make([]types.EntityEvent, 0, len(response.Events))
 	for _, ev := range response.Events {
 		eventType, err := convertEventType(ev.Type)
@@ -523,7 +553,7 @@ func (t *remoteTagger) processResponse(response *pb.StreamTagsResponse) error {
 		}
 
 		entity := ev.Entity
-		events = append(events, types.EntityEvent{
+		event := types.EntityEvent{
 			EventType: eventType,
 			Entity: types.Entity{
 				ID:                          types.NewEntityID(types.EntityIDPrefix(entity.Id.Prefix), entity.Id.Uid),
@@ -532,7 +562,16 @@ func (t *remoteTagger) processResponse(response *pb.StreamTagsResponse) error {
 				LowCardinalityTags:          entity.LowCardinalityTags,
 				StandardTags:                entity.StandardTags,
 			},
-		})
+		}
+
+		// a delta of an entity not in the store holds all its tags
+		if deltas && eventType == types.EventTypeModified && t.ready {
+			if stored := t.store.getEntity(event.Entity.ID); stored != nil {
+				event.Entity = applyDelta(stored, event.Entity, ev.RemovedTags)
+			}
+		}
+
+		events = append(events, event)
 	}
 
 	// if the tagger was not ready by this point, it means an error
@@ -589,6 +628,7 @@ func (t *remoteTagger) startTaggerStream(maxElapsed time.Duration) error {
 				Cardinality: pb.TagCardinality(t.filter.GetCardinality()),
 				StreamingID: fmt.Sprintf("%s:%s", flavor.GetFlavor(), uuid.New().String()),
 				Prefixes:    prefixes,
+				Deltas:      t.deltas,
 			})
 
 			if err != nil {
@@ -601,11 +641,40 @@ func (t *remoteTagger) startTaggerStream(maxElapsed time.Duration) error {
 				continue
 			}
 
+			t.lastSequence = 0
+
 			return nil
 		}
 	}
 }
 
+// applyDelta returns the entity with the tags added in added and removed in
+// removed.
+func applyDelta(entity *types.Entity, added types.Entity, removed *pb.Entity) types.Entity {
+	return types.Entity{
+		ID:                          entity.ID,
+		HighCardinalityTags:         applyTagsDelta(entity.HighCardinalityTags, added.HighCardinalityTags, removed.GetHighCardinalityTags()),
+		OrchestratorCardinalityTags: applyTagsDelta(entity.OrchestratorCardinalityTags, added.OrchestratorCardinalityTags, removed.GetOrchestratorCardinalityTags()),
+		LowCardinalityTags:          applyTagsDelta(entity.LowCardinalityTags, added.LowCardinalityTags, removed.GetLowCardinalityTags()),
+		StandardTags:                applyTagsDelta(entity.StandardTags, added.StandardTags, removed.GetStandardTags()),
+	}
+}
+
+func applyTagsDelta(tags, added, removed []string) []string {
+	removedSet := make(map[string]struct{}, len(removed))
+	for _, tag := range removed {
+		removedSet[tag] = struct{}{}
+	}
+
+	result := make([]string, 0, len(tags)+len(added))
+	for _, tag := range tags {
+		if _, found := removedSet[tag]; !found {
+			result = append(result, tag)
+		}
+	}
+	return append(result, added...)
+}
+
 func (t *remoteTagger) writeList(w http.ResponseWriter, _ *http.Request) {
 	response := t.List()
 
@@ -23,11 +23,13 @@ import (
 	ipcmock "github.com/DataDog/datadog-agent/comp/core/ipc/mock"
 	logmock "github.com/DataDog/datadog-agent/comp/core/log/mock"
 	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
+	taggerTelemetry "github.com/DataDog/datadog-agent/comp/core/tagger/telemetry"
 	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
 	nooptelemetry "github.com/DataDog/datadog-agent/comp/core/telemetry/noopsimpl"
 	compdef "github.com/DataDog/datadog-agent/comp/def"
 	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
 	configmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
 )
 
 // TestNewComponent tests that the Remote Tagger can be instantiated and started.
@@ -172,3 +174,62 @@ func TestNewComponentWithOverride(t *testing.T) {
 		assert.Less(t, elapsed, 15*time.Second, "Should not wait excessively long")
 	})
 }
+
+func TestProcessResponseDeltas(t *testing.T) {
+	telemetryStore := taggerTelemetry.NewStore(nooptelemetry.GetCompatComponent())
+	remoteTagger := &remoteTagger{
+		store:          newTagStore(telemetryStore),
+		telemetryStore: telemetryStore,
+		log:            logmock.New(t),
+		deltas:         true,
+	}
+	id := &pb.EntityId{Prefix: string(types.ContainerID), Uid: "foo"}
+	entityID := types.NewEntityID(types.ContainerID, "foo")
+
+	err := remoteTagger.processResponse(&pb.StreamTagsResponse{
+		Sequence: 1,
+		Events: []*pb.StreamTagsEvent{{
+			Type: pb.EventType_ADDED,
+			Entity: &pb.Entity{
+				Id:                  id,
+				LowCardinalityTags:  []string{"env:prod", "image_name:redis"},
+				HighCardinalityTags: []string{"container_id:foo"},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	err = remoteTagger.processResponse(&pb.StreamTagsResponse{
+		Sequence: 2,
+		Events: []*pb.StreamTagsEvent{{
+			Type: pb.EventType_MODIFIED,
+			Entity: &pb.Entity{
+				Id:                  id,
+				LowCardinalityTags:  []string{"team:cache"},
+				HighCardinalityTags: []string{"env:prod"},
+			},
+			RemovedTags: &pb.Entity{
+				LowCardinalityTags: []string{"env:prod"},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	entity := remoteTagger.store.getEntity(entityID)
+	require.NotNil(t, entity)
+	assert.Equal(t, []string{"image_name:redis", "team:cache"}, entity.LowCardinalityTags)
+	assert.Equal(t, []string{"container_id:foo", "env:prod"}, entity.HighCardinalityTags)
+
+	// The keep-alive responses are not numbered
+	require.NoError(t, remoteTagger.processResponse(&pb.StreamTagsResponse{}))
+
+	err = remoteTagger.processResponse(&pb.StreamTagsResponse{
+		Sequence: 4,
+		Events: []*pb.StreamTagsEvent{{
+			Type:   pb.EventType_DELETED,
+			Entity: &pb.Entity{Id: id},
+		}},
+	})
+	assert.ErrorIs(t, err, errSequenceGap)
+	assert.NotNil(t, remoteTagger.store.getEntity(entityID))
+}
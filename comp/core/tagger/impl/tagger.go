@@ -74,6 +74,8 @@ type localTagger struct {
 	log           log.Component
 	cfg           config.Component
 	collector     *collectors.WorkloadMetaCollector
+	// externalCollector is nil when no external tag provider is configured
+	externalCollector *collectors.ExternalGRPCCollector
 
 	datadogConfig              datadogConfig
 	tlmUDPOriginDetectionError coretelemetry.Counter
@@ -123,10 +125,24 @@ func NewComponent(req Requires) (Provides, error) {
 			taggerInstance.tagStore,
 		)
 
+		// Create the collector of the external tag provider, if any. It
+		// registers its priority, so it must be created before the TagStore
+		// starts reading the priorities.
+		externalCollector, err := collectors.NewExternalGRPCCollector(taggerInstance.cfg, taggerInstance.tagStore)
+		if err != nil {
+			req.Log.Errorf("Unable to start the external tag provider collector: %s", err)
+		}
+
 		// Start the TagStore and the WorkloadMeta collector.
 		go taggerInstance.tagStore.Run(taggerInstance.ctx)
 		go taggerInstance.collector.Run(taggerInstance.ctx)
 
+		// Start the collector of the external tag provider, if any.
+		if externalCollector != nil {
+			taggerInstance.externalCollector = externalCollector
+			go taggerInstance.externalCollector.Run(taggerInstance.ctx)
+		}
+
 		return nil
 	}})
 	req.Lc.Append(compdef.Hook{OnStop: func(context.Context) error {
@@ -176,6 +192,17 @@ func newLocalTagger(cfg config.Component, wmeta workloadmeta.Component, log log.
 		tagStore = tagstore.NewTagStore(telemetryStore)
 	}
 
+	tagBudgetMode, err := tagstore.ParseTagBudgetMode(cfg.GetString("tagger.tag_budget.mode"))
+	if err != nil {
+		log.Warnf("%s, defaulting to %q", err, tagBudgetMode)
+	}
+	tagStore.SetTagBudget(tagstore.TagBudget{
+		MaxTagsPerSource: cfg.GetInt("tagger.tag_budget.max_tags_per_source"),
+		MaxTagsPerEntity: cfg.GetInt("tagger.tag_budget.max_tags_per_entity"),
+		MaxTagLength:     cfg.GetInt("tagger.tag_budget.max_tag_length"),
+		Mode:             tagBudgetMode,
+	})
+
 	// we use to pull tagger metrics in dogstatsd. Pulling it later in the
 	// pipeline improve memory allocation. We kept the old name to be
 	// backward compatible and because origin detection only affect
@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"github.com/DataDog/datadog-agent/comp/core/tagger/proto"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+// entityDeltas turns the events of the entities streamed to a client into
+// deltas, keeping the tags last streamed for each entity. The ADDED events
+// and the MODIFIED events of the entities not streamed yet hold all the tags
+// of the entity, the other MODIFIED events only the tags added and removed.
+type entityDeltas struct {
+	entities map[types.EntityID]types.Entity
+}
+
+func newEntityDeltas() *entityDeltas {
+	return &entityDeltas{
+		entities: make(map[types.EntityID]types.Entity),
+	}
+}
+
+// toPbEvent returns the event to stream for an entity event, or false if the
+// tags of the entity did not change since its previous event.
+func (d *entityDeltas) toPbEvent(event types.EntityEvent) (*pb.StreamTagsEvent, bool, error) {
+	entity := event.Entity
+
+	if event.EventType == types.EventTypeDeleted {
+		delete(d.entities, entity.ID)
+		e, err := proto.Tagger2PbEntityEvent(event)
+		return e, true, err
+	}
+
+	previous, found := d.entities[entity.ID]
+	d.entities[entity.ID] = entity
+	if event.EventType != types.EventTypeModified || !found {
+		e, err := proto.Tagger2PbEntityEvent(event)
+		return e, true, err
+	}
+
+	entityID, err := proto.Tagger2PbEntityID(entity.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	added := &pb.Entity{Id: entityID}
+	removed := &pb.Entity{}
+	added.HighCardinalityTags, removed.HighCardinalityTags = diffTags(previous.HighCardinalityTags, entity.HighCardinalityTags)
+	added.OrchestratorCardinalityTags, removed.OrchestratorCardinalityTags = diffTags(previous.OrchestratorCardinalityTags, entity.OrchestratorCardinalityTags)
+	added.LowCardinalityTags, removed.LowCardinalityTags = diffTags(previous.LowCardinalityTags, entity.LowCardinalityTags)
+	added.StandardTags, removed.StandardTags = diffTags(previous.StandardTags, entity.StandardTags)
+
+	if len(added.HighCardinalityTags)+len(added.OrchestratorCardinalityTags)+len(added.LowCardinalityTags)+len(added.StandardTags) == 0 &&
+		len(removed.HighCardinalityTags)+len(removed.OrchestratorCardinalityTags)+len(removed.LowCardinalityTags)+len(removed.StandardTags) == 0 {
+		return nil, false, nil
+	}
+
+	return &pb.StreamTagsEvent{
+		Type:        pb.EventType_MODIFIED,
+		Entity:      added,
+		RemovedTags: removed,
+	}, true, nil
+}
+
+// diffTags returns the tags added to and removed from previous in current.
+func diffTags(previous, current []string) (added, removed []string) {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, tag := range previous {
+		previousSet[tag] = struct{}{}
+	}
+
+	for _, tag := range current {
+		if _, found := previousSet[tag]; found {
+			delete(previousSet, tag)
+			continue
+		}
+		added = append(added, tag)
+	}
+
+	// Keep the order of previous for the removed tags
+	for _, tag := range previous {
+		if _, found := previousSet[tag]; found {
+			removed = append(removed, tag)
+			delete(previousSet, tag)
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+func TestEntityDeltas(t *testing.T) {
+	entityID := types.NewEntityID(types.ContainerID, "foo")
+	deltas := newEntityDeltas()
+
+	e, changed, err := deltas.toPbEvent(types.EntityEvent{
+		EventType: types.EventTypeAdded,
+		Entity: types.Entity{
+			ID:                  entityID,
+			LowCardinalityTags:  []string{"env:prod", "image_name:redis"},
+			HighCardinalityTags: []string{"container_id:foo"},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	assert.Equal(t, pb.EventType_ADDED, e.Type)
+	assert.Equal(t, []string{"env:prod", "image_name:redis"}, e.Entity.LowCardinalityTags)
+	assert.Nil(t, e.RemovedTags)
+
+	// A tag moved to another cardinality is removed from one and added to
+	// the other
+	e, changed, err = deltas.toPbEvent(types.EntityEvent{
+		EventType: types.EventTypeModified,
+		Entity: types.Entity{
+			ID:                  entityID,
+			LowCardinalityTags:  []string{"image_name:redis", "team:cache"},
+			HighCardinalityTags: []string{"container_id:foo", "env:prod"},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	assert.Equal(t, pb.EventType_MODIFIED, e.Type)
+	assert.Equal(t, "foo", e.Entity.Id.Uid)
+	assert.Equal(t, []string{"team:cache"}, e.Entity.LowCardinalityTags)
+	assert.Equal(t, []string{"env:prod"}, e.Entity.HighCardinalityTags)
+	assert.Equal(t, []string{"env:prod"}, e.RemovedTags.LowCardinalityTags)
+	assert.Empty(t, e.RemovedTags.HighCardinalityTags)
+
+	_, changed, err = deltas.toPbEvent(types.EntityEvent{
+		EventType: types.EventTypeModified,
+		Entity: types.Entity{
+			ID:                  entityID,
+			LowCardinalityTags:  []string{"team:cache", "image_name:redis"},
+			HighCardinalityTags: []string{"container_id:foo", "env:prod"},
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	e, changed, err = deltas.toPbEvent(types.EntityEvent{
+		EventType: types.EventTypeDeleted,
+		Entity:    types.Entity{ID: entityID},
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	assert.Equal(t, pb.EventType_DELETED, e.Type)
+
+	// An entity modified after it was deleted is streamed with all its tags
+	e, changed, err = deltas.toPbEvent(types.EntityEvent{
+		EventType: types.EventTypeModified,
+		Entity: types.Entity{
+			ID:                 entityID,
+			LowCardinalityTags: []string{"env:prod"},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	assert.Equal(t, []string{"env:prod"}, e.Entity.LowCardinalityTags)
+	assert.Nil(t, e.RemovedTags)
+}
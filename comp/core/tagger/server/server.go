@@ -52,6 +52,10 @@ func NewServer(t tagger.Component, telemetry telemetry.Component, maxEventSize i
 // TaggerStreamEntities subscribes to added, removed, or changed entities in the Tagger
 // and streams them to clients as pb.StreamTagsResponse events. Filtering is as
 // of yet not implemented.
+//
+// When the client requests deltas, the MODIFIED events only hold the tags
+// added to and removed from the entity, and the responses are numbered so
+// that the client can detect a gap and resync by opening a new stream.
 func (s *Server) TaggerStreamEntities(in *pb.StreamTagsRequest, out pb.AgentSecure_TaggerStreamEntitiesServer) error {
 	cardinality, err := proto.Pb2TaggerCardinality(in.GetCardinality())
 	if err != nil {
@@ -124,11 +128,22 @@ func (s *Server) TaggerStreamEntities(in *pb.StreamTagsRequest, out pb.AgentSecu
 
 	defer subscription.Unsubscribe()
 
+	var deltas *entityDeltas
+	if in.GetDeltas() {
+		deltas = newEntityDeltas()
+	}
+
+	var sequence uint64
 	sendFunc := func(chunk []*pb.StreamTagsEvent) error {
+		response := &pb.StreamTagsResponse{
+			Events: chunk,
+		}
+		if deltas != nil {
+			sequence++
+			response.Sequence = sequence
+		}
 		return grpc.DoWithTimeout(func() error {
-			return out.Send(&pb.StreamTagsResponse{
-				Events: chunk,
-			})
+			return out.Send(response)
 		}, taggerStreamSendTimeout)
 	}
 
@@ -144,11 +159,21 @@ func (s *Server) TaggerStreamEntities(in *pb.StreamTagsRequest, out pb.AgentSecu
 
 			responseEvents := make([]*pb.StreamTagsEvent, 0, len(events))
 			for _, event := range events {
-				e, err := proto.Tagger2PbEntityEvent(event)
+				var e *pb.StreamTagsEvent
+				var err error
+				changed := true
+				if deltas != nil {
+					e, changed, err = deltas.toPbEvent(event)
+				} else {
+					e, err = proto.Tagger2PbEntityEvent(event)
+				}
 				if err != nil {
 					log.Warnf("can't convert tagger entity to protobuf: %s", err)
 					continue
 				}
+				if !changed {
+					continue
+				}
 
 				responseEvents = append(responseEvents, e)
 			}
@@ -0,0 +1,206 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tagstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// TagBudgetMode is the way the tag budget is enforced when the tags of an
+// entity exceed it.
+type TagBudgetMode string
+
+const (
+	// TagBudgetModeWarn keeps the tags and reports the violation
+	TagBudgetModeWarn TagBudgetMode = "warn"
+	// TagBudgetModeTruncate truncates the tags that are too long and drops
+	// the tags over the limits, high cardinality tags first
+	TagBudgetModeTruncate TagBudgetMode = "truncate"
+	// TagBudgetModeDropSource drops all the tags of the source exceeding the
+	// budget for the entity
+	TagBudgetModeDropSource TagBudgetMode = "drop_source"
+)
+
+const (
+	tagBudgetLimitTagLength     = "tag_length"
+	tagBudgetLimitTagsPerSource = "tags_per_source"
+	tagBudgetLimitTagsPerEntity = "tags_per_entity"
+)
+
+// TagBudget limits the number and the length of the tags generated for an
+// entity, so that a misbehaving set of labels can't explode the cardinality
+// of the metrics. A zero limit is unlimited. The standard tags are not
+// counted, as they are also low cardinality tags.
+type TagBudget struct {
+	// MaxTagsPerSource is the maximum number of tags of an entity from a
+	// single source
+	MaxTagsPerSource int
+	// MaxTagsPerEntity is the maximum number of tags of an entity from all
+	// the sources
+	MaxTagsPerEntity int
+	// MaxTagLength is the maximum length of a tag, in bytes
+	MaxTagLength int
+	Mode         TagBudgetMode
+}
+
+// ParseTagBudgetMode returns the TagBudgetMode named mode.
+func ParseTagBudgetMode(mode string) (TagBudgetMode, error) {
+	switch m := TagBudgetMode(mode); m {
+	case TagBudgetModeWarn, TagBudgetModeTruncate, TagBudgetModeDropSource:
+		return m, nil
+	default:
+		return TagBudgetModeWarn, fmt.Errorf("invalid tag budget mode %q, must be %q, %q or %q", mode, TagBudgetModeWarn, TagBudgetModeTruncate, TagBudgetModeDropSource)
+	}
+}
+
+func (b TagBudget) isUnlimited() bool {
+	return b.MaxTagsPerSource <= 0 && b.MaxTagsPerEntity <= 0 && b.MaxTagLength <= 0
+}
+
+// SetTagBudget sets the budget enforced on the tags processed by the store.
+func (s *TagStore) SetTagBudget(budget TagBudget) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.tagBudget = budget
+}
+
+// enforceTagBudget returns the tags of an entity from a source, within the tag
+// budget. storedTags are the tags of the entity from all the sources, nil for
+// a new entity.
+func (s *TagStore) enforceTagBudget(entityID types.EntityID, source string, st sourceTags, storedTags EntityTags) sourceTags {
+	budget := s.tagBudget
+	if budget.isUnlimited() {
+		return st
+	}
+
+	if budget.MaxTagLength > 0 && st.hasTagLongerThan(budget.MaxTagLength) {
+		s.tagBudgetExceeded(entityID, source, tagBudgetLimitTagLength, fmt.Sprintf("tags longer than %d bytes", budget.MaxTagLength))
+		switch budget.Mode {
+		case TagBudgetModeTruncate:
+			st = st.truncateTags(budget.MaxTagLength)
+		case TagBudgetModeDropSource:
+			return sourceTags{expiryDate: st.expiryDate}
+		}
+	}
+
+	if budget.MaxTagsPerSource > 0 && st.count() > budget.MaxTagsPerSource {
+		s.tagBudgetExceeded(entityID, source, tagBudgetLimitTagsPerSource, fmt.Sprintf("%d tags over a limit of %d", st.count(), budget.MaxTagsPerSource))
+		switch budget.Mode {
+		case TagBudgetModeTruncate:
+			st = st.keepFirst(budget.MaxTagsPerSource)
+		case TagBudgetModeDropSource:
+			return sourceTags{expiryDate: st.expiryDate}
+		}
+	}
+
+	if budget.MaxTagsPerEntity > 0 {
+		remaining := budget.MaxTagsPerEntity
+		if storedTags != nil {
+			for _, otherSource := range storedTags.sources() {
+				if otherSource == source {
+					continue
+				}
+				if otherTags := storedTags.tagsForSource(otherSource); otherTags != nil {
+					remaining -= otherTags.count()
+				}
+			}
+		}
+		remaining = max(remaining, 0)
+
+		if st.count() > remaining {
+			s.tagBudgetExceeded(entityID, source, tagBudgetLimitTagsPerEntity, fmt.Sprintf("%d tags with %d left for the entity over a limit of %d", st.count(), remaining, budget.MaxTagsPerEntity))
+			switch budget.Mode {
+			case TagBudgetModeTruncate:
+				st = st.keepFirst(remaining)
+			case TagBudgetModeDropSource:
+				return sourceTags{expiryDate: st.expiryDate}
+			}
+		}
+	}
+
+	return st
+}
+
+func (s *TagStore) tagBudgetExceeded(entityID types.EntityID, source string, limit string, reason string) {
+	if s.telemetryStore != nil {
+		s.telemetryStore.TagBudgetExceeded.Inc(source, limit, string(s.tagBudget.Mode))
+	}
+
+	if s.tagBudgetLogLimit.ShouldLog() {
+		action := "keeping them"
+		switch s.tagBudget.Mode {
+		case TagBudgetModeTruncate:
+			action = "truncating them"
+		case TagBudgetModeDropSource:
+			action = "dropping them"
+		}
+		log.Warnf("Tags of entity %s from source %s exceed the tag budget, %s: %s", entityID, source, action, reason)
+	}
+}
+
+// count returns the number of tags, the standard tags being also low
+// cardinality tags.
+func (st *sourceTags) count() int {
+	return len(st.lowCardTags) + len(st.orchestratorCardTags) + len(st.highCardTags)
+}
+
+func (st *sourceTags) hasTagLongerThan(length int) bool {
+	for _, tags := range [][]string{st.lowCardTags, st.orchestratorCardTags, st.highCardTags, st.standardTags} {
+		for _, tag := range tags {
+			if len(tag) > length {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// truncateTags returns a copy of the tags truncated to length bytes.
+func (st sourceTags) truncateTags(length int) sourceTags {
+	truncate := func(tags []string) []string {
+		if tags == nil {
+			return nil
+		}
+		truncated := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if len(tag) > length {
+				tag = strings.ToValidUTF8(tag[:length], "")
+			}
+			truncated = append(truncated, tag)
+		}
+		return truncated
+	}
+
+	st.lowCardTags = truncate(st.lowCardTags)
+	st.orchestratorCardTags = truncate(st.orchestratorCardTags)
+	st.highCardTags = truncate(st.highCardTags)
+	st.standardTags = truncate(st.standardTags)
+	return st
+}
+
+// keepFirst returns the first n tags, the low cardinality tags first and the
+// high cardinality tags last.
+func (st sourceTags) keepFirst(n int) sourceTags {
+	keep := func(tags []string) []string {
+		if len(tags) <= n {
+			n -= len(tags)
+			return tags
+		}
+		kept := tags[:n:n]
+		n = 0
+		return kept
+	}
+
+	st.lowCardTags = keep(st.lowCardTags)
+	st.orchestratorCardTags = keep(st.orchestratorCardTags)
+	st.highCardTags = keep(st.highCardTags)
+	return st
+}
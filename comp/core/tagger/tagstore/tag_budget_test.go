@@ -0,0 +1,120 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tagstore
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	taggerTelemetry "github.com/DataDog/datadog-agent/comp/core/tagger/telemetry"
+	"github.com/DataDog/datadog-agent/comp/core/tagger/types"
+	"github.com/DataDog/datadog-agent/comp/core/telemetry"
+	"github.com/DataDog/datadog-agent/comp/core/telemetry/telemetryimpl"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestTagBudget(t *testing.T) {
+	entityID := types.NewEntityID(types.ContainerID, "test")
+	otherSourceTags := &types.TagInfo{
+		Source:      "source1",
+		EntityID:    entityID,
+		LowCardTags: []string{"env:prod", "team:payments"},
+	}
+	tagInfo := &types.TagInfo{
+		Source:               "source2",
+		EntityID:             entityID,
+		LowCardTags:          []string{"service:api", "label:" + "aaaaaaaaaaaaaaaaaaaa"},
+		OrchestratorCardTags: []string{"pod_name:api-1"},
+		HighCardTags:         []string{"container_id:abc", "request_id:123"},
+	}
+
+	tests := []struct {
+		name         string
+		budget       TagBudget
+		expectedTags sourceTags
+		exceeded     map[string]float64
+	}{
+		{
+			name:   "unlimited",
+			budget: TagBudget{Mode: TagBudgetModeTruncate},
+			expectedTags: sourceTags{
+				lowCardTags:          []string{"service:api", "label:aaaaaaaaaaaaaaaaaaaa"},
+				orchestratorCardTags: []string{"pod_name:api-1"},
+				highCardTags:         []string{"container_id:abc", "request_id:123"},
+			},
+		},
+		{
+			name:   "warn",
+			budget: TagBudget{MaxTagsPerSource: 2, MaxTagLength: 10, Mode: TagBudgetModeWarn},
+			expectedTags: sourceTags{
+				lowCardTags:          []string{"service:api", "label:aaaaaaaaaaaaaaaaaaaa"},
+				orchestratorCardTags: []string{"pod_name:api-1"},
+				highCardTags:         []string{"container_id:abc", "request_id:123"},
+			},
+			exceeded: map[string]float64{tagBudgetLimitTagsPerSource: 1, tagBudgetLimitTagLength: 1},
+		},
+		{
+			name:   "truncate tag length",
+			budget: TagBudget{MaxTagLength: 16, Mode: TagBudgetModeTruncate},
+			expectedTags: sourceTags{
+				lowCardTags:          []string{"service:api", "label:aaaaaaaaaa"},
+				orchestratorCardTags: []string{"pod_name:api-1"},
+				highCardTags:         []string{"container_id:abc", "request_id:123"},
+			},
+			exceeded: map[string]float64{tagBudgetLimitTagLength: 1},
+		},
+		{
+			name:   "truncate tags per source",
+			budget: TagBudget{MaxTagsPerSource: 4, Mode: TagBudgetModeTruncate},
+			expectedTags: sourceTags{
+				lowCardTags:          []string{"service:api", "label:aaaaaaaaaaaaaaaaaaaa"},
+				orchestratorCardTags: []string{"pod_name:api-1"},
+				highCardTags:         []string{"container_id:abc"},
+			},
+			exceeded: map[string]float64{tagBudgetLimitTagsPerSource: 1},
+		},
+		{
+			name:   "truncate tags per entity",
+			budget: TagBudget{MaxTagsPerEntity: 4, Mode: TagBudgetModeTruncate},
+			expectedTags: sourceTags{
+				lowCardTags:          []string{"service:api", "label:aaaaaaaaaaaaaaaaaaaa"},
+				orchestratorCardTags: []string{},
+				highCardTags:         []string{},
+			},
+			exceeded: map[string]float64{tagBudgetLimitTagsPerEntity: 1},
+		},
+		{
+			name:         "drop source",
+			budget:       TagBudget{MaxTagsPerEntity: 4, Mode: TagBudgetModeDropSource},
+			expectedTags: sourceTags{},
+			exceeded:     map[string]float64{tagBudgetLimitTagsPerEntity: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tel := fxutil.Test[telemetry.Component](t, telemetryimpl.MockModule())
+			telemetryStore := taggerTelemetry.NewStore(tel)
+			store := newTagStoreWithClock(clock.NewMock(), telemetryStore)
+			store.SetTagBudget(test.budget)
+
+			store.ProcessTagInfo([]*types.TagInfo{otherSourceTags, tagInfo})
+
+			storedTags, exists := store.store.Get(entityID)
+			require.True(t, exists)
+			assert.Equal(t, &test.expectedTags, storedTags.tagsForSource("source2"))
+			assert.Equal(t, []string{"env:prod", "team:payments"}, storedTags.tagsForSource("source1").lowCardTags)
+
+			for _, limit := range []string{tagBudgetLimitTagLength, tagBudgetLimitTagsPerSource, tagBudgetLimitTagsPerEntity} {
+				count := telemetryStore.TagBudgetExceeded.WithValues("source2", limit, string(test.budget.Mode)).Get()
+				assert.Equal(t, test.exceeded[limit], count, limit)
+			}
+		})
+	}
+}
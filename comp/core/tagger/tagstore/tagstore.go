@@ -45,6 +45,9 @@ type TagStore struct {
 	clock clock.Clock
 
 	telemetryStore *telemetry.Store
+
+	tagBudget         TagBudget
+	tagBudgetLogLimit *log.Limit
 }
 
 // NewTagStore creates new LocalTaggerTagStore.
@@ -60,7 +63,8 @@ func newTagStoreWithClock(clock clock.Clock, telemetryStore *telemetry.Store) *T
 		clock:               clock,
 		// telemetryStore is optional. If it is nil, we will not collect
 		// telemetry. The fake tagger does not have a telemetry store.
-		telemetryStore: telemetryStore,
+		telemetryStore:    telemetryStore,
+		tagBudgetLogLimit: log.NewLogLimit(10, 10*time.Minute),
 	}
 }
 
@@ -126,6 +130,7 @@ func (s *TagStore) ProcessTagInfo(tagInfos []*types.TagInfo) {
 			standardTags:         info.StandardTags,
 			expiryDate:           info.ExpiryDate,
 		}
+		newSt = s.enforceTagBudget(info.EntityID, info.Source, newSt, storedTags)
 
 		eventType := types.EventTypeModified
 		if exist {
@@ -44,6 +44,10 @@ type Store struct {
 	// tagger events.
 	ClientStreamErrors telemetry.Counter
 
+	// ClientStreamResyncs tracks how many times the remote tagger resynced
+	// after a gap in the sequence of the deltas streamed.
+	ClientStreamResyncs telemetry.Counter
+
 	// Subscribers tracks how many subscribers the tagger has.
 	Subscribers telemetry.Gauge
 	// Events tracks the number of tagger events being sent out.
@@ -61,6 +65,10 @@ type Store struct {
 	// to generate a container ID from Origin Info.
 	OriginInfoRequests telemetry.Counter
 
+	// TagBudgetExceeded tracks the number of times the tags of an entity
+	// from a source exceeded the tag budget.
+	TagBudgetExceeded telemetry.Counter
+
 	LowCardinalityQueries          CardinalityTelemetry
 	OrchestratorCardinalityQueries CardinalityTelemetry
 	HighCardinalityQueries         CardinalityTelemetry
@@ -99,6 +107,13 @@ func NewStore(telemetryComp telemetry.Component) *Store {
 			[]string{}, "Errors received when streaming tagger events",
 			commonOpts),
 
+		// ClientStreamResyncs tracks how many times the remote tagger
+		// resynced after a gap in the sequence of the deltas streamed.
+		// Remote
+		ClientStreamResyncs: telemetryComp.NewCounterWithOpts(subsystem, "client_stream_resyncs",
+			[]string{}, "Resyncs after a gap in the sequence of the tagger deltas streamed",
+			commonOpts),
+
 		// Subscribers tracks how many subscribers the tagger has.
 		Subscribers: telemetryComp.NewGaugeWithOpts(subsystem, "subscribers",
 			[]string{}, "Number of channels subscribing to tagger events",
@@ -128,6 +143,12 @@ func NewStore(telemetryComp telemetry.Component) *Store {
 			[]string{"status"}, "Number of requests to the tagger to generate a container ID from origin info.",
 			commonOpts),
 
+		// TagBudgetExceeded tracks the number of times the tags of an
+		// entity from a source exceeded the tag budget.
+		TagBudgetExceeded: telemetryComp.NewCounterWithOpts(subsystem, "tag_budget_exceeded",
+			[]string{"source", "limit", "mode"}, "Number of times the tags of an entity from a source exceeded the tag budget.",
+			commonOpts),
+
 		LowCardinalityQueries:          newCardinalityTelemetry(queries, types.LowCardinalityString),
 		OrchestratorCardinalityQueries: newCardinalityTelemetry(queries, types.OrchestratorCardinalityString),
 		HighCardinalityQueries:         newCardinalityTelemetry(queries, types.HighCardinalityString),
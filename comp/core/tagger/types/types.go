@@ -68,6 +68,12 @@ const (
 	ClusterOrchestrator
 )
 
+// Priorities of an external tag provider, lower or higher than the priorities of all the other collectors
+const (
+	ExternalLowPriority  CollectorPriority = NodeRuntime - 1
+	ExternalHighPriority CollectorPriority = ClusterOrchestrator + 1
+)
+
 // TagCardinality indicates the cardinality-level of a tag.
 // It can be low cardinality (in the host count order of magnitude)
 // orchestrator cardinality (tags that change value for each pod, task, etc.)
@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package amdgpu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/fx"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/errors"
+	"github.com/DataDog/datadog-agent/pkg/util/kernel"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	collectorID   = "amdgpu"
+	componentName = "workloadmeta-amdgpu"
+	amdVendor     = "amd"
+
+	// amdPCIVendorID is the PCI vendor ID of AMD
+	amdPCIVendorID = "0x1002"
+)
+
+// cardPattern matches the DRM cards, not their connectors (e.g., card0-DP-1)
+var cardPattern = regexp.MustCompile(`^card(\d+)$`)
+
+type collector struct {
+	id        string
+	catalog   workloadmeta.AgentType
+	store     workloadmeta.Component
+	sysfsRoot string
+	seenIDs   map[string]struct{}
+}
+
+// NewCollector returns an AMD GPU CollectorProvider that instantiates its collector
+func NewCollector() (workloadmeta.CollectorProvider, error) {
+	return workloadmeta.CollectorProvider{
+		Collector: &collector{
+			id:        collectorID,
+			catalog:   workloadmeta.NodeAgent,
+			sysfsRoot: kernel.HostSys(),
+			seenIDs:   map[string]struct{}{},
+		},
+	}, nil
+}
+
+// GetFxOptions returns the FX framework options for the collector
+func GetFxOptions() fx.Option {
+	return fx.Provide(NewCollector)
+}
+
+// Start checks that AMD GPUs are available and sets the store
+func (c *collector) Start(_ context.Context, store workloadmeta.Component) error {
+	gpus, err := c.listGPUs()
+	if err != nil {
+		return errors.NewDisabled(componentName, fmt.Sprintf("cannot list the DRM devices: %v", err))
+	}
+	if len(gpus) == 0 {
+		return errors.NewDisabled(componentName, "no AMD GPU found")
+	}
+
+	c.store = store
+
+	return nil
+}
+
+// Pull collects the AMD GPUs available on the node and notifies the store
+func (c *collector) Pull(_ context.Context) error {
+	gpus, err := c.listGPUs()
+	if err != nil {
+		return fmt.Errorf("failed to list the AMD GPUs: %w", err)
+	}
+
+	// note: the device list can change over time so we need to set/unset for reconciliation
+	currentIDs := map[string]struct{}{}
+	events := make([]workloadmeta.CollectorEvent, 0, len(gpus))
+	for _, gpu := range gpus {
+		events = append(events, workloadmeta.CollectorEvent{
+			Source: workloadmeta.SourceRuntime,
+			Type:   workloadmeta.EventTypeSet,
+			Entity: gpu,
+		})
+		currentIDs[gpu.ID] = struct{}{}
+	}
+
+	for id := range c.seenIDs {
+		if _, ok := currentIDs[id]; ok {
+			continue
+		}
+
+		events = append(events, workloadmeta.CollectorEvent{
+			Source: workloadmeta.SourceRuntime,
+			Type:   workloadmeta.EventTypeUnset,
+			Entity: &workloadmeta.GPU{
+				EntityID: workloadmeta.EntityID{
+					ID:   id,
+					Kind: workloadmeta.KindGPU,
+				},
+			},
+		})
+	}
+
+	c.seenIDs = currentIDs
+
+	c.store.Notify(events)
+
+	return nil
+}
+
+// listGPUs returns the AMD GPUs of the DRM subsystem, by card number.
+func (c *collector) listGPUs() ([]*workloadmeta.GPU, error) {
+	drmDir := filepath.Join(c.sysfsRoot, "class", "drm")
+	entries, err := os.ReadDir(drmDir)
+	if err != nil {
+		return nil, err
+	}
+
+	type card struct {
+		number int
+		path   string
+	}
+	var cards []card
+	for _, entry := range entries {
+		match := cardPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		cards = append(cards, card{number: number, path: filepath.Join(drmDir, entry.Name(), "device")})
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].number < cards[j].number })
+
+	driverVersion := readSysfsFile(filepath.Join(c.sysfsRoot, "module", "amdgpu", "version"))
+
+	var gpus []*workloadmeta.GPU
+	for _, card := range cards {
+		if readSysfsFile(filepath.Join(card.path, "vendor")) != amdPCIVendorID {
+			continue
+		}
+
+		gpu := getGPUDeviceInfo(card.path)
+		if gpu.ID == "" {
+			log.Debugf("cannot identify the AMD GPU of %s", card.path)
+			continue
+		}
+		gpu.Index = len(gpus)
+		gpu.DriverVersion = driverVersion
+		gpus = append(gpus, gpu)
+	}
+
+	return gpus, nil
+}
+
+// getGPUDeviceInfo returns the GPU of the PCI device at devicePath. The GPU
+// is identified by its unique ID, when the device exposes it, and by its PCI
+// bus ID otherwise.
+func getGPUDeviceInfo(devicePath string) *workloadmeta.GPU {
+	var pciBusID string
+	if resolved, err := filepath.EvalSymlinks(devicePath); err == nil {
+		pciBusID = filepath.Base(resolved)
+	} else {
+		log.Debugf("cannot resolve the PCI device of %s: %v", devicePath, err)
+	}
+
+	id := readSysfsFile(filepath.Join(devicePath, "unique_id"))
+	if id == "" {
+		id = pciBusID
+	}
+
+	name := readSysfsFile(filepath.Join(devicePath, "product_name"))
+	if name == "" {
+		name = "AMD GPU " + readSysfsFile(filepath.Join(devicePath, "device"))
+	}
+
+	gpu := &workloadmeta.GPU{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindGPU,
+			ID:   id,
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: name,
+		},
+		Vendor:     amdVendor,
+		Device:     name,
+		DeviceType: workloadmeta.GPUDeviceTypePhysical,
+		PCIBusID:   pciBusID,
+	}
+
+	if vram := readSysfsFile(filepath.Join(devicePath, "mem_info_vram_total")); vram != "" {
+		if totalMemory, err := strconv.ParseUint(vram, 10, 64); err == nil {
+			gpu.TotalMemory = totalMemory
+		}
+	}
+
+	return gpu
+}
+
+// readSysfsFile returns the trimmed content of a sysfs file, or an empty
+// string if it cannot be read.
+func readSysfsFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+func (c *collector) GetID() string {
+	return c.id
+}
+
+func (c *collector) GetTargetCatalog() workloadmeta.AgentType {
+	return c.catalog
+}
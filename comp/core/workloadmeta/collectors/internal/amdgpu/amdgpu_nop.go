@@ -3,10 +3,9 @@
 // This product includes software developed at Datadog (https://www.datadoghq.com/).
 // Copyright 2016-present Datadog, Inc.
 
-//go:build !docker
+//go:build !linux
 
-// Package ecsfargate provides the ecsfargate colletor for workloadmeta
-package ecsfargate
+package amdgpu
 
 import "go.uber.org/fx"
 
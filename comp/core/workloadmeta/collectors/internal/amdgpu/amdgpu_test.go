@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux && test
+
+package amdgpu
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	logmock "github.com/DataDog/datadog-agent/comp/core/log/mock"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	workloadmetafxmock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/fx-mock"
+	workloadmetamock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/mock"
+	"github.com/DataDog/datadog-agent/pkg/errors"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+// createFakeCard creates a DRM card for a PCI device in a fake sysfs
+func createFakeCard(t *testing.T, sysfsRoot string, card string, pciBusID string, files map[string]string) {
+	devicePath := filepath.Join(sysfsRoot, "devices", "pci0000:00", pciBusID)
+	require.NoError(t, os.MkdirAll(devicePath, 0755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(devicePath, name), []byte(content+"\n"), 0644))
+	}
+
+	cardPath := filepath.Join(sysfsRoot, "class", "drm", card)
+	require.NoError(t, os.MkdirAll(cardPath, 0755))
+	require.NoError(t, os.Symlink(devicePath, filepath.Join(cardPath, "device")))
+}
+
+func TestPull(t *testing.T) {
+	sysfsRoot := t.TempDir()
+	createFakeCard(t, sysfsRoot, "card1", "0000:c1:00.0", map[string]string{
+		"vendor":              "0x1002",
+		"device":              "0x740f",
+		"unique_id":           "3c3d5f8a1b2c9e01",
+		"product_name":        "Instinct MI210",
+		"mem_info_vram_total": "68702699520",
+	})
+	createFakeCard(t, sysfsRoot, "card0", "0000:03:00.0", map[string]string{
+		"vendor": "0x1002",
+		"device": "0x73bf",
+	})
+	createFakeCard(t, sysfsRoot, "card2", "0000:05:00.0", map[string]string{
+		"vendor": "0x10de",
+		"device": "0x2330",
+	})
+	// connectors of the cards are ignored
+	require.NoError(t, os.MkdirAll(filepath.Join(sysfsRoot, "class", "drm", "card0-DP-1"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(sysfsRoot, "module", "amdgpu"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sysfsRoot, "module", "amdgpu", "version"), []byte("6.7.0\n"), 0644))
+
+	store := fxutil.Test[workloadmetamock.Mock](t, fx.Options(
+		fx.Provide(func(t testing.TB) log.Component { return logmock.New(t) }),
+		fx.Provide(func(t testing.TB) config.Component { return config.NewMock(t) }),
+		workloadmetafxmock.MockModule(workloadmeta.NewParams()),
+	))
+
+	c := &collector{
+		id:        collectorID,
+		catalog:   workloadmeta.NodeAgent,
+		sysfsRoot: sysfsRoot,
+		seenIDs:   map[string]struct{}{},
+	}
+	require.NoError(t, c.Start(context.Background(), store))
+	require.NoError(t, c.Pull(context.Background()))
+
+	gpus := store.ListGPUs()
+	require.Len(t, gpus, 2)
+
+	gpu, err := store.GetGPU("0000:03:00.0")
+	require.NoError(t, err)
+	assert.Equal(t, "AMD GPU 0x73bf", gpu.Device)
+	assert.Equal(t, "0000:03:00.0", gpu.PCIBusID)
+	assert.Equal(t, 0, gpu.Index)
+	assert.Equal(t, "6.7.0", gpu.DriverVersion)
+
+	gpu, err = store.GetGPU("3c3d5f8a1b2c9e01")
+	require.NoError(t, err)
+	assert.Equal(t, amdVendor, gpu.Vendor)
+	assert.Equal(t, "Instinct MI210", gpu.Device)
+	assert.Equal(t, "0000:c1:00.0", gpu.PCIBusID)
+	assert.Equal(t, 1, gpu.Index)
+	assert.Equal(t, uint64(68702699520), gpu.TotalMemory)
+
+	// a removed device is unset
+	require.NoError(t, os.RemoveAll(filepath.Join(sysfsRoot, "class", "drm", "card1")))
+	require.NoError(t, c.Pull(context.Background()))
+	_, err = store.GetGPU("3c3d5f8a1b2c9e01")
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestStartWithoutAMDGPU(t *testing.T) {
+	sysfsRoot := t.TempDir()
+	createFakeCard(t, sysfsRoot, "card0", "0000:05:00.0", map[string]string{
+		"vendor": "0x10de",
+	})
+
+	c := &collector{
+		id:        collectorID,
+		catalog:   workloadmeta.NodeAgent,
+		sysfsRoot: sysfsRoot,
+		seenIDs:   map[string]struct{}{},
+	}
+	err := c.Start(context.Background(), nil)
+	assert.True(t, errors.IsDisabled(err))
+}
@@ -0,0 +1,248 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package containerlanguages implements the workloadmeta collector retaining
+// the languages detected for the processes of containers.
+package containerlanguages
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/errors"
+	"github.com/DataDog/datadog-agent/pkg/languagedetection/languagemodels"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	collectorID   = "container-languages"
+	componentName = "workloadmeta-container-languages"
+)
+
+// detectedProcess is a running process for which a language was detected
+type detectedProcess struct {
+	containerID string
+	language    languagemodels.Language
+}
+
+// collector retains the languages detected for the processes of a container
+// once the processes have exited, until they have not been detected for the
+// retention TTL. The languages of the processes are collected by the process
+// and process language collectors, and removed with the processes.
+type collector struct {
+	id      string
+	catalog workloadmeta.AgentType
+	store   workloadmeta.Component
+	config  config.Component
+	clock   clock.Clock
+	ttl     time.Duration
+
+	mu sync.Mutex
+	// processes are the running processes with a language, by process ID
+	processes map[string]detectedProcess
+	// languages are the languages detected by container ID and language name
+	languages map[string]map[languagemodels.LanguageName]*workloadmeta.DetectedLanguage
+	// updated are the IDs of the containers whose languages changed since
+	// the last pull
+	updated map[string]struct{}
+}
+
+type dependencies struct {
+	fx.In
+	Config config.Component
+}
+
+// NewCollector returns a new container languages collector provider and an
+// error.
+func NewCollector(deps dependencies) (workloadmeta.CollectorProvider, error) {
+	return workloadmeta.CollectorProvider{
+		Collector: newCollector(deps.Config, clock.New()),
+	}, nil
+}
+
+func newCollector(cfg config.Component, clock clock.Clock) *collector {
+	return &collector{
+		id:        collectorID,
+		catalog:   workloadmeta.NodeAgent,
+		config:    cfg,
+		clock:     clock,
+		processes: make(map[string]detectedProcess),
+		languages: make(map[string]map[languagemodels.LanguageName]*workloadmeta.DetectedLanguage),
+		updated:   make(map[string]struct{}),
+	}
+}
+
+// GetFxOptions returns the FX framework options for the collector
+func GetFxOptions() fx.Option {
+	return fx.Provide(NewCollector)
+}
+
+// Start starts the collector. It subscribes to the process events of the
+// store to retain the languages of the processes.
+func (c *collector) Start(ctx context.Context, store workloadmeta.Component) error {
+	if !c.config.GetBool("language_detection.enabled") {
+		return errors.NewDisabled(componentName, "language detection is disabled")
+	}
+
+	c.store = store
+	c.ttl = c.config.GetDuration("language_detection.retention_ttl")
+
+	filter := workloadmeta.NewFilterBuilder().
+		AddKind(workloadmeta.KindProcess).
+		Build()
+	eventCh := store.Subscribe(collectorID, workloadmeta.NormalPriority, filter)
+
+	go func() {
+		defer store.Unsubscribe(eventCh)
+		for {
+			select {
+			case eventBundle, ok := <-eventCh:
+				if !ok {
+					return
+				}
+				c.handleEvents(eventBundle)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// handleEvents tracks the running processes with a language.
+func (c *collector) handleEvents(eventBundle workloadmeta.EventBundle) {
+	eventBundle.Acknowledge()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for _, event := range eventBundle.Events {
+		process, ok := event.Entity.(*workloadmeta.Process)
+		if !ok {
+			continue
+		}
+
+		if event.Type == workloadmeta.EventTypeUnset {
+			delete(c.processes, process.ID)
+			continue
+		}
+
+		if process.ContainerID == "" || process.Language == nil || process.Language.Name == "" {
+			continue
+		}
+
+		detected := detectedProcess{containerID: process.ContainerID, language: *process.Language}
+		c.processes[process.ID] = detected
+		// The language is recorded right away, as the process may exit
+		// before the next pull
+		c.detect(detected, now)
+	}
+}
+
+// detect records that the language of a process was detected at now.
+func (c *collector) detect(process detectedProcess, now time.Time) {
+	languages, ok := c.languages[process.containerID]
+	if !ok {
+		languages = make(map[languagemodels.LanguageName]*workloadmeta.DetectedLanguage)
+		c.languages[process.containerID] = languages
+	}
+
+	language, ok := languages[process.language.Name]
+	if !ok {
+		language = &workloadmeta.DetectedLanguage{Language: process.language}
+		languages[process.language.Name] = language
+		c.updated[process.containerID] = struct{}{}
+	} else if language.Version != process.language.Version {
+		language.Version = process.language.Version
+		c.updated[process.containerID] = struct{}{}
+	}
+	language.LastDetected = now
+}
+
+// Pull refreshes the languages of the running processes, expires the
+// languages not detected within the retention TTL, and notifies the store of
+// the containers whose languages changed.
+func (c *collector) Pull(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for _, process := range c.processes {
+		c.detect(process, now)
+	}
+
+	for containerID, languages := range c.languages {
+		for name, language := range languages {
+			if now.Sub(language.LastDetected) > c.ttl {
+				log.Debugf("Language %s of container %s expired", name, containerID)
+				delete(languages, name)
+				c.updated[containerID] = struct{}{}
+			}
+		}
+	}
+
+	events := make([]workloadmeta.CollectorEvent, 0, len(c.updated))
+	for containerID := range c.updated {
+		entityID := workloadmeta.EntityID{
+			Kind: workloadmeta.KindContainerLanguages,
+			ID:   containerID,
+		}
+
+		languages := c.languages[containerID]
+		if len(languages) == 0 {
+			delete(c.languages, containerID)
+			events = append(events, workloadmeta.CollectorEvent{
+				Type:   workloadmeta.EventTypeUnset,
+				Source: workloadmeta.SourceContainerLanguagesCollector,
+				Entity: &workloadmeta.ContainerLanguages{EntityID: entityID},
+			})
+			continue
+		}
+
+		entity := &workloadmeta.ContainerLanguages{
+			EntityID:  entityID,
+			Languages: make([]workloadmeta.DetectedLanguage, 0, len(languages)),
+		}
+		for _, language := range languages {
+			entity.Languages = append(entity.Languages, *language)
+		}
+		slices.SortFunc(entity.Languages, func(a, b workloadmeta.DetectedLanguage) int {
+			return strings.Compare(string(a.Name), string(b.Name))
+		})
+
+		events = append(events, workloadmeta.CollectorEvent{
+			Type:   workloadmeta.EventTypeSet,
+			Source: workloadmeta.SourceContainerLanguagesCollector,
+			Entity: entity,
+		})
+	}
+	clear(c.updated)
+
+	if len(events) > 0 {
+		c.store.Notify(events)
+	}
+
+	return nil
+}
+
+// GetID returns the identifier for the respective component.
+func (c *collector) GetID() string {
+	return c.id
+}
+
+// GetTargetCatalog gets the expected catalog.
+func (c *collector) GetTargetCatalog() workloadmeta.AgentType {
+	return c.catalog
+}
@@ -0,0 +1,107 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build test
+
+package containerlanguages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	logmock "github.com/DataDog/datadog-agent/comp/core/log/mock"
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	workloadmetafxmock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/fx-mock"
+	workloadmetamock "github.com/DataDog/datadog-agent/comp/core/workloadmeta/mock"
+	"github.com/DataDog/datadog-agent/pkg/errors"
+	"github.com/DataDog/datadog-agent/pkg/languagedetection/languagemodels"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func processEvent(eventType workloadmeta.EventType, pid string, containerID string, language languagemodels.LanguageName) workloadmeta.Event {
+	return workloadmeta.Event{
+		Type: eventType,
+		Entity: &workloadmeta.Process{
+			EntityID:    workloadmeta.EntityID{Kind: workloadmeta.KindProcess, ID: pid},
+			ContainerID: containerID,
+			Language:    &languagemodels.Language{Name: language},
+		},
+	}
+}
+
+func languageNames(t *testing.T, store workloadmetamock.Mock, containerID string) []languagemodels.LanguageName {
+	entity, err := store.GetContainerLanguages(containerID)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	require.NoError(t, err)
+
+	var names []languagemodels.LanguageName
+	for _, language := range entity.Languages {
+		names = append(names, language.Name)
+	}
+	return names
+}
+
+func TestStartDisabled(t *testing.T) {
+	c := newCollector(config.NewMock(t), clock.NewMock())
+	err := c.Start(context.Background(), nil)
+	assert.True(t, errors.IsDisabled(err))
+}
+
+func TestRetention(t *testing.T) {
+	cfg := config.NewMockWithOverrides(t, map[string]interface{}{
+		"language_detection.enabled":       true,
+		"language_detection.retention_ttl": "10m",
+	})
+	mockStore := fxutil.Test[workloadmetamock.Mock](t, fx.Options(
+		fx.Provide(func(t testing.TB) log.Component { return logmock.New(t) }),
+		fx.Provide(func() config.Component { return cfg }),
+		workloadmetafxmock.MockModule(workloadmeta.Params{
+			AgentType: workloadmeta.NodeAgent,
+		}),
+	))
+
+	mockClock := clock.NewMock()
+	c := newCollector(cfg, mockClock)
+	c.store = mockStore
+	c.ttl = cfg.GetDuration("language_detection.retention_ttl")
+
+	c.handleEvents(workloadmeta.EventBundle{Events: []workloadmeta.Event{
+		processEvent(workloadmeta.EventTypeSet, "1", "container-1", languagemodels.Java),
+		processEvent(workloadmeta.EventTypeSet, "2", "container-1", languagemodels.Python),
+		processEvent(workloadmeta.EventTypeSet, "3", "", languagemodels.Go),
+	}})
+	require.NoError(t, c.Pull(context.Background()))
+	assert.Equal(t, []languagemodels.LanguageName{languagemodels.Java, languagemodels.Python}, languageNames(t, mockStore, "container-1"))
+
+	// The language of an exited process is retained until the TTL expires
+	c.handleEvents(workloadmeta.EventBundle{Events: []workloadmeta.Event{
+		processEvent(workloadmeta.EventTypeUnset, "2", "container-1", languagemodels.Python),
+	}})
+	mockClock.Add(5 * time.Minute)
+	require.NoError(t, c.Pull(context.Background()))
+	assert.Equal(t, []languagemodels.LanguageName{languagemodels.Java, languagemodels.Python}, languageNames(t, mockStore, "container-1"))
+
+	// The language of a running process is never expired
+	mockClock.Add(6 * time.Minute)
+	require.NoError(t, c.Pull(context.Background()))
+	assert.Equal(t, []languagemodels.LanguageName{languagemodels.Java}, languageNames(t, mockStore, "container-1"))
+
+	c.handleEvents(workloadmeta.EventBundle{Events: []workloadmeta.Event{
+		processEvent(workloadmeta.EventTypeUnset, "1", "container-1", languagemodels.Java),
+	}})
+	mockClock.Add(11 * time.Minute)
+	require.NoError(t, c.Pull(context.Background()))
+	assert.Empty(t, languageNames(t, mockStore, "container-1"))
+}
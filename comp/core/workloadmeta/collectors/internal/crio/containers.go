@@ -10,6 +10,7 @@ package crio
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"time"
 
@@ -23,13 +24,21 @@ import (
 // convertContainerToEvent converts a CRI-O container to a workloadmeta event.
 func (c *collector) convertContainerToEvent(ctx context.Context, ctr *v1.Container) workloadmeta.CollectorEvent {
 	name := getContainerName(ctr.GetMetadata())
-	namespace := getPodNamespace(ctx, c.client, ctr.GetPodSandboxId())
+	podSandbox := getPodSandboxStatus(ctx, c.client, ctr.GetPodSandboxId())
 	containerStatus, info := getContainerStatus(ctx, c.client, ctr.GetId())
 	pid, hostname, cgroupsPath := parseContainerInfo(info)
 	cpuLimit, memLimit := getResourceLimits(containerStatus, info)
-	image := getContainerImage(containerStatus)
+	image := getContainerImage(ctx, c.client, containerStatus)
 	ports := parsePortsFromAnnotations(ctr.GetAnnotations())
 
+	var owner *workloadmeta.EntityID
+	if podUID := podSandbox.GetMetadata().GetUid(); podUID != "" {
+		owner = &workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesPod,
+			ID:   podUID,
+		}
+	}
+
 	return workloadmeta.CollectorEvent{
 		Type:   workloadmeta.EventTypeSet,
 		Source: workloadmeta.SourceRuntime,
@@ -40,10 +49,11 @@ func (c *collector) convertContainerToEvent(ctx context.Context, ctr *v1.Contain
 			},
 			EntityMeta: workloadmeta.EntityMeta{
 				Name:        name,
-				Namespace:   namespace,
+				Namespace:   podSandbox.GetMetadata().GetNamespace(),
 				Labels:      ctr.GetLabels(),
-				Annotations: ctr.GetAnnotations(),
+				Annotations: getContainerAnnotations(ctr, podSandbox),
 			},
+			Owner:    owner,
 			Hostname: hostname,
 			Image:    image,
 			PID:      pid,
@@ -67,14 +77,38 @@ func getContainerName(containerMetadata *v1.ContainerMetadata) string {
 	return containerMetadata.GetName()
 }
 
-// getPodNamespace retrieves the namespace for a given pod ID.
-func getPodNamespace(ctx context.Context, client crio.Client, podID string) string {
+// getPodSandboxStatus retrieves the status of the pod sandbox for a given pod ID.
+func getPodSandboxStatus(ctx context.Context, client crio.Client, podID string) *v1.PodSandboxStatus {
 	pod, err := client.GetPodStatus(ctx, podID)
 	if err != nil || pod == nil || pod.GetMetadata() == nil {
-		log.Errorf("Failed to get pod namespace for pod ID %s: %v", podID, err)
-		return ""
+		log.Errorf("Failed to get pod sandbox status for pod ID %s: %v", podID, err)
+		return nil
+	}
+	return pod
+}
+
+// getPodNamespace retrieves the namespace for a given pod ID.
+func getPodNamespace(ctx context.Context, client crio.Client, podID string) string {
+	return getPodSandboxStatus(ctx, client, podID).GetMetadata().GetNamespace()
+}
+
+// getContainerAnnotations returns the annotations of the container completed
+// with the annotations of its pod sandbox, as the kubelet only sets the pod
+// annotations on the sandbox. The container annotations take precedence.
+func getContainerAnnotations(ctr *v1.Container, podSandbox *v1.PodSandboxStatus) map[string]string {
+	podAnnotations := podSandbox.GetAnnotations()
+	if len(podAnnotations) == 0 {
+		return ctr.GetAnnotations()
 	}
-	return pod.GetMetadata().GetNamespace()
+
+	annotations := make(map[string]string, len(podAnnotations)+len(ctr.GetAnnotations()))
+	for k, v := range podAnnotations {
+		annotations[k] = v
+	}
+	for k, v := range ctr.GetAnnotations() {
+		annotations[k] = v
+	}
+	return annotations
 }
 
 // getContainerStatus retrieves the status of a container.
@@ -90,7 +124,7 @@ func getContainerStatus(ctx context.Context, client crio.Client, containerID str
 }
 
 // getContainerImage retrieves and converts a container image to workloadmeta format.
-func getContainerImage(ctrStatus *v1.ContainerStatus) workloadmeta.ContainerImage {
+func getContainerImage(ctx context.Context, client crio.Client, ctrStatus *v1.ContainerStatus) workloadmeta.ContainerImage {
 	if ctrStatus == nil {
 		log.Warn("container status is nil, cannot fetch image")
 		return workloadmeta.ContainerImage{}
@@ -102,12 +136,18 @@ func getContainerImage(ctrStatus *v1.ContainerStatus) workloadmeta.ContainerImag
 		return workloadmeta.ContainerImage{}
 	}
 
+	imgID := ctrStatus.GetImageId()
+
 	digest, digestErr := parseDigests([]string{ctrStatus.ImageRef})
 	if digestErr != nil {
-		digest = ctrStatus.ImageRef
+		// Depending on the CRI-O version, the image reference of the container
+		// is the image ID, so the repo digest is read from the image status
+		digest, digestErr = getImageRepoDigest(ctx, client, imgID)
+		if digestErr != nil {
+			digest = ctrStatus.ImageRef
+		}
 	}
 
-	imgID := ctrStatus.GetImageId()
 	wmImg, err := workloadmeta.NewContainerImage(imgID, imageSpec.Image)
 	if err != nil {
 		log.Debugf("Failed to create image: %v", err)
@@ -122,6 +162,19 @@ func getContainerImage(ctrStatus *v1.ContainerStatus) workloadmeta.ContainerImag
 	return wmImg
 }
 
+// getImageRepoDigest returns the digest of the first repo digest of an image.
+func getImageRepoDigest(ctx context.Context, client crio.Client, imgID string) (string, error) {
+	if imgID == "" {
+		return "", errors.New("empty image ID")
+	}
+
+	imageResp, err := client.GetContainerImage(ctx, &v1.ImageSpec{Image: imgID}, false)
+	if err != nil {
+		return "", err
+	}
+	return parseDigests(imageResp.GetImage().GetRepoDigests())
+}
+
 // getContainerState returns the workloadmeta.ContainerState based on container status.
 func getContainerState(containerStatus *v1.ContainerStatus) workloadmeta.ContainerState {
 	if containerStatus == nil {
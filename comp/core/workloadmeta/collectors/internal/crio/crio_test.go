@@ -126,6 +126,94 @@ func TestPull(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "Pod sandbox metadata and image ID as image reference",
+			mockGetAllContainers: func(_ context.Context) ([]*v1.Container, error) {
+				return []*v1.Container{
+					{
+						Id:           "container1",
+						Image:        &v1.ImageSpec{Image: "image123"},
+						ImageRef:     "image123",
+						PodSandboxId: "pod1",
+						Metadata:     &v1.ContainerMetadata{Name: "container1"},
+						Annotations: map[string]string{
+							"io.kubernetes.container.hash": "1234",
+							"shared":                       "container",
+						},
+					},
+				}, nil
+			},
+			mockGetPodStatus: func(_ context.Context, _ string) (*v1.PodSandboxStatus, error) {
+				return &v1.PodSandboxStatus{
+					Metadata: &v1.PodSandboxMetadata{Namespace: "default", Uid: "pod-uid"},
+					Annotations: map[string]string{
+						"ad.datadoghq.com/tags": `{"team":"containers"}`,
+						"shared":                "pod",
+					},
+				}, nil
+			},
+			mockGetContainerStatus: func(_ context.Context, _ string) (*v1.ContainerStatusResponse, error) {
+				return &v1.ContainerStatusResponse{
+					Status: &v1.ContainerStatus{
+						Metadata:   &v1.ContainerMetadata{Name: "container1"},
+						State:      v1.ContainerState_CONTAINER_RUNNING,
+						CreatedAt:  createTime,
+						StartedAt:  startTime,
+						FinishedAt: finishTime,
+						Image:      &v1.ImageSpec{Image: "myrepo/myimage:latest"},
+						ImageRef:   "image123",
+						ImageId:    "image123",
+					},
+				}, nil
+			},
+			mockGetContainerImage: func(_ context.Context, imageSpec *v1.ImageSpec, _ bool) (*v1.ImageStatusResponse, error) {
+				assert.Equal(t, "image123", imageSpec.GetImage())
+				return &v1.ImageStatusResponse{
+					Image: &v1.Image{
+						Id:          "image123",
+						RepoTags:    []string{"myrepo/myimage:latest"},
+						RepoDigests: []string{"myrepo/myimage@sha256:456def"},
+					},
+				}, nil
+			},
+			expectedEvents: []workloadmeta.CollectorEvent{
+				{
+					Type:   workloadmeta.EventTypeSet,
+					Source: workloadmeta.SourceRuntime,
+					Entity: &workloadmeta.Container{
+						EntityID: workloadmeta.EntityID{Kind: workloadmeta.KindContainer, ID: "container1"},
+						EntityMeta: workloadmeta.EntityMeta{
+							Name:      "container1",
+							Namespace: "default",
+							Annotations: map[string]string{
+								"ad.datadoghq.com/tags":        `{"team":"containers"}`,
+								"io.kubernetes.container.hash": "1234",
+								"shared":                       "container",
+							},
+						},
+						Owner: &workloadmeta.EntityID{Kind: workloadmeta.KindKubernetesPod, ID: "pod-uid"},
+						Image: workloadmeta.ContainerImage{
+							Name:       "myrepo/myimage",
+							ShortName:  "myimage",
+							RawName:    "myrepo/myimage:latest",
+							ID:         "image123",
+							Tag:        "latest",
+							RepoDigest: "sha256:456def",
+						},
+						Runtime: workloadmeta.ContainerRuntimeCRIO,
+						State: workloadmeta.ContainerState{
+							Status:     workloadmeta.ContainerStatusRunning,
+							Running:    true,
+							CreatedAt:  time.Unix(0, createTime).UTC(),
+							StartedAt:  time.Unix(0, startTime).UTC(),
+							FinishedAt: time.Unix(0, finishTime).UTC(),
+							ExitCode:   pointer.Ptr(int64(0)),
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name: "Missing resources in container but available in Info",
 			mockGetAllContainers: func(_ context.Context) ([]*v1.Container, error) {
@@ -5,11 +5,13 @@
 
 //go:build docker
 
-// Package ecs implements the ECS Workloadmeta collector.
+// Package ecs implements the ECS Workloadmeta collector, for the tasks run on
+// EC2, Fargate and ECS Anywhere external instances.
 package ecs
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -23,6 +25,7 @@ import (
 	ecsutil "github.com/DataDog/datadog-agent/pkg/util/ecs"
 	ecsmeta "github.com/DataDog/datadog-agent/pkg/util/ecs/metadata"
 	v1 "github.com/DataDog/datadog-agent/pkg/util/ecs/metadata/v1"
+	v2 "github.com/DataDog/datadog-agent/pkg/util/ecs/metadata/v2"
 	"github.com/DataDog/datadog-agent/pkg/util/ecs/metadata/v3or4"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
@@ -32,6 +35,21 @@ const (
 	componentName = "workloadmeta-ecs"
 )
 
+// deploymentMode is the way the ECS tasks monitored by the agent are run
+type deploymentMode string
+
+const (
+	// deploymentModeEC2 collects the tasks of an EC2 container instance from
+	// the ECS agent
+	deploymentModeEC2 deploymentMode = "ec2"
+	// deploymentModeFargate collects the task the agent runs in from the task
+	// metadata endpoint
+	deploymentModeFargate deploymentMode = "fargate"
+	// deploymentModeExternal collects the tasks of an ECS Anywhere external
+	// instance from the ECS agent
+	deploymentModeExternal deploymentMode = "external"
+)
+
 type dependencies struct {
 	fx.In
 
@@ -42,8 +60,11 @@ type collector struct {
 	id                   string
 	store                workloadmeta.Component
 	catalog              workloadmeta.AgentType
+	deploymentMode       deploymentMode
 	metaV1               v1.Client
+	metaV2               v2.Client
 	metaV3or4            func(metaURI, metaVersion string) v3or4.Client
+	metaV4               v3or4.Client
 	clusterName          string
 	containerInstanceARN string
 	hasResourceTags      bool
@@ -53,7 +74,7 @@ type collector struct {
 	config               config.Component
 	// taskCollectionEnabled is a flag to enable detailed task collection
 	// if the flag is enabled, the collector will query the latest metadata endpoint, currently v4, for each task
-	// that is returned from the v1/tasks endpoint
+	// that is returned from the v1/tasks endpoint, or for the task of the agent on Fargate
 	taskCollectionEnabled        bool
 	taskCollectionParser         util.TaskParser
 	taskCache                    *cache.Cache
@@ -95,13 +116,79 @@ func GetFxOptions() fx.Option {
 }
 
 func (c *collector) Start(ctx context.Context, store workloadmeta.Component) error {
-	if !env.IsFeaturePresent(env.ECSEC2) {
-		return errors.NewDisabled(componentName, "Agent is not running on ECS EC2")
+	c.store = store
+
+	switch {
+	case env.IsFeaturePresent(env.ECSFargate):
+		c.deploymentMode = deploymentModeFargate
+		return c.startFargate()
+	case env.IsFeaturePresent(env.ECSEC2):
+		c.deploymentMode = c.detectDeploymentMode(ctx)
+		return c.startContainerInstance(ctx)
+	default:
+		return errors.NewDisabled(componentName, "Agent is not running on ECS")
 	}
+}
 
+// detectDeploymentMode returns the deployment mode of an agent that is not
+// running on Fargate, from the ecs_deployment_mode setting or, when it is set
+// to "auto", from the launch type of the task of the agent.
+func (c *collector) detectDeploymentMode(ctx context.Context) deploymentMode {
+	switch mode := c.config.GetString("ecs_deployment_mode"); mode {
+	case string(deploymentModeEC2), string(deploymentModeExternal):
+		return deploymentMode(mode)
+	case "auto":
+	default:
+		log.Warnf("unknown ecs_deployment_mode %q, detecting it", mode)
+	}
+
+	// The agent may run on the host, outside of any task
+	metaV4, err := ecsmeta.V4FromCurrentTask()
+	if err != nil {
+		log.Debugf("cannot get the task of the agent, assuming an EC2 container instance: %s", err)
+		return deploymentModeEC2
+	}
+	task, err := metaV4.GetTask(ctx)
+	if err != nil {
+		log.Debugf("cannot get the task of the agent, assuming an EC2 container instance: %s", err)
+		return deploymentModeEC2
+	}
+	if strings.ToUpper(task.LaunchType) == "EXTERNAL" {
+		return deploymentModeExternal
+	}
+	return deploymentModeEC2
+}
+
+// startFargate sets up the collection of the task of the agent on Fargate.
+func (c *collector) startFargate() error {
 	var err error
+	c.metaV2, err = ecsmeta.V2()
+	if err != nil {
+		return err
+	}
 
-	c.store = store
+	if !c.taskCollectionEnabled {
+		log.Infof("detailed task collection disabled, using metadata v2 endpoint")
+		c.taskCollectionParser = c.parseTaskFromV2Endpoint
+		return nil
+	}
+
+	c.metaV4, err = ecsmeta.V4FromCurrentTask()
+	if err != nil {
+		log.Warnf("failed to initialize metadata v4 client, using metdata v2: %v", err)
+		c.taskCollectionParser = c.parseTaskFromV2Endpoint
+		return nil
+	}
+
+	log.Infof("detailed task collection enabled, using metadata v4 endpoint")
+	c.taskCollectionParser = c.parseCurrentTaskFromV4Endpoint
+	return nil
+}
+
+// startContainerInstance sets up the collection of the tasks of an EC2 or
+// external container instance from the ECS agent.
+func (c *collector) startContainerInstance(ctx context.Context) error {
+	var err error
 	c.metaV1, err = ecsmeta.V1()
 	if err != nil {
 		return err
@@ -128,6 +215,7 @@ func (c *collector) Start(ctx context.Context, store workloadmeta.Component) err
 		log.Warnf("cannot determine ECS cluster name: %s", err)
 	}
 
+	log.Infof("collecting the ECS tasks of the %s container instance", c.deploymentMode)
 	return nil
 }
 
@@ -176,6 +264,28 @@ func (c *collector) GetTargetCatalog() workloadmeta.AgentType {
 	return c.catalog
 }
 
+// launchType returns the launch type of the tasks that are not described by
+// the metadata v4 endpoint.
+func (c *collector) launchType() workloadmeta.ECSLaunchType {
+	switch c.deploymentMode {
+	case deploymentModeFargate:
+		return workloadmeta.ECSLaunchTypeFargate
+	case deploymentModeExternal:
+		return workloadmeta.ECSLaunchTypeExternal
+	default:
+		return workloadmeta.ECSLaunchTypeEC2
+	}
+}
+
+// eventSource returns the source of the events of the collector. On Fargate,
+// the ECS metadata endpoint is the only runtime the agent has access to.
+func (c *collector) eventSource() workloadmeta.Source {
+	if c.deploymentMode == deploymentModeFargate {
+		return workloadmeta.SourceRuntime
+	}
+	return workloadmeta.SourceNodeOrchestrator
+}
+
 func (c *collector) setLastSeenEntitiesAndUnsetEvents(events []workloadmeta.CollectorEvent, seen map[workloadmeta.EntityID]struct{}) []workloadmeta.CollectorEvent {
 	for seenID := range c.seen {
 		if _, ok := seen[seenID]; ok {
@@ -199,7 +309,7 @@ func (c *collector) setLastSeenEntitiesAndUnsetEvents(events []workloadmeta.Coll
 
 		events = append(events, workloadmeta.CollectorEvent{
 			Type:   workloadmeta.EventTypeUnset,
-			Source: workloadmeta.SourceNodeOrchestrator,
+			Source: c.eventSource(),
 			Entity: entity,
 		})
 	}
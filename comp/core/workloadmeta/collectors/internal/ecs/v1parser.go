@@ -52,12 +52,16 @@ func (c *collector) parseTasksFromV1Endpoint(ctx context.Context) ([]workloadmet
 				Name: taskID,
 			},
 			ClusterName:          c.clusterName,
+			ClusterARN:           util.BuildClusterARN(c.clusterName, taskAccountID, taskRegion),
 			ContainerInstanceARN: c.containerInstanceARN,
 			Family:               task.Family,
 			Version:              task.Version,
+			TaskDefinitionARN:    util.BuildTaskDefinitionARN(taskAccountID, task.Family, taskRegion, task.Version),
+			DesiredStatus:        task.DesiredStatus,
+			KnownStatus:          task.KnownStatus,
 			Region:               taskRegion,
 			AWSAccountID:         taskAccountID,
-			LaunchType:           workloadmeta.ECSLaunchTypeEC2,
+			LaunchType:           c.launchType(),
 			Containers:           taskContainers,
 		}
 
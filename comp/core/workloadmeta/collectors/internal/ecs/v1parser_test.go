@@ -95,3 +95,33 @@ func TestPullWithV1Parser(t *testing.T) {
 	}
 
 }
+
+// TestPullWithV1ParserExternalInstance tests that the tasks of an ECS Anywhere external instance are reported with the
+// external launch type.
+func TestPullWithV1ParserExternalInstance(t *testing.T) {
+	taskARN := "arn:aws:ecs:us-east-1:123457279990:task/ecs-cluster/938f6d263c464aa5985dc67ab7f38a7e"
+	c := collector{
+		deploymentMode: deploymentModeExternal,
+		clusterName:    "ecs-cluster",
+		resourceTags:   make(map[string]resourceTags),
+		seen:           make(map[workloadmeta.EntityID]struct{}),
+		store:          &fakeWorkloadmetaStore{},
+		metaV1: &fakev1EcsClient{
+			mockGetTasks: func(_ context.Context) ([]v1.Task, error) {
+				return []v1.Task{{Arn: taskARN, Family: "redis", Version: "3", KnownStatus: "RUNNING"}}, nil
+			},
+		},
+	}
+	c.taskCollectionParser = c.parseTasksFromV1Endpoint
+
+	err := c.Pull(context.TODO())
+	require.NoError(t, err)
+
+	store := c.store.(*fakeWorkloadmetaStore)
+	require.Len(t, store.notifiedEvents, 1)
+	assert.Equal(t, workloadmeta.SourceNodeOrchestrator, store.notifiedEvents[0].Source)
+	task := store.notifiedEvents[0].Entity.(*workloadmeta.ECSTask)
+	assert.Equal(t, workloadmeta.ECSLaunchTypeExternal, task.LaunchType)
+	assert.Equal(t, "arn:aws:ecs:us-east-1:123457279990:cluster/ecs-cluster", task.ClusterARN)
+	assert.Equal(t, "arn:aws:ecs:us-east-1:123457279990:task-definition/redis:3", task.TaskDefinitionARN)
+}
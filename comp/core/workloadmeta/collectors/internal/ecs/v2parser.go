@@ -5,8 +5,7 @@
 
 //go:build docker
 
-// Package ecsfargate implements the ECS Fargate Workloadmeta collector.
-package ecsfargate
+package ecs
 
 import (
 	"context"
@@ -19,6 +18,8 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
+// parseTaskFromV2Endpoint queries the v2 task endpoint for the task of the
+// agent on Fargate.
 func (c *collector) parseTaskFromV2Endpoint(ctx context.Context) ([]workloadmeta.CollectorEvent, error) {
 	task, err := c.metaV2.GetTask(ctx)
 	if err != nil {
@@ -45,20 +46,25 @@ func (c *collector) parseV2Task(task *v2.Task) []workloadmeta.CollectorEvent {
 
 	seen[entityID] = struct{}{}
 
-	taskContainers, containerEvents := c.parseTaskContainers(task, seen)
+	taskContainers, containerEvents := c.parseV2TaskContainers(task, seen)
 	taskRegion, taskAccountID := util.ParseRegionAndAWSAccountID(task.TaskARN)
+	clusterName := parseClusterName(task.ClusterName)
 	entity := &workloadmeta.ECSTask{
 		EntityID: entityID,
 		EntityMeta: workloadmeta.EntityMeta{
 			Name: taskID,
 		},
-		ClusterName:  parseClusterName(task.ClusterName),
-		Region:       taskRegion,
-		AWSAccountID: taskAccountID,
-		Family:       task.Family,
-		Version:      task.Version,
-		LaunchType:   workloadmeta.ECSLaunchTypeFargate,
-		Containers:   taskContainers,
+		ClusterName:       clusterName,
+		ClusterARN:        util.BuildClusterARN(clusterName, taskAccountID, taskRegion),
+		Region:            taskRegion,
+		AWSAccountID:      taskAccountID,
+		Family:            task.Family,
+		Version:           task.Version,
+		TaskDefinitionARN: util.BuildTaskDefinitionARN(taskAccountID, task.Family, taskRegion, task.Version),
+		DesiredStatus:     task.DesiredStatus,
+		KnownStatus:       task.KnownStatus,
+		LaunchType:        workloadmeta.ECSLaunchTypeFargate,
+		Containers:        taskContainers,
 
 		// the AvailabilityZone metadata is only available for
 		// Fargate tasks using platform version 1.4 or later
@@ -72,35 +78,10 @@ func (c *collector) parseV2Task(task *v2.Task) []workloadmeta.CollectorEvent {
 		Entity: entity,
 	})
 
-	for seenID := range c.seen {
-		if _, ok := seen[seenID]; ok {
-			continue
-		}
-
-		var entity workloadmeta.Entity
-		switch seenID.Kind {
-		case workloadmeta.KindECSTask:
-			entity = &workloadmeta.ECSTask{EntityID: seenID}
-		case workloadmeta.KindContainer:
-			entity = &workloadmeta.Container{EntityID: seenID}
-		default:
-			log.Errorf("cannot handle expired entity of kind %q, skipping", seenID.Kind)
-			continue
-		}
-
-		events = append(events, workloadmeta.CollectorEvent{
-			Type:   workloadmeta.EventTypeUnset,
-			Source: workloadmeta.SourceRuntime,
-			Entity: entity,
-		})
-	}
-
-	c.seen = seen
-
-	return events
+	return c.setLastSeenEntitiesAndUnsetEvents(events, seen)
 }
 
-func (c *collector) parseTaskContainers(
+func (c *collector) parseV2TaskContainers(
 	task *v2.Task,
 	seen map[workloadmeta.EntityID]struct{},
 ) ([]workloadmeta.OrchestratorContainer, []workloadmeta.CollectorEvent) {
@@ -174,3 +155,27 @@ func (c *collector) parseTaskContainers(
 
 	return taskContainers, events
 }
+
+// parseClusterName returns the short name of a cluster. it detects if the name
+// is an ARN and converts it if that's the case.
+func parseClusterName(value string) string {
+	if strings.Contains(value, "/") {
+		parts := strings.Split(value, "/")
+		return parts[len(parts)-1]
+	}
+
+	return value
+}
+
+func parseStatus(status string) workloadmeta.ContainerStatus {
+	switch status {
+	case "RUNNING":
+		return workloadmeta.ContainerStatusRunning
+	case "STOPPED":
+		return workloadmeta.ContainerStatusStopped
+	case "PULLED", "CREATED", "RESOURCES_PROVISIONED":
+		return workloadmeta.ContainerStatusCreated
+	}
+
+	return workloadmeta.ContainerStatusUnknown
+}
@@ -5,8 +5,7 @@
 
 //go:build docker
 
-// Package ecsfargate implements the ECS Fargate Workloadmeta collector.
-package ecsfargate
+package ecs
 
 import (
 	"context"
@@ -21,7 +20,7 @@ import (
 	v2 "github.com/DataDog/datadog-agent/pkg/util/ecs/metadata/v2"
 )
 
-func TestPullWithTaskCollectionEnabledWithV2Parser(t *testing.T) {
+func TestPullFargateWithV2Parser(t *testing.T) {
 	// Start a dummy Http server to simulate ECS Fargate metadata v2 endpoint
 	dummyECS, err := testutil.NewDummyECS(
 		testutil.FileHandlerOption("/v2/metadata", "./testdata/redis.json"),
@@ -34,6 +33,7 @@ func TestPullWithTaskCollectionEnabledWithV2Parser(t *testing.T) {
 	// create an ECS Fargate collector with orchestratorECSCollectionEnabled enabled
 	collector := collector{
 		store:                 store,
+		deploymentMode:        deploymentModeFargate,
 		taskCollectionEnabled: true,
 		metaV2:                v2.NewClient(fmt.Sprintf("%s/v2/metadata", ts.URL)),
 	}
@@ -53,6 +53,7 @@ func TestPullWithTaskCollectionEnabledWithV2Parser(t *testing.T) {
 			require.Equal(t, "us-east-1", entity.Region)
 			require.Equal(t, "123457279990", entity.AWSAccountID)
 			require.Equal(t, "ecs-cluster", entity.ClusterName)
+			require.Equal(t, "arn:aws:ecs:us-east-1:123457279990:cluster/ecs-cluster", entity.ClusterARN)
 			require.Equal(t, "my-redis", entity.Family)
 			require.Equal(t, "1", entity.Version)
 			require.Equal(t, workloadmeta.ECSLaunchTypeFargate, entity.LaunchType)
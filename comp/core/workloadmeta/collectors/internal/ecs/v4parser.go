@@ -42,7 +42,11 @@ func (c *collector) parseTasksFromV4Endpoint(ctx context.Context) ([]workloadmet
 	// otherwise only update the last seen entities
 	for _, task := range rest {
 		if _, found := c.taskCache.Get(task.Arn); !found {
-			events = append(events, util.ParseV4Task(v1TaskToV4Task(task), seen)...)
+			v4Task := v1TaskToV4Task(task)
+			if c.deploymentMode == deploymentModeExternal {
+				v4Task.LaunchType = "EXTERNAL"
+			}
+			events = append(events, util.ParseV4Task(v4Task, seen)...)
 		} else {
 			setLastSeenEntity(task, seen)
 		}
@@ -56,6 +60,27 @@ func (c *collector) parseTasksFromV4Endpoint(ctx context.Context) ([]workloadmet
 	return c.setLastSeenEntitiesAndUnsetEvents(events, seen), nil
 }
 
+// parseCurrentTaskFromV4Endpoint queries the v4 task endpoint for the task of
+// the agent on Fargate.
+func (c *collector) parseCurrentTaskFromV4Endpoint(ctx context.Context) ([]workloadmeta.CollectorEvent, error) {
+	task, err := c.metaV4.GetTask(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []workloadmeta.CollectorEvent{}
+	seen := make(map[workloadmeta.EntityID]struct{})
+
+	// We only want to collect tasks without a STOPPED status.
+	if task.KnownStatus == workloadmeta.ECSTaskKnownStatusStopped {
+		return events, nil
+	}
+
+	events = append(events, util.ParseV4Task(*task, seen)...)
+
+	return c.setLastSeenEntitiesAndUnsetEvents(events, seen), nil
+}
+
 // getTaskWithTagsFromV4Endpoint fetches task and tags from the metadata v4 API
 func (c *collector) getTaskWithTagsFromV4Endpoint(ctx context.Context, task v1.Task) (v3or4.Task, error) {
 	// Get tags from the cache
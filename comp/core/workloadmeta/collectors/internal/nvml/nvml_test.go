@@ -69,6 +69,35 @@ func TestPull(t *testing.T) {
 	}
 }
 
+func TestPullMIGPartitioning(t *testing.T) {
+	wmetaMock := testutil.GetWorkloadMetaMock(t)
+	nvmlMock := testutil.GetBasicNvmlMock()
+
+	c := &collector{
+		id:      collectorID,
+		catalog: workloadmeta.NodeAgent,
+		store:   wmetaMock,
+	}
+
+	ddnvml.WithMockNVML(t, nvmlMock)
+
+	c.Pull(context.Background())
+
+	for _, deviceIdx := range testutil.DevicesWithMIGChildren {
+		parentUUID := testutil.GPUUUIDs[deviceIdx]
+		parent, err := wmetaMock.GetGPU(parentUUID)
+		require.NoError(t, err)
+		require.Equal(t, testutil.MIGChildrenUUIDs[deviceIdx], parent.ChildrenGPUUUIDs)
+
+		for _, childUUID := range testutil.MIGChildrenUUIDs[deviceIdx] {
+			child, err := wmetaMock.GetGPU(childUUID)
+			require.NoError(t, err)
+			require.Equal(t, workloadmeta.GPUDeviceTypeMIG, child.DeviceType)
+			require.Equal(t, parentUUID, child.ParentGPUUUID)
+		}
+	}
+}
+
 func TestGpuArchToString(t *testing.T) {
 	tests := []struct {
 		arch     nvml.DeviceArchitecture
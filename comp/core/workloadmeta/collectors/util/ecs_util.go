@@ -82,9 +82,14 @@ func ParseV4Task(task v3or4.Task, seen map[workloadmeta.EntityID]struct{}) []wor
 
 	source := workloadmeta.SourceNodeOrchestrator
 	entity.LaunchType = workloadmeta.ECSLaunchTypeEC2
-	if strings.ToUpper(task.LaunchType) == "FARGATE" {
+	switch strings.ToUpper(task.LaunchType) {
+	case "FARGATE":
 		entity.LaunchType = workloadmeta.ECSLaunchTypeFargate
 		source = workloadmeta.SourceRuntime
+	case "EXTERNAL":
+		// ECS Anywhere, the task runs on an external instance registered
+		// to the cluster
+		entity.LaunchType = workloadmeta.ECSLaunchTypeExternal
 	}
 
 	events = append(events, containerEvents...)
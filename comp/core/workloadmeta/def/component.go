@@ -116,10 +116,20 @@ type Component interface {
 	// There can only be one kubelet entity so further specification is unnecessary.
 	GetKubelet() (*Kubelet, error)
 
+	// GetContainerLanguages returns the languages detected for the processes
+	// of a container. It fetches the entity with kind KindContainerLanguages
+	// and the given container ID.
+	GetContainerLanguages(containerID string) (*ContainerLanguages, error)
+
 	// ListGPUs returns metadata about all known GPU devices, equivalent
 	// to all entities with kind KindGPU.
 	ListGPUs() []*GPU
 
+	// ListEntitiesByIP returns the entities with the given IP address: the
+	// Kubernetes pods not running on the host network and the containers.
+	// It lets the network components resolve remote endpoints to workloads.
+	ListEntitiesByIP(ip string) []Entity
+
 	// ListProcessesWithFilter returns all the processes for which the passed
 	// filter evaluates to true.
 	ListProcessesWithFilter(filterFunc EntityFilterFunc[*Process]) []*Process
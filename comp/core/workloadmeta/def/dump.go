@@ -8,6 +8,7 @@ package workloadmeta
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/fatih/color"
 )
@@ -37,3 +38,37 @@ func (wdr WorkloadDumpResponse) Write(writer io.Writer) {
 		}
 	}
 }
+
+// WorkloadStreamRequest holds the filters of the events of the store streamed
+// to the agent's CLI.
+type WorkloadStreamRequest struct {
+	// Kinds are the kinds of the streamed entities, all kinds if empty.
+	Kinds []string `json:"kinds,omitempty"`
+	// Source is the source of the streamed entities, all sources if empty.
+	Source string `json:"source,omitempty"`
+	// Name is a regular expression matching the name or the ID of the
+	// streamed entities.
+	Name    string `json:"name,omitempty"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+// WorkloadStreamEvent is an event of the store streamed to the agent's CLI.
+type WorkloadStreamEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+	Kind      string    `json:"kind,omitempty"`
+	ID        string    `json:"id,omitempty"`
+	Info      string    `json:"info,omitempty"`
+}
+
+// Write writes the event in a given writer.
+// Useful for agent's CLI.
+func (wse WorkloadStreamEvent) Write(writer io.Writer) {
+	if writer != color.Output {
+		color.NoColor = true
+	}
+
+	fmt.Fprintf(writer, "\n=== %s %s %s %s ===\n", wse.Timestamp.Format(time.RFC3339), color.YellowString(wse.Type), color.GreenString(wse.Kind), color.GreenString(wse.ID))
+	fmt.Fprint(writer, wse.Info)
+	fmt.Fprintln(writer, "===")
+}
@@ -52,6 +52,7 @@ const (
 	KindProcess                Kind = "process"
 	KindGPU                    Kind = "gpu"
 	KindKubelet                Kind = "kubelet"
+	KindContainerLanguages     Kind = "container_languages"
 )
 
 // Source is the source name of an entity.
@@ -65,8 +66,8 @@ const (
 
 	// SourceRuntime represents entities detected by the container runtime
 	// running on the node, collecting lower level information about
-	// containers. `docker`, `containerd`, 'crio', `podman` and `ecs_fargate`
-	// use this source.
+	// containers. `docker`, `containerd`, 'crio', `podman` and `ecs` on
+	// Fargate use this source.
 	SourceRuntime Source = "runtime"
 
 	// SourceTrivy represents entities detected by Trivy during the SBOM scan.
@@ -108,6 +109,11 @@ const (
 
 	// SourceKubeAPIServer represents metadata collected from the Kubernetes API Server
 	SourceKubeAPIServer Source = "kubeapiserver"
+
+	// SourceContainerLanguagesCollector represents the languages detected
+	// for the processes of containers, retained by the
+	// ContainerLanguagesCollector.
+	SourceContainerLanguagesCollector Source = "container_languages_collector"
 )
 
 // ContainerRuntime is the container runtime used by a container.
@@ -162,8 +168,9 @@ type ECSLaunchType string
 
 // Defined ECSLaunchTypes
 const (
-	ECSLaunchTypeEC2     ECSLaunchType = "ec2"
-	ECSLaunchTypeFargate ECSLaunchType = "fargate"
+	ECSLaunchTypeEC2      ECSLaunchType = "ec2"
+	ECSLaunchTypeFargate  ECSLaunchType = "fargate"
+	ECSLaunchTypeExternal ECSLaunchType = "external"
 )
 
 // AgentType defines the workloadmeta agent type
@@ -1845,6 +1852,73 @@ func (p HostTags) String(verbose bool) string {
 	return sb.String()
 }
 
+// ContainerLanguages is an Entity that represents the languages detected for
+// the processes of a container. Unlike the language of a Process, which is
+// removed with the process, a language is retained until it has not been
+// detected for language_detection.retention_ttl. The ID of the entity is the
+// ID of the container.
+type ContainerLanguages struct {
+	EntityID
+
+	Languages []DetectedLanguage
+}
+
+// DetectedLanguage is a language detected for the processes of a container.
+type DetectedLanguage struct {
+	languagemodels.Language
+
+	// LastDetected is the last time a process of the container was detected
+	// running with the language, as of the last update of the entity.
+	LastDetected time.Time
+}
+
+var _ Entity = &ContainerLanguages{}
+
+// GetID implements Entity#GetID.
+func (c ContainerLanguages) GetID() EntityID {
+	return c.EntityID
+}
+
+// DeepCopy implements Entity#DeepCopy.
+func (c ContainerLanguages) DeepCopy() Entity {
+	cp := deepcopy.Copy(c).(ContainerLanguages)
+	return &cp
+}
+
+// Merge implements Entity#Merge.
+func (c *ContainerLanguages) Merge(e Entity) error {
+	otherLanguages, ok := e.(*ContainerLanguages)
+	if !ok {
+		return fmt.Errorf("cannot merge ContainerLanguages with different kind %T", e)
+	}
+
+	// The languages of the source replace the ones of the destination
+	if len(otherLanguages.Languages) > 0 {
+		c.Languages = nil
+	}
+
+	return merge(c, otherLanguages)
+}
+
+// String implements Entity#String.
+func (c ContainerLanguages) String(verbose bool) string {
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintln(&sb, "----------- Entity ID -----------")
+	_, _ = fmt.Fprint(&sb, c.EntityID.String(verbose))
+
+	_, _ = fmt.Fprintln(&sb, "----------- Languages -----------")
+	for _, language := range c.Languages {
+		if verbose {
+			_, _ = fmt.Fprintln(&sb, "Language:", language.Name, "Version:", language.Version, "Last Detected:", language.LastDetected)
+		} else {
+			_, _ = fmt.Fprintln(&sb, "Language:", language.Name)
+		}
+	}
+
+	return sb.String()
+}
+
 // CollectorEvent is an event generated by a metadata collector, to be handled
 // by the metadata store.
 type CollectorEvent struct {
@@ -1996,6 +2070,18 @@ type GPU struct {
 
 	// VirtualizationMode contains the virtualization mode of the device
 	VirtualizationMode string
+
+	// ParentGPUUUID is the UUID of the physical device a MIG device is a
+	// partition of. Empty for the other devices.
+	ParentGPUUUID string
+
+	// ChildrenGPUUUIDs contains the UUIDs of the MIG devices a physical
+	// device is partitioned in.
+	ChildrenGPUUUIDs []string
+
+	// PCIBusID is the PCI bus ID of the device (e.g., 0000:03:00.0), which
+	// identifies the AMD GPUs allocated to containers. Optional, can be empty.
+	PCIBusID string
 }
 
 var _ Entity = &GPU{}
@@ -2017,6 +2103,11 @@ func (g *GPU) Merge(e Entity) error {
 		g.ActivePIDs = nil
 	}
 
+	// Same for the MIG devices, which can be created and destroyed
+	if gg.ChildrenGPUUUIDs != nil {
+		g.ChildrenGPUUUIDs = nil
+	}
+
 	return merge(g, gg)
 }
 
@@ -2052,6 +2143,13 @@ func (g GPU) String(verbose bool) string {
 	// Do not show "physical" device type as it's the default and redundant information
 	if g.DeviceType == GPUDeviceTypeMIG {
 		_, _ = fmt.Fprintln(&sb, "Device Type: MIG")
+		_, _ = fmt.Fprintln(&sb, "Parent GPU UUID:", g.ParentGPUUUID)
+	}
+	if len(g.ChildrenGPUUUIDs) > 0 {
+		_, _ = fmt.Fprintln(&sb, "MIG Devices:", g.ChildrenGPUUUIDs)
+	}
+	if g.PCIBusID != "" {
+		_, _ = fmt.Fprintln(&sb, "PCI Bus ID:", g.PCIBusID)
 	}
 
 	return sb.String()
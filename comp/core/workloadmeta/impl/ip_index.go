@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmetaimpl
+
+import (
+	wmdef "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+// ipIndex indexes the entities of the store by IP address. It is kept up to
+// date as the store handles events, so that looking up the workloads of an IP
+// does not need to go through all the entities.
+type ipIndex struct {
+	// entitiesByIP are the IDs of the entities with an IP address
+	entitiesByIP map[string]map[wmdef.EntityID]struct{}
+	// ipsByEntity are the IP addresses indexed for an entity
+	ipsByEntity map[wmdef.EntityID][]string
+}
+
+func newIPIndex() *ipIndex {
+	return &ipIndex{
+		entitiesByIP: make(map[string]map[wmdef.EntityID]struct{}),
+		ipsByEntity:  make(map[wmdef.EntityID][]string),
+	}
+}
+
+// update indexes the current IP addresses of an entity.
+func (idx *ipIndex) update(entityID wmdef.EntityID, entity wmdef.Entity) {
+	idx.remove(entityID)
+
+	ips := entityIPs(entity)
+	if len(ips) == 0 {
+		return
+	}
+
+	for _, ip := range ips {
+		entities, found := idx.entitiesByIP[ip]
+		if !found {
+			entities = make(map[wmdef.EntityID]struct{})
+			idx.entitiesByIP[ip] = entities
+		}
+		entities[entityID] = struct{}{}
+	}
+	idx.ipsByEntity[entityID] = ips
+}
+
+// remove removes an entity from the index.
+func (idx *ipIndex) remove(entityID wmdef.EntityID) {
+	for _, ip := range idx.ipsByEntity[entityID] {
+		entities := idx.entitiesByIP[ip]
+		delete(entities, entityID)
+		if len(entities) == 0 {
+			delete(idx.entitiesByIP, ip)
+		}
+	}
+	delete(idx.ipsByEntity, entityID)
+}
+
+// get returns the IDs of the entities with the given IP address.
+func (idx *ipIndex) get(ip string) []wmdef.EntityID {
+	entities := idx.entitiesByIP[ip]
+
+	entityIDs := make([]wmdef.EntityID, 0, len(entities))
+	for entityID := range entities {
+		entityIDs = append(entityIDs, entityID)
+	}
+
+	return entityIDs
+}
+
+// entityIPs returns the IP addresses of an entity. The pods on the host
+// network are not indexed, as their IP is the one of the node.
+func entityIPs(entity wmdef.Entity) []string {
+	switch e := entity.(type) {
+	case *wmdef.KubernetesPod:
+		if e.IP == "" || e.HostNetwork {
+			return nil
+		}
+		return []string{e.IP}
+	case *wmdef.Container:
+		ips := make([]string, 0, len(e.NetworkIPs))
+		for _, ip := range e.NetworkIPs {
+			if ip != "" {
+				ips = append(ips, ip)
+			}
+		}
+		return ips
+	default:
+		return nil
+	}
+}
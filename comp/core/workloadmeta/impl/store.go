@@ -479,6 +479,16 @@ func (w *workloadmeta) GetKubelet() (*wmdef.Kubelet, error) {
 	return entity.(*wmdef.Kubelet), nil
 }
 
+// GetContainerLanguages implements Store#GetContainerLanguages.
+func (w *workloadmeta) GetContainerLanguages(containerID string) (*wmdef.ContainerLanguages, error) {
+	entity, err := w.getEntityByKind(wmdef.KindContainerLanguages, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.(*wmdef.ContainerLanguages), nil
+}
+
 // ListGPUs implements Store#ListGPUs.
 func (w *workloadmeta) ListGPUs() []*wmdef.GPU {
 	entities := w.listEntitiesByKind(wmdef.KindGPU)
@@ -491,6 +501,23 @@ func (w *workloadmeta) ListGPUs() []*wmdef.GPU {
 	return gpuList
 }
 
+// ListEntitiesByIP implements Store#ListEntitiesByIP.
+func (w *workloadmeta) ListEntitiesByIP(ip string) []wmdef.Entity {
+	w.storeMut.RLock()
+	defer w.storeMut.RUnlock()
+
+	entityIDs := w.ipIndex.get(ip)
+
+	entities := make([]wmdef.Entity, 0, len(entityIDs))
+	for _, entityID := range entityIDs {
+		if entity, found := w.store[entityID.Kind][entityID.ID]; found {
+			entities = append(entities, entity.cached)
+		}
+	}
+
+	return entities
+}
+
 // Notify implements Store#Notify
 func (w *workloadmeta) Notify(events []wmdef.CollectorEvent) {
 	if len(events) > 0 {
@@ -797,6 +824,12 @@ func (w *workloadmeta) handleEvents(evs []wmdef.CollectorEvent) {
 			w.log.Errorf("cannot handle event of type %d. event dump: %+v", ev.Type, ev)
 		}
 
+		if stored, found := entitiesOfKind[entityID.ID]; found {
+			w.ipIndex.update(entityID, stored.cached)
+		} else {
+			w.ipIndex.remove(entityID)
+		}
+
 		for _, sub := range w.subscribers {
 			filter := sub.filter
 
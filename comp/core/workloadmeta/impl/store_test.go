@@ -1239,6 +1239,67 @@ func TestListKubernetesPods(t *testing.T) {
 	}
 }
 
+func TestListEntitiesByIP(t *testing.T) {
+	pod := &wmdef.KubernetesPod{
+		EntityID: wmdef.EntityID{
+			Kind: wmdef.KindKubernetesPod,
+			ID:   "pod-uid",
+		},
+		IP: "10.0.0.1",
+	}
+	hostNetworkPod := &wmdef.KubernetesPod{
+		EntityID: wmdef.EntityID{
+			Kind: wmdef.KindKubernetesPod,
+			ID:   "host-network-pod-uid",
+		},
+		IP:          "192.168.0.1",
+		HostNetwork: true,
+	}
+	container := &wmdef.Container{
+		EntityID: wmdef.EntityID{
+			Kind: wmdef.KindContainer,
+			ID:   "container-id",
+		},
+		NetworkIPs: map[string]string{
+			"bridge": "172.17.0.2",
+			"custom": "10.0.0.1",
+		},
+	}
+
+	s := newWorkloadmetaObject(t)
+	s.handleEvents([]wmdef.CollectorEvent{
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: pod},
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: hostNetworkPod},
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: container},
+	})
+
+	assert.ElementsMatch(t, []wmdef.Entity{pod, container}, s.ListEntitiesByIP("10.0.0.1"))
+	assert.ElementsMatch(t, []wmdef.Entity{container}, s.ListEntitiesByIP("172.17.0.2"))
+	assert.Empty(t, s.ListEntitiesByIP("192.168.0.1"))
+
+	// The index follows the IP changes of the entities
+	updatedPod := pod.DeepCopy().(*wmdef.KubernetesPod)
+	updatedPod.IP = "10.0.0.2"
+	s.handleEvents([]wmdef.CollectorEvent{
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: updatedPod},
+	})
+	assert.ElementsMatch(t, []wmdef.Entity{container}, s.ListEntitiesByIP("10.0.0.1"))
+	assert.ElementsMatch(t, []wmdef.Entity{updatedPod}, s.ListEntitiesByIP("10.0.0.2"))
+
+	// The IPs of an entity stay indexed until all its sources are unset
+	s.handleEvents([]wmdef.CollectorEvent{
+		{Type: wmdef.EventTypeSet, Source: barSource, Entity: container},
+		{Type: wmdef.EventTypeUnset, Source: fooSource, Entity: container},
+	})
+	assert.ElementsMatch(t, []wmdef.Entity{container}, s.ListEntitiesByIP("172.17.0.2"))
+
+	s.handleEvents([]wmdef.CollectorEvent{
+		{Type: wmdef.EventTypeUnset, Source: barSource, Entity: container},
+	})
+	assert.Empty(t, s.ListEntitiesByIP("172.17.0.2"))
+	assert.Empty(t, s.ListEntitiesByIP("10.0.0.1"))
+}
+
 func TestGetKubeletMetrics(t *testing.T) {
 	testKubeletMetrics := &wmdef.KubeletMetrics{
 		EntityID: wmdef.EntityID{
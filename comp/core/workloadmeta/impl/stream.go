@@ -0,0 +1,193 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmetaimpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	apiutils "github.com/DataDog/datadog-agent/comp/api/api/utils"
+	wmdef "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+)
+
+const (
+	streamSubscriberName = "workload-list-stream"
+
+	// streamBufferSize is the number of events buffered for a client of the
+	// stream before events are dropped
+	streamBufferSize = 1000
+
+	streamEventTypeSet     = "set"
+	streamEventTypeUnset   = "unset"
+	streamEventTypeDropped = "dropped"
+)
+
+// streamResponse streams the events of the store matching the filters of the
+// request, as one JSON object per line, until the client disconnects.
+func (w *workloadmeta) streamResponse(writer http.ResponseWriter, r *http.Request) {
+	var request wmdef.WorkloadStreamRequest
+	if r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			httputils.SetJSONError(writer, w.log.Errorf("Error while reading HTTP request body: %s", err), 500)
+			return
+		}
+
+		if err := json.Unmarshal(body, &request); err != nil {
+			httputils.SetJSONError(writer, w.log.Errorf("Error while unmarshaling JSON from request body: %s", err), 400)
+			return
+		}
+	}
+
+	var nameRegex *regexp.Regexp
+	if request.Name != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(request.Name); err != nil {
+			httputils.SetJSONError(writer, fmt.Errorf("invalid name regex %q: %w", request.Name, err), 400)
+			return
+		}
+	}
+
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		httputils.SetJSONError(writer, w.log.Errorf("Expected a Flusher type, got: %T", writer), 500)
+		return
+	}
+
+	filterBuilder := wmdef.NewFilterBuilder()
+	for _, kind := range request.Kinds {
+		filterBuilder.AddKind(wmdef.Kind(kind))
+	}
+	if request.Source != "" {
+		filterBuilder.SetSource(wmdef.Source(request.Source))
+	}
+
+	// Reset the `server_timeout` deadline for this connection as streaming holds the connection open.
+	if conn, ok := apiutils.GetConnection(r); ok {
+		_ = conn.SetDeadline(time.Time{})
+	}
+
+	writer.Header().Set("Transfer-Encoding", "chunked")
+
+	// The bundles are acknowledged as soon as they are received and their
+	// events dropped when the client does not keep up, so that a slow client
+	// never holds the store back.
+	eventCh := w.Subscribe(streamSubscriberName, wmdef.NormalPriority, filterBuilder.Build())
+	defer w.Unsubscribe(eventCh)
+
+	events := make(chan wmdef.Event, streamBufferSize)
+	dropped := make(chan int, 1)
+	go func() {
+		for bundle := range eventCh {
+			bundle.Acknowledge()
+
+			droppedEvents := 0
+			for _, event := range bundle.Events {
+				select {
+				case events <- event:
+				default:
+					droppedEvents++
+				}
+			}
+
+			if droppedEvents > 0 {
+				select {
+				case previous := <-dropped:
+					dropped <- previous + droppedEvents
+				default:
+					dropped <- droppedEvents
+				}
+			}
+		}
+	}()
+
+	encoder := json.NewEncoder(writer)
+	flushTicker := time.NewTicker(time.Second)
+	defer flushTicker.Stop()
+	for {
+		var streamEvent wmdef.WorkloadStreamEvent
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-flushTicker.C:
+			flusher.Flush()
+			continue
+		case droppedEvents := <-dropped:
+			streamEvent = wmdef.WorkloadStreamEvent{
+				Timestamp: time.Now(),
+				Type:      streamEventTypeDropped,
+				Info:      fmt.Sprintf("%d events dropped as the client did not keep up\n", droppedEvents),
+			}
+		case event := <-events:
+			if nameRegex != nil && !matchEntityName(nameRegex, event.Entity) {
+				continue
+			}
+			streamEvent = toStreamEvent(event, request.Verbose)
+		}
+
+		if err := encoder.Encode(streamEvent); err != nil {
+			w.log.Debugf("Stopping the workload stream: %v", err)
+			return
+		}
+
+		if len(events) == 0 {
+			flusher.Flush()
+		}
+	}
+}
+
+// toStreamEvent converts an event of the store to an event of the stream.
+func toStreamEvent(event wmdef.Event, verbose bool) wmdef.WorkloadStreamEvent {
+	eventType := streamEventTypeSet
+	if event.Type == wmdef.EventTypeUnset {
+		eventType = streamEventTypeUnset
+	}
+
+	entityID := event.Entity.GetID()
+	return wmdef.WorkloadStreamEvent{
+		Timestamp: time.Now(),
+		Type:      eventType,
+		Kind:      string(entityID.Kind),
+		ID:        entityID.ID,
+		Info:      event.Entity.String(verbose),
+	}
+}
+
+// matchEntityName returns whether the name or the ID of an entity matches the
+// regex.
+func matchEntityName(nameRegex *regexp.Regexp, entity wmdef.Entity) bool {
+	if nameRegex.MatchString(entity.GetID().ID) {
+		return true
+	}
+
+	var name string
+	switch e := entity.(type) {
+	case *wmdef.Container:
+		name = e.Name
+	case *wmdef.KubernetesPod:
+		name = e.Name
+	case *wmdef.KubernetesMetadata:
+		name = e.Name
+	case *wmdef.KubernetesDeployment:
+		name = e.Name
+	case *wmdef.ECSTask:
+		name = e.Name
+	case *wmdef.ContainerImageMetadata:
+		name = e.Name
+	case *wmdef.GPU:
+		name = e.Name
+	case *wmdef.Process:
+		name = e.Name
+	}
+
+	return name != "" && nameRegex.MatchString(name)
+}
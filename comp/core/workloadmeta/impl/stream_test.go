@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build test
+
+package workloadmetaimpl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wmdef "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+)
+
+func TestStreamResponse(t *testing.T) {
+	s := newWorkloadmetaObject(t)
+
+	container := func(id, name string) *wmdef.Container {
+		return &wmdef.Container{
+			EntityID:   wmdef.EntityID{Kind: wmdef.KindContainer, ID: id},
+			EntityMeta: wmdef.EntityMeta{Name: name},
+		}
+	}
+
+	s.handleEvents([]wmdef.CollectorEvent{
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: container("redis-1", "redis")},
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: container("nginx-1", "nginx")},
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: &wmdef.KubernetesPod{
+			EntityID:   wmdef.EntityID{Kind: wmdef.KindKubernetesPod, ID: "redis-pod"},
+			EntityMeta: wmdef.EntityMeta{Name: "redis"},
+		}},
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(s.streamResponse))
+	defer server.Close()
+
+	body, err := json.Marshal(wmdef.WorkloadStreamRequest{
+		Kinds: []string{string(wmdef.KindContainer)},
+		Name:  "^redis",
+	})
+	require.NoError(t, err)
+
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	lines := bufio.NewScanner(resp.Body)
+	nextEvent := func() wmdef.WorkloadStreamEvent {
+		require.True(t, lines.Scan())
+		var event wmdef.WorkloadStreamEvent
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &event))
+		return event
+	}
+
+	// The entities of the store matching the filters are streamed first
+	event := nextEvent()
+	assert.Equal(t, "set", event.Type)
+	assert.Equal(t, string(wmdef.KindContainer), event.Kind)
+	assert.Equal(t, "redis-1", event.ID)
+
+	s.handleEvents([]wmdef.CollectorEvent{
+		{Type: wmdef.EventTypeSet, Source: fooSource, Entity: container("nginx-2", "nginx")},
+		{Type: wmdef.EventTypeUnset, Source: fooSource, Entity: container("redis-1", "redis")},
+	})
+
+	event = nextEvent()
+	assert.Equal(t, "unset", event.Type)
+	assert.Equal(t, "redis-1", event.ID)
+}
+
+func TestStreamResponseInvalidName(t *testing.T) {
+	s := newWorkloadmetaObject(t)
+
+	server := httptest.NewServer(http.HandlerFunc(s.streamResponse))
+	defer server.Close()
+
+	body, err := json.Marshal(wmdef.WorkloadStreamRequest{Name: "("})
+	require.NoError(t, err)
+
+	resp, err := http.Post(server.URL, "application/json", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
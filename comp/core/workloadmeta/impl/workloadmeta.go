@@ -33,6 +33,7 @@ type workloadmeta struct {
 	// Store related
 	storeMut sync.RWMutex
 	store    map[wmdef.Kind]map[string]*cachedEntity // store[entity.Kind][entity.ID] = &cachedEntity{}
+	ipIndex  *ipIndex                                // protected by storeMut
 
 	subscribersMut sync.RWMutex
 	subscribers    []subscriber
@@ -61,9 +62,10 @@ type Dependencies struct {
 
 // Provider contains components provided by workloadmeta constructor.
 type Provider struct {
-	Comp          wmdef.Component
-	FlareProvider flaretypes.Provider
-	Endpoint      api.AgentEndpointProvider
+	Comp           wmdef.Component
+	FlareProvider  flaretypes.Provider
+	Endpoint       api.AgentEndpointProvider
+	StreamEndpoint api.AgentEndpointProvider
 }
 
 // NewWorkloadMeta creates a new workloadmeta component.
@@ -80,6 +82,7 @@ func NewWorkloadMeta(deps Dependencies) Provider {
 		config: deps.Config,
 
 		store:                 make(map[wmdef.Kind]map[string]*cachedEntity),
+		ipIndex:               newIPIndex(),
 		candidates:            candidates,
 		collectors:            make(map[string]wmdef.Collector),
 		eventCh:               make(chan []wmdef.CollectorEvent, eventChBufferSize),
@@ -111,9 +114,10 @@ func NewWorkloadMeta(deps Dependencies) Provider {
 	}})
 
 	return Provider{
-		Comp:          wm,
-		FlareProvider: flaretypes.NewProvider(wm.sbomFlareProvider),
-		Endpoint:      api.NewAgentEndpointProvider(wm.writeResponse, "/workload-list", "GET"),
+		Comp:           wm,
+		FlareProvider:  flaretypes.NewProvider(wm.sbomFlareProvider),
+		Endpoint:       api.NewAgentEndpointProvider(wm.writeResponse, "/workload-list", "GET"),
+		StreamEndpoint: api.NewAgentEndpointProvider(wm.streamResponse, "/workload-list/stream", "POST"),
 	}
 }
 
@@ -514,6 +514,8 @@ func toProtoLaunchType(launchType workloadmeta.ECSLaunchType) (pb.ECSLaunchType,
 		return pb.ECSLaunchType_EC2, nil
 	case workloadmeta.ECSLaunchTypeFargate:
 		return pb.ECSLaunchType_FARGATE, nil
+	case workloadmeta.ECSLaunchTypeExternal:
+		return pb.ECSLaunchType_EXTERNAL, nil
 	}
 
 	return pb.ECSLaunchType_EC2, fmt.Errorf("unknown launch type: %s", launchType)
@@ -1065,6 +1067,8 @@ func toECSLaunchType(protoLaunchType pb.ECSLaunchType) (workloadmeta.ECSLaunchTy
 		return workloadmeta.ECSLaunchTypeEC2, nil
 	case pb.ECSLaunchType_FARGATE:
 		return workloadmeta.ECSLaunchTypeFargate, nil
+	case pb.ECSLaunchType_EXTERNAL:
+		return workloadmeta.ECSLaunchTypeExternal, nil
 	}
 
 	return workloadmeta.ECSLaunchTypeEC2, fmt.Errorf("unknown launch type: %s", protoLaunchType)
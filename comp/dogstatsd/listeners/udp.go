@@ -13,11 +13,14 @@ import (
 	"sync"
 	"time"
 
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
 	"github.com/DataDog/datadog-agent/comp/dogstatsd/packets"
+	"github.com/DataDog/datadog-agent/comp/dogstatsd/pidmap"
 	replay "github.com/DataDog/datadog-agent/comp/dogstatsd/replay/def"
 	"github.com/DataDog/datadog-agent/pkg/config/model"
 	configutils "github.com/DataDog/datadog-agent/pkg/config/utils"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
 )
 
 var (
@@ -45,7 +48,8 @@ type netUDPConn interface {
 // UDPListener implements the StatsdListener interface for UDP protocol.
 // It listens to a given UDP address and sends back packets ready to be
 // processed.
-// Origin detection is not implemented for UDP.
+// Origin detection is only available through system-probe for UDP, see
+// dogstatsd_origin_detection_udp.
 type UDPListener struct {
 	conn            netUDPConn
 	packetsBuffer   *packets.Buffer
@@ -54,10 +58,15 @@ type UDPListener struct {
 	trafficCapture  replay.Component // Currently ignored
 	listenWg        sync.WaitGroup
 	telemetryStore  *TelemetryStore
+
+	// originResolver is set when the origin of the traffic is detected with
+	// system-probe, the packets are then not assembled to keep their origin
+	originResolver          *udpOriginResolver
+	sharedPacketPoolManager *packets.PoolManager[packets.Packet]
 }
 
 // NewUDPListener returns an idle UDP Statsd listener
-func NewUDPListener(packetOut chan packets.Packets, sharedPacketPoolManager *packets.PoolManager[packets.Packet], cfg model.Reader, capture replay.Component, telemetryStore *TelemetryStore, packetsTelemetryStore *packets.TelemetryStore) (*UDPListener, error) {
+func NewUDPListener(packetOut chan packets.Packets, sharedPacketPoolManager *packets.PoolManager[packets.Packet], cfg model.Reader, capture replay.Component, wmeta option.Option[workloadmeta.Component], pidMap pidmap.Component, telemetryStore *TelemetryStore, packetsTelemetryStore *packets.TelemetryStore) (*UDPListener, error) {
 	var err error
 	var url string
 
@@ -104,6 +113,10 @@ func NewUDPListener(packetOut chan packets.Packets, sharedPacketPoolManager *pac
 		trafficCapture:  capture,
 		telemetryStore:  telemetryStore,
 	}
+	if cfg.GetBool("dogstatsd_origin_detection_udp") {
+		listener.originResolver = newUDPOriginResolver(conn.LocalAddr().(*net.UDPAddr).Port, wmeta, pidMap)
+		listener.sharedPacketPoolManager = sharedPacketPoolManager
+	}
 	log.Debugf("dogstatsd-udp: %s successfully initialized", conn.LocalAddr())
 	return listener, nil
 }
@@ -127,7 +140,7 @@ func (l *UDPListener) listen() {
 	var t1, t2 time.Time
 	log.Infof("dogstatsd-udp: starting to listen on %s", l.conn.LocalAddr())
 	for {
-		n, _, err := l.conn.ReadFrom(l.buffer)
+		n, addr, err := l.conn.ReadFrom(l.buffer)
 		t1 = time.Now()
 		udpPackets.Add(1)
 
@@ -146,8 +159,12 @@ func (l *UDPListener) listen() {
 			udpBytes.Add(int64(n))
 			l.telemetryStore.tlmUDPPacketsBytes.Add(float64(n))
 
-			// packetAssembler merges multiple packets together and sends them when its buffer is full
-			l.packetAssembler.AddMessage(l.buffer[:n])
+			if l.originResolver != nil {
+				l.appendPacketWithOrigin(l.buffer[:n], addr)
+			} else {
+				// packetAssembler merges multiple packets together and sends them when its buffer is full
+				l.packetAssembler.AddMessage(l.buffer[:n])
+			}
 		}
 
 		t2 = time.Now()
@@ -155,8 +172,22 @@ func (l *UDPListener) listen() {
 	}
 }
 
+// appendPacketWithOrigin sends the message in its own packet, tagged with the
+// origin of its sender.
+func (l *UDPListener) appendPacketWithOrigin(message []byte, addr net.Addr) {
+	packet := l.sharedPacketPoolManager.Get()
+	n := copy(packet.Buffer, message)
+	packet.Contents = packet.Buffer[:n]
+	packet.Source = packets.UDP
+	packet.ProcessID, packet.Origin = l.originResolver.origin(addr)
+	l.packetsBuffer.Append(packet)
+}
+
 // Stop closes the UDP connection and stops listening
 func (l *UDPListener) Stop() {
+	if l.originResolver != nil {
+		l.originResolver.close()
+	}
 	l.packetAssembler.Close()
 	l.packetsBuffer.Close()
 	l.conn.Close()
@@ -0,0 +1,124 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package listeners
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/comp/dogstatsd/packets"
+	"github.com/DataDog/datadog-agent/comp/dogstatsd/pidmap"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	sysprobeclient "github.com/DataDog/datadog-agent/pkg/system-probe/api/client"
+	sysconfig "github.com/DataDog/datadog-agent/pkg/system-probe/config"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
+)
+
+// udpOriginRefreshInterval is the interval at which the PIDs of the senders
+// of the UDP traffic are fetched from system-probe.
+const udpOriginRefreshInterval = 5 * time.Second
+
+// udpOriginResolver detects the origin of the UDP traffic with system-probe,
+// which maps the source address of the active UDP connections to the PID of
+// the process owning their socket. The PID is then resolved to its container
+// as with UDS origin detection.
+type udpOriginResolver struct {
+	client *http.Client
+	port   int
+	wmeta  option.Option[workloadmeta.Component]
+	pidMap pidmap.Component
+
+	mu   sync.RWMutex
+	pids map[string]uint32
+
+	stop chan struct{}
+}
+
+// newUDPOriginResolver returns a resolver of the origin of the UDP traffic
+// received on the given port, fetching the PIDs of the senders in the
+// background until stopped.
+func newUDPOriginResolver(port int, wmeta option.Option[workloadmeta.Component], pidMap pidmap.Component) *udpOriginResolver {
+	r := &udpOriginResolver{
+		client: sysprobeclient.Get(pkgconfigsetup.SystemProbe().GetString("system_probe_config.sysprobe_socket")),
+		port:   port,
+		wmeta:  wmeta,
+		pidMap: pidMap,
+		pids:   make(map[string]uint32),
+		stop:   make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *udpOriginResolver) run() {
+	ticker := time.NewTicker(udpOriginRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.refresh(); err != nil {
+			log.Debugf("dogstatsd-udp: unable to fetch the pids of the udp sources from system-probe: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *udpOriginResolver) refresh() error {
+	url := sysprobeclient.ModuleURL(sysconfig.NetworkTracerModule, "/udp_source_pids?port="+strconv.Itoa(r.port))
+	resp, err := r.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("udp_source_pids request failed: url: %s, status code: %d", url, resp.StatusCode)
+	}
+
+	pids := make(map[string]uint32)
+	if err := json.NewDecoder(resp.Body).Decode(&pids); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.pids = pids
+	r.mu.Unlock()
+	return nil
+}
+
+// origin returns the PID and the entity of the sender of the traffic received
+// from the given address, or packets.NoOrigin if it is unknown.
+func (r *udpOriginResolver) origin(addr net.Addr) (uint32, string) {
+	r.mu.RLock()
+	pid, found := r.pids[addr.String()]
+	r.mu.RUnlock()
+	if !found {
+		return 0, packets.NoOrigin
+	}
+
+	entity, err := getEntityForPID(int32(pid), false, r.wmeta, r.pidMap)
+	if err != nil {
+		log.Debugf("dogstatsd-udp: unable to resolve the origin of pid %d: %v", pid, err)
+		return pid, packets.NoOrigin
+	}
+	return pid, entity
+}
+
+// close stops fetching the PIDs of the senders.
+func (r *udpOriginResolver) close() {
+	close(r.stop)
+}
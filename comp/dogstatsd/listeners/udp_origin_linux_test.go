@@ -0,0 +1,35 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package listeners
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/comp/dogstatsd/packets"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
+)
+
+func TestUDPOriginResolverUnknownSource(t *testing.T) {
+	deps := fulfillDepsWithConfig(t, map[string]interface{}{})
+	r := &udpOriginResolver{
+		wmeta:  option.None[workloadmeta.Component](),
+		pidMap: deps.PidMap,
+		pids: map[string]uint32{
+			"10.0.0.1:40000": 4242,
+		},
+	}
+
+	pid, origin := r.origin(&net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 40000})
+	assert.Zero(t, pid)
+	assert.Equal(t, packets.NoOrigin, origin)
+
+	pid, _ = r.origin(&net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000})
+	assert.Equal(t, uint32(4242), pid)
+}
@@ -0,0 +1,31 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !linux
+
+package listeners
+
+import (
+	"net"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/comp/dogstatsd/packets"
+	"github.com/DataDog/datadog-agent/comp/dogstatsd/pidmap"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
+)
+
+// udpOriginResolver is only implemented on Linux hosts
+type udpOriginResolver struct{}
+
+// newUDPOriginResolver returns nil on non-linux hosts
+func newUDPOriginResolver(_ int, _ option.Option[workloadmeta.Component], _ pidmap.Component) *udpOriginResolver {
+	return nil
+}
+
+func (r *udpOriginResolver) origin(_ net.Addr) (uint32, string) {
+	return 0, packets.NoOrigin
+}
+
+func (r *udpOriginResolver) close() {}
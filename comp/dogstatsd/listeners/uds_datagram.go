@@ -30,7 +30,17 @@ type UDSDatagramListener struct {
 
 // NewUDSDatagramListener returns an idle UDS datagram Statsd listener
 func NewUDSDatagramListener(packetOut chan packets.Packets, sharedPacketPoolManager *packets.PoolManager[packets.Packet], sharedOobPoolManager *packets.PoolManager[[]byte], cfg model.Reader, capture replay.Component, wmeta option.Option[workloadmeta.Component], pidMap pidmap.Component, telemetryStore *TelemetryStore, packetsTelemetryStore *packets.TelemetryStore, telemetryComponent telemetry.Component) (*UDSDatagramListener, error) {
-	socketPath := cfg.GetString("dogstatsd_socket")
+	return NewUDSDatagramListenerOnPath(cfg.GetString("dogstatsd_socket"), packetOut, sharedPacketPoolManager, sharedOobPoolManager, cfg, capture, wmeta, pidMap, telemetryStore, packetsTelemetryStore, telemetryComponent)
+}
+
+// ShardSocketPath returns the path of a shard of the dogstatsd socket: dsd.socket-0, dsd.socket-1, ...
+func ShardSocketPath(socketPath string, shard int) string {
+	return fmt.Sprintf("%s-%d", socketPath, shard)
+}
+
+// NewUDSDatagramListenerOnPath returns an idle UDS datagram Statsd listener on
+// the given socket path, used for the shards of the dogstatsd socket.
+func NewUDSDatagramListenerOnPath(socketPath string, packetOut chan packets.Packets, sharedPacketPoolManager *packets.PoolManager[packets.Packet], sharedOobPoolManager *packets.PoolManager[[]byte], cfg model.Reader, capture replay.Component, wmeta option.Option[workloadmeta.Component], pidMap pidmap.Component, telemetryStore *TelemetryStore, packetsTelemetryStore *packets.TelemetryStore, telemetryComponent telemetry.Component) (*UDSDatagramListener, error) {
 	transport := "unixgram"
 
 	_, err := setupSocketBeforeListen(socketPath, transport)
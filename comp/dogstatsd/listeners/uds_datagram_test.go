@@ -130,3 +130,21 @@ func TestUDSDatagramReceive(t *testing.T) {
 		assert.FailNow(t, "Timeout on receive channel")
 	}
 }
+
+func TestUDSDatagramListenerOnShardPath(t *testing.T) {
+	socketPath := testSocketPath(t)
+	shardPath := ShardSocketPath(socketPath, 1)
+	assert.Equal(t, socketPath+"-1", shardPath)
+
+	mockConfig := map[string]interface{}{}
+	mockConfig[socketPathConfKey("unixgram")] = socketPath
+
+	deps := fulfillDepsWithConfig(t, mockConfig)
+	telemetryStore := NewTelemetryStore(nil, deps.Telemetry)
+	packetsTelemetryStore := packets.NewTelemetryStore(nil, deps.Telemetry)
+	s, err := NewUDSDatagramListenerOnPath(shardPath, make(chan packets.Packets), newPacketPoolManagerUDS(deps.Config, packetsTelemetryStore), nil, deps.Config, nil, option.None[workloadmeta.Component](), deps.PidMap, telemetryStore, packetsTelemetryStore, deps.Telemetry)
+	require.NoError(t, err)
+	defer s.Stop()
+
+	assert.Equal(t, shardPath, s.conn.LocalAddr().String())
+}
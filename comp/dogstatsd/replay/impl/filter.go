@@ -0,0 +1,245 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package replayimpl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+
+	proto "github.com/golang/protobuf/proto"
+)
+
+// anonymizedValueLength is the number of hexadecimal characters of the hash
+// replacing an anonymized tag value.
+const anonymizedValueLength = 16
+
+var (
+	eventPrefix        = []byte("_e{")
+	serviceCheckPrefix = []byte("_sc|")
+)
+
+// PayloadFilter filters the messages of the payloads of a traffic capture by
+// metric prefix and tag, and anonymizes their tag values.
+type PayloadFilter struct {
+	metricPrefixes []string
+	tags           []string
+	anonymize      bool
+}
+
+// NewPayloadFilter returns a PayloadFilter keeping the metrics whose name
+// starts with one of the metric prefixes and the messages having one of the
+// tags, given as key:value or as key to match any value. Events and service
+// checks are dropped when metric prefixes are given. Tag values are replaced
+// by a deterministic hash when anonymize is true, so that the same value is
+// anonymized the same way in all the messages of a capture.
+func NewPayloadFilter(metricPrefixes []string, tags []string, anonymize bool) *PayloadFilter {
+	return &PayloadFilter{
+		metricPrefixes: metricPrefixes,
+		tags:           tags,
+		anonymize:      anonymize,
+	}
+}
+
+// IsNoop returns true if the filter doesn't modify the payloads.
+func (f *PayloadFilter) IsNoop() bool {
+	return f == nil || (len(f.metricPrefixes) == 0 && len(f.tags) == 0 && !f.anonymize)
+}
+
+// Filter returns the payload with the messages not matching the filter
+// removed and their tag values anonymized. The returned payload is empty if
+// no message matches the filter.
+func (f *PayloadFilter) Filter(payload []byte) []byte {
+	if f.IsNoop() {
+		return payload
+	}
+
+	filtered := make([]byte, 0, len(payload))
+	for _, message := range bytes.Split(payload, []byte("\n")) {
+		if len(message) == 0 {
+			continue
+		}
+
+		fields := bytes.Split(message, []byte("|"))
+		if !f.matchesMetricPrefixes(message) || !f.matchesTags(fields) {
+			continue
+		}
+		if f.anonymize {
+			message = anonymizeMessage(fields)
+		}
+
+		if len(filtered) > 0 {
+			filtered = append(filtered, '\n')
+		}
+		filtered = append(filtered, message...)
+	}
+	return filtered
+}
+
+// FilterState returns the tagger state of a capture with its tag values
+// anonymized if needed.
+func (f *PayloadFilter) FilterState(state *pb.TaggerState) *pb.TaggerState {
+	if state == nil || f == nil || !f.anonymize {
+		return state
+	}
+
+	for _, entity := range state.State {
+		for _, tags := range [][]string{entity.HighCardinalityTags, entity.OrchestratorCardinalityTags, entity.LowCardinalityTags, entity.StandardTags} {
+			for i, tag := range tags {
+				tags[i] = anonymizeTag(tag)
+			}
+		}
+	}
+	return state
+}
+
+func (f *PayloadFilter) matchesMetricPrefixes(message []byte) bool {
+	if len(f.metricPrefixes) == 0 {
+		return true
+	}
+	if bytes.HasPrefix(message, eventPrefix) || bytes.HasPrefix(message, serviceCheckPrefix) {
+		return false
+	}
+
+	for _, prefix := range f.metricPrefixes {
+		if bytes.HasPrefix(message, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *PayloadFilter) matchesTags(fields [][]byte) bool {
+	if len(f.tags) == 0 {
+		return true
+	}
+
+	for _, field := range fields {
+		if len(field) == 0 || field[0] != '#' {
+			continue
+		}
+		for _, tag := range strings.Split(string(field[1:]), ",") {
+			for _, filterTag := range f.tags {
+				if tag == filterTag || strings.HasPrefix(tag, filterTag+":") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// anonymizeMessage returns the message with the values of the tags of its
+// fields anonymized.
+func anonymizeMessage(fields [][]byte) []byte {
+	for i, field := range fields {
+		if len(field) == 0 || field[0] != '#' {
+			continue
+		}
+		tags := strings.Split(string(field[1:]), ",")
+		for j, tag := range tags {
+			tags[j] = anonymizeTag(tag)
+		}
+		fields[i] = []byte("#" + strings.Join(tags, ","))
+	}
+	return bytes.Join(fields, []byte("|"))
+}
+
+// anonymizeTag replaces the value of a key:value tag, or the tag itself if it
+// has no value, by the beginning of its SHA-256 hash.
+func anonymizeTag(tag string) string {
+	key, value, found := strings.Cut(tag, ":")
+	if !found {
+		return anonymizeValue(tag)
+	}
+	return key + ":" + anonymizeValue(value)
+}
+
+func anonymizeValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:anonymizedValueLength]
+}
+
+// WriteFilteredCapture writes the messages of a traffic capture matching the
+// filter, followed by its tagger state, to a new capture file. The messages
+// are written with nanosecond timestamps, whatever the version of the capture.
+func WriteFilteredCapture(reader *TrafficCaptureReader, w io.Writer, filter *PayloadFilter) error {
+	if err := WriteHeader(w); err != nil {
+		return err
+	}
+
+	reader.Seek(0)
+	for {
+		msg, err := reader.ReadNext()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		payload := filter.Filter(msg.Payload[:msg.PayloadSize])
+		if len(payload) == 0 {
+			continue
+		}
+		msg.Payload = payload
+		msg.PayloadSize = int32(len(payload))
+		if reader.Version < minNanoVersion {
+			msg.Timestamp *= int64(1e9)
+		}
+
+		if err := writeRecord(w, msg); err != nil {
+			return err
+		}
+	}
+
+	// Record State Separator
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return err
+	}
+
+	var state []byte
+	if reader.Version >= minStateVersion {
+		pbState, err := reader.ReadState()
+		if err != nil {
+			return err
+		}
+		if pbState != nil {
+			if state, err = proto.Marshal(filter.FilterState(pbState)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Record State, followed by its size
+	if _, err := w.Write(state); err != nil {
+		return err
+	}
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, uint32(len(state)))
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeRecord writes a message of a capture file, preceded by its size.
+func writeRecord(w io.Writer, msg *pb.UnixDogstatsdMsg) error {
+	record, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, uint32(len(record)))
+	if _, err := w.Write(buf); err != nil {
+		return err
+	}
+	_, err = w.Write(record)
+	return err
+}
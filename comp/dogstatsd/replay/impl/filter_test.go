@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package replayimpl
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+func TestPayloadFilter(t *testing.T) {
+	payload := []byte("app.requests:1|c|#env:prod,team:payments\n" +
+		"app.latency:12|d|#env:staging\n" +
+		"other.metric:1|g|#env:prod\n" +
+		"_e{5,4}:title|text|#env:prod\n" +
+		"_sc|check|0|#env:prod|m:message")
+
+	for _, tc := range []struct {
+		name     string
+		filter   *PayloadFilter
+		expected string
+	}{
+		{
+			name:     "no filter",
+			filter:   NewPayloadFilter(nil, nil, false),
+			expected: string(payload),
+		},
+		{
+			name:     "metric prefix",
+			filter:   NewPayloadFilter([]string{"app."}, nil, false),
+			expected: "app.requests:1|c|#env:prod,team:payments\napp.latency:12|d|#env:staging",
+		},
+		{
+			name:     "tag",
+			filter:   NewPayloadFilter(nil, []string{"env:prod"}, false),
+			expected: "app.requests:1|c|#env:prod,team:payments\nother.metric:1|g|#env:prod\n_e{5,4}:title|text|#env:prod\n_sc|check|0|#env:prod|m:message",
+		},
+		{
+			name:     "tag key",
+			filter:   NewPayloadFilter([]string{"app."}, []string{"team"}, false),
+			expected: "app.requests:1|c|#env:prod,team:payments",
+		},
+		{
+			name:     "no match",
+			filter:   NewPayloadFilter([]string{"unknown."}, nil, false),
+			expected: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, string(tc.filter.Filter(payload)))
+		})
+	}
+}
+
+func TestPayloadFilterAnonymize(t *testing.T) {
+	filter := NewPayloadFilter(nil, nil, true)
+
+	anonymized := string(filter.Filter([]byte("app.requests:1|c|#env:prod,internal\n_sc|check|0|#env:prod|m:message")))
+
+	prod := anonymizeValue("prod")
+	assert.Len(t, prod, anonymizedValueLength)
+	assert.Equal(t, "app.requests:1|c|#env:"+prod+","+anonymizeValue("internal")+"\n_sc|check|0|#env:"+prod+"|m:message", anonymized)
+
+	state := filter.FilterState(&pb.TaggerState{
+		State: map[string]*pb.Entity{
+			"container": {LowCardinalityTags: []string{"env:prod"}},
+		},
+	})
+	assert.Equal(t, []string{"env:" + prod}, state.State["container"].LowCardinalityTags)
+}
+
+func TestWriteFilteredCapture(t *testing.T) {
+	reader, err := NewTrafficCaptureReader("resources/test/datadog-capture.dog", 1, false)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	path := filepath.Join(t.TempDir(), "filtered.dog")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, WriteFilteredCapture(reader, f, NewPayloadFilter(nil, nil, true)))
+	require.NoError(t, f.Close())
+
+	filtered, err := NewTrafficCaptureReader(path, 1, false)
+	require.NoError(t, err)
+	defer filtered.Close()
+	assert.Equal(t, int(datadogFileVersion), filtered.Version)
+
+	state, err := filtered.ReadState()
+	require.NoError(t, err)
+	assert.NotNil(t, state.PidMap)
+
+	filtered.Seek(0)
+	cnt := 0
+	for msg, err := filtered.ReadNext(); err != io.EOF; msg, err = filtered.ReadNext() {
+		require.NoError(t, err)
+		assert.Equal(t, int(msg.PayloadSize), len(msg.Payload))
+		cnt++
+	}
+	assert.Equal(t, 21, cnt)
+}
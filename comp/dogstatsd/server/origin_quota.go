@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/core/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+// messageTypeCounts holds a number for each message type.
+type messageTypeCounts [eventType + 1]int
+
+// originQuotas limits the metrics, events and service checks ingested per
+// second from each origin (container or pod), so that a single noisy origin
+// cannot starve all the others. Messages without origin are not limited.
+type originQuotas struct {
+	// limits holds the number of messages allowed per second for each message
+	// type, 0 meaning unlimited
+	limits messageTypeCounts
+
+	// lock must be held when accessing second and counts
+	lock   sync.Mutex
+	second int64
+	// counts holds the messages ingested from each origin during the current second
+	counts map[string]*messageTypeCounts
+
+	now        func() time.Time
+	tlmDropped telemetry.Counter
+}
+
+// newOriginQuotas returns the origin quotas defined in the configuration, or
+// nil if no quota is defined.
+func newOriginQuotas(cfg model.Reader, telemetrycomp telemetry.Component) *originQuotas {
+	var limits messageTypeCounts
+	limits[metricSampleType] = cfg.GetInt("dogstatsd_origin_quota.metrics_per_second")
+	limits[eventType] = cfg.GetInt("dogstatsd_origin_quota.events_per_second")
+	limits[serviceCheckType] = cfg.GetInt("dogstatsd_origin_quota.service_checks_per_second")
+
+	if limits == (messageTypeCounts{}) {
+		return nil
+	}
+
+	return &originQuotas{
+		limits: limits,
+		counts: make(map[string]*messageTypeCounts),
+		now:    time.Now,
+		tlmDropped: telemetrycomp.NewCounter("dogstatsd", "origin_quota_drops",
+			[]string{"message_type", "origin"}, "Count of service checks/events/metrics dropped because their origin exceeded its quota"),
+	}
+}
+
+// allow returns whether a message of the given type from the given origin is
+// within the quota of the origin for the current second. Dropped messages are
+// counted by message type and origin.
+func (q *originQuotas) allow(origin string, msgType messageType) bool {
+	if q == nil || origin == "" || q.limits[msgType] <= 0 {
+		return true
+	}
+
+	q.lock.Lock()
+	second := q.now().Unix()
+	if second != q.second {
+		// only keeping the origins of the current second bounds the memory usage
+		q.second = second
+		clear(q.counts)
+	}
+	counts, ok := q.counts[origin]
+	if !ok {
+		counts = &messageTypeCounts{}
+		q.counts[origin] = counts
+	}
+	counts[msgType]++
+	allowed := counts[msgType] <= q.limits[msgType]
+	q.lock.Unlock()
+
+	if !allowed {
+		q.tlmDropped.Inc(messageTypeTag(msgType), origin)
+	}
+	return allowed
+}
+
+// messageTypeTag returns the message_type tag of the dogstatsd telemetry for a message type.
+func messageTypeTag(msgType messageType) string {
+	switch msgType {
+	case serviceCheckType:
+		return "service_checks"
+	case eventType:
+		return "events"
+	default:
+		return "metrics"
+	}
+}
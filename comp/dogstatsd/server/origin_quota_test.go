@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/telemetry/telemetryimpl"
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
+)
+
+func TestOriginQuotasDisabled(t *testing.T) {
+	cfg := configmock.New(t)
+	q := newOriginQuotas(cfg, telemetryimpl.NewMock(t))
+	assert.Nil(t, q)
+
+	// a nil quota allows everything
+	for i := 0; i < 10; i++ {
+		assert.True(t, q.allow("container_id://foo", metricSampleType))
+	}
+}
+
+func TestOriginQuotas(t *testing.T) {
+	cfg := configmock.New(t)
+	cfg.SetWithoutSource("dogstatsd_origin_quota.metrics_per_second", 3)
+	cfg.SetWithoutSource("dogstatsd_origin_quota.events_per_second", 1)
+	telemetryMock := telemetryimpl.NewMock(t)
+
+	q := newOriginQuotas(cfg, telemetryMock)
+	require.NotNil(t, q)
+	now := time.Unix(1000, 0)
+	q.now = func() time.Time { return now }
+
+	allowed := func(origin string, msgType messageType, count int) int {
+		n := 0
+		for i := 0; i < count; i++ {
+			if q.allow(origin, msgType) {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.Equal(t, 3, allowed("container_id://noisy", metricSampleType, 10))
+	assert.Equal(t, 1, allowed("container_id://noisy", eventType, 2))
+	// no quota for service checks
+	assert.Equal(t, 5, allowed("container_id://noisy", serviceCheckType, 5))
+	// the quotas are per origin
+	assert.Equal(t, 3, allowed("container_id://quiet", metricSampleType, 3))
+	// messages without origin are not limited
+	assert.Equal(t, 10, allowed("", metricSampleType, 10))
+
+	// the quotas are reset every second
+	now = now.Add(time.Second)
+	assert.Equal(t, 3, allowed("container_id://noisy", metricSampleType, 4))
+
+	drops, err := telemetryMock.GetCountMetric("dogstatsd", "origin_quota_drops")
+	require.NoError(t, err)
+	dropsByType := map[string]float64{}
+	for _, metric := range drops {
+		assert.Equal(t, "container_id://noisy", metric.Tags()["origin"])
+		dropsByType[metric.Tags()["message_type"]] = metric.Value()
+	}
+	assert.Equal(t, map[string]float64{"metrics": 8, "events": 1}, dropsByType)
+}
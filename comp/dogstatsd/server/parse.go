@@ -18,6 +18,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/util/containers/metrics/provider"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 	"github.com/DataDog/datadog-agent/pkg/util/option"
+	utilstrings "github.com/DataDog/datadog-agent/pkg/util/strings"
 )
 
 type messageType int
@@ -66,6 +67,10 @@ type parser struct {
 
 	// readTimestamps is true if the parser has to read timestamps from messages.
 	readTimestamps bool
+	// noAggPipelinePrefixes restricts the metrics whose timestamp is read, and
+	// which are sent to the no-aggregation pipeline, to the metrics matching
+	// these prefixes. The timestamps of all the metrics are read when nil.
+	noAggPipelinePrefixes *utilstrings.Matcher
 
 	// Generic Metric Provider
 	provider provider.Provider
@@ -75,12 +80,19 @@ func newParser(cfg model.Reader, float64List *float64ListPool, workerNum int, wm
 	stringInternerCacheSize := cfg.GetInt("dogstatsd_string_interner_size")
 	readTimestamps := cfg.GetBool("dogstatsd_no_aggregation_pipeline")
 
+	var noAggPipelinePrefixes *utilstrings.Matcher
+	if prefixes := cfg.GetStringSlice("dogstatsd_no_aggregation_pipeline_prefixes"); readTimestamps && len(prefixes) > 0 {
+		matcher := utilstrings.NewMatcher(prefixes, true)
+		noAggPipelinePrefixes = &matcher
+	}
+
 	return &parser{
-		interner:         newStringInterner(stringInternerCacheSize, workerNum, stringInternerTelemetry),
-		readTimestamps:   readTimestamps,
-		float64List:      float64List,
-		dsdOriginEnabled: cfg.GetBool("dogstatsd_origin_detection_client"),
-		provider:         provider.GetProvider(wmeta),
+		interner:              newStringInterner(stringInternerCacheSize, workerNum, stringInternerTelemetry),
+		readTimestamps:        readTimestamps,
+		noAggPipelinePrefixes: noAggPipelinePrefixes,
+		float64List:           float64List,
+		dsdOriginEnabled:      cfg.GetBool("dogstatsd_origin_detection_client"),
+		provider:              provider.GetProvider(wmeta),
 	}
 }
 
@@ -194,7 +206,9 @@ func (p *parser) parseMetricSample(message []byte) (dogstatsdMetricSample, error
 			}
 		// timestamp
 		case bytes.HasPrefix(optionalField, timestampFieldPrefix):
-			if !p.readTimestamps {
+			// the metrics not opted in the no-aggregation pipeline are aggregated
+			// as if they had no timestamp
+			if !p.readTimestamps || (p.noAggPipelinePrefixes != nil && !p.noAggPipelinePrefixes.Test(string(name))) {
 				continue
 			}
 			ts, err := strconv.ParseInt(string(optionalField[len(timestampFieldPrefix):]), 10, 0)
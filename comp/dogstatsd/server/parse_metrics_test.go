@@ -351,6 +351,23 @@ func TestParseMetricError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParseTimestampNoAggPipelinePrefixes(t *testing.T) {
+	cfg := map[string]any{}
+	cfg["dogstatsd_no_aggregation_pipeline"] = true
+	cfg["dogstatsd_no_aggregation_pipeline_prefixes"] = []string{"business."}
+
+	// the timestamp of the metrics matching a prefix is read
+	sample, err := parseMetricSample(t, cfg, []byte("business.orders:1|c|T1657100430"))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1657100430, 0), sample.ts)
+
+	// the other metrics are aggregated as if they had no timestamp
+	sample, err = parseMetricSample(t, cfg, []byte("app.requests:1|c|T1657100430"))
+	assert.NoError(t, err)
+	assert.Equal(t, "app.requests", sample.name)
+	assert.Zero(t, sample.ts)
+}
+
 func TestParseGaugeWithTimestamp(t *testing.T) {
 	// disable the no agg pipeline
 
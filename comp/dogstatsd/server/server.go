@@ -13,6 +13,7 @@ import (
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/fx"
@@ -32,6 +33,7 @@ import (
 	rctypes "github.com/DataDog/datadog-agent/comp/remote-config/rcclient/types"
 	"github.com/DataDog/datadog-agent/pkg/aggregator"
 	"github.com/DataDog/datadog-agent/pkg/config/model"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/config/structure"
 	"github.com/DataDog/datadog-agent/pkg/config/utils"
 	"github.com/DataDog/datadog-agent/pkg/metrics"
@@ -124,6 +126,10 @@ type server struct {
 	// and pushing them to the aggregator
 	workers []*worker
 
+	// shardPacketsIn holds the packets read on each shard of the dogstatsd
+	// socket, processed by a dedicated worker
+	shardPacketsIn []chan packets.Packets
+
 	packetsIn               chan packets.Packets
 	captureChan             chan packets.Packets
 	serverlessFlushChan     chan bool
@@ -141,7 +147,7 @@ type server struct {
 
 	tCapture                replay.Component
 	pidMap                  pidmap.Component
-	mapper                  *mapper.MetricMapper
+	mapper                  atomic.Pointer[mapper.MetricMapper]
 	eolTerminationUDP       bool
 	eolTerminationUDS       bool
 	eolTerminationNamedPipe bool
@@ -166,6 +172,9 @@ type server struct {
 	// originTelemetry is true if we want to report telemetry per origin.
 	originTelemetry bool
 
+	// originQuotas limits the messages ingested per second from each origin, nil if disabled
+	originQuotas *originQuotas
+
 	enrichConfig
 	localFilterListConfig
 
@@ -328,6 +337,7 @@ func newServerCompat(cfg model.ReaderWriter, log log.Component, hostname hostnam
 		tlmProcessedOk:          dogstatsdTelemetryCount.WithValues("metrics", "ok", ""),
 		tlmProcessedError:       dogstatsdTelemetryCount.WithValues("metrics", "error", ""),
 		stringInternerTelemetry: newSiTelemetry(utils.IsTelemetryEnabled(cfg), telemetrycomp),
+		originQuotas:            newOriginQuotas(cfg, telemetrycomp),
 	}
 
 	buckets := getBuckets(cfg, log, "telemetry.dogstatsd.aggregator_channel_latency_buckets")
@@ -419,6 +429,21 @@ func (s *server) start(context.Context) error {
 		}
 	}
 
+	var shardPacketsIn []chan packets.Packets
+	if shards := s.config.GetInt("dogstatsd_socket_shards"); len(socketPath) > 0 && shards > 0 {
+		for i := 0; i < shards; i++ {
+			shardPath := listeners.ShardSocketPath(socketPath, i)
+			shardPackets := make(chan packets.Packets, s.config.GetInt("dogstatsd_queue_size"))
+			shardListener, err := listeners.NewUDSDatagramListenerOnPath(shardPath, shardPackets, sharedPacketPoolManager, sharedUDSOobPoolManager, s.config, s.tCapture, s.wmeta, s.pidMap, s.listernersTelemetry, s.packetsTelemetry, s.telemetry)
+			if err != nil {
+				s.log.Errorf("Can't init UDS listener on path %s: %s", shardPath, err.Error())
+				continue
+			}
+			tmpListeners = append(tmpListeners, shardListener)
+			shardPacketsIn = append(shardPacketsIn, shardPackets)
+		}
+	}
+
 	if len(socketStreamPath) > 0 {
 		s.log.Warnf("dogstatsd_stream_socket is not yet supported, run it at your own risk")
 		unixListener, err := listeners.NewUDSStreamListener(packetsChannel, sharedPacketPoolManager, sharedUDSOobPoolManager, s.config, s.tCapture, s.wmeta, s.pidMap, s.listernersTelemetry, s.packetsTelemetry, s.telemetry)
@@ -430,7 +455,7 @@ func (s *server) start(context.Context) error {
 	}
 
 	if s.config.GetString("dogstatsd_port") == listeners.RandomPortName || s.config.GetInt("dogstatsd_port") > 0 {
-		udpListener, err := listeners.NewUDPListener(packetsChannel, sharedPacketPoolManager, s.config, s.tCapture, s.listernersTelemetry, s.packetsTelemetry)
+		udpListener, err := listeners.NewUDPListener(packetsChannel, sharedPacketPoolManager, s.config, s.tCapture, s.wmeta, s.pidMap, s.listernersTelemetry, s.packetsTelemetry)
 		if err != nil {
 			s.log.Errorf("%s", err.Error())
 		} else {
@@ -455,6 +480,7 @@ func (s *server) start(context.Context) error {
 
 	s.packetsIn = packetsChannel
 	s.captureChan = packetsChannel
+	s.shardPacketsIn = shardPacketsIn
 	s.sharedPacketPool = sharedPacketPool
 	s.sharedPacketPoolManager = sharedPacketPoolManager
 	s.listeners = tmpListeners
@@ -488,18 +514,19 @@ func (s *server) start(context.Context) error {
 	// map some metric name
 	// ----------------------
 
-	cacheSize := s.config.GetInt("dogstatsd_mapper_cache_size")
-
-	mappings, err := getDogstatsdMappingProfiles(s.config)
+	mapperInstance, err := newDogstatsdMapper(s.config)
 	if err != nil {
 		s.log.Warn(err)
-	} else if len(mappings) != 0 {
-		mapperInstance, err := mapper.NewMetricMapper(mappings, cacheSize)
-		if err != nil {
-			s.log.Warnf("Could not create metric mapper: %v", err)
-		} else {
-			s.mapper = mapperInstance
-		}
+	} else {
+		s.mapper.Store(mapperInstance)
+	}
+
+	// the mapper profiles are reloaded with the configuration file, the
+	// workers pick up the new mapper on the next metric they parse
+	for _, key := range []string{"dogstatsd_mapper_profiles", "dogstatsd_mapper_cache_size"} {
+		pkgconfigsetup.OnReload(key, func(string, any, any) {
+			s.reloadMapper()
+		})
 	}
 
 	// start the workers processing the packets read on the socket
@@ -625,6 +652,17 @@ func (s *server) handleMessages() {
 		s.workers = append(s.workers, worker)
 	}
 
+	// each shard of the dogstatsd socket has its own worker, sharing nothing with the others
+	for i, shardPackets := range s.shardPacketsIn {
+		worker := newWorker(s, workersCount+i, s.wmeta, s.packetsTelemetry, s.stringInternerTelemetry)
+		worker.packetsIn = shardPackets
+		go worker.run()
+		s.workers = append(s.workers, worker)
+	}
+	if len(s.shardPacketsIn) > 0 {
+		s.log.Debug("DogStatsD will run", len(s.shardPacketsIn), "workers for the shards of the socket")
+	}
+
 	// init the metric names filterlist
 
 	s.localFilterListConfig = localFilterListConfig{
@@ -761,6 +799,9 @@ func (s *server) parsePackets(batcher dogstatsdBatcher, parser *parser, packets
 				s.Statistics.StatEvent(1)
 			}
 			messageType := findMessageType(message)
+			if !s.originQuotas.allow(packet.Origin, messageType) {
+				continue
+			}
 
 			switch messageType {
 			case serviceCheckType:
@@ -887,8 +928,8 @@ func (s *server) parseMetricMessage(metricSamples []metrics.MetricSample, parser
 		s.tlmMetricTypeTiming.Inc()
 	}
 
-	if s.mapper != nil {
-		mapResult := s.mapper.Map(sample.name)
+	if metricMapper := s.mapper.Load(); metricMapper != nil {
+		mapResult := metricMapper.Map(sample.name)
 		if mapResult != nil {
 			s.log.Tracef("Dogstatsd mapper: metric mapped from %q to %q with tags %v", sample.name, mapResult.Name, mapResult.Tags)
 			sample.name = mapResult.Name
@@ -963,6 +1004,36 @@ func getBuckets(cfg model.Reader, logger log.Component, option string) []float64
 	return buckets
 }
 
+// reloadMapper replaces the metric mapper with one built from the current
+// configuration. The current mapper is kept if the new profiles are invalid.
+func (s *server) reloadMapper() {
+	mapperInstance, err := newDogstatsdMapper(s.config)
+	if err != nil {
+		s.log.Errorf("Dogstatsd mapper profiles not reloaded, keeping the current ones: %v", err)
+		return
+	}
+	s.mapper.Store(mapperInstance)
+	s.log.Info("Dogstatsd mapper profiles reloaded")
+}
+
+// newDogstatsdMapper returns the metric mapper configured with
+// dogstatsd_mapper_profiles, or nil if no profile is configured.
+func newDogstatsdMapper(cfg model.Reader) (*mapper.MetricMapper, error) {
+	mappings, err := getDogstatsdMappingProfiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+
+	mapperInstance, err := mapper.NewMetricMapper(mappings, cfg.GetInt("dogstatsd_mapper_cache_size"))
+	if err != nil {
+		return nil, fmt.Errorf("Could not create metric mapper: %v", err)
+	}
+	return mapperInstance, nil
+}
+
 func getDogstatsdMappingProfiles(cfg model.Reader) ([]mapper.MappingProfileConfig, error) {
 	var mappings []mapper.MappingProfileConfig
 	if cfg.IsSet("dogstatsd_mapper_profiles") {
@@ -99,7 +99,7 @@ func TestNoMappingsConfig(t *testing.T) {
 
 	requireStart(t, s)
 
-	assert.Nil(t, s.mapper)
+	assert.Nil(t, s.mapper.Load())
 
 	parser := newParser(deps.Config, s.sharedFloat64List, 1, deps.WMeta, s.stringInternerTelemetry)
 	samples, err := s.parseMetricMessage(samples, parser, []byte("test.metric:666|g"), "", 0, "", false, nil)
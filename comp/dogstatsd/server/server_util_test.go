@@ -109,6 +109,23 @@ func fulfillDepsWithConfigYaml(t testing.TB, yaml string) serverDeps {
 	))
 }
 
+func fulfillDepsWithConfigYamlFile(t testing.TB, yamlFilePath string) serverDeps {
+	return fxutil.Test[serverDeps](t, fx.Options(
+		fx.Provide(func(t testing.TB) log.Component { return logmock.New(t) }),
+		fx.Provide(func(t testing.TB) configComponent.Component { return configComponent.NewMockFromYAMLFile(t, yamlFilePath) }),
+		telemetryimpl.MockModule(),
+		hostnameimpl.MockModule(),
+		serverdebugimpl.MockModule(),
+		replaymock.MockModule(),
+		metricscompression.MockModule(),
+		logscompression.MockModule(),
+		pidmapimpl.Module(),
+		demultiplexerimpl.FakeSamplerMockModule(),
+		workloadmetafxmock.MockModule(workloadmeta.NewParams()),
+		Module(Params{Serverless: false}),
+	))
+}
+
 // Returns a server that is not started along with associated dependencies
 // Be careful when using this functionality, as server start instantiates many internal components to non-nil values
 func fulfillDepsWithInactiveServer(t *testing.T, cfg map[string]interface{}) (depsWithoutServer, *server) {
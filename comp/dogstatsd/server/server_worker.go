@@ -36,6 +36,10 @@ type worker struct {
 
 	packetsTelemetry *packets.TelemetryStore
 
+	// packetsIn is the channel of the packets processed by the worker, either
+	// shared by all the workers or dedicated to a shard of the dogstatsd socket
+	packetsIn chan packets.Packets
+
 	FilterListUpdate chan utilstrings.Matcher
 	filterList       utilstrings.Matcher
 }
@@ -54,6 +58,7 @@ func newWorker(s *server, workerNum int, wmeta option.Option[workloadmeta.Compon
 		parser:           newParser(s.config, s.sharedFloat64List, workerNum, wmeta, stringInternerTelemetry),
 		samples:          make(metrics.MetricSampleBatch, 0, defaultSampleSize),
 		packetsTelemetry: packetsTelemetry,
+		packetsIn:        s.packetsIn,
 		FilterListUpdate: make(chan utilstrings.Matcher),
 	}
 }
@@ -68,7 +73,7 @@ func (w *worker) run() {
 			w.batcher.flush()
 		case filterList := <-w.FilterListUpdate:
 			w.filterList = filterList
-		case ps := <-w.server.packetsIn:
+		case ps := <-w.packetsIn:
 			w.packetsTelemetry.TelemetryUntrackPackets(ps)
 			w.samples = w.samples[0:0]
 			// we return the samples in case the slice was extended
@@ -7,6 +7,9 @@
 package server
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -15,6 +18,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/comp/core/telemetry"
 	"github.com/DataDog/datadog-agent/comp/dogstatsd/listeners"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/metrics"
 	"github.com/DataDog/datadog-agent/pkg/metrics/event"
 )
@@ -248,7 +252,7 @@ func TestParseMetricMessageTelemetry(t *testing.T) {
 
 	deps, s := fulfillDepsWithInactiveServer(t, cfg)
 
-	assert.Nil(t, s.mapper)
+	assert.Nil(t, s.mapper.Load())
 
 	var samples []metrics.MetricSample
 
@@ -374,6 +378,62 @@ dogstatsd_mapper_profiles:
 	}
 }
 
+func TestMappingReload(t *testing.T) {
+	profiles := `
+dogstatsd_port: __random__
+dogstatsd_mapper_profiles:
+  - name: test
+    prefix: 'test.'
+    mappings:
+      - match: "test.job.duration.*"
+        name: "%s"
+        tags:
+          %s: "$1"
+`
+	path := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(profiles, "test.job.duration", "job_name")), 0600))
+
+	deps := fulfillDepsWithConfigYamlFile(t, path)
+	s := deps.Server.(*server)
+	requireStart(t, s)
+
+	parse := func(packet string) metrics.MetricSample {
+		parser := newParser(deps.Config, s.sharedFloat64List, 1, deps.WMeta, s.stringInternerTelemetry)
+		var b batcherMock
+		s.parsePackets(&b, parser, genTestPackets([]byte(packet)), metrics.MetricSampleBatch{}, nil)
+		require.Len(t, b.samples, 1)
+		return b.samples[0]
+	}
+	reload := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		_, err := pkgconfigsetup.ReloadConfigFile(deps.Config)
+		require.NoError(t, err)
+	}
+
+	sample := parse("test.job.duration.my_job:666|g")
+	assert.Equal(t, "test.job.duration", sample.Name)
+	assert.Equal(t, []string{"job_name:my_job"}, sample.Tags)
+
+	reload(fmt.Sprintf(profiles, "test.job.runtime", "job"))
+
+	sample = parse("test.job.duration.my_job:666|g")
+	assert.Equal(t, "test.job.runtime", sample.Name)
+	assert.Equal(t, []string{"job:my_job"}, sample.Tags)
+
+	// invalid profiles are rejected and the current mapper is kept
+	reload("dogstatsd_port: __random__\ndogstatsd_mapper_profiles:\n  - name: test\n")
+
+	sample = parse("test.job.duration.my_job:666|g")
+	assert.Equal(t, "test.job.runtime", sample.Name)
+
+	// removing the profiles disables the mapper
+	reload("dogstatsd_port: __random__\n")
+
+	assert.Nil(t, s.mapper.Load())
+	sample = parse("test.job.duration.my_job:666|g")
+	assert.Equal(t, "test.job.duration.my_job", sample.Name)
+}
+
 func TestParseEventMessageTelemetry(t *testing.T) {
 	cfg := make(map[string]interface{})
 
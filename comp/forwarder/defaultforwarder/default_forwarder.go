@@ -48,6 +48,10 @@ const (
 	arbitraryTagHTTPHeaderKey = "Allow-Arbitrary-Tag-Value"
 )
 
+// defaultStorageFreeDiskRatio is the ratio of the free disk space the retry
+// queue storage can grow up to when it auto-scales
+const defaultStorageFreeDiskRatio = 0.5
+
 // The amount of time the forwarder will wait to receive process-like response payloads before giving up
 // This is a var so that it can be changed for testing
 var defaultResponseTimeout = 30 * time.Second
@@ -172,6 +176,22 @@ func NewOptions(config config.Component, log log.Component, keysPerDomain map[st
 			return nil, err
 		}
 		resolvers[utils.GetInfraEndpoint(config)] = resolver
+	} else if failoverDomains := config.GetStringSlice("forwarder_failover_domains"); ok && len(failoverDomains) > 0 {
+		log.Infof("Configuring forwarder to send to the healthiest of %s and %s", r.GetBaseDomain(), strings.Join(failoverDomains, ", "))
+		apiKeys, _ := r.GetAPIKeysInfo()
+		resolver, err := pkgresolver.NewFailoverDomainResolver(
+			r.GetBaseDomain(),
+			apiKeys,
+			failoverDomains,
+			pkgresolver.FailoverSettings{
+				SwitchThreshold:   config.GetFloat64("forwarder_failover_switch_threshold"),
+				MinActiveDuration: time.Duration(config.GetInt("forwarder_failover_min_active_duration")) * time.Second,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		resolvers[utils.GetInfraEndpoint(config)] = resolver
 	}
 
 	return NewOptionsWithResolvers(config, log, resolvers), nil
@@ -269,6 +289,7 @@ type DefaultForwarder struct {
 	domainResolvers  map[string]pkgresolver.DomainResolver
 	localForwarder   *domainForwarder // domain forward used for communication with the local cluster-agent
 	healthChecker    *forwarderHealth
+	failoverProber   *failoverProber
 	internalState    *atomic.Uint32
 	m                sync.Mutex // To control Start/Stop races
 
@@ -300,10 +321,11 @@ func NewDefaultForwarder(config config.Component, log log.Component, options *Op
 	}
 	var optionalRemovalPolicy *retry.FileRemovalPolicy
 	storageMaxSize := config.GetInt64("forwarder_storage_max_size_in_bytes")
+	storageAutoScale := config.GetBool("forwarder_storage_auto_scale")
 	var diskUsageLimit *retry.DiskUsageLimit
 
 	// Disk Persistence is a core-only feature for now.
-	if storageMaxSize == 0 {
+	if storageMaxSize == 0 && !storageAutoScale {
 		log.Infof("Retry queue storage on disk is disabled")
 	} else if agentName != "" {
 		storagePath := config.GetString("forwarder_storage_path")
@@ -326,7 +348,16 @@ func NewDefaultForwarder(config config.Component, log log.Component, options *Op
 		}
 
 		diskRatio := config.GetFloat64("forwarder_storage_max_disk_ratio")
-		diskUsageLimit = retry.NewDiskUsageLimit(storagePath, filesystem.NewDisk(), storageMaxSize, diskRatio)
+		if storageAutoScale {
+			freeDiskRatio := config.GetFloat64("forwarder_storage_auto_scale_free_disk_ratio")
+			if freeDiskRatio <= 0 || freeDiskRatio > 1 {
+				log.Warnf("'forwarder_storage_auto_scale_free_disk_ratio' set to invalid value (%v), defaulting to %v", freeDiskRatio, defaultStorageFreeDiskRatio)
+				freeDiskRatio = defaultStorageFreeDiskRatio
+			}
+			diskUsageLimit = retry.NewAutoScaleDiskUsageLimit(storagePath, filesystem.NewDisk(), storageMaxSize, diskRatio, freeDiskRatio)
+		} else {
+			diskUsageLimit = retry.NewDiskUsageLimit(storagePath, filesystem.NewDisk(), storageMaxSize, diskRatio)
+		}
 
 	} else {
 		log.Infof("Retry queue storage on disk is disabled because the feature is unavailable for this process.")
@@ -400,6 +431,16 @@ func NewDefaultForwarder(config config.Component, log log.Component, options *Op
 		}
 	}
 
+	var failoverResolvers []*pkgresolver.FailoverDomainResolver
+	for _, resolver := range f.domainResolvers {
+		if r, ok := resolver.(*pkgresolver.FailoverDomainResolver); ok {
+			failoverResolvers = append(failoverResolvers, r)
+		}
+	}
+	if len(failoverResolvers) > 0 {
+		f.failoverProber = newFailoverProber(config, log, failoverResolvers)
+	}
+
 	timeInterval := config.GetInt("forwarder_retry_queue_capacity_time_interval_sec")
 	if f.agentName != "" {
 		f.queueDurationCapacity = retry.NewQueueDurationCapacity(
@@ -454,6 +495,9 @@ func (f *DefaultForwarder) Start() error {
 		len(endpointLogs), f.NumberOfWorkers, strings.Join(endpointLogs, " ; "))
 
 	f.healthChecker.Start()
+	if f.failoverProber != nil {
+		f.failoverProber.Start()
+	}
 	f.internalState.Store(Started)
 	return nil
 }
@@ -502,6 +546,9 @@ func (f *DefaultForwarder) Stop() {
 	}
 
 	f.healthChecker.Stop()
+	if f.failoverProber != nil {
+		f.failoverProber.Stop()
+	}
 
 	f.healthChecker = nil
 	f.domainForwarders = map[string]*domainForwarder{}
@@ -672,7 +719,9 @@ func (f *DefaultForwarder) SubmitSeries(payloads transaction.BytesPayloads, extr
 // SubmitV1CheckRuns will send service checks to v1 endpoint (this will be removed once
 // the backend handles v2 endpoints).
 func (f *DefaultForwarder) SubmitV1CheckRuns(payload transaction.BytesPayloads, extra http.Header) error {
-	transactions := f.createHTTPTransactions(endpoints.V1CheckRunsEndpoint, payload, transaction.CheckRuns, extra)
+	// Service checks are retried before the metrics so that the state of the
+	// checks is not delayed by a backlog of metrics after an outage.
+	transactions := f.createAdvancedHTTPTransactions(endpoints.V1CheckRunsEndpoint, payload, extra, transaction.TransactionPriorityHigh, transaction.CheckRuns, true)
 	return f.sendHTTPTransactions(transactions)
 }
 
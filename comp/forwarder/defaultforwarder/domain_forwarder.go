@@ -51,6 +51,9 @@ type domainForwarder struct {
 	transactionPrioritySorter retry.TransactionPrioritySorter
 	blockedList               *blockedEndpoints
 	pointCountTelemetry       *retry.PointCountTelemetry
+	// retryMaxAge is the age after which the transactions of a priority are
+	// dropped instead of being retried, 0 means they are retried until sent
+	retryMaxAge map[transaction.Priority]time.Duration
 }
 
 func newDomainForwarder(
@@ -79,10 +82,14 @@ func newDomainForwarder(
 		transactionPrioritySorter: transactionPrioritySorter,
 		pointCountTelemetry:       pointCountTelemetry,
 		Client:                    NewSharedConnection(log, isLocal, numberOfWorkers, config),
+		retryMaxAge: map[transaction.Priority]time.Duration{
+			transaction.TransactionPriorityNormal: time.Duration(config.GetInt("forwarder_retry_queue_normal_priority_max_age")) * time.Second,
+			transaction.TransactionPriorityHigh:   time.Duration(config.GetInt("forwarder_retry_queue_high_priority_max_age")) * time.Second,
+		},
 	}
 }
 
-func (f *domainForwarder) retryTransactions(_ time.Time) {
+func (f *domainForwarder) retryTransactions(now time.Time) {
 	// In case it takes more that flushInterval to sort and retry
 	// transactions we skip a retry.
 	if !f.isRetrying.CompareAndSwap(false, true) {
@@ -93,6 +100,7 @@ func (f *domainForwarder) retryTransactions(_ time.Time) {
 
 	droppedRetryQueueFull := 0
 	droppedWorkerBusy := 0
+	droppedExpired := 0
 
 	var transactions []transaction.Transaction
 	var err error
@@ -106,9 +114,21 @@ func (f *domainForwarder) retryTransactions(_ time.Time) {
 
 	for _, t := range transactions {
 		transactionEndpointName := t.GetEndpointName()
+		if maxAge := f.retryMaxAge[t.GetPriority()]; maxAge > 0 && now.Sub(t.GetCreatedAt()) > maxAge {
+			droppedExpired++
+			f.pointCountTelemetry.OnPointDropped(t.GetPointCount())
+			tlmTxRetryExpired.Inc(f.domain, transactionEndpointName, t.GetPriority().String())
+			continue
+		}
 		if !f.blockedList.isBlock(t.GetTarget()) {
+			// the high priority transactions, such as the host metadata, are
+			// retried ahead of the backlog of the other transactions
+			retryChan := f.lowPrio
+			if t.GetPriority() == transaction.TransactionPriorityHigh {
+				retryChan = f.highPrio
+			}
 			select {
-			case f.lowPrio <- t:
+			case retryChan <- t:
 				transactionsRetriedByEndpoint.Add(transactionEndpointName, 1)
 				transactionsRetried.Add(1)
 				tlmTxRetried.Inc(f.domain, transactionEndpointName)
@@ -128,7 +148,11 @@ func (f *domainForwarder) retryTransactions(_ time.Time) {
 	transactionCount := f.retryQueue.GetTransactionCount()
 	transactionsRetryQueueSize.Set(int64(transactionCount))
 	tlmTxRetryQueueSize.Set(float64(transactionCount), f.domain)
+	transaction.SetRetryQueueDepth(f.domain, transactionCount)
 
+	if droppedExpired > 0 {
+		f.log.Warnf("Dropped %d transactions in this retry attempt because they are older than the maximum age of their priority", droppedExpired)
+	}
 	if droppedRetryQueueFull+droppedWorkerBusy > 0 {
 		f.log.Errorf("Dropped %d transactions in this retry attempt:%d for exceeding the retry queue payloads size limit of %d, %d because the workers are too busy",
 			droppedRetryQueueFull+droppedWorkerBusy, droppedRetryQueueFull, f.retryQueue.GetMaxMemSizeInBytes(), droppedWorkerBusy)
@@ -157,6 +181,7 @@ func (f *domainForwarder) requeueTransaction(t transaction.Transaction) {
 	transactionsRequeued.Add(1)
 	transactionsRetryQueueSize.Set(int64(retryQueueSize))
 	tlmTxRetryQueueSize.Set(float64(retryQueueSize), f.domain)
+	transaction.SetRetryQueueDepth(f.domain, retryQueueSize)
 }
 
 func (f *domainForwarder) handleFailedTransactions() {
@@ -271,16 +296,25 @@ func NewHTTPClient(config config.Component, numberOfWorkers int, log log.Compone
 func NewHTTPTransport(config config.Component, numberOfWorkers int, log log.Component) *http.Transport {
 	var transport *http.Transport
 
+	// the connection pool settings must be applied before configuring HTTP/2
+	options := []func(*http.Transport){httputils.MaxConnsPerHost(numberOfWorkers)}
+	if maxIdleConnsPerHost := config.GetInt("forwarder_max_idle_conns_per_host"); maxIdleConnsPerHost > 0 {
+		options = append(options, httputils.MaxIdleConnsPerHost(maxIdleConnsPerHost))
+	}
+	if idleConnTimeout := config.GetInt("forwarder_idle_conn_timeout"); idleConnTimeout > 0 {
+		options = append(options, httputils.IdleConnTimeout(time.Duration(idleConnTimeout)*time.Second))
+	}
+
 	transportConfig := config.Get("forwarder_http_protocol")
 
 	switch transportConfig {
 	case "http1":
-		transport = httputils.CreateHTTPTransport(config, httputils.MaxConnsPerHost(numberOfWorkers))
+		transport = httputils.CreateHTTPTransport(config, options...)
 	case "auto":
-		transport = httputils.CreateHTTPTransport(config, httputils.WithHTTP2(), httputils.MaxConnsPerHost(numberOfWorkers))
+		transport = httputils.CreateHTTPTransport(config, append(options, httputils.WithHTTP2())...)
 	default:
 		log.Warnf("Invalid http_protocol '%v', falling back to 'auto'", transportConfig)
-		transport = httputils.CreateHTTPTransport(config, httputils.WithHTTP2(), httputils.MaxConnsPerHost(numberOfWorkers))
+		transport = httputils.CreateHTTPTransport(config, append(options, httputils.WithHTTP2())...)
 	}
 
 	return transport
@@ -22,6 +22,21 @@ import (
 	mock "github.com/DataDog/datadog-agent/pkg/config/mock"
 )
 
+func TestNewHTTPTransportConnectionPool(t *testing.T) {
+	mockConfig := mock.New(t)
+	log := logmock.New(t)
+
+	transport := NewHTTPTransport(mockConfig, 1, log)
+	assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 45*time.Second, transport.IdleConnTimeout)
+
+	mockConfig.SetWithoutSource("forwarder_max_idle_conns_per_host", 20)
+	mockConfig.SetWithoutSource("forwarder_idle_conn_timeout", 90)
+	transport = NewHTTPTransport(mockConfig, 1, log)
+	assert.Equal(t, 20, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+}
+
 func TestNewDomainForwarder(t *testing.T) {
 	mockConfig := mock.New(t)
 	log := logmock.New(t)
@@ -255,6 +270,43 @@ func TestRetryTransactions(t *testing.T) {
 	assert.Equal(t, int64(1), transaction.TransactionsDropped.Value())
 }
 
+func TestRetryTransactionsPriority(t *testing.T) {
+	mockConfig := mock.New(t)
+	mockConfig.SetWithoutSource("forwarder_retry_queue_normal_priority_max_age", 60)
+	log := logmock.New(t)
+	forwarder := newDomainForwarderForTest(mockConfig, log, 0, false)
+	forwarder.init()
+
+	// empty payloads so that the retry queue is not full
+	payload := transaction.NewBytesPayloadWithoutMetaData([]byte{})
+	newTransaction := func(route string, priority transaction.Priority, createdAt time.Time) *transaction.HTTPTransaction {
+		tr := transaction.NewHTTPTransaction()
+		tr.Domain = "domain/"
+		tr.Endpoint.Route = route
+		tr.Payload = payload
+		tr.Priority = priority
+		tr.CreatedAt = createdAt
+		return tr
+	}
+	now := time.Now()
+	normal := newTransaction("normal", transaction.TransactionPriorityNormal, now)
+	expired := newTransaction("expired", transaction.TransactionPriorityNormal, now.Add(-2*time.Minute))
+	high := newTransaction("high", transaction.TransactionPriorityHigh, now.Add(-2*time.Minute))
+
+	forwarder.requeueTransaction(normal)
+	forwarder.requeueTransaction(expired)
+	forwarder.requeueTransaction(high)
+	forwarder.retryTransactions(now)
+
+	// the high priority transaction jumps ahead of the retried transactions
+	require.Len(t, forwarder.highPrio, 1)
+	assert.Equal(t, high, <-forwarder.highPrio)
+	// the expired normal priority transaction is dropped
+	require.Len(t, forwarder.lowPrio, 1)
+	assert.Equal(t, normal, <-forwarder.lowPrio)
+	requireLenForwarderRetryQueue(t, forwarder, 0)
+}
+
 func TestForwarderRetry(t *testing.T) {
 	mockConfig := mock.New(t)
 	log := logmock.New(t)
@@ -0,0 +1,33 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package defaultforwarder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	api "github.com/DataDog/datadog-agent/comp/api/api/def"
+	"github.com/DataDog/datadog-agent/comp/forwarder/defaultforwarder/transaction"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+)
+
+func newEndpointsStatsEndpoint() api.AgentEndpointProvider {
+	return api.NewAgentEndpointProvider(getEndpointsStats, "/forwarder/endpoints-stats", "GET")
+}
+
+// getEndpointsStats is the handler of the agent API returning the health of
+// the endpoints the forwarder sends transactions to.
+func getEndpointsStats(w http.ResponseWriter, _ *http.Request) {
+	resp, err := json.Marshal(transaction.GetEndpointsStats())
+	if err != nil {
+		httputils.SetJSONError(w, fmt.Errorf("unable to serialize the forwarder endpoints stats: %v", err), 500)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
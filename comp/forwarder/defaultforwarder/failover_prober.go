@@ -0,0 +1,132 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package defaultforwarder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	"github.com/DataDog/datadog-agent/comp/forwarder/defaultforwarder/endpoints"
+	pkgresolver "github.com/DataDog/datadog-agent/comp/forwarder/defaultforwarder/resolver"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/version"
+)
+
+// failoverProbeTimeout is the timeout of a probe of a domain, a probe timing
+// out counts as an error
+const failoverProbeTimeout = 10 * time.Second
+
+const defaultFailoverProbeInterval = 30 * time.Second
+
+// failoverProber periodically measures the latency and the errors of the
+// domains of the failover resolvers, and lets them pick the active domain.
+type failoverProber struct {
+	log       log.Component
+	client    *http.Client
+	resolvers []*pkgresolver.FailoverDomainResolver
+	interval  time.Duration
+
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func newFailoverProber(config config.Component, log log.Component, resolvers []*pkgresolver.FailoverDomainResolver) *failoverProber {
+	interval := time.Duration(config.GetInt("forwarder_failover_probe_interval")) * time.Second
+	if interval <= 0 {
+		log.Warnf("'forwarder_failover_probe_interval' set to invalid value (%v), defaulting to %v", interval, defaultFailoverProbeInterval)
+		interval = defaultFailoverProbeInterval
+	}
+	return &failoverProber{
+		log: log,
+		client: &http.Client{
+			Transport: httputils.CreateHTTPTransport(config),
+			Timeout:   failoverProbeTimeout,
+		},
+		resolvers: resolvers,
+		interval:  interval,
+	}
+}
+
+func (p *failoverProber) Start() {
+	p.stop = make(chan struct{})
+	p.stopped = make(chan struct{})
+	go func() {
+		defer close(p.stopped)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-p.stop
+			cancel()
+		}()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			p.probeAll(ctx)
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p *failoverProber) Stop() {
+	close(p.stop)
+	<-p.stopped
+}
+
+func (p *failoverProber) probeAll(ctx context.Context) {
+	for _, r := range p.resolvers {
+		apiKeys := r.GetAPIKeys()
+		if len(apiKeys) == 0 {
+			continue
+		}
+		for _, domain := range r.GetDomains() {
+			start := time.Now()
+			err := p.probe(ctx, domain, apiKeys[0])
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				p.log.Debugf("Failover probe of %s failed: %v", domain, err)
+			}
+			r.ReportProbe(domain, time.Since(start), err)
+		}
+		if active, changed := r.Evaluate(); changed {
+			p.log.Warnf("Switching the transactions of %s to %s, which is the healthiest domain", r.GetBaseDomain(), active)
+		}
+	}
+}
+
+// probe sends a request to the API key validation endpoint of domain. Any
+// response other than a server error means the domain is reachable.
+func (p *failoverProber) probe(ctx context.Context, domain string, apiKey string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", domain+endpoints.V1ValidateEndpoint.Route, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(apiHTTPHeaderKey, apiKey)
+	req.Header.Set(useragentHTTPHeaderKey, fmt.Sprintf("datadog-agent/%s", version.AgentVersion))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
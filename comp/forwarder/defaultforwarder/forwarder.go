@@ -12,6 +12,7 @@ import (
 
 	"go.uber.org/fx"
 
+	api "github.com/DataDog/datadog-agent/comp/api/api/def"
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
 	"github.com/DataDog/datadog-agent/comp/core/status"
@@ -33,12 +34,14 @@ type provides struct {
 
 	Comp           Component
 	StatusProvider status.InformationProvider
+	Endpoint       api.AgentEndpointProvider
 }
 
 func newForwarder(dep dependencies) (provides, error) {
 	if dep.Params.useNoopForwarder {
 		return provides{
-			Comp: NoopForwarder{},
+			Comp:     NoopForwarder{},
+			Endpoint: newEndpointsStatsEndpoint(),
 		}, nil
 	}
 
@@ -108,6 +111,7 @@ func NewForwarder(config config.Component, log log.Component, lc fx.Lifecycle, i
 	return provides{
 		Comp:           forwarder,
 		StatusProvider: status.NewInformationProvider(statusProvider{config: config}),
+		Endpoint:       newEndpointsStatsEndpoint(),
 	}
 }
 
@@ -18,6 +18,9 @@ type DiskUsageLimit struct {
 	maxSizeInBytes int64
 	disk           diskUsageRetriever
 	maxDiskRatio   float64
+	// freeDiskRatio is the ratio of the free disk space the storage can grow
+	// up to when it auto-scales, 0 when its capacity is static.
+	freeDiskRatio float64
 }
 
 type diskUsageRetriever interface {
@@ -38,6 +41,27 @@ func NewDiskUsageLimit(
 	}
 }
 
+// NewAutoScaleDiskUsageLimit creates a new instance of DiskUsageLimit growing up to freeDiskRatio of the free disk
+// space, including the space already used to store transactions, and shrinking when the free disk space decreases.
+// maxSizeInBytes is an upper bound of the storage size, 0 means unbounded.
+func NewAutoScaleDiskUsageLimit(
+	diskPath string,
+	disk diskUsageRetriever,
+	maxSizeInBytes int64,
+	maxDiskRatio float64,
+	freeDiskRatio float64) *DiskUsageLimit {
+	if maxSizeInBytes <= 0 {
+		maxSizeInBytes = math.MaxInt64
+	}
+	return &DiskUsageLimit{
+		diskPath:       diskPath,
+		maxSizeInBytes: maxSizeInBytes,
+		disk:           disk,
+		maxDiskRatio:   maxDiskRatio,
+		freeDiskRatio:  freeDiskRatio,
+	}
+}
+
 func (s *DiskUsageLimit) computeAvailableSpace(currentSize int64) (int64, error) {
 	usage, err := s.disk.GetUsage(s.diskPath)
 	if err != nil {
@@ -45,8 +69,14 @@ func (s *DiskUsageLimit) computeAvailableSpace(currentSize int64) (int64, error)
 	}
 	diskReserved := float64(usage.Total) * (1 - s.maxDiskRatio)
 	availableDiskUsage := int64(usage.Available) - int64(math.Ceil(diskReserved))
+	maxStorage := currentSize + availableDiskUsage
+
+	if s.freeDiskRatio > 0 {
+		freeDiskUsage := int64(float64(int64(usage.Available)+currentSize) * s.freeDiskRatio)
+		maxStorage = min(maxStorage, freeDiskUsage)
+	}
 
-	return min(s.maxSizeInBytes, currentSize+availableDiskUsage), nil
+	return min(s.maxSizeInBytes, maxStorage), nil
 }
 
 func (s *DiskUsageLimit) getMaxSizeInBytes() int64 {
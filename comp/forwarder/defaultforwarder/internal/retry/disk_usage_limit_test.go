@@ -6,6 +6,7 @@
 package retry
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,3 +40,38 @@ func TestComputeAvailableSpace(t *testing.T) {
 	r.NoError(err)
 	r.Equal(30-int64(100*(1-0.9))+5, max)
 }
+
+func TestComputeAvailableSpaceAutoScale(t *testing.T) {
+	r := require.New(t)
+	disk := &diskUsageRetrieverMock{
+		diskUsage: &filesystem.DiskUsage{
+			Available: 60,
+			Total:     100,
+		}}
+	diskUsageLimit := NewAutoScaleDiskUsageLimit("", disk, 0, 0.9, 0.5)
+	r.Equal(int64(math.MaxInt64), diskUsageLimit.getMaxSizeInBytes())
+
+	// the storage grows up to half of the free disk space, including the space it uses
+	max, err := diskUsageLimit.computeAvailableSpace(10)
+	r.NoError(err)
+	r.Equal(int64((60+10)*0.5), max)
+
+	// and shrinks when the free disk space decreases
+	disk.diskUsage.Available = 20
+	max, err = diskUsageLimit.computeAvailableSpace(10)
+	r.NoError(err)
+	r.Equal(int64((20+10)*0.5), max)
+
+	// the maximum disk ratio still applies
+	disk.diskUsage.Available = 8
+	max, err = diskUsageLimit.computeAvailableSpace(10)
+	r.NoError(err)
+	r.Equal(10+8-int64(100*(1-0.9)), max)
+
+	// as does the maximum size
+	disk.diskUsage.Available = 60
+	diskUsageLimit = NewAutoScaleDiskUsageLimit("", disk, 20, 0.9, 0.5)
+	max, err = diskUsageLimit.computeAvailableSpace(10)
+	r.NoError(err)
+	r.Equal(int64(20), max)
+}
@@ -162,6 +162,7 @@ func (s *onDiskRetryQueue) makeRoomFor(bufferSize int64) error {
 	if err != nil {
 		return err
 	}
+	s.telemetry.setMaxSizeInBytes(maxStorageInBytes)
 	for len(s.filenames) > 0 && s.currentSizeInBytes+bufferSize > maxStorageInBytes {
 		index := 0
 		filename := s.filenames[index]
@@ -176,6 +177,7 @@ func (s *onDiskRetryQueue) makeRoomFor(bufferSize int64) error {
 				pointDroppedCount += tr.GetPointCount()
 			}
 			s.onPointDropped(pointDroppedCount)
+			s.telemetry.addTransactionsDroppedCount(len(transactions))
 		} else {
 			s.log.Errorf("Cannot deserialize the content of file %v: %v", filename, errDeserialize)
 		}
@@ -74,6 +74,8 @@ var (
 	filesCountTelemetry                     *gaugeExpvar
 	startupReloadedRetryFilesCountTelemetry *gaugeExpvar
 	filesRemovedCountTelemetry              *counterExpvar
+	maxSizeInBytesTelemetry                 *gaugeExpvar
+	fileStorageTransactionsDroppedTelemetry *counterExpvar
 	fileStoragePointDroppedCountTelemetry   *counterExpvar
 	deserializeErrorsCountTelemetry         *counterExpvar
 	deserializeTransactionsCountTelemetry   *counterExpvar
@@ -182,6 +184,18 @@ func init() {
 		domainTag,
 		"The number of files removed because the disk limit was reached",
 		&fileStorageExpvar)
+	maxSizeInBytesTelemetry = newGaugeExpvar(
+		"file_storage",
+		"max_size_in_bytes",
+		domainTag,
+		"The maximum number of bytes which can be used to store transactions on the disk",
+		&fileStorageExpvar)
+	fileStorageTransactionsDroppedTelemetry = newCounterExpvar(
+		"file_storage",
+		"transactions_dropped_count",
+		domainTag,
+		"The number of transactions dropped because the disk limit was reached",
+		&fileStorageExpvar)
 
 	fileStoragePointDroppedCountTelemetry = newCounterExpvar(
 		"file_storage",
@@ -292,6 +306,14 @@ func (t onDiskRetryQueueTelemetry) addFilesRemovedCount() {
 	filesRemovedCountTelemetry.add(1, t.domainName)
 }
 
+func (t onDiskRetryQueueTelemetry) setMaxSizeInBytes(count int64) {
+	maxSizeInBytesTelemetry.set(float64(count), t.domainName)
+}
+
+func (t onDiskRetryQueueTelemetry) addTransactionsDroppedCount(count int) {
+	fileStorageTransactionsDroppedTelemetry.add(float64(count), t.domainName)
+}
+
 func (t onDiskRetryQueueTelemetry) addPointDroppedCount(count int) {
 	fileStoragePointDroppedCountTelemetry.add(float64(count), t.domainName)
 }
@@ -0,0 +1,220 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package resolver
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/forwarder/defaultforwarder/transaction"
+	"github.com/DataDog/datadog-agent/pkg/config/utils"
+	"github.com/DataDog/datadog-agent/pkg/telemetry"
+)
+
+const (
+	// healthSmoothingFactor is the weight of the last probe in the moving
+	// averages of the latency and of the error rate of a domain
+	healthSmoothingFactor = 0.3
+	// errorPenaltyMs is the latency, in milliseconds, a domain failing all its
+	// probes is considered to have on top of its own latency
+	errorPenaltyMs = 10000
+)
+
+var (
+	tlmFailoverActiveDomain = telemetry.NewGauge("forwarder", "failover_active_domain",
+		[]string{"base_domain", "domain"}, "1 for the domain the transactions of a failover resolver are sent to, 0 for the others")
+	tlmFailoverSwitches = telemetry.NewCounter("forwarder", "failover_switches",
+		[]string{"base_domain", "from", "to"}, "Count of the changes of the domain the transactions of a failover resolver are sent to")
+	tlmFailoverDomainLatency = telemetry.NewGauge("forwarder", "failover_domain_latency_ms",
+		[]string{"base_domain", "domain"}, "Moving average of the latency of the probes of the domains of a failover resolver")
+)
+
+// FailoverSettings are the settings of a FailoverDomainResolver
+type FailoverSettings struct {
+	// SwitchThreshold is the relative improvement of the score of a domain
+	// over the active one required to switch to it
+	SwitchThreshold float64
+	// MinActiveDuration is the minimum duration a domain stays active before
+	// switching to another one
+	MinActiveDuration time.Duration
+}
+
+type domainHealth struct {
+	probed bool
+	// latencyMs and errorRate are moving averages of the probes
+	latencyMs float64
+	errorRate float64
+}
+
+// score returns the score of the domain, the lower the healthier.
+func (h *domainHealth) score() float64 {
+	if !h.probed {
+		return math.Inf(1)
+	}
+	return h.latencyMs + h.errorRate*errorPenaltyMs
+}
+
+// FailoverDomainResolver sends the transactions to the healthiest of several
+// domains sharing the same API keys, based on the latency and the error rate
+// of probes reported with ReportProbe. The active domain only changes when
+// another domain is healthier by SwitchThreshold and the active domain was
+// used for at least MinActiveDuration, to avoid flapping between domains.
+type FailoverDomainResolver struct {
+	*SingleDomainResolver
+
+	settings        FailoverSettings
+	failoverDomains []string
+
+	healthMu    sync.Mutex
+	active      string
+	activeSince time.Time
+	health      map[string]*domainHealth
+	now         func() time.Time
+}
+
+// NewFailoverDomainResolver creates a FailoverDomainResolver sending to baseDomain until one of failoverDomains is
+// healthier.
+func NewFailoverDomainResolver(baseDomain string, apiKeys []utils.APIKeys, failoverDomains []string, settings FailoverSettings) (*FailoverDomainResolver, error) {
+	single, err := NewSingleDomainResolver(baseDomain, apiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make([]string, 0, len(failoverDomains))
+	for _, domain := range failoverDomains {
+		if versioned, err := utils.AddAgentVersionToDomain(domain, "app"); err == nil {
+			domain = versioned
+		}
+		if domain != baseDomain && !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+
+	return &FailoverDomainResolver{
+		SingleDomainResolver: single,
+		settings:             settings,
+		failoverDomains:      domains,
+		health:               make(map[string]*domainHealth),
+		now:                  time.Now,
+	}, nil
+}
+
+// Resolve returns the active domain
+func (r *FailoverDomainResolver) Resolve(transaction.Endpoint) (string, DestinationType) {
+	return r.GetActiveDomain(), Datadog
+}
+
+// GetActiveDomain returns the domain the transactions are currently sent to
+func (r *FailoverDomainResolver) GetActiveDomain() string {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+	if r.active == "" {
+		return r.GetBaseDomain()
+	}
+	return r.active
+}
+
+// SetBaseDomain sets the base domain, which is active until a failover domain is healthier
+func (r *FailoverDomainResolver) SetBaseDomain(domain string) {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+	if r.active == r.GetBaseDomain() {
+		r.active = ""
+	}
+	r.SingleDomainResolver.SetBaseDomain(domain)
+}
+
+// GetAlternateDomains returns the failover domains
+func (r *FailoverDomainResolver) GetAlternateDomains() []string {
+	return slices.Clone(r.failoverDomains)
+}
+
+// GetDomains returns the base domain followed by the failover domains
+func (r *FailoverDomainResolver) GetDomains() []string {
+	return append([]string{r.GetBaseDomain()}, r.failoverDomains...)
+}
+
+// ReportProbe records the result of a probe of domain taking latency
+func (r *FailoverDomainResolver) ReportProbe(domain string, latency time.Duration, err error) {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+
+	h, ok := r.health[domain]
+	if !ok {
+		h = &domainHealth{}
+		r.health[domain] = h
+	}
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+	latencyMs := float64(latency.Microseconds()) / 1000
+	if !h.probed {
+		h.probed = true
+		h.latencyMs = latencyMs
+		h.errorRate = failed
+	} else {
+		h.latencyMs += healthSmoothingFactor * (latencyMs - h.latencyMs)
+		h.errorRate += healthSmoothingFactor * (failed - h.errorRate)
+	}
+	tlmFailoverDomainLatency.Set(h.latencyMs, r.GetBaseDomain(), domain)
+}
+
+// Evaluate switches the active domain to the healthiest one, if it is healthier enough than the active one, and
+// returns the active domain and whether it changed.
+func (r *FailoverDomainResolver) Evaluate() (string, bool) {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+
+	baseDomain := r.GetBaseDomain()
+	active := r.active
+	if active == "" {
+		active = baseDomain
+	}
+	now := r.now()
+	if r.activeSince.IsZero() {
+		r.activeSince = now
+	}
+
+	best := active
+	bestScore := r.scoreLocked(active)
+	for _, domain := range append([]string{baseDomain}, r.failoverDomains...) {
+		if score := r.scoreLocked(domain); score < bestScore {
+			best, bestScore = domain, score
+		}
+	}
+
+	changed := false
+	activeScore := r.scoreLocked(active)
+	if best != active && now.Sub(r.activeSince) >= r.settings.MinActiveDuration &&
+		(math.IsInf(activeScore, 1) || bestScore < activeScore*(1-r.settings.SwitchThreshold)) {
+		tlmFailoverSwitches.Inc(baseDomain, active, best)
+		r.active = best
+		r.activeSince = now
+		active = best
+		changed = true
+	}
+
+	for _, domain := range append([]string{baseDomain}, r.failoverDomains...) {
+		value := 0.0
+		if domain == active {
+			value = 1
+		}
+		tlmFailoverActiveDomain.Set(value, baseDomain, domain)
+	}
+	return active, changed
+}
+
+func (r *FailoverDomainResolver) scoreLocked(domain string) float64 {
+	h, ok := r.health[domain]
+	if !ok {
+		return math.Inf(1)
+	}
+	return h.score()
+}
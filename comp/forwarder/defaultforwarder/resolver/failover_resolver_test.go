@@ -0,0 +1,91 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package resolver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/forwarder/defaultforwarder/transaction"
+	"github.com/DataDog/datadog-agent/pkg/config/utils"
+)
+
+func newTestFailoverResolver(t *testing.T) (*FailoverDomainResolver, *time.Time) {
+	apiKeys := []utils.APIKeys{utils.NewAPIKeys("api_key", "key1")}
+	resolver, err := NewFailoverDomainResolver("https://primary.example.com", apiKeys,
+		[]string{"https://secondary.example.com", "https://primary.example.com"},
+		FailoverSettings{SwitchThreshold: 0.2, MinActiveDuration: time.Minute})
+	require.NoError(t, err)
+
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+	return resolver, &now
+}
+
+func TestFailoverDomainResolverDomains(t *testing.T) {
+	resolver, _ := newTestFailoverResolver(t)
+
+	assert.Equal(t, []string{"https://primary.example.com", "https://secondary.example.com"}, resolver.GetDomains())
+	assert.Equal(t, []string{"https://secondary.example.com"}, resolver.GetAlternateDomains())
+	assert.Equal(t, []string{"key1"}, resolver.GetAPIKeys())
+
+	domain, dType := resolver.Resolve(transaction.Endpoint{})
+	assert.Equal(t, "https://primary.example.com", domain)
+	assert.Equal(t, Datadog, dType)
+}
+
+func TestFailoverDomainResolverSwitchesToHealthiest(t *testing.T) {
+	resolver, now := newTestFailoverResolver(t)
+
+	resolver.ReportProbe("https://primary.example.com", 100*time.Millisecond, nil)
+	resolver.ReportProbe("https://secondary.example.com", 90*time.Millisecond, nil)
+	active, changed := resolver.Evaluate()
+	assert.Equal(t, "https://primary.example.com", active)
+	assert.False(t, changed)
+
+	// the primary domain fails, but stays active for the minimum duration
+	resolver.ReportProbe("https://primary.example.com", 100*time.Millisecond, errors.New("unreachable"))
+	_, changed = resolver.Evaluate()
+	assert.False(t, changed)
+
+	*now = now.Add(time.Minute)
+	active, changed = resolver.Evaluate()
+	assert.Equal(t, "https://secondary.example.com", active)
+	assert.True(t, changed)
+	domain, _ := resolver.Resolve(transaction.Endpoint{})
+	assert.Equal(t, "https://secondary.example.com", domain)
+}
+
+func TestFailoverDomainResolverHysteresis(t *testing.T) {
+	resolver, now := newTestFailoverResolver(t)
+	*now = now.Add(time.Hour)
+
+	resolver.ReportProbe("https://primary.example.com", 100*time.Millisecond, nil)
+	// 10% faster is not enough to switch
+	resolver.ReportProbe("https://secondary.example.com", 90*time.Millisecond, nil)
+	_, changed := resolver.Evaluate()
+	assert.False(t, changed)
+
+	*now = now.Add(time.Hour)
+	for i := 0; i < 20; i++ {
+		resolver.ReportProbe("https://secondary.example.com", 50*time.Millisecond, nil)
+	}
+	active, changed := resolver.Evaluate()
+	assert.Equal(t, "https://secondary.example.com", active)
+	assert.True(t, changed)
+}
+
+func TestFailoverDomainResolverSetBaseDomain(t *testing.T) {
+	resolver, _ := newTestFailoverResolver(t)
+
+	resolver.SetBaseDomain("https://7-0-0-app.primary.example.com")
+	assert.Equal(t, "https://7-0-0-app.primary.example.com", resolver.GetActiveDomain())
+	assert.Equal(t, "https://7-0-0-app.primary.example.com", resolver.GetDomains()[0])
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 )
 
 // SharedConnection holds a shared http.Client that is used by each worker.
@@ -42,6 +43,14 @@ func NewSharedConnection(
 
 	sc.client = sc.newClient()
 
+	if !isLocal {
+		// The timeout of the client is set from forwarder_timeout when it is
+		// created
+		pkgconfigsetup.OnReload("forwarder_timeout", func(string, any, any) {
+			sc.ResetClient()
+		})
+	}
+
 	return sc
 }
 
@@ -40,6 +40,8 @@ var (
 		[]string{"domain", "endpoint"}, "Transaction retry count")
 	tlmTxRetryQueueSize = telemetry.NewGauge("transactions", "retry_queue_size",
 		[]string{"domain"}, "Retry queue size")
+	tlmTxRetryExpired = telemetry.NewCounter("transactions", "retry_expired",
+		[]string{"domain", "endpoint", "priority"}, "Count of transactions dropped because they are older than the maximum age of their priority")
 )
 
 func init() {
@@ -0,0 +1,69 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package transaction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	contentEncodingHeaderKey = "Content-Encoding"
+	zstdContentEncoding      = "zstd"
+	gzipContentEncoding      = "gzip"
+)
+
+// gzipFallbackDomains are the domains which rejected a zstd payload, the zstd
+// payloads sent to these domains are re-encoded with gzip
+var gzipFallbackDomains sync.Map
+
+// useGzipFallback re-encodes the payload of the transaction with gzip if it is
+// compressed with zstd and its domain does not support zstd.
+func (t *HTTPTransaction) useGzipFallback() error {
+	if t.Headers.Get(contentEncodingHeaderKey) != zstdContentEncoding {
+		return nil
+	}
+	if _, ok := gzipFallbackDomains.Load(t.Domain); !ok {
+		return nil
+	}
+
+	content, err := zstdToGzip(t.Payload.GetContent())
+	if err != nil {
+		return err
+	}
+	payload := NewBytesPayload(content, t.Payload.GetPointCount())
+	payload.Destination = t.Payload.Destination
+	// the payload is shared with the transactions of the other domains
+	t.Payload = payload
+	t.Headers.Set(contentEncodingHeaderKey, gzipContentEncoding)
+	return nil
+}
+
+// zstdToGzip re-encodes a zstd compressed payload with gzip.
+func zstdToGzip(src []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+	decoded, err := decoder.DecodeAll(src, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(decoded); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,183 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package transaction
+
+import (
+	"expvar"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySamplesCount is the number of latencies kept per endpoint to compute
+// the percentiles of the payload latency
+const latencySamplesCount = 1000
+
+// DomainStats is the health of the endpoints of a domain
+type DomainStats struct {
+	Domain string
+	// RetryQueueDepth is the number of transactions in the retry queue of the domain
+	RetryQueueDepth int
+	Endpoints       []EndpointStats
+}
+
+// EndpointStats is the health of an endpoint of a domain
+type EndpointStats struct {
+	Endpoint      string
+	Success       int64
+	Errors        int64
+	Dropped       int64
+	LastError     string `json:",omitempty"`
+	LastErrorTime string `json:",omitempty"`
+	// LatencyP50, LatencyP90 and LatencyP99 are the percentiles in milliseconds
+	// of the latency of the last payloads sent successfully
+	LatencyP50 float64
+	LatencyP90 float64
+	LatencyP99 float64
+}
+
+type endpointStats struct {
+	success       int64
+	errors        int64
+	dropped       int64
+	lastError     string
+	lastErrorTime time.Time
+	// latencies is a ring buffer of the last latencies
+	latencies []time.Duration
+	next      int
+}
+
+type domainStats struct {
+	retryQueueDepth int
+	endpoints       map[string]*endpointStats
+}
+
+type endpointsStatsRegistry struct {
+	m       sync.Mutex
+	domains map[string]*domainStats
+}
+
+var endpointsStats = &endpointsStatsRegistry{domains: map[string]*domainStats{}}
+
+func init() {
+	ForwarderExpvars.Set("EndpointsStats", expvar.Func(func() interface{} {
+		return GetEndpointsStats()
+	}))
+}
+
+// GetEndpointsStats returns the health of the endpoints the transactions were
+// sent to, sorted by domain and endpoint.
+func GetEndpointsStats() []DomainStats {
+	return endpointsStats.snapshot()
+}
+
+// SetRetryQueueDepth records the number of transactions in the retry queue of
+// domain.
+func SetRetryQueueDepth(domain string, depth int) {
+	endpointsStats.m.Lock()
+	defer endpointsStats.m.Unlock()
+	endpointsStats.getDomain(domain).retryQueueDepth = depth
+}
+
+func (r *endpointsStatsRegistry) getDomain(domain string) *domainStats {
+	d, ok := r.domains[domain]
+	if !ok {
+		d = &domainStats{endpoints: map[string]*endpointStats{}}
+		r.domains[domain] = d
+	}
+	return d
+}
+
+func (r *endpointsStatsRegistry) getEndpoint(domain, endpoint string) *endpointStats {
+	d := r.getDomain(domain)
+	e, ok := d.endpoints[endpoint]
+	if !ok {
+		e = &endpointStats{}
+		d.endpoints[endpoint] = e
+	}
+	return e
+}
+
+func (r *endpointsStatsRegistry) recordSuccess(domain, endpoint string, latency time.Duration) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	e := r.getEndpoint(domain, endpoint)
+	e.success++
+	if len(e.latencies) < latencySamplesCount {
+		e.latencies = append(e.latencies, latency)
+	} else {
+		e.latencies[e.next] = latency
+		e.next = (e.next + 1) % latencySamplesCount
+	}
+}
+
+func (r *endpointsStatsRegistry) recordError(domain, endpoint string, dropped bool, lastError string, now time.Time) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	e := r.getEndpoint(domain, endpoint)
+	if dropped {
+		e.dropped++
+	} else {
+		e.errors++
+	}
+	e.lastError = lastError
+	e.lastErrorTime = now
+}
+
+func (r *endpointsStatsRegistry) snapshot() []DomainStats {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	result := make([]DomainStats, 0, len(r.domains))
+	for domain, d := range r.domains {
+		stats := DomainStats{
+			Domain:          domain,
+			RetryQueueDepth: d.retryQueueDepth,
+			Endpoints:       make([]EndpointStats, 0, len(d.endpoints)),
+		}
+		for name, e := range d.endpoints {
+			endpoint := EndpointStats{
+				Endpoint:  name,
+				Success:   e.success,
+				Errors:    e.errors,
+				Dropped:   e.dropped,
+				LastError: e.lastError,
+			}
+			if !e.lastErrorTime.IsZero() {
+				endpoint.LastErrorTime = e.lastErrorTime.UTC().Format(time.RFC3339)
+			}
+			latencies := slices.Clone(e.latencies)
+			slices.Sort(latencies)
+			endpoint.LatencyP50 = latencyPercentile(latencies, 50)
+			endpoint.LatencyP90 = latencyPercentile(latencies, 90)
+			endpoint.LatencyP99 = latencyPercentile(latencies, 99)
+			stats.Endpoints = append(stats.Endpoints, endpoint)
+		}
+		sort.Slice(stats.Endpoints, func(i, j int) bool {
+			return stats.Endpoints[i].Endpoint < stats.Endpoints[j].Endpoint
+		})
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Domain < result[j].Domain
+	})
+	return result
+}
+
+// latencyPercentile returns the percentile p of the sorted latencies, in
+// milliseconds, using the nearest-rank method.
+func latencyPercentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return float64(sorted[rank-1].Microseconds()) / 1000
+}
@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointsStats(t *testing.T) {
+	r := &endpointsStatsRegistry{domains: map[string]*domainStats{}}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i := 1; i <= 100; i++ {
+		r.recordSuccess("https://app.datadoghq.com", "series_v2", time.Duration(i)*time.Millisecond)
+	}
+	r.recordError("https://app.datadoghq.com", "series_v2", false, "503 Service Unavailable", now)
+	r.recordError("https://app.datadoghq.com", "check_run_v1", true, "413 Request Entity Too Large", now)
+	r.recordSuccess("https://app.datadoghq.eu", "series_v2", 10*time.Millisecond)
+	r.getDomain("https://app.datadoghq.com").retryQueueDepth = 3
+
+	stats := r.snapshot()
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "https://app.datadoghq.com", stats[0].Domain)
+	assert.Equal(t, 3, stats[0].RetryQueueDepth)
+	assert.Equal(t, []EndpointStats{
+		{
+			Endpoint:      "check_run_v1",
+			Dropped:       1,
+			LastError:     "413 Request Entity Too Large",
+			LastErrorTime: "2025-01-02T03:04:05Z",
+		},
+		{
+			Endpoint:      "series_v2",
+			Success:       100,
+			Errors:        1,
+			LastError:     "503 Service Unavailable",
+			LastErrorTime: "2025-01-02T03:04:05Z",
+			LatencyP50:    50,
+			LatencyP90:    90,
+			LatencyP99:    99,
+		},
+	}, stats[0].Endpoints)
+
+	assert.Equal(t, "https://app.datadoghq.eu", stats[1].Domain)
+	assert.Equal(t, []EndpointStats{
+		{
+			Endpoint:   "series_v2",
+			Success:    1,
+			LatencyP50: 10,
+			LatencyP90: 10,
+			LatencyP99: 10,
+		},
+	}, stats[1].Endpoints)
+}
+
+func TestEndpointsStatsLatencySamples(t *testing.T) {
+	r := &endpointsStatsRegistry{domains: map[string]*domainStats{}}
+
+	for i := 0; i < latencySamplesCount; i++ {
+		r.recordSuccess("domain", "endpoint", time.Second)
+	}
+	// the oldest latencies are replaced by the new ones
+	for i := 0; i < latencySamplesCount; i++ {
+		r.recordSuccess("domain", "endpoint", time.Millisecond)
+	}
+
+	stats := r.snapshot()
+	require.Len(t, stats, 1)
+	require.Len(t, stats[0].Endpoints, 1)
+	assert.EqualValues(t, 2*latencySamplesCount, stats[0].Endpoints[0].Success)
+	assert.Equal(t, 1.0, stats[0].Endpoints[0].LatencyP99)
+}
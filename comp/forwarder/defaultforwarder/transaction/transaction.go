@@ -34,6 +34,7 @@ var (
 
 	connectionDNSSuccess         = expvar.Int{}
 	connectionConnectSuccess     = expvar.Int{}
+	connectionReused             = expvar.Int{}
 	transactionsConnectionEvents = expvar.Map{}
 
 	// TransactionsDropped is the number of transaction dropped.
@@ -109,6 +110,12 @@ func GetClientTrace(log log.Component) *httptrace.ClientTrace {
 				tlmConnectEvents.Inc("connection_success")
 				log.Tracef("New successful connection to address: %q", addr)
 			},
+			GotConn: func(connInfo httptrace.GotConnInfo) {
+				if connInfo.Reused {
+					connectionReused.Add(1)
+					tlmConnectEvents.Inc("connection_reused")
+				}
+			},
 			TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
 				if err != nil {
 					transactionsTLSErrors.Add(1)
@@ -144,6 +151,7 @@ func init() {
 	ForwarderExpvars.Set("Transactions", &TransactionsExpvars)
 	transactionsConnectionEvents.Set("DNSSuccess", &connectionDNSSuccess)
 	transactionsConnectionEvents.Set("ConnectSuccess", &connectionConnectSuccess)
+	transactionsConnectionEvents.Set("ConnectionReused", &connectionReused)
 	TransactionsExpvars.Set("ConnectionEvents", &transactionsConnectionEvents)
 	TransactionsExpvars.Set("Dropped", &TransactionsDropped)
 	TransactionsExpvars.Set("DroppedByEndpoint", &TransactionsDroppedByEndpoint)
@@ -174,6 +182,14 @@ const (
 	TransactionPriorityHigh Priority = iota
 )
 
+// String returns the name of the priority
+func (p Priority) String() string {
+	if p == TransactionPriorityHigh {
+		return "high"
+	}
+	return "normal"
+}
+
 // Kind defines de kind of transaction (metrics, metadata, process, ...)
 type Kind int
 
@@ -370,22 +386,33 @@ func (t *HTTPTransaction) Process(ctx context.Context, config config.Component,
 // internalProcess does the  work of actually sending the http request to the specified domain
 // This will return  (http status code, response body, error).
 func (t *HTTPTransaction) internalProcess(ctx context.Context, config config.Component, log log.Component, client *http.Client) (int, []byte, error) {
-	payload := t.Payload.GetContent()
-	reader := bytes.NewReader(payload)
 	url := t.Domain + t.Endpoint.Route
 	transactionEndpointName := t.GetEndpointName()
 	logURL := scrubber.ScrubLine(url) // sanitized url that can be logged
 
+	if err := t.useGzipFallback(); err != nil {
+		log.Errorf("Could not re-encode the transaction to %q with gzip (dropping transaction): %s", logURL, err)
+		TransactionsDroppedByEndpoint.Add(transactionEndpointName, 1)
+		TransactionsDropped.Add(1)
+		TlmTxDropped.Inc(t.Domain, transactionEndpointName)
+		endpointsStats.recordError(t.Domain, transactionEndpointName, true, "could not re-encode the payload with gzip", time.Now())
+		return 0, nil, nil
+	}
+	payload := t.Payload.GetContent()
+	reader := bytes.NewReader(payload)
+
 	req, err := http.NewRequestWithContext(ctx, "POST", url, reader)
 	if err != nil {
 		log.Errorf("Could not create request for transaction to invalid URL %q (dropping transaction): %s", logURL, err)
 		transactionsErrors.Add(1)
 		tlmTxErrors.Inc(t.Domain, transactionEndpointName, "invalid_request")
 		transactionsSentRequestErrors.Add(1)
+		endpointsStats.recordError(t.Domain, transactionEndpointName, true, "invalid request: "+scrubber.ScrubLine(err.Error()), time.Now())
 		return 0, nil, nil
 	}
 	req.Header = t.Headers
 	log.Tracef("Sending %s request to %s with body size %d and headers %v", req.Method, logURL, len(payload), req.Header)
+	start := time.Now()
 	resp, err := client.Do(req)
 
 	if err != nil {
@@ -396,6 +423,7 @@ func (t *HTTPTransaction) internalProcess(ctx context.Context, config config.Com
 		t.ErrorCount++
 		transactionsErrors.Add(1)
 		tlmTxErrors.Inc(t.Domain, transactionEndpointName, "cant_send")
+		endpointsStats.recordError(t.Domain, transactionEndpointName, false, scrubber.ScrubLine(err.Error()), time.Now())
 		return 0, nil, fmt.Errorf("error while sending transaction, rescheduling it: %s", scrubber.ScrubLine(err.Error()))
 	}
 	defer func() { _ = resp.Body.Close() }()
@@ -403,6 +431,7 @@ func (t *HTTPTransaction) internalProcess(ctx context.Context, config config.Com
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Errorf("Fail to read the response Body: %s", err)
+		endpointsStats.recordError(t.Domain, transactionEndpointName, false, "failed to read the response body: "+err.Error(), time.Now())
 		return 0, nil, err
 	}
 
@@ -420,6 +449,15 @@ func (t *HTTPTransaction) internalProcess(ctx context.Context, config config.Com
 		tlmTxHTTPErrors.Inc(t.Domain, transactionEndpointName, statusCode)
 	}
 
+	if resp.StatusCode == http.StatusUnsupportedMediaType && t.Headers.Get(contentEncodingHeaderKey) == zstdContentEncoding {
+		// the domain does not support zstd, the transaction is sent again with
+		// gzip as are all the next transactions to this domain
+		if _, loaded := gzipFallbackDomains.LoadOrStore(t.Domain, struct{}{}); !loaded {
+			log.Warnf("%q does not support zstd payloads, falling back to gzip", scrubber.ScrubLine(t.Domain))
+		}
+		return t.internalProcess(ctx, config, log, client)
+	}
+
 	// We want to retry 404s even if that means that the agent would retry
 	// payloads on endpoints that don’t exist at the intake it’s sending data
 	// to (example: a specific DD region, or a http proxy)
@@ -428,17 +466,20 @@ func (t *HTTPTransaction) internalProcess(ctx context.Context, config config.Com
 		TransactionsDroppedByEndpoint.Add(transactionEndpointName, 1)
 		TransactionsDropped.Add(1)
 		TlmTxDropped.Inc(t.Domain, transactionEndpointName)
+		endpointsStats.recordError(t.Domain, transactionEndpointName, true, resp.Status, time.Now())
 		return resp.StatusCode, body, nil
 	} else if resp.StatusCode == 403 {
 		log.Errorf("API Key invalid, dropping transaction for %s", logURL)
 		TransactionsDroppedByEndpoint.Add(transactionEndpointName, 1)
 		TransactionsDropped.Add(1)
 		TlmTxDropped.Inc(t.Domain, transactionEndpointName)
+		endpointsStats.recordError(t.Domain, transactionEndpointName, true, resp.Status+": API Key invalid", time.Now())
 		return resp.StatusCode, body, nil
 	} else if resp.StatusCode > 400 {
 		t.ErrorCount++
 		transactionsErrors.Add(1)
 		tlmTxErrors.Inc(t.Domain, transactionEndpointName, "gt_400")
+		endpointsStats.recordError(t.Domain, transactionEndpointName, false, resp.Status, time.Now())
 		return resp.StatusCode, body, fmt.Errorf("error %q while sending transaction to %q, rescheduling it: %q", resp.Status, logURL, truncateBodyForLog(body))
 	}
 
@@ -447,6 +488,7 @@ func (t *HTTPTransaction) internalProcess(ctx context.Context, config config.Com
 	TransactionsSuccessByEndpoint.Add(transactionEndpointName, 1)
 	transactionsSuccessBytesByEndpoint.Add(transactionEndpointName, int64(t.GetPayloadSize()))
 	transactionsSuccess.Add(1)
+	endpointsStats.recordSuccess(t.Domain, transactionEndpointName, time.Since(start))
 
 	loggingFrequency := config.GetInt64("logging_frequency")
 
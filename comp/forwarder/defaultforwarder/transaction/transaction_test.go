@@ -6,7 +6,9 @@
 package transaction
 
 import (
+	"compress/gzip"
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -14,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 
 	logmock "github.com/DataDog/datadog-agent/comp/core/log/mock"
@@ -182,3 +185,53 @@ func Test_truncateBodyForLog(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessFallsBackToGzip(t *testing.T) {
+	var encodings []string
+	var received []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		encodings = append(encodings, encoding)
+		if encoding == "zstd" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		reader, err := gzip.NewReader(r.Body)
+		if assert.NoError(t, err) {
+			received, err = io.ReadAll(reader)
+			assert.NoError(t, err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	defer gzipFallbackDomains.Delete(ts.URL)
+
+	encoder, err := zstd.NewWriter(nil)
+	assert.NoError(t, err)
+	payload := NewBytesPayload(encoder.EncodeAll([]byte("test payload"), nil), 1)
+
+	newTransaction := func() *HTTPTransaction {
+		transaction := NewHTTPTransaction()
+		transaction.Domain = ts.URL
+		transaction.Endpoint.Route = "/endpoint/test"
+		transaction.Headers.Set("Content-Encoding", "zstd")
+		transaction.Payload = payload
+		return transaction
+	}
+
+	client := &http.Client{}
+	mockConfig := configmock.New(t)
+	log := logmock.New(t)
+	err = newTransaction().Process(context.Background(), mockConfig, log, client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zstd", "gzip"}, encodings)
+	assert.Equal(t, []byte("test payload"), received)
+
+	// the next transactions are directly sent with gzip, without altering the shared payload
+	transaction := newTransaction()
+	err = transaction.Process(context.Background(), mockConfig, log, client)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zstd", "gzip", "gzip"}, encodings)
+	assert.NotSame(t, payload, transaction.Payload)
+	assert.Equal(t, 1, transaction.GetPointCount())
+}
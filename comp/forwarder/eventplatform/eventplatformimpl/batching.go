@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventplatformimpl
+
+import (
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+	"github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/config/structure"
+	"github.com/DataDog/datadog-agent/pkg/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// eventTypeBatchingKey is the setting mapping event types to the batching
+// policy of their pipeline
+const eventTypeBatchingKey = "event_platform_batching"
+
+// maxBatchWait is the maximum batch_wait of a pipeline, like for the logs
+const maxBatchWait = 10 * time.Second
+
+var (
+	tlmBatchWait = telemetry.NewGauge("event_platform", "batch_wait_ms",
+		[]string{"event_type"}, "Maximum time in milliseconds the events of a pipeline wait before being sent")
+	tlmBatchMaxSize = telemetry.NewGauge("event_platform", "batch_max_size",
+		[]string{"event_type"}, "Maximum number of events in a payload of a pipeline")
+	tlmBatchMaxContentSize = telemetry.NewGauge("event_platform", "batch_max_content_size",
+		[]string{"event_type"}, "Maximum size in bytes of the content of a payload of a pipeline")
+)
+
+// batchingPolicy overrides the batching settings of the pipeline of an event
+// type, zero values keep the settings of the pipeline. The json tags are used
+// when the setting is a JSON string, like with DD_EVENT_PLATFORM_BATCHING.
+type batchingPolicy struct {
+	// BatchWait is in seconds
+	BatchWait           int `mapstructure:"batch_wait" json:"batch_wait"`
+	BatchMaxSize        int `mapstructure:"batch_max_size" json:"batch_max_size"`
+	BatchMaxContentSize int `mapstructure:"batch_max_content_size" json:"batch_max_content_size"`
+}
+
+func getBatchingPolicy(coreConfig model.Reader, eventType string) batchingPolicy {
+	var policies map[string]batchingPolicy
+	err := structure.UnmarshalKey(coreConfig, eventTypeBatchingKey, &policies, structure.EnableStringUnmarshal)
+	if err != nil {
+		log.Warnf("Could not parse %s: %v", eventTypeBatchingKey, err)
+	}
+	return policies[eventType]
+}
+
+// applyBatchingPolicy overrides the batching settings of endpoints with the
+// policy of eventType, and reports the resulting settings.
+func applyBatchingPolicy(coreConfig model.Reader, eventType string, endpoints *config.Endpoints) {
+	policy := getBatchingPolicy(coreConfig, eventType)
+
+	if policy.BatchWait != 0 {
+		batchWait := time.Duration(policy.BatchWait) * time.Second
+		if batchWait < 0 || batchWait > maxBatchWait {
+			log.Warnf("Invalid %s.%s.batch_wait: %v should be in [1, 10], ignoring it", eventTypeBatchingKey, eventType, policy.BatchWait)
+		} else {
+			endpoints.BatchWait = batchWait
+		}
+	}
+	if policy.BatchMaxSize < 0 {
+		log.Warnf("Invalid %s.%s.batch_max_size: %v should be > 0, ignoring it", eventTypeBatchingKey, eventType, policy.BatchMaxSize)
+	} else if policy.BatchMaxSize > 0 {
+		endpoints.BatchMaxSize = policy.BatchMaxSize
+	}
+	if policy.BatchMaxContentSize < 0 {
+		log.Warnf("Invalid %s.%s.batch_max_content_size: %v should be > 0, ignoring it", eventTypeBatchingKey, eventType, policy.BatchMaxContentSize)
+	} else if policy.BatchMaxContentSize > 0 {
+		endpoints.BatchMaxContentSize = policy.BatchMaxContentSize
+	}
+
+	tlmBatchWait.Set(float64(endpoints.BatchWait.Milliseconds()), eventType)
+	tlmBatchMaxSize.Set(float64(endpoints.BatchMaxSize), eventType)
+	tlmBatchMaxContentSize.Set(float64(endpoints.BatchMaxContentSize), eventType)
+}
@@ -0,0 +1,70 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventplatformimpl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/comp/forwarder/eventplatform"
+	logsconfig "github.com/DataDog/datadog-agent/comp/logs/agent/config"
+)
+
+func newTestBatchingEndpoints() *logsconfig.Endpoints {
+	return &logsconfig.Endpoints{
+		BatchWait:           5 * time.Second,
+		BatchMaxSize:        1000,
+		BatchMaxContentSize: 5000000,
+	}
+}
+
+func TestApplyBatchingPolicy(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_batching", map[string]interface{}{
+		eventplatform.EventTypeNetworkPath: map[string]interface{}{
+			"batch_wait":     1,
+			"batch_max_size": 100,
+		},
+	})
+
+	endpoints := newTestBatchingEndpoints()
+	applyBatchingPolicy(cfg, eventplatform.EventTypeNetworkPath, endpoints)
+	assert.Equal(t, time.Second, endpoints.BatchWait)
+	assert.Equal(t, 100, endpoints.BatchMaxSize)
+	assert.Equal(t, 5000000, endpoints.BatchMaxContentSize)
+
+	// the other event types keep the settings of their pipeline
+	endpoints = newTestBatchingEndpoints()
+	applyBatchingPolicy(cfg, eventplatform.EventTypeNetworkDevicesNetFlow, endpoints)
+	assert.Equal(t, newTestBatchingEndpoints(), endpoints)
+}
+
+func TestApplyBatchingPolicyFromJSON(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_batching", `{"dbm-samples": {"batch_max_content_size": 10000000}}`)
+
+	endpoints := newTestBatchingEndpoints()
+	applyBatchingPolicy(cfg, eventTypeDBMSamples, endpoints)
+	assert.Equal(t, 10000000, endpoints.BatchMaxContentSize)
+}
+
+func TestApplyBatchingPolicyInvalid(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_batching", map[string]interface{}{
+		eventplatform.EventTypeNetworkPath: map[string]interface{}{
+			"batch_wait":             60,
+			"batch_max_size":         -1,
+			"batch_max_content_size": -1,
+		},
+	})
+
+	endpoints := newTestBatchingEndpoints()
+	applyBatchingPolicy(cfg, eventplatform.EventTypeNetworkPath, endpoints)
+	assert.Equal(t, newTestBatchingEndpoints(), endpoints)
+}
@@ -0,0 +1,298 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventplatformimpl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	spoolFileExtension = ".spool"
+	spoolFileFormat    = "2006_01_02__15_04_05_"
+	// spoolFileMaxSize is the size above which the events are stored in a new
+	// file, so that the oldest events can be removed without rewriting a file
+	spoolFileMaxSize = 1024 * 1024
+	// spoolRecordHeaderSize is the size of the length prefixing each event
+	spoolRecordHeaderSize = 4
+	// spoolReplayInterval is the interval at which the stored events are sent
+	// again, when the pipeline has room for them
+	spoolReplayInterval = time.Second
+)
+
+type spoolFile struct {
+	name string
+	size int64
+}
+
+// diskSpool stores on the disk the events of a pipeline whose input channel is
+// full, because the intake is unreachable, and sends them again once the
+// pipeline drains. The events are stored in files of at most spoolFileMaxSize
+// bytes, and the oldest files are removed when maxSizeInBytes is reached.
+type diskSpool struct {
+	m                  sync.Mutex
+	eventType          string
+	storagePath        string
+	maxSizeInBytes     int64
+	files              []spoolFile
+	currentSizeInBytes int64
+	// current is the file the events are appended to, it is the last of files
+	current *os.File
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newDiskSpool returns the disk spool of eventType, or nil if the events of
+// eventType are not stored on the disk.
+func newDiskSpool(config model.Reader, eventType string) (*diskSpool, error) {
+	if !slices.Contains(config.GetStringSlice("event_platform_storage.event_types"), eventType) {
+		return nil, nil
+	}
+	maxSizeInBytes := config.GetInt64("event_platform_storage.max_size_in_bytes")
+	if maxSizeInBytes <= 0 {
+		return nil, nil
+	}
+
+	storagePath := config.GetString("event_platform_storage.path")
+	if storagePath == "" {
+		storagePath = filepath.Join(config.GetString("run_path"), "event_platform")
+	}
+	storagePath = filepath.Join(storagePath, eventType)
+	if err := os.MkdirAll(storagePath, 0700); err != nil {
+		return nil, err
+	}
+
+	s := &diskSpool{
+		eventType:      eventType,
+		storagePath:    storagePath,
+		maxSizeInBytes: maxSizeInBytes,
+	}
+	if err := s.reloadExistingFiles(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// store appends the content of an event to the spool, removing the oldest
+// events if there is not enough room for it.
+func (s *diskSpool) store(content []byte) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	recordSize := int64(spoolRecordHeaderSize + len(content))
+	if recordSize > s.maxSizeInBytes {
+		return fmt.Errorf("the event is too big. Current:%v Maximum:%v", recordSize, s.maxSizeInBytes)
+	}
+	s.makeRoomFor(recordSize)
+
+	if s.current == nil || s.files[len(s.files)-1].size+recordSize > spoolFileMaxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]byte, recordSize)
+	binary.LittleEndian.PutUint32(record, uint32(len(content)))
+	copy(record[spoolRecordHeaderSize:], content)
+	if _, err := s.current.Write(record); err != nil {
+		// the file may contain a partial record, do not append to it anymore
+		s.closeCurrent()
+		return err
+	}
+	s.files[len(s.files)-1].size += recordSize
+	s.currentSizeInBytes += recordSize
+	return nil
+}
+
+// extractOldest removes the oldest file of the spool and returns the content
+// of the events it contains.
+func (s *diskSpool) extractOldest() ([][]byte, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if len(s.files) == 0 {
+		return nil, nil
+	}
+	if len(s.files) == 1 {
+		s.closeCurrent()
+	}
+	name := s.files[0].name
+	bytes, err := os.ReadFile(name)
+
+	// Remove the file even in case of a read failure.
+	s.removeOldest()
+	if err != nil {
+		return nil, err
+	}
+	return decodeSpoolRecords(bytes), nil
+}
+
+func (s *diskSpool) isEmpty() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.files) == 0
+}
+
+// startReplay sends the stored events to in, oldest first, while in is less
+// than half full.
+func (s *diskSpool) startReplay(in chan *message.Message) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(spoolReplayInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if !s.replay(in) {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// replay sends the stored events to in until the spool is empty or in is half
+// full. It returns false if the spool was stopped.
+func (s *diskSpool) replay(in chan *message.Message) bool {
+	for !s.isEmpty() && len(in) < cap(in)/2 {
+		contents, err := s.extractOldest()
+		if err != nil {
+			log.Errorf("Unable to read the events stored on disk for eventType=%s: %v", s.eventType, err)
+			continue
+		}
+		log.Debugf("Sending %d events stored on disk for eventType=%s", len(contents), s.eventType)
+		for i, content := range contents {
+			select {
+			case in <- message.NewMessage(content, nil, "", 0):
+			case <-s.stop:
+				// keep the events that were not sent for the next start
+				for _, content := range contents[i:] {
+					if err := s.store(content); err != nil {
+						log.Warnf("Dropping an event stored on disk for eventType=%s: %v", s.eventType, err)
+					}
+				}
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// stopReplay stops sending the stored events and closes the current file.
+func (s *diskSpool) stopReplay() {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closeCurrent()
+}
+
+func (s *diskSpool) makeRoomFor(recordSize int64) {
+	for len(s.files) > 0 && s.currentSizeInBytes+recordSize > s.maxSizeInBytes {
+		log.Warnf("Maximum disk space for the events of eventType=%s is reached. Removing %s", s.eventType, s.files[0].name)
+		if len(s.files) == 1 {
+			s.closeCurrent()
+		}
+		s.removeOldest()
+	}
+}
+
+func (s *diskSpool) rotate() error {
+	s.closeCurrent()
+	filename := time.Now().UTC().Format(spoolFileFormat)
+	file, err := os.CreateTemp(s.storagePath, filename+"*"+spoolFileExtension)
+	if err != nil {
+		return err
+	}
+	s.current = file
+	s.files = append(s.files, spoolFile{name: file.Name()})
+	return nil
+}
+
+func (s *diskSpool) closeCurrent() {
+	if s.current == nil {
+		return
+	}
+	if err := s.current.Close(); err != nil {
+		log.Warnf("Unable to close %s: %v", s.current.Name(), err)
+	}
+	s.current = nil
+}
+
+func (s *diskSpool) removeOldest() {
+	file := s.files[0]
+	// Remove the file from s.files also in case of error to not fail on the
+	// next call.
+	s.files = slices.Delete(s.files, 0, 1)
+	s.currentSizeInBytes -= file.size
+	if err := os.Remove(file.name); err != nil {
+		log.Warnf("Unable to remove %s: %v", file.name, err)
+	}
+}
+
+func (s *diskSpool) reloadExistingFiles() error {
+	entries, err := os.ReadDir(s.storagePath)
+	if err != nil {
+		return err
+	}
+	var files []os.FileInfo
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			log.Warnf("Can't get file info: %v", err)
+			continue
+		}
+		if info.Mode().IsRegular() && filepath.Ext(entry.Name()) == spoolFileExtension {
+			files = append(files, info)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, file := range files {
+		s.files = append(s.files, spoolFile{name: filepath.Join(s.storagePath, file.Name()), size: file.Size()})
+		s.currentSizeInBytes += file.Size()
+	}
+	if len(s.files) > 0 {
+		log.Infof("Reloaded %d files of events stored on disk for eventType=%s", len(s.files), s.eventType)
+	}
+	return nil
+}
+
+// decodeSpoolRecords returns the content of the events stored in bytes. A
+// truncated record at the end, left by a failed write, is ignored.
+func decodeSpoolRecords(bytes []byte) [][]byte {
+	var contents [][]byte
+	for len(bytes) >= spoolRecordHeaderSize {
+		size := int(binary.LittleEndian.Uint32(bytes))
+		bytes = bytes[spoolRecordHeaderSize:]
+		if size > len(bytes) {
+			break
+		}
+		contents = append(contents, bytes[:size])
+		bytes = bytes[size:]
+	}
+	return contents
+}
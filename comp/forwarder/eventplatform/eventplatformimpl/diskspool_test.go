@@ -0,0 +1,125 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventplatformimpl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/comp/forwarder/eventplatform"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+func newTestDiskSpool(t *testing.T, maxSizeInBytes int64) (*diskSpool, string) {
+	path := t.TempDir()
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_storage.event_types", []string{eventplatform.EventTypeNetworkPath})
+	cfg.SetWithoutSource("event_platform_storage.path", path)
+	cfg.SetWithoutSource("event_platform_storage.max_size_in_bytes", maxSizeInBytes)
+
+	spool, err := newDiskSpool(cfg, eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+	require.NotNil(t, spool)
+	return spool, path
+}
+
+func TestNewDiskSpoolDisabled(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_storage.path", t.TempDir())
+
+	spool, err := newDiskSpool(cfg, eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+	assert.Nil(t, spool)
+
+	cfg.SetWithoutSource("event_platform_storage.event_types", []string{eventplatform.EventTypeNetworkPath})
+	spool, err = newDiskSpool(cfg, eventplatform.EventTypeNetworkDevicesNetFlow)
+	require.NoError(t, err)
+	assert.Nil(t, spool)
+}
+
+func TestDiskSpoolStoreAndExtract(t *testing.T) {
+	spool, path := newTestDiskSpool(t, 1024)
+
+	require.NoError(t, spool.store([]byte("event1")))
+	require.NoError(t, spool.store([]byte("event2")))
+	assert.False(t, spool.isEmpty())
+
+	files, err := filepath.Glob(filepath.Join(path, eventplatform.EventTypeNetworkPath, "*"+spoolFileExtension))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	contents, err := spool.extractOldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("event1"), []byte("event2")}, contents)
+	assert.True(t, spool.isEmpty())
+
+	files, err = filepath.Glob(filepath.Join(path, eventplatform.EventTypeNetworkPath, "*"+spoolFileExtension))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestDiskSpoolEvictsOldest(t *testing.T) {
+	spool, _ := newTestDiskSpool(t, 20)
+
+	require.NoError(t, spool.store([]byte("event1")))
+	require.NoError(t, spool.store([]byte("event2")))
+	// there is no room left for a third event, the oldest events are removed
+	require.NoError(t, spool.store([]byte("event3")))
+	assert.LessOrEqual(t, spool.currentSizeInBytes, int64(20))
+
+	contents, err := spool.extractOldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("event3")}, contents)
+
+	assert.Error(t, spool.store(make([]byte, 20)))
+}
+
+func TestDiskSpoolReloadsExistingFiles(t *testing.T) {
+	spool, path := newTestDiskSpool(t, 1024)
+	require.NoError(t, spool.store([]byte("event1")))
+	spool.stopReplay()
+
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_storage.event_types", []string{eventplatform.EventTypeNetworkPath})
+	cfg.SetWithoutSource("event_platform_storage.path", path)
+	reloaded, err := newDiskSpool(cfg, eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+
+	require.NoError(t, reloaded.store([]byte("event2")))
+	contents, err := reloaded.extractOldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("event1")}, contents)
+	contents, err = reloaded.extractOldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("event2")}, contents)
+}
+
+func TestDiskSpoolReplay(t *testing.T) {
+	spool, _ := newTestDiskSpool(t, 1024)
+	require.NoError(t, spool.store([]byte("event1")))
+	require.NoError(t, spool.store([]byte("event2")))
+
+	in := make(chan *message.Message, 10)
+	spool.stop = make(chan struct{})
+	assert.True(t, spool.replay(in))
+	assert.True(t, spool.isEmpty())
+	require.Len(t, in, 2)
+	assert.Equal(t, []byte("event1"), (<-in).GetContent())
+	assert.Equal(t, []byte("event2"), (<-in).GetContent())
+
+	// nothing is sent while the pipeline is more than half full
+	require.NoError(t, spool.store([]byte("event3")))
+	for i := 0; i < 5; i++ {
+		in <- message.NewMessage(nil, nil, "", 0)
+	}
+	assert.True(t, spool.replay(in))
+	assert.False(t, spool.isEmpty())
+	assert.Len(t, in, 5)
+}
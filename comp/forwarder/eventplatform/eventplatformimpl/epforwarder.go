@@ -53,6 +53,10 @@ const (
 	eventTypeDBMHealth   = "dbm-health"
 )
 
+// eventTypeAdditionalEndpointsKey is the setting mapping event types to the
+// additional endpoints their events are sent to
+const eventTypeAdditionalEndpointsKey = "event_platform_additional_endpoints"
+
 func getPassthroughPipelines() []passthroughPipelineDesc {
 	var passthroughPipelineDescs = []passthroughPipelineDesc{
 		{
@@ -275,10 +279,20 @@ func (s *defaultEventPlatformForwarder) SendEventPlatformEvent(e *message.Messag
 	// Stream to console if debug mode is enabled
 	p.eventPlatformReceiver.HandleMessage(e, []byte{}, eventType)
 
+	if done, err := p.writeToFileSink(e, eventType); done || err != nil {
+		return err
+	}
+
 	select {
 	case p.in <- e:
 		return nil
 	default:
+		if p.spool != nil {
+			if err := p.spool.store(e.GetContent()); err != nil {
+				return fmt.Errorf("event platform forwarder pipeline channel is full for eventType=%s and the event cannot be stored on disk: %v", eventType, err)
+			}
+			return nil
+		}
 		return fmt.Errorf("event platform forwarder pipeline channel is full for eventType=%s. Channel capacity is %d. consider increasing batch_max_concurrent_send", eventType, cap(p.in))
 	}
 }
@@ -336,6 +350,10 @@ func (s *defaultEventPlatformForwarder) SendEventPlatformEventBlocking(e *messag
 	// Stream to console if debug mode is enabled
 	p.eventPlatformReceiver.HandleMessage(e, []byte{}, eventType)
 
+	if done, err := p.writeToFileSink(e, eventType); done || err != nil {
+		return err
+	}
+
 	p.in <- e
 	return nil
 }
@@ -393,6 +411,12 @@ type passthroughPipeline struct {
 	strategy              sender.Strategy
 	in                    chan *message.Message
 	eventPlatformReceiver eventplatformreceiver.Component
+	// spool stores the events on disk when in is full, it is nil when the
+	// events of the pipeline are not stored on disk
+	spool *diskSpool
+	// fileSink writes the events to files, it is nil when the events of the
+	// pipeline are not written to files
+	fileSink *fileSink
 }
 
 type passthroughPipelineDesc struct {
@@ -441,6 +465,10 @@ func newHTTPPassthroughPipeline(
 	if !endpoints.UseHTTP {
 		return nil, fmt.Errorf("endpoints must be http")
 	}
+	// the endpoints specific to the event type get their own destination, and
+	// so their own retries, like the additional endpoints of the prefix
+	eventTypeEndpoints := config.BuildEventTypeAdditionalEndpoints(coreConfig, eventTypeAdditionalEndpointsKey, desc.eventType, endpoints.Main, desc.intakeTrackType, config.DefaultIntakeProtocol, config.DefaultIntakeOrigin)
+	endpoints.Endpoints = append(endpoints.Endpoints, eventTypeEndpoints...)
 	// epforwarder pipelines apply their own defaults on top of the hardcoded logs defaults
 	if endpoints.BatchMaxConcurrentSend <= 0 {
 		endpoints.BatchMaxConcurrentSend = desc.defaultBatchMaxConcurrentSend
@@ -454,6 +482,8 @@ func newHTTPPassthroughPipeline(
 	if endpoints.InputChanSize <= pkgconfigsetup.DefaultInputChanSize {
 		endpoints.InputChanSize = desc.defaultInputChanSize
 	}
+	// the event types sharing a config prefix can still be batched differently
+	applyBatchingPolicy(coreConfig, desc.eventType, endpoints)
 
 	pipelineMonitor := metrics.NewNoopPipelineMonitor(strconv.Itoa(pipelineID))
 
@@ -502,21 +532,35 @@ func newHTTPPassthroughPipeline(
 		)
 	}
 
-	log.Debugf("Initialized event platform forwarder pipeline. eventType=%s mainHosts=%s additionalHosts=%s batch_max_concurrent_send=%d batch_max_content_size=%d batch_max_size=%d, input_chan_size=%d, compression_kind=%s, compression_level=%d",
+	log.Debugf("Initialized event platform forwarder pipeline. eventType=%s mainHosts=%s additionalHosts=%s batch_max_concurrent_send=%d batch_max_content_size=%d batch_max_size=%d batch_wait=%s, input_chan_size=%d, compression_kind=%s, compression_level=%d",
 		desc.eventType,
 		joinHosts(endpoints.GetReliableEndpoints()),
 		joinHosts(endpoints.GetUnReliableEndpoints()),
 		endpoints.BatchMaxConcurrentSend,
 		endpoints.BatchMaxContentSize,
 		endpoints.BatchMaxSize,
+		endpoints.BatchWait,
 		endpoints.InputChanSize,
 		endpoints.Main.CompressionKind,
 		endpoints.Main.CompressionLevel)
+	spool, err := newDiskSpool(coreConfig, desc.eventType)
+	if err != nil {
+		log.Errorf("Unable to store the events on disk for eventType=%s: %v", desc.eventType, err)
+	}
+	fileSink, err := newFileSink(coreConfig, desc.eventType)
+	if err != nil {
+		log.Errorf("Unable to write the events to files for eventType=%s: %v", desc.eventType, err)
+	} else if fileSink != nil {
+		log.Infof("Writing the events of eventType=%s to %s, send_to_intake=%t", desc.eventType, fileSink.path, fileSink.sendToIntake)
+	}
+
 	return &passthroughPipeline{
 		sender:                senderImpl,
 		strategy:              strategy,
 		in:                    inputChan,
 		eventPlatformReceiver: eventPlatformReceiver,
+		spool:                 spool,
+		fileSink:              fileSink,
 	}, nil
 }
 
@@ -524,16 +568,44 @@ func (p *passthroughPipeline) Start() {
 	if p.strategy != nil {
 		p.strategy.Start()
 		p.sender.Start()
+		if p.spool != nil {
+			p.spool.startReplay(p.in)
+		}
 	}
 }
 
 func (p *passthroughPipeline) Stop() {
+	if p.spool != nil {
+		p.spool.stopReplay()
+	}
+	if p.fileSink != nil {
+		p.fileSink.close()
+	}
 	if p.strategy != nil {
 		p.strategy.Stop()
 		p.sender.Stop()
 	}
 }
 
+// writeToFileSink writes e to the file sink of the pipeline, if any. It returns
+// true if e must not be sent to the intake as well.
+func (p *passthroughPipeline) writeToFileSink(e *message.Message, eventType string) (bool, error) {
+	if p.fileSink == nil {
+		return false, nil
+	}
+	err := p.fileSink.write(e.GetContent())
+	if p.fileSink.sendToIntake {
+		if err != nil {
+			log.Warnf("Unable to write an event to a file for eventType=%s: %v", eventType, err)
+		}
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("unable to write the event to a file for eventType=%s: %v", eventType, err)
+	}
+	return true, nil
+}
+
 func joinHosts(endpoints []config.Endpoint) string {
 	var additionalHosts []string
 	for _, e := range endpoints {
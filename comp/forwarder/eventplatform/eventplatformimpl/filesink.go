@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventplatformimpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	fileSinkExtension = ".ndjson"
+	fileSinkFormat    = "2006_01_02__15_04_05_"
+)
+
+// fileSink writes the events of a pipeline to NDJSON files, one event per
+// line, instead of or in addition to sending them to the intake. A new file is
+// started when the current one reaches maxFileSize bytes, and the oldest files
+// are removed to keep at most maxFiles files.
+type fileSink struct {
+	m            sync.Mutex
+	eventType    string
+	path         string
+	maxFileSize  int64
+	maxFiles     int
+	sendToIntake bool
+	files        []string
+	// current is the file the events are appended to, it is the last of files
+	current     *os.File
+	currentSize int64
+}
+
+// newFileSink returns the file sink of eventType, or nil if the events of
+// eventType are not written to files.
+func newFileSink(config model.Reader, eventType string) (*fileSink, error) {
+	if !slices.Contains(config.GetStringSlice("event_platform_file_sink.event_types"), eventType) {
+		return nil, nil
+	}
+
+	path := config.GetString("event_platform_file_sink.path")
+	if path == "" {
+		path = filepath.Join(config.GetString("run_path"), "event_platform_sink")
+	}
+	path = filepath.Join(path, eventType)
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, err
+	}
+
+	s := &fileSink{
+		eventType:    eventType,
+		path:         path,
+		maxFileSize:  config.GetInt64("event_platform_file_sink.max_file_size_in_bytes"),
+		maxFiles:     config.GetInt("event_platform_file_sink.max_files"),
+		sendToIntake: config.GetBool("event_platform_file_sink.send_to_intake"),
+	}
+	if s.maxFiles < 1 {
+		s.maxFiles = 1
+	}
+	if err := s.reloadExistingFiles(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// write appends the content of an event as a line of the current file. The
+// events which are not JSON, like the protobuf ones, are written as a JSON
+// string of their base64 encoding.
+func (s *fileSink) write(content []byte) error {
+	var line []byte
+	if json.Valid(content) {
+		// the events are written one per line
+		line = compactJSON(content)
+	} else {
+		var err error
+		if line, err = json.Marshal(content); err != nil {
+			return err
+		}
+	}
+	line = append(line, '\n')
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.current == nil || (s.currentSize > 0 && s.currentSize+int64(len(line)) > s.maxFileSize) {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.current.Write(line)
+	s.currentSize += int64(n)
+	return err
+}
+
+// close closes the current file.
+func (s *fileSink) close() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closeCurrent()
+}
+
+func (s *fileSink) rotate() error {
+	s.closeCurrent()
+	filename := time.Now().UTC().Format(fileSinkFormat)
+	file, err := os.CreateTemp(s.path, filename+"*"+fileSinkExtension)
+	if err != nil {
+		return err
+	}
+	s.current = file
+	s.currentSize = 0
+	s.files = append(s.files, file.Name())
+
+	for len(s.files) > s.maxFiles {
+		if err := os.Remove(s.files[0]); err != nil {
+			log.Warnf("Unable to remove %s: %v", s.files[0], err)
+		}
+		s.files = slices.Delete(s.files, 0, 1)
+	}
+	return nil
+}
+
+func (s *fileSink) closeCurrent() {
+	if s.current == nil {
+		return
+	}
+	if err := s.current.Close(); err != nil {
+		log.Warnf("Unable to close %s: %v", s.current.Name(), err)
+	}
+	s.current = nil
+}
+
+// reloadExistingFiles lists the files written before a restart, so that they
+// are removed in turn. The events are always appended to a new file.
+func (s *fileSink) reloadExistingFiles() error {
+	entries, err := os.ReadDir(s.path)
+	if err != nil {
+		return err
+	}
+	var files []os.FileInfo
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			log.Warnf("Can't get file info: %v", err)
+			continue
+		}
+		if info.Mode().IsRegular() && filepath.Ext(entry.Name()) == fileSinkExtension {
+			files = append(files, info)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, file := range files {
+		s.files = append(s.files, filepath.Join(s.path, file.Name()))
+	}
+	return nil
+}
+
+// compactJSON removes the insignificant spaces, and in particular the line
+// breaks, of a valid JSON content.
+func compactJSON(content []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, content); err != nil {
+		return slices.Clone(content)
+	}
+	return buf.Bytes()
+}
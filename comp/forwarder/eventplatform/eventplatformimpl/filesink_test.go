@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventplatformimpl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/comp/forwarder/eventplatform"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+func newTestFileSink(t *testing.T, maxFileSize int64, maxFiles int) (*fileSink, string) {
+	path := t.TempDir()
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_file_sink.event_types", []string{eventplatform.EventTypeNetworkPath})
+	cfg.SetWithoutSource("event_platform_file_sink.path", path)
+	cfg.SetWithoutSource("event_platform_file_sink.max_file_size_in_bytes", maxFileSize)
+	cfg.SetWithoutSource("event_platform_file_sink.max_files", maxFiles)
+
+	sink, err := newFileSink(cfg, eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+	require.NotNil(t, sink)
+	t.Cleanup(sink.close)
+	return sink, filepath.Join(path, eventplatform.EventTypeNetworkPath)
+}
+
+func readFileSink(t *testing.T, path string) []string {
+	files, err := filepath.Glob(filepath.Join(path, "*"+fileSinkExtension))
+	require.NoError(t, err)
+	var contents []string
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func TestNewFileSinkDisabled(t *testing.T) {
+	cfg := config.NewMock(t)
+	cfg.SetWithoutSource("event_platform_file_sink.path", t.TempDir())
+
+	sink, err := newFileSink(cfg, eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+}
+
+func TestFileSinkWrite(t *testing.T) {
+	sink, path := newTestFileSink(t, 1024, 5)
+
+	require.NoError(t, sink.write([]byte("{\n  \"a\": 1\n}")))
+	require.NoError(t, sink.write([]byte{0x0a, 0x01}))
+	sink.close()
+
+	assert.Equal(t, []string{"{\"a\":1}\n\"CgE=\"\n"}, readFileSink(t, path))
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	sink, path := newTestFileSink(t, 10, 2)
+
+	require.NoError(t, sink.write([]byte(`{"a":1}`)))
+	require.NoError(t, sink.write([]byte(`{"a":2}`)))
+	require.NoError(t, sink.write([]byte(`{"a":3}`)))
+	sink.close()
+
+	// the file of the first event was removed
+	assert.ElementsMatch(t, []string{"{\"a\":2}\n", "{\"a\":3}\n"}, readFileSink(t, path))
+}
+
+func TestPassthroughPipelineWriteToFileSink(t *testing.T) {
+	sink, path := newTestFileSink(t, 1024, 5)
+	p := &passthroughPipeline{
+		in:       make(chan *message.Message, 1),
+		fileSink: sink,
+	}
+
+	done, err := p.writeToFileSink(message.NewMessage([]byte(`{"a":1}`), nil, "", 0), eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	sink.sendToIntake = true
+	done, err = p.writeToFileSink(message.NewMessage([]byte(`{"a":2}`), nil, "", 0), eventplatform.EventTypeNetworkPath)
+	require.NoError(t, err)
+	assert.False(t, done)
+	sink.close()
+
+	assert.Equal(t, []string{"{\"a\":1}\n{\"a\":2}\n"}, readFileSink(t, path))
+}
@@ -81,6 +81,12 @@ func GlobalProcessingRules(coreConfig pkgconfigmodel.Reader) ([]*ProcessingRule,
 	if err != nil {
 		return nil, err
 	}
+	// the sensitive data scanner rules are applied after the other global rules
+	sdsRules, err := globalSDSRules(coreConfig)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, sdsRules...)
 	err = ValidateProcessingRules(rules)
 	if err != nil {
 		return nil, err
@@ -20,6 +20,11 @@ type LogsConfigKeys struct {
 	prefix       string
 	vectorPrefix string
 	config       pkgconfigmodel.Reader
+	// eventTypeEndpointsKey is the setting mapping event types to their
+	// additional endpoints. When set, the additional endpoints are the ones
+	// of eventType in it instead of the ones of the prefix.
+	eventTypeEndpointsKey string
+	eventType             string
 }
 
 // CompressionKind constants
@@ -179,6 +184,10 @@ func (l *LogsConfigKeys) connectionResetInterval() time.Duration {
 }
 
 func (l *LogsConfigKeys) getAdditionalEndpoints() ([]unmarshalEndpoint, string) {
+	if l.eventTypeEndpointsKey != "" {
+		return l.getEventTypeAdditionalEndpoints()
+	}
+
 	var endpoints []unmarshalEndpoint
 	configKey := l.getConfigKey("additional_endpoints")
 	err := structure.UnmarshalKey(l.getConfig(), configKey, &endpoints, structure.EnableStringUnmarshal, structure.EnableSquash)
@@ -188,6 +197,15 @@ func (l *LogsConfigKeys) getAdditionalEndpoints() ([]unmarshalEndpoint, string)
 	return endpoints, configKey
 }
 
+func (l *LogsConfigKeys) getEventTypeAdditionalEndpoints() ([]unmarshalEndpoint, string) {
+	var endpoints map[string][]unmarshalEndpoint
+	err := structure.UnmarshalKey(l.getConfig(), l.eventTypeEndpointsKey, &endpoints, structure.EnableStringUnmarshal, structure.EnableSquash)
+	if err != nil {
+		log.Warnf("Could not parse %s: %v", l.eventTypeEndpointsKey, err)
+	}
+	return endpoints[l.eventType], l.eventTypeEndpointsKey
+}
+
 func (l *LogsConfigKeys) expectedTagsDuration() time.Duration {
 	return l.getConfig().GetDuration(l.getConfigKey("expected_tags_duration"))
 }
@@ -161,6 +161,51 @@ func (suite *ConfigTestSuite) TestGlobalProcessingRulesShouldReturnRulesWithVali
 	suite.NotNil(rule.Regex)
 }
 
+func (suite *ConfigTestSuite) TestGlobalProcessingRulesWithSDSRules() {
+	suite.config.SetWithoutSource("logs_config.processing_rules", []map[string]interface{}{
+		{
+			"type":    "exclude_at_match",
+			"name":    "exclude_foo",
+			"pattern": "foo",
+		},
+	})
+	suite.config.SetWithoutSource("logs_config.sds.rules", `[{"name":"card","pattern":"\\d{16}","included_keywords":{"keywords":["Card"],"character_count":10},"match_action":{"type":"Partial_Redact","character_count":12},"tags":["sensitive:card"]}]`)
+
+	rules, err := GlobalProcessingRules(suite.config)
+	suite.Nil(err)
+	suite.Equal(2, len(rules))
+
+	rule := rules[1]
+	suite.Equal(SensitiveDataScanner, rule.Type)
+	suite.Equal("card", rule.Name)
+	suite.NotNil(rule.Regex)
+	suite.True(rule.Regex.MatchString("4111111111111111"))
+	suite.Equal([]string{"card"}, rule.SDS.IncludedKeywords.Keywords)
+	suite.Equal(SDSMatchAction{Type: SDSMatchActionPartialRedact, Direction: SDSPartialRedactLastCharacters, CharacterCount: 12}, rule.SDS.MatchAction)
+	suite.Equal([]string{"sensitive:card"}, rule.SDS.Tags)
+}
+
+func (suite *ConfigTestSuite) TestGlobalProcessingRulesWithInvalidSDSRules() {
+	for _, sdsRules := range []string{
+		`[{"pattern":"\\d{16}","match_action":{"type":"redact"}}]`,
+		`[{"name":"card","match_action":{"type":"redact"}}]`,
+		`[{"name":"card","pattern":"\\d{16}"}]`,
+		`[{"name":"card","pattern":"\\d{16}","match_action":{"type":"unknown"}}]`,
+		`[{"name":"card","pattern":"\\d{16}","match_action":{"type":"partial_redact"}}]`,
+		`[{"name":"card","pattern":"\\d{16}","included_keywords":{"keywords":["card"]},"match_action":{"type":"redact"}}]`,
+		`[{"name":"card","pattern":"(?=card)","match_action":{"type":"redact"}}]`,
+	} {
+		suite.config.SetWithoutSource("logs_config.sds.rules", sdsRules)
+		_, err := GlobalProcessingRules(suite.config)
+		suite.NotNil(err, sdsRules)
+	}
+}
+
+func (suite *ConfigTestSuite) TestProcessingRulesCannotDefineSDSRules() {
+	rules := []*ProcessingRule{{Type: SensitiveDataScanner, Name: "card", Pattern: "card"}}
+	suite.NotNil(ValidateProcessingRules(rules))
+}
+
 func (suite *ConfigTestSuite) TestTaggerWarmupDuration() {
 	// assert TaggerWarmupDuration is disabled by default
 	taggerWarmupDuration := TaggerWarmupDuration(suite.config)
@@ -337,6 +382,38 @@ func (suite *ConfigTestSuite) TestMultipleTCPEndpointsEnvVar() {
 	suite.compareEndpoints(expectedEndpoints, endpoints)
 }
 
+func (suite *ConfigTestSuite) TestEventTypeAdditionalEndpoints() {
+	suite.config.SetWithoutSource("event_platform_additional_endpoints", `{
+		"network-path": [
+			{"api_key": "456", "host": "additional.endpoint.1", "port": 1234},
+			{"api_key": "789", "host": "additional.endpoint.2", "port": 1234, "use_ssl": false}
+		],
+		"dbm-samples": [{"api_key": "abc", "host": "dbm.endpoint"}]
+	}`)
+	main := NewEndpoint("123", "api_key", "main.endpoint", 443, "", true)
+	main.UseCompression = true
+	main.CompressionKind = ZstdCompressionKind
+	main.CompressionLevel = ZstdCompressionLevel
+
+	endpoints := BuildEventTypeAdditionalEndpoints(suite.config, "event_platform_additional_endpoints", "network-path", main, "", "", "")
+	suite.Require().Len(endpoints, 2)
+	for idx, endpoint := range endpoints {
+		suite.Equal("event_platform_additional_endpoints", endpoint.configSettingPath)
+		suite.True(endpoint.isAdditionalEndpoint)
+		suite.Equal(idx, endpoint.additionalEndpointsIdx)
+		suite.True(endpoint.IsReliable())
+		suite.Equal(ZstdCompressionKind, endpoint.CompressionKind)
+	}
+	suite.Equal("additional.endpoint.1", endpoints[0].Host)
+	suite.Equal("456", endpoints[0].GetAPIKey())
+	suite.True(endpoints[0].UseSSL())
+	suite.Equal("additional.endpoint.2", endpoints[1].Host)
+	suite.Equal("789", endpoints[1].GetAPIKey())
+	suite.False(endpoints[1].UseSSL())
+
+	suite.Empty(BuildEventTypeAdditionalEndpoints(suite.config, "event_platform_additional_endpoints", "network-devices-netflow", main, "", "", ""))
+}
+
 func (suite *ConfigTestSuite) TestMultipleHttpEndpointsInConfig() {
 	suite.config.SetWithoutSource("api_key", "123")
 	suite.config.SetWithoutSource("logs_config.batch_wait", 1)
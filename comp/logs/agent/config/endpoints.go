@@ -234,6 +234,13 @@ func loadHTTPAdditionalEndpoints(main Endpoint, l *LogsConfigKeys, intakeTrackTy
 	return newEndpoints
 }
 
+// BuildEventTypeAdditionalEndpoints returns the additional endpoints of eventType in the setting configKey,
+// which maps event types to lists of endpoints, set up like the additional endpoints of main.
+func BuildEventTypeAdditionalEndpoints(coreConfig model.Reader, configKey string, eventType string, main Endpoint, intakeTrackType IntakeTrackType, intakeProtocol IntakeProtocol, intakeOrigin IntakeOrigin) []Endpoint {
+	l := &LogsConfigKeys{config: coreConfig, eventTypeEndpointsKey: configKey, eventType: eventType}
+	return loadHTTPAdditionalEndpoints(main, l, intakeTrackType, intakeProtocol, intakeOrigin)
+}
+
 // GetAPIKey returns the latest API Key for the Endpoint, including when the configuration gets updated at runtime
 func (e *Endpoint) GetAPIKey() string {
 	return e.apiKey.Load()
@@ -20,6 +20,8 @@ import (
 const (
 	TCPType           = "tcp"
 	UDPType           = "udp"
+	SocketType        = "socket"
+	PipeType          = "pipe"
 	FileType          = "file"
 	DockerType        = "docker"
 	ContainerdType    = "containerd"
@@ -45,7 +47,7 @@ type LogsConfig struct {
 
 	Port        int    // Network
 	IdleTimeout string `mapstructure:"idle_timeout" json:"idle_timeout" yaml:"idle_timeout"` // Network
-	Path        string // File, Journald
+	Path        string // File, Journald, Socket, Pipe
 
 	Encoding     string           `mapstructure:"encoding" json:"encoding" yaml:"encoding"`                   // File
 	ExcludePaths StringSliceField `mapstructure:"exclude_paths" json:"exclude_paths" yaml:"exclude_paths"`    // File
@@ -70,6 +72,10 @@ type LogsConfig struct {
 
 	ChannelPath string `mapstructure:"channel_path" json:"channel_path" yaml:"channel_path"` // Windows Event
 	Query       string // Windows Event
+	// ChannelQueries overrides Query for some of the channels matching ChannelPath, by channel
+	ChannelQueries map[string]string `mapstructure:"channel_queries" json:"channel_queries" yaml:"channel_queries"` // Windows Event
+	// RenderXML adds the full XML rendering of the events to the logs
+	RenderXML bool `mapstructure:"render_xml" json:"render_xml" yaml:"render_xml"` // Windows Event
 
 	// used as input only by the Channel tailer.
 	// could have been unidirectional but the tailer could not close it in this case.
@@ -114,6 +120,9 @@ type SourceAutoMultiLineOptions struct {
 	// EnableJSONDetection allows to enable or disable the detection of multi-line JSON logs for this source.
 	EnableJSONDetection *bool `mapstructure:"enable_json_detection" json:"enable_json_detection" yaml:"enable_json_detection"`
 
+	// EnableStackTraceDetection allows to enable or disable the detection of the lines of Java and Python stack traces for this source.
+	EnableStackTraceDetection *bool `mapstructure:"enable_stack_trace_detection" json:"enable_stack_trace_detection" yaml:"enable_stack_trace_detection"`
+
 	// EnableDatetimeDetection allows to enable or disable the detection of multi-lines based on leading datetime stamps for this source.
 	EnableDatetimeDetection *bool `mapstructure:"enable_datetime_detection" json:"enable_datetime_detection" yaml:"enable_datetime_detection"`
 
@@ -129,6 +138,9 @@ type SourceAutoMultiLineOptions struct {
 	// PatternTableMatchThreshold sets the threshold for pattern table match for this source.
 	PatternTableMatchThreshold *float64 `mapstructure:"pattern_table_match_threshold" json:"pattern_table_match_threshold" yaml:"pattern_table_match_threshold"`
 
+	// PinLearnedPattern allows to pin the most frequent pattern starting a multi-line log for this source once it is learned.
+	PinLearnedPattern *bool `mapstructure:"pin_learned_pattern" json:"pin_learned_pattern" yaml:"pin_learned_pattern"`
+
 	// EnableJSONAggregation allows to enable or disable the aggregation of multi-line JSON logs for this source.
 	EnableJSONAggregation *bool `mapstructure:"enable_json_aggregation" json:"enable_json_aggregation" yaml:"enable_json_aggregation"`
 
@@ -202,6 +214,8 @@ func (c *LogsConfig) Dump(multiline bool) string {
 	case UDPType:
 		fmt.Fprintf(&b, ws("Port: %d,"), c.Port)
 		fmt.Fprintf(&b, ws("IdleTimeout: %#v,"), c.IdleTimeout)
+	case SocketType, PipeType:
+		fmt.Fprintf(&b, ws("Path: %#v,"), c.Path)
 	case FileType:
 		fmt.Fprintf(&b, ws("Path: %#v,"), c.Path)
 		fmt.Fprintf(&b, ws("Encoding: %#v,"), c.Encoding)
@@ -224,6 +238,8 @@ func (c *LogsConfig) Dump(multiline bool) string {
 	case WindowsEventType:
 		fmt.Fprintf(&b, ws("ChannelPath: %#v,"), c.ChannelPath)
 		fmt.Fprintf(&b, ws("Query: %#v,"), c.Query)
+		fmt.Fprintf(&b, ws("ChannelQueries: %#v,"), c.ChannelQueries)
+		fmt.Fprintf(&b, ws("RenderXML: %t,"), c.RenderXML)
 	case StringChannelType:
 		fmt.Fprintf(&b, ws("Channel: %p,"), c.Channel)
 		c.ChannelTagsMutex.Lock()
@@ -352,6 +368,10 @@ func (c *LogsConfig) Validate() error {
 		return fmt.Errorf("tcp source must have a port")
 	case c.Type == UDPType && c.Port == 0:
 		return fmt.Errorf("udp source must have a port")
+	case c.Type == SocketType && c.Path == "":
+		return fmt.Errorf("socket source must have a path")
+	case c.Type == PipeType && c.Path == "":
+		return fmt.Errorf("pipe source must have a path")
 	}
 
 	// Validate fingerprint configuration
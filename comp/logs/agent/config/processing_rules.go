@@ -29,6 +29,9 @@ type ProcessingRule struct {
 	// TODO: should be moved out
 	Regex       *regexp.Regexp
 	Placeholder []byte
+	// SDS is the Sensitive Data Scanner rule of a SensitiveDataScanner rule,
+	// it can only be defined in logs_config.sds.rules
+	SDS *SDSRule `mapstructure:"-" json:"-" yaml:"-"`
 }
 
 // ValidateProcessingRules validates the rules and raises an error if one is misconfigured.
@@ -53,6 +56,14 @@ func ValidateProcessingRules(rules []*ProcessingRule) error {
 			}
 		case ExcludeTruncated:
 			break
+		case SensitiveDataScanner:
+			if rule.SDS == nil {
+				return fmt.Errorf("sensitive data scanner rules must be defined in logs_config.sds.rules: %s", rule.Name)
+			}
+			_, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %s for processing rule: %s", rule.Pattern, rule.Name)
+			}
 		case "":
 			return fmt.Errorf("type must be set for processing rule `%s`", rule.Name)
 		default:
@@ -73,7 +84,7 @@ func CompileProcessingRules(rules []*ProcessingRule) error {
 			return err
 		}
 		switch rule.Type {
-		case ExcludeAtMatch, IncludeAtMatch:
+		case ExcludeAtMatch, IncludeAtMatch, SensitiveDataScanner:
 			rule.Regex = re
 		case MaskSequences:
 			rule.Regex = re
@@ -0,0 +1,124 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/config/structure"
+)
+
+// SensitiveDataScanner is the type of the processing rules created from the
+// Sensitive Data Scanner rules defined in logs_config.sds.rules.
+const SensitiveDataScanner = "sensitive_data_scanner"
+
+// Sensitive Data Scanner match actions
+const (
+	SDSMatchActionNone          = "none"
+	SDSMatchActionRedact        = "redact"
+	SDSMatchActionPartialRedact = "partial_redact"
+	SDSMatchActionHash          = "hash"
+
+	SDSPartialRedactFirstCharacters = "first"
+	SDSPartialRedactLastCharacters  = "last"
+)
+
+// defaultSDSPlaceholder replaces the matches of the redact rules without placeholder.
+const defaultSDSPlaceholder = "[REDACTED]"
+
+// SDSRule is a Sensitive Data Scanner rule defined in the agent configuration,
+// following the schema of the rules of the scanning groups.
+type SDSRule struct {
+	Name             string
+	Pattern          string
+	IncludedKeywords SDSKeywords    `mapstructure:"included_keywords" json:"included_keywords" yaml:"included_keywords"`
+	MatchAction      SDSMatchAction `mapstructure:"match_action" json:"match_action" yaml:"match_action"`
+	Tags             []string
+}
+
+// SDSKeywords are the keywords, one of which must be found in the
+// CharacterCount characters preceding a match for it to be considered.
+type SDSKeywords struct {
+	Keywords       []string
+	CharacterCount int `mapstructure:"character_count" json:"character_count" yaml:"character_count"`
+}
+
+// SDSMatchAction is the action applied on the matches of a rule.
+type SDSMatchAction struct {
+	Type           string
+	Placeholder    string
+	Direction      string
+	CharacterCount int `mapstructure:"character_count" json:"character_count" yaml:"character_count"`
+}
+
+// globalSDSRules returns the processing rules created from the Sensitive Data
+// Scanner rules defined in logs_config.sds.rules.
+func globalSDSRules(coreConfig pkgconfigmodel.Reader) ([]*ProcessingRule, error) {
+	var sdsRules []*SDSRule
+	err := structure.UnmarshalKey(coreConfig, "logs_config.sds.rules", &sdsRules, structure.EnableStringUnmarshal)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*ProcessingRule, 0, len(sdsRules))
+	for _, sdsRule := range sdsRules {
+		if err := sdsRule.validate(); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &ProcessingRule{
+			Type:    SensitiveDataScanner,
+			Name:    sdsRule.Name,
+			Pattern: sdsRule.Pattern,
+			SDS:     sdsRule,
+		})
+	}
+	return rules, nil
+}
+
+// validate validates a Sensitive Data Scanner rule and sets the default values
+// of its match action.
+func (r *SDSRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("all sensitive data scanner rules must have a name")
+	}
+	if r.Pattern == "" {
+		return fmt.Errorf("no pattern provided for sensitive data scanner rule: %s", r.Name)
+	}
+	if len(r.IncludedKeywords.Keywords) > 0 && r.IncludedKeywords.CharacterCount <= 0 {
+		return fmt.Errorf("included keywords require a positive character count for sensitive data scanner rule: %s", r.Name)
+	}
+	for i, keyword := range r.IncludedKeywords.Keywords {
+		r.IncludedKeywords.Keywords[i] = strings.ToLower(keyword)
+	}
+
+	action := &r.MatchAction
+	action.Type = strings.ToLower(action.Type)
+	switch action.Type {
+	case SDSMatchActionNone, SDSMatchActionHash:
+	case SDSMatchActionRedact:
+		if action.Placeholder == "" {
+			action.Placeholder = defaultSDSPlaceholder
+		}
+	case SDSMatchActionPartialRedact:
+		if action.CharacterCount <= 0 {
+			return fmt.Errorf("partial_redact requires a positive character count for sensitive data scanner rule: %s", r.Name)
+		}
+		switch action.Direction {
+		case "":
+			action.Direction = SDSPartialRedactLastCharacters
+		case SDSPartialRedactFirstCharacters, SDSPartialRedactLastCharacters:
+		default:
+			return fmt.Errorf("direction %s is not supported for sensitive data scanner rule `%s`", action.Direction, r.Name)
+		}
+	case "":
+		return fmt.Errorf("match action must be set for sensitive data scanner rule `%s`", r.Name)
+	default:
+		return fmt.Errorf("match action %s is not supported for sensitive data scanner rule `%s`", action.Type, r.Name)
+	}
+	return nil
+}
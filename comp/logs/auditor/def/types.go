@@ -23,4 +23,8 @@ type Registry interface {
 	// SetOffset allows direct setting of an offset for an identifier, marking it as tailed.
 	// This enables tailers to persist bookmarks without sending messages through the pipeline.
 	SetOffset(identifier string, offset string)
+
+	// MigrateFingerprint sets the fingerprint of an identifier stored without a valid one, such as the entries
+	// written before the files were fingerprinted. Identifiers already having a valid fingerprint are left unchanged.
+	MigrateFingerprint(identifier string, fingerprint *types.Fingerprint)
 }
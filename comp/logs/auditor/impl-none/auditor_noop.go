@@ -63,6 +63,11 @@ func (a *NullAuditor) SetOffset(_ string, _ string) {
 	// No-op
 }
 
+// MigrateFingerprint does nothing in the null auditor
+func (a *NullAuditor) MigrateFingerprint(_ string, _ *types.Fingerprint) {
+	// No-op
+}
+
 // Start starts the NullAuditor main loop
 func (a *NullAuditor) Start() {
 	go a.run()
@@ -240,6 +240,20 @@ func (a *registryAuditor) SetOffset(identifier string, offset string) {
 	}
 }
 
+// MigrateFingerprint sets the fingerprint of an identifier stored without a valid one, so that the
+// entries written before the files were fingerprinted can detect the files reusing their inode.
+func (a *registryAuditor) MigrateFingerprint(identifier string, fingerprint *types.Fingerprint) {
+	if fingerprint == nil || !fingerprint.ValidFingerprint() {
+		return
+	}
+
+	a.registryMutex.Lock()
+	defer a.registryMutex.Unlock()
+	if entry, exists := a.registry[identifier]; exists && !entry.Fingerprint.ValidFingerprint() {
+		entry.Fingerprint = *fingerprint
+	}
+}
+
 // run keeps up to date the registry on different events
 func (a *registryAuditor) run() {
 	cleanUpTicker := time.NewTicker(defaultCleanupPeriod)
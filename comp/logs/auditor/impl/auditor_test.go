@@ -179,6 +179,35 @@ func (suite *AuditorTestSuite) TestAuditorRecoversRegistryForFingerprint() {
 	suite.Nil(fingerprint)
 }
 
+func (suite *AuditorTestSuite) TestAuditorMigratesFingerprint() {
+	fingerprintConfig := &types.FingerprintConfig{
+		FingerprintStrategy: types.FingerprintStrategyLineChecksum,
+		Count:               1,
+		MaxBytes:            1000,
+	}
+	fingerprint := &types.Fingerprint{Value: 12345, Config: fingerprintConfig}
+
+	suite.a.registry = make(map[string]*RegistryEntry)
+	suite.a.registry["file:/var/log/old.log"] = &RegistryEntry{Offset: "42"}
+	suite.a.registry["file:/var/log/fingerprinted.log"] = &RegistryEntry{
+		Offset:      "42",
+		Fingerprint: types.Fingerprint{Value: 6789, Config: fingerprintConfig},
+	}
+
+	suite.a.MigrateFingerprint("file:/var/log/old.log", fingerprint)
+	suite.a.MigrateFingerprint("file:/var/log/fingerprinted.log", fingerprint)
+	suite.a.MigrateFingerprint("file:/var/log/unknown.log", fingerprint)
+
+	suite.Equal(uint64(12345), suite.a.registry["file:/var/log/old.log"].Fingerprint.Value)
+	suite.Equal("42", suite.a.registry["file:/var/log/old.log"].Offset)
+	suite.Equal(uint64(6789), suite.a.registry["file:/var/log/fingerprinted.log"].Fingerprint.Value, "valid fingerprints are never replaced")
+	suite.NotContains(suite.a.registry, "file:/var/log/unknown.log")
+
+	suite.a.registry["file:/var/log/invalid.log"] = &RegistryEntry{Offset: "42"}
+	suite.a.MigrateFingerprint("file:/var/log/invalid.log", &types.Fingerprint{Value: types.InvalidFingerprintValue, Config: fingerprintConfig})
+	suite.False(suite.a.registry["file:/var/log/invalid.log"].Fingerprint.ValidFingerprint())
+}
+
 func (suite *AuditorTestSuite) TestAuditorCleansupRegistry() {
 	suite.a.registry = make(map[string]*RegistryEntry)
 	suite.a.registry[suite.source.Config.Path] = &RegistryEntry{
@@ -8,11 +8,14 @@ package auditorimpl
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 
 	auditor "github.com/DataDog/datadog-agent/comp/logs/auditor/def"
 )
 
-// atomicRegistryWriter implements atomic registry writing using a temporary file and rename
+// atomicRegistryWriter implements atomic registry writing using a temporary file and rename.
+// The temporary file and the registry directory are synced to disk so that the registry
+// is not lost or truncated if the host crashes right after it is written.
 type atomicRegistryWriter struct{}
 
 // NewAtomicRegistryWriter returns a new atomic registry writer
@@ -39,10 +42,30 @@ func (w *atomicRegistryWriter) WriteRegistry(registryPath string, registryDirPat
 	if err = f.Chmod(0644); err != nil {
 		return err
 	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
 	if err = f.Close(); err != nil {
 		return err
 	}
-	return os.Rename(tmpName, registryPath)
+	if err = os.Rename(tmpName, registryPath); err != nil {
+		return err
+	}
+	return syncDir(registryDirPath)
+}
+
+// syncDir syncs a directory to disk to persist the files renamed in it
+func syncDir(dirPath string) error {
+	if runtime.GOOS == "windows" {
+		// directories cannot be synced on Windows
+		return nil
+	}
+	d, err := os.Open(dirPath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
 }
 
 // nonAtomicRegistryWriter implements direct registry writing without atomic operations
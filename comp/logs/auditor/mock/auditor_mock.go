@@ -84,20 +84,22 @@ func (a *Auditor) run() {
 // Registry does nothing
 type Registry struct {
 	sync.Mutex
-	tailingMode       string
-	fingerprint       uint64
-	fingerprintConfig *types.FingerprintConfig
-	StoredOffsets     map[string]string
-	KeepAlives        map[string]bool
-	TailedSources     map[string]bool
+	tailingMode          string
+	fingerprint          uint64
+	fingerprintConfig    *types.FingerprintConfig
+	StoredOffsets        map[string]string
+	MigratedFingerprints map[string]*types.Fingerprint
+	KeepAlives           map[string]bool
+	TailedSources        map[string]bool
 }
 
 // NewMockRegistry returns a new mock registry.
 func NewMockRegistry() *Registry {
 	return &Registry{
-		StoredOffsets: make(map[string]string),
-		KeepAlives:    make(map[string]bool),
-		TailedSources: make(map[string]bool),
+		StoredOffsets:        make(map[string]string),
+		MigratedFingerprints: make(map[string]*types.Fingerprint),
+		KeepAlives:           make(map[string]bool),
+		TailedSources:        make(map[string]bool),
 	}
 }
 
@@ -154,6 +156,13 @@ func (r *Registry) SetFingerprint(fingerprint *types.Fingerprint) {
 	}
 }
 
+// MigrateFingerprint stores the migrated fingerprint of the identifier.
+func (r *Registry) MigrateFingerprint(identifier string, fingerprint *types.Fingerprint) {
+	r.Lock()
+	defer r.Unlock()
+	r.MigratedFingerprints[identifier] = fingerprint
+}
+
 // SetTailed stores the tailed status of the identifier.
 func (r *Registry) SetTailed(identifier string, isTailed bool) {
 	r.Lock()
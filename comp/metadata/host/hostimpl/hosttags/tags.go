@@ -18,6 +18,7 @@ import (
 	configUtils "github.com/DataDog/datadog-agent/pkg/config/utils"
 	gpu "github.com/DataDog/datadog-agent/pkg/gpu/tags"
 	"github.com/DataDog/datadog-agent/pkg/util/cache"
+	"github.com/DataDog/datadog-agent/pkg/util/cloudproviders/azure"
 	"github.com/DataDog/datadog-agent/pkg/util/cloudproviders/gce"
 	"github.com/DataDog/datadog-agent/pkg/util/docker"
 	ec2tags "github.com/DataDog/datadog-agent/pkg/util/ec2/tags"
@@ -61,6 +62,10 @@ func getProvidersDefinitions(conf model.Reader) map[string]*providerDef {
 		providers["ec2"] = &providerDef{10, ec2tags.GetTags}
 	}
 
+	if conf.GetBool("collect_azure_tags") {
+		providers["azure"] = &providerDef{1, azure.GetTags}
+	}
+
 	if conf.GetBool("collect_ec2_instance_info") {
 		providers["ec2_instance_info"] = &providerDef{3, ec2tags.GetInstanceInfo}
 	}
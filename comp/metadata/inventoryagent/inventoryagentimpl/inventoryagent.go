@@ -250,6 +250,12 @@ func (ia *inventoryagent) fetchCoreAgentMetadata() {
 
 	ia.data["fleet_policies_applied"] = ia.conf.GetStringSlice("fleet_layers")
 
+	deprecatedSettings := []string{}
+	for _, usage := range pkgconfigsetup.FindDeprecatedSettings(ia.conf) {
+		deprecatedSettings = append(deprecatedSettings, usage.Key)
+	}
+	ia.data["config_deprecated_settings"] = deprecatedSettings
+
 	// Synthetics
 	ia.data["feature_synthetics_collector_enabled"] = ia.conf.GetBool("synthetics.collector.enabled")
 
@@ -179,6 +179,7 @@ func TestInitData(t *testing.T) {
 		"sbom.container_image.enabled":                true,
 		"sbom.host.enabled":                           true,
 		"infrastructure_mode":                         "basic",
+		"log_enabled":                                 true,
 	}
 	ia := getTestInventoryPayload(t, overrides, sysprobeOverrides)
 	ia.refreshMetadata()
@@ -249,6 +250,7 @@ func TestInitData(t *testing.T) {
 		"system_probe_root_namespace_enabled":          true,
 		"system_probe_max_connections_per_message":     10,
 		"infrastructure_mode":                          "basic",
+		"config_deprecated_settings":                   []string{"log_enabled"},
 	}
 
 	if !kernel.IsIPv6Enabled() {
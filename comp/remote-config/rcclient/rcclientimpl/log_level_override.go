@@ -0,0 +1,129 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package rcclientimpl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	configcomp "github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/pkg/config/model"
+	pkglog "github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// logLevelExpiresAtKey is the setting holding the time at which the log
+	// level set through remote config is reverted, if it has a TTL
+	logLevelExpiresAtKey = "remote_configuration.log_level_expires_at"
+
+	// auditFileName is the file of the run path where the changes of the log
+	// level made through remote config are recorded
+	auditFileName = "remote_config_audit.log"
+	// auditFileMaxSize is the size above which the audit file is truncated
+	auditFileMaxSize = 1024 * 1024
+)
+
+// logLevelOverride reverts the log level set through remote config once its
+// TTL expires. It is shared by the copies of the rcClient.
+type logLevelOverride struct {
+	m     sync.Mutex
+	timer *time.Timer
+	now   func() time.Time
+}
+
+func newLogLevelOverride() *logLevelOverride {
+	return &logLevelOverride{now: time.Now}
+}
+
+// set records that the log level of cfg was set to level through remote
+// config. When ttl is positive, the level is reverted to the value of the
+// other sources once it expires, unless it was changed in the meantime.
+func (o *logLevelOverride) set(cfg configcomp.Component, level string, ttl time.Duration) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	o.stopLocked(cfg)
+	if ttl <= 0 {
+		auditLogLevel(cfg, o.now(), "log_level set to '%s' through remote config", level)
+		return
+	}
+
+	expiresAt := o.now().Add(ttl)
+	if cfg.IsKnown(logLevelExpiresAtKey) {
+		cfg.Set(logLevelExpiresAtKey, expiresAt.UTC().Format(time.RFC3339), model.SourceRC)
+	}
+	auditLogLevel(cfg, o.now(), "log_level set to '%s' through remote config until %s", level, expiresAt.UTC().Format(time.RFC3339))
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		o.m.Lock()
+		defer o.m.Unlock()
+		// the override was replaced or removed before expiring
+		if o.timer != timer {
+			return
+		}
+		o.stopLocked(cfg)
+		o.revertLocked(cfg, level)
+	})
+	o.timer = timer
+}
+
+// clear forgets the current override, after the log level set through remote
+// config was removed.
+func (o *logLevelOverride) clear(cfg configcomp.Component) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	o.stopLocked(cfg)
+	auditLogLevel(cfg, o.now(), "log_level override removed through remote config, falling back to '%s'", cfg.GetString("log_level"))
+}
+
+func (o *logLevelOverride) stopLocked(cfg configcomp.Component) {
+	if o.timer != nil {
+		o.timer.Stop()
+		o.timer = nil
+	}
+	if cfg.IsKnown(logLevelExpiresAtKey) {
+		cfg.UnsetForSource(logLevelExpiresAtKey, model.SourceRC)
+	}
+}
+
+func (o *logLevelOverride) revertLocked(cfg configcomp.Component, level string) {
+	if cfg.GetSource("log_level") != model.SourceRC || cfg.GetString("log_level") != level {
+		return
+	}
+	cfg.UnsetForSource("log_level", model.SourceRC)
+	pkglog.Infof("The log level '%s' set through remote config expired, falling back to '%s'", level, cfg.GetString("log_level"))
+	auditLogLevel(cfg, o.now(), "log_level override '%s' expired, falling back to '%s'", level, cfg.GetString("log_level"))
+}
+
+// auditLogLevel records a change of the log level made through remote config
+// in the audit file of the run path.
+func auditLogLevel(cfg configcomp.Component, now time.Time, format string, args ...interface{}) {
+	runPath := cfg.GetString("run_path")
+	if runPath == "" {
+		return
+	}
+	path := filepath.Join(runPath, auditFileName)
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if info, err := os.Stat(path); err == nil && info.Size() > auditFileMaxSize {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0640)
+	if err != nil {
+		pkglog.Debugf("Unable to open the remote config audit file %s: %v", path, err)
+		return
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "%s %s\n", now.UTC().Format(time.RFC3339), fmt.Sprintf(format, args...)); err != nil {
+		pkglog.Debugf("Unable to write to the remote config audit file %s: %v", path, err)
+	}
+}
@@ -60,6 +60,7 @@ type rcClient struct {
 	config            configcomp.Component
 	sysprobeConfig    option.Option[sysprobeconfig.Component]
 	isSystemProbe     bool
+	logLevelOverride  *logLevelOverride
 }
 
 type dependencies struct {
@@ -133,6 +134,7 @@ func newRemoteConfigClient(deps dependencies) (rcclient.Component, error) {
 		config:            deps.Config,
 		sysprobeConfig:    deps.SysprobeConfig,
 		isSystemProbe:     deps.Params.IsSystemProbe,
+		logLevelOverride:  newLogLevelOverride(),
 	}
 
 	if configUtils.IsRemoteConfigEnabled(deps.Config) {
@@ -344,11 +346,14 @@ func (rc rcClient) agentConfigUpdateCallback(updates map[string]state.RawConfig,
 		if len(mergedConfig.LogLevel) == 0 {
 			targetCmp.UnsetForSource("log_level", model.SourceRC)
 			pkglog.Infof("Removing remote-config log level override, falling back to '%s'", targetCmp.Get("log_level"))
+			rc.logLevelOverride.clear(targetCmp)
 		} else {
 			newLevel := mergedConfig.LogLevel
 			pkglog.Infof("Changing log level to '%s' through remote config", newLevel)
 			if err := rc.settingsComponent.SetRuntimeSetting("log_level", newLevel, model.SourceRC); err != nil {
 				errs = multierror.Append(errs, err)
+			} else {
+				rc.logLevelOverride.set(targetCmp, newLevel, time.Duration(mergedConfig.LogLevelTTL)*time.Second)
 			}
 		}
 
@@ -369,6 +374,8 @@ func (rc rcClient) agentConfigUpdateCallback(updates map[string]state.RawConfig,
 		pkglog.Infof("Changing log level to '%s' through remote config (new source)", mergedConfig.LogLevel)
 		if err := rc.settingsComponent.SetRuntimeSetting("log_level", mergedConfig.LogLevel, model.SourceRC); err != nil {
 			errs = multierror.Append(errs, err)
+		} else {
+			rc.logLevelOverride.set(targetCmp, mergedConfig.LogLevel, time.Duration(mergedConfig.LogLevelTTL)*time.Second)
 		}
 	}
 
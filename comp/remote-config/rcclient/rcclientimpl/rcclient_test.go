@@ -7,6 +7,8 @@ package rcclientimpl
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -235,6 +237,79 @@ func TestAgentConfigCallback(t *testing.T) {
 	assert.Equal(t, model.SourceCLI, cfg.GetSource("log_level"))
 }
 
+func TestAgentConfigCallbackLogLevelTTL(t *testing.T) {
+	pkglog.SetupLogger(pkglog.Default(), "info")
+	cfg := configmock.New(t)
+	runPath := t.TempDir()
+	cfg.SetWithoutSource("run_path", runPath)
+
+	var ipcComp ipc.Component
+
+	rc := fxutil.Test[rcclient.Component](t,
+		fx.Options(
+			Module(),
+			fx.Provide(func() log.Component { return logmock.New(t) }),
+			fx.Provide(func() config.Component { return cfg }),
+			sysprobeconfig.NoneModule(),
+			fx.Supply(
+				rcclient.Params{
+					AgentName:    "test-agent",
+					AgentVersion: "7.0.0",
+				},
+			),
+			fx.Supply(
+				settings.Params{
+					Settings: map[string]settings.RuntimeSetting{
+						"log_level": &mockLogLevelRuntimeSettings{cfg: cfg, logLevel: "info"},
+					},
+					Config: cfg,
+				},
+			),
+			settingsimpl.Module(),
+			fx.Provide(func() ipc.Component { return ipcmock.New(t) }),
+			fx.Populate(&ipcComp),
+		),
+	)
+
+	structRC := rc.(rcClient)
+
+	ipcAddress, err := pkgconfigsetup.GetIPCAddress(cfg)
+	assert.NoError(t, err)
+
+	structRC.client, _ = client.NewUnverifiedGRPCClient(
+		ipcAddress,
+		pkgconfigsetup.GetIPCPort(),
+		ipcComp.GetAuthToken(),
+		ipcComp.GetTLSClientConfig(),
+		client.WithAgent("test-agent", "9.99.9"),
+		client.WithProducts(state.ProductAgentConfig),
+		client.WithPollInterval(time.Hour),
+	)
+
+	layer := state.RawConfig{Config: []byte(`{"name": "layer1", "config": {"log_level": "debug", "log_level_ttl": 1}}`)}
+	configOrder := state.RawConfig{Config: []byte(`{"internal_order": ["layer1"]}`)}
+
+	structRC.agentConfigUpdateCallback(map[string]state.RawConfig{
+		"datadog/2/AGENT_CONFIG/layer1/configname":              layer,
+		"datadog/2/AGENT_CONFIG/configuration_order/configname": configOrder,
+	}, applyEmpty)
+	assert.Equal(t, "debug", cfg.Get("log_level"))
+	assert.Equal(t, model.SourceRC, cfg.GetSource("log_level"))
+	assert.NotEmpty(t, cfg.GetString(logLevelExpiresAtKey))
+
+	// the log level falls back to its previous value once the TTL expires
+	assert.Eventually(t, func() bool {
+		return cfg.GetSource("log_level") == model.SourceDefault
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "info", cfg.Get("log_level"))
+	assert.Empty(t, cfg.GetString(logLevelExpiresAtKey))
+
+	audit, err := os.ReadFile(filepath.Join(runPath, auditFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(audit), "log_level set to 'debug' through remote config until")
+	assert.Contains(t, string(audit), "log_level override 'debug' expired, falling back to 'info'")
+}
+
 func TestAgentMRFConfigCallback(t *testing.T) {
 	pkglog.SetupLogger(pkglog.Default(), "info")
 	cfg := configmock.New(t)
@@ -10,6 +10,7 @@ import (
 	"context"
 	"expvar"
 	"fmt"
+	"runtime"
 	"time"
 
 	cfgcomp "github.com/DataDog/datadog-agent/comp/core/config"
@@ -21,6 +22,7 @@ import (
 	remoteconfig "github.com/DataDog/datadog-agent/pkg/config/remote/service"
 	configUtils "github.com/DataDog/datadog-agent/pkg/config/utils"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+	"github.com/DataDog/datadog-agent/pkg/util/hostinfo"
 	"github.com/DataDog/datadog-agent/pkg/util/option"
 	"github.com/DataDog/datadog-agent/pkg/version"
 
@@ -30,11 +32,13 @@ import (
 var (
 	rcExpvars              = expvar.NewMap("remoteConfigStartup")
 	rcStartupFailureReason = expvar.String{}
+	rcEndpoint             = expvar.String{}
 )
 
 func init() {
 	rcExpvars.Init()
 	rcExpvars.Set("startupFailureReason", &rcStartupFailureReason)
+	rcExpvars.Set("endpoint", &rcEndpoint)
 }
 
 // Module conditionally provides the remote config service.
@@ -80,6 +84,7 @@ func newRemoteConfigService(deps dependencies) (rcservice.Component, error) {
 	}
 	apiKey = configUtils.SanitizeAPIKey(apiKey)
 	baseRawURL := configUtils.GetMainEndpoint(deps.Cfg, "https://config.", "remote_configuration.rc_dd_url")
+	rcEndpoint.Set(baseRawURL)
 	traceAgentEnv := configUtils.GetTraceAgentDefaultEnv(deps.Cfg)
 
 	options := []remoteconfig.Option{
@@ -88,6 +93,7 @@ func newRemoteConfigService(deps dependencies) (rcservice.Component, error) {
 		remoteconfig.WithConfigRootOverride(deps.Cfg.GetString("site"), deps.Cfg.GetString("remote_configuration.config_root")),
 		remoteconfig.WithDirectorRootOverride(deps.Cfg.GetString("site"), deps.Cfg.GetString("remote_configuration.director_root")),
 		remoteconfig.WithRcKey(deps.Cfg.GetString("remote_configuration.key")),
+		remoteconfig.WithTargetingFacts(getTargetingFacts(deps.Cfg)),
 	}
 	if deps.Params != nil {
 		options = append(options, deps.Params.Options...)
@@ -107,6 +113,10 @@ func newRemoteConfigService(deps dependencies) (rcservice.Component, error) {
 	if deps.Cfg.IsSet("remote_configuration.clients.cache_bypass_limit") {
 		options = append(options, remoteconfig.WithClientCacheBypassLimit(deps.Cfg.GetInt("remote_configuration.clients.cache_bypass_limit"), "remote_configuration.clients.cache_bypass_limit"))
 	}
+	if bundlePath := deps.Cfg.GetString("remote_configuration.bundle_path"); bundlePath != "" {
+		options = append(options, remoteconfig.WithBundlePath(bundlePath))
+		rcEndpoint.Set(bundlePath)
+	}
 
 	configService, err := remoteconfig.NewService(
 		deps.Cfg,
@@ -142,6 +152,34 @@ func newRemoteConfigService(deps dependencies) (rcservice.Component, error) {
 	return configService, nil
 }
 
+// getTargetingFacts returns the facts advertised by the agent to evaluate the
+// agent predicates of the configs: the host metadata, overridden by the facts
+// set in remote_configuration.targeting_facts.
+func getTargetingFacts(config cfgcomp.Component) map[string]string {
+	facts := map[string]string{
+		"agent_version": version.AgentVersion,
+		"arch":          runtime.GOARCH,
+	}
+
+	info := hostinfo.GetInformation()
+	for key, value := range map[string]string{
+		"os":               info.OS,
+		"platform":         info.Platform,
+		"platform_family":  info.PlatformFamily,
+		"platform_version": info.PlatformVersion,
+		"kernel_version":   info.KernelVersion,
+	} {
+		if value != "" {
+			facts[key] = value
+		}
+	}
+
+	for key, value := range config.GetStringMapString("remote_configuration.targeting_facts") {
+		facts[key] = value
+	}
+	return facts
+}
+
 func getHostTags(config cfgcomp.Component) func() []string {
 	return func() []string {
 		// Host tags are cached on host, but we add a timeout to avoid blocking the RC request
@@ -219,6 +219,9 @@ type timestampContextResolver struct {
 
 	contextExpireTime int64
 	counterExpireTime int64
+	// distributionExpireTime is the expire time of the distribution contexts,
+	// defaulting to contextExpireTime when unset
+	distributionExpireTime int64
 }
 
 func newTimestampContextResolver(tagger tagger.Component, cache *tags.Store, id string, contextExpireTime, counterExpireTime int64) *timestampContextResolver {
@@ -254,6 +257,8 @@ func (cr *timestampContextResolver) expireContexts(timestamp int64) {
 		ttl := cr.contextExpireTime
 		if entry.context.mtype == metrics.CounterType {
 			ttl = cr.counterExpireTime
+		} else if entry.context.mtype == metrics.DistributionType && cr.distributionExpireTime > ttl {
+			ttl = cr.distributionExpireTime
 		}
 		if entry.lastSeen+ttl < timestamp {
 			cr.resolver.remove(ck)
@@ -38,6 +38,12 @@ type TimeSampler struct {
 	lastCutOffTime     int64
 	sketchMap          sketchMap
 
+	// distributions matching distributionPrefixes are aggregated in sketches
+	// over distributionInterval seconds rather than over interval seconds
+	distributionInterval  int64
+	distributionPrefixes  *utilstrings.Matcher
+	distributionSketchMap sketchMap
+
 	// id is a number to differentiate multiple time samplers
 	// since we start running more than one with the demultiplexer introduction
 	id       TimeSamplerID
@@ -55,22 +61,48 @@ func NewTimeSampler(id TimeSamplerID, interval int64, cache *tags.Store, tagger
 	idString := strconv.Itoa(int(id))
 	log.Infof("Creating TimeSampler #%s", idString)
 
+	distributionInterval, distributionPrefixes := distributionAggregationConfig(interval)
+
 	contextExpireTime := pkgconfigsetup.Datadog().GetInt64("dogstatsd_context_expiry_seconds")
 	counterExpireTime := contextExpireTime + pkgconfigsetup.Datadog().GetInt64("dogstatsd_expiry_seconds")
+	// the contexts of the aggregated distributions must be kept until their sketches are flushed
+	distributionExpireTime := contextExpireTime + distributionInterval
 
 	s := &TimeSampler{
-		interval:           interval,
-		contextResolver:    newTimestampContextResolver(tagger, cache, idString, contextExpireTime, counterExpireTime),
-		metricsByTimestamp: map[int64]metrics.ContextMetrics{},
-		sketchMap:          make(sketchMap),
-		id:                 id,
-		idString:           idString,
-		hostname:           hostname,
+		interval:              interval,
+		contextResolver:       newTimestampContextResolver(tagger, cache, idString, contextExpireTime, counterExpireTime),
+		metricsByTimestamp:    map[int64]metrics.ContextMetrics{},
+		sketchMap:             make(sketchMap),
+		distributionInterval:  distributionInterval,
+		distributionPrefixes:  distributionPrefixes,
+		distributionSketchMap: make(sketchMap),
+		id:                    id,
+		idString:              idString,
+		hostname:              hostname,
 	}
+	s.contextResolver.distributionExpireTime = distributionExpireTime
 
 	return s
 }
 
+// distributionAggregationConfig returns the interval over which the
+// distributions are aggregated and the prefixes of the metrics opting in, or
+// a nil matcher if the aggregation of the distributions is disabled.
+func distributionAggregationConfig(interval int64) (int64, *utilstrings.Matcher) {
+	distributionInterval := pkgconfigsetup.Datadog().GetInt64("dogstatsd_distribution_aggregation.interval")
+	prefixes := pkgconfigsetup.Datadog().GetStringSlice("dogstatsd_distribution_aggregation.metric_prefixes")
+	if distributionInterval == 0 || len(prefixes) == 0 {
+		return 0, nil
+	}
+	if distributionInterval <= interval || distributionInterval%interval != 0 {
+		log.Warnf("Ignoring dogstatsd_distribution_aggregation.interval %d: it must be a multiple of %d seconds greater than %d seconds", distributionInterval, interval, interval)
+		return 0, nil
+	}
+
+	matcher := utilstrings.NewMatcher(prefixes, true)
+	return distributionInterval, &matcher
+}
+
 func (s *TimeSampler) calculateBucketStart(timestamp float64) int64 {
 	return int64(timestamp) - int64(timestamp)%s.interval
 }
@@ -91,6 +123,11 @@ func (s *TimeSampler) sample(metricSample *metrics.MetricSample, timestamp float
 
 	switch metricSample.Mtype {
 	case metrics.DistributionType:
+		if s.distributionPrefixes.Test(metricSample.Name) {
+			distributionBucketStart := int64(timestamp) - int64(timestamp)%s.distributionInterval
+			s.distributionSketchMap.insert(distributionBucketStart, contextKey, metricSample.Value, metricSample.SampleRate)
+			break
+		}
 		s.sketchMap.insert(bucketStart, contextKey, metricSample.Value, metricSample.SampleRate)
 	default:
 		// If it's a new bucket, initialize it
@@ -106,7 +143,7 @@ func (s *TimeSampler) sample(metricSample *metrics.MetricSample, timestamp float
 	}
 }
 
-func (s *TimeSampler) newSketchSeries(ck ckey.ContextKey, points []metrics.SketchPoint) *metrics.SketchSeries {
+func (s *TimeSampler) newSketchSeries(ck ckey.ContextKey, points []metrics.SketchPoint, interval int64) *metrics.SketchSeries {
 	ctx, ok := s.contextResolver.get(ck)
 	if !ok {
 		return nil
@@ -116,7 +153,7 @@ func (s *TimeSampler) newSketchSeries(ck ckey.ContextKey, points []metrics.Sketc
 		Name:       ctx.Name,
 		Tags:       ctx.Tags(),
 		Host:       ctx.Host,
-		Interval:   s.interval,
+		Interval:   interval,
 		Points:     points,
 		ContextKey: ck,
 		Source:     ctx.source,
@@ -209,7 +246,15 @@ func (s *TimeSampler) dedupSerieBySerieSignature(
 	}
 }
 
-func (s *TimeSampler) flushSketches(cutoffTime int64, sketchesSink metrics.SketchesSink, forceFlushAll bool) {
+func (s *TimeSampler) flushSketches(timestamp float64, cutoffTime int64, sketchesSink metrics.SketchesSink, forceFlushAll bool) {
+	s.flushSketchMap(s.sketchMap, s.interval, cutoffTime, sketchesSink, forceFlushAll)
+	if s.distributionPrefixes != nil {
+		distributionCutoffTime := int64(timestamp) - int64(timestamp)%s.distributionInterval
+		s.flushSketchMap(s.distributionSketchMap, s.distributionInterval, distributionCutoffTime, sketchesSink, forceFlushAll)
+	}
+}
+
+func (s *TimeSampler) flushSketchMap(sketchMap sketchMap, interval int64, cutoffTime int64, sketchesSink metrics.SketchesSink, forceFlushAll bool) {
 	pointsByCtx := make(map[ckey.ContextKey][]metrics.SketchPoint)
 
 	flushAllBefore := cutoffTime
@@ -217,14 +262,14 @@ func (s *TimeSampler) flushSketches(cutoffTime int64, sketchesSink metrics.Sketc
 		flushAllBefore = math.MaxInt64
 	}
 
-	s.sketchMap.flushBefore(flushAllBefore, func(ck ckey.ContextKey, p metrics.SketchPoint) {
+	sketchMap.flushBefore(flushAllBefore, func(ck ckey.ContextKey, p metrics.SketchPoint) {
 		if p.Sketch == nil {
 			return
 		}
 		pointsByCtx[ck] = append(pointsByCtx[ck], p)
 	})
 	for ck, points := range pointsByCtx {
-		ss := s.newSketchSeries(ck, points)
+		ss := s.newSketchSeries(ck, points, interval)
 		if ss == nil {
 			log.Errorf("TimeSampler #%d Ignoring all metrics on context key '%v': inconsistent context resolver state: the context is not tracked", s.id, ck)
 			continue
@@ -238,7 +283,7 @@ func (s *TimeSampler) flush(timestamp float64, series metrics.SerieSink, sketche
 	cutoffTime := s.calculateBucketStart(timestamp)
 
 	s.flushSeries(cutoffTime, series, filterList, forceFlushAll)
-	s.flushSketches(cutoffTime, sketches, forceFlushAll)
+	s.flushSketches(timestamp, cutoffTime, sketches, forceFlushAll)
 	// expiring contexts
 	s.contextResolver.expireContexts(int64(timestamp))
 	s.lastCutOffTime = cutoffTime
@@ -18,6 +18,7 @@ import (
 	nooptagger "github.com/DataDog/datadog-agent/comp/core/tagger/impl-noop"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/ckey"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/internal/tags"
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
 	"github.com/DataDog/datadog-agent/pkg/metrics"
 	"github.com/DataDog/datadog-agent/pkg/tagset"
 	"github.com/DataDog/datadog-agent/pkg/util/quantile"
@@ -533,6 +534,68 @@ func testFlushMissingContext(t *testing.T, store *tags.Store) {
 func TestFlushMissingContext(t *testing.T) {
 	testWithTagsStore(t, testFlushMissingContext)
 }
+
+func testDistributionAggregation(t *testing.T, store *tags.Store) {
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("dogstatsd_distribution_aggregation.interval", 60)
+	mockConfig.SetWithoutSource("dogstatsd_distribution_aggregation.metric_prefixes", []string{"aggregated."})
+	sampler := testTimeSampler(store)
+
+	aggregated := metrics.MetricSample{
+		Name:       "aggregated.metric",
+		Value:      1,
+		Mtype:      metrics.DistributionType,
+		Tags:       []string{"a", "b"},
+		SampleRate: 1,
+	}
+	raw := metrics.MetricSample{
+		Name:       "raw.metric",
+		Value:      1,
+		Mtype:      metrics.DistributionType,
+		SampleRate: 1,
+	}
+	for _, ts := range []float64{10021, 10031, 10041} {
+		sampler.sample(&aggregated, ts)
+		sampler.sample(&raw, ts)
+	}
+
+	// the bucket of the aggregated distribution is still open
+	_, sketches := flushSerie(sampler, 10050, false)
+	require.Len(t, sketches, 1)
+	assert.Equal(t, "raw.metric", sketches[0].Name)
+	assert.Len(t, sketches[0].Points, 3)
+
+	// the contexts of the aggregated distributions are kept until they are flushed
+	sampler.contextResolver.expireContexts(10095)
+
+	expSketch := &quantile.Sketch{}
+	expSketch.Insert(quantile.Default(), 1, 1, 1)
+
+	_, sketches = flushSerie(sampler, 10100, false)
+	require.Len(t, sketches, 1)
+	metrics.AssertSketchSeriesEqual(t, &metrics.SketchSeries{
+		Name:     "aggregated.metric",
+		Tags:     tagset.CompositeTagsFromSlice([]string{"a", "b"}),
+		Interval: 60,
+		Points: []metrics.SketchPoint{
+			{Ts: 10020, Sketch: expSketch},
+		},
+		ContextKey: generateContextKey(&aggregated),
+	}, sketches[0])
+}
+func TestDistributionAggregation(t *testing.T) {
+	testWithTagsStore(t, testDistributionAggregation)
+}
+
+func TestDistributionAggregationInvalidInterval(t *testing.T) {
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("dogstatsd_distribution_aggregation.interval", 15)
+	mockConfig.SetWithoutSource("dogstatsd_distribution_aggregation.metric_prefixes", []string{"aggregated."})
+
+	interval, prefixes := distributionAggregationConfig(10)
+	assert.Zero(t, interval)
+	assert.Nil(t, prefixes)
+}
 func testFlushFilterList(t *testing.T, store *tags.Store) {
 	sampler := testTimeSampler(store)
 	sampler.sample(&metrics.MetricSample{
@@ -238,6 +238,8 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 	createHiddenStringFlag(cmd, &cliParams.profileMemoryVerbose, "m-verbose", "", "whether or not to include potentially noisy sources")
 	createHiddenBooleanFlag(cmd, &cliParams.generateIntegrationTraces, "m-trace", false, "send the integration traces")
 
+	cmd.AddCommand(makeConfigCommand(globalParamsGetter))
+
 	cmd.SetArgs([]string{"checkName"})
 
 	return cmd
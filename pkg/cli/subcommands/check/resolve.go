@@ -0,0 +1,313 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/comp/core"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/common/utils"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/configresolver"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/listeners"
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/providers"
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	log "github.com/DataDog/datadog-agent/comp/core/log/def"
+	secretsnoopfx "github.com/DataDog/datadog-agent/comp/core/secrets/fx-noop"
+	workloadfilter "github.com/DataDog/datadog-agent/comp/core/workloadfilter/def"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+	"github.com/DataDog/datadog-agent/pkg/util/scrubber"
+)
+
+// resolveParams are the command-line arguments of the `check config resolve`
+// subcommand.
+type resolveParams struct {
+	templateFile    string
+	annotationsFile string
+	checkName       string
+	containerName   string
+	serviceID       string
+	hostname        string
+	pid             int
+	hosts           []string
+	ports           []string
+	extraConfig     []string
+	tags            []string
+}
+
+// makeConfigCommand returns the `check config` command, which groups the
+// commands to debug check configurations.
+func makeConfigCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Debug check configurations",
+	}
+
+	params := &resolveParams{}
+	resolveCmd := &cobra.Command{
+		Use:   "resolve",
+		Short: "Print the check configurations resolved from an Autodiscovery template",
+		Long: `Resolve an Autodiscovery template, from a configuration file or from pod annotations, against
+the service described by the flags and print the resulting configurations, with the
+template variables substituted and the secrets scrubbed. Nothing is scheduled.
+
+Secrets using the ENC[] notation are not decrypted.`,
+		Args: cobra.NoArgs,
+		RunE: func(*cobra.Command, []string) error {
+			globalParams := globalParamsGetter()
+
+			return fxutil.OneShot(runResolve,
+				fx.Supply(params),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParams(globalParams.ConfFilePath, config.WithConfigName(globalParams.ConfigName), config.WithExtraConfFiles(globalParams.ExtraConfFilePaths), config.WithFleetPoliciesDirPath(globalParams.FleetPoliciesDirPath)),
+					LogParams:    log.ForOneShot(globalParams.LoggerName, "off", true),
+				}),
+				core.Bundle(),
+				secretsnoopfx.Module(),
+			)
+		},
+	}
+
+	resolveCmd.Flags().StringVarP(&params.templateFile, "file", "f", "", "configuration file holding the template")
+	resolveCmd.Flags().StringVar(&params.annotationsFile, "annotations", "", "YAML or JSON file holding the annotations of a pod")
+	resolveCmd.Flags().StringVar(&params.checkName, "check-name", "", "name of the check configured by --file (defaults to the name of the file)")
+	resolveCmd.Flags().StringVar(&params.containerName, "container", "", "name of the container the pod annotations are resolved for")
+	resolveCmd.Flags().StringVar(&params.serviceID, "service-id", "dry-run://service", "ID of the service")
+	resolveCmd.Flags().StringVar(&params.hostname, "hostname", "", "hostname of the service, for %%hostname%%")
+	resolveCmd.Flags().IntVar(&params.pid, "pid", 0, "PID of the service, for %%pid%%")
+	resolveCmd.Flags().StringArrayVar(&params.hosts, "host", nil, "network and IP of the service as <network>=<ip>, for %%host%%")
+	resolveCmd.Flags().StringArrayVar(&params.ports, "port", nil, "port of the service as <port> or <name>=<port>, for %%port%%")
+	resolveCmd.Flags().StringArrayVar(&params.extraConfig, "extra", nil, "extra configuration of the service as <key>=<value>, for %%extra_<key>%% and %%kube_<key>%%")
+	resolveCmd.Flags().StringArrayVar(&params.tags, "tag", nil, "tag of the service")
+
+	configCmd.AddCommand(resolveCmd)
+
+	return configCmd
+}
+
+// runResolve depends on the config component so that the settings used by
+// the resolution, like ad_allowed_env_vars, are loaded.
+func runResolve(_ config.Component, params *resolveParams) error {
+	svc, err := params.service()
+	if err != nil {
+		return err
+	}
+
+	templates, err := params.templates()
+	if err != nil {
+		return err
+	}
+
+	return resolveTemplates(color.Output, templates, svc)
+}
+
+// templates loads the templates to resolve.
+func (p *resolveParams) templates() ([]integration.Config, error) {
+	switch {
+	case p.templateFile != "" && p.annotationsFile != "":
+		return nil, errors.New("only one of --file and --annotations can be set")
+	case p.templateFile != "":
+		name := p.checkName
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(p.templateFile), filepath.Ext(p.templateFile))
+		}
+		tpl, _, err := providers.GetIntegrationConfigFromFile(name, p.templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the template from %s: %w", p.templateFile, err)
+		}
+		return []integration.Config{tpl}, nil
+	case p.annotationsFile != "":
+		if p.containerName == "" {
+			return nil, errors.New("--container is required with --annotations")
+		}
+		content, err := os.ReadFile(p.annotationsFile)
+		if err != nil {
+			return nil, err
+		}
+		var annotations map[string]string
+		if err := yaml.Unmarshal(content, &annotations); err != nil {
+			return nil, fmt.Errorf("could not parse the annotations from %s: %w", p.annotationsFile, err)
+		}
+		templates, errs := utils.ExtractTemplatesFromAnnotations(p.serviceID, annotations, p.containerName)
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		if len(templates) == 0 {
+			return nil, fmt.Errorf("no template found for %s in %s", p.containerName, p.annotationsFile)
+		}
+		return templates, nil
+	default:
+		return nil, errors.New("one of --file or --annotations is required")
+	}
+}
+
+// service builds the service the templates are resolved against.
+func (p *resolveParams) service() (*dryRunService, error) {
+	hosts, err := parseKeyValues("host", p.hosts)
+	if err != nil {
+		return nil, err
+	}
+	extraConfig, err := parseKeyValues("extra", p.extraConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []listeners.ContainerPort
+	for _, value := range p.ports {
+		name, rawPort, found := strings.Cut(value, "=")
+		if !found {
+			name, rawPort = "", value
+		}
+		port, err := strconv.Atoi(rawPort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --port %q: %w", value, err)
+		}
+		ports = append(ports, listeners.ContainerPort{Port: port, Name: name})
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Port < ports[j].Port
+	})
+
+	return &dryRunService{
+		serviceID:   p.serviceID,
+		hosts:       hosts,
+		ports:       ports,
+		pid:         p.pid,
+		hostname:    p.hostname,
+		extraConfig: extraConfig,
+		tags:        p.tags,
+	}, nil
+}
+
+func parseKeyValues(flag string, values []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(values))
+	for _, value := range values {
+		k, v, found := strings.Cut(value, "=")
+		if !found || k == "" {
+			return nil, fmt.Errorf("invalid --%s %q, expected <key>=<value>", flag, value)
+		}
+		parsed[k] = v
+	}
+	return parsed, nil
+}
+
+// resolveTemplates resolves the templates against the service and prints
+// the scrubbed configurations. It returns an error if any template could not
+// be resolved.
+func resolveTemplates(w io.Writer, templates []integration.Config, svc listeners.Service) error {
+	var errs []error
+	for _, tpl := range templates {
+		fmt.Fprintf(w, "=== %s check ===\n", color.GreenString(tpl.Name))
+		resolved, err := configresolver.Resolve(tpl, svc)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %s\n", color.RedString("Error"), err)
+			errs = append(errs, fmt.Errorf("could not resolve the %s template: %w", tpl.Name, err))
+			continue
+		}
+		scrubbed, err := scrubber.ScrubYamlString(resolved.String())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not scrub the %s configuration: %w", tpl.Name, err))
+			continue
+		}
+		fmt.Fprint(w, scrubbed)
+	}
+	return errors.Join(errs...)
+}
+
+// dryRunService is a service described on the command line.
+type dryRunService struct {
+	serviceID   string
+	hosts       map[string]string
+	ports       []listeners.ContainerPort
+	pid         int
+	hostname    string
+	extraConfig map[string]string
+	tags        []string
+}
+
+var _ listeners.Service = &dryRunService{}
+
+func (s *dryRunService) Equal(o listeners.Service) bool {
+	return s.GetServiceID() == o.GetServiceID()
+}
+
+func (s *dryRunService) GetServiceID() string {
+	return s.serviceID
+}
+
+func (s *dryRunService) GetADIdentifiers() []string {
+	return nil
+}
+
+func (s *dryRunService) GetHosts() (map[string]string, error) {
+	if len(s.hosts) == 0 {
+		return nil, errors.New("no host set, use --host")
+	}
+	return s.hosts, nil
+}
+
+func (s *dryRunService) GetPorts() ([]listeners.ContainerPort, error) {
+	if len(s.ports) == 0 {
+		return nil, errors.New("no port set, use --port")
+	}
+	return s.ports, nil
+}
+
+func (s *dryRunService) GetTags() ([]string, error) {
+	return s.tags, nil
+}
+
+func (s *dryRunService) GetTagsWithCardinality(_ string) ([]string, error) {
+	return s.GetTags()
+}
+
+func (s *dryRunService) GetPid() (int, error) {
+	if s.pid == 0 {
+		return -1, errors.New("no PID set, use --pid")
+	}
+	return s.pid, nil
+}
+
+func (s *dryRunService) GetHostname() (string, error) {
+	if s.hostname == "" {
+		return "", errors.New("no hostname set, use --hostname")
+	}
+	return s.hostname, nil
+}
+
+func (s *dryRunService) IsReady() bool {
+	return true
+}
+
+func (s *dryRunService) HasFilter(_ workloadfilter.Scope) bool {
+	return false
+}
+
+func (s *dryRunService) GetExtraConfig(key string) (string, error) {
+	value, found := s.extraConfig[key]
+	if !found {
+		return "", fmt.Errorf("no %q extra configuration set, use --extra", key)
+	}
+	return value, nil
+}
+
+func (s *dryRunService) GetImageName() string {
+	return ""
+}
+
+func (s *dryRunService) FilterTemplates(_ map[string]integration.Config) {}
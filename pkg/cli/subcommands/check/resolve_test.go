@@ -0,0 +1,132 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockconfig "github.com/DataDog/datadog-agent/pkg/config/mock"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestResolveCommand(t *testing.T) {
+	commands := []*cobra.Command{
+		MakeCommand(func() GlobalParams {
+			config := path.Join(t.TempDir(), "datadog.yaml")
+			err := os.WriteFile(config, []byte("hostname: test"), 0644)
+			require.NoError(t, err)
+
+			return GlobalParams{
+				ConfFilePath: config,
+			}
+		}),
+	}
+
+	fxutil.TestOneShotSubcommand(t,
+		commands,
+		[]string{"check", "config", "resolve", "--file", "redisdb.yaml", "--host", "pod=10.0.0.1", "--port", "6379", "--extra", "namespace=default"},
+		runResolve,
+		func(params *resolveParams) {
+			require.Equal(t, "redisdb.yaml", params.templateFile)
+			require.Equal(t, []string{"pod=10.0.0.1"}, params.hosts)
+			require.Equal(t, []string{"6379"}, params.ports)
+			require.Equal(t, []string{"namespace=default"}, params.extraConfig)
+		})
+}
+
+func TestResolveTemplateFile(t *testing.T) {
+	mockconfig.New(t)
+	t.Setenv("REDIS_PASSWORD", "s3cr3t")
+
+	file := filepath.Join(t.TempDir(), "redisdb.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`ad_identifiers:
+  - redis
+init_config:
+instances:
+  - host: "%%host%%"
+    port: "%%port%%"
+    namespace: "%%kube_namespace%%"
+    password: "%%env_REDIS_PASSWORD%%"
+`), 0644))
+
+	params := &resolveParams{
+		templateFile: file,
+		serviceID:    "docker://abcdef",
+		hosts:        []string{"bridge=10.0.0.1"},
+		ports:        []string{"metrics=9121", "6379"},
+		extraConfig:  []string{"namespace=default"},
+		tags:         []string{"env:test"},
+	}
+	templates, err := params.templates()
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "redisdb", templates[0].Name)
+
+	svc, err := params.service()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, resolveTemplates(&out, templates, svc))
+	assert.Contains(t, out.String(), "host: 10.0.0.1")
+	assert.Contains(t, out.String(), "port: 9121")
+	assert.Contains(t, out.String(), "namespace: default")
+	assert.Contains(t, out.String(), "- env:test")
+	assert.NotContains(t, out.String(), "s3cr3t")
+}
+
+func TestResolveAnnotations(t *testing.T) {
+	mockconfig.New(t)
+
+	file := filepath.Join(t.TempDir(), "annotations.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`ad.datadoghq.com/nginx.checks: |
+  {"nginx": {"instances": [{"nginx_status_url": "http://%%host%%:%%port%%/status"}]}}
+`), 0644))
+
+	params := &resolveParams{
+		annotationsFile: file,
+		containerName:   "nginx",
+		serviceID:       "containerd://abcdef",
+	}
+	templates, err := params.templates()
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	// No host is set, so the resolution fails.
+	svc, err := params.service()
+	require.NoError(t, err)
+	var out bytes.Buffer
+	assert.ErrorContains(t, resolveTemplates(&out, templates, svc), "could not resolve the nginx template")
+
+	params.hosts = []string{"pod=10.0.0.2"}
+	params.ports = []string{"80"}
+	svc, err = params.service()
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, resolveTemplates(&out, templates, svc))
+	assert.Contains(t, out.String(), "nginx_status_url: http://10.0.0.2:80/status")
+}
+
+func TestResolveParamsErrors(t *testing.T) {
+	_, err := (&resolveParams{}).templates()
+	assert.EqualError(t, err, "one of --file or --annotations is required")
+
+	_, err = (&resolveParams{annotationsFile: "annotations.yaml"}).templates()
+	assert.EqualError(t, err, "--container is required with --annotations")
+
+	_, err = (&resolveParams{hosts: []string{"10.0.0.1"}}).service()
+	assert.EqualError(t, err, `invalid --host "10.0.0.1", expected <key>=<value>`)
+
+	_, err = (&resolveParams{ports: []string{"http=eighty"}}).service()
+	assert.ErrorContains(t, err, `invalid --port "http=eighty"`)
+}
@@ -10,6 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"go.uber.org/fx"
 
@@ -20,8 +23,11 @@ import (
 	ipchttp "github.com/DataDog/datadog-agent/comp/core/ipc/httphelpers"
 	log "github.com/DataDog/datadog-agent/comp/core/log/def"
 	secretnoopfx "github.com/DataDog/datadog-agent/comp/core/secrets/fx-noop"
+	settingsComponent "github.com/DataDog/datadog-agent/comp/core/settings"
 	ddflareextensiontypes "github.com/DataDog/datadog-agent/comp/otelcol/ddflareextension/types"
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
 	"github.com/DataDog/datadog-agent/pkg/config/settings"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 
 	"github.com/spf13/cobra"
@@ -110,6 +116,42 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 	cmd.AddCommand(getCmd)
 	getCmd.Flags().BoolVarP(&cliParams.source, "source", "s", false, "print every source and its value")
 
+	sourceCmd := &cobra.Command{
+		Use:   "source [setting]",
+		Short: "Show the value of any configuration setting and the value set by each of its sources",
+		Long: `Show the final value of a configuration setting, the source it comes from, and the value set by every source
+that sets it (default, file, environment variable, remote config, runtime...), from the lowest priority to the highest.`,
+		RunE: oneShotRunE(getConfigValueSources),
+	}
+	cmd.AddCommand(sourceCmd)
+
+	diffCmd := &cobra.Command{
+		Use:   "diff [file]",
+		Short: "Print the settings of a running agent that differ from their default or from another configuration dump",
+		Long: `Without argument, print the settings of the running agent that are not set to their default value.
+
+With a file, compare the runtime configuration of the running agent with a configuration dump exported from another
+host, with 'config --all', or from a flare, like runtime_config_dump.yaml, and print the settings that differ.
+Secrets are redacted in the runtime configuration.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: oneShotRunE(diffRuntimeConfiguration),
+	}
+	cmd.AddCommand(diffCmd)
+
+	validateCmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a configuration file without a running agent",
+		Long: `Check an agent configuration file for unknown settings, values that do not match the type of their setting and
+invalid values for the settings that accept a fixed set of values. The format of the file, YAML, JSON or TOML, is
+detected from its extension. The command fails if any issue is found, so that it can be used in CI before deploying
+a configuration file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return validateConfigFile(os.Stdout, args[0], pkgconfigsetup.Datadog())
+		},
+	}
+	cmd.AddCommand(validateCmd)
+
 	otelCmd := &cobra.Command{
 		Use:   "otel-agent",
 		Short: "Otel-agent, prints out the read-only runtime configs of otel-agent if otel-agent is present and converter is enabled",
@@ -223,6 +265,91 @@ func getConfigValue(_ log.Component, client ipc.HTTPClient, cliParams *cliParams
 	return nil
 }
 
+func getConfigValueSources(_ log.Component, client ipc.HTTPClient, cliParams *cliParams) error {
+	if len(cliParams.args) != 1 {
+		return fmt.Errorf("a single setting name must be specified")
+	}
+
+	c, err := cliParams.SettingsBuilder(client)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Sources(cliParams.args[0])
+	if err != nil {
+		return err
+	}
+
+	printConfigValueSources(os.Stdout, resp)
+	return nil
+}
+
+func printConfigValueSources(w io.Writer, resp settingsComponent.SettingSourcesResponse) {
+	fmt.Fprintf(w, "%s is set to: %v (source: %s)\n", resp.Setting, resp.Value, resp.Source)
+	fmt.Fprintf(w, "override chain, from the lowest priority to the highest:\n")
+	for _, sourceValue := range resp.Sources {
+		marker := ""
+		if sourceValue.Source == resp.Source {
+			marker = " <- applied"
+		}
+		fmt.Fprintf(w, "  %s: %v%s\n", sourceValue.Source, sourceValue.Value, marker)
+	}
+}
+
+func diffRuntimeConfiguration(_ log.Component, client ipc.HTTPClient, cliParams *cliParams) error {
+	c, err := cliParams.SettingsBuilder(client)
+	if err != nil {
+		return err
+	}
+
+	if len(cliParams.args) == 0 {
+		runtimeConfig, err := c.FullConfigWithoutDefaults()
+		if err != nil {
+			return err
+		}
+		return printNonDefaultConfig(os.Stdout, []byte(runtimeConfig))
+	}
+
+	otherConfig, err := os.ReadFile(cliParams.args[0])
+	if err != nil {
+		return err
+	}
+	runtimeConfig, err := c.FullConfig()
+	if err != nil {
+		return err
+	}
+	return diffConfigs(os.Stdout, cliParams.args[0], otherConfig, "runtime configuration", []byte(runtimeConfig))
+}
+
+// validateConfigFile validates a configuration file against the settings known
+// by config and prints the issues found.
+func validateConfigFile(w io.Writer, path string, config pkgconfigmodel.Reader) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// the format is detected from the extension of the file, like the agent does
+	format := pkgconfigmodel.ConfigFileFormat(path)
+	issues, err := pkgconfigsetup.ValidateConfigFile(content, format, config)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid %s file: %w", path, strings.ToUpper(format), err)
+	}
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "%s is a valid %s configuration file\n", path, strings.ToUpper(format))
+		return nil
+	}
+
+	for _, issue := range issues {
+		if issue.Key == "" {
+			fmt.Fprintf(w, "%s:%d: %s\n", path, issue.Line, issue.Message)
+		} else {
+			fmt.Fprintf(w, "%s:%d: %s: %s\n", path, issue.Line, issue.Key, issue.Message)
+		}
+	}
+	return fmt.Errorf("found %d issues in %s", len(issues), path)
+}
+
 func otelAgentCfg(_ log.Component, config config.Component, client ipc.HTTPClient, _ *cliParams) error {
 	if !config.GetBool("otelcollector.enabled") {
 		return errors.New("otel-agent is not enabled")
@@ -6,12 +6,18 @@
 package config
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/comp/core"
+	settingsComponent "github.com/DataDog/datadog-agent/comp/core/settings"
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 )
 
@@ -78,3 +84,84 @@ func TestConfigGetCommand(t *testing.T) {
 			require.Equal(t, []string{"foo"}, cliParams.args)
 		})
 }
+
+func TestConfigSourceCommand(t *testing.T) {
+	commands := []*cobra.Command{
+		MakeCommand(func() GlobalParams {
+			return GlobalParams{}
+		}),
+	}
+
+	fxutil.TestOneShotSubcommand(t,
+		commands,
+		[]string{"config", "source", "foo"},
+		getConfigValueSources,
+		func(cliParams *cliParams, _ core.BundleParams) {
+			require.Equal(t, []string{"foo"}, cliParams.args)
+		})
+}
+
+func TestPrintConfigValueSources(t *testing.T) {
+	var b bytes.Buffer
+	printConfigValueSources(&b, settingsComponent.SettingSourcesResponse{
+		Setting: "log_level",
+		Value:   "debug",
+		Source:  "environment-variable",
+		Sources: []settingsComponent.SettingSourceValue{
+			{Source: "default", Value: "info"},
+			{Source: "file", Value: "warn"},
+			{Source: "environment-variable", Value: "debug"},
+		},
+	})
+
+	assert.Equal(t, `log_level is set to: debug (source: environment-variable)
+override chain, from the lowest priority to the highest:
+  default: info
+  file: warn
+  environment-variable: debug <- applied
+`, b.String())
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`api_key: abc
+log_level: verbose
+logs_enabled: yes please
+unknown_setting: true
+logs_config:
+  batch_wait: 5
+`), 0600))
+
+	var b bytes.Buffer
+	err := validateConfigFile(&b, path, configmock.New(t))
+	assert.EqualError(t, err, "found 3 issues in "+path)
+	assert.Equal(t, path+`:2: log_level: invalid value "verbose", expected one of: trace, debug, info, warn, warning, error, critical, off
+`+path+`:3: logs_enabled: expected a boolean, got "yes please"
+`+path+`:4: unknown_setting: unknown setting
+`, b.String())
+
+	require.NoError(t, os.WriteFile(path, []byte("api_key: abc\nlog_level: debug\n"), 0600))
+	b.Reset()
+	assert.NoError(t, validateConfigFile(&b, path, configmock.New(t)))
+	assert.Equal(t, path+" is a valid YAML configuration file\n", b.String())
+}
+
+func TestValidateConfigFileFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "datadog.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"api_key": "abc", "log_level": "debug"}`), 0600))
+	var b bytes.Buffer
+	assert.NoError(t, validateConfigFile(&b, jsonPath, configmock.New(t)))
+	assert.Equal(t, jsonPath+" is a valid JSON configuration file\n", b.String())
+
+	tomlPath := filepath.Join(dir, "datadog.toml")
+	require.NoError(t, os.WriteFile(tomlPath, []byte("api_key = \"abc\"\nunknown_setting = true\n"), 0600))
+	b.Reset()
+	assert.EqualError(t, validateConfigFile(&b, tomlPath, configmock.New(t)), "found 1 issues in "+tomlPath)
+	assert.Equal(t, tomlPath+":2: unknown_setting: unknown setting\n", b.String())
+
+	require.NoError(t, os.WriteFile(tomlPath, []byte("api_key: abc\n"), 0600))
+	b.Reset()
+	assert.ErrorContains(t, validateConfigFile(&b, tomlPath, configmock.New(t)), tomlPath+" is not a valid TOML file")
+}
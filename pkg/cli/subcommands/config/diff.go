@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// printNonDefaultConfig prints the settings of a configuration dump that only
+// contains the settings not set to their default value, one per line.
+func printNonDefaultConfig(w io.Writer, dump []byte) error {
+	settings, err := flattenConfigDump(dump)
+	if err != nil {
+		return fmt.Errorf("unable to parse the runtime configuration: %w", err)
+	}
+	if len(settings) == 0 {
+		fmt.Fprintln(w, "All settings are set to their default value")
+		return nil
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		fmt.Fprintf(w, "%s: %v\n", key, settings[key])
+	}
+	return nil
+}
+
+// diffConfigs prints the settings whose value differs between two configuration
+// dumps, as a unified diff from the first dump to the second one.
+func diffConfigs(w io.Writer, fromName string, fromDump []byte, toName string, toDump []byte) error {
+	from, err := flattenConfigDump(fromDump)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", fromName, err)
+	}
+	to, err := flattenConfigDump(toDump)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %w", toName, err)
+	}
+
+	keys := slices.Collect(maps.Keys(from))
+	for key := range to {
+		if _, found := from[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var lines []string
+	for _, key := range keys {
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		if inFrom && inTo && reflect.DeepEqual(fromValue, toValue) {
+			continue
+		}
+		if inFrom {
+			lines = append(lines, fmt.Sprintf("- %s: %v", key, fromValue))
+		}
+		if inTo {
+			lines = append(lines, fmt.Sprintf("+ %s: %v", key, toValue))
+		}
+	}
+
+	if len(lines) == 0 {
+		fmt.Fprintf(w, "No difference between %s and %s\n", fromName, toName)
+		return nil
+	}
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", fromName, toName)
+	fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return nil
+}
+
+// flattenConfigDump parses a YAML or JSON configuration dump into a map of
+// settings indexed by their dotted name. Lists are kept as values.
+func flattenConfigDump(dump []byte) (map[string]interface{}, error) {
+	var root map[interface{}]interface{}
+	if err := yaml.Unmarshal(dump, &root); err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]interface{})
+	flattenConfigSection("", root, settings)
+	return settings, nil
+}
+
+func flattenConfigSection(prefix string, section map[interface{}]interface{}, settings map[string]interface{}) {
+	for k, value := range section {
+		key := strings.ToLower(fmt.Sprint(k))
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if subSection, ok := value.(map[interface{}]interface{}); ok && len(subSection) > 0 {
+			flattenConfigSection(key, subSection, settings)
+			continue
+		}
+		settings[key] = value
+	}
+}
@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/core"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestConfigDiffCommand(t *testing.T) {
+	commands := []*cobra.Command{
+		MakeCommand(func() GlobalParams {
+			return GlobalParams{}
+		}),
+	}
+
+	fxutil.TestOneShotSubcommand(t,
+		commands,
+		[]string{"config", "diff", "runtime_config_dump.yaml"},
+		diffRuntimeConfiguration,
+		func(cliParams *cliParams, _ core.BundleParams) {
+			require.Equal(t, []string{"runtime_config_dump.yaml"}, cliParams.args)
+		})
+}
+
+func TestPrintNonDefaultConfig(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, printNonDefaultConfig(&b, []byte(`api_key: '***************************aaaaa'
+logs_config:
+  batch_wait: 10
+tags:
+- env:prod
+`)))
+	assert.Equal(t, `api_key: ***************************aaaaa
+logs_config.batch_wait: 10
+tags: [env:prod]
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, printNonDefaultConfig(&b, []byte("{}\n")))
+	assert.Equal(t, "All settings are set to their default value\n", b.String())
+}
+
+func TestDiffConfigs(t *testing.T) {
+	hostA := []byte(`api_key: '***************************aaaaa'
+log_level: info
+logs_config:
+  batch_wait: 5
+  use_http: true
+tags:
+- env:prod
+`)
+	hostB := []byte(`api_key: '***************************aaaaa'
+log_level: debug
+logs_config:
+  batch_wait: 5
+tags:
+- env:prod
+- team:agent
+hostname: host-b
+`)
+
+	var b bytes.Buffer
+	require.NoError(t, diffConfigs(&b, "host-a.yaml", hostA, "runtime configuration", hostB))
+	assert.Equal(t, `--- host-a.yaml
++++ runtime configuration
++ hostname: host-b
+- log_level: info
++ log_level: debug
+- logs_config.use_http: true
+- tags: [env:prod]
++ tags: [env:prod team:agent]
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, diffConfigs(&b, "host-a.yaml", hostA, "runtime configuration", hostA))
+	assert.Equal(t, "No difference between host-a.yaml and runtime configuration\n", b.String())
+
+	assert.Error(t, diffConfigs(&b, "host-a.yaml", []byte("- not a map"), "runtime configuration", hostA))
+}
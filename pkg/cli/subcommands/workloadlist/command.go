@@ -7,8 +7,11 @@
 package workloadlist
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	"go.uber.org/fx"
 
@@ -33,6 +36,10 @@ type cliParams struct {
 	GlobalParams
 
 	verboseList bool
+	watch       bool
+	kinds       []string
+	source      string
+	name        string
 }
 
 // GlobalParams contains the values of agent-global Cobra flags.
@@ -60,7 +67,16 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 
 			cliParams.GlobalParams = globalParams
 
-			return fxutil.OneShot(workloadList,
+			if !cliParams.watch && (len(cliParams.kinds) > 0 || cliParams.source != "" || cliParams.name != "") {
+				return errors.New("the --kind, --source and --name filters require --watch")
+			}
+
+			run := workloadList
+			if cliParams.watch {
+				run = workloadWatch
+			}
+
+			return fxutil.OneShot(run,
 				fx.Supply(cliParams),
 				fx.Supply(core.BundleParams{
 					ConfigParams: config.NewAgentParams(
@@ -78,6 +94,10 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 	}
 
 	workloadListCommand.Flags().BoolVarP(&cliParams.verboseList, "verbose", "v", false, "print out a full dump of the workload store")
+	workloadListCommand.Flags().BoolVarP(&cliParams.watch, "watch", "w", false, "stream the events of the workload store as they happen")
+	workloadListCommand.Flags().StringSliceVar(&cliParams.kinds, "kind", nil, "only stream the entities of these kinds (e.g. container,kubernetes_pod)")
+	workloadListCommand.Flags().StringVar(&cliParams.source, "source", "", "only stream the entities from this source (e.g. runtime)")
+	workloadListCommand.Flags().StringVar(&cliParams.name, "name", "", "only stream the entities whose name or ID matches this regex")
 
 	return workloadListCommand
 }
@@ -108,6 +128,65 @@ func workloadList(_ log.Component, client ipc.HTTPClient, cliParams *cliParams)
 	return nil
 }
 
+func workloadWatch(_ log.Component, client ipc.HTTPClient, cliParams *cliParams) error {
+	if flavor.GetFlavor() == flavor.ClusterAgent {
+		return errors.New("--watch is not supported by the cluster agent")
+	}
+
+	url, err := workloadStreamURL()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(workloadmeta.WorkloadStreamRequest{
+		Kinds:   cliParams.kinds,
+		Source:  cliParams.source,
+		Name:    cliParams.name,
+		Verbose: cliParams.verboseList,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The events are streamed as one JSON object per line, which can be split
+	// across chunks
+	var pending []byte
+	err = client.PostChunk(url, "application/json", bytes.NewBuffer(body), func(chunk []byte) {
+		pending = append(pending, chunk...)
+		for {
+			i := bytes.IndexByte(pending, '\n')
+			if i < 0 {
+				return
+			}
+			line := pending[:i]
+			pending = pending[i+1:]
+
+			var event workloadmeta.WorkloadStreamEvent
+			if err := json.Unmarshal(line, &event); err != nil {
+				fmt.Fprintf(color.Output, "The agent ran into an error while streaming the workload store: %s\n", string(line))
+				continue
+			}
+			event.Write(color.Output)
+		}
+	}, ipchttp.WithCloseConnection)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		fmt.Fprintf(color.Output, "Failed to query the agent (running?): %s\n", err)
+	}
+	return err
+}
+
+func workloadStreamURL() (string, error) {
+	ipcAddress, err := pkgconfigsetup.GetIPCAddress(pkgconfigsetup.Datadog())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://%v:%v/agent/workload-list/stream", ipcAddress, pkgconfigsetup.Datadog().GetInt("cmd_port")), nil
+}
+
 func workloadURL(verbose bool) (string, error) {
 	ipcAddress, err := pkgconfigsetup.GetIPCAddress(pkgconfigsetup.Datadog())
 	if err != nil {
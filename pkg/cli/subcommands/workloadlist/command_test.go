@@ -30,3 +30,22 @@ func TestCommand(t *testing.T) {
 			require.Equal(t, true, cliParams.verboseList)
 		})
 }
+
+func TestCommandWatch(t *testing.T) {
+	commands := []*cobra.Command{
+		MakeCommand(func() GlobalParams {
+			return GlobalParams{}
+		}),
+	}
+
+	fxutil.TestOneShotSubcommand(t,
+		commands,
+		[]string{"workload-list", "--watch", "--kind", "container,kubernetes_pod", "--source", "runtime", "--name", "^redis"},
+		workloadWatch,
+		func(cliParams *cliParams, _ core.BundleParams) {
+			require.True(t, cliParams.watch)
+			require.Equal(t, []string{"container", "kubernetes_pod"}, cliParams.kinds)
+			require.Equal(t, "runtime", cliParams.source)
+			require.Equal(t, "^redis", cliParams.name)
+		})
+}
@@ -209,7 +209,7 @@ func (m *Check) generateFlare() error {
 	}
 
 	// Create an instance of the flare struct
-	flarePath, err := m.flareComponent.CreateWithArgs(flareArgs, 0, nil, []byte{})
+	flarePath, err := m.flareComponent.CreateWithArgs(flareArgs, nil, 0, nil, []byte{})
 	if err != nil {
 		return fmt.Errorf("Failed to create flare: %w", err)
 	}
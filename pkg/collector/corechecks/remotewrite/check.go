@@ -0,0 +1,195 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package remotewrite implements the prometheus_remote_write check, which
+// accepts Prometheus remote-write requests and submits their samples as
+// metrics.
+package remotewrite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/comp/core/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/option"
+)
+
+const (
+	// CheckName is the name of the check
+	CheckName = "prometheus_remote_write"
+
+	defaultListenAddress  = "localhost:9201"
+	defaultPath           = "/api/v1/write"
+	defaultMaxRequestSize = 10 * 1024 * 1024
+)
+
+// Config holds the prometheus_remote_write check configuration
+type Config struct {
+	ListenAddress  string            `yaml:"listen_address"`
+	Path           string            `yaml:"path"`
+	MaxRequestSize int               `yaml:"max_request_size"`
+	Namespace      string            `yaml:"namespace"`
+	LabelsMapper   map[string]string `yaml:"labels_mapper"`
+	ExcludeLabels  []string          `yaml:"exclude_labels"`
+}
+
+// Parse parses the prometheus_remote_write check config and set default values
+func (c *Config) Parse(data []byte) error {
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return err
+	}
+
+	if c.ListenAddress == "" {
+		c.ListenAddress = defaultListenAddress
+	}
+	if c.Path == "" {
+		c.Path = defaultPath
+	}
+	if c.MaxRequestSize <= 0 {
+		c.MaxRequestSize = defaultMaxRequestSize
+	}
+	return nil
+}
+
+// Check listens for Prometheus remote-write requests and submits their samples
+type Check struct {
+	core.CheckBase
+	instance  *Config
+	converter *converter
+	server    *http.Server
+	stopCh    chan struct{}
+}
+
+// Configure parses the check configuration and initializes the prometheus_remote_write check
+func (c *Check) Configure(senderManager sender.SenderManager, _ uint64, config, initConfig integration.Data, source string) error {
+	err := c.CommonConfigure(senderManager, initConfig, config, source)
+	if err != nil {
+		return err
+	}
+
+	err = c.instance.Parse(config)
+	if err != nil {
+		return err
+	}
+
+	c.converter = newConverter(c.instance)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(c.instance.Path, c.handleWrite)
+	c.server = &http.Server{
+		Addr:              c.instance.ListenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return nil
+}
+
+// Run starts the prometheus_remote_write check
+func (c *Check) Run() error {
+	log.Infof("Starting long-running check %q", c.ID())
+	defer log.Infof("Shutting down long-running check %q", c.ID())
+
+	listener, err := net.Listen("tcp", c.instance.ListenAddress)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", c.instance.ListenAddress, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-c.stopCh:
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return c.server.Shutdown(ctx)
+	}
+}
+
+// handleWrite submits the samples of a remote-write request
+func (c *Check) handleWrite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	compressed, err := io.ReadAll(io.LimitReader(r.Body, int64(c.instance.MaxRequestSize)+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(compressed) > c.instance.MaxRequestSize {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	req, err := decodeWriteRequest(compressed)
+	if err != nil {
+		log.Debugf("Invalid remote-write request: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s, err := c.GetSender()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.converter.submit(s, req)
+	s.Commit()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeWriteRequest decodes a snappy compressed remote-write request
+func decodeWriteRequest(compressed []byte) (*prompb.WriteRequest, error) {
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress the request: %w", err)
+	}
+
+	var req prompb.WriteRequest
+	if err := req.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("unable to decode the request: %w", err)
+	}
+	return &req, nil
+}
+
+// Cancel stops the prometheus_remote_write check
+func (c *Check) Cancel() { close(c.stopCh) }
+
+// Interval returns 0, it makes prometheus_remote_write a long-running check
+func (c *Check) Interval() time.Duration { return 0 }
+
+// Factory returns a new check factory
+func Factory() option.Option[func() check.Check] {
+	return option.New(func() check.Check {
+		return core.NewLongRunningCheckWrapper(&Check{
+			CheckBase: core.NewCheckBase(CheckName),
+			instance:  &Config{},
+			stopCh:    make(chan struct{}),
+		})
+	})
+}
@@ -0,0 +1,85 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remotewrite
+
+import (
+	"math"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+)
+
+// metricNameLabel is the label holding the name of the metric of a series
+const metricNameLabel = "__name__"
+
+// converter converts the series of remote-write requests to metrics, mapping
+// their labels to tags.
+type converter struct {
+	namespace     string
+	labelsMapper  map[string]string
+	excludeLabels map[string]struct{}
+}
+
+func newConverter(config *Config) *converter {
+	namespace := config.Namespace
+	if namespace != "" {
+		namespace += "."
+	}
+
+	excludeLabels := make(map[string]struct{}, len(config.ExcludeLabels))
+	for _, label := range config.ExcludeLabels {
+		excludeLabels[label] = struct{}{}
+	}
+
+	return &converter{
+		namespace:     namespace,
+		labelsMapper:  config.LabelsMapper,
+		excludeLabels: excludeLabels,
+	}
+}
+
+// submit submits the samples of the series of a remote-write request as gauges
+// with their timestamp. Stale markers and series without name are skipped.
+func (c *converter) submit(s sender.Sender, req *prompb.WriteRequest) {
+	for _, series := range req.Timeseries {
+		name, tags := c.nameAndTags(series.Labels)
+		if name == "" {
+			continue
+		}
+
+		for _, sample := range series.Samples {
+			if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+				continue
+			}
+			// remote-write timestamps are in milliseconds
+			s.GaugeWithTimestamp(c.namespace+name, sample.Value, "", tags, float64(sample.Timestamp)/1000) //nolint:errcheck
+		}
+	}
+}
+
+// nameAndTags returns the name of the metric of a series and its labels
+// converted to tags.
+func (c *converter) nameAndTags(labels []prompb.Label) (string, []string) {
+	var name string
+	tags := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label.Name == metricNameLabel {
+			name = label.Value
+			continue
+		}
+		if _, excluded := c.excludeLabels[label.Name]; excluded {
+			continue
+		}
+
+		key := label.Name
+		if mapped, found := c.labelsMapper[key]; found {
+			key = mapped
+		}
+		tags = append(tags, key+":"+label.Value)
+	}
+	return name, tags
+}
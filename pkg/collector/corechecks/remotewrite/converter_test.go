@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package remotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+)
+
+func TestConverterSubmit(t *testing.T) {
+	c := newConverter(&Config{
+		Namespace:     "prom",
+		LabelsMapper:  map[string]string{"instance": "host_instance"},
+		ExcludeLabels: []string{"job"},
+	})
+
+	mockSender := mocksender.NewMockSender("prometheus_remote_write")
+	mockSender.On("GaugeWithTimestamp", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	c.submit(mockSender, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "http_requests_total"},
+					{Name: "instance", Value: "web-1"},
+					{Name: "job", Value: "web"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []prompb.Sample{
+					{Value: 42, Timestamp: 1700000000000},
+					{Value: math.NaN(), Timestamp: 1700000015000},
+					{Value: 43, Timestamp: 1700000030000},
+				},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "instance", Value: "web-1"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1700000000000}},
+			},
+		},
+	})
+
+	expectedTags := []string{"host_instance:web-1", "code:200"}
+	mockSender.AssertMetricWithTimestamp(t, "GaugeWithTimestamp", "prom.http_requests_total", 42, "", expectedTags, 1700000000)
+	mockSender.AssertMetricWithTimestamp(t, "GaugeWithTimestamp", "prom.http_requests_total", 43, "", expectedTags, 1700000030)
+	// excluded labels are dropped
+	mockSender.AssertCalled(t, "GaugeWithTimestamp", "prom.http_requests_total", 42.0, "", expectedTags, 1700000000.0)
+	mockSender.AssertNumberOfCalls(t, "GaugeWithTimestamp", 2)
+}
+
+func TestDecodeWriteRequest(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1700000000000}},
+			},
+		},
+	}
+	data, err := req.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := decodeWriteRequest(snappy.Encode(nil, data))
+	require.NoError(t, err)
+	assert.Equal(t, req.Timeseries, decoded.Timeseries)
+
+	_, err = decodeWriteRequest(data)
+	assert.Error(t, err)
+}
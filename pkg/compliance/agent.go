@@ -10,6 +10,7 @@ package compliance
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/binary"
 	"encoding/json"
 	"expvar"
@@ -19,6 +20,7 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -34,6 +36,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/compliance/types"
 	"github.com/DataDog/datadog-agent/pkg/compliance/utils"
 	"github.com/DataDog/datadog-agent/pkg/config/env"
+	"github.com/DataDog/datadog-agent/pkg/config/remote/client"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/security/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
@@ -93,6 +96,14 @@ type AgentOptions struct {
 	// SysProbeClient is the HTTP client to allow the execution of benchmarks
 	// from system-probe. see: cmd/system-probe/modules/compliance.go
 	SysProbeClient *http.Client
+
+	// CustomBenchmarksEnabled enables the reception of custom rego
+	// benchmarks through remote config.
+	CustomBenchmarksEnabled bool
+
+	// CustomBenchmarksPublicKeys lists the public keys trusted to sign custom
+	// benchmarks.
+	CustomBenchmarksPublicKeys []ed25519.PublicKey
 }
 
 // ConfigurationExporter is an enum type defining all configuration export
@@ -128,6 +139,10 @@ type Agent struct {
 	cancel context.CancelFunc
 
 	k8sManaged *string
+
+	rcClient           *client.Client
+	customBenchmarks   map[string]*Benchmark
+	customBenchmarksMu sync.RWMutex
 }
 
 func xccdfEnabled() bool {
@@ -232,6 +247,12 @@ func (a *Agent) Start() error {
 		}),
 	)
 
+	if a.opts.CustomBenchmarksEnabled {
+		if err := a.startCustomBenchmarksClient(); err != nil {
+			log.Errorf("could not subscribe to custom benchmarks: %v", err)
+		}
+	}
+
 	_, k8sResourceData := k8sconfig.LoadConfiguration(ctx, a.opts.HostRoot)
 	if k8sResourceData != nil && k8sResourceData.ManagedEnvironment != nil {
 		a.k8sManaged = &k8sResourceData.ManagedEnvironment.Name
@@ -295,6 +316,9 @@ func (a *Agent) Start() error {
 // Stop stops the compliance agent.
 func (a *Agent) Stop() {
 	log.Tracef("shutting down compliance agent")
+	if a.rcClient != nil {
+		a.rcClient.Close()
+	}
 	a.cancel()
 	select {
 	case <-time.After(20 * time.Second):
@@ -312,7 +336,7 @@ func (a *Agent) runRegoBenchmarks(ctx context.Context) {
 		log.Warnf("could not load rego benchmarks: %v", err)
 		return
 	}
-	if len(benchmarks) == 0 {
+	if len(benchmarks) == 0 && !a.opts.CustomBenchmarksEnabled {
 		log.Infof("no rego benchmark to run")
 		return
 	}
@@ -326,7 +350,7 @@ func (a *Agent) runRegoBenchmarks(ctx context.Context) {
 
 	log.Debugf("will be executing %d rego benchmarks every %s", len(benchmarks), checkInterval)
 	for runCount := uint64(0); ; runCount++ {
-		for _, benchmark := range benchmarks {
+		for _, benchmark := range slices.Concat(benchmarks, a.getCustomBenchmarks()) {
 			if sleepRandomJitter(ctx, checkInterval, runCount, a.opts.Hostname, benchmark.FrameworkID) {
 				return
 			}
@@ -337,12 +361,14 @@ func (a *Agent) runRegoBenchmarks(ctx context.Context) {
 				if err := ctx.Err(); err != nil {
 					return
 				}
+				var events []*CheckEvent
 				if err != nil {
-					a.reportCheckEvents(checkInterval, CheckEventFromError(RegoEvaluator, rule, benchmark, err))
+					events = []*CheckEvent{CheckEventFromError(RegoEvaluator, rule, benchmark, err)}
 				} else {
-					events := EvaluateRegoRule(ctx, inputs, benchmark, rule)
-					a.reportCheckEvents(checkInterval, events...)
+					events = EvaluateRegoRule(ctx, inputs, benchmark, rule)
 				}
+				tagCustomBenchmarkEvents(benchmark, events)
+				a.reportCheckEvents(checkInterval, events...)
 
 				if sleepAborted(ctx, throttler.C) {
 					resolver.Close()
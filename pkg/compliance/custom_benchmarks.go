@@ -0,0 +1,196 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package compliance
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/config/remote/client"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/remoteconfig/state"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/version"
+)
+
+const (
+	// customBenchmarkSource is the source of the benchmarks received through
+	// remote config.
+	customBenchmarkSource = "remote-config"
+
+	// customFrameworkTagPrefix is the prefix of the tag added to the events
+	// produced by custom benchmarks.
+	customFrameworkTagPrefix = "custom_framework:"
+
+	customBenchmarksRCPollInterval = 5 * time.Second
+)
+
+// CustomBenchmarkBundle is the signed envelope of a benchmark distributed
+// through remote config.
+type CustomBenchmarkBundle struct {
+	// Payload is the JSON encoded CustomBenchmarkPayload.
+	Payload []byte `json:"payload"`
+	// Signature is the ed25519 signature of the payload.
+	Signature []byte `json:"signature"`
+}
+
+// CustomBenchmarkPayload holds a benchmark definition along with the rego
+// modules of its rules, indexed by filename.
+type CustomBenchmarkPayload struct {
+	Benchmark *Benchmark        `json:"benchmark"`
+	Modules   map[string]string `json:"modules"`
+}
+
+// ParseCustomBenchmarksPublicKeys decodes the given base64 encoded ed25519
+// public keys.
+func ParseCustomBenchmarksPublicKeys(encodedKeys []string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(encodedKeys))
+	for _, encodedKey := range encodedKeys {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom benchmarks public key: %w", err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid custom benchmarks public key: bad key size %d", len(key))
+		}
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+	return keys, nil
+}
+
+// ParseCustomBenchmarkBundle checks the signature of the given bundle against
+// the trusted public keys and returns the benchmark it holds. Only rego
+// benchmarks are supported.
+func ParseCustomBenchmarkBundle(raw []byte, publicKeys []ed25519.PublicKey) (*Benchmark, error) {
+	var bundle CustomBenchmarkBundle
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		return nil, fmt.Errorf("could not parse custom benchmark bundle: %w", err)
+	}
+
+	verified := slices.ContainsFunc(publicKeys, func(key ed25519.PublicKey) bool {
+		return ed25519.Verify(key, bundle.Payload, bundle.Signature)
+	})
+	if !verified {
+		return nil, errors.New("custom benchmark bundle signature verification failed")
+	}
+
+	var payload CustomBenchmarkPayload
+	if err := json.Unmarshal(bundle.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("could not parse custom benchmark payload: %w", err)
+	}
+
+	benchmark := payload.Benchmark
+	if benchmark == nil {
+		return nil, errors.New("bad custom benchmark: missing benchmark definition")
+	}
+	if err := benchmark.Valid(); err != nil {
+		return nil, err
+	}
+	for _, rule := range benchmark.Rules {
+		if !rule.IsRego() {
+			return nil, fmt.Errorf("bad custom benchmark: rule %s is not a rego rule", rule.ID)
+		}
+	}
+
+	benchmark.Source = customBenchmarkSource
+	benchmark.sources = make(map[string][]byte, len(payload.Modules))
+	for name, module := range payload.Modules {
+		benchmark.sources[name] = []byte(module)
+	}
+	return benchmark, nil
+}
+
+// startCustomBenchmarksClient subscribes to the custom benchmarks distributed
+// through remote config.
+func (a *Agent) startCustomBenchmarksClient() error {
+	ipcAddress, err := pkgconfigsetup.GetIPCAddress(pkgconfigsetup.Datadog())
+	if err != nil {
+		return fmt.Errorf("failed to get ipc address: %w", err)
+	}
+
+	c, err := client.NewGRPCClient(ipcAddress, pkgconfigsetup.GetIPCPort(),
+		a.ipc.GetAuthToken(),
+		a.ipc.GetTLSClientConfig(),
+		client.WithAgent("security-agent", version.AgentVersion),
+		client.WithProducts(state.ProductComplianceCustom),
+		client.WithPollInterval(customBenchmarksRCPollInterval),
+		client.WithDirectorRootOverride(pkgconfigsetup.Datadog().GetString("site"), pkgconfigsetup.Datadog().GetString("remote_configuration.director_root")),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.Subscribe(state.ProductComplianceCustom, a.onCustomBenchmarksUpdate)
+	c.Start()
+	a.rcClient = c
+	return nil
+}
+
+func (a *Agent) onCustomBenchmarksUpdate(updates map[string]state.RawConfig, applyStateCallback func(string, state.ApplyStatus)) {
+	benchmarks := make(map[string]*Benchmark, len(updates))
+	for path, config := range updates {
+		benchmark, err := ParseCustomBenchmarkBundle(config.Config, a.opts.CustomBenchmarksPublicKeys)
+		if err != nil {
+			log.Errorf("could not load custom benchmark %s: %v", path, err)
+			applyStateCallback(path, state.ApplyStatus{State: state.ApplyStateError, Error: err.Error()})
+			continue
+		}
+
+		var rules []*Rule
+		for _, rule := range benchmark.Rules {
+			if a.opts.RuleFilter(rule) {
+				rules = append(rules, rule)
+			}
+		}
+		benchmark.Rules = rules
+
+		benchmarks[path] = benchmark
+		applyStateCallback(path, state.ApplyStatus{State: state.ApplyStateAcknowledged})
+	}
+
+	log.Infof("loaded %d custom benchmarks from remote config", len(benchmarks))
+	a.addBenchmarks(slices.Collect(maps.Values(benchmarks))...)
+
+	a.customBenchmarksMu.Lock()
+	a.customBenchmarks = benchmarks
+	a.customBenchmarksMu.Unlock()
+}
+
+// getCustomBenchmarks returns the custom benchmarks currently loaded, sorted
+// by their remote config path so that their run order is stable.
+func (a *Agent) getCustomBenchmarks() []*Benchmark {
+	a.customBenchmarksMu.RLock()
+	defer a.customBenchmarksMu.RUnlock()
+
+	paths := slices.Collect(maps.Keys(a.customBenchmarks))
+	sort.Strings(paths)
+
+	benchmarks := make([]*Benchmark, 0, len(paths))
+	for _, path := range paths {
+		if benchmark := a.customBenchmarks[path]; len(benchmark.Rules) > 0 {
+			benchmarks = append(benchmarks, benchmark)
+		}
+	}
+	return benchmarks
+}
+
+// tagCustomBenchmarkEvents marks the events produced by custom benchmarks
+// with their framework.
+func tagCustomBenchmarkEvents(benchmark *Benchmark, events []*CheckEvent) {
+	if benchmark.Source != customBenchmarkSource {
+		return
+	}
+	for _, event := range events {
+		event.Tags = append(event.Tags, customFrameworkTagPrefix+benchmark.FrameworkID)
+	}
+}
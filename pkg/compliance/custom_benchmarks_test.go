@@ -0,0 +1,116 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package compliance
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customBenchmarkRule = `package datadog
+
+findings[f] {
+	f := dd.passed_finding("my_resource_type", "my_resource_id", {})
+}
+`
+
+func newCustomBenchmarkBundle(t *testing.T, key ed25519.PrivateKey, payload CustomBenchmarkPayload) []byte {
+	rawPayload, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(CustomBenchmarkBundle{
+		Payload:   rawPayload,
+		Signature: ed25519.Sign(key, rawPayload),
+	})
+	require.NoError(t, err)
+	return raw
+}
+
+func newCustomBenchmarkPayload() CustomBenchmarkPayload {
+	return CustomBenchmarkPayload{
+		Benchmark: &Benchmark{
+			Name:        "My benchmark",
+			FrameworkID: "my-framework",
+			Version:     "1.0.0",
+			Rules: []*Rule{
+				{
+					ID:         "my-rule",
+					InputSpecs: []*InputSpec{{Constants: &InputSpecConstants{"foo": "bar"}}},
+				},
+			},
+		},
+		Modules: map[string]string{
+			"my-rule.rego": customBenchmarkRule,
+		},
+	}
+}
+
+func TestParseCustomBenchmarkBundle(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	keys, err := ParseCustomBenchmarksPublicKeys([]string{base64.StdEncoding.EncodeToString(pub)})
+	require.NoError(t, err)
+
+	benchmark, err := ParseCustomBenchmarkBundle(newCustomBenchmarkBundle(t, priv, newCustomBenchmarkPayload()), keys)
+	require.NoError(t, err)
+	assert.Equal(t, "my-framework", benchmark.FrameworkID)
+	assert.Equal(t, customBenchmarkSource, benchmark.Source)
+
+	module, err := benchmark.loadFile("my-rule.rego")
+	require.NoError(t, err)
+	assert.Equal(t, customBenchmarkRule, string(module))
+
+	_, err = benchmark.loadFile("unknown.rego")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestParseCustomBenchmarkBundleErrors(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	t.Run("untrusted signature", func(t *testing.T) {
+		_, err := ParseCustomBenchmarkBundle(newCustomBenchmarkBundle(t, otherPriv, newCustomBenchmarkPayload()), []ed25519.PublicKey{pub})
+		assert.ErrorContains(t, err, "signature verification failed")
+	})
+
+	t.Run("no trusted keys", func(t *testing.T) {
+		_, err := ParseCustomBenchmarkBundle(newCustomBenchmarkBundle(t, priv, newCustomBenchmarkPayload()), nil)
+		assert.ErrorContains(t, err, "signature verification failed")
+	})
+
+	t.Run("xccdf rule", func(t *testing.T) {
+		payload := newCustomBenchmarkPayload()
+		payload.Benchmark.Rules[0].InputSpecs = []*InputSpec{{XCCDF: &InputSpecXCCDF{Name: "foo.xml"}}}
+		_, err := ParseCustomBenchmarkBundle(newCustomBenchmarkBundle(t, priv, payload), []ed25519.PublicKey{pub})
+		assert.ErrorContains(t, err, "is not a rego rule")
+	})
+
+	t.Run("bad public key", func(t *testing.T) {
+		_, err := ParseCustomBenchmarksPublicKeys([]string{base64.StdEncoding.EncodeToString([]byte("short"))})
+		assert.Error(t, err)
+	})
+}
+
+func TestTagCustomBenchmarkEvents(t *testing.T) {
+	benchmark := &Benchmark{FrameworkID: "my-framework", Source: customBenchmarkSource}
+	events := []*CheckEvent{{RuleID: "my-rule"}}
+
+	tagCustomBenchmarkEvents(benchmark, events)
+	assert.Equal(t, []string{"custom_framework:my-framework"}, events[0].Tags)
+
+	builtinEvents := []*CheckEvent{{RuleID: "cis-rule"}}
+	tagCustomBenchmarkEvents(&Benchmark{FrameworkID: "cis-docker"}, builtinEvents)
+	assert.Empty(t, builtinEvents[0].Tags)
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"slices"
@@ -364,6 +365,9 @@ func NewResolvedInputs(resolvingContext ResolvingContext, resolved map[string]in
 // rules. Rules of a same Benchmark are typically run together.
 type Benchmark struct {
 	dirname string
+	// sources holds the in-memory files of the benchmark, used instead of
+	// dirname when the benchmark was not loaded from disk.
+	sources map[string][]byte
 
 	Name        string   `yaml:"name,omitempty" json:"name,omitempty"`
 	FrameworkID string   `yaml:"framework,omitempty" json:"framework,omitempty"`
@@ -485,6 +489,19 @@ func listBenchmarksFilenames(rootDir string, glob string) []string {
 	return paths
 }
 
+// loadFile reads one of the files associated with the benchmark, like the
+// rego modules of its rules.
+func (b *Benchmark) loadFile(filename string) ([]byte, error) {
+	if b.sources != nil {
+		source, ok := b.sources[filename]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		return source, nil
+	}
+	return loadFile(b.dirname, filename)
+}
+
 func loadFile(rootDir, filename string) ([]byte, error) {
 	path := filepath.Join(rootDir, filepath.Join("/", filename))
 	return os.ReadFile(path)
@@ -38,7 +38,7 @@ func EvaluateRegoRule(ctx context.Context, resolvedInputs ResolvedInputs, benchm
 
 	log.Debugf("running rego check for rule=%s", rule.ID)
 	log.Tracef("building rego modules for rule=%s", rule.ID)
-	modules, err := buildRegoModules(benchmark.dirname, rule)
+	modules, err := buildRegoModules(benchmark, rule)
 	if err != nil {
 		return wrapErr(fmt.Errorf("could not build rego modules: %w", err))
 	}
@@ -145,12 +145,12 @@ func newCheckEventFromRegoResult(data interface{}, rule *Rule, resolvedInputs Re
 	return event
 }
 
-func buildRegoModules(rootDir string, rule *Rule) (map[string]string, error) {
+func buildRegoModules(benchmark *Benchmark, rule *Rule) (map[string]string, error) {
 	modules := map[string]string{
 		"datadog_helpers.rego": regoHelpersSource,
 	}
 	ruleFilename := fmt.Sprintf("%s.rego", rule.ID)
-	ruleCode, err := loadFile(rootDir, ruleFilename)
+	ruleCode, err := benchmark.loadFile(ruleFilename)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
@@ -161,7 +161,7 @@ func buildRegoModules(rootDir string, rule *Rule) (map[string]string, error) {
 		if _, ok := modules[name]; ok {
 			continue
 		}
-		source, err := loadFile(rootDir, name)
+		source, err := benchmark.loadFile(name)
 		if err != nil {
 			return nil, err
 		}
@@ -123,16 +123,19 @@ func DiscoverComponentsFromEnv() ([]pkgconfigsetup.ConfigurationProviders, []pkg
 
 	isContainerEnv := env.IsFeaturePresent(env.Docker) ||
 		env.IsFeaturePresent(env.Containerd) ||
-		env.IsFeaturePresent(env.Podman) ||
-		env.IsFeaturePresent(env.ECSFargate)
+		env.IsFeaturePresent(env.Podman)
+	isECSFargateEnv := env.IsFeaturePresent(env.ECSFargate)
 	isKubeEnv := env.IsFeaturePresent(env.Kubernetes)
 
-	if isContainerEnv || isKubeEnv {
+	if isContainerEnv || isECSFargateEnv || isKubeEnv {
 		detectedProviders = append(detectedProviders, pkgconfigsetup.ConfigurationProviders{Name: names.KubeContainer})
 		log.Info("Adding KubeContainer provider from environment")
 	}
 
-	if isContainerEnv && !isKubeEnv {
+	if isECSFargateEnv && !isKubeEnv {
+		detectedListeners = append(detectedListeners, pkgconfigsetup.Listeners{Name: "ecs_fargate"})
+		log.Info("Adding ECS Fargate listener from environment")
+	} else if isContainerEnv && !isKubeEnv {
 		detectedListeners = append(detectedListeners, pkgconfigsetup.Listeners{Name: names.Container})
 		log.Info("Adding Container listener from environment")
 	}
@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package model
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Formats of the configuration files
+const (
+	ConfigFormatYAML = "yaml"
+	ConfigFormatJSON = "json"
+	ConfigFormatTOML = "toml"
+)
+
+// configFileExtensions are the extensions of the supported configuration
+// files, in the order in which they are looked up in the configuration paths.
+// This is the order used by Viper.
+var configFileExtensions = []string{"json", "toml", "yaml", "yml"}
+
+// ConfigFileExtensions returns the extensions of the supported configuration
+// files, in the order in which they are looked up in the configuration paths.
+func ConfigFileExtensions() []string {
+	return slices.Clone(configFileExtensions)
+}
+
+// HasConfigFileExtension returns whether path has the extension of a supported
+// configuration file.
+func HasConfigFileExtension(path string) bool {
+	return slices.Contains(configFileExtensions, strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")))
+}
+
+// ConfigFileFormat returns the format of a configuration file from its
+// extension. Files without a JSON or TOML extension are YAML files.
+func ConfigFileFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ConfigFormatJSON
+	case ".toml":
+		return ConfigFormatTOML
+	}
+	return ConfigFormatYAML
+}
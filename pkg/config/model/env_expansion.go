@@ -0,0 +1,203 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package model
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EnvVarExpansionSetting enables the expansion of the references to
+// environment variables in the values of the configuration files. The values
+// are expanded while a file is loaded, so the setting is read from the file
+// itself, or from the DD_CONFIG_ENV_VAR_EXPANSION environment variable.
+const EnvVarExpansionSetting = "config_env_var_expansion"
+
+// IsEnvVarExpansionEnabled returns whether the settings read from a
+// configuration file enable the expansion of the references to environment
+// variables in its values. The environment variable takes precedence over the
+// file, like for any other setting.
+func IsEnvVarExpansionEnabled(settings interface{}) bool {
+	if enabled, err := strconv.ParseBool(os.Getenv("DD_CONFIG_ENV_VAR_EXPANSION")); err == nil {
+		return enabled
+	}
+
+	var value interface{}
+	switch s := settings.(type) {
+	case map[string]interface{}:
+		value = s[EnvVarExpansionSetting]
+	case map[interface{}]interface{}:
+		value = s[EnvVarExpansionSetting]
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		enabled, _ := strconv.ParseBool(v)
+		return enabled
+	}
+	return false
+}
+
+// ExpandEnvVars replaces the references to environment variables in s, which
+// is a value of a configuration file:
+//   - ${VAR} is replaced by the value of VAR
+//   - ${VAR:-default} is replaced by the value of VAR, or default if VAR is not
+//     set or empty
+//   - $${ is replaced by a literal ${
+//
+// References to variables that are not set and have no default, that are not
+// closed or whose name is not a valid environment variable name are left
+// untouched.
+func ExpandEnvVars(s string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+
+		// $${ escapes the reference
+		if start > 0 && s[start-1] == '$' {
+			b.WriteString(s[:start-1])
+			b.WriteString("${")
+			s = s[start+2:]
+			continue
+		}
+
+		b.WriteString(s[:start])
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			b.WriteString(s[start:])
+			return b.String()
+		}
+		end += start
+
+		reference := s[start+2 : end]
+		if value, ok := lookupEnvReference(reference); ok {
+			b.WriteString(value)
+		} else {
+			b.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+}
+
+// lookupEnvReference returns the value of a ${...} reference, without its
+// delimiters, and false if it is not a valid reference or cannot be resolved.
+func lookupEnvReference(reference string) (string, bool) {
+	name, defaultValue, hasDefault := strings.Cut(reference, ":-")
+	if !isEnvVarName(name) {
+		return "", false
+	}
+
+	value, found := os.LookupEnv(name)
+	if hasDefault && (!found || value == "") {
+		return defaultValue, true
+	}
+	return value, found
+}
+
+func isEnvVarName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ExpandEnvVarsInValue returns value, parsed from a configuration file, with
+// the references to environment variables expanded by ExpandEnvVars in all the
+// strings it contains, including the elements of lists and maps. Only the
+// values are expanded, not the keys. It also returns whether any string was
+// changed, value is returned as is when none was.
+func ExpandEnvVarsInValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		expanded := ExpandEnvVars(v)
+		return expanded, expanded != v
+	case map[string]interface{}:
+		var result map[string]interface{}
+		for key, item := range v {
+			expanded, changed := ExpandEnvVarsInValue(item)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = maps.Clone(v)
+			}
+			result[key] = expanded
+		}
+		if result == nil {
+			return value, false
+		}
+		return result, true
+	case map[interface{}]interface{}:
+		var result map[interface{}]interface{}
+		for key, item := range v {
+			expanded, changed := ExpandEnvVarsInValue(item)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = maps.Clone(v)
+			}
+			result[key] = expanded
+		}
+		if result == nil {
+			return value, false
+		}
+		return result, true
+	case []interface{}:
+		var result []interface{}
+		for i, item := range v {
+			expanded, changed := ExpandEnvVarsInValue(item)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = slices.Clone(v)
+			}
+			result[i] = expanded
+		}
+		if result == nil {
+			return value, false
+		}
+		return result, true
+	case []string:
+		var result []string
+		for i, item := range v {
+			expanded := ExpandEnvVars(item)
+			if expanded == item {
+				continue
+			}
+			if result == nil {
+				result = slices.Clone(v)
+			}
+			result[i] = expanded
+		}
+		if result == nil {
+			return value, false
+		}
+		return result, true
+	}
+	return value, false
+}
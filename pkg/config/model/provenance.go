@@ -0,0 +1,31 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package model
+
+// SettingProvenance is the effective value of a setting and the source that
+// sets it
+type SettingProvenance struct {
+	Value  interface{} `json:"value" yaml:"value"`
+	Source Source      `json:"source" yaml:"source"`
+}
+
+// ConfigProvenance returns the effective value of the settings of config along
+// with the source that sets each of them, indexed by setting name. Settings
+// set to their default value are only included when includeDefaults is true.
+func ConfigProvenance(config Reader, includeDefaults bool) map[string]SettingProvenance {
+	provenance := make(map[string]SettingProvenance)
+	for _, key := range config.AllKeysLowercased() {
+		source := config.GetSource(key)
+		if !includeDefaults && (source == SourceDefault || source == SourceSchema) {
+			continue
+		}
+		provenance[key] = SettingProvenance{
+			Value:  config.Get(key),
+			Source: source,
+		}
+	}
+	return provenance
+}
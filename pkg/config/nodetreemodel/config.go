@@ -843,7 +843,7 @@ func (c *ntmConfig) MergeConfig(in io.Reader) error {
 	}
 
 	other := newInnerNode(nil)
-	if err = c.readConfigurationContent(other, model.SourceFile, content); err != nil {
+	if err = c.readConfigurationContent(other, model.SourceFile, content, c.configFormat()); err != nil {
 		return err
 	}
 
@@ -883,7 +883,7 @@ func (c *ntmConfig) MergeFleetPolicy(configPath string) error {
 	}
 
 	other := newInnerNode(nil)
-	if err = c.readConfigurationContent(other, model.SourceFleetPolicies, content); err != nil {
+	if err = c.readConfigurationContent(other, model.SourceFleetPolicies, content, model.ConfigFormatYAML); err != nil {
 		return err
 	}
 
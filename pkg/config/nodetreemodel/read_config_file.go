@@ -6,12 +6,14 @@
 package nodetreemodel
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/pelletier/go-toml"
 	"gopkg.in/yaml.v2"
 
 	"github.com/DataDog/datadog-agent/pkg/config/model"
@@ -21,15 +23,27 @@ import (
 func (c *ntmConfig) findConfigFile() {
 	if c.configFile == "" {
 		for _, path := range c.configPaths {
-			configFilePath := filepath.Join(path, c.configName+".yaml")
-			if _, err := os.Stat(configFilePath); err == nil {
-				c.configFile = configFilePath
-				return
+			for _, ext := range model.ConfigFileExtensions() {
+				configFilePath := filepath.Join(path, c.configName+"."+ext)
+				if _, err := os.Stat(configFilePath); err == nil {
+					c.configFile = configFilePath
+					return
+				}
 			}
 		}
 	}
 }
 
+// configFormat returns the format of the content given to ReadConfig and
+// MergeConfig: the configured type if any, otherwise the format of the
+// configuration file.
+func (c *ntmConfig) configFormat() string {
+	if c.configType != "" {
+		return strings.ToLower(c.configType)
+	}
+	return model.ConfigFileFormat(c.configFile)
+}
+
 // ReadInConfig resets the file tree and reads the configuration from the file system.
 func (c *ntmConfig) ReadInConfig() error {
 	if !c.isReady() && !c.allowDynamicSchema.Load() {
@@ -77,7 +91,7 @@ func (c *ntmConfig) ReadConfig(in io.Reader) error {
 	if err != nil {
 		return err
 	}
-	if err := c.readConfigurationContent(c.file, model.SourceFile, content); err != nil {
+	if err := c.readConfigurationContent(c.file, model.SourceFile, content, c.configFormat()); err != nil {
 		return err
 	}
 	return c.mergeAllLayers()
@@ -88,17 +102,35 @@ func (c *ntmConfig) readInConfig(filePath string) error {
 	if err != nil {
 		return model.NewConfigFileNotFoundError(err) // nolint: forbidigo // constructing proper error
 	}
-	return c.readConfigurationContent(c.file, model.SourceFile, content)
+	return c.readConfigurationContent(c.file, model.SourceFile, content, model.ConfigFileFormat(filePath))
 }
 
-func (c *ntmConfig) readConfigurationContent(target InnerNode, source model.Source, content []byte) error {
+func (c *ntmConfig) readConfigurationContent(target InnerNode, source model.Source, content []byte, format string) error {
 	var inData map[string]interface{}
 
-	if strictErr := yaml.UnmarshalStrict(content, &inData); strictErr != nil {
-		log.Errorf("warning reading config file: %v\n", strictErr)
-		if err := yaml.Unmarshal(content, &inData); err != nil {
+	switch format {
+	case model.ConfigFormatJSON:
+		if err := json.Unmarshal(content, &inData); err != nil {
+			return err
+		}
+	case model.ConfigFormatTOML:
+		tree, err := toml.LoadBytes(content)
+		if err != nil {
 			return err
 		}
+		inData = tree.ToMap()
+	default:
+		if strictErr := yaml.UnmarshalStrict(content, &inData); strictErr != nil {
+			log.Errorf("warning reading config file: %v\n", strictErr)
+			if err := yaml.Unmarshal(content, &inData); err != nil {
+				return err
+			}
+		}
+	}
+	if source == model.SourceFile && model.IsEnvVarExpansionEnabled(inData) {
+		if expanded, changed := model.ExpandEnvVarsInValue(inData); changed {
+			inData = expanded.(map[string]interface{})
+		}
 	}
 	c.warnings = append(c.warnings, loadYamlInto(target, source, inData, "", c.schema, c.allowDynamicSchema.Load())...)
 	return nil
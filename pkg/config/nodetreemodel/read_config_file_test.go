@@ -96,6 +96,70 @@ func TestReadSingleFile(t *testing.T) {
 	assert.Equal(t, "abc", cfg.GetString("network_devices.snmp_traps.namespace"))
 }
 
+func TestReadJSONAndTOMLFiles(t *testing.T) {
+	confJSON := `{"network_devices": {"snmp_traps": {"enabled": true, "port": 1234, "bind_host": "ok"}}}`
+	confTOML := `[network_devices.snmp_traps]
+enabled = true
+port = 1234
+bind_host = "ok"
+`
+	for name, content := range map[string]string{"datadog.json": confJSON, "datadog.toml": confTOML} {
+		t.Run(name, func(t *testing.T) {
+			confPath := writeTempFile(t, name, content)
+
+			cfg := NewNodeTreeConfig("datadog", "DD", nil)
+			cfg.AddConfigPath(filepath.Dir(confPath))
+			setupDefault(t, cfg)
+
+			err := cfg.ReadInConfig()
+			require.NoError(t, err)
+
+			assert.Equal(t, confPath, cfg.ConfigFileUsed())
+			assert.Equal(t, true, cfg.GetBool("network_devices.snmp_traps.enabled"))
+			assert.Equal(t, 1234, cfg.GetInt("network_devices.snmp_traps.port"))
+			assert.Equal(t, "ok", cfg.GetString("network_devices.snmp_traps.bind_host"))
+			assert.Equal(t, model.SourceFile, cfg.GetSource("network_devices.snmp_traps.port"))
+		})
+	}
+}
+
+func TestReadConfigExpandsEnvVars(t *testing.T) {
+	t.Setenv("TEST_EXPAND_BIND_HOST", "127.0.0.1")
+	confPath := writeTempFile(t, "datadog.yaml", `
+config_env_var_expansion: true
+network_devices:
+  snmp_traps:
+    bind_host: ${TEST_EXPAND_BIND_HOST}
+    namespace: ${TEST_EXPAND_NAMESPACE:-default}
+`)
+
+	cfg := NewNodeTreeConfig("datadog", "DD", nil)
+	cfg.SetConfigFile(confPath)
+	setupDefault(t, cfg)
+
+	require.NoError(t, cfg.ReadInConfig())
+
+	assert.Equal(t, "127.0.0.1", cfg.GetString("network_devices.snmp_traps.bind_host"))
+	assert.Equal(t, "default", cfg.GetString("network_devices.snmp_traps.namespace"))
+	assert.Equal(t, model.SourceFile, cfg.GetSource("network_devices.snmp_traps.namespace"))
+}
+
+func TestReadExtraFileOtherFormat(t *testing.T) {
+	confPath := writeTempFile(t, "datadog.yaml", confYaml)
+	confPath2 := writeTempFile(t, "security-agent.toml", "[network_devices.snmp_traps]\nport = 9876\n")
+
+	cfg := NewNodeTreeConfig("datadog", "DD", nil)
+	cfg.SetConfigFile(confPath)
+	cfg.AddExtraConfigPaths([]string{confPath2})
+	setupDefault(t, cfg)
+
+	err := cfg.ReadInConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, true, cfg.GetBool("network_devices.snmp_traps.enabled"))
+	assert.Equal(t, 9876, cfg.GetInt("network_devices.snmp_traps.port"))
+}
+
 func TestReadFilePathError(t *testing.T) {
 	cfg := NewNodeTreeConfig("datadog", "DD", nil)
 	cfg.SetConfigFile("does_not_exist.yaml")
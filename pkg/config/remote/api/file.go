@@ -0,0 +1,173 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	pbgo "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// BundleConfigsFile is the file of a bundle holding the serialized
+	// LatestConfigsResponse, as returned by the configurations endpoint.
+	BundleConfigsFile = "latest_configs.pb"
+	// BundleOrgDataFile is the file of a bundle holding the serialized
+	// OrgDataResponse, as returned by the org endpoint.
+	BundleOrgDataFile = "org_data.pb"
+
+	// maxBundleFileSize bounds the size of the files read from a bundle
+	maxBundleFileSize = 64 * 1024 * 1024
+)
+
+// FileClient fetches configurations from a local bundle, for hosts that can't
+// reach the Remote Config backend. The bundle is a directory or a tarball,
+// optionally gzipped, refreshed by out-of-band tooling. It holds the responses
+// of the backend, so the configurations go through the same TUF verification
+// as the ones fetched over HTTP.
+type FileClient struct {
+	bundlePath string
+}
+
+// NewFileClient returns a new configuration client reading the bundle at the given path
+func NewFileClient(bundlePath string) *FileClient {
+	return &FileClient{
+		bundlePath: bundlePath,
+	}
+}
+
+// Fetch remote configuration from the bundle
+func (c *FileClient) Fetch(_ context.Context, _ *pbgo.LatestConfigsRequest) (*pbgo.LatestConfigsResponse, error) {
+	log.Debugf("fetching configurations from bundle %s", c.bundlePath)
+	response := &pbgo.LatestConfigsResponse{}
+	if err := c.readBundleFile(BundleConfigsFile, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// FetchOrgData org data from the bundle
+func (c *FileClient) FetchOrgData(_ context.Context) (*pbgo.OrgDataResponse, error) {
+	response := &pbgo.OrgDataResponse{}
+	if err := c.readBundleFile(BundleOrgDataFile, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// FetchOrgStatus returns the org and key status. A bundle is only produced for
+// organizations with Remote Config enabled, so both are always reported as enabled.
+func (c *FileClient) FetchOrgStatus(_ context.Context) (*pbgo.OrgStatusResponse, error) {
+	return &pbgo.OrgStatusResponse{
+		Enabled:    true,
+		Authorized: true,
+	}, nil
+}
+
+// UpdatePARJWT is a no-op, the bundle doesn't require authentication
+func (c *FileClient) UpdatePARJWT(string) {}
+
+// UpdateAPIKey is a no-op, the bundle doesn't require authentication
+func (c *FileClient) UpdateAPIKey(string) {}
+
+// readBundleFile reads and decodes the given file of the bundle
+func (c *FileClient) readBundleFile(name string, m proto.Message) error {
+	data, err := c.bundleFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s from bundle %s: %w", name, c.bundlePath, err)
+	}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to decode %s from bundle %s: %w", name, c.bundlePath, err)
+	}
+	return nil
+}
+
+// bundleFile returns the content of the given file of the bundle
+func (c *FileClient) bundleFile(name string) ([]byte, error) {
+	info, err := os.Stat(c.bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return readLimited(filepath.Join(c.bundlePath, name))
+	}
+
+	f, err := os.Open(c.bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return tarballFile(f, name)
+}
+
+// tarballFile returns the content of the given file of a tarball, which is
+// decompressed first if gzipped
+func tarballFile(r io.Reader, name string) ([]byte, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var tr *tar.Reader
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		tr = tar.NewReader(gr)
+	} else {
+		tr = tar.NewReader(br)
+	}
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, os.ErrNotExist
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || path.Clean(header.Name) != name {
+			continue
+		}
+		if header.Size > maxBundleFileSize {
+			return nil, fmt.Errorf("file is larger than %d bytes", maxBundleFileSize)
+		}
+		return io.ReadAll(tr)
+	}
+}
+
+func readLimited(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxBundleFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBundleFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxBundleFileSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbgo "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+func testBundleFiles(t *testing.T) map[string][]byte {
+	configs, err := proto.Marshal(&pbgo.LatestConfigsResponse{
+		ConfigMetas: &pbgo.ConfigMetas{
+			Roots: []*pbgo.TopMeta{{Version: 1, Raw: []byte(`{"signed":{}}`)}},
+		},
+		TargetFiles: []*pbgo.File{{Path: "datadog/2/AGENT_CONFIG/config/config", Raw: []byte(`{}`)}},
+	})
+	require.NoError(t, err)
+	orgData, err := proto.Marshal(&pbgo.OrgDataResponse{Uuid: "org-uuid"})
+	require.NoError(t, err)
+
+	return map[string][]byte{
+		BundleConfigsFile: configs,
+		BundleOrgDataFile: orgData,
+	}
+}
+
+func writeTarball(t *testing.T, w io.Writer, files map[string][]byte) {
+	tw := tar.NewWriter(w)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./" + name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+}
+
+func assertBundle(t *testing.T, client *FileClient) {
+	configs, err := client.Fetch(context.Background(), &pbgo.LatestConfigsRequest{})
+	require.NoError(t, err)
+	require.Len(t, configs.ConfigMetas.Roots, 1)
+	assert.EqualValues(t, 1, configs.ConfigMetas.Roots[0].Version)
+	require.Len(t, configs.TargetFiles, 1)
+	assert.Equal(t, "datadog/2/AGENT_CONFIG/config/config", configs.TargetFiles[0].Path)
+
+	orgData, err := client.FetchOrgData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "org-uuid", orgData.Uuid)
+
+	orgStatus, err := client.FetchOrgStatus(context.Background())
+	require.NoError(t, err)
+	assert.True(t, orgStatus.Enabled)
+	assert.True(t, orgStatus.Authorized)
+}
+
+func TestFileClientDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range testBundleFiles(t) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0644))
+	}
+
+	assertBundle(t, NewFileClient(dir))
+}
+
+func TestFileClientTarball(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.tar")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	writeTarball(t, f, testBundleFiles(t))
+	require.NoError(t, f.Close())
+
+	assertBundle(t, NewFileClient(path))
+}
+
+func TestFileClientGzippedTarball(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	gw := gzip.NewWriter(f)
+	writeTarball(t, gw, testBundleFiles(t))
+	require.NoError(t, gw.Close())
+	require.NoError(t, f.Close())
+
+	assertBundle(t, NewFileClient(path))
+}
+
+func TestFileClientMissingFile(t *testing.T) {
+	client := NewFileClient(t.TempDir())
+
+	_, err := client.Fetch(context.Background(), &pbgo.LatestConfigsRequest{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = NewFileClient(filepath.Join(t.TempDir(), "missing.tar.gz")).FetchOrgData(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
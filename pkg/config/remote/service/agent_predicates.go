@@ -0,0 +1,140 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// AgentPredicate targets the hosts whose facts satisfy all its conditions
+type AgentPredicate struct {
+	Conditions []AgentCondition `json:"conditions"`
+}
+
+// AgentCondition compares a fact advertised by the agent to a value. The
+// ordering operators compare versions, so that "kernel_version >= 5.14"
+// matches a kernel 5.15.0-1051-aws.
+type AgentCondition struct {
+	Fact     string `json:"fact"`
+	Operator string `json:"op"`
+	Value    string `json:"value"`
+}
+
+// versionPrefix extracts the numeric part of a version, dropping the
+// distribution or build suffixes that aren't valid semver
+var versionPrefix = regexp.MustCompile(`^v?(\d+(?:\.\d+){0,2})`)
+
+// executeAgentPredicates returns whether a config targeted with the given
+// predicates applies to the host. Configs without predicates apply to every
+// host, otherwise at least one predicate must match. A condition on a fact
+// the agent doesn't advertise never matches.
+func executeAgentPredicates(predicates []AgentPredicate, facts map[string]string) (bool, error) {
+	if len(predicates) == 0 {
+		return true, nil
+	}
+
+	for _, predicate := range predicates {
+		matched := true
+		for _, condition := range predicate.Conditions {
+			ok, err := executeAgentCondition(condition, facts)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func executeAgentCondition(condition AgentCondition, facts map[string]string) (bool, error) {
+	fact, found := facts[condition.Fact]
+	if condition.Operator == "exists" {
+		return found, nil
+	}
+	if !found {
+		return false, nil
+	}
+
+	switch condition.Operator {
+	case "==":
+		return fact == condition.Value, nil
+	case "!=":
+		return fact != condition.Value, nil
+	case ">", ">=", "<", "<=":
+		cmp, err := compareVersions(fact, condition.Value)
+		if err != nil {
+			return false, err
+		}
+		switch condition.Operator {
+		case ">":
+			return cmp > 0, nil
+		case ">=":
+			return cmp >= 0, nil
+		case "<":
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+	default:
+		return false, fmt.Errorf("unknown operator %q for fact %q", condition.Operator, condition.Fact)
+	}
+}
+
+func compareVersions(a, b string) (int, error) {
+	va, err := parseFactVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseFactVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func parseFactVersion(s string) (*semver.Version, error) {
+	match := versionPrefix.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("invalid version %q", s)
+	}
+	return semver.NewVersion(match[1])
+}
+
+// tagsToFacts converts key:value tags to facts. The first value of a key is kept.
+func tagsToFacts(tags []string) map[string]string {
+	facts := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value, found := strings.Cut(tag, ":")
+		if !found || key == "" {
+			continue
+		}
+		if _, exists := facts[key]; !exists {
+			facts[key] = value
+		}
+	}
+	return facts
+}
+
+// factsToTags converts facts to sorted key:value tags
+func factsToTags(facts map[string]string) []string {
+	tags := make([]string, 0, len(facts))
+	for key, value := range facts {
+		tags = append(tags, key+":"+value)
+	}
+	sort.Strings(tags)
+	return tags
+}
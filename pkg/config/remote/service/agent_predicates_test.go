@@ -0,0 +1,138 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DataDog/go-tuf/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbgo "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+)
+
+func TestAgentPredicates(t *testing.T) {
+	facts := map[string]string{
+		"kernel_version": "5.15.0-1051-aws",
+		"env":            "prod",
+		"os":             "linux",
+	}
+
+	for _, tc := range []struct {
+		name       string
+		predicates []AgentPredicate
+		expected   bool
+	}{
+		{
+			name:     "no predicates",
+			expected: true,
+		},
+		{
+			name: "all conditions match",
+			predicates: []AgentPredicate{{Conditions: []AgentCondition{
+				{Fact: "kernel_version", Operator: ">=", Value: "5.14"},
+				{Fact: "env", Operator: "==", Value: "prod"},
+			}}},
+			expected: true,
+		},
+		{
+			name: "one condition doesn't match",
+			predicates: []AgentPredicate{{Conditions: []AgentCondition{
+				{Fact: "kernel_version", Operator: ">=", Value: "5.14"},
+				{Fact: "env", Operator: "==", Value: "staging"},
+			}}},
+			expected: false,
+		},
+		{
+			name: "any predicate matches",
+			predicates: []AgentPredicate{
+				{Conditions: []AgentCondition{{Fact: "kernel_version", Operator: "<", Value: "5.10"}}},
+				{Conditions: []AgentCondition{{Fact: "os", Operator: "!=", Value: "windows"}}},
+			},
+			expected: true,
+		},
+		{
+			name: "version comparison",
+			predicates: []AgentPredicate{{Conditions: []AgentCondition{
+				{Fact: "kernel_version", Operator: ">", Value: "5.15"},
+			}}},
+			expected: false,
+		},
+		{
+			name: "missing fact",
+			predicates: []AgentPredicate{{Conditions: []AgentCondition{
+				{Fact: "cluster", Operator: "!=", Value: "dev"},
+			}}},
+			expected: false,
+		},
+		{
+			name: "exists",
+			predicates: []AgentPredicate{{Conditions: []AgentCondition{
+				{Fact: "env", Operator: "exists"},
+			}}},
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, err := executeAgentPredicates(tc.predicates, facts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestAgentPredicatesErrors(t *testing.T) {
+	facts := map[string]string{"kernel_version": "unknown"}
+
+	_, err := executeAgentPredicates([]AgentPredicate{{Conditions: []AgentCondition{
+		{Fact: "kernel_version", Operator: ">=", Value: "5.14"},
+	}}}, facts)
+	assert.Error(t, err)
+
+	_, err = executeAgentPredicates([]AgentPredicate{{Conditions: []AgentCondition{
+		{Fact: "kernel_version", Operator: "~", Value: "5.14"},
+	}}}, facts)
+	assert.Error(t, err)
+}
+
+func TestTracerPredicatesWithAgentPredicates(t *testing.T) {
+	custom := func(predicates string) *json.RawMessage {
+		raw := json.RawMessage(`{"expires":0,"agent-predicates":` + predicates + `}`)
+		return &raw
+	}
+	targets := data.TargetFiles{
+		"datadog/2/APM_TRACING/matching/config": data.TargetFileMeta{
+			Custom: custom(`[{"conditions":[{"fact":"env","op":"==","value":"prod"}]}]`),
+		},
+		"datadog/2/APM_TRACING/other/config": data.TargetFileMeta{
+			Custom: custom(`[{"conditions":[{"fact":"env","op":"==","value":"staging"}]}]`),
+		},
+		"datadog/2/APM_TRACING/invalid/config": data.TargetFileMeta{
+			Custom: custom(`[{"conditions":[{"fact":"env","op":"~","value":"prod"}]}]`),
+		},
+		"datadog/2/APM_TRACING/all/config": data.TargetFileMeta{},
+	}
+
+	configs, err := executeTracerPredicates(
+		&pbgo.Client{Products: []string{"APM_TRACING"}},
+		targets,
+		map[string]string{"env": "prod"},
+	)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"datadog/2/APM_TRACING/matching/config",
+		"datadog/2/APM_TRACING/all/config",
+	}, configs)
+}
+
+func TestTagsToFacts(t *testing.T) {
+	facts := tagsToFacts([]string{"env:prod", "env:staging", "team:core", "novalue", ":empty"})
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, facts)
+
+	assert.Equal(t, []string{"env:prod", "team:core"}, factsToTags(facts))
+}
@@ -19,6 +19,7 @@ import (
 	"maps"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -150,6 +151,11 @@ type CoreAgentService struct {
 	uptane        coreAgentUptaneClient
 	api           api.API
 
+	// targetingFacts are the facts advertised by the agent, completed by
+	// the host tags in tagFacts, used to evaluate the agent predicates
+	targetingFacts map[string]string
+	tagFacts       map[string]string
+
 	products           map[rdata.Product]struct{}
 	newProducts        map[rdata.Product]struct{}
 	clients            *clients
@@ -251,6 +257,8 @@ type options struct {
 	clientTTL                      time.Duration
 	disableConfigPollLoop          bool
 	orgStatusRefreshInterval       time.Duration
+	bundlePath                     string
+	targetingFacts                 map[string]string
 	// for mocking creating db instance in test
 	uptaneFactory func(md *uptane.Metadata) (coreAgentUptaneClient, error)
 }
@@ -407,6 +415,22 @@ func WithAgentPollLoopDisabled() func(s *options) {
 	}
 }
 
+// WithBundlePath makes the service fetch the configurations from the local
+// bundle at the given path instead of the Remote Config backend
+func WithBundlePath(path string) func(s *options) {
+	return func(s *options) {
+		s.bundlePath = path
+	}
+}
+
+// WithTargetingFacts sets the facts advertised by the agent, against which
+// the agent predicates of the configs are evaluated
+func WithTargetingFacts(facts map[string]string) func(s *options) {
+	return func(s *options) {
+		s.targetingFacts = facts
+	}
+}
+
 // withUptaneFactory creates a mock version for testing
 func withUptaneFactory(f func(md *uptane.Metadata) (coreAgentUptaneClient, error)) Option {
 	return func(o *options) { o.uptaneFactory = f }
@@ -445,9 +469,18 @@ func NewService(cfg model.Reader, rcType, baseRawURL, hostname string, tagsGette
 	if err != nil {
 		return nil, err
 	}
-	http, err := api.NewHTTPClient(authKeys.apiAuth(), cfg, baseURL)
-	if err != nil {
-		return nil, err
+
+	var client api.API
+	var http *api.HTTPClient
+	if options.bundlePath != "" {
+		log.Infof("[%s] Remote Config configurations are read from the bundle %s", rcType, options.bundlePath)
+		client = api.NewFileClient(options.bundlePath)
+	} else {
+		http, err = api.NewHTTPClient(authKeys.apiAuth(), cfg, baseURL)
+		if err != nil {
+			return nil, err
+		}
+		client = http
 	}
 
 	databaseFilePath := cfg.GetString("run_path")
@@ -479,7 +512,7 @@ func NewService(cfg model.Reader, rcType, baseRawURL, hostname string, tagsGette
 	} else {
 		uptaneClient, err = uptane.NewCoreAgentClientWithNewTransactionalStore(
 			dbMetadata,
-			newRCBackendOrgUUIDProvider(http),
+			newRCBackendOrgUUIDProvider(client),
 			opt...,
 		)
 	}
@@ -491,7 +524,7 @@ func NewService(cfg model.Reader, rcType, baseRawURL, hostname string, tagsGette
 
 	// WebSocket test actor - must call Start() to spawn the background task.
 	var websocketTest startstop.StartStoppable
-	if cfg.GetBool("remote_configuration.no_websocket_echo") {
+	if cfg.GetBool("remote_configuration.no_websocket_echo") || http == nil {
 		websocketTest = &noOpRunnable{}
 	} else {
 		websocketTest = NewWebSocketTestActor(http)
@@ -514,7 +547,8 @@ func NewService(cfg model.Reader, rcType, baseRawURL, hostname string, tagsGette
 		tagsGetter:                     tagsGetter,
 		clock:                          clock,
 		traceAgentEnv:                  options.traceAgentEnv,
-		api:                            http,
+		targetingFacts:                 options.targetingFacts,
+		api:                            client,
 		uptane:                         uptaneClient,
 		clients:                        newClients(clock, options.clientTTL),
 		cacheBypassClients: cacheBypassClients{
@@ -545,6 +579,15 @@ func NewService(cfg model.Reader, rcType, baseRawURL, hostname string, tagsGette
 	return cas, nil
 }
 
+// facts returns the facts the agent predicates are evaluated against. The
+// configured facts take precedence over the host tags.
+func (s *CoreAgentService) facts() map[string]string {
+	facts := make(map[string]string, len(s.tagFacts)+len(s.targetingFacts))
+	maps.Copy(facts, s.tagFacts)
+	maps.Copy(facts, s.targetingFacts)
+	return facts
+}
+
 func newRCBackendOrgUUIDProvider(http api.API) uptane.OrgUUIDProvider {
 	return func() (string, error) {
 		// XXX: We may want to tune the context timeout here
@@ -748,7 +791,11 @@ func (s *CoreAgentService) refresh() error {
 		return err
 	}
 
-	request := buildLatestConfigsRequest(s.hostname, s.agentVersion, s.tagsGetter(), s.traceAgentEnv, orgUUID, previousState, activeClients, s.products, s.newProducts, s.lastUpdateErr, clientState)
+	tags := s.tagsGetter()
+	s.tagFacts = tagsToFacts(tags)
+	tags = append(slices.Clip(tags), factsToTags(s.targetingFacts)...)
+
+	request := buildLatestConfigsRequest(s.hostname, s.agentVersion, tags, s.traceAgentEnv, orgUUID, previousState, activeClients, s.products, s.newProducts, s.lastUpdateErr, clientState)
 	s.Unlock()
 	ctx := context.Background()
 	response, err := s.api.Fetch(ctx, request)
@@ -958,7 +1005,7 @@ func (s *CoreAgentService) ClientGetConfigs(_ context.Context, request *pbgo.Cli
 	if err != nil {
 		return nil, err
 	}
-	matchedClientConfigs, err := executeTracerPredicates(request.Client, directorTargets)
+	matchedClientConfigs, err := executeTracerPredicates(request.Client, directorTargets, s.facts())
 	if err != nil {
 		return nil, err
 	}
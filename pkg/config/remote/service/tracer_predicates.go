@@ -14,12 +14,17 @@ import (
 
 	rdata "github.com/DataDog/datadog-agent/pkg/config/remote/data"
 	pbgo "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
 // ConfigFileMetaCustom is the custom metadata of a config
 type ConfigFileMetaCustom struct {
 	Predicates *pbgo.TracerPredicates `json:"tracer-predicates,omitempty"`
 	Expires    int64                  `json:"expires"`
+
+	// AgentPredicates restrict the hosts the config applies to, based on
+	// the facts advertised by the agent
+	AgentPredicates []AgentPredicate `json:"agent-predicates,omitempty"`
 }
 
 // Given the hostname and state will parse predicates and execute them
@@ -27,6 +32,7 @@ type ConfigFileMetaCustom struct {
 func executeTracerPredicates(
 	client *pbgo.Client,
 	directorTargets data.TargetFiles,
+	facts map[string]string,
 ) ([]string, error) {
 	configs := make([]string, 0)
 
@@ -51,6 +57,17 @@ func executeTracerPredicates(
 			continue
 		}
 
+		// Configs targeting other hosts are not applied. An invalid agent
+		// predicate only excludes its own config.
+		applies, err := executeAgentPredicates(configMetadata.AgentPredicates, facts)
+		if err != nil {
+			log.Warnf("Invalid agent predicates for config %s: %v", path, err)
+			continue
+		}
+		if !applies {
+			continue
+		}
+
 		tracerPredicates := configMetadata.Predicates
 		matched := false
 		nullPredicates := tracerPredicates == nil || tracerPredicates.TracerPredicatesV1 == nil
@@ -15,6 +15,7 @@ import (
 type Client interface {
 	Get(key string) (interface{}, error)
 	GetWithSources(key string) (map[string]interface{}, error)
+	Sources(key string) (settings.SettingSourcesResponse, error)
 	Set(key string, value string) (bool, error)
 	List() (map[string]settings.RuntimeSettingResponse, error)
 	FullConfig() (string, error)
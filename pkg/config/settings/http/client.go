@@ -217,6 +217,17 @@ func (rc *runtimeSettingsClient) GetWithSources(key string) (map[string]interfac
 	return setting, nil
 }
 
+func (rc *runtimeSettingsClient) Sources(key string) (settingsComponent.SettingSourcesResponse, error) {
+	var sources settingsComponent.SettingSourcesResponse
+	r, err := rc.doGet(fmt.Sprintf("%s/sources/%s", rc.baseURL, key), false)
+	if err != nil {
+		return sources, err
+	}
+
+	err = json.Unmarshal([]byte(r), &sources)
+	return sources, err
+}
+
 func (rc *runtimeSettingsClient) Set(key string, value string) (bool, error) {
 	settingsList, err := rc.List()
 	if err != nil {
@@ -397,6 +397,16 @@ func InitConfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("flare.rc_profiling.mutex_fraction", 0)
 
 	config.BindEnvAndSetDefault("flare.rc_streamlogs.duration", 60*time.Second)
+	// flare archives bigger than this size are sent in chunks of this size. Disabled by default, it must only be set
+	// when the flare endpoint accepts chunked uploads.
+	config.BindEnvAndSetDefault("flare.upload.chunk_size", 0)
+	// additional redaction rules applied to the files of the flare
+	config.BindEnvAndSetDefault("flare.redaction.patterns", []string{})
+	config.BindEnvAndSetDefault("flare.redaction.keys", []string{})
+	config.BindEnvAndSetDefault("flare.redaction.excluded_files", []string{})
+	// approval policy of the flares requested by remote-config: auto-approve, require-confirmation or deny
+	config.BindEnvAndSetDefault("flare.rc_approval.policy", "auto-approve")
+	config.BindEnvAndSetDefault("flare.rc_approval.timeout", 1*time.Hour)
 
 	// Docker
 	config.BindEnvAndSetDefault("docker_query_timeout", int64(5))
@@ -626,6 +636,7 @@ func InitConfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("collect_ec2_instance_info", false)
 	config.BindEnvAndSetDefault("collect_ec2_tags_use_imds", false)
 	config.BindEnvAndSetDefault("exclude_ec2_tags", []string{})
+	config.BindEnvAndSetDefault("include_ec2_tags", []string{})
 	config.BindEnvAndSetDefault("ec2_imdsv2_transition_payload_enabled", true)
 
 	// ECS
@@ -641,6 +652,7 @@ func InitConfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("ecs_task_cache_ttl", 3*time.Minute)
 	config.BindEnvAndSetDefault("ecs_task_collection_rate", 35)
 	config.BindEnvAndSetDefault("ecs_task_collection_burst", 60)
+	config.BindEnvAndSetDefault("ecs_deployment_mode", "auto") // auto, ec2 or external
 
 	// GCE
 	config.BindEnvAndSetDefault("collect_gce_tags", true)
@@ -650,6 +662,7 @@ func InitConfig(config pkgconfigmodel.Setup) {
 		"bosh_settings", "windows-startup-script-ps1", "common-psm1", "k8s-node-setup-psm1", "serial-port-logging-enable",
 		"enable-oslogin", "disable-address-manager", "disable-legacy-endpoints", "windows-keys", "kubeconfig", "gce-container-declaration",
 	})
+	config.BindEnvAndSetDefault("include_gce_tags", []string{})
 	config.BindEnvAndSetDefault("gce_send_project_id_tag", false)
 	config.BindEnvAndSetDefault("gce_metadata_timeout", 1000) // value in milliseconds
 
@@ -689,6 +702,9 @@ func InitConfig(config pkgconfigmodel.Setup) {
 	// Azure
 	config.BindEnvAndSetDefault("azure_hostname_style", "os")
 	config.BindEnvAndSetDefault("azure_metadata_timeout", 300)
+	config.BindEnvAndSetDefault("collect_azure_tags", false)
+	config.BindEnvAndSetDefault("exclude_azure_tags", []string{})
+	config.BindEnvAndSetDefault("include_azure_tags", []string{})
 
 	// IBM cloud
 	// We use a long timeout here since the metadata and token API can be very slow sometimes.
@@ -823,6 +839,21 @@ func InitConfig(config pkgconfigmodel.Setup) {
 
 	// Remote tagger
 	config.BindEnvAndSetDefault("remote_tagger.max_concurrent_sync", 3)
+	// Request the entity updates as the tags added and removed, instead of all the tags of the entities
+	config.BindEnvAndSetDefault("remote_tagger.deltas_enabled", true)
+
+	// External tag provider, streaming the tags of entities over the TaggerStreamEntities gRPC method
+	config.BindEnvAndSetDefault("tagger.external_provider.address", "")
+	config.BindEnvAndSetDefault("tagger.external_provider.precedence", "low") // "low" or "high"
+	config.BindEnvAndSetDefault("tagger.external_provider.grace_time", 1*time.Minute)
+	config.BindEnvAndSetDefault("tagger.external_provider.ca_file", "")
+	config.BindEnvAndSetDefault("tagger.external_provider.cert_file", "")
+	config.BindEnvAndSetDefault("tagger.external_provider.key_file", "")
+	config.BindEnvAndSetDefault("tagger.external_provider.token", "")
+	config.BindEnvAndSetDefault("tagger.tag_budget.max_tags_per_source", 0) // 0 is unlimited
+	config.BindEnvAndSetDefault("tagger.tag_budget.max_tags_per_entity", 0) // 0 is unlimited
+	config.BindEnvAndSetDefault("tagger.tag_budget.max_tag_length", 0)      // 0 is unlimited
+	config.BindEnvAndSetDefault("tagger.tag_budget.mode", "warn")           // "warn", "truncate" or "drop_source"
 
 	// CSI driver
 	config.BindEnvAndSetDefault("csi.enabled", false)
@@ -1069,6 +1100,8 @@ func InitConfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("compliance_config.container_include", []string{})
 	config.BindEnvAndSetDefault("compliance_config.container_exclude", []string{})
 	config.BindEnvAndSetDefault("compliance_config.exclude_pause_container", true)
+	config.BindEnvAndSetDefault("compliance_config.custom_benchmarks.enabled", false)
+	config.BindEnvAndSetDefault("compliance_config.custom_benchmarks.public_keys", []string{})
 
 	// Datadog security agent (runtime)
 	config.BindEnvAndSetDefault("runtime_security_config.enabled", false)
@@ -1136,6 +1169,8 @@ func InitConfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("language_detection.reporting.buffer_period", "10s")
 	// TTL refresh period represents how frequently actively detected languages are refreshed by reporting them again to the language detection handler in the cluster agent
 	config.BindEnvAndSetDefault("language_detection.reporting.refresh_period", "20m")
+	// retention TTL represents how long the languages detected for the processes of a container are retained in workloadmeta after the processes exit
+	config.BindEnvAndSetDefault("language_detection.retention_ttl", "30m")
 
 	setupProcesses(config)
 
@@ -1222,6 +1257,12 @@ func agent(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("log_file_max_size", "10Mb")
 	config.BindEnvAndSetDefault("log_file_max_rolls", 1)
 	config.BindEnvAndSetDefault("log_level", "info")
+	// Reload the reloadable settings, like log_level, when datadog.yaml changes
+	config.BindEnvAndSetDefault("config_reload_on_change", false)
+	// Expand the ${VAR} references to environment variables in the values of the configuration files
+	config.BindEnvAndSetDefault("config_env_var_expansion", false)
+	// Fail to start when datadog.yaml has unknown settings or invalid values
+	config.BindEnvAndSetDefault("config_strict_validation", false)
 	config.BindEnvAndSetDefault("log_to_syslog", false)
 	config.BindEnvAndSetDefault("log_to_console", true)
 	config.BindEnvAndSetDefault("log_format_rfc3339", false)
@@ -1349,6 +1390,10 @@ func remoteconfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("remote_configuration.max_backoff_interval", 5*time.Minute)
 	config.BindEnvAndSetDefault("remote_configuration.clients.ttl_seconds", 30*time.Second)
 	config.BindEnvAndSetDefault("remote_configuration.clients.cache_bypass_limit", 5)
+	// Local bundle of Remote Config payloads used instead of the backend, for air-gapped environments
+	config.BindEnvAndSetDefault("remote_configuration.bundle_path", "")
+	// Facts advertised by the agent, on top of the host metadata, to evaluate the targeting predicates of the configs
+	config.BindEnvAndSetDefault("remote_configuration.targeting_facts", map[string]string{})
 	// Remote config products
 	config.BindEnvAndSetDefault("remote_configuration.apm_sampling.enabled", true)
 	config.BindEnvAndSetDefault("remote_configuration.agent_integrations.enabled", false)
@@ -1357,6 +1402,9 @@ func remoteconfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("remote_configuration.agent_integrations.allow_log_config_scheduling", false)
 	// Websocket echo test
 	config.BindEnvAndSetDefault("remote_configuration.no_websocket_echo", false)
+	// Set by the agent when the log level set through remote configuration
+	// has a TTL, to the time at which it is reverted
+	config.BindEnvAndSetDefault("remote_configuration.log_level_expires_at", "")
 }
 
 func autoconfig(config pkgconfigmodel.Setup) {
@@ -1365,6 +1413,7 @@ func autoconfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("autoconf_template_dir", "/datadog/check_configs")
 	config.BindEnvAndSetDefault("autoconf_config_files_poll", false)
 	config.BindEnvAndSetDefault("autoconf_config_files_poll_interval", 60)
+	config.BindEnvAndSetDefault("autoconf_config_files_watch", false)
 	config.BindEnvAndSetDefault("exclude_pause_container", true)
 	config.BindEnvAndSetDefault("include_ephemeral_containers", false)
 	config.BindEnvAndSetDefault("ac_include", []string{})
@@ -1384,6 +1433,11 @@ func autoconfig(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("ad_config_poll_interval", int64(10))                 // in seconds
 	config.BindEnvAndSetDefault("ad_allowed_env_vars", []string{})
 	config.BindEnvAndSetDefault("ad_disable_env_var_resolution", false)
+	config.BindEnvAndSetDefault("ad_listeners_include_namespaces", []string{})
+	config.BindEnvAndSetDefault("ad_listeners_exclude_namespaces", []string{})
+	config.BindEnvAndSetDefault("ad_listeners_label_selector", "")
+	config.BindEnvAndSetDefault("ad_schedule_jitter", 0) // in milliseconds
+	config.BindEnvAndSetDefault("ad_max_concurrent_resolutions", 1)
 	config.BindEnvAndSetDefault("extra_listeners", []string{})
 	config.BindEnvAndSetDefault("extra_config_providers", []string{})
 	config.BindEnvAndSetDefault("ignore_autoconf", []string{})
@@ -1447,6 +1501,9 @@ func debugging(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("tracemalloc_whitelist", "") // deprecated
 	config.BindEnvAndSetDefault("tracemalloc_blacklist", "") // deprecated
 	config.BindEnvAndSetDefault("run_path", defaultRunPath)
+	// Overrides of the install paths, see applyPathOverrides
+	config.BindEnvAndSetDefault("system_probe_socket", "")
+	config.BindEnvAndSetDefault("agent_binary_path", "")
 	config.BindEnv("no_proxy_nonexact_match") //nolint:forbidigo // TODO: replace by 'SetDefaultAndBindEnv'
 }
 
@@ -1545,12 +1602,39 @@ func forwarder(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("forwarder_storage_max_size_in_bytes", 0)                // 0 means disabled. This is a BETA feature.
 	config.BindEnvAndSetDefault("forwarder_storage_max_disk_ratio", 0.80)                // Do not store transactions on disk when the disk usage exceeds 80% of the disk capacity. Use 80% as some applications do not behave well when the disk space is very small.
 	config.BindEnvAndSetDefault("forwarder_retry_queue_capacity_time_interval_sec", 900) // 15 mins
+	config.BindEnvAndSetDefault("forwarder_retry_queue_normal_priority_max_age", 0)      // in seconds, 0 means no limit
+	config.BindEnvAndSetDefault("forwarder_retry_queue_high_priority_max_age", 0)        // in seconds, 0 means no limit
+	config.BindEnvAndSetDefault("forwarder_storage_auto_scale", false)
+	config.BindEnvAndSetDefault("forwarder_storage_auto_scale_free_disk_ratio", 0.5) // Grow up to 50% of the free disk space
+
+	// Forwarder failover between intake domains
+	config.BindEnvAndSetDefault("forwarder_failover_domains", []string{})
+	config.BindEnvAndSetDefault("forwarder_failover_probe_interval", 30)      // in seconds
+	config.BindEnvAndSetDefault("forwarder_failover_switch_threshold", 0.2)   // the healthiest domain must be 20% better than the active one
+	config.BindEnvAndSetDefault("forwarder_failover_min_active_duration", 60) // in seconds
+
+	// Event platform forwarder storage on disk
+	config.BindEnvAndSetDefault("event_platform_storage.event_types", []string{}) // event types whose events are stored on disk when their pipeline is full
+	config.BindEnvAndSetDefault("event_platform_storage.path", "")                // defaults to run_path/event_platform
+	config.BindEnvAndSetDefault("event_platform_storage.max_size_in_bytes", 100*1024*1024)
+	// Event platform forwarder output to local files
+	config.BindEnvAndSetDefault("event_platform_file_sink.event_types", []string{}) // event types whose events are written to files
+	config.BindEnvAndSetDefault("event_platform_file_sink.path", "")                // defaults to run_path/event_platform_sink
+	config.BindEnvAndSetDefault("event_platform_file_sink.max_file_size_in_bytes", 10*1024*1024)
+	config.BindEnvAndSetDefault("event_platform_file_sink.max_files", 5)
+	config.BindEnvAndSetDefault("event_platform_file_sink.send_to_intake", false)
+	// Additional endpoints of the event platform forwarder, per event type
+	config.BindEnv("event_platform_additional_endpoints") //nolint:forbidigo // TODO: replace by 'SetDefaultAndBindEnv'
+	// Batching policy of the event platform forwarder, per event type
+	config.BindEnv("event_platform_batching") //nolint:forbidigo // TODO: replace by 'SetDefaultAndBindEnv'
 
 	// Forwarder channels buffer size
 	config.BindEnvAndSetDefault("forwarder_high_prio_buffer_size", 100)
 	config.BindEnvAndSetDefault("forwarder_low_prio_buffer_size", 100)
 	config.BindEnvAndSetDefault("forwarder_requeue_buffer_size", 100)
 	config.BindEnvAndSetDefault("forwarder_http_protocol", "auto")
+	config.BindEnvAndSetDefault("forwarder_max_idle_conns_per_host", 5)
+	config.BindEnvAndSetDefault("forwarder_idle_conn_timeout", 45) // in seconds
 }
 
 func dogstatsd(config pkgconfigmodel.Setup) {
@@ -1590,10 +1674,15 @@ func dogstatsd(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("dogstatsd_expiry_seconds", 300)
 	// Control dogstatsd shutdown behaviors
 	config.BindEnvAndSetDefault("dogstatsd_flush_incomplete_buckets", false)
+	// Aggregate the distributions of the metrics matching the prefixes in sketches over a longer interval, in seconds. 0 disables it.
+	config.BindEnvAndSetDefault("dogstatsd_distribution_aggregation.interval", 0)
+	config.BindEnvAndSetDefault("dogstatsd_distribution_aggregation.metric_prefixes", []string{})
 	// Control how long we keep dogstatsd contexts in memory.
 	config.BindEnvAndSetDefault("dogstatsd_context_expiry_seconds", 20)
 	config.BindEnvAndSetDefault("dogstatsd_origin_detection", false) // Only supported for socket traffic
 	config.BindEnvAndSetDefault("dogstatsd_origin_detection_client", false)
+	// Detect the origin of the UDP traffic with system-probe, which maps the source address to its process
+	config.BindEnvAndSetDefault("dogstatsd_origin_detection_udp", false)
 	config.BindEnvAndSetDefault("dogstatsd_origin_optout_enabled", true)
 	config.BindEnvAndSetDefault("dogstatsd_so_rcvbuf", 0)
 	config.BindEnvAndSetDefault("dogstatsd_metrics_stats_enable", false)
@@ -1613,8 +1702,16 @@ func dogstatsd(config pkgconfigmodel.Setup) {
 	config.BindEnvAndSetDefault("dogstatsd_no_aggregation_pipeline", true)
 	// How many metrics maximum in payloads sent by the no-aggregation pipeline to the intake.
 	config.BindEnvAndSetDefault("dogstatsd_no_aggregation_pipeline_batch_size", 2048)
+	// Restrict the no-aggregation pipeline to the metrics matching these prefixes. Empty means all the metrics with a timestamp.
+	config.BindEnvAndSetDefault("dogstatsd_no_aggregation_pipeline_prefixes", []string{})
 	// Force the amount of dogstatsd workers (mainly used for benchmarks or some very specific use-case)
 	config.BindEnvAndSetDefault("dogstatsd_workers_count", 0)
+	// Number of shards of the dogstatsd socket, each listening on <dogstatsd_socket>-<shard> with its own worker. 0 disables sharding.
+	config.BindEnvAndSetDefault("dogstatsd_socket_shards", 0)
+	// Limit the metrics, events and service checks ingested per second from each origin. 0 means unlimited.
+	config.BindEnvAndSetDefault("dogstatsd_origin_quota.metrics_per_second", 0)
+	config.BindEnvAndSetDefault("dogstatsd_origin_quota.events_per_second", 0)
+	config.BindEnvAndSetDefault("dogstatsd_origin_quota.service_checks_per_second", 0)
 
 	// To enable the following feature, GODEBUG must contain `madvdontneed=1`
 	config.BindEnvAndSetDefault("dogstatsd_mem_based_rate_limiter.enabled", false)
@@ -1759,6 +1856,26 @@ func logsagent(config pkgconfigmodel.Setup) {
 	// maximum time that the windows tailer will hold a log file open, while waiting for
 	// the downstream logs pipeline to be ready to accept more data
 	config.BindEnvAndSetDefault("logs_config.windows_open_file_timeout", 5)
+	// position from which the journald tailers start when their source does not set a start_position:
+	// "end" and "beginning" resume from the stored cursor and only apply on the first run,
+	// "forceEnd" and "forceBeginning" ignore the stored cursor
+	config.BindEnvAndSetDefault("logs_config.journald_start_position", "end")
+
+	// Logs archive settings
+	config.BindEnvAndSetDefault("logs_config.archive.enabled", false)
+	config.BindEnvAndSetDefault("logs_config.archive.path", "")
+	config.BindEnvAndSetDefault("logs_config.archive.bucket_url", "")
+	config.BindEnvAndSetDefault("logs_config.archive.region", "us-east-1")
+	config.BindEnvAndSetDefault("logs_config.archive.access_key_id", "")
+	config.BindEnvAndSetDefault("logs_config.archive.secret_access_key", "")
+	config.BindEnvAndSetDefault("logs_config.archive.max_file_size", 10*1024*1024)
+	config.BindEnvAndSetDefault("logs_config.archive.flush_interval", 60)
+	config.BindEnvAndSetDefault("logs_config.archive.max_pending_files", 10)
+
+	// Logs OTLP export settings
+	config.BindEnvAndSetDefault("logs_config.otlp_export.enabled", false)
+	config.BindEnvAndSetDefault("logs_config.otlp_export.endpoint", "")
+	config.BindEnvAndSetDefault("logs_config.otlp_export.headers", map[string]string{})
 
 	// Auto multiline detection settings
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line_detection", false)
@@ -1766,10 +1883,12 @@ func logsagent(config pkgconfigmodel.Setup) {
 	config.SetKnown("logs_config.auto_multi_line_detection_custom_samples") //nolint:forbidigo // TODO: replace by 'SetDefaultAndBindEnv'
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.enable_json_detection", true)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.enable_datetime_detection", true)
+	config.BindEnvAndSetDefault("logs_config.auto_multi_line.enable_stack_trace_detection", true)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.timestamp_detector_match_threshold", 0.5)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.tokenizer_max_input_bytes", 60)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.pattern_table_max_size", 20)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.pattern_table_match_threshold", 0.75)
+	config.BindEnvAndSetDefault("logs_config.auto_multi_line.pin_learned_pattern", false)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.enable_json_aggregation", true)
 	config.BindEnvAndSetDefault("logs_config.auto_multi_line.tag_aggregated_json", false)
 
@@ -1790,6 +1909,11 @@ func logsagent(config pkgconfigmodel.Setup) {
 	// Number of logs pipeline instances. Defaults to number of logical CPU cores as defined by GOMAXPROCS or 4, whichever is lower.
 	logsPipelines := min(4, runtime.GOMAXPROCS(0))
 	config.BindEnvAndSetDefault("logs_config.pipelines", logsPipelines)
+	// Scale the number of logs pipelines between logs_config.pipelines and max_pipelines (bounded by GOMAXPROCS)
+	// based on the saturation of the pipelines and on the ingestion rate.
+	config.BindEnvAndSetDefault("logs_config.pipelines_autoscaling.enabled", false)
+	config.BindEnvAndSetDefault("logs_config.pipelines_autoscaling.max_pipelines", 0)
+	config.BindEnvAndSetDefault("logs_config.pipelines_autoscaling.target_rate_per_pipeline", 10000)
 
 	// If true, the agent looks for container logs in the location used by podman, rather
 	// than docker.  This is a temporary configuration parameter to support podman logs until
@@ -1834,6 +1958,8 @@ func logsagent(config pkgconfigmodel.Setup) {
 	// SDS logs blocking mechanism
 	config.BindEnvAndSetDefault("logs_config.sds.wait_for_configuration", "")
 	config.BindEnvAndSetDefault("logs_config.sds.buffer_max_size", 0)
+	// Sensitive Data Scanner rules applied by the logs processor before the logs leave the host
+	config.BindEnv("logs_config.sds.rules") //nolint:forbidigo // TODO: replace by 'SetDefaultAndBindEnv'
 
 	// Max size in MB to allow for integrations logs files
 	config.BindEnvAndSetDefault("logs_config.integrations_logs_files_max_size", 100)
@@ -2220,6 +2346,9 @@ func LoadDatadog(config pkgconfigmodel.Config, secretResolver secrets.Component,
 	// Feature detection running in a defer func as it always  need to run (whether config load has been successful or not)
 	// Because some Agents (e.g. trace-agent) will run even if config file does not exist
 	defer func() {
+		// The install path overrides also apply when there is no config file,
+		// through environment variables
+		applyPathOverrides(config, "sbom-agent")
 		// Environment feature detection needs to run before applying override funcs
 		// as it may provide such overrides
 		pkgconfigenv.DetectFeatures(config)
@@ -2234,6 +2363,12 @@ func LoadDatadog(config pkgconfigmodel.Config, secretResolver secrets.Component,
 		return err
 	}
 
+	if config.GetBool("config_strict_validation") {
+		if err := validateConfigFileStrict(config); err != nil {
+			return err
+		}
+	}
+
 	// We resolve proxy setting before secrets. This allows setting secrets through DD_PROXY_* env variables
 	LoadProxyFromEnv(config)
 
@@ -2256,6 +2391,7 @@ func LoadDatadog(config pkgconfigmodel.Config, secretResolver secrets.Component,
 	sanitizeAPIKeyConfig(config, "api_key")
 	sanitizeAPIKeyConfig(config, "logs_config.api_key")
 	setNumWorkers(config)
+	applyDeprecatedSettings(config)
 
 	flareStrippedKeys := config.GetStringSlice("flare_stripped_keys")
 	if len(flareStrippedKeys) > 0 {
@@ -2272,7 +2408,11 @@ func LoadDatadog(config pkgconfigmodel.Config, secretResolver secrets.Component,
 
 // LoadSystemProbe reads config files and initializes config with decrypted secrets for system-probe
 func LoadSystemProbe(config pkgconfigmodel.Config, additionalKnownEnvVars []string) error {
-	return loadCustom(config, additionalKnownEnvVars)
+	err := loadCustom(config, additionalKnownEnvVars)
+	// system-probe runs without a config file, the install path overrides
+	// can be set through environment variables
+	applyPathOverrides(config, "sbom-sysprobe")
+	return err
 }
 
 // loadCustom reads config into the provided config object
@@ -2690,6 +2830,46 @@ func setNumWorkers(config pkgconfigmodel.Config) {
 	}
 }
 
+// applyPathOverrides applies the run_path, system_probe_socket and
+// agent_binary_path settings to the settings whose default value is derived
+// from the install path, so that agents installed under a non-standard prefix
+// only need to set them once. The derived settings that are explicitly
+// configured are left untouched. sbomDir is the directory of the SBOM cache
+// in the run path, which differs between the agent and system-probe.
+func applyPathOverrides(config pkgconfigmodel.Config, sbomDir string) {
+	if config.IsKnown("run_path") && config.IsConfigured("run_path") {
+		runPath := config.GetString("run_path")
+		setDerivedPath(config, "logs_config.run_path", runPath)
+		setDerivedPath(config, "sbom.cache_directory", filepath.Join(runPath, sbomDir))
+		setDerivedPath(config, "runtime_security_config.activity_dump.local_storage.output_directory", filepath.Join(runPath, "runtime-security", "profiles"))
+		setDerivedPath(config, "runtime_security_config.security_profile.dir", filepath.Join(runPath, "runtime-security", "profiles"))
+	}
+
+	if config.IsKnown("system_probe_socket") {
+		if socket := config.GetString("system_probe_socket"); socket != "" {
+			setDerivedPath(config, "system_probe_config.sysprobe_socket", socket)
+		}
+	}
+
+	if config.IsKnown("agent_binary_path") {
+		if agentBin := config.GetString("agent_binary_path"); agentBin != "" {
+			setDerivedPath(config, "process_config.dd_agent_bin", agentBin)
+			setDerivedPath(config, "apm_config.dd_agent_bin", agentBin)
+		}
+	}
+}
+
+// setDerivedPath sets the default value of a setting derived from one of the
+// install path overrides, unless it is unknown to config or explicitly
+// configured.
+func setDerivedPath(config pkgconfigmodel.Config, key string, value string) {
+	if !config.IsKnown(key) || config.IsConfigured(key) {
+		return
+	}
+	log.Debugf("Setting %s to %s from the install path overrides", key, value)
+	config.Set(key, value, pkgconfigmodel.SourceDefault)
+}
+
 // IsCLCRunner returns whether the Agent is in cluster check runner mode
 func IsCLCRunner(config pkgconfigmodel.Reader) bool {
 	if !config.GetBool("clc_runner_enabled") {
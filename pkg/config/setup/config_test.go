@@ -1523,3 +1523,42 @@ func TestLoadProxyFromEnv(t *testing.T) {
 	assert.Equal(t, "http://www.example.com/", cfg.Get("proxy.http"))
 	assert.Equal(t, pkgconfigmodel.SourceAgentRuntime, cfg.GetSource("proxy.http"))
 }
+
+func TestApplyPathOverrides(t *testing.T) {
+	conf := confFromYAML(t, `
+run_path: /custom/run
+system_probe_socket: /custom/run/sysprobe.sock
+agent_binary_path: /custom/bin/agent
+process_config:
+  dd_agent_bin: /other/bin/agent
+`)
+	applyPathOverrides(conf, "sbom-agent")
+
+	assert.Equal(t, "/custom/run", conf.GetString("logs_config.run_path"))
+	assert.Equal(t, pkgconfigmodel.SourceDefault, conf.GetSource("logs_config.run_path"))
+	assert.False(t, conf.IsConfigured("logs_config.run_path"))
+	assert.Equal(t, filepath.Join("/custom/run", "sbom-agent"), conf.GetString("sbom.cache_directory"))
+	assert.Equal(t, "/custom/bin/agent", conf.GetString("apm_config.dd_agent_bin"))
+	// derived settings that are explicitly configured are not overridden
+	assert.Equal(t, "/other/bin/agent", conf.GetString("process_config.dd_agent_bin"))
+	assert.Equal(t, pkgconfigmodel.SourceFile, conf.GetSource("process_config.dd_agent_bin"))
+
+	sysprobe := newEmptyMockConf(t)
+	InitSystemProbeConfig(sysprobe)
+	sysprobe.SetWithoutSource("run_path", "/custom/run")
+	sysprobe.SetWithoutSource("system_probe_socket", "/custom/run/sysprobe.sock")
+	applyPathOverrides(sysprobe, "sbom-sysprobe")
+
+	assert.Equal(t, "/custom/run/sysprobe.sock", sysprobe.GetString("system_probe_config.sysprobe_socket"))
+	assert.Equal(t, filepath.Join("/custom/run", "sbom-sysprobe"), sysprobe.GetString("sbom.cache_directory"))
+	assert.Equal(t, filepath.Join("/custom/run", "runtime-security", "profiles"), sysprobe.GetString("runtime_security_config.security_profile.dir"))
+}
+
+func TestApplyPathOverridesDefaults(t *testing.T) {
+	conf := newTestConf(t)
+	applyPathOverrides(conf, "sbom-agent")
+
+	assert.Equal(t, DefaultDDAgentBin, conf.GetString("process_config.dd_agent_bin"))
+	assert.Equal(t, defaultRunPath, conf.GetString("logs_config.run_path"))
+	assert.Equal(t, pkgconfigmodel.SourceDefault, conf.GetSource("logs_config.run_path"))
+}
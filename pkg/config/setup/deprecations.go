@@ -0,0 +1,82 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package setup
+
+import (
+	"fmt"
+	"strings"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// DeprecatedSetting is a setting replaced by another one
+type DeprecatedSetting struct {
+	// Key is the deprecated setting
+	Key string
+	// ReplacedBy is the setting replacing it
+	ReplacedBy string
+}
+
+// deprecatedSettings are the settings replaced by another setting with the
+// same meaning. When a deprecated setting is configured and the setting
+// replacing it is not, its value is used for the setting replacing it.
+var deprecatedSettings = []DeprecatedSetting{
+	{Key: "log_enabled", ReplacedBy: "logs_enabled"},
+	{Key: "ipc_address", ReplacedBy: "cmd_host"},
+	{Key: "process_config.orchestrator_dd_url", ReplacedBy: "orchestrator_explorer.orchestrator_dd_url"},
+	{Key: "process_config.orchestrator_additional_endpoints", ReplacedBy: "orchestrator_explorer.orchestrator_additional_endpoints"},
+}
+
+// DeprecatedSettingUsage is a deprecated setting configured in the agent
+type DeprecatedSettingUsage struct {
+	DeprecatedSetting
+	// Source is the source setting the deprecated setting
+	Source pkgconfigmodel.Source
+}
+
+// String returns a human-readable representation of the usage
+func (u DeprecatedSettingUsage) String() string {
+	return fmt.Sprintf("%s (set by %s) is deprecated, use %s instead", u.Key, u.Source, u.ReplacedBy)
+}
+
+// FindDeprecatedSettings returns the deprecated settings configured in config,
+// in the order of the table of deprecated settings.
+func FindDeprecatedSettings(config pkgconfigmodel.Reader) []DeprecatedSettingUsage {
+	var usages []DeprecatedSettingUsage
+	for _, setting := range deprecatedSettings {
+		if !config.IsKnown(setting.Key) || !config.IsConfigured(setting.Key) {
+			continue
+		}
+		usages = append(usages, DeprecatedSettingUsage{
+			DeprecatedSetting: setting,
+			Source:            config.GetSource(setting.Key),
+		})
+	}
+	return usages
+}
+
+// applyDeprecatedSettings sets the settings replacing the deprecated settings
+// configured in config from their value, unless they are configured too, and
+// logs a single warning listing all the deprecated settings in use.
+func applyDeprecatedSettings(config pkgconfigmodel.Config) {
+	usages := FindDeprecatedSettings(config)
+	if len(usages) == 0 {
+		return
+	}
+
+	messages := make([]string, 0, len(usages))
+	for _, usage := range usages {
+		messages = append(messages, usage.String())
+		if config.IsConfigured(usage.ReplacedBy) {
+			continue
+		}
+		// the source of the deprecated setting is kept, so that the value
+		// has the same priority as if the new setting was used
+		config.Set(usage.ReplacedBy, config.Get(usage.Key), usage.Source)
+	}
+	log.Warnf("The configuration uses deprecated settings: %s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,51 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package setup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+func TestDeprecatedSettingsAreKnown(t *testing.T) {
+	conf := newTestConf(t)
+	for _, setting := range deprecatedSettings {
+		assert.True(t, conf.IsKnown(setting.Key), "%s is not a known setting", setting.Key)
+		assert.True(t, conf.IsKnown(setting.ReplacedBy), "%s is not a known setting", setting.ReplacedBy)
+	}
+}
+
+func TestApplyDeprecatedSettings(t *testing.T) {
+	assert.Empty(t, FindDeprecatedSettings(newTestConf(t)))
+
+	t.Setenv("DD_PROCESS_CONFIG_ORCHESTRATOR_DD_URL", "https://orchestrator.example.com")
+	conf := confFromYAML(t, `
+log_enabled: true
+ipc_address: 127.0.0.1
+cmd_host: localhost
+`)
+
+	usages := FindDeprecatedSettings(conf)
+	require.Len(t, usages, 3)
+	assert.Equal(t, DeprecatedSettingUsage{
+		DeprecatedSetting: DeprecatedSetting{Key: "log_enabled", ReplacedBy: "logs_enabled"},
+		Source:            pkgconfigmodel.SourceFile,
+	}, usages[0])
+	assert.Equal(t, "process_config.orchestrator_dd_url (set by environment-variable) is deprecated, use orchestrator_explorer.orchestrator_dd_url instead", usages[2].String())
+
+	applyDeprecatedSettings(conf)
+
+	assert.True(t, conf.GetBool("logs_enabled"))
+	assert.Equal(t, pkgconfigmodel.SourceFile, conf.GetSource("logs_enabled"))
+	assert.Equal(t, "https://orchestrator.example.com", conf.GetString("orchestrator_explorer.orchestrator_dd_url"))
+	assert.Equal(t, pkgconfigmodel.SourceEnvVar, conf.GetSource("orchestrator_explorer.orchestrator_dd_url"))
+	// the new setting is not overridden when it is configured
+	assert.Equal(t, "localhost", conf.GetString("cmd_host"))
+}
@@ -80,6 +80,9 @@ const (
 	// DefaultProcessEventsCheckInterval is the default interval used by the process_events check
 	DefaultProcessEventsCheckInterval = 10 * time.Second
 
+	// DefaultProcessEventsSampleRate is the default share of the processes whose lifecycle events are collected
+	DefaultProcessEventsSampleRate = 1.0
+
 	// DefaultProcessDiscoveryHintFrequency is the default frequency in terms of number of checks which we send a process discovery hint
 	DefaultProcessDiscoveryHintFrequency = 60
 )
@@ -116,6 +119,8 @@ func setupProcesses(config pkgconfigmodel.Setup) {
 	procBindEnvAndSetDefault(config, "process_config.container_collection.enabled", true)
 	procBindEnvAndSetDefault(config, "process_config.process_collection.enabled", false)
 	procBindEnvAndSetDefault(config, "process_config.process_collection.use_wlm", runtime.GOOS == "linux")
+	// Number of ancestors of each process added to its tags in the process payloads, 0 to disable
+	procBindEnvAndSetDefault(config, "process_config.process_collection.ancestry_depth", 0)
 
 	// This allows for the process check to run in the core agent but is for linux only
 	procBindEnvAndSetDefault(config, "process_config.run_in_core_agent.enabled", runtime.GOOS == "linux")
@@ -157,6 +162,16 @@ func setupProcesses(config pkgconfigmodel.Setup) {
 
 		return strings.Split(val, ",")
 	})
+	procBindEnvAndSetDefault(config, "process_config.custom_sensitive_regex", []string{})
+	config.ParseEnvAsStringSlice("process_config.custom_sensitive_regex", func(val string) []string {
+		// regular expressions can contain spaces and commas, a list must be set as a JSON array
+		res := []string{}
+		if err := json.Unmarshal([]byte(val), &res); err != nil {
+			log.Errorf("Error parsing JSON value for 'process_config.custom_sensitive_regex' from env vars: %s", err)
+			return nil
+		}
+		return res
+	})
 	config.BindEnv("process_config.scrub_args", //nolint:forbidigo // TODO: replace by 'SetDefaultAndBindEnv'
 		"DD_SCRUB_ARGS",
 		"DD_PROCESS_CONFIG_SCRUB_ARGS",
@@ -167,6 +182,7 @@ func setupProcesses(config pkgconfigmodel.Setup) {
 		"DD_PROCESS_AGENT_STRIP_PROC_ARGUMENTS")
 	// Use PDH API to collect performance counter data for process check on Windows
 	procBindEnvAndSetDefault(config, "process_config.windows.use_perf_counters", false)
+	procBindEnvAndSetDefault(config, "process_config.windows.process_metrics.enabled", false)
 	config.BindEnvAndSetDefault("process_config.additional_endpoints", make(map[string][]string),
 		"DD_PROCESS_CONFIG_ADDITIONAL_ENDPOINTS",
 		"DD_PROCESS_AGENT_ADDITIONAL_ENDPOINTS",
@@ -201,6 +217,7 @@ func setupProcesses(config pkgconfigmodel.Setup) {
 	procBindEnvAndSetDefault(config, "process_config.event_collection.store.stats_interval", DefaultProcessEventStoreStatsInterval)
 	procBindEnvAndSetDefault(config, "process_config.event_collection.enabled", false)
 	procBindEnvAndSetDefault(config, "process_config.event_collection.interval", DefaultProcessEventsCheckInterval)
+	procBindEnvAndSetDefault(config, "process_config.event_collection.sample_rate", DefaultProcessEventsSampleRate)
 
 	procBindEnvAndSetDefault(config, "process_config.cache_lookupid", false)
 
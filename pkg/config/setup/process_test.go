@@ -130,6 +130,10 @@ func TestProcessDefaultConfig(t *testing.T) {
 			key:          "process_config.event_collection.interval",
 			defaultValue: DefaultProcessEventsCheckInterval,
 		},
+		{
+			key:          "process_config.event_collection.sample_rate",
+			defaultValue: DefaultProcessEventsSampleRate,
+		},
 		{
 			key:          "process_config.language_detection.grpc_port",
 			defaultValue: DefaultProcessEntityStreamPort,
@@ -143,6 +147,18 @@ func TestProcessDefaultConfig(t *testing.T) {
 			key:          "process_config.process_collection.use_wlm",
 			defaultValue: runtime.GOOS == "linux",
 		},
+		{
+			key:          "process_config.process_collection.ancestry_depth",
+			defaultValue: 0,
+		},
+		{
+			key:          "process_config.custom_sensitive_regex",
+			defaultValue: []string{},
+		},
+		{
+			key:          "process_config.windows.process_metrics.enabled",
+			defaultValue: false,
+		},
 	} {
 		t.Run(tc.key+" default", func(t *testing.T) {
 			assert.Equal(t, tc.defaultValue, cfg.Get(tc.key))
@@ -447,6 +463,18 @@ func TestEnvVarOverride(t *testing.T) {
 			value:    "20s",
 			expected: 20 * time.Second,
 		},
+		{
+			key:      "process_config.windows.process_metrics.enabled",
+			env:      "DD_PROCESS_CONFIG_WINDOWS_PROCESS_METRICS_ENABLED",
+			value:    "true",
+			expected: true,
+		},
+		{
+			key:      "process_config.event_collection.sample_rate",
+			env:      "DD_PROCESS_CONFIG_EVENT_COLLECTION_SAMPLE_RATE",
+			value:    "0.5",
+			expected: 0.5,
+		},
 		{
 			key:      "process_config.language_detection.grpc_port",
 			env:      "DD_PROCESS_CONFIG_LANGUAGE_DETECTION_GRPC_PORT",
@@ -466,6 +494,12 @@ func TestEnvVarOverride(t *testing.T) {
 			value:    "false",
 			expected: false,
 		},
+		{
+			key:      "process_config.process_collection.ancestry_depth",
+			env:      "DD_PROCESS_CONFIG_PROCESS_COLLECTION_ANCESTRY_DEPTH",
+			value:    "3",
+			expected: 3,
+		},
 	} {
 		t.Run(tc.env, func(t *testing.T) {
 			// internal configuration rely on a syncOnce so we have to reset if after each call
@@ -553,6 +587,17 @@ func TestEnvVarCustomSensitiveWords(t *testing.T) {
 	}
 }
 
+func TestEnvVarCustomSensitiveRegex(t *testing.T) {
+	cfg := newTestConf(t)
+
+	for _, e := range []string{"DD_PROCESS_CONFIG_CUSTOM_SENSITIVE_REGEX", "DD_PROCESS_AGENT_CUSTOM_SENSITIVE_REGEX"} {
+		t.Run(e, func(t *testing.T) {
+			t.Setenv(e, `["(--token[= ])\\S+", "a,b"]`)
+			assert.Equal(t, []string{`(--token[= ])\S+`, "a,b"}, cfg.GetStringSlice("process_config.custom_sensitive_regex"))
+		})
+	}
+}
+
 func TestProcBindEnvAndSetDefault(t *testing.T) {
 	cfg := newTestConf(t)
 	procBindEnvAndSetDefault(cfg, "process_config.foo.bar", "asdf")
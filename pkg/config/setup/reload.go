@@ -0,0 +1,207 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package setup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// reloadableKeys are the settings reloaded from the configuration file without
+// restarting the agent, on SIGHUP or when the file changes.
+var reloadableKeys = []string{
+	"log_level",
+	"forwarder_timeout",
+	"dogstatsd_mapper_profiles",
+	"dogstatsd_mapper_cache_size",
+}
+
+// ReloadReceiver is called with the previous and the new value of a reloadable
+// setting when a reload of the configuration file changes it.
+type ReloadReceiver func(key string, oldValue, newValue any)
+
+var (
+	reloadReceiversMutex sync.RWMutex
+	reloadReceivers      = map[string][]ReloadReceiver{}
+
+	// reloadMutex serializes the reloads of the configuration file
+	reloadMutex sync.Mutex
+)
+
+// ReloadableKeys returns the settings reloaded from the configuration file
+// without restarting the agent.
+func ReloadableKeys() []string {
+	return slices.Clone(reloadableKeys)
+}
+
+// IsReloadable returns whether a setting is reloaded from the configuration
+// file without restarting the agent.
+func IsReloadable(key string) bool {
+	return slices.Contains(reloadableKeys, strings.ToLower(key))
+}
+
+// OnReload subscribes a receiver to the changes of a reloadable setting made by
+// ReloadConfigFile. Subscriptions to settings that are not reloadable are
+// ignored.
+func OnReload(key string, receiver ReloadReceiver) {
+	key = strings.ToLower(key)
+	if !IsReloadable(key) {
+		log.Errorf("Setting %s cannot be reloaded, ignoring the subscription to its reloads", key)
+		return
+	}
+
+	reloadReceiversMutex.Lock()
+	defer reloadReceiversMutex.Unlock()
+	reloadReceivers[key] = append(reloadReceivers[key], receiver)
+}
+
+// ReloadConfigFile reads the configuration file of cfg again and applies the
+// values it sets for the reloadable settings. Settings removed from the file
+// fall back to their value from the other sources. The receivers subscribed
+// to a setting are notified when its value changes, which it does not when a
+// source with a higher priority than the file, like an environment variable,
+// sets it. The settings whose value changed are returned.
+func ReloadConfigFile(cfg pkgconfigmodel.ReaderWriter) ([]string, error) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	path := cfg.ConfigFileUsed()
+	if path == "" {
+		return nil, errors.New("no configuration file to reload")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the configuration file %s: %w", path, err)
+	}
+
+	fileSettings, err := parseConfigFile(path, content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the configuration file %s: %w", path, err)
+	}
+
+	var changed []string
+	for _, key := range reloadableKeys {
+		oldValue := cfg.Get(key)
+		if value, found := lookupFileSetting(fileSettings, key); found {
+			cfg.Set(key, value, pkgconfigmodel.SourceFile)
+		} else if fileValue(cfg, key) != nil {
+			cfg.UnsetForSource(key, pkgconfigmodel.SourceFile)
+			// Some backends keep the values read from the file when the
+			// configuration was loaded, they are replaced by the value from
+			// the sources with a lower priority than the file, or by the
+			// zero value of the setting if there is none.
+			if fileValue(cfg, key) != nil {
+				value := valueBelowFile(cfg, key)
+				if value == nil && oldValue != nil {
+					value = reflect.Zero(reflect.TypeOf(oldValue)).Interface()
+				}
+				cfg.Set(key, value, pkgconfigmodel.SourceFile)
+			}
+		}
+
+		newValue := cfg.Get(key)
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		log.Infof("Setting %s reloaded from %s", key, path)
+		changed = append(changed, key)
+		notifyReload(key, oldValue, newValue)
+	}
+
+	return changed, nil
+}
+
+// parseConfigFile parses the content of a configuration file according to the
+// format of the file, and expands the references to environment variables in
+// its values like the configuration does when loading the file, if it enables it.
+func parseConfigFile(path string, content []byte) (interface{}, error) {
+	var fileSettings interface{}
+	switch pkgconfigmodel.ConfigFileFormat(path) {
+	case pkgconfigmodel.ConfigFormatJSON:
+		if err := json.Unmarshal(content, &fileSettings); err != nil {
+			return nil, err
+		}
+	case pkgconfigmodel.ConfigFormatTOML:
+		tree, err := toml.LoadBytes(content)
+		if err != nil {
+			return nil, err
+		}
+		fileSettings = tree.ToMap()
+	default:
+		if err := yaml.Unmarshal(content, &fileSettings); err != nil {
+			return nil, err
+		}
+	}
+	if pkgconfigmodel.IsEnvVarExpansionEnabled(fileSettings) {
+		fileSettings, _ = pkgconfigmodel.ExpandEnvVarsInValue(fileSettings)
+	}
+	return fileSettings, nil
+}
+
+// lookupFileSetting returns the value of a setting in the parsed configuration
+// file, following the sections of its dotted name.
+func lookupFileSetting(fileSettings interface{}, key string) (interface{}, bool) {
+	value := fileSettings
+	for _, part := range strings.Split(key, ".") {
+		var found bool
+		switch section := value.(type) {
+		case map[interface{}]interface{}:
+			value, found = section[part]
+		case map[string]interface{}:
+			value, found = section[part]
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
+
+// fileValue returns the value of a setting set by the configuration file
+func fileValue(cfg pkgconfigmodel.Reader, key string) interface{} {
+	for _, value := range cfg.GetAllSources(key) {
+		if value.Source == pkgconfigmodel.SourceFile {
+			return value.Value
+		}
+	}
+	return nil
+}
+
+// valueBelowFile returns the value of a setting from the sources with a lower
+// priority than the configuration file
+func valueBelowFile(cfg pkgconfigmodel.Reader, key string) interface{} {
+	var value interface{}
+	for _, v := range cfg.GetAllSources(key) {
+		if v.Value != nil && pkgconfigmodel.SourceFile.IsGreaterThan(v.Source) {
+			value = v.Value
+		}
+	}
+	return value
+}
+
+func notifyReload(key string, oldValue, newValue any) {
+	reloadReceiversMutex.RLock()
+	receivers := slices.Clone(reloadReceivers[key])
+	reloadReceiversMutex.RUnlock()
+
+	for _, receiver := range receivers {
+		receiver(key, oldValue, newValue)
+	}
+}
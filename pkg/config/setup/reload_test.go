@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+func newReloadTestConf(t *testing.T, content string) (pkgconfigmodel.BuildableConfig, string) {
+	path := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	conf := newTestConf(t)
+	conf.SetConfigFile(path)
+	require.NoError(t, conf.ReadInConfig())
+	return conf, path
+}
+
+// resetReloadReceivers drops the receivers subscribed by a test when it ends
+func resetReloadReceivers(t *testing.T) {
+	t.Cleanup(func() {
+		reloadReceiversMutex.Lock()
+		defer reloadReceiversMutex.Unlock()
+		reloadReceivers = map[string][]ReloadReceiver{}
+	})
+}
+
+func TestReloadConfigFile(t *testing.T) {
+	resetReloadReceivers(t)
+	conf, path := newReloadTestConf(t, "log_level: warn\nforwarder_timeout: 30\nhostname: foo\n")
+
+	var notified []string
+	OnReload("forwarder_timeout", func(key string, oldValue, newValue any) {
+		notified = append(notified, key)
+		assert.EqualValues(t, 30, oldValue)
+		assert.EqualValues(t, 10, newValue)
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("forwarder_timeout: 10\nhostname: bar\n"), 0600))
+
+	changed, err := ReloadConfigFile(conf)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"log_level", "forwarder_timeout"}, changed)
+	assert.Equal(t, []string{"forwarder_timeout"}, notified)
+	assert.EqualValues(t, 10, conf.GetInt("forwarder_timeout"))
+	// log_level falls back to its default when it is removed from the file
+	assert.Equal(t, "info", conf.GetString("log_level"))
+	// settings that are not reloadable are not changed
+	assert.Equal(t, "foo", conf.GetString("hostname"))
+
+	// nothing changes when the file did not change
+	changed, err = ReloadConfigFile(conf)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestReloadConfigFileEnvVarPriority(t *testing.T) {
+	t.Setenv("DD_LOG_LEVEL", "debug")
+	conf, path := newReloadTestConf(t, "log_level: info\n")
+
+	require.NoError(t, os.WriteFile(path, []byte("log_level: warn\n"), 0600))
+
+	changed, err := ReloadConfigFile(conf)
+	require.NoError(t, err)
+
+	assert.Empty(t, changed)
+	assert.Equal(t, "debug", conf.GetString("log_level"))
+}
+
+func TestReloadConfigFileFormats(t *testing.T) {
+	testCases := map[string]string{
+		"datadog.json": `{"forwarder_timeout": 10, "log_level": "warn"}`,
+		"datadog.toml": "forwarder_timeout = 10\nlog_level = \"warn\"\n",
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+			conf := newTestConf(t)
+			conf.SetConfigFile(path)
+
+			changed, err := ReloadConfigFile(conf)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"forwarder_timeout", "log_level"}, changed)
+			assert.Equal(t, 10, conf.GetInt("forwarder_timeout"))
+			assert.Equal(t, "warn", conf.GetString("log_level"))
+		})
+	}
+}
+
+func TestReloadConfigFileInvalid(t *testing.T) {
+	conf, path := newReloadTestConf(t, "forwarder_timeout: 30\n")
+
+	require.NoError(t, os.WriteFile(path, []byte("forwarder_timeout: [\n"), 0600))
+
+	_, err := ReloadConfigFile(conf)
+	assert.Error(t, err)
+	assert.EqualValues(t, 30, conf.GetInt("forwarder_timeout"))
+}
+
+func TestIsReloadable(t *testing.T) {
+	assert.True(t, IsReloadable("log_level"))
+	assert.True(t, IsReloadable("forwarder_timeout"))
+	assert.False(t, IsReloadable("logs_config.processing_rules"))
+	assert.False(t, IsReloadable("api_key"))
+}
@@ -74,6 +74,12 @@ func InitSystemProbeConfig(cfg pkgconfigmodel.Setup) {
 	cfg.BindEnvAndSetDefault("ignore_host_etc", false)
 	cfg.BindEnvAndSetDefault("go_core_dump", false)
 	cfg.BindEnvAndSetDefault(join(spNS, "disable_thp"), true)
+	// Expand the ${VAR} references to environment variables in the values of system-probe.yaml
+	cfg.BindEnvAndSetDefault("config_env_var_expansion", false)
+
+	// Overrides of the install paths, see applyPathOverrides
+	cfg.BindEnvAndSetDefault("run_path", defaultRunPath)
+	cfg.BindEnvAndSetDefault("system_probe_socket", "")
 
 	// SBOM configuration
 	cfg.BindEnvAndSetDefault("sbom.host.enabled", false)
@@ -188,6 +194,8 @@ func InitSystemProbeConfig(cfg pkgconfigmodel.Setup) {
 	cfg.BindEnvAndSetDefault(join(diNS, "debug_info_disk_cache", "max_total_bytes"), int64(2<<30 /* 2GiB */))
 	cfg.BindEnvAndSetDefault(join(diNS, "debug_info_disk_cache", "required_disk_space_bytes"), int64(512<<20 /* 512MiB */))
 	cfg.BindEnvAndSetDefault(join(diNS, "debug_info_disk_cache", "required_disk_space_percent"), float64(0.0))
+	cfg.BindEnvAndSetDefault(join(diNS, "debug_info_disk_cache", "persistent"), true)
+	cfg.BindEnvAndSetDefault(join(diNS, "max_events_per_second"), 0, "DD_DYNAMIC_INSTRUMENTATION_MAX_EVENTS_PER_SECOND")
 
 	// network_tracer settings
 	// we cannot use BindEnvAndSetDefault for network_config.enabled because we need to know if it was manually set.
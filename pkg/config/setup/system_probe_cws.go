@@ -32,6 +32,7 @@ func initCWSSystemProbeConfig(cfg pkgconfigmodel.Setup) {
 	cfg.BindEnvAndSetDefault("runtime_security_config.log_tags", []string{})
 	cfg.BindEnvAndSetDefault("runtime_security_config.self_test.enabled", true)
 	cfg.BindEnvAndSetDefault("runtime_security_config.self_test.send_report", true)
+	cfg.BindEnvAndSetDefault("runtime_security_config.self_test.schedule", "1h")
 	cfg.BindEnvAndSetDefault("runtime_security_config.remote_configuration.enabled", true)
 	cfg.BindEnvAndSetDefault("runtime_security_config.remote_configuration.dump_policies", false)
 	cfg.BindEnvAndSetDefault("runtime_security_config.direct_send_from_system_probe", false)
@@ -122,6 +123,7 @@ func initCWSSystemProbeConfig(cfg pkgconfigmodel.Setup) {
 	cfg.BindEnvAndSetDefault("runtime_security_config.hash_resolver.hash_algorithms", []string{"sha1", "sha256", "ssdeep"})
 	cfg.BindEnvAndSetDefault("runtime_security_config.hash_resolver.cache_size", 500)
 	cfg.BindEnvAndSetDefault("runtime_security_config.hash_resolver.replace", map[string]string{})
+	cfg.BindEnvAndSetDefault("runtime_security_config.hash_resolver.async_queue_size", 1024)
 
 	// CWS - SysCtl
 	cfg.BindEnvAndSetDefault("runtime_security_config.sysctl.enabled", true)
@@ -132,6 +134,7 @@ func initCWSSystemProbeConfig(cfg pkgconfigmodel.Setup) {
 
 	// CWS - UserSessions
 	cfg.BindEnvAndSetDefault("runtime_security_config.user_sessions.cache_size", 1024)
+	cfg.BindEnvAndSetDefault("runtime_security_config.user_sessions.login_sessions.enabled", true)
 
 	// CWS -eBPF Less
 	cfg.BindEnvAndSetDefault("runtime_security_config.ebpfless.enabled", false)
@@ -140,6 +143,14 @@ func initCWSSystemProbeConfig(cfg pkgconfigmodel.Setup) {
 	// CWS - IMDS
 	cfg.BindEnvAndSetDefault("runtime_security_config.imds_ipv4", "169.254.169.254")
 
+	// CWS - DNS exfiltration
+	cfg.BindEnvAndSetDefault("runtime_security_config.dns_exfiltration.enabled", false)
+	cfg.BindEnvAndSetDefault("runtime_security_config.dns_exfiltration.entropy_threshold", 3.5)
+	cfg.BindEnvAndSetDefault("runtime_security_config.dns_exfiltration.label_length_threshold", 40)
+	cfg.BindEnvAndSetDefault("runtime_security_config.dns_exfiltration.query_rate_threshold", 100)
+	cfg.BindEnvAndSetDefault("runtime_security_config.dns_exfiltration.window", "1m")
+	cfg.BindEnvAndSetDefault("runtime_security_config.dns_exfiltration.min_score", 2)
+
 	// CWS enforcement capabilities
 	cfg.BindEnvAndSetDefault("runtime_security_config.enforcement.enabled", true)
 	cfg.BindEnvAndSetDefault("runtime_security_config.enforcement.raw_syscall.enabled", false)
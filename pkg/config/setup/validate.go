@@ -0,0 +1,378 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package setup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v3"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// enumSettings lists the values accepted by the settings that only accept a
+// fixed set of values. The values are compared case-insensitively.
+var enumSettings = map[string][]string{
+	"log_level":                  {"trace", "debug", "info", "warn", "warning", "error", "critical", "off"},
+	"forwarder_http_protocol":    {"auto", "http1"},
+	"serializer_compressor_kind": {"zlib", "zstd", "gzip", "none"},
+}
+
+// ValidationIssue is a problem found in a configuration file by
+// ValidateConfigFile
+type ValidationIssue struct {
+	// Line is the line of the setting in the file
+	Line int
+	// Key is the setting, in its dotted form
+	Key     string
+	Message string
+}
+
+// String returns a human-readable representation of the issue
+func (i ValidationIssue) String() string {
+	if i.Key == "" {
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Key, i.Message)
+}
+
+// ValidateConfigFile checks the content of a configuration file in the given
+// format (see pkgconfigmodel.ConfigFileFormat) against the settings known by
+// config: it reports the unknown settings, the values whose type does not
+// match the default value of their setting, and the invalid values of the
+// settings that only accept a fixed set of values. The issues are sorted by
+// line. An error is returned if the content cannot be parsed.
+func ValidateConfigFile(content []byte, format string, config pkgconfigmodel.Reader) ([]ValidationIssue, error) {
+	document, err := parseConfigNode(content, format)
+	if err != nil {
+		return nil, err
+	}
+	// an empty file is a valid configuration
+	if document == nil {
+		return nil, nil
+	}
+
+	if document.Kind != yaml.MappingNode {
+		return []ValidationIssue{{Line: document.Line, Message: "the configuration must be a map of settings"}}, nil
+	}
+
+	if pkgconfigmodel.IsEnvVarExpansionEnabled(topLevelScalars(document)) {
+		expandNodeEnvVars(document)
+	}
+
+	v := newConfigValidator(config)
+	v.validateSection("", document)
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Line < v.issues[j].Line
+	})
+	return v.issues, nil
+}
+
+// validateConfigFileStrict validates the configuration file of config, when
+// config_strict_validation is enabled, and fails if any issue is found.
+func validateConfigFileStrict(config pkgconfigmodel.Reader) error {
+	path := config.ConfigFileUsed()
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	issues, err := ValidateConfigFile(content, pkgconfigmodel.ConfigFileFormat(path), config)
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	for _, issue := range issues {
+		log.Errorf("Invalid configuration in %s, %s", path, issue)
+	}
+	return fmt.Errorf("found %d issues in the configuration file %s, config_strict_validation is enabled", len(issues), path)
+}
+
+// parseConfigNode parses a configuration file into a YAML node, which keeps the
+// line of each setting. JSON files are YAML files, TOML files are converted.
+func parseConfigNode(content []byte, format string) (*yaml.Node, error) {
+	if format == pkgconfigmodel.ConfigFormatTOML {
+		tree, err := toml.LoadBytes(content)
+		if err != nil {
+			return nil, err
+		}
+		if len(tree.Keys()) == 0 {
+			return nil, nil
+		}
+		return tomlTreeToNode(tree), nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	return root.Content[0], nil
+}
+
+func tomlTreeToNode(tree *toml.Tree) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: tree.Position().Line}
+	for _, key := range tree.Keys() {
+		path := []string{key}
+		line := tree.GetPositionPath(path).Line
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, Line: line},
+			tomlValueToNode(tree.GetPath(path), line))
+	}
+	return node
+}
+
+func tomlValueToNode(value interface{}, line int) *yaml.Node {
+	switch v := value.(type) {
+	case *toml.Tree:
+		return tomlTreeToNode(v)
+	case []*toml.Tree:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line}
+		for _, item := range v {
+			node.Content = append(node.Content, tomlTreeToNode(item))
+		}
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line}
+		for _, item := range v {
+			node.Content = append(node.Content, tomlValueToNode(item, line))
+		}
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v, Line: line}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v), Line: line}
+	case int64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(v, 10), Line: line}
+	case float64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(v, 'g', -1, 64), Line: line}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(value), Line: line}
+}
+
+// topLevelScalars returns the settings of a map node whose value is a scalar
+func topLevelScalars(node *yaml.Node) map[string]interface{} {
+	settings := map[string]interface{}{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if value := node.Content[i+1]; value.Kind == yaml.ScalarNode {
+			settings[strings.ToLower(node.Content[i].Value)] = value.Value
+		}
+	}
+	return settings
+}
+
+// expandNodeEnvVars expands the references to environment variables in the
+// scalar values of node, so that values are validated like they are loaded.
+func expandNodeEnvVars(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		node.Value = pkgconfigmodel.ExpandEnvVars(node.Value)
+		return
+	}
+	for i, child := range node.Content {
+		// the keys of a mapping node are not expanded
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+		expandNodeEnvVars(child)
+	}
+}
+
+type configValidator struct {
+	config    pkgconfigmodel.Reader
+	knownKeys map[string]interface{}
+	// sections are the prefixes of the known settings, like logs_config
+	sections map[string]struct{}
+	issues   []ValidationIssue
+}
+
+func newConfigValidator(config pkgconfigmodel.Reader) *configValidator {
+	v := &configValidator{
+		config:    config,
+		knownKeys: config.GetKnownKeysLowercased(),
+		sections:  map[string]struct{}{},
+	}
+	for knownKey := range v.knownKeys {
+		for i := strings.LastIndex(knownKey, "."); i > 0; i = strings.LastIndex(knownKey[:i], ".") {
+			v.sections[knownKey[:i]] = struct{}{}
+		}
+	}
+	return v
+}
+
+func (v *configValidator) addIssue(node *yaml.Node, key string, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{Line: node.Line, Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateSection validates the settings of a map, which is either the root
+// of the file or a section of settings, like logs_config.
+func (v *configValidator) validateSection(prefix string, section *yaml.Node) {
+	// the content of a mapping node alternates keys and values
+	for i := 0; i+1 < len(section.Content); i += 2 {
+		keyNode, valueNode := section.Content[i], section.Content[i+1]
+		key := strings.ToLower(keyNode.Value)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		// sections are checked first, as some backends also know them as
+		// settings
+		switch {
+		case v.isSection(key):
+			if valueNode.Kind == yaml.MappingNode {
+				v.validateSection(key, valueNode)
+			} else if !isNull(valueNode) {
+				v.addIssue(valueNode, key, "expected a map of settings, got %s", describeNode(valueNode))
+			}
+		case v.isKnown(key):
+			v.validateValue(key, valueNode)
+		case v.isWithinKnownKey(key):
+			// values nested in a setting that is a map, like the tags of
+			// a map of tags, are not settings
+		default:
+			v.addIssue(keyNode, key, "unknown setting")
+		}
+	}
+}
+
+func (v *configValidator) validateValue(key string, node *yaml.Node) {
+	// the values of secrets are only known once they are decrypted
+	if isNull(node) || (node.Kind == yaml.ScalarNode && strings.HasPrefix(node.Value, "ENC[")) {
+		return
+	}
+
+	if defaultValue, found := v.defaultValue(key); found {
+		if expected := expectedType(defaultValue); expected != "" && !matchesType(defaultValue, node) {
+			v.addIssue(node, key, "expected %s, got %s", expected, describeNode(node))
+			return
+		}
+	}
+
+	if allowed, found := enumSettings[key]; found && node.Kind == yaml.ScalarNode {
+		if !slices.Contains(allowed, strings.ToLower(node.Value)) {
+			v.addIssue(node, key, "invalid value %q, expected one of: %s", node.Value, strings.Join(allowed, ", "))
+		}
+	}
+}
+
+func (v *configValidator) isKnown(key string) bool {
+	_, found := v.knownKeys[key]
+	return found
+}
+
+// isSection returns whether key is a section grouping other settings
+func (v *configValidator) isSection(key string) bool {
+	_, found := v.sections[key]
+	return found
+}
+
+// isWithinKnownKey returns whether key is nested in the value of a setting
+func (v *configValidator) isWithinKnownKey(key string) bool {
+	for knownKey := range v.knownKeys {
+		if strings.HasPrefix(key, knownKey+".") && !v.isSection(knownKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *configValidator) defaultValue(key string) (interface{}, bool) {
+	for _, valueWithSource := range v.config.GetAllSources(key) {
+		if valueWithSource.Source == pkgconfigmodel.SourceDefault {
+			return valueWithSource.Value, valueWithSource.Value != nil
+		}
+	}
+	return nil, false
+}
+
+// expectedType returns the type of YAML value expected for a setting from its
+// default value, or an empty string if any value is accepted.
+func expectedType(defaultValue interface{}) string {
+	if _, ok := defaultValue.(time.Duration); ok {
+		return "a duration"
+	}
+
+	switch reflect.ValueOf(defaultValue).Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice:
+		return "a list"
+	case reflect.Map:
+		return "a map"
+	}
+	return ""
+}
+
+// matchesType returns whether a YAML value can be used for a setting with the
+// given default value. Scalars are accepted for the types the agent converts
+// them to, like "true" for a boolean or a space-separated string for a list.
+func matchesType(defaultValue interface{}, node *yaml.Node) bool {
+	if _, ok := defaultValue.(time.Duration); ok {
+		// durations are numbers of seconds or strings like "10s"
+		return node.Kind == yaml.ScalarNode
+	}
+
+	switch reflect.ValueOf(defaultValue).Kind() {
+	case reflect.Bool:
+		_, err := strconv.ParseBool(node.Value)
+		return node.Kind == yaml.ScalarNode && err == nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		_, err := strconv.ParseFloat(node.Value, 64)
+		return node.Kind == yaml.ScalarNode && err == nil
+	case reflect.String:
+		return node.Kind == yaml.ScalarNode
+	case reflect.Slice:
+		return node.Kind == yaml.SequenceNode || node.Kind == yaml.ScalarNode
+	case reflect.Map:
+		return node.Kind == yaml.MappingNode
+	}
+	return true
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.AliasNode:
+		return "an alias"
+	}
+	return strconv.Quote(node.Value)
+}
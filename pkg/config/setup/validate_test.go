@@ -0,0 +1,154 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected []ValidationIssue
+	}{
+		{
+			name:    "valid",
+			content: "api_key: abc\nlog_level: WARN\nforwarder_timeout: 30\ntags:\n  - env:prod\nlogs_config:\n  batch_wait: 5\n",
+		},
+		{
+			name: "empty",
+		},
+		{
+			name:    "unknown settings",
+			content: "api_key: abc\nfoo: bar\nlogs_config:\n  unknown: true\n",
+			expected: []ValidationIssue{
+				{Line: 2, Key: "foo", Message: "unknown setting"},
+				{Line: 4, Key: "logs_config.unknown", Message: "unknown setting"},
+			},
+		},
+		{
+			name:    "type mismatches",
+			content: "forwarder_timeout: soon\nlogs_enabled: [true]\ntags: {env: prod}\nlogs_config: true\n",
+			expected: []ValidationIssue{
+				{Line: 1, Key: "forwarder_timeout", Message: `expected a number, got "soon"`},
+				{Line: 2, Key: "logs_enabled", Message: "expected a boolean, got a list"},
+				{Line: 3, Key: "tags", Message: "expected a list, got a map"},
+				{Line: 4, Key: "logs_config", Message: `expected a map of settings, got "true"`},
+			},
+		},
+		{
+			name:    "invalid enum value",
+			content: "log_level: verbose\nforwarder_http_protocol: http2\n",
+			expected: []ValidationIssue{
+				{Line: 1, Key: "log_level", Message: `invalid value "verbose", expected one of: trace, debug, info, warn, warning, error, critical, off`},
+				{Line: 2, Key: "forwarder_http_protocol", Message: `invalid value "http2", expected one of: auto, http1`},
+			},
+		},
+		{
+			name:    "secrets and null values",
+			content: "forwarder_timeout: ENC[timeout]\nlog_level:\n",
+		},
+		{
+			name:    "not a map",
+			content: "- api_key\n",
+			expected: []ValidationIssue{
+				{Line: 1, Message: "the configuration must be a map of settings"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, err := ValidateConfigFile([]byte(tc.content), pkgconfigmodel.ConfigFormatYAML, newTestConf(t))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, issues)
+		})
+	}
+}
+
+func TestValidateConfigFileInvalidYAML(t *testing.T) {
+	_, err := ValidateConfigFile([]byte("api_key: [abc\n"), pkgconfigmodel.ConfigFormatYAML, newTestConf(t))
+	assert.Error(t, err)
+
+	_, err = ValidateConfigFile([]byte("api_key = [abc\n"), pkgconfigmodel.ConfigFormatTOML, newTestConf(t))
+	assert.Error(t, err)
+}
+
+func TestValidateConfigFileJSON(t *testing.T) {
+	content := `{
+  "api_key": "abc",
+  "forwarder_timeout": "soon",
+  "logs_config": {
+    "unknown": true
+  }
+}`
+	issues, err := ValidateConfigFile([]byte(content), pkgconfigmodel.ConfigFormatJSON, newTestConf(t))
+	require.NoError(t, err)
+	assert.Equal(t, []ValidationIssue{
+		{Line: 3, Key: "forwarder_timeout", Message: `expected a number, got "soon"`},
+		{Line: 5, Key: "logs_config.unknown", Message: "unknown setting"},
+	}, issues)
+}
+
+func TestValidateConfigFileTOML(t *testing.T) {
+	content := `api_key = "abc"
+log_level = "verbose"
+forwarder_timeout = 30
+tags = ["env:prod"]
+
+[logs_config]
+batch_wait = 5
+unknown = true
+`
+	issues, err := ValidateConfigFile([]byte(content), pkgconfigmodel.ConfigFormatTOML, newTestConf(t))
+	require.NoError(t, err)
+	assert.Equal(t, []ValidationIssue{
+		{Line: 2, Key: "log_level", Message: `invalid value "verbose", expected one of: trace, debug, info, warn, warning, error, critical, off`},
+		{Line: 8, Key: "logs_config.unknown", Message: "unknown setting"},
+	}, issues)
+
+	issues, err = ValidateConfigFile(nil, pkgconfigmodel.ConfigFormatTOML, newTestConf(t))
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestValidateConfigFileEnvVars(t *testing.T) {
+	t.Setenv("TEST_VALIDATE_TIMEOUT", "30")
+	content := "config_env_var_expansion: true\nforwarder_timeout: ${TEST_VALIDATE_TIMEOUT}\nlog_level: ${TEST_VALIDATE_LOG_LEVEL:-verbose}\n"
+	issues, err := ValidateConfigFile([]byte(content), pkgconfigmodel.ConfigFormatYAML, newTestConf(t))
+	require.NoError(t, err)
+	assert.Equal(t, []ValidationIssue{
+		{Line: 3, Key: "log_level", Message: `invalid value "verbose", expected one of: trace, debug, info, warn, warning, error, critical, off`},
+	}, issues)
+}
+
+func TestValidationIssueString(t *testing.T) {
+	assert.Equal(t, "line 2: foo: unknown setting", ValidationIssue{Line: 2, Key: "foo", Message: "unknown setting"}.String())
+	assert.Equal(t, "line 1: not a map", ValidationIssue{Line: 1, Message: "not a map"}.String())
+}
+
+func TestValidateConfigFileStrict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("api_key: abc\nfoo: bar\n"), 0600))
+
+	conf := newTestConf(t)
+	conf.SetConfigFile(path)
+	assert.EqualError(t, validateConfigFileStrict(conf), "found 1 issues in the configuration file "+path+", config_strict_validation is enabled")
+
+	require.NoError(t, os.WriteFile(path, []byte("api_key: abc\n"), 0600))
+	assert.NoError(t, validateConfigFileStrict(conf))
+
+	conf.SetConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NoError(t, validateConfigFileStrict(conf))
+}
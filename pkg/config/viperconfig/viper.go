@@ -646,13 +646,41 @@ func (c *safeConfig) ReadInConfig() error {
 
 	// Merge with base config and 'file' config
 	for _, confFile := range extraConfContents {
-		err = errors.Join(c.Viper.MergeConfig(bytes.NewReader(confFile.content)), c.configSources[model.SourceFile].MergeConfig(bytes.NewReader(confFile.content)))
+		err = errors.Join(mergeConfigContent(c.Viper, confFile.path, confFile.content), mergeConfigContent(c.configSources[model.SourceFile], confFile.path, confFile.content))
 		if err != nil {
 			return fmt.Errorf("error merging %s config file: %w", confFile.path, err)
 		}
 		log.Infof("extra configuration file %s was loaded successfully", confFile.path)
 	}
-	return nil
+	return c.expandFileEnvVars()
+}
+
+// expandFileEnvVars expands the references to environment variables in the
+// values read from the configuration files, see model.ExpandEnvVars, when they
+// enable it.
+func (c *safeConfig) expandFileEnvVars() error {
+	settings := c.configSources[model.SourceFile].AllSettings()
+	if !model.IsEnvVarExpansionEnabled(settings) {
+		return nil
+	}
+	expanded, changed := model.ExpandEnvVarsInValue(settings)
+	if !changed {
+		return nil
+	}
+	settings = expanded.(map[string]interface{})
+	return errors.Join(c.Viper.MergeConfigMap(settings), c.configSources[model.SourceFile].MergeConfigMap(settings))
+}
+
+// mergeConfigContent merges the content of an extra configuration file into v.
+// The content is parsed according to the format of the extra file, which can
+// differ from the format of the main configuration file.
+func mergeConfigContent(v *viper.Viper, path string, content []byte) error {
+	extra := viper.New()
+	extra.SetConfigType(model.ConfigFileFormat(path))
+	if err := extra.ReadConfig(bytes.NewReader(content)); err != nil {
+		return err
+	}
+	return v.MergeConfigMap(extra.AllSettings())
 }
 
 // ReadConfig wraps Viper for concurrent access
@@ -667,7 +695,10 @@ func (c *safeConfig) ReadConfig(in io.Reader) error {
 	if err != nil {
 		return err
 	}
-	return c.configSources[model.SourceFile].ReadConfig(bytes.NewReader(b))
+	if err := c.configSources[model.SourceFile].ReadConfig(bytes.NewReader(b)); err != nil {
+		return err
+	}
+	return c.expandFileEnvVars()
 }
 
 // MergeConfig wraps Viper for concurrent access
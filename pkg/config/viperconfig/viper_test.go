@@ -203,6 +203,42 @@ foo: bar
 	assert.Equal(t, map[string]interface{}{"foo": "bar"}, config.AllSettingsWithoutDefault())
 }
 
+func TestSourceFileReadConfigExpandsEnvVars(t *testing.T) {
+	t.Setenv("TEST_EXPAND_HOST", "example.com")
+	config := NewViperConfig("test", "DD", strings.NewReplacer(".", "_")) // nolint: forbidigo
+	yamlExample := []byte(`
+config_env_var_expansion: true
+foo: https://${TEST_EXPAND_HOST}/api
+section:
+  region: ${TEST_EXPAND_REGION:-us1}
+  list: ["${TEST_EXPAND_HOST}", "$${TEST_EXPAND_HOST}"]
+  token: ${TEST_EXPAND_UNSET}
+`)
+
+	tempfile, err := os.CreateTemp("", "test-*.yaml")
+	assert.NoError(t, err, "failed to create temporary file")
+	tempfile.Write(yamlExample)
+	defer os.Remove(tempfile.Name())
+
+	config.SetConfigFile(tempfile.Name())
+	assert.NoError(t, config.ReadInConfig())
+
+	assert.Equal(t, "https://example.com/api", config.Get("foo"))
+	assert.Equal(t, "us1", config.Get("section.region"))
+	assert.Equal(t, []string{"example.com", "${TEST_EXPAND_HOST}"}, config.GetStringSlice("section.list"))
+	assert.Equal(t, "${TEST_EXPAND_UNSET}", config.Get("section.token"))
+	assert.Equal(t, model.SourceFile, config.GetSource("section.region"))
+}
+
+func TestSourceFileReadConfigEnvVarsDisabled(t *testing.T) {
+	t.Setenv("TEST_EXPAND_HOST", "example.com")
+	config := NewViperConfig("test", "DD", strings.NewReplacer(".", "_")) // nolint: forbidigo
+	config.SetConfigType("yaml")
+	assert.NoError(t, config.ReadConfig(strings.NewReader("foo: https://${TEST_EXPAND_HOST}/api\n")))
+
+	assert.Equal(t, "https://${TEST_EXPAND_HOST}/api", config.Get("foo"))
+}
+
 func TestNotification(t *testing.T) {
 	config := NewViperConfig("test", "DD", strings.NewReplacer(".", "_")) // nolint: forbidigo
 
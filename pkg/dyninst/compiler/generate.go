@@ -29,6 +29,9 @@ type Function struct {
 type Throttler struct {
 	PeriodNs uint64
 	Budget   int64
+	// ProbeIdx is the index in the IR program of the probe the throttler
+	// belongs to.
+	ProbeIdx int
 }
 
 // Program represents stack machine program.
@@ -96,6 +99,7 @@ func GenerateProgram(program *ir.Program) (Program, error) {
 			throttlers = append(throttlers, Throttler{
 				PeriodNs: periodNs,
 				Budget:   throttleConfig.GetThrottleBudget(),
+				ProbeIdx: idx,
 			})
 		}
 	}
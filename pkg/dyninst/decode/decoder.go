@@ -243,6 +243,9 @@ func (s *snapshotMessage) init(
 	}
 	probeEvent := decoder.probeEvents[decoder.entry.rootType.ID]
 	probe := probeEvent.probe
+	maxFieldCount := probe.GetCaptureConfig().GetMaxFieldCount()
+	decoder.entry.maxFieldCount = maxFieldCount
+	decoder._return.maxFieldCount = maxFieldCount
 	header, err := event.EntryOrLine.Header()
 	if err != nil {
 		return probe, fmt.Errorf("error getting header %w", err)
@@ -8,9 +8,11 @@
 package decode
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"slices"
 	"testing"
 	"time"
@@ -850,3 +852,44 @@ func TestDecoderFailsOnEvaluationErrorAndRetainsPassedBuffer(t *testing.T) {
 	require.Equal(t, buf, []byte{1, 2, 3, 4, 5})
 
 }
+
+func TestStructureFieldCountLimit(t *testing.T) {
+	intType := &ir.BaseType{
+		TypeCommon:       ir.TypeCommon{ID: 1, Name: "int", ByteSize: 8},
+		GoTypeAttributes: ir.GoTypeAttributes{GoKind: reflect.Int},
+	}
+	structType := &ir.StructureType{
+		TypeCommon: ir.TypeCommon{ID: 2, Name: "main.point", ByteSize: 24},
+		RawFields: []ir.Field{
+			{Name: "x", Offset: 0, Type: intType},
+			{Name: "_", Offset: 8, Type: intType},
+			{Name: "y", Offset: 16, Type: intType},
+		},
+	}
+	data := make([]byte, 24)
+	binary.NativeEndian.PutUint64(data[0:], 1)
+	binary.NativeEndian.PutUint64(data[16:], 2)
+
+	for _, tc := range []struct {
+		maxFieldCount uint32
+		expected      string
+	}{
+		{0, `{"type":"main.point","fields":{"x":{"type":"int","value":"1"},"y":{"type":"int","value":"2"}}}`},
+		{2, `{"type":"main.point","fields":{"x":{"type":"int","value":"1"},"y":{"type":"int","value":"2"}}}`},
+		{1, `{"type":"main.point","fields":{"x":{"type":"int","value":"1"}},"notCapturedReason":"fieldCount"}`},
+	} {
+		t.Run(fmt.Sprintf("maxFieldCount=%d", tc.maxFieldCount), func(t *testing.T) {
+			c := &encodingContext{
+				typesByID: map[ir.TypeID]decoderType{
+					intType.ID:    (*baseType)(intType),
+					structType.ID: (*structureType)(structType),
+				},
+				maxFieldCount: tc.maxFieldCount,
+			}
+			var buf bytes.Buffer
+			enc := jsontext.NewEncoder(&buf)
+			require.NoError(t, encodeValue(c, enc, structType.ID, data, structType.Name))
+			require.JSONEq(t, tc.expected, buf.String())
+		})
+	}
+}
@@ -108,6 +108,7 @@ func (ce *captureEvent) clear() {
 	ce.rootData = nil
 	ce.rootType = nil
 	ce.evaluationErrors = nil
+	ce.maxFieldCount = 0
 
 	clear(ce.dataItems)
 	clear(ce.currentlyEncoding)
@@ -22,7 +22,7 @@ var (
 	// This is used when we're missing the type information for a value
 	// underneath an interface.
 	tokenNotCapturedReasonMissingTypeInfo = jsontext.String("missing type information")
-	// tokenNotCapturedReasonFieldCount      = jsontext.String("fieldCount")
+	tokenNotCapturedReasonFieldCount      = jsontext.String("fieldCount")
 
 	tokenTruncated = jsontext.String("truncated")
 )
@@ -44,6 +44,9 @@ type encodingContext struct {
 	currentlyEncoding    map[typeAndAddr]struct{}
 	dataItems            map[typeAndAddr]output.DataItem
 	typeResolver         TypeNameResolver
+	// maxFieldCount is the maximum number of fields encoded for each
+	// structure. Zero means no limit.
+	maxFieldCount uint32
 }
 
 // ResolveTypeName implements encodingContext.
@@ -912,7 +915,16 @@ func (s *structureType) encodeValueFields(
 		jsontext.BeginObject); err != nil {
 		return err
 	}
+	var fieldCount uint32
 	for field := range s.irType().(*ir.StructureType).Fields() {
+		if c.maxFieldCount > 0 && fieldCount == c.maxFieldCount {
+			return writeTokens(enc,
+				jsontext.EndObject,
+				tokenNotCapturedReason,
+				tokenNotCapturedReasonFieldCount,
+			)
+		}
+		fieldCount++
 		if err := writeTokens(enc, jsontext.String(field.Name)); err != nil {
 			return err
 		}
@@ -15,9 +15,11 @@
 
 char _license[] SEC("license") = "GPL";
 
+// Number of events dropped by throttling, indexed by throttler. The size is
+// set at load time to the number of throttlers.
 struct {
   __uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
-  __uint(max_entries, 1);
+  __uint(max_entries, 0);
   __type(key, uint32_t);
   __type(value, uint64_t);
 } throttled_events SEC(".maps");
@@ -79,6 +81,10 @@ SEC("uprobe")
 int probe_run_with_cookie(struct pt_regs* regs) {
   uint64_t start_ns = bpf_ktime_get_ns();
 
+  if (target_tgid != 0 && (bpf_get_current_pid_tgid() >> 32) != target_tgid) {
+    return 0;
+  }
+
   const uint64_t cookie = bpf_get_attach_cookie(regs);
   if (cookie >= num_probe_params) {
     return 0;
@@ -89,9 +95,10 @@ int probe_run_with_cookie(struct pt_regs* regs) {
   }
 
   if (params->kind != EVENT_KIND_RETURN) {
-    if (should_throttle(params->throttler_idx, start_ns)) {
-      uint32_t zero = 0;
-      uint64_t* cnt = bpf_map_lookup_elem(&throttled_events, &zero);
+    if (should_throttle(params->throttler_idx, start_ns) ||
+        should_throttle_global(start_ns)) {
+      uint32_t throttler_idx = params->throttler_idx;
+      uint64_t* cnt = bpf_map_lookup_elem(&throttled_events, &throttler_idx);
       if (cnt) {
         ++*cnt;
       }
@@ -16,6 +16,10 @@ volatile const uint32_t OFFSET_runtime_dot_stack__hi = 0;
 
 volatile const uint32_t prog_id = 0;
 
+// If non-zero, the only process (in the initial PID namespace) whose events
+// are emitted. Used when the uprobes cannot be attached to a single process.
+volatile const uint32_t target_tgid = 0;
+
 struct {
   __uint(type, BPF_MAP_TYPE_ARRAY);
   __uint(max_entries, 0);
@@ -15,11 +15,19 @@ struct {
   __type(value, throttler_t);
 } throttler_buf SEC(".maps");
 
-static bool should_throttle(uint32_t throttler_idx, uint64_t start_ns) {
-  throttler_t* throttler = (throttler_t*)bpf_map_lookup_elem(&throttler_buf, &throttler_idx);
-  if (!throttler) {
-    return true;
-  }
+// Throttling parameters shared by all the probes of the program. A zero
+// budget disables the global throttler.
+volatile const uint64_t global_throttle_period_ns = 0;
+volatile const uint64_t global_throttle_budget = 0;
+
+struct {
+  __uint(type, BPF_MAP_TYPE_ARRAY);
+  __uint(max_entries, 1);
+  __type(key, uint32_t);
+  __type(value, throttler_t);
+} global_throttler_buf SEC(".maps");
+
+static bool throttle(throttler_t* throttler, const throttler_params_t* params, uint64_t start_ns) {
   // Try twice to determine throttling result.
   for (int i = 0; i < 2; i++) {
     // Check if we are within budget. First do only a memory read, to avoid
@@ -31,10 +39,6 @@ static bool should_throttle(uint32_t throttler_idx, uint64_t start_ns) {
     }
     // We are out of budget, check if throttling period passed and budget
     // could be refreshed.
-    throttler_params_t* params = bpf_map_lookup_elem(&throttler_params, &throttler_idx);
-    if (!params) {
-      return true;
-    }
     if (throttler->last_probe_run_ns > 0 && start_ns - throttler->last_probe_run_ns < params->period_ns) {
       return true;
     }
@@ -56,4 +60,32 @@ static bool should_throttle(uint32_t throttler_idx, uint64_t start_ns) {
   return true;
 }
 
+static bool should_throttle(uint32_t throttler_idx, uint64_t start_ns) {
+  throttler_t* throttler = (throttler_t*)bpf_map_lookup_elem(&throttler_buf, &throttler_idx);
+  if (!throttler) {
+    return true;
+  }
+  throttler_params_t* params = bpf_map_lookup_elem(&throttler_params, &throttler_idx);
+  if (!params) {
+    return true;
+  }
+  return throttle(throttler, params, start_ns);
+}
+
+static bool should_throttle_global(uint64_t start_ns) {
+  if (global_throttle_budget == 0) {
+    return false;
+  }
+  const uint32_t zero = 0;
+  throttler_t* throttler = (throttler_t*)bpf_map_lookup_elem(&global_throttler_buf, &zero);
+  if (!throttler) {
+    return true;
+  }
+  const throttler_params_t params = {
+      .period_ns = global_throttle_period_ns,
+      .budget = (int64_t)global_throttle_budget,
+  };
+  return throttle(throttler, &params, start_ns);
+}
+
 #endif // __THROTTLER_H__
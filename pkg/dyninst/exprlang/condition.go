@@ -0,0 +1,201 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package exprlang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// LiteralExpr represents a constant value.
+type LiteralExpr struct {
+	// Value is either nil, a bool, a float64 or a string.
+	Value any
+}
+
+func (le *LiteralExpr) expr() {}
+
+// GetMemberExpr represents the access to a field of a struct.
+type GetMemberExpr struct {
+	Object Expr
+	Member string
+}
+
+func (ge *GetMemberExpr) expr() {}
+
+// IndexExpr represents the access to an element of a collection, or to the
+// value of a map by key.
+type IndexExpr struct {
+	Collection Expr
+	Key        Expr
+}
+
+func (ie *IndexExpr) expr() {}
+
+// UnaryExpr represents an operation with a single operand.
+type UnaryExpr struct {
+	// Operation is one of "not", "len", "count" and "isEmpty".
+	Operation string
+	Operand   Expr
+}
+
+func (ue *UnaryExpr) expr() {}
+
+// BinaryExpr represents an operation with two operands.
+type BinaryExpr struct {
+	// Operation is one of "eq", "ne", "lt", "le", "gt", "ge", "and", "or",
+	// "contains", "startsWith" and "endsWith".
+	Operation string
+	Left      Expr
+	Right     Expr
+}
+
+func (be *BinaryExpr) expr() {}
+
+var unaryOperations = []string{"not", "len", "count", "isEmpty"}
+
+var binaryOperations = []string{
+	"eq", "ne", "lt", "le", "gt", "ge",
+	"and", "or",
+	"contains", "startsWith", "endsWith",
+}
+
+// ParseCondition parses the JSON representation of a probe condition into an
+// expression tree. Unlike Parse, it parses nested operations and returns an
+// error for operations that cannot be evaluated.
+func ParseCondition(dslJSON []byte) (Expr, error) {
+	if len(dslJSON) == 0 {
+		return nil, fmt.Errorf("parse error: empty DSL expression")
+	}
+	dec := json.NewDecoder(bytes.NewReader(dslJSON))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("parse error: unexpected data after expression")
+	}
+	return parseConditionValue(v)
+}
+
+func parseConditionValue(v any) (Expr, error) {
+	switch v := v.(type) {
+	case nil, bool, string:
+		return &LiteralExpr{Value: v}, nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("parse error: invalid number %s: %w", v, err)
+		}
+		return &LiteralExpr{Value: f}, nil
+	case map[string]any:
+		if len(v) != 1 {
+			return nil, fmt.Errorf(
+				"parse error: malformed DSL: expected a single operation, got %d", len(v),
+			)
+		}
+		for operation, argument := range v {
+			return parseOperation(operation, argument)
+		}
+	}
+	return nil, fmt.Errorf("parse error: malformed DSL: unexpected value %v", v)
+}
+
+func parseOperation(operation string, argument any) (Expr, error) {
+	switch {
+	case operation == "ref":
+		ref, ok := argument.(string)
+		if !ok {
+			return nil, fmt.Errorf("parse error: malformed ref: expected string, got %v", argument)
+		}
+		if ref == "" {
+			return nil, fmt.Errorf("parse error: ref value cannot be empty")
+		}
+		return &RefExpr{Ref: ref}, nil
+	case operation == "getmember":
+		args, err := parseArguments(operation, argument)
+		if err != nil {
+			return nil, err
+		}
+		literal, ok := args[1].(*LiteralExpr)
+		if !ok {
+			return nil, fmt.Errorf("parse error: getmember expects a member name")
+		}
+		member, ok := literal.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("parse error: getmember expects a member name")
+		}
+		return &GetMemberExpr{Object: args[0], Member: member}, nil
+	case operation == "index":
+		args, err := parseArguments(operation, argument)
+		if err != nil {
+			return nil, err
+		}
+		return &IndexExpr{Collection: args[0], Key: args[1]}, nil
+	case slices.Contains(unaryOperations, operation):
+		operand, err := parseConditionValue(argument)
+		if err != nil {
+			return nil, err
+		}
+		return &UnaryExpr{Operation: operation, Operand: operand}, nil
+	case slices.Contains(binaryOperations, operation):
+		args, err := parseArguments(operation, argument)
+		if err != nil {
+			return nil, err
+		}
+		return &BinaryExpr{Operation: operation, Left: args[0], Right: args[1]}, nil
+	default:
+		return nil, fmt.Errorf("parse error: unsupported operation %q", operation)
+	}
+}
+
+// parseArguments parses the two arguments of an operation.
+func parseArguments(operation string, argument any) ([2]Expr, error) {
+	var ret [2]Expr
+	args, ok := argument.([]any)
+	if !ok || len(args) != 2 {
+		return ret, fmt.Errorf("parse error: %s expects 2 arguments", operation)
+	}
+	for i, arg := range args {
+		expr, err := parseConditionValue(arg)
+		if err != nil {
+			return ret, err
+		}
+		ret[i] = expr
+	}
+	return ret, nil
+}
+
+// Refs returns the variables referenced by the expression.
+func Refs(expr Expr) []string {
+	var refs []string
+	var visit func(Expr)
+	visit = func(expr Expr) {
+		switch e := expr.(type) {
+		case *RefExpr:
+			if !slices.Contains(refs, e.Ref) {
+				refs = append(refs, e.Ref)
+			}
+		case *GetMemberExpr:
+			visit(e.Object)
+		case *IndexExpr:
+			visit(e.Collection)
+			visit(e.Key)
+		case *UnaryExpr:
+			visit(e.Operand)
+		case *BinaryExpr:
+			visit(e.Left)
+			visit(e.Right)
+		}
+	}
+	visit(expr)
+	return refs
+}
@@ -0,0 +1,136 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package exprlang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mapEnv map[string]any
+
+func (m mapEnv) Lookup(ref string) (any, error) {
+	v, ok := m[ref]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %q", ref)
+	}
+	return v, nil
+}
+
+type testObject struct {
+	fields   map[string]any
+	elements []any
+}
+
+func (o *testObject) Member(name string) (any, error) {
+	v, ok := o.fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown member %q", name)
+	}
+	return v, nil
+}
+
+func (o *testObject) Index(key any) (any, error) {
+	i, ok := key.(float64)
+	if !ok || int(i) < 0 || int(i) >= len(o.elements) {
+		return nil, fmt.Errorf("invalid index %v", key)
+	}
+	return o.elements[int(i)], nil
+}
+
+func (o *testObject) Len() (int, error) { return len(o.elements), nil }
+
+func TestParseCondition(t *testing.T) {
+	expr, err := ParseCondition([]byte(
+		`{"or": [{"eq": [{"getmember": [{"ref": "arg1"}, "UserID"]}, "x"]}, {"gt": [{"len": {"ref": "arg2"}}, 100]}]}`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, &BinaryExpr{
+		Operation: "or",
+		Left: &BinaryExpr{
+			Operation: "eq",
+			Left:      &GetMemberExpr{Object: &RefExpr{Ref: "arg1"}, Member: "UserID"},
+			Right:     &LiteralExpr{Value: "x"},
+		},
+		Right: &BinaryExpr{
+			Operation: "gt",
+			Left:      &UnaryExpr{Operation: "len", Operand: &RefExpr{Ref: "arg2"}},
+			Right:     &LiteralExpr{Value: float64(100)},
+		},
+	}, expr)
+	require.Equal(t, []string{"arg1", "arg2"}, Refs(expr))
+
+	for _, input := range []string{
+		``,
+		`{}`,
+		`{"ref": ""}`,
+		`{"ref": 1}`,
+		`{"eq": [1]}`,
+		`{"getmember": [{"ref": "a"}, 1]}`,
+		`{"matches": [{"ref": "a"}, "[0-9]+"]}`,
+		`{"any": [{"ref": "a"}, {"isEmpty": {"ref": "@it"}}]}`,
+		`{"ref": "a"} {"ref": "b"}`,
+	} {
+		_, err := ParseCondition([]byte(input))
+		require.Error(t, err, "input: %s", input)
+	}
+}
+
+func TestEvalCondition(t *testing.T) {
+	env := mapEnv{
+		"user": &testObject{fields: map[string]any{
+			"ID":   "x",
+			"Age":  float64(42),
+			"Next": nil,
+		}},
+		"items": &testObject{elements: []any{"a", "b", "c"}},
+		"name":  "hello world",
+		"flag":  true,
+	}
+	for _, tc := range []struct {
+		condition string
+		expected  bool
+		err       string
+	}{
+		{condition: `true`, expected: true},
+		{condition: `{"eq": [{"getmember": [{"ref": "user"}, "ID"]}, "x"]}`, expected: true},
+		{condition: `{"ne": [{"getmember": [{"ref": "user"}, "ID"]}, "x"]}`, expected: false},
+		{condition: `{"eq": [{"getmember": [{"ref": "user"}, "Next"]}, null]}`, expected: true},
+		{condition: `{"ge": [{"getmember": [{"ref": "user"}, "Age"]}, 42]}`, expected: true},
+		{condition: `{"lt": [{"getmember": [{"ref": "user"}, "Age"]}, 42]}`, expected: false},
+		{condition: `{"gt": [{"len": {"ref": "items"}}, 2]}`, expected: true},
+		{condition: `{"eq": [{"index": [{"ref": "items"}, 1]}, "b"]}`, expected: true},
+		{condition: `{"isEmpty": {"ref": "name"}}`, expected: false},
+		{condition: `{"not": {"ref": "flag"}}`, expected: false},
+		{condition: `{"contains": [{"ref": "name"}, "o w"]}`, expected: true},
+		{condition: `{"startsWith": [{"ref": "name"}, "hello"]}`, expected: true},
+		{condition: `{"endsWith": [{"ref": "name"}, "hello"]}`, expected: false},
+		// The right operand is not evaluated when the result is known.
+		{condition: `{"or": [{"ref": "flag"}, {"ref": "unknown"}]}`, expected: true},
+		{condition: `{"and": [{"not": {"ref": "flag"}}, {"ref": "unknown"}]}`, expected: false},
+		{condition: `{"and": [{"ref": "flag"}, {"ref": "unknown"}]}`, err: `unknown variable "unknown"`},
+		{condition: `{"ref": "name"}`, err: "condition evaluated to a string, expected a bool"},
+		{condition: `{"gt": [{"ref": "name"}, 1]}`, err: "cannot compare a string and a number"},
+		{condition: `{"eq": [{"ref": "user"}, null]}`, err: "cannot compare an object"},
+		{condition: `{"getmember": [{"ref": "name"}, "x"]}`, err: `cannot get member "x" of a string`},
+	} {
+		t.Run(tc.condition, func(t *testing.T) {
+			expr, err := ParseCondition([]byte(tc.condition))
+			require.NoError(t, err)
+			result, err := EvalCondition(expr, env)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
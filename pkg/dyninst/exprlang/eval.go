@@ -0,0 +1,240 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package exprlang
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// Object is a structured value that expressions can navigate, e.g. a struct,
+// a collection or a map.
+type Object interface {
+	// Member returns the value of the named field.
+	Member(name string) (any, error)
+	// Index returns the element at the given index of a collection, or the
+	// value for the given key of a map.
+	Index(key any) (any, error)
+	// Len returns the number of elements of a collection or map.
+	Len() (int, error)
+}
+
+// Env resolves the variables referenced by expressions.
+type Env interface {
+	// Lookup returns the value of the named variable.
+	Lookup(ref string) (any, error)
+}
+
+// Eval evaluates an expression parsed by ParseCondition. Values, including
+// the ones returned by Env and Object, are either nil, a bool, a float64, a
+// string or an Object.
+func Eval(expr Expr, env Env) (any, error) {
+	switch e := expr.(type) {
+	case *LiteralExpr:
+		return e.Value, nil
+	case *RefExpr:
+		return env.Lookup(e.Ref)
+	case *GetMemberExpr:
+		v, err := Eval(e.Object, env)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := v.(Object)
+		if !ok {
+			return nil, fmt.Errorf("cannot get member %q of %s", e.Member, describe(v))
+		}
+		return obj.Member(e.Member)
+	case *IndexExpr:
+		v, err := Eval(e.Collection, env)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := v.(Object)
+		if !ok {
+			return nil, fmt.Errorf("cannot index %s", describe(v))
+		}
+		key, err := Eval(e.Key, env)
+		if err != nil {
+			return nil, err
+		}
+		return obj.Index(key)
+	case *UnaryExpr:
+		return evalUnary(e, env)
+	case *BinaryExpr:
+		return evalBinary(e, env)
+	default:
+		return nil, fmt.Errorf("unsupported expression %T", expr)
+	}
+}
+
+// EvalCondition evaluates an expression that must result in a bool.
+func EvalCondition(expr Expr, env Env) (bool, error) {
+	v, err := Eval(expr, env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition evaluated to %s, expected a bool", describe(v))
+	}
+	return b, nil
+}
+
+func evalUnary(e *UnaryExpr, env Env) (any, error) {
+	v, err := Eval(e.Operand, env)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Operation {
+	case "not":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("not expects a bool, got %s", describe(v))
+		}
+		return !b, nil
+	case "len", "count":
+		n, err := length(v)
+		if err != nil {
+			return nil, err
+		}
+		return float64(n), nil
+	case "isEmpty":
+		n, err := length(v)
+		if err != nil {
+			return nil, err
+		}
+		return n == 0, nil
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", e.Operation)
+	}
+}
+
+func evalBinary(e *BinaryExpr, env Env) (any, error) {
+	left, err := Eval(e.Left, env)
+	if err != nil {
+		return nil, err
+	}
+	// Boolean operators short-circuit.
+	if e.Operation == "and" || e.Operation == "or" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s expects bools, got %s", e.Operation, describe(left))
+		}
+		if l == (e.Operation == "or") {
+			return l, nil
+		}
+		right, err := Eval(e.Right, env)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s expects bools, got %s", e.Operation, describe(right))
+		}
+		return r, nil
+	}
+	right, err := Eval(e.Right, env)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Operation {
+	case "eq", "ne":
+		eq, err := equal(left, right)
+		if err != nil {
+			return nil, err
+		}
+		return eq == (e.Operation == "eq"), nil
+	case "lt", "le", "gt", "ge":
+		c, err := compare(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch e.Operation {
+		case "lt":
+			return c < 0, nil
+		case "le":
+			return c <= 0, nil
+		case "gt":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case "contains", "startsWith", "endsWith":
+		s, ok := left.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a string, got %s", e.Operation, describe(left))
+		}
+		sub, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s expects a string, got %s", e.Operation, describe(right))
+		}
+		switch e.Operation {
+		case "contains":
+			return strings.Contains(s, sub), nil
+		case "startsWith":
+			return strings.HasPrefix(s, sub), nil
+		default:
+			return strings.HasSuffix(s, sub), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", e.Operation)
+	}
+}
+
+func length(v any) (int, error) {
+	switch v := v.(type) {
+	case string:
+		return len(v), nil
+	case Object:
+		return v.Len()
+	default:
+		return 0, fmt.Errorf("cannot take the length of %s", describe(v))
+	}
+}
+
+func equal(a, b any) (bool, error) {
+	if _, ok := a.(Object); ok {
+		return false, fmt.Errorf("cannot compare %s", describe(a))
+	}
+	if _, ok := b.(Object); ok {
+		return false, fmt.Errorf("cannot compare %s", describe(b))
+	}
+	return a == b, nil
+}
+
+func compare(a, b any) (int, error) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return cmp.Compare(a, b), nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %s and %s", describe(a), describe(b))
+}
+
+func describe(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a bool"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case Object:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -30,6 +30,30 @@ type ProbeDefinition interface {
 	GetThrottleConfig() ThrottleConfig
 }
 
+// ConditionalProbeDefinition is implemented by probe definitions that may
+// carry a condition that must hold for an event to be emitted.
+type ConditionalProbeDefinition interface {
+	ProbeDefinition
+	// GetCondition returns the condition of the probe in the expression
+	// language, both as written by the user and as JSON. The JSON is empty if
+	// the probe has no condition.
+	GetCondition() (dsl string, dslJSON []byte)
+}
+
+// MetricProbeDefinition is implemented by the definitions of probes that
+// update a metric.
+type MetricProbeDefinition interface {
+	ProbeDefinition
+	// GetMetricKind returns the kind of the metric, e.g. COUNT or GAUGE.
+	GetMetricKind() string
+	// GetMetricName returns the name of the metric.
+	GetMetricName() string
+	// GetMetricValue returns the expression of the value of the metric,
+	// both as written by the user and as JSON. The JSON is empty if the
+	// probe has no value, in which case each hit counts as 1.
+	GetMetricValue() (dsl string, dslJSON []byte)
+}
+
 // CompareProbeIDs compares two probe definitions by their ID and version.
 func CompareProbeIDs[A, B ProbeIDer](a A, b B) int {
 	return cmp.Or(
@@ -45,6 +45,7 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/DataDog/datadog-agent/pkg/dyninst/dwarf/loclist"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/exprlang"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/gotype"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/object"
@@ -2292,6 +2293,10 @@ func newProbe(
 		}, nil
 	}
 
+	if issue := checkProbeExpressions(probeCfg, subprogram); !issue.IsNone() {
+		return nil, issue, nil
+	}
+
 	if subprogram.OutOfLinePCRanges == nil && len(subprogram.InlinePCRanges) == 0 {
 		return nil, ir.Issue{
 			Kind:    ir.IssueKindMalformedExecutable,
@@ -2376,6 +2381,53 @@ func newProbe(
 	return probe, ir.Issue{}, nil
 }
 
+// checkProbeExpressions ensures that the expressions of the probe, i.e. its
+// condition and metric value, can be parsed and only reference variables of
+// the subprogram.
+func checkProbeExpressions(probeCfg ir.ProbeDefinition, subprogram *ir.Subprogram) ir.Issue {
+	if conditional, ok := probeCfg.(ir.ConditionalProbeDefinition); ok {
+		dsl, dslJSON := conditional.GetCondition()
+		if issue := checkExpression("condition", dsl, dslJSON, subprogram); !issue.IsNone() {
+			return issue
+		}
+	}
+	if metric, ok := probeCfg.(ir.MetricProbeDefinition); ok {
+		dsl, dslJSON := metric.GetMetricValue()
+		if issue := checkExpression("metric value", dsl, dslJSON, subprogram); !issue.IsNone() {
+			return issue
+		}
+	}
+	return ir.Issue{}
+}
+
+func checkExpression(
+	what string, dsl string, dslJSON []byte, subprogram *ir.Subprogram,
+) ir.Issue {
+	if len(dslJSON) == 0 {
+		return ir.Issue{}
+	}
+	expr, err := exprlang.ParseCondition(dslJSON)
+	if err != nil {
+		return ir.Issue{
+			Kind:    ir.IssueKindInvalidProbeDefinition,
+			Message: fmt.Sprintf("invalid %s %q: %v", what, dsl, err),
+		}
+	}
+	for _, ref := range exprlang.Refs(expr) {
+		if !slices.ContainsFunc(subprogram.Variables, func(v *ir.Variable) bool {
+			return v.Name == ref
+		}) {
+			return ir.Issue{
+				Kind: ir.IssueKindInvalidProbeDefinition,
+				Message: fmt.Sprintf(
+					"invalid %s %q: unknown variable %q", what, dsl, ref,
+				),
+			}
+		}
+	}
+	return ir.Issue{}
+}
+
 // Returns a list of injection points for a given probe, as well as optional
 // return event, if required.
 func pickInjectionPoint(
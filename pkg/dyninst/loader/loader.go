@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/cilium/ebpf"
@@ -54,11 +55,25 @@ func WithDebugLevel(level int) Option {
 	return debugLevelOption(level)
 }
 
+// WithGlobalThrottle limits the number of events each loaded program may emit
+// per second, across all its probes. Zero disables the limit.
+func WithGlobalThrottle(eventsPerSecond uint32) Option {
+	return globalThrottleOption(eventsPerSecond)
+}
+
 // WithAdditionalSerializer sets an additional serializer for the ebpf program.
 func WithAdditionalSerializer(serializer compiler.CodeSerializer) Option {
 	return additionalSerializerOption{serializer}
 }
 
+// WithTargetPID restricts the loaded program to the events of a single
+// process, identified by its PID in the initial PID namespace. This allows the
+// program to be attached to all the processes running an executable when the
+// PID cannot be used to filter the uprobes.
+func WithTargetPID(pid uint32) LoadOption {
+	return targetPIDOption(pid)
+}
+
 // NewLoader creates a new Loader.
 func NewLoader(opts ...Option) (*Loader, error) {
 	l := &Loader{}
@@ -71,7 +86,11 @@ func NewLoader(opts ...Option) (*Loader, error) {
 }
 
 // Load loads the program.
-func (l *Loader) Load(program compiler.Program) (*Program, error) {
+func (l *Loader) Load(program compiler.Program, opts ...LoadOption) (*Program, error) {
+	var cfg loadConfig
+	for _, opt := range opts {
+		opt.applyLoad(&cfg)
+	}
 	serialized, err := serializeProgram(program, l.additionalSerializer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize program: %w", err)
@@ -92,16 +111,20 @@ func (l *Loader) Load(program compiler.Program) (*Program, error) {
 		}
 	}()
 
+	if err := setVariable(spec, "target_tgid", cfg.targetPID); err != nil {
+		return nil, err
+	}
+
 	ringbufMapSpec, ok := spec.Maps[ringbufMapName]
 	if !ok {
 		return nil, fmt.Errorf("ringbuffer map not found in eBPF spec")
 	}
 	ringbufMapSpec.MaxEntries = uint32(l.config.ringBufSize)
 
-	opts := ebpf.CollectionOptions{}
-	opts.MapReplacements = maps
-	opts.MapReplacements[ringbufMapName] = l.ringbufMap
-	collection, err := ebpf.NewCollectionWithOptions(spec, opts)
+	collectionOpts := ebpf.CollectionOptions{}
+	collectionOpts.MapReplacements = maps
+	collectionOpts.MapReplacements[ringbufMapName] = l.ringbufMap
+	collection, err := ebpf.NewCollectionWithOptions(spec, collectionOpts)
 	if err != nil {
 		var ve *ebpf.VerifierError
 		if errors.As(err, &ve) {
@@ -119,6 +142,7 @@ func (l *Loader) Load(program compiler.Program) (*Program, error) {
 		Collection:   collection,
 		BpfProgram:   bpfProgram,
 		Attachpoints: serialized.bpfAttachPoints,
+		TargetPID:    cfg.targetPID,
 	}, nil
 }
 
@@ -170,6 +194,32 @@ type Program struct {
 	Collection   *ebpf.Collection
 	BpfProgram   *ebpf.Program
 	Attachpoints []BPFAttachPoint
+	// TargetPID is the only process whose events are emitted by the program,
+	// or zero if the program is not restricted to a process.
+	TargetPID uint32
+}
+
+// ThrottledEvents returns the number of events dropped by throttling, indexed
+// by throttler.
+func (p *Program) ThrottledEvents() ([]uint64, error) {
+	if p == nil || p.Collection == nil {
+		return nil, nil
+	}
+	m, ok := p.Collection.Maps[throttledEventsMapName]
+	if !ok {
+		return nil, fmt.Errorf("%s map not found in collection", throttledEventsMapName)
+	}
+	counts := make([]uint64, m.MaxEntries())
+	var perCPU []uint64
+	for i := range counts {
+		if err := m.Lookup(uint32(i), &perCPU); err != nil {
+			return nil, fmt.Errorf("failed to lookup %s: %w", throttledEventsMapName, err)
+		}
+		for _, v := range perCPU {
+			counts[i] += v
+		}
+	}
+	return counts, nil
 }
 
 // Close releases the program resources.
@@ -181,12 +231,15 @@ func (p *Program) Close() {
 
 const defaultRingbufSize = 1 << 20 // 1 MiB
 const ringbufMapName = "out_ringbuf"
+const throttledEventsMapName = "throttled_events"
 
 type config struct {
 	ebpfConfig *ddebpf.Config
 
 	ringBufSize uint32
 
+	globalThrottleBudget uint32
+
 	dyninstDebugLevel   uint8
 	dyninstDebugEnabled bool
 
@@ -210,6 +263,12 @@ func (o ringBufSizeOption) apply(c *config) {
 	c.ringBufSize = uint32(o)
 }
 
+type globalThrottleOption uint32
+
+func (o globalThrottleOption) apply(c *config) {
+	c.globalThrottleBudget = uint32(o)
+}
+
 type debugLevelOption uint8
 
 func (o debugLevelOption) apply(c *config) {
@@ -225,6 +284,21 @@ func (o additionalSerializerOption) apply(c *config) {
 	c.additionalSerializer = o
 }
 
+// LoadOption configures the loading of a single program.
+type LoadOption interface {
+	applyLoad(c *loadConfig)
+}
+
+type loadConfig struct {
+	targetPID uint32
+}
+
+type targetPIDOption uint32
+
+func (o targetPIDOption) applyLoad(c *loadConfig) {
+	c.targetPID = uint32(o)
+}
+
 func (l *Loader) init(opts ...Option) error {
 	l.config.ringBufSize = defaultRingbufSize
 	for _, opt := range opts {
@@ -416,6 +490,23 @@ func (l *Loader) loadData(
 	}
 	mapSpec.MaxEntries = uint32(len(serialized.throttlerParams))
 
+	mapSpec, ok = spec.Maps[throttledEventsMapName]
+	if !ok {
+		return nil, fmt.Errorf("%s map not found in eBPF spec", throttledEventsMapName)
+	}
+	mapSpec.MaxEntries = uint32(len(serialized.throttlerParams))
+
+	if err := setVariable(
+		spec, "global_throttle_period_ns", uint64(time.Second),
+	); err != nil {
+		return nil, fmt.Errorf("failed to set global_throttle_period_ns: %w", err)
+	}
+	if err := setVariable(
+		spec, "global_throttle_budget", uint64(l.config.globalThrottleBudget),
+	); err != nil {
+		return nil, fmt.Errorf("failed to set global_throttle_budget: %w", err)
+	}
+
 	mapSpec, probeParamsMap, err := makeArrayMap(
 		probeParamsMapName, serialized.probeParams, allowMultipleMapEntries,
 	)
@@ -0,0 +1,242 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/exprlang"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// probeCondition is the condition a probe's events must satisfy to be
+// emitted.
+//
+// Conditions are evaluated in userspace on the captured values of the
+// decoded event, so events that do not satisfy the condition still count
+// against the probe's sampling budget in the eBPF program.
+type probeCondition struct {
+	dsl  string
+	expr exprlang.Expr
+}
+
+// makeProbeConditions parses the conditions of the probes of a program,
+// indexed by probe ID. Probes without a condition are omitted.
+func makeProbeConditions(probes []*ir.Probe) map[string]*probeCondition {
+	var conditions map[string]*probeCondition
+	for _, probe := range probes {
+		conditional, ok := probe.ProbeDefinition.(ir.ConditionalProbeDefinition)
+		if !ok {
+			continue
+		}
+		dsl, dslJSON := conditional.GetCondition()
+		if len(dslJSON) == 0 {
+			continue
+		}
+		// The condition has already been validated when generating the
+		// program, so this is not expected to fail.
+		expr, err := exprlang.ParseCondition(dslJSON)
+		if err != nil {
+			log.Warnf("ignoring invalid condition of probe %s: %v", probe.GetID(), err)
+			continue
+		}
+		if conditions == nil {
+			conditions = make(map[string]*probeCondition)
+		}
+		conditions[probe.GetID()] = &probeCondition{dsl: dsl, expr: expr}
+	}
+	return conditions
+}
+
+// eval evaluates the condition against the captures of a decoded event.
+func (c *probeCondition) eval(decoded []byte) (bool, error) {
+	env, err := newCaptureEnv(decoded)
+	if err != nil {
+		return false, err
+	}
+	return exprlang.EvalCondition(c.expr, env)
+}
+
+// capturesSnapshot is the part of a decoded event that holds the captured
+// values.
+type capturesSnapshot struct {
+	Debugger struct {
+		Snapshot struct {
+			Captures struct {
+				Entry  *capturedVariables            `json:"entry"`
+				Return *capturedVariables            `json:"return"`
+				Lines  map[string]*capturedVariables `json:"lines"`
+			} `json:"captures"`
+		} `json:"snapshot"`
+	} `json:"debugger"`
+}
+
+type capturedVariables struct {
+	Arguments map[string]*capturedValue `json:"arguments"`
+	Locals    map[string]*capturedValue `json:"locals"`
+}
+
+// capturedValue is the representation of a value in a snapshot.
+type capturedValue struct {
+	Type              string                    `json:"type"`
+	Value             *string                   `json:"value"`
+	IsNull            bool                      `json:"isNull"`
+	Truncated         bool                      `json:"truncated"`
+	Size              string                    `json:"size"`
+	NotCapturedReason string                    `json:"notCapturedReason"`
+	Fields            map[string]*capturedValue `json:"fields"`
+	Elements          []*capturedValue          `json:"elements"`
+	Entries           [][2]*capturedValue       `json:"entries"`
+}
+
+// captureEnv resolves references to the captured variables of an event.
+type captureEnv map[string]*capturedValue
+
+var _ exprlang.Env = captureEnv(nil)
+
+// newCaptureEnv makes the captured variables of a decoded event available to
+// expressions. Variables captured at return shadow the ones captured at entry.
+func newCaptureEnv(decoded []byte) (captureEnv, error) {
+	var snapshot capturesSnapshot
+	if err := json.Unmarshal(decoded, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+	captures := snapshot.Debugger.Snapshot.Captures
+	env := make(captureEnv)
+	env.add(captures.Entry)
+	for _, line := range captures.Lines {
+		env.add(line)
+	}
+	env.add(captures.Return)
+	return env, nil
+}
+
+func (e captureEnv) add(vars *capturedVariables) {
+	if vars == nil {
+		return
+	}
+	for name, v := range vars.Arguments {
+		e[name] = v
+	}
+	for name, v := range vars.Locals {
+		e[name] = v
+	}
+}
+
+func (e captureEnv) Lookup(ref string) (any, error) {
+	v, ok := e[ref]
+	if !ok {
+		return nil, fmt.Errorf("variable %q was not captured", ref)
+	}
+	return v.toExprValue()
+}
+
+var errTruncated = errors.New("value is truncated")
+
+// toExprValue converts the captured value to a value of the expression
+// language.
+func (v *capturedValue) toExprValue() (any, error) {
+	if v == nil {
+		return nil, errors.New("missing value")
+	}
+	switch {
+	case v.IsNull:
+		return nil, nil
+	case v.NotCapturedReason != "":
+		return nil, fmt.Errorf("value of type %s not captured: %s", v.Type, v.NotCapturedReason)
+	case v.Fields != nil, v.Elements != nil, v.Entries != nil:
+		return (*capturedObject)(v), nil
+	case v.Value == nil:
+		return nil, fmt.Errorf("value of type %s not captured", v.Type)
+	}
+	switch {
+	case v.Type == "string":
+		if v.Truncated {
+			return (*capturedObject)(v), nil
+		}
+		return *v.Value, nil
+	case v.Type == "bool":
+		return strconv.ParseBool(*v.Value)
+	case isNumericType(v.Type):
+		return strconv.ParseFloat(*v.Value, 64)
+	default:
+		return nil, fmt.Errorf("unsupported value of type %s", v.Type)
+	}
+}
+
+func isNumericType(t string) bool {
+	t = strings.TrimLeft(t, "u")
+	return strings.HasPrefix(t, "int") ||
+		strings.HasPrefix(t, "float") ||
+		t == "byte" || t == "rune"
+}
+
+// capturedObject exposes structs, collections, maps and truncated strings to
+// the expression language.
+type capturedObject capturedValue
+
+var _ exprlang.Object = (*capturedObject)(nil)
+
+func (o *capturedObject) Member(name string) (any, error) {
+	f, ok := o.Fields[name]
+	if !ok {
+		return nil, fmt.Errorf("%s has no captured field %q", o.Type, name)
+	}
+	return f.toExprValue()
+}
+
+func (o *capturedObject) Index(key any) (any, error) {
+	if o.Truncated {
+		return nil, errTruncated
+	}
+	if o.Entries != nil {
+		for _, entry := range o.Entries {
+			k, err := entry[0].toExprValue()
+			if err != nil {
+				continue
+			}
+			if k == key {
+				return entry[1].toExprValue()
+			}
+		}
+		if n, _ := o.Len(); n > len(o.Entries) {
+			return nil, errTruncated
+		}
+		return nil, fmt.Errorf("%s has no key %v", o.Type, key)
+	}
+	i, ok := key.(float64)
+	if !ok || i < 0 || i != float64(int(i)) {
+		return nil, fmt.Errorf("invalid index %v for %s", key, o.Type)
+	}
+	if int(i) >= len(o.Elements) {
+		if n, _ := o.Len(); int(i) < n {
+			return nil, errTruncated
+		}
+		return nil, fmt.Errorf("index %d out of range for %s", int(i), o.Type)
+	}
+	return o.Elements[int(i)].toExprValue()
+}
+
+func (o *capturedObject) Len() (int, error) {
+	if o.Size != "" {
+		return strconv.Atoi(o.Size)
+	}
+	switch {
+	case o.Elements != nil:
+		return len(o.Elements), nil
+	case o.Entries != nil:
+		return len(o.Entries), nil
+	default:
+		return 0, fmt.Errorf("cannot take the length of %s", o.Type)
+	}
+}
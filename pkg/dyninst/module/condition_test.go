@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/rcjson"
+)
+
+const conditionTestEvent = `{
+  "debugger": {
+    "snapshot": {
+      "captures": {
+        "entry": {
+          "arguments": {
+            "req": {"type": "*main.request", "address": "0x1", "fields": {
+              "UserID": {"type": "string", "value": "x"},
+              "Retries": {"type": "int", "value": "3"},
+              "Parent": {"type": "*main.request", "isNull": true}
+            }},
+            "payload": {"type": "[]uint8", "size": "200", "elements": [
+              {"type": "uint8", "value": "1"}
+            ]},
+            "headers": {"type": "map[string]string", "size": "1", "entries": [
+              [{"type": "string", "value": "k"}, {"type": "string", "value": "v"}]
+            ]},
+            "body": {"type": "string", "size": "1000", "truncated": true, "value": "xxx"}
+          }
+        },
+        "return": {
+          "locals": {"~r0": {"type": "bool", "value": "true"}}
+        }
+      }
+    }
+  }
+}`
+
+func conditionalProbe(id string, condition string) *ir.Probe {
+	return &ir.Probe{
+		ProbeDefinition: &rcjson.SnapshotProbe{
+			LogProbeCommon: rcjson.LogProbeCommon{
+				ProbeCommon: rcjson.ProbeCommon{ID: id},
+				When: &rcjson.When{
+					DSL:  id,
+					JSON: json.RawMessage(condition),
+				},
+			},
+		},
+	}
+}
+
+func TestProbeCondition(t *testing.T) {
+	for _, tc := range []struct {
+		condition string
+		expected  bool
+		err       string
+	}{
+		{condition: `{"eq": [{"getmember": [{"ref": "req"}, "UserID"]}, "x"]}`, expected: true},
+		{condition: `{"gt": [{"getmember": [{"ref": "req"}, "Retries"]}, 3]}`, expected: false},
+		{condition: `{"eq": [{"getmember": [{"ref": "req"}, "Parent"]}, null]}`, expected: true},
+		{condition: `{"gt": [{"len": {"ref": "payload"}}, 100]}`, expected: true},
+		{condition: `{"eq": [{"index": [{"ref": "payload"}, 0]}, 1]}`, expected: true},
+		{condition: `{"eq": [{"index": [{"ref": "headers"}, "k"]}, "v"]}`, expected: true},
+		{condition: `{"ge": [{"len": {"ref": "body"}}, 1000]}`, expected: true},
+		{condition: `{"ref": "~r0"}`, expected: true},
+		{condition: `{"eq": [{"index": [{"ref": "payload"}, 1]}, 1]}`, err: "value is truncated"},
+		{condition: `{"eq": [{"ref": "body"}, "xxx"]}`, err: "cannot compare an object"},
+		{condition: `{"ref": "missing"}`, err: `variable "missing" was not captured`},
+	} {
+		t.Run(tc.condition, func(t *testing.T) {
+			conditions := makeProbeConditions([]*ir.Probe{
+				conditionalProbe("probe", tc.condition),
+			})
+			require.Contains(t, conditions, "probe")
+			matched, err := conditions["probe"].eval([]byte(conditionTestEvent))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestMakeProbeConditions(t *testing.T) {
+	conditions := makeProbeConditions([]*ir.Probe{
+		testProbe("unconditional"),
+		conditionalProbe("conditional", `{"ref": "x"}`),
+		conditionalProbe("invalid", `{"matches": [{"ref": "x"}, "y"]}`),
+	})
+	require.Len(t, conditions, 1)
+	require.Contains(t, conditions, "conditional")
+}
@@ -14,6 +14,8 @@ import (
 	"os"
 	"strconv"
 
+	ddgostatsd "github.com/DataDog/datadog-go/v5/statsd"
+
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/actuator"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/loader"
@@ -42,6 +44,15 @@ type Config struct {
 	// ProcessSyncDisabled disables the process sync for the module.
 	ProcessSyncDisabled bool
 
+	// MaxEventsPerSecond is the global budget of events emitted by all the
+	// probes. It is enforced both in the eBPF programs and before uploading
+	// the events. Zero disables the limit.
+	MaxEventsPerSecond int
+
+	// Statsd is the client used to emit the metrics of metric probes. Events
+	// of metric probes are dropped if it is nil.
+	Statsd ddgostatsd.ClientInterface
+
 	TestingKnobs struct {
 		LoaderOptions       []loader.Option
 		ScraperOverride     func(Scraper) Scraper
@@ -78,6 +89,13 @@ func NewConfig(_ *sysconfigtypes.Config) (*Config, error) {
 		SymDBUploaderURL:   withPath(traceAgentURL, symdbUploaderPath),
 		DiskCacheEnabled:   cacheEnabled,
 		DiskCacheConfig:    cacheConfig,
+		MaxEventsPerSecond: pkgconfigsetup.SystemProbe().GetInt("dynamic_instrumentation.max_events_per_second"),
+	}
+	if c.MaxEventsPerSecond < 0 {
+		return nil, fmt.Errorf(
+			"dynamic_instrumentation.max_events_per_second must be non-negative, got %d",
+			c.MaxEventsPerSecond,
+		)
 	}
 	return c, nil
 }
@@ -114,6 +132,7 @@ func getDebugInfoDiskCacheConfig() (
 	}
 	cacheConfig.RequiredDiskSpaceBytes = requiredDiskSpaceBytes
 	cacheConfig.RequiredDiskSpacePercent = cfg.GetFloat64(key("required_disk_space_percent"))
+	cacheConfig.Persistent = cfg.GetBool(key("persistent"))
 	return
 }
 
@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/DataDog/datadog-agent/pkg/dyninst/actuator"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/compiler"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/decode"
@@ -41,6 +43,11 @@ type dependencies struct {
 	LogsFactory         LogsUploaderFactory[LogsUploader]
 	DiagnosticsUploader DiagnosticsUploader
 	symdbManager        *symdbManager
+	// eventsLimiter enforces the global events budget in userspace. A nil
+	// limiter allows all events.
+	eventsLimiter *rate.Limiter
+	// metrics emits the metrics of metric probes. A nil client drops them.
+	metrics metricsClient
 }
 
 // ProcessSubscriber is an interface that can be used to subscribe to process
@@ -73,7 +80,7 @@ type ProgramCompiler interface {
 
 // KernelLoader loads compiled programs into the kernel.
 type KernelLoader interface {
-	Load(compiler.Program) (*loader.Program, error)
+	Load(compiler.Program, ...loader.LoadOption) (*loader.Program, error)
 }
 
 // Attacher connects a loaded program to a target process.
@@ -48,12 +48,23 @@ func (e *diagnosticTracker) mark(runtimeID string, probeID string, probeVersion
 	return first
 }
 
+// probeState is the latest status reported for a probe in a process.
+type probeState struct {
+	runtimeID    procRuntimeID
+	probeVersion int
+	status       uploader.Status
+	exception    *uploader.DiagnosticException
+}
+
 type diagnosticsManager struct {
 	uploader  DiagnosticsUploader
 	received  *diagnosticTracker
 	installed *diagnosticTracker
 	emitted   *diagnosticTracker
 	errors    *diagnosticTracker
+
+	statesMu sync.Mutex
+	states   map[probeKey]*probeState
 }
 
 func newDiagnosticsManager(uploader DiagnosticsUploader) *diagnosticsManager {
@@ -63,6 +74,7 @@ func newDiagnosticsManager(uploader DiagnosticsUploader) *diagnosticsManager {
 		installed: newDiagnosticTracker("installed"),
 		emitted:   newDiagnosticTracker("emitted"),
 		errors:    newDiagnosticTracker("errors"),
+		states:    make(map[probeKey]*probeState),
 	}
 }
 
@@ -76,6 +88,7 @@ func (m *diagnosticsManager) enqueue(
 	if !tracker.mark(runtimeID.runtimeID, probe.GetID(), probe.GetVersion()) {
 		return false
 	}
+	m.setState(runtimeID, probe, status, exception)
 	diag := uploader.Diagnostic{
 		RuntimeID:           runtimeID.runtimeID,
 		ProbeID:             probe.GetID(),
@@ -89,6 +102,46 @@ func (m *diagnosticsManager) enqueue(
 	return true
 }
 
+func (m *diagnosticsManager) setState(
+	runtimeID procRuntimeID,
+	probe ir.ProbeIDer,
+	status uploader.Status,
+	exception *uploader.DiagnosticException,
+) {
+	key := probeKey{runtimeID: runtimeID.runtimeID, probeID: probe.GetID()}
+	m.statesMu.Lock()
+	defer m.statesMu.Unlock()
+	state, ok := m.states[key]
+	if !ok {
+		state = &probeState{}
+		m.states[key] = state
+	}
+	// Keep the error of the current version of the probe visible until the
+	// probe is updated.
+	if state.probeVersion == probe.GetVersion() &&
+		state.status == uploader.StatusError &&
+		status != uploader.StatusError {
+		return
+	}
+	*state = probeState{
+		runtimeID:    runtimeID,
+		probeVersion: probe.GetVersion(),
+		status:       status,
+		exception:    exception,
+	}
+}
+
+// getStates returns a copy of the latest status of all the probes.
+func (m *diagnosticsManager) getStates() map[probeKey]probeState {
+	m.statesMu.Lock()
+	defer m.statesMu.Unlock()
+	states := make(map[probeKey]probeState, len(m.states))
+	for key, state := range m.states {
+		states[key] = *state
+	}
+	return states
+}
+
 func (m *diagnosticsManager) reportReceived(runtimeID procRuntimeID, probe ir.ProbeIDer) {
 	m.enqueue(m.received, runtimeID, probe, uploader.StatusReceived, nil)
 }
@@ -116,4 +169,12 @@ func (m *diagnosticsManager) remove(runtimeID string) {
 	m.installed.byRuntimeID.Delete(id)
 	m.emitted.byRuntimeID.Delete(id)
 	m.errors.byRuntimeID.Delete(id)
+
+	m.statesMu.Lock()
+	defer m.statesMu.Unlock()
+	for key := range m.states {
+		if key.runtimeID == runtimeID {
+			delete(m.states, key)
+		}
+	}
 }
@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/exprlang"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/rcjson"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// metricsClient is the subset of the dogstatsd client used to emit the
+// metrics of metric probes.
+type metricsClient interface {
+	Count(name string, value int64, tags []string, rate float64) error
+	Gauge(name string, value float64, tags []string, rate float64) error
+	Histogram(name string, value float64, tags []string, rate float64) error
+	Distribution(name string, value float64, tags []string, rate float64) error
+}
+
+// probeMetric is the metric updated by each event of a metric probe.
+type probeMetric struct {
+	kind string
+	name string
+	dsl  string
+	// value is the expression of the value of the metric. A nil value counts
+	// each event as 1.
+	value exprlang.Expr
+	tags  []string
+}
+
+// makeProbeMetrics parses the metrics of the metric probes of a program,
+// indexed by probe ID.
+func makeProbeMetrics(probes []*ir.Probe, service string) map[string]*probeMetric {
+	var metrics map[string]*probeMetric
+	for _, probe := range probes {
+		def, ok := probe.ProbeDefinition.(ir.MetricProbeDefinition)
+		if !ok {
+			continue
+		}
+		m := &probeMetric{
+			kind: def.GetMetricKind(),
+			name: def.GetMetricName(),
+			tags: append(
+				[]string{"debugger.probeid:" + probe.GetID(), "service:" + service},
+				probe.GetTags()...,
+			),
+		}
+		dsl, dslJSON := def.GetMetricValue()
+		if len(dslJSON) != 0 {
+			// The value has already been validated when generating the
+			// program, so this is not expected to fail.
+			expr, err := exprlang.ParseCondition(dslJSON)
+			if err != nil {
+				log.Warnf("ignoring metric probe %s with invalid value: %v", probe.GetID(), err)
+				continue
+			}
+			m.dsl, m.value = dsl, expr
+		}
+		if metrics == nil {
+			metrics = make(map[string]*probeMetric)
+		}
+		metrics[probe.GetID()] = m
+	}
+	return metrics
+}
+
+// emit updates the metric with the value computed from a decoded event.
+func (m *probeMetric) emit(client metricsClient, decoded []byte) error {
+	value := 1.0
+	if m.value != nil {
+		env, err := newCaptureEnv(decoded)
+		if err != nil {
+			return err
+		}
+		v, err := exprlang.Eval(m.value, env)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate %q: %w", m.dsl, err)
+		}
+		if value, err = metricValue(v); err != nil {
+			return fmt.Errorf("failed to evaluate %q: %w", m.dsl, err)
+		}
+	}
+	switch m.kind {
+	case rcjson.MetricKindCount:
+		return client.Count(m.name, int64(math.Round(value)), m.tags, 1)
+	case rcjson.MetricKindGauge:
+		return client.Gauge(m.name, value, m.tags, 1)
+	case rcjson.MetricKindHistogram:
+		return client.Histogram(m.name, value, m.tags, 1)
+	case rcjson.MetricKindDistribution:
+		return client.Distribution(m.name, value, m.tags, 1)
+	default:
+		return fmt.Errorf("unsupported metric kind %q", m.kind)
+	}
+}
+
+func metricValue(v any) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		// Numeric literals are sometimes sent as strings.
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not a number", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("value is not a number")
+	}
+}
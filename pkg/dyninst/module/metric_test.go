@@ -0,0 +1,101 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/rcjson"
+)
+
+type recordedMetric struct {
+	kind  string
+	name  string
+	value float64
+	tags  []string
+}
+
+type fakeMetricsClient struct {
+	metrics []recordedMetric
+}
+
+func (f *fakeMetricsClient) record(kind, name string, value float64, tags []string) error {
+	f.metrics = append(f.metrics, recordedMetric{kind: kind, name: name, value: value, tags: tags})
+	return nil
+}
+
+func (f *fakeMetricsClient) Count(name string, value int64, tags []string, _ float64) error {
+	return f.record("count", name, float64(value), tags)
+}
+
+func (f *fakeMetricsClient) Gauge(name string, value float64, tags []string, _ float64) error {
+	return f.record("gauge", name, value, tags)
+}
+
+func (f *fakeMetricsClient) Histogram(name string, value float64, tags []string, _ float64) error {
+	return f.record("histogram", name, value, tags)
+}
+
+func (f *fakeMetricsClient) Distribution(name string, value float64, tags []string, _ float64) error {
+	return f.record("distribution", name, value, tags)
+}
+
+func metricProbe(id, kind string, value *rcjson.Value) *ir.Probe {
+	return &ir.Probe{
+		ProbeDefinition: &rcjson.MetricProbe{
+			ProbeCommon: rcjson.ProbeCommon{ID: id, Tags: []string{"foo:bar"}},
+			Kind:        kind,
+			MetricName:  id + ".metric",
+			Value:       value,
+		},
+	}
+}
+
+func TestProbeMetrics(t *testing.T) {
+	metrics := makeProbeMetrics([]*ir.Probe{
+		metricProbe("hits", "count", nil),
+		metricProbe("retries", "gauge", &rcjson.Value{
+			DSL:  "req.Retries",
+			JSON: json.RawMessage(`{"getmember": [{"ref": "req"}, "Retries"]}`),
+		}),
+		metricProbe("size", "distribution", &rcjson.Value{
+			DSL:  "len(payload)",
+			JSON: json.RawMessage(`{"len": {"ref": "payload"}}`),
+		}),
+		metricProbe("literal", "histogram", &rcjson.Value{DSL: "2", JSON: json.RawMessage(`"2"`)}),
+		metricProbe("invalid", "gauge", &rcjson.Value{
+			DSL:  "req.UserID",
+			JSON: json.RawMessage(`{"getmember": [{"ref": "req"}, "UserID"]}`),
+		}),
+		testProbe("log"),
+	}, "my-service")
+	require.Len(t, metrics, 5)
+	require.NotContains(t, metrics, "log")
+
+	client := &fakeMetricsClient{}
+	for _, id := range []string{"hits", "retries", "size", "literal"} {
+		require.NoError(t, metrics[id].emit(client, []byte(conditionTestEvent)), id)
+	}
+	require.ErrorContains(t,
+		metrics["invalid"].emit(client, []byte(conditionTestEvent)),
+		`value "x" is not a number`,
+	)
+	tags := func(id string) []string {
+		return []string{"debugger.probeid:" + id, "service:my-service", "foo:bar"}
+	}
+	require.Equal(t, []recordedMetric{
+		{kind: "count", name: "hits.metric", value: 1, tags: tags("hits")},
+		{kind: "gauge", name: "retries.metric", value: 3, tags: tags("retries")},
+		{kind: "distribution", name: "size.metric", value: 200, tags: tags("size")},
+		{kind: "histogram", name: "literal.metric", value: 2, tags: tags("literal")},
+	}, client.metrics)
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"golang.org/x/sys/unix"
+	"golang.org/x/time/rate"
 
 	"github.com/DataDog/datadog-agent/pkg/dyninst/actuator"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/dispatcher"
@@ -40,6 +41,7 @@ type Module struct {
 
 	store       *processStore
 	diagnostics *diagnosticsManager
+	probeStats  *probeStatsTracker
 
 	testingKnobs testingKnobs
 
@@ -68,6 +70,14 @@ func NewModule(
 	if override := config.TestingKnobs.IRGeneratorOverride; override != nil {
 		deps.IRGenerator = override(deps.IRGenerator)
 	}
+	if config.MaxEventsPerSecond > 0 {
+		deps.eventsLimiter = rate.NewLimiter(
+			rate.Limit(config.MaxEventsPerSecond), config.MaxEventsPerSecond,
+		)
+	}
+	if config.Statsd != nil {
+		deps.metrics = config.Statsd
+	}
 	m := newUnstartedModule(deps)
 	m.shutdown.realDependencies = realDeps
 	procMon := procmon.NewProcessMonitor(&processHandler{
@@ -99,6 +109,7 @@ func newUnstartedModule(deps dependencies) *Module {
 	store := newProcessStore()
 	logsUploader := logsUploaderFactoryImpl[LogsUploader]{factory: deps.LogsFactory}
 	diagnostics := newDiagnosticsManager(deps.DiagnosticsUploader)
+	probeStats := newProbeStatsTracker()
 	bufferedMessagesTracker := newBufferedMessageTracker(bufferedMessagesByteLimit)
 	runtime := &runtimeImpl{
 		store:                    store,
@@ -112,6 +123,9 @@ func newUnstartedModule(deps dependencies) *Module {
 		logsFactory:              logsUploader,
 		procRuntimeIDbyProgramID: &sync.Map{},
 		bufferedMessageTracker:   bufferedMessagesTracker,
+		eventsLimiter:            deps.eventsLimiter,
+		metrics:                  deps.metrics,
+		probeStats:               probeStats,
 	}
 	tenant := deps.Actuator.NewTenant("dyninst", runtime)
 
@@ -119,6 +133,7 @@ func newUnstartedModule(deps dependencies) *Module {
 		rcScraper:    deps.Scraper,
 		store:        store,
 		diagnostics:  diagnostics,
+		probeStats:   probeStats,
 		symdb:        deps.symdbManager,
 		tenant:       tenant,
 		testingKnobs: testingKnobs{},
@@ -217,6 +232,9 @@ func makeRealDependencies(config *Config) (_ realDependencies, retErr error) {
 	if config.TestingKnobs.LoaderOptions != nil {
 		loaderOpts = config.TestingKnobs.LoaderOptions
 	}
+	if config.MaxEventsPerSecond > 0 {
+		loaderOpts = append(loaderOpts, loader.WithGlobalThrottle(uint32(config.MaxEventsPerSecond)))
+	}
 	ret.loader, err = loader.NewLoader(loaderOpts...)
 	if err != nil {
 		return ret, fmt.Errorf("error creating loader: %w", err)
@@ -294,6 +312,9 @@ func (m *Module) GetStats() map[string]any {
 			stats["actuator"] = actuatorStats
 		}
 	}
+	if probes := m.getProbeStatuses(); len(probes) > 0 {
+		stats["probes"] = probes
+	}
 	return stats
 }
 
@@ -310,6 +331,15 @@ func (m *Module) Register(router *module.Router) error {
 			},
 		),
 	)
+	router.HandleFunc(
+		"/probes",
+		utils.WithConcurrencyLimit(
+			utils.DefaultMaxConcurrentRequests,
+			func(w http.ResponseWriter, _ *http.Request) {
+				utils.WriteAsJSON(w, m.getProbeStatuses(), utils.CompactOutput)
+			},
+		),
+	)
 	return nil
 }
 
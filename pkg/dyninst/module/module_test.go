@@ -630,7 +630,7 @@ type fakeKernelLoader struct {
 	err error
 }
 
-func (f *fakeKernelLoader) Load(compiler.Program) (*loader.Program, error) {
+func (f *fakeKernelLoader) Load(compiler.Program, ...loader.LoadOption) (*loader.Program, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
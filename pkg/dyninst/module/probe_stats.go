@@ -0,0 +1,148 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"sync"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/compiler"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/loader"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// probeStats holds the event counters of a probe in a process.
+type probeStats struct {
+	// EmittedEvents is the number of events uploaded for the probe.
+	EmittedEvents uint64
+	// ThrottledEvents is the number of events dropped by the eBPF program,
+	// because either the probe sampling rate or the global events budget
+	// was exceeded.
+	ThrottledEvents uint64
+	// RateLimitedEvents is the number of events dropped in userspace because
+	// the global events budget was exceeded.
+	RateLimitedEvents uint64
+}
+
+// probeKey identifies a probe in a process.
+type probeKey struct {
+	runtimeID string
+	probeID   string
+}
+
+// throttledEventsReader reads the per-throttler drop counters of a loaded
+// program.
+type throttledEventsReader interface {
+	ThrottledEvents() ([]uint64, error)
+}
+
+var _ throttledEventsReader = (*loader.Program)(nil)
+
+type programStats struct {
+	runtimeID string
+	program   throttledEventsReader
+	// throttlerProbes is the ID of the probe of each throttler of the
+	// program.
+	throttlerProbes []string
+	probes          map[string]*probeStats
+}
+
+// probeStatsTracker keeps track of the event counters of each probe of the
+// loaded programs.
+type probeStatsTracker struct {
+	mu       sync.Mutex
+	programs map[ir.ProgramID]*programStats
+}
+
+func newProbeStatsTracker() *probeStatsTracker {
+	return &probeStatsTracker{
+		programs: make(map[ir.ProgramID]*programStats),
+	}
+}
+
+func (t *probeStatsTracker) register(
+	programID ir.ProgramID,
+	runtimeID string,
+	program throttledEventsReader,
+	irProgram *ir.Program,
+	throttlers []compiler.Throttler,
+) {
+	throttlerProbes := make([]string, len(throttlers))
+	for i, throttler := range throttlers {
+		throttlerProbes[i] = irProgram.Probes[throttler.ProbeIdx].GetID()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.programs[programID] = &programStats{
+		runtimeID:       runtimeID,
+		program:         program,
+		throttlerProbes: throttlerProbes,
+		probes:          make(map[string]*probeStats),
+	}
+}
+
+func (t *probeStatsTracker) unregister(programID ir.ProgramID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.programs, programID)
+}
+
+func (t *probeStatsTracker) update(
+	programID ir.ProgramID, probeID string, f func(*probeStats),
+) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.programs[programID]
+	if !ok {
+		return
+	}
+	s, ok := p.probes[probeID]
+	if !ok {
+		s = &probeStats{}
+		p.probes[probeID] = s
+	}
+	f(s)
+}
+
+func (t *probeStatsTracker) recordEmitted(programID ir.ProgramID, probeID string) {
+	t.update(programID, probeID, func(s *probeStats) { s.EmittedEvents++ })
+}
+
+func (t *probeStatsTracker) recordRateLimited(programID ir.ProgramID, probeID string) {
+	t.update(programID, probeID, func(s *probeStats) { s.RateLimitedEvents++ })
+}
+
+// stats returns the counters of all the probes of the loaded programs.
+func (t *probeStatsTracker) stats() map[probeKey]probeStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ret := make(map[probeKey]probeStats)
+	for programID, p := range t.programs {
+		throttled, err := p.program.ThrottledEvents()
+		if err != nil {
+			log.Warnf("failed to read throttled events of program %v: %v", programID, err)
+		}
+		for i, count := range throttled {
+			if i >= len(p.throttlerProbes) {
+				break
+			}
+			key := probeKey{runtimeID: p.runtimeID, probeID: p.throttlerProbes[i]}
+			s := ret[key]
+			s.ThrottledEvents += count
+			ret[key] = s
+		}
+		for probeID, counters := range p.probes {
+			key := probeKey{runtimeID: p.runtimeID, probeID: probeID}
+			s := ret[key]
+			s.EmittedEvents += counters.EmittedEvents
+			s.RateLimitedEvents += counters.RateLimitedEvents
+			ret[key] = s
+		}
+	}
+	return ret
+}
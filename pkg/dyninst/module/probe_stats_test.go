@@ -0,0 +1,67 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/compiler"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/rcjson"
+)
+
+type fakeThrottledEventsReader []uint64
+
+func (f fakeThrottledEventsReader) ThrottledEvents() ([]uint64, error) {
+	return f, nil
+}
+
+func testProbe(id string) *ir.Probe {
+	return &ir.Probe{
+		ProbeDefinition: &rcjson.LogProbe{
+			LogProbeCommon: rcjson.LogProbeCommon{
+				ProbeCommon: rcjson.ProbeCommon{ID: id},
+			},
+		},
+	}
+}
+
+func TestProbeStatsTracker(t *testing.T) {
+	tracker := newProbeStatsTracker()
+	irProgram := &ir.Program{
+		Probes: []*ir.Probe{testProbe("probe-a"), testProbe("probe-b")},
+	}
+	// probe-a has an entry and a return event, hence two throttlers.
+	throttlers := []compiler.Throttler{{ProbeIdx: 0}, {ProbeIdx: 0}, {ProbeIdx: 1}}
+
+	tracker.register(1, "runtime-1", fakeThrottledEventsReader{3, 4, 5}, irProgram, throttlers)
+	tracker.register(2, "runtime-2", fakeThrottledEventsReader{1, 0, 0}, irProgram, throttlers)
+	tracker.recordEmitted(1, "probe-a")
+	tracker.recordEmitted(1, "probe-a")
+	tracker.recordRateLimited(1, "probe-b")
+	tracker.recordRateLimited(2, "probe-b")
+	tracker.recordRateLimited(3, "probe-b") // unknown program, ignored
+
+	require.Equal(t, map[probeKey]probeStats{
+		{"runtime-1", "probe-a"}: {EmittedEvents: 2, ThrottledEvents: 7},
+		{"runtime-1", "probe-b"}: {ThrottledEvents: 5, RateLimitedEvents: 1},
+		{"runtime-2", "probe-a"}: {ThrottledEvents: 1},
+		{"runtime-2", "probe-b"}: {RateLimitedEvents: 1},
+	}, tracker.stats())
+
+	tracker.unregister(1)
+	require.Equal(t, map[probeKey]probeStats{
+		{"runtime-2", "probe-a"}: {ThrottledEvents: 1},
+		{"runtime-2", "probe-b"}: {RateLimitedEvents: 1},
+	}, tracker.stats())
+
+	tracker.unregister(2)
+	require.Empty(t, tracker.stats())
+}
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/uploader"
+)
+
+// ProbeStatus describes the state of a probe in a process. It is exposed
+// through the /probes endpoint and the module stats.
+type ProbeStatus struct {
+	ProbeID      string `json:"probe_id"`
+	ProbeVersion int    `json:"probe_version"`
+	Service      string `json:"service"`
+	RuntimeID    string `json:"runtime_id"`
+	PID          int32  `json:"pid"`
+	// Status is the last status reported for the probe. A probe is attached
+	// to the process once it is INSTALLED.
+	Status uploader.Status `json:"status"`
+	// ErrorType and Error describe why the probe is in the ERROR status, e.g.
+	// the target symbol was not found or the program failed to load.
+	ErrorType string `json:"error_type,omitempty"`
+	Error     string `json:"error,omitempty"`
+
+	EmittedEvents     uint64 `json:"emitted_events"`
+	ThrottledEvents   uint64 `json:"throttled_events"`
+	RateLimitedEvents uint64 `json:"rate_limited_events"`
+}
+
+// getProbeStatuses returns the status of all the known probes, sorted by
+// service, probe and process.
+func (m *Module) getProbeStatuses() []ProbeStatus {
+	states := m.diagnostics.getStates()
+	stats := m.probeStats.stats()
+	statuses := make([]ProbeStatus, 0, len(states))
+	for key, state := range states {
+		s := stats[key]
+		status := ProbeStatus{
+			ProbeID:           key.probeID,
+			ProbeVersion:      state.probeVersion,
+			Service:           state.runtimeID.service,
+			RuntimeID:         key.runtimeID,
+			PID:               state.runtimeID.PID,
+			Status:            state.status,
+			EmittedEvents:     s.EmittedEvents,
+			ThrottledEvents:   s.ThrottledEvents,
+			RateLimitedEvents: s.RateLimitedEvents,
+		}
+		if state.exception != nil {
+			status.ErrorType = state.exception.Type
+			status.Error = state.exception.Message
+		}
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b ProbeStatus) int {
+		return cmp.Or(
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.ProbeID, b.ProbeID),
+			cmp.Compare(a.PID, b.PID),
+		)
+	})
+	return statuses
+}
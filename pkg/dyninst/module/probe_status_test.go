@@ -0,0 +1,74 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package module
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/dyninst/compiler"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/procmon"
+	"github.com/DataDog/datadog-agent/pkg/dyninst/uploader"
+)
+
+type noopDiagnosticsUploader struct{}
+
+func (noopDiagnosticsUploader) Enqueue(*uploader.DiagnosticMessage) error { return nil }
+
+func TestGetProbeStatuses(t *testing.T) {
+	m := &Module{
+		diagnostics: newDiagnosticsManager(noopDiagnosticsUploader{}),
+		probeStats:  newProbeStatsTracker(),
+	}
+	runtimeID := procRuntimeID{
+		ProcessID: procmon.ProcessID{PID: 42},
+		service:   "svc",
+		runtimeID: "runtime-1",
+	}
+	ok, broken := testProbe("probe-ok"), testProbe("probe-broken")
+
+	m.diagnostics.reportReceived(runtimeID, ok)
+	m.diagnostics.reportReceived(runtimeID, broken)
+	m.diagnostics.reportInstalled(runtimeID, ok)
+	m.diagnostics.reportEmitting(runtimeID, ok)
+	m.diagnostics.reportError(runtimeID, broken, errors.New("symbol not found"), "TargetNotFound")
+	// A later status does not hide the error of the same probe version.
+	m.diagnostics.reportInstalled(runtimeID, broken)
+
+	m.probeStats.register(1, "runtime-1", fakeThrottledEventsReader{4}, &ir.Program{
+		Probes: []*ir.Probe{ok},
+	}, []compiler.Throttler{{ProbeIdx: 0}})
+	m.probeStats.recordEmitted(1, "probe-ok")
+
+	require.Equal(t, []ProbeStatus{
+		{
+			ProbeID:   "probe-broken",
+			Service:   "svc",
+			RuntimeID: "runtime-1",
+			PID:       42,
+			Status:    uploader.StatusError,
+			ErrorType: "TargetNotFound",
+			Error:     "symbol not found",
+		},
+		{
+			ProbeID:         "probe-ok",
+			Service:         "svc",
+			RuntimeID:       "runtime-1",
+			PID:             42,
+			Status:          uploader.StatusEmitting,
+			EmittedEvents:   1,
+			ThrottledEvents: 4,
+		},
+	}, m.getProbeStatuses())
+
+	m.diagnostics.remove("runtime-1")
+	require.Empty(t, m.getProbeStatuses())
+}
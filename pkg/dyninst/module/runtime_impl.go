@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"sync"
 
+	"golang.org/x/time/rate"
+
 	"github.com/DataDog/datadog-agent/pkg/dyninst/actuator"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/loader"
@@ -32,6 +34,9 @@ type runtimeImpl struct {
 	logsFactory              erasedLogsUploaderFactory
 	procRuntimeIDbyProgramID *sync.Map
 	bufferedMessageTracker   *bufferedMessageTracker
+	eventsLimiter            *rate.Limiter
+	metrics                  metricsClient
+	probeStats               *probeStatsTracker
 }
 
 type irGenFailedError struct {
@@ -98,7 +103,9 @@ func (rt *runtimeImpl) Load(
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate program: %w", err)
 	}
-	loadedProgram, err := rt.kernelLoader.Load(compiled)
+	loadedProgram, err := rt.kernelLoader.Load(
+		compiled, loader.WithTargetPID(uint32(processID.PID)),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load program: %w", err)
 	}
@@ -150,9 +157,14 @@ func (rt *runtimeImpl) Load(
 			EntityID:    entityID,
 			ContainerID: containerID,
 		}),
-		tree: rt.bufferedMessageTracker.newTree(),
+		tree:       rt.bufferedMessageTracker.newTree(),
+		conditions: makeProbeConditions(irProgram.Probes),
+		metrics:    makeProbeMetrics(irProgram.Probes, runtimeID.service),
 	}
 	rt.dispatcher.RegisterSink(programID, s)
+	rt.probeStats.register(
+		programID, runtimeID.runtimeID, loadedProgram, irProgram, compiled.Throttlers,
+	)
 
 	return &loadedProgramImpl{
 		runtime:       rt,
@@ -191,6 +203,7 @@ func (l *loadedProgramImpl) Attach(processID actuator.ProcessID, executable actu
 func (l *loadedProgramImpl) Close() error {
 	l.loadedProgram.Close()
 	l.runtime.dispatcher.UnregisterSink(l.programID)
+	l.runtime.probeStats.unregister(l.programID)
 	l.runtime.onProgramDetached(l.programID)
 	return nil
 }
@@ -245,6 +258,16 @@ func (rt *runtimeImpl) setProbeMaybeEmitting(programID ir.ProgramID, probe ir.Pr
 	}
 }
 
+// allowEvent reports whether an event of the given probe fits in the global
+// events budget, and accounts for it as dropped if it does not.
+func (rt *runtimeImpl) allowEvent(programID ir.ProgramID, probe ir.ProbeDefinition) bool {
+	if rt.eventsLimiter == nil || rt.eventsLimiter.Allow() {
+		return true
+	}
+	rt.probeStats.recordRateLimited(programID, probe.GetID())
+	return false
+}
+
 func (rt *runtimeImpl) reportProbeError(
 	programID ir.ProgramID, probe ir.ProbeDefinition, err error, errType string,
 ) (reported bool) {
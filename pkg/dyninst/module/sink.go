@@ -31,6 +31,12 @@ type sink struct {
 	service      string
 	logUploader  LogsUploader
 	tree         *bufferTree
+	// conditions are the conditions of the probes of the program, by probe
+	// ID.
+	conditions map[string]*probeCondition
+	// metrics are the metrics updated by the metric probes of the program,
+	// by probe ID.
+	metrics map[string]*probeMetric
 }
 
 var _ dispatcher.Sink = &sink{}
@@ -41,6 +47,10 @@ var decodingErrorLogLimiter = rate.NewLimiter(rate.Every(1*time.Minute), 10)
 
 var noMatchingEventLogLimiter = rate.NewLimiter(rate.Every(1*time.Minute), 10)
 
+var conditionErrorLogLimiter = rate.NewLimiter(rate.Every(1*time.Minute), 10)
+
+var metricErrorLogLimiter = rate.NewLimiter(rate.Every(1*time.Minute), 10)
+
 func (s *sink) HandleEvent(msg dispatcher.Message) error {
 	defer func() {
 		if msg != (dispatcher.Message{}) {
@@ -138,11 +148,63 @@ func (s *sink) HandleEvent(msg dispatcher.Message) error {
 		// or program.
 		return nil
 	}
+	if cond, ok := s.conditions[probe.GetID()]; ok {
+		matched, err := cond.eval(decodedBytes)
+		// Events for which the condition cannot be evaluated are emitted, so
+		// that the user can notice that the condition is wrong.
+		if err != nil {
+			if conditionErrorLogLimiter.Allow() {
+				log.Warnf(
+					"failed to evaluate condition %q of probe %s in service %s: %v",
+					cond.dsl, probe.GetID(), s.service, err,
+				)
+			} else {
+				log.Tracef(
+					"failed to evaluate condition %q of probe %s in service %s: %v",
+					cond.dsl, probe.GetID(), s.service, err,
+				)
+			}
+		} else if !matched {
+			return nil
+		}
+	}
+	if metric, ok := s.metrics[probe.GetID()]; ok {
+		s.emitMetric(probe, metric, decodedBytes)
+		return nil
+	}
+	if !s.runtime.allowEvent(s.programID, probe) {
+		return nil
+	}
 	s.runtime.setProbeMaybeEmitting(s.programID, probe)
+	s.runtime.probeStats.recordEmitted(s.programID, probe.GetID())
 	s.logUploader.Enqueue(json.RawMessage(decodedBytes))
 	return nil
 }
 
+// emitMetric updates the metric of a metric probe instead of uploading the
+// event.
+func (s *sink) emitMetric(probe ir.ProbeDefinition, metric *probeMetric, decodedBytes []byte) {
+	if s.runtime.metrics == nil {
+		return
+	}
+	if err := metric.emit(s.runtime.metrics, decodedBytes); err != nil {
+		if metricErrorLogLimiter.Allow() {
+			log.Warnf(
+				"failed to emit metric %s of probe %s in service %s: %v",
+				metric.name, probe.GetID(), s.service, err,
+			)
+		} else {
+			log.Tracef(
+				"failed to emit metric %s of probe %s in service %s: %v",
+				metric.name, probe.GetID(), s.service, err,
+			)
+		}
+		return
+	}
+	s.runtime.setProbeMaybeEmitting(s.programID, probe)
+	s.runtime.probeStats.recordEmitted(s.programID, probe.GetID())
+}
+
 func (s *sink) Close() {
 	if s.logUploader != nil {
 		s.logUploader.Close()
@@ -53,6 +53,12 @@ type DiskCacheConfig struct {
 	// MaxTotalBytes is the maximum aggregate size of all sections that can be
 	// cached, in bytes.
 	MaxTotalBytes uint64
+	// Persistent keeps decompressed sections on disk after they are released
+	// so that later loads of the same binary, including after a restart, can
+	// reuse them. Sections are keyed by the content hash of the binary, so a
+	// rebuilt binary never reuses stale data. The least recently used sections
+	// are removed to keep the directory within MaxTotalBytes.
+	Persistent bool
 }
 
 func (cfg *DiskCacheConfig) validate() error {
@@ -74,8 +80,9 @@ func (cfg *DiskCacheConfig) validate() error {
 // DiskCache enables loading object files and storing decompressed sections on
 // disk.
 type DiskCache struct {
-	dirPath string
-	checker spaceChecker
+	dirPath    string
+	checker    spaceChecker
+	persistent bool
 
 	// maxTotalBytes is the maximum aggregate size of all sections currently
 	// held in the cache (open or in-flight).
@@ -108,6 +115,7 @@ func newDiskCache(cfg DiskCacheConfig, diskUsageReader diskUsageReader) (*DiskCa
 	c := &DiskCache{
 		dirPath:       cfg.DirPath,
 		maxTotalBytes: cfg.MaxTotalBytes,
+		persistent:    cfg.Persistent,
 		checker: spaceChecker{
 			disk:                     diskUsageReader,
 			requiredDiskSpaceBytes:   cfg.RequiredDiskSpaceBytes,
@@ -115,6 +123,14 @@ func newDiskCache(cfg DiskCacheConfig, diskUsageReader diskUsageReader) (*DiskCa
 		},
 	}
 	c.mu.entries = make(map[cacheKey]*cacheEntry)
+	if c.persistent {
+		if err := os.MkdirAll(c.persistedSectionsDir(), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create persisted sections directory: %w", err)
+		}
+		if err := c.prunePersistedSections(); err != nil {
+			log.Warnf("failed to prune persisted debug info sections: %v", err)
+		}
+	}
 	return c, nil
 }
 
@@ -240,9 +256,13 @@ func (c *DiskCache) loadSection(
 
 	// Ensure decompression (only first goroutine will actually perform work).
 	entry.decompress.Do(func() {
+		if c.persistent {
+			entry.decompress.data, entry.decompress.err = c.loadPersistedSection(key, mef)
+			return
+		}
 		outputPath := filepath.Join(c.dirPath, key.String())
 		entry.decompress.data, entry.decompress.err = decompressToDisk(
-			&c.checker, outputPath, cr.compressedFileRange, mef,
+			&c.checker, outputPath, cr.compressedFileRange, mef, false, /* persist */
 		)
 	})
 	if entry.decompress.err != nil {
@@ -305,25 +325,48 @@ func (c *DiskCache) getOrCreateEntry(key cacheKey) (*cacheEntry, error) {
 
 // decompressToDisk performs the actual decompression and writes the
 // uncompressed data to the outputPath.
+//
+// If persist is false, the file is unlinked right away and only lives as long
+// as the returned mapping. Otherwise, the data is written to a temporary file
+// that is renamed to outputPath once complete, so that a crash never leaves a
+// truncated section behind.
 func decompressToDisk(
 	checker *spaceChecker,
 	outputPath string,
 	cr compressedFileRange,
 	mef *MMappingElfFile,
+	persist bool,
 ) (_ []byte, retErr error) {
 	if err := checker.check(uint64(cr.uncompressedLength)); err != nil {
 		return nil, err
 	}
 
-	f, err := os.OpenFile(outputPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	var f *os.File
+	var err error
+	if persist {
+		tmpPath := outputPath + persistedTmpSuffix
+		f, err = os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		defer func() {
+			if retErr != nil {
+				_ = os.Remove(tmpPath)
+			}
+		}()
+	} else {
+		f, err = os.OpenFile(outputPath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
 	}
 	defer f.Close() // in case of a failure, we'll clean up the file
 	// Early remove the file to avoid leaking it to the system if we crash or
 	// are killed.
-	if err := os.Remove(outputPath); err != nil {
-		return nil, fmt.Errorf("failed to remove decompressed data file: %w", err)
+	if !persist {
+		if err := os.Remove(outputPath); err != nil {
+			return nil, fmt.Errorf("failed to remove decompressed data file: %w", err)
+		}
 	}
 	if cr.format != compressionFormatZlib {
 		return nil, fmt.Errorf("unsupported compression format: %d", cr.format)
@@ -350,6 +393,11 @@ func decompressToDisk(
 	if err := f.Sync(); err != nil {
 		return nil, fmt.Errorf("failed to sync temp file: %w", err)
 	}
+	if persist {
+		if err := os.Rename(f.Name(), outputPath); err != nil {
+			return nil, fmt.Errorf("failed to persist decompressed data file: %w", err)
+		}
+	}
 	m, err := syscall.Mmap(int(f.Fd()), 0, int(n), syscall.PROT_READ, syscall.MAP_SHARED)
 	if err != nil {
 		return nil, fmt.Errorf("failed to mmap cached section: %w", err)
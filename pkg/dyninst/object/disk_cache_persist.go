@@ -0,0 +1,167 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux_bpf
+
+package object
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// persistedSectionsDirName is the subdirectory of the cache directory in
+	// which persisted sections are stored.
+	persistedSectionsDirName = "sections"
+	// persistedTmpSuffix is the suffix of sections being written.
+	persistedTmpSuffix = ".tmp"
+)
+
+func (c *DiskCache) persistedSectionsDir() string {
+	return filepath.Join(c.dirPath, persistedSectionsDirName)
+}
+
+// loadPersistedSection maps the persisted file for the given key, writing it
+// first if it does not exist yet.
+func (c *DiskCache) loadPersistedSection(
+	key cacheKey, mef *MMappingElfFile,
+) ([]byte, error) {
+	sectionPath := filepath.Join(c.persistedSectionsDir(), key.String())
+	data, err := mmapPersistedSection(sectionPath, key.uncompressedLength)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		return data, nil
+	}
+	data, err = decompressToDisk(
+		&c.checker, sectionPath, key.compressedFileRange, mef, true, /* persist */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.prunePersistedSections(); err != nil {
+		log.Warnf("failed to prune persisted debug info sections: %v", err)
+	}
+	return data, nil
+}
+
+// mmapPersistedSection maps a previously persisted section. It returns nil if
+// the section does not exist or is unusable, in which case it needs to be
+// written again.
+func mmapPersistedSection(sectionPath string, length int64) (_ []byte, retErr error) {
+	f, err := os.Open(sectionPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open persisted section: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat persisted section: %w", err)
+	}
+	if info.Size() != length || length == 0 {
+		log.Warnf(
+			"removing persisted section %s: size %d does not match expected %d",
+			sectionPath, info.Size(), length,
+		)
+		if err := os.Remove(sectionPath); err != nil {
+			return nil, fmt.Errorf("failed to remove persisted section: %w", err)
+		}
+		return nil, nil
+	}
+	m, err := syscall.Mmap(int(f.Fd()), 0, int(length), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mmap persisted section: %w", err)
+	}
+	// The modification time tracks the last use of the section for eviction.
+	now := time.Now()
+	if err := os.Chtimes(sectionPath, now, now); err != nil {
+		log.Debugf("failed to update persisted section times: %v", err)
+	}
+	return m, nil
+}
+
+// prunePersistedSections removes leftover temporary files and evicts the
+// least recently used persisted sections until the sections that are not in
+// use fit within the cache size limit alongside the ones that are.
+func (c *DiskCache) prunePersistedSections() error {
+	dir := c.persistedSectionsDir()
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read persisted sections directory: %w", err)
+	}
+	inUse := make(map[string]struct{})
+	c.mu.Lock()
+	for key := range c.mu.entries {
+		inUse[key.String()] = struct{}{}
+	}
+	c.mu.Unlock()
+
+	type persistedFile struct {
+		name    string
+		size    uint64
+		modTime time.Time
+	}
+	var files []persistedFile
+	var total uint64
+	var errs []error
+	for _, de := range dirEntries {
+		name := de.Name()
+		if _, ok := inUse[strings.TrimSuffix(name, persistedTmpSuffix)]; ok {
+			info, err := de.Info()
+			if err == nil {
+				total += uint64(info.Size())
+			}
+			continue
+		}
+		if strings.HasSuffix(name, persistedTmpSuffix) || !de.Type().IsRegular() {
+			if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		size := uint64(info.Size())
+		files = append(files, persistedFile{
+			name: name, size: size, modTime: info.ModTime(),
+		})
+		total += size
+	}
+	slices.SortFunc(files, func(a, b persistedFile) int {
+		return cmp.Or(a.modTime.Compare(b.modTime), cmp.Compare(a.name, b.name))
+	})
+	for _, f := range files {
+		if total <= c.maxTotalBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, f.name)); err != nil &&
+			!errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+		total -= f.size
+	}
+	return errors.Join(errs...)
+}
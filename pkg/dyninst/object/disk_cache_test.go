@@ -309,3 +309,89 @@ func TestDiskCacheLimits(t *testing.T) {
 		})
 	}
 }
+
+// TestDiskCachePersistent verifies that persisted sections survive the cache
+// and are reused by a new cache rooted at the same directory.
+func TestDiskCachePersistent(t *testing.T) {
+	cfgs := testprogs.MustGetCommonConfigs(t)
+	require.NotEmpty(t, cfgs)
+	binaryPath := testprogs.MustGetBinary(t, "sample", cfgs[0])
+
+	cacheDir := t.TempDir()
+	sectionsDir := path.Join(cacheDir, "sections")
+	cfg := object.DiskCacheConfig{
+		DirPath:       cacheDir,
+		MaxTotalBytes: 512 * 1024 * 1024, // 512 MiB
+		Persistent:    true,
+	}
+	disk := stubDisk{total: 1 << 30, available: 1 << 30}
+
+	cache, err := object.NewDiskCacheInternal(cfg, disk)
+	require.NoError(t, err)
+	obj, err := cache.Load(binaryPath)
+	require.NoError(t, err)
+	directObj, err := object.OpenElfFileWithDwarf(binaryPath)
+	require.NoError(t, err)
+	defer directObj.Close()
+	requireEqualDwarfSections(t, obj, directObj)
+	require.NoError(t, obj.Close())
+	require.Zero(t, cache.SpaceInUse())
+
+	persisted, err := os.ReadDir(sectionsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, persisted, "expected sections to be persisted")
+	before := make(map[string]os.FileInfo, len(persisted))
+	for _, de := range persisted {
+		require.False(t, strings.HasSuffix(de.Name(), ".tmp"), de.Name())
+		info, err := de.Info()
+		require.NoError(t, err)
+		before[de.Name()] = info
+	}
+
+	// A new cache, as after a restart, reuses the persisted files.
+	cache, err = object.NewDiskCacheInternal(cfg, disk)
+	require.NoError(t, err)
+	obj, err = cache.Load(binaryPath)
+	require.NoError(t, err)
+	requireEqualDwarfSections(t, obj, directObj)
+	require.NoError(t, obj.Close())
+	persisted, err = os.ReadDir(sectionsDir)
+	require.NoError(t, err)
+	require.Len(t, persisted, len(before))
+	for _, de := range persisted {
+		info, err := de.Info()
+		require.NoError(t, err)
+		require.True(t, os.SameFile(before[de.Name()], info), de.Name())
+	}
+}
+
+// TestDiskCachePersistentPrune verifies that leftover temporary files and the
+// least recently used persisted sections are removed to honor the size limit.
+func TestDiskCachePersistentPrune(t *testing.T) {
+	cacheDir := t.TempDir()
+	sectionsDir := path.Join(cacheDir, "sections")
+	require.NoError(t, os.MkdirAll(sectionsDir, 0o755))
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c", "d"} {
+		p := path.Join(sectionsDir, name)
+		require.NoError(t, os.WriteFile(p, make([]byte, 1024), 0o600))
+		mtime := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(p, mtime, mtime))
+	}
+	require.NoError(t, os.WriteFile(path.Join(sectionsDir, "e.tmp"), []byte("partial"), 0o600))
+
+	_, err := object.NewDiskCacheInternal(object.DiskCacheConfig{
+		DirPath:       cacheDir,
+		MaxTotalBytes: 2048,
+		Persistent:    true,
+	}, stubDisk{total: 1 << 30, available: 1 << 30})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(sectionsDir)
+	require.NoError(t, err)
+	var names []string
+	for _, de := range entries {
+		names = append(names, de.Name())
+	}
+	require.Equal(t, []string{"c", "d"}, names)
+}
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/DataDog/datadog-agent/pkg/dyninst/ir"
 )
@@ -177,13 +178,52 @@ type MetricProbe struct {
 	Value *Value `json:"value,omitempty"`
 }
 
+var _ ir.MetricProbeDefinition = (*MetricProbe)(nil)
+
 func (m *MetricProbe) validate() error {
-	return validateWhere(m.Where)
+	if err := validateWhere(m.Where); err != nil {
+		return err
+	}
+	if m.MetricName == "" {
+		return errors.New("metricName must be set")
+	}
+	switch strings.ToUpper(m.Kind) {
+	case MetricKindCount, MetricKindGauge, MetricKindHistogram, MetricKindDistribution:
+	default:
+		return fmt.Errorf("unsupported metric kind %q", m.Kind)
+	}
+	return nil
 }
 
-// GetCaptureConfig returns the capture configuration of the probe.
+// Metric kinds supported by metric probes.
+const (
+	MetricKindCount        = "COUNT"
+	MetricKindGauge        = "GAUGE"
+	MetricKindHistogram    = "HISTOGRAM"
+	MetricKindDistribution = "DISTRIBUTION"
+)
+
+// GetCaptureConfig returns the capture configuration of the probe. Values
+// are only captured when the metric value is computed from them.
 func (m *MetricProbe) GetCaptureConfig() ir.CaptureConfig {
-	return noCaptureConfig{}
+	if m.Value == nil || len(m.Value.JSON) == 0 {
+		return noCaptureConfig{}
+	}
+	return (*irCaptureConfig)(nil)
+}
+
+// GetMetricKind returns the kind of the metric, in upper case.
+func (m *MetricProbe) GetMetricKind() string { return strings.ToUpper(m.Kind) }
+
+// GetMetricName returns the name of the metric.
+func (m *MetricProbe) GetMetricName() string { return m.MetricName }
+
+// GetMetricValue returns the value expression of the metric, if any.
+func (m *MetricProbe) GetMetricValue() (string, []byte) {
+	if m.Value == nil {
+		return "", nil
+	}
+	return m.Value.DSL, m.Value.JSON
 }
 
 // GetThrottleConfig returns the throttle configuration of the probe.
@@ -221,6 +261,19 @@ func (l *LogProbeCommon) GetCaptureConfig() ir.CaptureConfig {
 	return (*irCaptureConfig)(l.Capture)
 }
 
+// GetCondition returns the condition of the probe, if any.
+func (l *LogProbeCommon) GetCondition() (string, []byte) {
+	if l.When == nil {
+		return "", nil
+	}
+	return l.When.DSL, l.When.JSON
+}
+
+var (
+	_ ir.ConditionalProbeDefinition = (*LogProbe)(nil)
+	_ ir.ConditionalProbeDefinition = (*SnapshotProbe)(nil)
+)
+
 // LogProbe is a probe that emits a log.
 type LogProbe struct {
 	LogProbeCommon
@@ -378,13 +431,26 @@ func (noCaptureConfig) GetMaxFieldCount() uint32     { return 0 }
 func (noCaptureConfig) GetMaxLength() uint32         { return 0 }
 func (noCaptureConfig) GetMaxCollectionSize() uint32 { return 0 }
 
+// logThrottleConfig is a throttle configuration for log probes. Unless the
+// probe specifies its own sampling rate, log probes are allowed 5000 events
+// per second.
 type logThrottleConfig Sampling
 
-// logThrottleConfig is a throttle configuration for log probes that is
 var _ ir.ThrottleConfig = (*logThrottleConfig)(nil)
 
-func (c *logThrottleConfig) GetThrottlePeriodMs() uint32 { return 100 }
-func (c *logThrottleConfig) GetThrottleBudget() int64    { return 500 }
+func (c *logThrottleConfig) GetThrottlePeriodMs() uint32 {
+	if c == nil || c.SnapshotsPerSecond <= 0 {
+		return 100
+	}
+	return 1000
+}
+
+func (c *logThrottleConfig) GetThrottleBudget() int64 {
+	if c == nil || c.SnapshotsPerSecond <= 0 {
+		return 500
+	}
+	return max(1, int64(c.SnapshotsPerSecond))
+}
 
 type snapshotThrottleConfig Sampling
 
@@ -240,6 +240,30 @@ var testCases = []testCase{
 			},
 		},
 	},
+	{
+		name: "metric probe with unsupported kind",
+		input: `{
+				"id": "metric-probe-2",
+				"type": "METRIC_PROBE",
+				"where": {
+					"methodName": "MyMethod"
+				},
+				"kind": "set",
+				"metricName": "my.metric"
+			}`,
+		want: &MetricProbe{
+			ProbeCommon: ProbeCommon{
+				ID:   "metric-probe-2",
+				Type: TypeMetricProbe.String(),
+				Where: &Where{
+					MethodName: "MyMethod",
+				},
+			},
+			Kind:       "set",
+			MetricName: "my.metric",
+		},
+		validationErr: `unsupported metric kind "set"`,
+	},
 	{
 		name:         "invalid json",
 		input:        `{invalid json}`,
@@ -276,3 +300,24 @@ func TestUnmarshalProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestLogProbeThrottleConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		sampling *Sampling
+		periodMs uint32
+		budget   int64
+	}{
+		{name: "default", sampling: nil, periodMs: 100, budget: 500},
+		{name: "zero rate", sampling: &Sampling{}, periodMs: 100, budget: 500},
+		{name: "custom rate", sampling: &Sampling{SnapshotsPerSecond: 20}, periodMs: 1000, budget: 20},
+		{name: "fractional rate", sampling: &Sampling{SnapshotsPerSecond: 0.5}, periodMs: 1000, budget: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := &LogProbe{LogProbeCommon: LogProbeCommon{Sampling: tt.sampling}}
+			cfg := probe.GetThrottleConfig()
+			assert.Equal(t, tt.periodMs, cfg.GetThrottlePeriodMs())
+			assert.Equal(t, tt.budget, cfg.GetThrottleBudget())
+		})
+	}
+}
@@ -44,7 +44,9 @@ func (s *scraperRuntime) Load(
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate program: %w", err)
 	}
-	lp, err := s.loader.Load(smProgram)
+	lp, err := s.loader.Load(
+		smProgram, loader.WithTargetPID(uint32(processID.PID)),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load program: %w", err)
 	}
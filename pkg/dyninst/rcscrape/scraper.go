@@ -72,7 +72,7 @@ type Dispatcher interface {
 
 // Loader is an interface that enables the Scraper to load programs.
 type Loader interface {
-	Load(compiler.Program) (*loader.Program, error)
+	Load(compiler.Program, ...loader.LoadOption) (*loader.Program, error)
 }
 
 // IRGenerator is an interface that enables the Scraper to generate IR.
@@ -102,6 +102,12 @@ func enforcesBudget(t *testing.T, busyloopPath string) {
 	v, err := rd.Read()
 	log.Printf("err: %v", err)
 	require.ErrorIs(t, err, os.ErrDeadlineExceeded, "expected deadline exceeded, got %#+v, %#+v", err, v)
+
+	// Dropped events are accounted for.
+	throttled, err := program.ThrottledEvents()
+	require.NoError(t, err)
+	require.Len(t, throttled, 1)
+	require.Positive(t, throttled[0])
 }
 
 func refreshesBudget(t *testing.T, busyloopPath string) {
@@ -12,11 +12,15 @@ package uprobe
 import (
 	"errors"
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/cilium/ebpf/link"
 
 	"github.com/DataDog/datadog-agent/pkg/dyninst/loader"
 	"github.com/DataDog/datadog-agent/pkg/dyninst/procmon"
+	"github.com/DataDog/datadog-agent/pkg/util/kernel"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
 	"github.com/DataDog/datadog-agent/pkg/util/safeelf"
 )
 
@@ -81,6 +85,23 @@ func Attach(
 		return nil, fmt.Errorf("text section not found")
 	}
 
+	// The PID passed to the kernel is interpreted in our own PID namespace, in
+	// which the process may not be visible when we are not running in the host
+	// PID namespace. In that case, the uprobes are attached to every process
+	// running the executable and the program filters out the events of the
+	// other processes.
+	pid := int(processID.PID)
+	if !inHostPIDNamespace() {
+		if loaded.TargetPID != uint32(processID.PID) {
+			return nil, fmt.Errorf(
+				"program is not restricted to process %d and cannot be "+
+					"attached outside of the host PID namespace",
+				processID.PID,
+			)
+		}
+		pid = 0
+	}
+
 	attached := make([]link.Link, 0, len(loaded.Attachpoints))
 	for _, attachpoint := range loaded.Attachpoints {
 		addr := attachpoint.PC - textSection.Addr + textSection.Offset
@@ -88,7 +109,7 @@ func Attach(
 			"",
 			loaded.BpfProgram,
 			&link.UprobeOptions{
-				PID:     int(processID.PID),
+				PID:     pid,
 				Address: addr,
 				Offset:  0,
 				Cookie:  attachpoint.Cookie,
@@ -112,3 +133,19 @@ func Attach(
 		attachpoints: attached,
 	}, nil
 }
+
+// inHostPIDNamespace reports whether we are running in the same PID namespace
+// as the init process of the host.
+var inHostPIDNamespace = sync.OnceValue(func() bool {
+	self, err := os.Stat("/proc/self/ns/pid")
+	if err != nil {
+		log.Warnf("failed to stat own PID namespace, assuming host PID namespace: %v", err)
+		return true
+	}
+	host, err := os.Stat(kernel.HostProc("1", "ns", "pid"))
+	if err != nil {
+		log.Warnf("failed to stat host PID namespace, assuming host PID namespace: %v", err)
+		return true
+	}
+	return os.SameFile(self, host)
+})
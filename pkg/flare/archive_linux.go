@@ -39,6 +39,13 @@ func addSystemProbePlatformSpecificEntries(fb flaretypes.FlareBuilder) {
 		if pkgconfigsetup.SystemProbe().GetBool("discovery.enabled") {
 			_ = fb.AddFileFromFunc(filepath.Join("system-probe", "discovery.log"), getSystemProbeDiscoveryState)
 		}
+		// the loaded eBPF programs and the size and usage of the eBPF maps
+		if pkgconfigsetup.SystemProbe().GetBool("ebpf_check.enabled") {
+			_ = fb.AddFileFromFunc(filepath.Join("system-probe", "ebpf_stats.json"), getSystemProbeEBPFStats)
+		}
+		if pkgconfigsetup.SystemProbe().GetBool("service_monitoring_config.enabled") {
+			_ = fb.AddFileFromFunc(filepath.Join("system-probe", "usm_telemetry.log"), getSystemProbeUSMTelemetry)
+		}
 	}
 }
 
@@ -77,3 +84,15 @@ func getSystemProbeDiscoveryState() ([]byte, error) {
 	url := sysprobeclient.ModuleURL(sysconfig.DiscoveryModule, "/state")
 	return priviledged.GetHTTPData(sysProbeClient, url)
 }
+
+func getSystemProbeEBPFStats() ([]byte, error) {
+	sysProbeClient := sysprobeclient.Get(priviledged.GetSystemProbeSocketPath())
+	url := sysprobeclient.ModuleURL(sysconfig.EBPFModule, "/debug/stats?pretty_print=true")
+	return priviledged.GetHTTPData(sysProbeClient, url)
+}
+
+func getSystemProbeUSMTelemetry() ([]byte, error) {
+	sysProbeClient := sysprobeclient.Get(priviledged.GetSystemProbeSocketPath())
+	url := sysprobeclient.ModuleURL(sysconfig.NetworkTracerModule, "/debug/usm_telemetry")
+	return priviledged.GetHTTPData(sysProbeClient, url)
+}
@@ -44,6 +44,13 @@ func PrintConfigCheck(w io.Writer, cr integration.ConfigCheckResponse, withDebug
 				}
 			}
 		}
+		if len(cr.ResolveErrors) > 0 {
+			fmt.Fprintf(w, "\n=== Resolve %s by provider ===\n", color.RedString("errors"))
+			for _, stats := range cr.ResolveErrors {
+				fmt.Fprintf(w, "\n%s / %s / %s: %d\n", color.YellowString(stats.Provider), stats.ServiceKind, stats.Reason, stats.Count)
+				fmt.Fprintf(w, "* last error: %s\n", stats.LastError)
+			}
+		}
 		if len(cr.Unresolved) > 0 {
 			fmt.Fprintf(w, "\n=== %s configs (matched and unmatched) ===\n", color.MagentaString("Collected"))
 			for _, config := range cr.Unresolved {
@@ -45,6 +45,15 @@ func TestPrintConfigCheck(t *testing.T) {
 		ResolveWarnings: map[string][]string{
 			"some_identifier": {"some_warning"},
 		},
+		ResolveErrors: []integration.ResolveErrorStats{
+			{
+				Provider:    "container",
+				ServiceKind: "docker",
+				Reason:      "template_variables",
+				Count:       3,
+				LastError:   "some_error",
+			},
+		},
 		Unresolved: map[string]integration.Config{
 			"unresolved_config": {
 				ADIdentifiers: []string{"unresolved_config"},
@@ -98,6 +107,11 @@ Log Config:
 some_identifier
 * some_warning
 
+=== Resolve errors by provider ===
+
+container / docker / template_variables: 3
+* last error: some_error
+
 === Collected configs (matched and unmatched) ===
 
 Auto-discovery IDs: unresolved_config
@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package archive provides a destination archiving the logs sent by the agent
+// to a local directory or to an S3/GCS-compatible bucket.
+package archive
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+// Config is the configuration of the archive destination.
+type Config struct {
+	// Path is the local directory the archives are written to.
+	Path string
+	// BucketURL is the URL of the bucket, and optional key prefix, the archives
+	// are uploaded to, e.g. https://s3.us-east-1.amazonaws.com/my-bucket/logs
+	BucketURL string
+	// Region, AccessKeyID and SecretAccessKey are used to sign the uploads with
+	// AWS Signature Version 4. Without an access key, the credentials of the
+	// AWS default credential chain are used.
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// MaxFileSize is the maximum uncompressed size of an archive, in bytes.
+	MaxFileSize int
+	// FlushInterval is the maximum time the logs are buffered before an archive
+	// is written.
+	FlushInterval time.Duration
+	// MaxPendingFiles is the number of archives waiting to be written before
+	// new archives are dropped.
+	MaxPendingFiles int
+}
+
+// NewConfig returns the configuration of the archive destination of the
+// logs-agent, and whether archiving is enabled.
+func NewConfig(cfg pkgconfigmodel.Reader) (*Config, bool) {
+	if !cfg.GetBool("logs_config.archive.enabled") {
+		return nil, false
+	}
+	return &Config{
+		Path:            cfg.GetString("logs_config.archive.path"),
+		BucketURL:       cfg.GetString("logs_config.archive.bucket_url"),
+		Region:          cfg.GetString("logs_config.archive.region"),
+		AccessKeyID:     cfg.GetString("logs_config.archive.access_key_id"),
+		SecretAccessKey: cfg.GetString("logs_config.archive.secret_access_key"),
+		MaxFileSize:     cfg.GetInt("logs_config.archive.max_file_size"),
+		FlushInterval:   time.Duration(cfg.GetInt("logs_config.archive.flush_interval")) * time.Second,
+		MaxPendingFiles: cfg.GetInt("logs_config.archive.max_pending_files"),
+	}, true
+}
+
+// validate checks that exactly one location is configured.
+func (c *Config) validate() error {
+	if (c.Path == "") == (c.BucketURL == "") {
+		return errors.New("exactly one of logs_config.archive.path and logs_config.archive.bucket_url must be set")
+	}
+	if c.MaxFileSize <= 0 || c.FlushInterval <= 0 || c.MaxPendingFiles <= 0 {
+		return errors.New("logs_config.archive.max_file_size, flush_interval and max_pending_files must be positive")
+	}
+	if c.BucketURL != "" {
+		u, err := url.Parse(c.BucketURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.New("logs_config.archive.bucket_url must be an http or https URL")
+		}
+	}
+	return nil
+}
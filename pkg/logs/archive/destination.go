@@ -0,0 +1,260 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"time"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/logs/client"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/logs/metrics"
+	"github.com/DataDog/datadog-agent/pkg/util/backoff"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// backoff between the attempts to write an archive, in seconds
+	backoffFactor = 2
+	backoffBase   = 1
+	backoffMax    = 120
+
+	uploadTimeout = 60 * time.Second
+)
+
+// archiveFile is a gzip compressed NDJSON archive waiting to be written.
+type archiveFile struct {
+	key   string
+	data  []byte
+	count int64
+}
+
+// Destination writes the logs it receives to gzip compressed NDJSON archives,
+// in a local directory or in an S3/GCS-compatible bucket. It is meant to be
+// used as an unreliable destination: the archives waiting to be written are
+// bounded and dropped when the location is unavailable for too long, so that
+// archiving never blocks the pipeline.
+type Destination struct {
+	store           store
+	instanceID      string
+	maxFileSize     int
+	flushInterval   time.Duration
+	maxPendingFiles int
+	backoff         backoff.Policy
+	seq             int
+}
+
+// NewDestination returns a new archive destination.
+func NewDestination(config *Config, instanceID string, cfg pkgconfigmodel.Reader) (*Destination, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var s store
+	if config.Path != "" {
+		s = &localStore{dir: config.Path}
+	} else {
+		creds, err := newCredentialsProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the AWS credentials: %w", err)
+		}
+		s = &objectStore{
+			bucketURL:   config.BucketURL,
+			region:      config.Region,
+			credentials: creds,
+			signer:      newSigner(),
+			client: &http.Client{
+				Timeout: uploadTimeout,
+				// reusing core agent HTTP transport to benefit from proxy settings.
+				Transport: httputils.CreateHTTPTransport(cfg),
+			},
+		}
+	}
+
+	return newDestination(s, config, instanceID), nil
+}
+
+// Destinations returns the destinations archiving the payloads of a logs-agent
+// sender worker, if archiving is enabled. The logs agent registers it as a
+// factory of additional destinations of its senders.
+func Destinations(cfg pkgconfigmodel.Reader, instanceID string) []client.Destination {
+	archiveConfig, enabled := NewConfig(cfg)
+	if !enabled {
+		return nil
+	}
+
+	destination, err := NewDestination(archiveConfig, instanceID, cfg)
+	if err != nil {
+		log.Errorf("Logs archiving is disabled: %v", err)
+		return nil
+	}
+	return []client.Destination{destination}
+}
+
+func newDestination(s store, config *Config, instanceID string) *Destination {
+	metrics.DestinationLogsDropped.Set(s.target(), &expvar.Int{})
+	return &Destination{
+		store:           s,
+		instanceID:      instanceID,
+		maxFileSize:     config.MaxFileSize,
+		flushInterval:   config.FlushInterval,
+		maxPendingFiles: config.MaxPendingFiles,
+		backoff:         backoff.NewExpBackoffPolicy(backoffFactor, backoffBase, backoffMax, 0, false),
+	}
+}
+
+// IsMRF returns false, archives are never used for Multi-Region Failover.
+func (d *Destination) IsMRF() bool {
+	return false
+}
+
+// Target returns the location of the archives.
+func (d *Destination) Target() string {
+	return d.store.target()
+}
+
+// Metadata is not supported for archive destinations
+func (d *Destination) Metadata() *client.DestinationMetadata {
+	return client.NewNoopDestinationMetadata()
+}
+
+// Start buffers the payloads of the input into archives, which are written in
+// the background. The pending archives are written, or dropped after a single
+// attempt, once the input is closed.
+func (d *Destination) Start(input chan *message.Payload, output chan *message.Payload, _ chan bool) (stopChan <-chan struct{}) {
+	stop := make(chan struct{})
+	files := make(chan *archiveFile, d.maxPendingFiles)
+	stopping := make(chan struct{})
+	written := make(chan struct{})
+
+	go func() {
+		for file := range files {
+			d.writeAndRetry(file, stopping)
+		}
+		close(written)
+	}()
+
+	go func() {
+		d.run(input, output, files)
+		close(stopping)
+		close(files)
+		<-written
+		stop <- struct{}{}
+	}()
+	return stop
+}
+
+// run buffers the logs of the payloads until an archive is full or the flush
+// interval has elapsed.
+func (d *Destination) run(input chan *message.Payload, output chan *message.Payload, files chan *archiveFile) {
+	ticker := time.NewTicker(d.flushInterval)
+	defer ticker.Stop()
+
+	var buffer []byte
+	var count int64
+	flush := func() {
+		if count > 0 {
+			d.flush(buffer, count, files)
+		}
+		buffer = buffer[:0]
+		count = 0
+	}
+
+	for {
+		select {
+		case payload, ok := <-input:
+			if !ok {
+				flush()
+				return
+			}
+
+			var err error
+			if buffer, err = appendNDJSON(buffer, payload); err != nil {
+				log.Warnf("Could not archive payload: %v", err)
+				d.drop(payload.Count())
+			} else {
+				count += payload.Count()
+			}
+			output <- payload
+
+			if len(buffer) >= d.maxFileSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// flush compresses the buffered logs into an archive and queues it, dropping
+// it if too many archives are already waiting to be written.
+func (d *Destination) flush(buffer []byte, count int64, files chan *archiveFile) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(buffer); err != nil {
+		log.Warnf("Could not compress the logs archive: %v", err)
+		d.drop(count)
+		return
+	}
+	if err := writer.Close(); err != nil {
+		log.Warnf("Could not compress the logs archive: %v", err)
+		d.drop(count)
+		return
+	}
+
+	file := &archiveFile{
+		key:   d.nextKey(time.Now()),
+		data:  compressed.Bytes(),
+		count: count,
+	}
+
+	select {
+	case files <- file:
+	default:
+		log.Warnf("Too many logs archives waiting to be written to %s, dropping %s", d.Target(), file.key)
+		d.drop(count)
+	}
+}
+
+// writeAndRetry writes an archive, retrying with a backoff on errors. When the
+// destination is stopping, the archive is dropped after a single attempt.
+func (d *Destination) writeAndRetry(file *archiveFile, stopping <-chan struct{}) {
+	for numErrors := 1; ; numErrors++ {
+		ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+		err := d.store.put(ctx, file.key, file.data)
+		cancel()
+		if err == nil {
+			return
+		}
+		log.Warnf("Could not write the logs archive %s to %s: %v", file.key, d.Target(), err)
+
+		select {
+		case <-stopping:
+			d.drop(file.count)
+			return
+		case <-time.After(d.backoff.GetBackoffDuration(numErrors)):
+		}
+	}
+}
+
+// nextKey returns the key of a new archive, partitioned by hour.
+func (d *Destination) nextKey(now time.Time) string {
+	now = now.UTC()
+	d.seq++
+	return fmt.Sprintf("%s/logs_%s_%d_%d.ndjson.gz", now.Format("2006/01/02/15"), d.instanceID, now.UnixNano(), d.seq)
+}
+
+func (d *Destination) drop(count int64) {
+	metrics.DestinationLogsDropped.Add(d.Target(), count)
+	metrics.TlmLogsDropped.Add(float64(count), d.Target())
+}
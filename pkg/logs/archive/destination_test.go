@@ -0,0 +1,279 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+func testConfig() *Config {
+	return &Config{
+		MaxFileSize:     1024,
+		FlushInterval:   time.Hour,
+		MaxPendingFiles: 10,
+	}
+}
+
+func gunzip(t *testing.T, data []byte) string {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+// readArchives returns the content of the archives written to a directory.
+func readArchives(t *testing.T, dir string) []string {
+	var archives []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".ndjson.gz") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		archives = append(archives, gunzip(t, data))
+		return nil
+	})
+	require.NoError(t, err)
+	return archives
+}
+
+// send sends the payloads to a destination and stops it.
+func send(destination *Destination, payloads ...*message.Payload) []*message.Payload {
+	input := make(chan *message.Payload, len(payloads))
+	output := make(chan *message.Payload, len(payloads))
+	stop := destination.Start(input, output, nil)
+	for _, payload := range payloads {
+		input <- payload
+	}
+	close(input)
+	<-stop
+	close(output)
+
+	var sent []*message.Payload
+	for payload := range output {
+		sent = append(sent, payload)
+	}
+	return sent
+}
+
+func TestNewDestinationInvalidConfig(t *testing.T) {
+	config := testConfig()
+	_, err := NewDestination(config, "0", nil)
+	assert.Error(t, err, "a location is required")
+
+	config.Path = t.TempDir()
+	config.BucketURL = "https://bucket.example.com"
+	_, err = NewDestination(config, "0", nil)
+	assert.Error(t, err, "the locations are exclusive")
+
+	config.Path = ""
+	config.BucketURL = "ftp://bucket.example.com"
+	_, err = NewDestination(config, "0", nil)
+	assert.Error(t, err, "the bucket URL must be an http URL")
+}
+
+func TestDestinationLocalDirectory(t *testing.T) {
+	dir := t.TempDir()
+	config := testConfig()
+	config.Path = dir
+	destination, err := NewDestination(config, "0", nil)
+	require.NoError(t, err)
+
+	payloads := []*message.Payload{
+		newPayload(compress(t, "gzip", []byte(`[{"message":"first"},{"message":"second"}]`)), "gzip", 2),
+		newPayload([]byte(`[{"message":"third"}]`), "identity", 1),
+	}
+	sent := send(destination, payloads...)
+	assert.Equal(t, payloads, sent)
+
+	archives := readArchives(t, dir)
+	assert.Equal(t, []string{"{\"message\":\"first\"}\n{\"message\":\"second\"}\n{\"message\":\"third\"}\n"}, archives)
+}
+
+func TestDestinationMaxFileSize(t *testing.T) {
+	dir := t.TempDir()
+	config := testConfig()
+	config.Path = dir
+	config.MaxFileSize = 10
+	destination, err := NewDestination(config, "0", nil)
+	require.NoError(t, err)
+
+	send(destination,
+		newPayload([]byte(`[{"message":"first"}]`), "identity", 1),
+		newPayload([]byte(`[{"message":"second"}]`), "identity", 1),
+	)
+
+	archives := readArchives(t, dir)
+	assert.ElementsMatch(t, []string{"{\"message\":\"first\"}\n", "{\"message\":\"second\"}\n"}, archives)
+}
+
+func TestDestinationFlushInterval(t *testing.T) {
+	dir := t.TempDir()
+	config := testConfig()
+	config.Path = dir
+	config.FlushInterval = 10 * time.Millisecond
+	destination, err := NewDestination(config, "0", nil)
+	require.NoError(t, err)
+
+	input := make(chan *message.Payload, 1)
+	output := make(chan *message.Payload, 1)
+	stop := destination.Start(input, output, nil)
+	input <- newPayload([]byte(`[{"message":"first"}]`), "identity", 1)
+	<-output
+
+	assert.Eventually(t, func() bool {
+		return len(readArchives(t, dir)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(input)
+	<-stop
+}
+
+// failingStore fails to write the archives until it is allowed to.
+type failingStore struct {
+	mu       sync.Mutex
+	fail     bool
+	attempts int
+	archives map[string][]byte
+}
+
+func (s *failingStore) put(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.fail {
+		return errors.New("unavailable")
+	}
+	s.archives[key] = data
+	return nil
+}
+
+func (s *failingStore) target() string {
+	return "failing"
+}
+
+func TestDestinationRetries(t *testing.T) {
+	s := &failingStore{fail: true, archives: make(map[string][]byte)}
+	destination := newDestination(s, testConfig(), "0")
+	destination.maxFileSize = 1
+
+	input := make(chan *message.Payload, 1)
+	output := make(chan *message.Payload, 1)
+	stop := destination.Start(input, output, nil)
+	input <- newPayload([]byte(`[{"message":"first"}]`), "identity", 1)
+	<-output
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.attempts > 0
+	}, 5*time.Second, time.Millisecond)
+
+	s.mu.Lock()
+	s.fail = false
+	s.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.archives) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	close(input)
+	<-stop
+}
+
+func TestDestinationDropsOnStop(t *testing.T) {
+	s := &failingStore{fail: true, archives: make(map[string][]byte)}
+	destination := newDestination(s, testConfig(), "0")
+
+	send(destination, newPayload([]byte(`[{"message":"first"}]`), "identity", 1))
+
+	assert.Equal(t, 1, s.attempts)
+	assert.Empty(t, s.archives)
+}
+
+func TestObjectStore(t *testing.T) {
+	var request *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	s := &objectStore{
+		bucketURL:   server.URL + "/bucket/prefix/",
+		region:      "us-east-1",
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "secret", "token"),
+		signer:      newSigner(),
+		client:      server.Client(),
+	}
+	err := s.put(context.Background(), "2026/10/15/14/logs.ndjson.gz", []byte("archive"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPut, request.Method)
+	assert.Equal(t, "/bucket/prefix/2026/10/15/14/logs.ndjson.gz", request.URL.Path)
+	assert.Equal(t, "archive", string(body))
+	assert.Equal(t, sha256Hex([]byte("archive")), request.Header.Get("X-Amz-Content-Sha256"))
+	assert.Equal(t, "token", request.Header.Get("X-Amz-Security-Token"))
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/us-east-1/s3/aws4_request, SignedHeaders=[a-z0-9;-]*x-amz-content-sha256;x-amz-date[a-z0-9;-]*, Signature=[0-9a-f]{64}$`, request.Header.Get("Authorization"))
+}
+
+func TestObjectStoreError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	s := &objectStore{
+		bucketURL:   server.URL,
+		credentials: credentials.NewStaticCredentialsProvider("AKID", "secret", ""),
+		signer:      newSigner(),
+		client:      server.Client(),
+	}
+	err := s.put(context.Background(), "logs.ndjson.gz", []byte("archive"))
+	assert.Error(t, err)
+}
+
+func TestObjectStoreCredentialsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("unsigned uploads must not be sent")
+	}))
+	defer server.Close()
+
+	s := &objectStore{
+		bucketURL: server.URL,
+		credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{}, errors.New("no credentials")
+		}),
+		signer: newSigner(),
+		client: server.Client(),
+	}
+	err := s.put(context.Background(), "logs.ndjson.gz", []byte("archive"))
+	assert.ErrorContains(t, err, "no credentials")
+}
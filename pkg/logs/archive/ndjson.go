@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/client"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+// appendNDJSON appends the logs of a payload to dst, one per line. The JSON
+// arrays of the http payloads are split into their elements, the content of
+// the other payloads is written as a single line.
+func appendNDJSON(dst []byte, payload *message.Payload) ([]byte, error) {
+	content, err := client.DecodePayload(payload)
+	if err != nil {
+		return dst, err
+	}
+
+	content = bytes.TrimSpace(content)
+	if len(content) == 0 {
+		return dst, nil
+	}
+
+	if content[0] == '[' {
+		var logs []json.RawMessage
+		if err := json.Unmarshal(content, &logs); err == nil {
+			buf := bytes.NewBuffer(dst)
+			for _, log := range logs {
+				// a log must fit on a single line
+				if err := json.Compact(buf, log); err != nil {
+					return dst, err
+				}
+				buf.WriteByte('\n')
+			}
+			return buf.Bytes(), nil
+		}
+	}
+
+	dst = append(dst, content...)
+	return append(dst, '\n'), nil
+}
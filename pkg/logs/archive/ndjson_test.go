@@ -0,0 +1,75 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+func newPayload(content []byte, encoding string, count int) *message.Payload {
+	metas := make([]*message.MessageMetadata, count)
+	for i := range metas {
+		metas[i] = &message.MessageMetadata{}
+	}
+	return message.NewPayload(metas, content, encoding, len(content))
+}
+
+func compress(t *testing.T, encoding string, content []byte) []byte {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+	case "zstd":
+		var err error
+		writer, err = zstd.NewWriter(&buf)
+		require.NoError(t, err)
+	default:
+		return content
+	}
+	_, err := writer.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestAppendNDJSON(t *testing.T) {
+	content := []byte(`[{"message":"first"},{"message": "second",
+"status":"info"}]`)
+
+	for _, encoding := range []string{"identity", "gzip", "deflate", "zstd"} {
+		t.Run(encoding, func(t *testing.T) {
+			payload := newPayload(compress(t, encoding, content), encoding, 2)
+
+			ndjson, err := appendNDJSON([]byte("{\"message\":\"previous\"}\n"), payload)
+			require.NoError(t, err)
+			assert.Equal(t, "{\"message\":\"previous\"}\n{\"message\":\"first\"}\n{\"message\":\"second\",\"status\":\"info\"}\n", string(ndjson))
+		})
+	}
+}
+
+func TestAppendNDJSONSingleLog(t *testing.T) {
+	ndjson, err := appendNDJSON(nil, newPayload([]byte("{\"message\":\"tcp\"}\n"), "", 1))
+	require.NoError(t, err)
+	assert.Equal(t, "{\"message\":\"tcp\"}\n", string(ndjson))
+}
+
+func TestAppendNDJSONUnsupportedEncoding(t *testing.T) {
+	_, err := appendNDJSON(nil, newPayload([]byte("content"), "br", 1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+const sigV4Service = "s3"
+
+// newCredentialsProvider returns the credentials used to sign the uploads: the
+// configured access key, or the credentials of the AWS default credential
+// chain (environment variables, shared configuration files, IAM role of the
+// instance or task) when no access key is set.
+func newCredentialsProvider(config *Config) (aws.CredentialsProvider, error) {
+	if config.AccessKeyID != "" {
+		return credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""), nil
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(config.Region))
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Credentials, nil
+}
+
+// newSigner returns a signer of S3 requests with AWS Signature Version 4, which
+// is also accepted by GCS with HMAC keys and by most S3-compatible object
+// stores.
+func newSigner() *v4.Signer {
+	return v4.NewSigner(func(o *v4.SignerOptions) {
+		// S3 paths are only escaped once
+		o.DisableURIPathEscaping = true
+	})
+}
+
+// signV4 signs an S3 request with the given credentials. S3 requires the
+// hash of the payload in the X-Amz-Content-Sha256 header.
+func signV4(ctx context.Context, signer *v4.Signer, creds aws.Credentials, req *http.Request, body []byte, region string, now time.Time) error {
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	return signer.SignHTTP(ctx, creds, req, payloadHash, sigV4Service, region, now)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
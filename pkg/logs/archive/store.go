@@ -0,0 +1,99 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// store writes the archives to their location.
+type store interface {
+	// put writes an archive under the given key, a slash separated path.
+	put(ctx context.Context, key string, data []byte) error
+	// target returns the location of the archives.
+	target() string
+}
+
+// localStore writes the archives to a local directory.
+type localStore struct {
+	dir string
+}
+
+func (s *localStore) put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that no partial archive is ever
+	// visible in the directory
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func (s *localStore) target() string {
+	return s.dir
+}
+
+// objectStore uploads the archives to an S3/GCS-compatible bucket with HTTP
+// PUT requests, signed with AWS Signature Version 4.
+type objectStore struct {
+	bucketURL   string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	client      *http.Client
+}
+
+func (s *objectStore) put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(s.bucketURL, "/")+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+
+	creds, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the credentials to sign the upload of %s: %w", key, err)
+	}
+	if err := signV4(ctx, s.signer, creds, req, data, s.region, time.Now()); err != nil {
+		return fmt.Errorf("unable to sign the upload of %s: %w", key, err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d uploading %s", resp.StatusCode, key)
+	}
+	return nil
+}
+
+func (s *objectStore) target() string {
+	return s.bucketURL
+}
@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
@@ -77,6 +78,9 @@ type Destination struct {
 	protocol            config.IntakeProtocol
 	origin              config.IntakeOrigin
 	isMRF               bool
+	// gzipFallback is set once the intake rejected a zstd payload, the zstd
+	// payloads are then re-encoded with gzip before being sent
+	gzipFallback atomic.Bool
 
 	// Concurrency
 	workerPool *workerPool
@@ -317,6 +321,16 @@ func (d *Destination) unconditionalSend(payload *message.Payload) (err error) {
 		compressionKind = d.endpoint.CompressionKind
 	}
 
+	encoded, encoding := payload.Encoded, payload.Encoding
+	if encoding == zstdContentEncoding && d.gzipFallback.Load() {
+		if encoded, err = zstdToGzip(payload.Encoded); err != nil {
+			tlmDropped.Inc()
+			return fmt.Errorf("could not re-encode the payload with gzip: %w", err)
+		}
+		encoding = gzipContentEncoding
+		compressionKind = gzipContentEncoding
+	}
+
 	if strings.Contains(d.Metadata().TelemetryName(), "logs") {
 		sourceTag = "logs"
 	} else {
@@ -324,10 +338,10 @@ func (d *Destination) unconditionalSend(payload *message.Payload) (err error) {
 	}
 
 	metrics.TlmBytesSent.Add(float64(payload.UnencodedSize), sourceTag)
-	metrics.EncodedBytesSent.Add(int64(len(payload.Encoded)))
-	metrics.TlmEncodedBytesSent.Add(float64(len(payload.Encoded)), sourceTag, compressionKind)
+	metrics.EncodedBytesSent.Add(int64(len(encoded)))
+	metrics.TlmEncodedBytesSent.Add(float64(len(encoded)), sourceTag, compressionKind)
 
-	req, err := http.NewRequest("POST", d.url, bytes.NewReader(payload.Encoded))
+	req, err := http.NewRequest("POST", d.url, bytes.NewReader(encoded))
 	if err != nil {
 		// the request could not be built,
 		// this can happen when the method or the url are valid.
@@ -337,8 +351,8 @@ func (d *Destination) unconditionalSend(payload *message.Payload) (err error) {
 	req.Header.Set("Content-Type", d.contentType)
 	req.Header.Set("User-Agent", fmt.Sprintf("datadog-agent/%s", version.AgentVersion))
 
-	if payload.Encoding != "" {
-		req.Header.Set("Content-Encoding", payload.Encoding)
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
 	}
 	if d.protocol != "" {
 		req.Header.Set("DD-PROTOCOL", string(d.protocol))
@@ -378,6 +392,13 @@ func (d *Destination) unconditionalSend(payload *message.Payload) (err error) {
 	metrics.DestinationHTTPRespByStatusAndURL.Add(strconv.Itoa(resp.StatusCode), 1)
 	metrics.TlmDestinationHTTPRespByStatusAndURL.Inc(strconv.Itoa(resp.StatusCode), d.url)
 
+	if resp.StatusCode == http.StatusUnsupportedMediaType && encoding == zstdContentEncoding {
+		// the intake does not support zstd, the payload is sent again with gzip
+		// as are all the next payloads of this destination
+		log.Warnf("%s does not support zstd payloads, falling back to gzip", d.url)
+		d.gzipFallback.Store(true)
+		return d.unconditionalSend(payload)
+	}
 	if resp.StatusCode >= http.StatusBadRequest {
 		log.Warnf("failed to post http payload. code=%d, url=%s, EvP track type=%s, content type=%s, EvP category=%s, origin=%s, response=%s", resp.StatusCode, d.url, d.endpoint.TrackType, d.contentType, d.destMeta.EvpCategory(), d.origin, string(response))
 	}
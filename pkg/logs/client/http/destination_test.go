@@ -8,14 +8,18 @@ package http
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/DataDog/datadog-agent/pkg/logs/client"
@@ -775,3 +779,49 @@ func TestHTTPTimeoutOverride(t *testing.T) {
 	client := httpClientFactory(cfg, 15*time.Second)()
 	assert.Equal(t, 15*time.Second, client.Timeout)
 }
+
+func TestDestinationFallsBackToGzip(t *testing.T) {
+	cfg := configmock.New(t)
+
+	var encodings []string
+	var received []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		encodings = append(encodings, encoding)
+		if encoding == "zstd" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		reader, err := gzip.NewReader(r.Body)
+		if assert.NoError(t, err) {
+			received, err = io.ReadAll(reader)
+			assert.NoError(t, err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	url := strings.Split(ts.URL, ":")
+	port, _ := strconv.Atoi(url[2])
+	destCtx := client.NewDestinationsContext()
+	destCtx.Start()
+	defer destCtx.Stop()
+	endpoint := config.NewEndpoint("test", "", strings.ReplaceAll(url[1], "/", ""), port, config.EmptyPathPrefix, false)
+	dest := NewDestination(endpoint, JSONContentType, destCtx, true, client.NewNoopDestinationMetadata(), cfg, 1, 1, metrics.NewNoopPipelineMonitor(""), "test")
+
+	encoder, err := zstd.NewWriter(nil)
+	assert.NoError(t, err)
+	payload := &message.Payload{
+		Encoded:       encoder.EncodeAll([]byte("payload"), nil),
+		Encoding:      "zstd",
+		UnencodedSize: 7,
+	}
+
+	assert.NoError(t, dest.unconditionalSend(payload))
+	assert.Equal(t, []string{"zstd", "gzip"}, encodings)
+	assert.Equal(t, []byte("payload"), received)
+
+	// the next payloads are directly sent with gzip
+	assert.NoError(t, dest.unconditionalSend(payload))
+	assert.Equal(t, []string{"zstd", "gzip", "gzip"}, encodings)
+}
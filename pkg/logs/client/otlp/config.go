@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package otlp provides a destination forwarding the logs sent by the agent to
+// an OTLP/HTTP endpoint.
+package otlp
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+)
+
+// logsPath is the path of the OTLP/HTTP logs endpoint
+const logsPath = "/v1/logs"
+
+// Config is the configuration of the OTLP destination.
+type Config struct {
+	// Endpoint is the base URL of the OTLP/HTTP receiver, e.g.
+	// http://otel-collector:4318
+	Endpoint string
+	// Headers are added to every export request, e.g. for authentication.
+	Headers map[string]string
+	// Timeout is the timeout of an export request.
+	Timeout time.Duration
+}
+
+// NewConfig returns the configuration of the OTLP destination of the
+// logs-agent, and whether the OTLP export is enabled.
+func NewConfig(cfg pkgconfigmodel.Reader) (*Config, bool) {
+	if !cfg.GetBool("logs_config.otlp_export.enabled") {
+		return nil, false
+	}
+	return &Config{
+		Endpoint: cfg.GetString("logs_config.otlp_export.endpoint"),
+		Headers:  cfg.GetStringMapString("logs_config.otlp_export.headers"),
+		Timeout:  time.Duration(cfg.GetInt("logs_config.http_timeout")) * time.Second,
+	}, true
+}
+
+// logsURL returns the URL of the logs endpoint of the receiver, the endpoint
+// being either the base URL of the receiver or the logs endpoint itself.
+func (c *Config) logsURL() (string, error) {
+	if c.Endpoint == "" {
+		return "", errors.New("logs_config.otlp_export.endpoint must be set")
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.New("logs_config.otlp_export.endpoint must be an http or https URL")
+	}
+	if !strings.HasSuffix(u.Path, logsPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + logsPath
+	}
+	return u.String(), nil
+}
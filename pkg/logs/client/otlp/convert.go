@@ -0,0 +1,175 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/version"
+)
+
+// scopeName is the instrumentation scope of the exported logs
+const scopeName = "datadog-agent"
+
+// datadogLog is the JSON representation of a log sent by the logs-agent to the
+// Datadog intake.
+type datadogLog struct {
+	Message   string `json:"message"`
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+	Hostname  string `json:"hostname"`
+	Service   string `json:"service"`
+	Source    string `json:"ddsource"`
+	Tags      string `json:"ddtags"`
+}
+
+// The types below follow the JSON encoding of the OTLP protocol, see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type exportLogsServiceRequest struct {
+	ResourceLogs []*resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource     `json:"resource"`
+	ScopeLogs []*scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type logRecord struct {
+	TimeUnixNano         string   `json:"timeUnixNano"`
+	ObservedTimeUnixNano string   `json:"observedTimeUnixNano"`
+	SeverityNumber       int      `json:"severityNumber,omitempty"`
+	SeverityText         string   `json:"severityText,omitempty"`
+	Body                 anyValue `json:"body"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// tagAttributes maps the tags to their semantic convention attribute.
+var tagAttributes = map[string]string{
+	"env":     "deployment.environment.name",
+	"version": "service.version",
+}
+
+// severityNumbers maps the statuses to their OTLP severity number.
+var severityNumbers = map[string]int{
+	"trace":     1,
+	"debug":     5,
+	"info":      9,
+	"notice":    10,
+	"warn":      13,
+	"warning":   13,
+	"error":     17,
+	"critical":  18,
+	"alert":     19,
+	"emergency": 21,
+	"fatal":     21,
+}
+
+// toRequest converts the content of a payload into an OTLP export request.
+// The logs are grouped by resource, made of their hostname, service, source
+// and tags. The content of the payloads that are not JSON arrays of logs is
+// exported as a single log.
+func toRequest(content []byte, now time.Time) *exportLogsServiceRequest {
+	var logs []datadogLog
+	content = bytes.TrimSpace(content)
+	if err := json.Unmarshal(content, &logs); err != nil {
+		logs = []datadogLog{{Message: string(content)}}
+	}
+
+	request := &exportLogsServiceRequest{}
+	scopes := make(map[string]*scopeLogs)
+	for _, l := range logs {
+		key := strings.Join([]string{l.Hostname, l.Service, l.Source, l.Tags}, "\x00")
+		sl, found := scopes[key]
+		if !found {
+			sl = &scopeLogs{Scope: scope{Name: scopeName, Version: version.AgentVersion}}
+			scopes[key] = sl
+			request.ResourceLogs = append(request.ResourceLogs, &resourceLogs{
+				Resource:  resource{Attributes: resourceAttributes(l)},
+				ScopeLogs: []*scopeLogs{sl},
+			})
+		}
+		sl.LogRecords = append(sl.LogRecords, toLogRecord(l, now))
+	}
+	return request
+}
+
+// resourceAttributes maps the hostname, service, source and tags of a log to
+// resource attributes.
+func resourceAttributes(l datadogLog) []keyValue {
+	var attributes []keyValue
+	add := func(key, value string) {
+		if value != "" {
+			attributes = append(attributes, keyValue{Key: key, Value: anyValue{StringValue: value}})
+		}
+	}
+
+	add("host.name", l.Hostname)
+	add("service.name", l.Service)
+	add("datadog.log.source", l.Source)
+
+	// the values of a tag set several times are joined
+	var keys []string
+	values := make(map[string][]string)
+	for _, tag := range strings.Split(l.Tags, ",") {
+		if tag == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(tag, ":")
+		if attribute, found := tagAttributes[key]; found {
+			key = attribute
+		}
+		if _, found := values[key]; !found {
+			keys = append(keys, key)
+		}
+		values[key] = append(values[key], value)
+	}
+	for _, key := range keys {
+		attributes = append(attributes, keyValue{Key: key, Value: anyValue{StringValue: strings.Join(values[key], ",")}})
+	}
+	return attributes
+}
+
+func toLogRecord(l datadogLog, now time.Time) logRecord {
+	observed := strconv.FormatInt(now.UnixNano(), 10)
+	record := logRecord{
+		TimeUnixNano:         observed,
+		ObservedTimeUnixNano: observed,
+		SeverityNumber:       severityNumbers[strings.ToLower(l.Status)],
+		SeverityText:         l.Status,
+		Body:                 anyValue{StringValue: l.Message},
+	}
+	if l.Timestamp != 0 {
+		record.TimeUnixNano = strconv.FormatInt(time.UnixMilli(l.Timestamp).UnixNano(), 10)
+	}
+	return record
+}
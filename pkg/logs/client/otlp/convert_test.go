@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringAttribute(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: value}}
+}
+
+func TestToRequest(t *testing.T) {
+	now := time.UnixMilli(1700000001000)
+	content := []byte(`[
+		{"message":"first","status":"info","timestamp":1700000000000,"hostname":"host","service":"web","ddsource":"nginx","ddtags":"env:prod,version:1.2,team:a,team:b,standalone"},
+		{"message":"second","status":"error","timestamp":0,"hostname":"host","service":"db","ddsource":"postgres","ddtags":""},
+		{"message":"third","status":"warn","timestamp":1700000000500,"hostname":"host","service":"web","ddsource":"nginx","ddtags":"env:prod,version:1.2,team:a,team:b,standalone"}
+	]`)
+
+	request := toRequest(content, now)
+	require.Len(t, request.ResourceLogs, 2)
+
+	web := request.ResourceLogs[0]
+	assert.Equal(t, []keyValue{
+		stringAttribute("host.name", "host"),
+		stringAttribute("service.name", "web"),
+		stringAttribute("datadog.log.source", "nginx"),
+		stringAttribute("deployment.environment.name", "prod"),
+		stringAttribute("service.version", "1.2"),
+		stringAttribute("team", "a,b"),
+		stringAttribute("standalone", ""),
+	}, web.Resource.Attributes)
+	require.Len(t, web.ScopeLogs, 1)
+	assert.Equal(t, scopeName, web.ScopeLogs[0].Scope.Name)
+	assert.Equal(t, []logRecord{
+		{
+			TimeUnixNano:         "1700000000000000000",
+			ObservedTimeUnixNano: "1700000001000000000",
+			SeverityNumber:       9,
+			SeverityText:         "info",
+			Body:                 anyValue{StringValue: "first"},
+		},
+		{
+			TimeUnixNano:         "1700000000500000000",
+			ObservedTimeUnixNano: "1700000001000000000",
+			SeverityNumber:       13,
+			SeverityText:         "warn",
+			Body:                 anyValue{StringValue: "third"},
+		},
+	}, web.ScopeLogs[0].LogRecords)
+
+	db := request.ResourceLogs[1]
+	assert.Equal(t, []keyValue{
+		stringAttribute("host.name", "host"),
+		stringAttribute("service.name", "db"),
+		stringAttribute("datadog.log.source", "postgres"),
+	}, db.Resource.Attributes)
+	require.Len(t, db.ScopeLogs[0].LogRecords, 1)
+	assert.Equal(t, "1700000001000000000", db.ScopeLogs[0].LogRecords[0].TimeUnixNano, "the observed time is used without timestamp")
+	assert.Equal(t, 17, db.ScopeLogs[0].LogRecords[0].SeverityNumber)
+}
+
+func TestToRequestNotJSON(t *testing.T) {
+	request := toRequest([]byte("<46>raw log\n"), time.Now())
+	require.Len(t, request.ResourceLogs, 1)
+	assert.Empty(t, request.ResourceLogs[0].Resource.Attributes)
+	require.Len(t, request.ResourceLogs[0].ScopeLogs[0].LogRecords, 1)
+	assert.Equal(t, "<46>raw log", request.ResourceLogs[0].ScopeLogs[0].LogRecords[0].Body.StringValue)
+}
@@ -0,0 +1,186 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/logs/client"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/logs/metrics"
+	"github.com/DataDog/datadog-agent/pkg/util/backoff"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// backoff between the attempts to export a payload, in seconds
+	backoffFactor = 2
+	backoffBase   = 1
+	backoffMax    = 30
+
+	// maxAttempts is the number of attempts to export a payload before it is
+	// dropped, so that an unavailable receiver never blocks the pipeline.
+	maxAttempts = 5
+)
+
+// retryableError is an export error worth retrying
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// Destination exports the logs it receives to an OTLP/HTTP receiver, using
+// the JSON encoding. It is meant to be used as an unreliable destination in
+// addition to the Datadog intake.
+type Destination struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	backoff backoff.Policy
+}
+
+// NewDestination returns a new OTLP destination.
+func NewDestination(config *Config, cfg pkgconfigmodel.Reader) (*Destination, error) {
+	url, err := config.logsURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return newDestination(url, config.Headers, &http.Client{
+		Timeout: config.Timeout,
+		// reusing core agent HTTP transport to benefit from proxy settings.
+		Transport: httputils.CreateHTTPTransport(cfg),
+	}), nil
+}
+
+func newDestination(url string, headers map[string]string, client *http.Client) *Destination {
+	metrics.DestinationLogsDropped.Set(url, &expvar.Int{})
+	return &Destination{
+		url:     url,
+		headers: headers,
+		client:  client,
+		backoff: backoff.NewExpBackoffPolicy(backoffFactor, backoffBase, backoffMax, 0, false),
+	}
+}
+
+// IsMRF returns false, OTLP destinations are never used for Multi-Region Failover.
+func (d *Destination) IsMRF() bool {
+	return false
+}
+
+// Target returns the URL of the logs endpoint of the receiver.
+func (d *Destination) Target() string {
+	return d.url
+}
+
+// Metadata is not supported for OTLP destinations
+func (d *Destination) Metadata() *client.DestinationMetadata {
+	return client.NewNoopDestinationMetadata()
+}
+
+// Start exports the payloads of the input until it is closed.
+func (d *Destination) Start(input chan *message.Payload, output chan *message.Payload, _ chan bool) (stopChan <-chan struct{}) {
+	stop := make(chan struct{})
+	go func() {
+		for payload := range input {
+			d.exportAndRetry(payload)
+			output <- payload
+		}
+		stop <- struct{}{}
+	}()
+	return stop
+}
+
+// exportAndRetry exports a payload, retrying with a backoff on transient
+// errors, and drops it after maxAttempts.
+func (d *Destination) exportAndRetry(payload *message.Payload) {
+	body, err := d.encode(payload)
+	if err != nil {
+		log.Warnf("Could not convert payload to OTLP: %v", err)
+		d.drop(payload.Count())
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := d.export(body)
+		if err == nil {
+			return
+		}
+
+		if _, retryable := err.(*retryableError); !retryable || attempt >= maxAttempts {
+			log.Warnf("Could not export payload to %s, dropping it: %v", d.url, err)
+			d.drop(payload.Count())
+			return
+		}
+		log.Debugf("Could not export payload to %s, retrying: %v", d.url, err)
+		time.Sleep(d.backoff.GetBackoffDuration(attempt))
+	}
+}
+
+// encode converts a payload into a gzip compressed OTLP export request.
+func (d *Destination) encode(payload *message.Payload) ([]byte, error) {
+	content, err := client.DecodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(toRequest(content, time.Now())); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *Destination) export(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	for key, value := range d.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return &retryableError{err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	// see https://opentelemetry.io/docs/specs/otlp/#retryable-response-codes
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusGatewayTimeout:
+		return &retryableError{fmt.Errorf("unexpected status code %d", resp.StatusCode)}
+	default:
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+}
+
+func (d *Destination) drop(count int64) {
+	metrics.DestinationLogsDropped.Add(d.url, count)
+	metrics.TlmLogsDropped.Add(float64(count), d.url)
+}
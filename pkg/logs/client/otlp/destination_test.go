@@ -0,0 +1,117 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package otlp
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/util/backoff"
+)
+
+func TestLogsURL(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"http://collector:4318":            "http://collector:4318/v1/logs",
+		"http://collector:4318/":           "http://collector:4318/v1/logs",
+		"https://otlp.example.com/v1/logs": "https://otlp.example.com/v1/logs",
+		"https://example.com/otlp":         "https://example.com/otlp/v1/logs",
+	} {
+		url, err := (&Config{Endpoint: endpoint}).logsURL()
+		require.NoError(t, err)
+		assert.Equal(t, expected, url)
+	}
+
+	for _, endpoint := range []string{"", "collector:4317", "grpc://collector:4317"} {
+		_, err := (&Config{Endpoint: endpoint}).logsURL()
+		assert.Error(t, err, endpoint)
+	}
+}
+
+// noBackoff retries immediately
+type noBackoff struct{ backoff.Policy }
+
+func (noBackoff) GetBackoffDuration(int) time.Duration { return 0 }
+
+// send sends a payload to a destination and stops it.
+func send(destination *Destination, payload *message.Payload) {
+	input := make(chan *message.Payload, 1)
+	output := make(chan *message.Payload, 1)
+	stop := destination.Start(input, output, nil)
+	input <- payload
+	<-output
+	close(input)
+	<-stop
+}
+
+func newPayload(content string) *message.Payload {
+	return message.NewPayload([]*message.MessageMetadata{{}}, []byte(content), "identity", len(content))
+}
+
+func TestDestinationExport(t *testing.T) {
+	var request exportLogsServiceRequest
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		reader, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(reader).Decode(&request))
+	}))
+	defer server.Close()
+
+	destination := newDestination(server.URL+logsPath, map[string]string{"Authorization": "Bearer token"}, server.Client())
+	send(destination, newPayload(`[{"message":"hello","status":"info","hostname":"host","service":"web"}]`))
+
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+	require.Len(t, request.ResourceLogs, 1)
+	require.Len(t, request.ResourceLogs[0].ScopeLogs[0].LogRecords, 1)
+	assert.Equal(t, "hello", request.ResourceLogs[0].ScopeLogs[0].LogRecords[0].Body.StringValue)
+}
+
+func TestDestinationRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	destination := newDestination(server.URL+logsPath, nil, server.Client())
+	destination.backoff = noBackoff{}
+	send(destination, newPayload(`[{"message":"hello"}]`))
+
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestDestinationDrops(t *testing.T) {
+	for status, expectedAttempts := range map[int]int32{
+		http.StatusBadRequest:         1,
+		http.StatusServiceUnavailable: maxAttempts,
+	} {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(status)
+		}))
+
+		destination := newDestination(server.URL+logsPath, nil, server.Client())
+		destination.backoff = noBackoff{}
+		send(destination, newPayload(`[{"message":"hello"}]`))
+
+		assert.Equal(t, expectedAttempts, attempts.Load(), "status %d", status)
+		server.Close()
+	}
+}
@@ -0,0 +1,50 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+// DecodePayload returns the uncompressed content of a payload, for the
+// destinations that do not forward the encoded payloads as is.
+func DecodePayload(payload *message.Payload) ([]byte, error) {
+	switch payload.Encoding {
+	case "", "identity":
+		return payload.Encoded, nil
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(payload.Encoded))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	case "deflate":
+		reader, err := zlib.NewReader(bytes.NewReader(payload.Encoded))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	case "zstd":
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer decoder.Close()
+		return decoder.DecodeAll(payload.Encoded, nil)
+	default:
+		return nil, fmt.Errorf("unsupported payload encoding %q", payload.Encoding)
+	}
+}
@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package automultilinedetection contains auto multiline detection and aggregation logic.
+package automultilinedetection
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	status "github.com/DataDog/datadog-agent/pkg/logs/status/utils"
+)
+
+// LabelStats is an analytics heuristic that counts the messages labeled by
+// each heuristic of a source. The share of the messages that were labeled by
+// a heuristic, rather than by default, is the confidence of the detection.
+type LabelStats struct {
+	lock   sync.Mutex
+	total  int64
+	counts map[string]int64
+}
+
+// NewLabelStats returns a new LabelStats heuristic.
+func NewLabelStats(tailerInfo *status.InfoRegistry) *LabelStats {
+	ls := &LabelStats{
+		counts: make(map[string]int64),
+	}
+
+	tailerInfo.Register(ls)
+	return ls
+}
+
+// ProcessAndContinue counts the heuristic that labeled the message.
+func (l *LabelStats) ProcessAndContinue(context *messageContext) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.total++
+	l.counts[context.labelAssignedBy]++
+	return true
+}
+
+// Confidence returns the share of the messages labeled by a heuristic rather
+// than by default, between 0 and 1.
+func (l *LabelStats) Confidence() float64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.confidence()
+}
+
+func (l *LabelStats) confidence() float64 {
+	if l.total == 0 {
+		return 0
+	}
+	return float64(l.total-l.counts[defaultLabelSource]) / float64(l.total)
+}
+
+// Implements the InfoProvider interface
+// This data is exposed on the status page
+
+// InfoKey returns a string representing the key for the label stats.
+func (l *LabelStats) InfoKey() string {
+	return "Auto multiline detection confidence"
+}
+
+// Info returns the confidence of the detection and the share of the messages
+// labeled by each heuristic.
+func (l *LabelStats) Info() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.total == 0 {
+		return []string{}
+	}
+
+	sources := make([]string, 0, len(l.counts))
+	for source := range l.counts {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if l.counts[sources[i]] != l.counts[sources[j]] {
+			return l.counts[sources[i]] > l.counts[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+
+	data := []string{fmt.Sprintf("Confidence: %.1f%% of %d lines labeled by a detector", l.confidence()*100, l.total)}
+	for _, source := range sources {
+		data = append(data, fmt.Sprintf("%-20s %5.1f%%", source, float64(l.counts[source])*100/float64(l.total)))
+	}
+	return data
+}
@@ -0,0 +1,34 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package automultilinedetection contains auto multiline detection and aggregation logic.
+package automultilinedetection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	status "github.com/DataDog/datadog-agent/pkg/logs/status/utils"
+)
+
+func TestLabelStats(t *testing.T) {
+	tailerInfo := status.NewInfoRegistry()
+	labelStats := NewLabelStats(tailerInfo)
+	assert.Equal(t, 0.0, labelStats.Confidence())
+	assert.Empty(t, labelStats.Info())
+
+	for _, labelAssignedBy := range []string{"timestamp_detector", "timestamp_detector", "stack_trace_detector", defaultLabelSource} {
+		labelStats.ProcessAndContinue(&messageContext{labelAssignedBy: labelAssignedBy})
+	}
+
+	assert.Equal(t, 0.75, labelStats.Confidence())
+	assert.Equal(t, []string{
+		"Confidence: 75.0% of 4 lines labeled by a detector",
+		"timestamp_detector    50.0%",
+		"default               25.0%",
+		"stack_trace_detector  25.0%",
+	}, tailerInfo.Get("Auto multiline detection confidence").Info())
+}
@@ -142,6 +142,21 @@ func (p *PatternTable) DumpTable() []DiagnosticRow {
 	return debug
 }
 
+// mostFrequentStartGroup returns the most frequent pattern of the table that
+// starts a group, and the number of times it occurred.
+func (p *PatternTable) mostFrequentStartGroup() ([]tokens.Token, int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// the table is sorted by frequency
+	for _, r := range p.table {
+		if r.label == startGroup {
+			return r.tokens, r.count
+		}
+	}
+	return nil, 0
+}
+
 // ProcessAndContinue adds a pattern to the table and updates its label based on it's frequency.
 // This implements the Heuristic interface - so we should stop processing if the label was changed
 // due to pattern detection.
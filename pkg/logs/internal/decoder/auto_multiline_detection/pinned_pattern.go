@@ -0,0 +1,112 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package automultilinedetection contains auto multiline detection and aggregation logic.
+package automultilinedetection
+
+import (
+	"sync"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/internal/decoder/auto_multiline_detection/tokens"
+	status "github.com/DataDog/datadog-agent/pkg/logs/status/utils"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// pinMinCount is the number of times the most frequent pattern starting a
+	// group must have occurred before it is pinned.
+	pinMinCount = 100
+	// pinMinConfidence is the minimum share of the messages labeled by a
+	// heuristic before the most frequent pattern is pinned.
+	pinMinConfidence = 0.9
+)
+
+// PinnedPattern is a heuristic that pins the pattern starting the groups of a
+// source once it was learned with enough confidence. Once pinned, the messages
+// matching the pattern start a group and all the other messages are
+// aggregated, as with a user defined multi-line pattern.
+type PinnedPattern struct {
+	patternTable   *PatternTable
+	labelStats     *LabelStats
+	matchThreshold float64
+
+	// The pinned pattern can be queried by the agent status command.
+	lock   sync.Mutex
+	pinned []tokens.Token
+}
+
+// NewPinnedPattern returns a new PinnedPattern heuristic learning the pattern
+// from the given pattern table.
+func NewPinnedPattern(patternTable *PatternTable, labelStats *LabelStats, matchThreshold float64, tailerInfo *status.InfoRegistry) *PinnedPattern {
+	pp := &PinnedPattern{
+		patternTable:   patternTable,
+		labelStats:     labelStats,
+		matchThreshold: matchThreshold,
+	}
+
+	tailerInfo.Register(pp)
+	return pp
+}
+
+// ProcessAndContinue labels a message with the pinned pattern, if any.
+// This implements the Heuristic interface - so we should stop processing once the pattern is pinned by returning false.
+func (p *PinnedPattern) ProcessAndContinue(context *messageContext) bool {
+	if context.tokens == nil {
+		log.Error("Tokens are required to process the pinned pattern")
+		return true
+	}
+
+	pinned := p.getOrPin()
+	if pinned == nil {
+		return true
+	}
+
+	if isMatch(pinned, context.tokens, p.matchThreshold) {
+		context.label = startGroup
+	} else {
+		context.label = aggregate
+	}
+	context.labelAssignedBy = "pinned_pattern"
+	return false
+}
+
+// getOrPin returns the pinned pattern, pinning the most frequent pattern
+// starting a group if it was learned with enough confidence.
+func (p *PinnedPattern) getOrPin() []tokens.Token {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.pinned != nil {
+		return p.pinned
+	}
+
+	pattern, count := p.patternTable.mostFrequentStartGroup()
+	if pattern == nil || count < pinMinCount || p.labelStats.Confidence() < pinMinConfidence {
+		return nil
+	}
+
+	log.Debugf("Pinning the auto multi-line pattern %s", tokensToString(pattern))
+	p.pinned = pattern
+	return p.pinned
+}
+
+// Implements the InfoProvider interface
+// This data is exposed on the status page
+
+// InfoKey returns a string representing the key for the pinned pattern.
+func (p *PinnedPattern) InfoKey() string {
+	return "Auto multiline pinned pattern"
+}
+
+// Info returns the pinned pattern.
+func (p *PinnedPattern) Info() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.pinned == nil {
+		return []string{"Not pinned yet"}
+	}
+	return []string{tokensToString(p.pinned)}
+}
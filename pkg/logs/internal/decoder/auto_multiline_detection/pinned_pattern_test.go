@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package automultilinedetection contains auto multiline detection and aggregation logic.
+package automultilinedetection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	status "github.com/DataDog/datadog-agent/pkg/logs/status/utils"
+)
+
+func TestPinnedPattern(t *testing.T) {
+	tailerInfo := status.NewInfoRegistry()
+	patternTable := NewPatternTable(5, 0.75, tailerInfo)
+	labelStats := NewLabelStats(tailerInfo)
+	pinnedPattern := NewPinnedPattern(patternTable, labelStats, 0.75, tailerInfo)
+
+	learn := func(str string, label Label, labelAssignedBy string) {
+		context := makeContext(str, label)
+		context.labelAssignedBy = labelAssignedBy
+		patternTable.ProcessAndContinue(context)
+		labelStats.ProcessAndContinue(context)
+	}
+
+	// The pattern is not pinned until it occurred often enough
+	for i := 0; i < pinMinCount-1; i++ {
+		learn("2024-03-28 13:45:30 INFO hello", startGroup, "timestamp_detector")
+	}
+	context := makeContext("2024-03-28 13:45:30 INFO hello", aggregate)
+	assert.True(t, pinnedPattern.ProcessAndContinue(context))
+	assert.Equal(t, []string{"Not pinned yet"}, tailerInfo.Get("Auto multiline pinned pattern").Info())
+
+	learn("2024-03-28 13:45:30 INFO hello", startGroup, "timestamp_detector")
+
+	// Once pinned, the messages matching the pattern start a group and the others are aggregated
+	context = makeContext("2024-03-28 13:45:31 WARN world", aggregate)
+	assert.False(t, pinnedPattern.ProcessAndContinue(context))
+	assert.Equal(t, startGroup, context.label)
+	assert.Equal(t, "pinned_pattern", context.labelAssignedBy)
+
+	context = makeContext(`{"key": "value"}`, noAggregate)
+	assert.False(t, pinnedPattern.ProcessAndContinue(context))
+	assert.Equal(t, aggregate, context.label)
+
+	assert.Equal(t, []string{"DDDD-DD-DD DD:DD:DD CCCC CCCCC"}, tailerInfo.Get("Auto multiline pinned pattern").Info())
+}
+
+func TestPinnedPatternRequiresConfidence(t *testing.T) {
+	tailerInfo := status.NewInfoRegistry()
+	patternTable := NewPatternTable(5, 0.75, tailerInfo)
+	labelStats := NewLabelStats(tailerInfo)
+	pinnedPattern := NewPinnedPattern(patternTable, labelStats, 0.75, tailerInfo)
+
+	// Half of the messages are labeled by default, the pattern is not pinned
+	for i := 0; i < pinMinCount; i++ {
+		for _, context := range []*messageContext{makeContext("2024-03-28 13:45:30 INFO hello", startGroup), makeContext("foo bar", aggregate)} {
+			context.labelAssignedBy = defaultLabelSource
+			if context.label == startGroup {
+				context.labelAssignedBy = "timestamp_detector"
+			}
+			patternTable.ProcessAndContinue(context)
+			labelStats.ProcessAndContinue(context)
+		}
+	}
+
+	assert.True(t, pinnedPattern.ProcessAndContinue(makeContext("2024-03-28 13:45:30 INFO hello", aggregate)))
+}
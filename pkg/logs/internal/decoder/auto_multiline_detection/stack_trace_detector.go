@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package automultilinedetection contains auto multiline detection and aggregation logic.
+package automultilinedetection
+
+import "regexp"
+
+// stackTraceRegexps match the lines of the Java and Python stack traces that
+// continue the log message of the exception.
+var stackTraceRegexps = []*regexp.Regexp{
+	// Java
+	regexp.MustCompile(`^\s+at\s+[\w$.<>/]+\(`),
+	regexp.MustCompile(`^\s*(Caused by|Suppressed): `),
+	regexp.MustCompile(`^\s+\.\.\. \d+ (more|common frames omitted)`),
+	// Python
+	regexp.MustCompile(`^Traceback \(most recent call last\):`),
+	regexp.MustCompile(`^\s+File "[^"]+", line \d+`),
+	regexp.MustCompile(`^(During handling of the above exception, another exception occurred|The above exception was the direct cause of the following exception):`),
+}
+
+// StackTraceDetector is a heuristic to detect the lines of Java and Python
+// stack traces, which are aggregated with the preceding log message.
+type StackTraceDetector struct{}
+
+// NewStackTraceDetector returns a new stack trace detection heuristic.
+func NewStackTraceDetector() *StackTraceDetector {
+	return &StackTraceDetector{}
+}
+
+// ProcessAndContinue checks if a message is a line of a stack trace.
+// This implements the Heuristic interface - so we should stop processing if we detect a stack trace line by returning false.
+func (s *StackTraceDetector) ProcessAndContinue(context *messageContext) bool {
+	if context.labelAssignedBy != defaultLabelSource {
+		return true
+	}
+	for _, re := range stackTraceRegexps {
+		if re.Match(context.rawMessage) {
+			context.label = aggregate
+			context.labelAssignedBy = "stack_trace_detector"
+			return false
+		}
+	}
+	return true
+}
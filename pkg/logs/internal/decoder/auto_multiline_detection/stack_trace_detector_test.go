@@ -0,0 +1,62 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package automultilinedetection contains auto multiline detection and aggregation logic.
+package automultilinedetection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackTraceDetector(t *testing.T) {
+	stackTraceDetector := NewStackTraceDetector()
+	testCases := []struct {
+		rawMessage     string
+		expectedLabel  Label
+		expectedResult bool
+	}{
+		// Java
+		{"\tat com.example.Foo.bar(Foo.java:42)", aggregate, false},
+		{"    at org.example.Main$Inner.<init>(Main.java:12)", aggregate, false},
+		{"Caused by: java.lang.IllegalStateException: boom", aggregate, false},
+		{"\tSuppressed: java.io.IOException: closed", aggregate, false},
+		{"\t... 12 more", aggregate, false},
+		{"\t... 3 common frames omitted", aggregate, false},
+		// Python
+		{"Traceback (most recent call last):", aggregate, false},
+		{`  File "/app/main.py", line 10, in <module>`, aggregate, false},
+		{"During handling of the above exception, another exception occurred:", aggregate, false},
+		{"The above exception was the direct cause of the following exception:", aggregate, false},
+		// Not stack traces
+		{"2024-03-28 13:45:30 ERROR something went wrong", aggregate, true},
+		{"at the end of the day", aggregate, true},
+		{"File not found", aggregate, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rawMessage, func(t *testing.T) {
+			messageContext := &messageContext{
+				rawMessage:      []byte(tc.rawMessage),
+				label:           aggregate,
+				labelAssignedBy: defaultLabelSource,
+			}
+			assert.Equal(t, tc.expectedResult, stackTraceDetector.ProcessAndContinue(messageContext))
+			assert.Equal(t, tc.expectedLabel, messageContext.label)
+		})
+	}
+}
+
+func TestStackTraceDetectorDoesntOverrideAssignedLabel(t *testing.T) {
+	stackTraceDetector := NewStackTraceDetector()
+	messageContext := &messageContext{
+		rawMessage:      []byte("\tat com.example.Foo.bar(Foo.java:42)"),
+		label:           startGroup,
+		labelAssignedBy: "Not default!",
+	}
+	assert.Equal(t, true, stackTraceDetector.ProcessAndContinue(messageContext))
+	assert.Equal(t, startGroup, messageContext.label)
+}
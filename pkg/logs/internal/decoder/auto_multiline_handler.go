@@ -40,6 +40,28 @@ func NewAutoMultilineHandler(outputFn func(m *message.Message), maxContentSize i
 	heuristics = append(heuristics, automultilinedetection.NewTokenizer(tokenizerMaxInputBytes))
 	heuristics = append(heuristics, automultilinedetection.NewUserSamples(pkgconfigsetup.Datadog(), sourceSamples))
 
+	patternTableMaxSize := pkgconfigsetup.Datadog().GetInt("logs_config.auto_multi_line.pattern_table_max_size")
+	if sourceHasSettings && sourceSettings.PatternTableMaxSize != nil {
+		patternTableMaxSize = *sourceSettings.PatternTableMaxSize
+	}
+	patternTableMatchThreshold := pkgconfigsetup.Datadog().GetFloat64("logs_config.auto_multi_line.pattern_table_match_threshold")
+	if sourceHasSettings && sourceSettings.PatternTableMatchThreshold != nil {
+		patternTableMatchThreshold = *sourceSettings.PatternTableMatchThreshold
+	}
+	patternTable := automultilinedetection.NewPatternTable(
+		patternTableMaxSize,
+		patternTableMatchThreshold,
+		tailerInfo)
+	labelStats := automultilinedetection.NewLabelStats(tailerInfo)
+
+	pinLearnedPattern := pkgconfigsetup.Datadog().GetBool("logs_config.auto_multi_line.pin_learned_pattern")
+	if sourceHasSettings && sourceSettings.PinLearnedPattern != nil {
+		pinLearnedPattern = *sourceSettings.PinLearnedPattern
+	}
+	if pinLearnedPattern {
+		heuristics = append(heuristics, automultilinedetection.NewPinnedPattern(patternTable, labelStats, patternTableMatchThreshold, tailerInfo))
+	}
+
 	enableJSONAggregation := pkgconfigsetup.Datadog().GetBool("logs_config.auto_multi_line.enable_json_aggregation")
 	if sourceHasSettings && sourceSettings.EnableJSONAggregation != nil {
 		enableJSONAggregation = *sourceSettings.EnableJSONAggregation
@@ -53,6 +75,14 @@ func NewAutoMultilineHandler(outputFn func(m *message.Message), maxContentSize i
 		heuristics = append(heuristics, automultilinedetection.NewJSONDetector())
 	}
 
+	enableStackTraceDetection := pkgconfigsetup.Datadog().GetBool("logs_config.auto_multi_line.enable_stack_trace_detection")
+	if sourceHasSettings && sourceSettings.EnableStackTraceDetection != nil {
+		enableStackTraceDetection = *sourceSettings.EnableStackTraceDetection
+	}
+	if enableStackTraceDetection {
+		heuristics = append(heuristics, automultilinedetection.NewStackTraceDetector())
+	}
+
 	enableDatetimeDetection := pkgconfigsetup.Datadog().GetBool("logs_config.auto_multi_line.enable_datetime_detection")
 	if sourceHasSettings && sourceSettings.EnableDatetimeDetection != nil {
 		enableDatetimeDetection = *sourceSettings.EnableDatetimeDetection
@@ -65,19 +95,7 @@ func NewAutoMultilineHandler(outputFn func(m *message.Message), maxContentSize i
 		heuristics = append(heuristics, automultilinedetection.NewTimestampDetector(timestampDetectorMatchThreshold))
 	}
 
-	patternTableMaxSize := pkgconfigsetup.Datadog().GetInt("logs_config.auto_multi_line.pattern_table_max_size")
-	if sourceHasSettings && sourceSettings.PatternTableMaxSize != nil {
-		patternTableMaxSize = *sourceSettings.PatternTableMaxSize
-	}
-	patternTableMatchThreshold := pkgconfigsetup.Datadog().GetFloat64("logs_config.auto_multi_line.pattern_table_match_threshold")
-	if sourceHasSettings && sourceSettings.PatternTableMatchThreshold != nil {
-		patternTableMatchThreshold = *sourceSettings.PatternTableMatchThreshold
-	}
-	analyticsHeuristics := []automultilinedetection.Heuristic{automultilinedetection.NewPatternTable(
-		patternTableMaxSize,
-		patternTableMatchThreshold,
-		tailerInfo),
-	}
+	analyticsHeuristics := []automultilinedetection.Heuristic{patternTable, labelStats}
 
 	return &AutoMultilineHandler{
 		labeler: automultilinedetection.NewLabeler(heuristics, analyticsHeuristics),
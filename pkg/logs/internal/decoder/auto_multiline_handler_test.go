@@ -203,3 +203,26 @@ func TestAutoMultilineHandler_JSONAggregationDisabled(t *testing.T) {
 	default:
 	}
 }
+
+func TestAutoMultilineHandler_PythonStackTrace(t *testing.T) {
+	outputChan := make(chan *message.Message, 10)
+	outputFn := func(m *message.Message) {
+		outputChan <- m
+	}
+
+	tailerInfo := status.NewInfoRegistry()
+	handler := NewAutoMultilineHandler(outputFn, 1000, 10*time.Second, tailerInfo, nil, nil)
+
+	handler.process(newTestMessage(`2025-04-10 12:00:00 ERROR request failed`))
+	handler.process(newTestMessage(`Traceback (most recent call last):`))
+	handler.process(newTestMessage(`  File "/app/main.py", line 10, in <module>`))
+	handler.process(newTestMessage(`ValueError: boom`))
+	handler.process(newTestMessage(`2025-04-10 12:00:01 INFO single line log`))
+
+	msg := <-outputChan
+	assert.Equal(t, []byte(`2025-04-10 12:00:00 ERROR request failed\nTraceback (most recent call last):\n  File "/app/main.py", line 10, in <module>\nValueError: boom`), msg.GetContent())
+
+	// The confidence of the detection is reported in the status
+	assert.NotNil(t, tailerInfo.Get("Auto multiline detection confidence"))
+	assert.Nil(t, tailerInfo.Get("Auto multiline pinned pattern"))
+}
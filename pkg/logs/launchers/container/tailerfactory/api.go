@@ -51,6 +51,7 @@ func (tf *factory) makeAPITailer(source *sources.LogSource) (Tailer, error) {
 	readTimeout := pkgconfigsetup.Datadog().GetDuration("logs_config.kubelet_api_client_read_timeout")
 
 	source.Config.Source, source.Config.Service = tf.defaultSourceAndService(source, containersorpods.LogPods)
+	source.Config.ProcessingRules = withPodProcessingRules(source.Config.ProcessingRules, pod, container.Name)
 
 	return tailers.NewAPITailer(
 		ku,
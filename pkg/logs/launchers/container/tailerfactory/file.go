@@ -242,7 +242,7 @@ func (tf *factory) makeK8sFileSource(source *sources.LogSource) (*sources.LogSou
 			Service:                     serviceName,
 			Source:                      sourceName,
 			Tags:                        source.Config.Tags,
-			ProcessingRules:             source.Config.ProcessingRules,
+			ProcessingRules:             withPodProcessingRules(source.Config.ProcessingRules, pod, container.Name),
 			FingerprintConfig:           source.Config.FingerprintConfig,
 			AutoMultiLine:               source.Config.AutoMultiLine,
 			AutoMultiLineSampleSize:     source.Config.AutoMultiLineSampleSize,
@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubelet || docker
+
+package tailerfactory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// podProcessingRulesAnnotation holds the processing rules applied to the
+	// logs of all the containers of a pod.
+	podProcessingRulesAnnotation = "ad.datadoghq.com/logs_processing_rules"
+
+	// containerProcessingRulesAnnotationFormat holds the processing rules
+	// applied to the logs of one container of a pod.
+	containerProcessingRulesAnnotationFormat = "ad.datadoghq.com/%s.logs_processing_rules"
+)
+
+// withPodProcessingRules returns the processing rules of a source followed by
+// the processing rules declared in the annotations of the pod of the
+// container, first for all the containers of the pod, then for the container
+// itself.  Invalid annotations are logged and ignored.
+func withPodProcessingRules(rules []*config.ProcessingRule, pod *workloadmeta.KubernetesPod, containerName string) []*config.ProcessingRule {
+	annotations := []string{
+		podProcessingRulesAnnotation,
+		fmt.Sprintf(containerProcessingRulesAnnotationFormat, containerName),
+	}
+
+	for _, annotation := range annotations {
+		value, found := pod.Annotations[annotation]
+		if !found {
+			continue
+		}
+
+		podRules, err := parseProcessingRules(value)
+		if err != nil {
+			log.Warnf("Ignoring the annotation %s of pod %s/%s: %v", annotation, pod.Namespace, pod.Name, err)
+			continue
+		}
+
+		// never modify the rules of the source, which can be shared
+		rules = append(slices.Clip(rules), podRules...)
+	}
+
+	return rules
+}
+
+// parseProcessingRules parses, validates and compiles the JSON array of
+// processing rules of an annotation.
+func parseProcessingRules(value string) ([]*config.ProcessingRule, error) {
+	var rules []*config.ProcessingRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of processing rules: %w", err)
+	}
+	if slices.Contains(rules, nil) {
+		return nil, errors.New("processing rules cannot be null")
+	}
+	if err := config.ValidateProcessingRules(rules); err != nil {
+		return nil, err
+	}
+	if err := config.CompileProcessingRules(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
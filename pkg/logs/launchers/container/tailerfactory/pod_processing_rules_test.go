@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubelet || docker
+
+package tailerfactory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+)
+
+func TestWithPodProcessingRules(t *testing.T) {
+	sourceRule := &config.ProcessingRule{Type: config.ExcludeAtMatch, Name: "source", Pattern: "debug"}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedNames []string
+	}{
+		{
+			name:          "no annotation",
+			expectedNames: []string{"source"},
+		},
+		{
+			name: "pod and container annotations",
+			annotations: map[string]string{
+				"ad.datadoghq.com/logs_processing_rules":       `[{"type":"mask_sequences","name":"mask_tokens","pattern":"token=\\w+","replace_placeholder":"token=[masked]"}]`,
+				"ad.datadoghq.com/cname.logs_processing_rules": `[{"type":"multi_line","name":"new_log","pattern":"\\d{4}-\\d{2}-\\d{2}"}]`,
+				"ad.datadoghq.com/other.logs_processing_rules": `[{"type":"exclude_at_match","name":"other","pattern":"foo"}]`,
+			},
+			expectedNames: []string{"source", "mask_tokens", "new_log"},
+		},
+		{
+			name: "invalid annotations are ignored",
+			annotations: map[string]string{
+				"ad.datadoghq.com/logs_processing_rules":       `{"type":"exclude_at_match","name":"not_an_array","pattern":"foo"}`,
+				"ad.datadoghq.com/cname.logs_processing_rules": `[{"type":"exclude_at_match","name":"invalid_pattern","pattern":"("}]`,
+			},
+			expectedNames: []string{"source"},
+		},
+		{
+			name: "null rules are ignored",
+			annotations: map[string]string{
+				"ad.datadoghq.com/logs_processing_rules": `[null]`,
+			},
+			expectedNames: []string{"source"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &workloadmeta.KubernetesPod{
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:        "podname",
+					Namespace:   "podns",
+					Annotations: test.annotations,
+				},
+			}
+			sourceRules := []*config.ProcessingRule{sourceRule}
+
+			rules := withPodProcessingRules(sourceRules, pod, "cname")
+
+			names := make([]string, 0, len(rules))
+			for _, rule := range rules {
+				names = append(names, rule.Name)
+				if rule.Name != "source" {
+					require.NotNil(t, rule.Regex, "rule %s should be compiled", rule.Name)
+				}
+			}
+			assert.Equal(t, test.expectedNames, names)
+			// the rules of the source are left untouched
+			assert.Equal(t, []*config.ProcessingRule{sourceRule}, sourceRules)
+		})
+	}
+}
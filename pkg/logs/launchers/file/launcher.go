@@ -419,6 +419,8 @@ func (s *Launcher) startNewTailer(file *tailer.File, m config.TailingMode, finge
 	if err != nil {
 		log.Warnf("Could not recover offset for file with path %v: %v", file.Path, err)
 	}
+	// fingerprint the registry entries written before the file was fingerprinted
+	s.registry.MigrateFingerprint(tailer.Identifier(), fingerprint)
 
 	log.Infof("Starting a new tailer for: %s (offset: %d, whence: %d) for tailer key %s", file.Path, offset, whence, file.GetScanKey())
 	err = tailer.Start(offset, whence)
@@ -7,6 +7,7 @@ package file
 
 import (
 	"io"
+	"os"
 	"strconv"
 
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
@@ -60,7 +61,17 @@ func Position(registry auditor.Registry, identifier string, mode config.TailingM
 			} else if mode == config.Beginning {
 				whence = io.SeekStart
 			}
+		} else if size, ok := fileSize(filePath); ok && offset > size {
+			// the file is smaller than the registered offset: it was truncated, or
+			// replaced by a file reusing its inode, so it is read from the beginning
+			log.Infof("File %s is smaller than its registered offset %d, reading it from the beginning", filePath, offset)
+			offset = 0
 		}
+	case value != "":
+		// an offset was registered for a file with a different content: the file
+		// was rotated while it was not tailed, so the new file is read entirely
+		log.Infof("The fingerprint of file %s changed since its offset was registered, reading it from the beginning", filePath)
+		offset, whence = 0, io.SeekStart
 	case mode == config.Beginning:
 		offset, whence = 0, io.SeekStart
 	case mode == config.End:
@@ -70,3 +81,15 @@ func Position(registry auditor.Registry, identifier string, mode config.TailingM
 	}
 	return offset, whence, err
 }
+
+// fileSize returns the size of a file, and whether it could be read.
+func fileSize(path string) (int64, bool) {
+	if path == "" {
+		return 0, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false
+	}
+	return info.Size(), true
+}
@@ -7,9 +7,12 @@ package file
 
 import (
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
 	auditorMock "github.com/DataDog/datadog-agent/comp/logs/auditor/mock"
@@ -89,3 +92,58 @@ func TestPosition(t *testing.T) {
 	assert.Equal(t, int64(0), offset)
 	assert.Equal(t, io.SeekEnd, whence)
 }
+
+func TestPositionFileSmallerThanOffset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.log")
+	require.NoError(t, os.WriteFile(path, []byte("hello world\n"), 0o644))
+	identifier := "file:" + path
+	fingerprinter := file.NewFingerprinter(types.FingerprintConfig{FingerprintStrategy: types.FingerprintStrategyDisabled})
+
+	registry := auditorMock.NewMockRegistry()
+	registry.SetOffset(identifier, "6")
+	offset, whence, err := Position(registry, identifier, config.End, *fingerprinter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), offset)
+	assert.Equal(t, io.SeekStart, whence)
+
+	// the file was truncated or replaced by a file reusing its inode
+	registry.SetOffset(identifier, "1000")
+	offset, whence, err = Position(registry, identifier, config.End, *fingerprinter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, io.SeekStart, whence)
+}
+
+func TestPositionFingerprintChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.log")
+	require.NoError(t, os.WriteFile(path, []byte("first line\nsecond line\n"), 0o644))
+	identifier := "file:" + path
+	fingerprintConfig := &types.FingerprintConfig{
+		FingerprintStrategy: types.FingerprintStrategyLineChecksum,
+		Count:               1,
+		MaxBytes:            2048,
+	}
+	fingerprinter := file.NewFingerprinter(*fingerprintConfig)
+	fingerprint, err := fingerprinter.ComputeFingerprintFromConfig(path, fingerprintConfig)
+	require.NoError(t, err)
+
+	registry := auditorMock.NewMockRegistry()
+	registry.SetOffset(identifier, "11")
+	registry.SetFingerprint(fingerprint)
+	offset, whence, err := Position(registry, identifier, config.End, *fingerprinter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), offset)
+	assert.Equal(t, io.SeekStart, whence)
+
+	// the file was rotated and a new file reused its path while it was not tailed
+	require.NoError(t, os.WriteFile(path, []byte("another file\n"), 0o644))
+	offset, whence, err = Position(registry, identifier, config.End, *fingerprinter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, io.SeekStart, whence)
+
+	offset, whence, err = Position(registry, identifier, config.ForceEnd, *fingerprinter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, io.SeekEnd, whence)
+}
@@ -0,0 +1,19 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !windows
+
+package listener
+
+import (
+	"github.com/DataDog/datadog-agent/pkg/logs/pipeline"
+	"github.com/DataDog/datadog-agent/pkg/logs/sources"
+	"github.com/DataDog/datadog-agent/pkg/util/startstop"
+)
+
+// named pipes are only supported on Windows
+func newPipeListener(_ pipeline.Provider, source *sources.LogSource, _ int) startstop.StartStoppable {
+	return &unsupportedListener{source: source}
+}
@@ -0,0 +1,164 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build windows
+
+package listener
+
+import (
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/Microsoft/go-winio"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/pipeline"
+	"github.com/DataDog/datadog-agent/pkg/logs/sources"
+	tailer "github.com/DataDog/datadog-agent/pkg/logs/tailers/socket"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/startstop"
+)
+
+// A PipeListener creates a Windows named pipe, accepts the connections of its
+// clients and delegates the read operations to a tailer per client. The
+// clients write logs separated by line feeds, as over TCP.
+type PipeListener struct {
+	pipelineProvider pipeline.Provider
+	source           *sources.LogSource
+	frameSize        int
+	listener         net.Listener
+	tailers          []*tailer.Tailer
+	mu               sync.Mutex
+	stop             chan struct{}
+}
+
+// NewPipeListener returns an initialized PipeListener
+func NewPipeListener(pipelineProvider pipeline.Provider, source *sources.LogSource, frameSize int) *PipeListener {
+	return &PipeListener{
+		pipelineProvider: pipelineProvider,
+		source:           source,
+		frameSize:        frameSize,
+		tailers:          []*tailer.Tailer{},
+		stop:             make(chan struct{}, 1),
+	}
+}
+
+func newPipeListener(pipelineProvider pipeline.Provider, source *sources.LogSource, frameSize int) startstop.StartStoppable {
+	return NewPipeListener(pipelineProvider, source, frameSize)
+}
+
+// Start creates the named pipe and starts accepting new clients.
+func (l *PipeListener) Start() {
+	log.Infof("Starting named pipe forwarder on %s, with read buffer size: %d", l.source.Config.Path, l.frameSize)
+	err := l.startListener()
+	if err != nil {
+		log.Errorf("Can't start named pipe forwarder on %s: %v", l.source.Config.Path, err)
+		l.source.Status.Error(err)
+		return
+	}
+	l.source.Status.Success()
+	go l.run()
+}
+
+// Stop stops the listener from accepting new clients and all the active tailers.
+func (l *PipeListener) Stop() {
+	log.Infof("Stopping named pipe forwarder on %s", l.source.Config.Path)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stop <- struct{}{}
+	if l.listener != nil {
+		l.listener.Close()
+	}
+	stopper := startstop.NewParallelStopper()
+	for _, tailer := range l.tailers {
+		stopper.Add(tailer)
+	}
+	stopper.Stop()
+	l.tailers = []*tailer.Tailer{}
+}
+
+// run accepts new clients and create a dedicated tailer for each.
+func (l *PipeListener) run() {
+	defer l.listener.Close()
+	for {
+		select {
+		case <-l.stop:
+			// stop accepting new clients.
+			return
+		default:
+			conn, err := l.listener.Accept()
+			switch {
+			case err != nil && (errors.Is(err, winio.ErrPipeListenerClosed) || isClosedConnError(err)):
+				return
+			case err != nil:
+				// an error occurred, recreate the named pipe.
+				log.Warnf("Can't accept clients on %s, recreating the named pipe: %v", l.source.Config.Path, err)
+				l.listener.Close()
+				err := l.startListener()
+				if err != nil {
+					log.Errorf("Can't recreate the named pipe %s: %v", l.source.Config.Path, err)
+					l.source.Status.Error(err)
+					return
+				}
+				l.source.Status.Success()
+				continue
+			default:
+				l.startTailer(conn)
+				l.source.Status.Success()
+			}
+		}
+	}
+}
+
+// startListener creates the named pipe, returns an error if it failed.
+func (l *PipeListener) startListener() error {
+	listener, err := winio.ListenPipe(l.source.Config.Path, &winio.PipeConfig{
+		InputBufferSize: int32(l.frameSize),
+	})
+	if err != nil {
+		return err
+	}
+	l.listener = listener
+	return nil
+}
+
+// read reads data from the client, returns an error if it failed and stop the tailer.
+func (l *PipeListener) read(tailer *tailer.Tailer) ([]byte, string, error) {
+	frame := make([]byte, l.frameSize)
+	n, err := tailer.Conn.Read(frame)
+	if err != nil {
+		// the client disconnecting is not an error of the source
+		if !errors.Is(err, io.EOF) {
+			l.source.Status.Error(err)
+		}
+		go l.stopTailer(tailer)
+		return nil, "", err
+	}
+	return frame[:n], "", nil
+}
+
+// startTailer creates and starts a new tailer that reads from the client.
+func (l *PipeListener) startTailer(conn net.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	tailer := tailer.NewTailer(l.source, conn, l.pipelineProvider.NextPipelineChan(), l.read)
+	l.tailers = append(l.tailers, tailer)
+	tailer.Start()
+}
+
+// stopTailer stops the tailer.
+func (l *PipeListener) stopTailer(tailer *tailer.Tailer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, t := range l.tailers {
+		if t == tailer {
+			tailer.Stop()
+			l.tailers = slices.Delete(l.tailers, i, i+1)
+			break
+		}
+	}
+}
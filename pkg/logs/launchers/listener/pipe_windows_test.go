@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build windows
+
+package listener
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Microsoft/go-winio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/logs/pipeline/mock"
+	"github.com/DataDog/datadog-agent/pkg/logs/sources"
+)
+
+func TestPipeShouldReceiveMessages(t *testing.T) {
+	path := `\\.\pipe\datadog-logs-test`
+	pp := mock.NewMockProvider()
+	msgChan := pp.NextPipelineChan()
+	source := sources.NewLogSource("", &config.LogsConfig{Type: config.PipeType, Path: path})
+	listener := NewPipeListener(pp, source, 9000)
+	listener.Start()
+	require.True(t, source.Status.IsSuccess())
+
+	conn, err := winio.DialPipe(path, nil)
+	require.NoError(t, err)
+
+	var msg *message.Message
+
+	fmt.Fprint(conn, "hello world\n")
+	msg = <-msgChan
+	assert.Equal(t, "hello world", string(msg.GetContent()))
+
+	fmt.Fprint(conn, "foo\nbar\n")
+	msg = <-msgChan
+	assert.Equal(t, "foo", string(msg.GetContent()))
+	msg = <-msgChan
+	assert.Equal(t, "bar", string(msg.GetContent()))
+
+	conn.Close()
+	listener.Stop()
+}
+
+func TestSocketShouldNotBeSupported(t *testing.T) {
+	source := sources.NewLogSource("", &config.LogsConfig{Type: config.SocketType, Path: `C:\logs.sock`})
+	listener := newSocketListener(mock.NewMockProvider(), source, 100)
+	listener.Start()
+	assert.True(t, source.Status.IsError())
+	listener.Stop()
+}
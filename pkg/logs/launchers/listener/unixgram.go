@@ -0,0 +1,135 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !windows
+
+package listener
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/pipeline"
+	"github.com/DataDog/datadog-agent/pkg/logs/sources"
+	tailer "github.com/DataDog/datadog-agent/pkg/logs/tailers/socket"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/util/startstop"
+)
+
+// A UnixgramListener binds a SOCK_DGRAM unix socket, and delegates the read
+// operations to a tailer. Each datagram is a log message, or several ones
+// separated by line feeds, truncated to the size of the read buffer as for
+// the UDPListener.
+type UnixgramListener struct {
+	pipelineProvider pipeline.Provider
+	source           *sources.LogSource
+	frameSize        int
+	tailer           *tailer.Tailer
+	conn             *net.UnixConn
+}
+
+// NewUnixgramListener returns an initialized UnixgramListener
+func NewUnixgramListener(pipelineProvider pipeline.Provider, source *sources.LogSource, frameSize int) *UnixgramListener {
+	return &UnixgramListener{
+		pipelineProvider: pipelineProvider,
+		source:           source,
+		frameSize:        frameSize,
+	}
+}
+
+func newSocketListener(pipelineProvider pipeline.Provider, source *sources.LogSource, frameSize int) startstop.StartStoppable {
+	return NewUnixgramListener(pipelineProvider, source, frameSize)
+}
+
+// Start binds the unix socket and starts a tailer.
+func (l *UnixgramListener) Start() {
+	log.Infof("Starting unix socket forwarder on %s, with read buffer size: %d", l.source.Config.Path, l.frameSize)
+	err := l.startNewTailer()
+	if err != nil {
+		log.Errorf("Can't start unix socket forwarder on %s: %v", l.source.Config.Path, err)
+		l.source.Status.Error(err)
+		return
+	}
+	l.source.Status.Success()
+}
+
+// Stop stops the tailer and removes the unix socket.
+func (l *UnixgramListener) Stop() {
+	if l.tailer != nil {
+		log.Infof("Stopping unix socket forwarder on %s", l.source.Config.Path)
+		l.tailer.Stop()
+		os.Remove(l.source.Config.Path) //nolint:errcheck
+	}
+}
+
+// startNewTailer starts a new Tailer
+func (l *UnixgramListener) startNewTailer() error {
+	conn, err := l.newUnixgramConnection()
+	if err != nil {
+		return err
+	}
+	l.tailer = tailer.NewTailer(l.source, conn, l.pipelineProvider.NextPipelineChan(), l.read)
+	l.tailer.Start()
+	return nil
+}
+
+// newUnixgramConnection binds the unix socket, replacing the one left by a
+// previous run if any, returns an error if the creation failed.
+func (l *UnixgramListener) newUnixgramConnection() (net.Conn, error) {
+	path := l.source.Config.Path
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode().Type() != os.ModeSocket {
+			return nil, fmt.Errorf("%s already exists and is not a unix socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	l.conn = conn
+	return conn, nil
+}
+
+// read reads a datagram from the tailer connection, returns an error if it failed and reset the tailer.
+func (l *UnixgramListener) read(_ *tailer.Tailer) ([]byte, string, error) {
+	frame := make([]byte, l.frameSize+1)
+	n, _, err := l.conn.ReadFromUnix(frame)
+	switch {
+	case err != nil && isClosedConnError(err):
+		return nil, "", err
+	case err != nil:
+		go l.resetTailer()
+		return nil, "", err
+	default:
+		// make sure all logs are separated by line feeds, otherwise they don't get properly split downstream
+		if n > l.frameSize {
+			// the message is bigger than the length of the read buffer,
+			// the trailing part of the content will be dropped.
+			frame[l.frameSize] = '\n'
+		} else if n > 0 && frame[n-1] != '\n' {
+			frame[n] = '\n'
+			n++
+		}
+		// the senders are usually unbound, there is no address to tag the logs with
+		return frame[:n], "", nil
+	}
+}
+
+// resetTailer creates a new tailer.
+func (l *UnixgramListener) resetTailer() {
+	log.Infof("Resetting the unix socket %s", l.source.Config.Path)
+	l.tailer.Stop()
+	err := l.startNewTailer()
+	if err != nil {
+		log.Errorf("Could not reset the unix socket %s: %v", l.source.Config.Path, err)
+		l.source.Status.Error(err)
+		return
+	}
+	l.source.Status.Success()
+}
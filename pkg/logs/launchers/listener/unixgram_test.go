@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !windows
+
+package listener
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/logs/pipeline/mock"
+	"github.com/DataDog/datadog-agent/pkg/logs/sources"
+)
+
+func TestUnixgramShouldReceiveMessages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.sock")
+	pp := mock.NewMockProvider()
+	msgChan := pp.NextPipelineChan()
+	frameSize := 100
+	source := sources.NewLogSource("", &config.LogsConfig{Type: config.SocketType, Path: path})
+	listener := NewUnixgramListener(pp, source, frameSize)
+	listener.Start()
+	require.True(t, source.Status.IsSuccess())
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var msg *message.Message
+
+	fmt.Fprint(conn, "hello world")
+	msg = <-msgChan
+	assert.Equal(t, "hello world", string(msg.GetContent()))
+
+	fmt.Fprint(conn, "foo\nbar\n")
+	msg = <-msgChan
+	assert.Equal(t, "foo", string(msg.GetContent()))
+	msg = <-msgChan
+	assert.Equal(t, "bar", string(msg.GetContent()))
+
+	// the datagrams bigger than the read buffer are truncated
+	fmt.Fprint(conn, strings.Repeat("a", frameSize+10))
+	msg = <-msgChan
+	assert.Equal(t, strings.Repeat("a", frameSize), string(msg.GetContent()))
+
+	listener.Stop()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "the socket should be removed")
+}
+
+func TestUnixgramShouldReplaceStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.sock")
+	pp := mock.NewMockProvider()
+	msgChan := pp.NextPipelineChan()
+
+	// a socket left by a previous run
+	stale, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	stale.Close()
+
+	source := sources.NewLogSource("", &config.LogsConfig{Type: config.SocketType, Path: path})
+	listener := NewUnixgramListener(pp, source, 100)
+	listener.Start()
+	require.True(t, source.Status.IsSuccess())
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	fmt.Fprint(conn, "hello world\n")
+	msg := <-msgChan
+	assert.Equal(t, "hello world", string(msg.GetContent()))
+
+	listener.Stop()
+}
+
+func TestUnixgramShouldNotReplaceRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.sock")
+	require.NoError(t, os.WriteFile(path, []byte("foo"), 0o644))
+
+	source := sources.NewLogSource("", &config.LogsConfig{Type: config.SocketType, Path: path})
+	listener := NewUnixgramListener(mock.NewMockProvider(), source, 100)
+	listener.Start()
+	assert.True(t, source.Status.IsError())
+	listener.Stop()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(content))
+}
+
+func TestPipeShouldNotBeSupported(t *testing.T) {
+	source := sources.NewLogSource("", &config.LogsConfig{Type: config.PipeType, Path: `\\.\pipe\logs`})
+	listener := newPipeListener(mock.NewMockProvider(), source, 100)
+	listener.Start()
+	assert.True(t, source.Status.IsError())
+	listener.Stop()
+}
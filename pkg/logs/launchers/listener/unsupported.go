@@ -0,0 +1,29 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package listener
+
+import (
+	"fmt"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/sources"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// An unsupportedListener reports that the type of its source is not supported
+// on the current platform.
+type unsupportedListener struct {
+	source *sources.LogSource
+}
+
+// Start reports the error in the status of the source.
+func (l *unsupportedListener) Start() {
+	err := fmt.Errorf("%s sources are not supported on this platform", l.source.Config.Type)
+	log.Errorf("Can't start forwarder for %s: %v", l.source.Config.Path, err)
+	l.source.Status.Error(err)
+}
+
+// Stop does nothing.
+func (l *unsupportedListener) Stop() {}
@@ -9,6 +9,9 @@
 package windowsevent
 
 import (
+	"path"
+	"strings"
+
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 	winevtapi "github.com/DataDog/datadog-agent/pkg/util/winutil/eventlog/api/windows"
 
@@ -38,6 +41,7 @@ type Launcher struct {
 	tailers                map[string]tailer
 	stop                   chan struct{}
 	publisherMetadataCache publishermetadatacachedef.Component
+	enumerateChannels      func() ([]string, error)
 }
 
 // NewLauncher returns a new Launcher.
@@ -47,6 +51,7 @@ func NewLauncher() *Launcher {
 		tailers:                make(map[string]tailer),
 		stop:                   make(chan struct{}),
 		publisherMetadataCache: cache,
+		enumerateChannels:      EnumerateChannels,
 	}
 }
 
@@ -55,7 +60,7 @@ func (l *Launcher) Start(sourceProvider launchers.SourceProvider, pipelineProvid
 	l.pipelineProvider = pipelineProvider
 	l.sources = sourceProvider.GetAddedForType(config.WindowsEventType)
 	l.registry = registry
-	availableChannels, err := EnumerateChannels()
+	availableChannels, err := l.enumerateChannels()
 	if err != nil {
 		log.Debug("Could not list windows event log channels: ", err)
 	} else {
@@ -69,16 +74,18 @@ func (l *Launcher) run() {
 	for {
 		select {
 		case source := <-l.sources:
-			identifier := windowsevent.Identifier(source.Config.ChannelPath, source.Config.Query)
-			if _, exists := l.tailers[identifier]; exists {
-				// tailer already setup
-				continue
-			}
-			tailer, err := l.setupTailer(source)
-			if err != nil {
-				log.Info("Could not set up windows event log tailer: ", err)
-			} else {
-				l.tailers[identifier] = tailer
+			for _, config := range l.channelConfigs(source.Config) {
+				identifier := windowsevent.Identifier(config.ChannelPath, config.Query)
+				if _, exists := l.tailers[identifier]; exists {
+					// tailer already setup
+					continue
+				}
+				tailer, err := l.setupTailer(source, config)
+				if err != nil {
+					log.Info("Could not set up windows event log tailer: ", err)
+				} else {
+					l.tailers[identifier] = tailer
+				}
 			}
 		case <-l.stop:
 			return
@@ -104,6 +111,7 @@ func (l *Launcher) sanitizedConfig(sourceConfig *config.LogsConfig) *windowseven
 		ChannelPath:       sourceConfig.ChannelPath,
 		Query:             sourceConfig.Query,
 		ProcessRawMessage: sourceConfig.ShouldProcessRawMessage(),
+		RenderXML:         sourceConfig.RenderXML,
 	}
 	if config.Query == "" {
 		config.Query = "*"
@@ -111,14 +119,71 @@ func (l *Launcher) sanitizedConfig(sourceConfig *config.LogsConfig) *windowseven
 	return config
 }
 
-// setupTailer configures and starts a new tailer
-func (l *Launcher) setupTailer(source *sources.LogSource) (tailer, error) {
-	sanitizedConfig := l.sanitizedConfig(source.Config)
-	config := &windowsevent.Config{
-		ChannelPath:       sanitizedConfig.ChannelPath,
-		Query:             sanitizedConfig.Query,
-		ProcessRawMessage: sanitizedConfig.ProcessRawMessage,
+// channelConfigs returns the tailer configs of the channels of a source: the
+// channels matching its channel path when it contains wildcards, or its
+// channel path otherwise.
+func (l *Launcher) channelConfigs(sourceConfig *config.LogsConfig) []*windowsevent.Config {
+	channels := []string{sourceConfig.ChannelPath}
+	if isChannelPattern(sourceConfig.ChannelPath) {
+		availableChannels, err := l.enumerateChannels()
+		if err != nil {
+			log.Warnf("Could not list windows event log channels matching %s: %v", sourceConfig.ChannelPath, err)
+			return nil
+		}
+		channels = matchChannels(sourceConfig.ChannelPath, availableChannels)
+		if len(channels) == 0 {
+			log.Infof("No windows event log channel matches %s", sourceConfig.ChannelPath)
+		}
 	}
+
+	configs := make([]*windowsevent.Config, 0, len(channels))
+	for _, channel := range channels {
+		config := l.sanitizedConfig(sourceConfig)
+		config.ChannelPath = channel
+		if query, found := channelQuery(sourceConfig.ChannelQueries, channel); found {
+			config.Query = query
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// isChannelPattern returns whether a channel path contains wildcards.
+func isChannelPattern(channelPath string) bool {
+	return strings.ContainsAny(channelPath, "*?[")
+}
+
+// matchChannels returns the channels matching a channel path pattern, such as
+// Microsoft-Windows-Sysmon/*. Channel names are not case sensitive.
+func matchChannels(pattern string, channels []string) []string {
+	pattern = strings.ToLower(pattern)
+	var matches []string
+	for _, channel := range channels {
+		matched, err := path.Match(pattern, strings.ToLower(channel))
+		if err != nil {
+			log.Warnf("Invalid windows event log channel pattern %s: %v", pattern, err)
+			return nil
+		}
+		if matched {
+			matches = append(matches, channel)
+		}
+	}
+	return matches
+}
+
+// channelQuery returns the query of a channel in the per-channel queries of a
+// source. Channel names are not case sensitive.
+func channelQuery(channelQueries map[string]string, channel string) (string, bool) {
+	for name, query := range channelQueries {
+		if strings.EqualFold(name, channel) && query != "" {
+			return query, true
+		}
+	}
+	return "", false
+}
+
+// setupTailer configures and starts a new tailer
+func (l *Launcher) setupTailer(source *sources.LogSource, config *windowsevent.Config) (tailer, error) {
 	t := windowsevent.NewTailer(nil, source, config, l.pipelineProvider.NextPipelineChan(), l.registry, l.publisherMetadataCache)
 	bookmark := l.registry.GetOffset(t.Identifier())
 	t.Start(bookmark)
@@ -19,3 +19,40 @@ func TestShouldSanitizeConfig(t *testing.T) {
 	launcher := NewLauncher()
 	assert.Equal(t, "*", launcher.sanitizedConfig(&config.LogsConfig{ChannelPath: "System", Query: ""}).Query)
 }
+
+func TestChannelConfigs(t *testing.T) {
+	launcher := NewLauncher()
+	launcher.enumerateChannels = func() ([]string, error) {
+		return []string{
+			"Application",
+			"System",
+			"Microsoft-Windows-Sysmon/Operational",
+			"Microsoft-Windows-Sysmon/Debug",
+			"Microsoft-Windows-PowerShell/Operational",
+		}, nil
+	}
+
+	configs := launcher.channelConfigs(&config.LogsConfig{
+		ChannelPath: "microsoft-windows-sysmon/*",
+		ChannelQueries: map[string]string{
+			"Microsoft-Windows-Sysmon/Operational": "*[System[(EventID=1)]]",
+		},
+		RenderXML: true,
+	})
+	assert.Len(t, configs, 2)
+	assert.Equal(t, "Microsoft-Windows-Sysmon/Operational", configs[0].ChannelPath)
+	assert.Equal(t, "*[System[(EventID=1)]]", configs[0].Query)
+	assert.True(t, configs[0].RenderXML)
+	assert.Equal(t, "Microsoft-Windows-Sysmon/Debug", configs[1].ChannelPath)
+	assert.Equal(t, "*", configs[1].Query)
+
+	// a channel path without wildcards is used as is
+	configs = launcher.channelConfigs(&config.LogsConfig{ChannelPath: "Security", Query: "*[System[Level=2]]"})
+	assert.Len(t, configs, 1)
+	assert.Equal(t, "Security", configs[0].ChannelPath)
+	assert.Equal(t, "*[System[Level=2]]", configs[0].Query)
+	assert.False(t, configs[0].RenderXML)
+
+	// no channel matches the pattern
+	assert.Empty(t, launcher.channelConfigs(&config.LogsConfig{ChannelPath: "Foo/*"}))
+}
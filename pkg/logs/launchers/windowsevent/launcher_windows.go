@@ -8,7 +8,6 @@
 package windowsevent
 
 import (
-	"fmt"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -55,7 +54,6 @@ func EnumerateChannels() (chans []string, err error) {
 			break
 		}
 	}
-	fmt.Printf("returning error %v\n", err)
 	return
 }
 
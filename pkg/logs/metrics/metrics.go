@@ -94,6 +94,14 @@ var (
 	TlmDestNumWorkers = telemetry.NewGauge("logs_destination", "destination_workers", []string{"instance"}, "Gauge of the number of destination workers in use")
 	// TlmDestVirtualLatency is a moving average of the destination's latency.
 	TlmDestVirtualLatency = telemetry.NewGauge("logs_destination", "virtual_latency", []string{"instance"}, "Gauge of the destination's average latency")
+	// TlmPipelinesActive is the number of logs pipelines new sources are assigned to, when autoscaling.
+	TlmPipelinesActive = telemetry.NewGauge("logs", "pipelines_active", nil, "Gauge of the number of logs pipelines new sources are assigned to")
+	// TlmPipelinesSaturation is the average fill ratio of the input channels of the active logs pipelines, when autoscaling.
+	TlmPipelinesSaturation = telemetry.NewGauge("logs", "pipelines_saturation", nil, "Gauge of the average fill ratio of the input channels of the active logs pipelines")
+	// TlmIngestionRate is the number of logs processed per second, when autoscaling.
+	TlmIngestionRate = telemetry.NewGauge("logs", "ingestion_rate", nil, "Gauge of the number of logs processed per second")
+	// TlmPipelinesScalingDecisions counts the scaling decisions of the logs pipelines by direction and reason.
+	TlmPipelinesScalingDecisions = telemetry.NewCounter("logs", "pipelines_scaling_decisions", []string{"direction", "reason"}, "Count of the scaling decisions of the logs pipelines")
 	// TlmDestWorkerResets tracks the count of times the destination worker pool resets the worker count after encountering a retryable error.
 	TlmDestWorkerResets = telemetry.NewCounter("logs_destination", "destination_worker_resets", []string{"instance"}, "Count of times the destination worker pool resets the worker count")
 	// LogsTruncated is the number of logs truncated by the Agent
@@ -0,0 +1,165 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package pipeline
+
+import (
+	"runtime"
+	"time"
+
+	"go.uber.org/atomic"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+	"github.com/DataDog/datadog-agent/pkg/logs/metrics"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// autoscalerSampleInterval is the interval at which the saturation of the
+	// pipelines is sampled.
+	autoscalerSampleInterval = time.Second
+	// autoscalerSamplesPerEvaluation is the number of samples averaged before
+	// a scaling decision is taken.
+	autoscalerSamplesPerEvaluation = 10
+
+	// scaleUpSaturation is the average fill ratio of the input channels of the
+	// active pipelines above which a pipeline is added.
+	scaleUpSaturation = 0.8
+	// scaleDownSaturation is the average fill ratio of the input channels of
+	// the active pipelines below which a pipeline may be removed.
+	scaleDownSaturation = 0.2
+	// scaleDownRateRatio is the share of the target rate per pipeline the
+	// remaining pipelines must stay below for a pipeline to be removed.
+	scaleDownRateRatio = 0.5
+	// scaleDownEvaluations is the number of consecutive idle evaluations
+	// before a pipeline is removed, to avoid flapping.
+	scaleDownEvaluations = 3
+)
+
+// autoscalingMaxPipelines returns the maximum number of pipelines when the
+// autoscaling of the pipelines is enabled, bounded by the CPUs available to
+// the agent, or 0 when it is disabled.
+func autoscalingMaxPipelines(cfg pkgconfigmodel.Reader, numberOfPipelines int) int {
+	if !cfg.GetBool("logs_config.pipelines_autoscaling.enabled") {
+		return 0
+	}
+	maxPipelines := runtime.GOMAXPROCS(0)
+	if configured := cfg.GetInt("logs_config.pipelines_autoscaling.max_pipelines"); configured > 0 {
+		maxPipelines = min(configured, maxPipelines)
+	}
+	return max(maxPipelines, numberOfPipelines)
+}
+
+// autoscaler adjusts the number of pipelines new sources are assigned to,
+// between a minimum and a maximum, based on the saturation of the input
+// channels of the pipelines and on the ingestion rate. Sources keep the
+// pipeline they were assigned to, so that the order of their logs is kept.
+type autoscaler struct {
+	minPipelines  int
+	maxPipelines  int
+	targetRate    float64
+	inputs        []chan *message.Message
+	active        *atomic.Uint32
+	processed     func() int64
+	lastProcessed int64
+
+	saturationSum   float64
+	samples         int
+	idleEvaluations int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newAutoscaler(minPipelines int, targetRate float64, inputs []chan *message.Message, active *atomic.Uint32) *autoscaler {
+	return &autoscaler{
+		minPipelines: minPipelines,
+		maxPipelines: len(inputs),
+		targetRate:   targetRate,
+		inputs:       inputs,
+		active:       active,
+		processed:    metrics.LogsProcessed.Value,
+	}
+}
+
+func (a *autoscaler) start() {
+	a.stop = make(chan struct{})
+	a.done = make(chan struct{})
+	a.lastProcessed = a.processed()
+	metrics.TlmPipelinesActive.Set(float64(a.active.Load()))
+
+	go func() {
+		defer close(a.done)
+		ticker := time.NewTicker(autoscalerSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.sample()
+				if a.samples >= autoscalerSamplesPerEvaluation {
+					a.evaluate(autoscalerSampleInterval * time.Duration(a.samples))
+				}
+			case <-a.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (a *autoscaler) stopAndWait() {
+	close(a.stop)
+	<-a.done
+}
+
+// sample adds the average fill ratio of the input channels of the active
+// pipelines to the current evaluation.
+func (a *autoscaler) sample() {
+	active := int(a.active.Load())
+	var saturation float64
+	for _, input := range a.inputs[:active] {
+		if cap(input) > 0 {
+			saturation += float64(len(input)) / float64(cap(input))
+		}
+	}
+	a.saturationSum += saturation / float64(active)
+	a.samples++
+}
+
+// evaluate takes a scaling decision from the samples of the elapsed period.
+func (a *autoscaler) evaluate(elapsed time.Duration) {
+	active := int(a.active.Load())
+	saturation := a.saturationSum / float64(a.samples)
+	processed := a.processed()
+	rate := float64(processed-a.lastProcessed) / elapsed.Seconds()
+	a.saturationSum, a.samples, a.lastProcessed = 0, 0, processed
+
+	metrics.TlmPipelinesSaturation.Set(saturation)
+	metrics.TlmIngestionRate.Set(rate)
+
+	switch {
+	case active < a.maxPipelines && saturation >= scaleUpSaturation:
+		a.scale(active+1, "up", "channel_saturation", saturation, rate)
+	case active < a.maxPipelines && a.targetRate > 0 && rate/float64(active) > a.targetRate:
+		a.scale(active+1, "up", "ingestion_rate", saturation, rate)
+	case active > a.minPipelines && saturation <= scaleDownSaturation &&
+		(a.targetRate <= 0 || rate/float64(active-1) < a.targetRate*scaleDownRateRatio):
+		a.idleEvaluations++
+		if a.idleEvaluations >= scaleDownEvaluations {
+			a.scale(active-1, "down", "idle", saturation, rate)
+		}
+	default:
+		a.idleEvaluations = 0
+	}
+}
+
+func (a *autoscaler) scale(pipelines int, direction string, reason string, saturation float64, rate float64) {
+	log.Infof("Scaling %s the logs pipelines from %d to %d (reason: %s, saturation: %.0f%%, ingestion rate: %.0f logs/s)",
+		direction, a.active.Load(), pipelines, reason, saturation*100, rate)
+	a.active.Store(uint32(pipelines))
+	a.idleEvaluations = 0
+	metrics.TlmPipelinesActive.Set(float64(pipelines))
+	metrics.TlmPipelinesScalingDecisions.Inc(direction, reason)
+}
@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package pipeline
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+func newTestAutoscaler(minPipelines int, maxPipelines int, targetRate float64) (*autoscaler, []chan *message.Message, *int64) {
+	inputs := make([]chan *message.Message, maxPipelines)
+	for i := range inputs {
+		inputs[i] = make(chan *message.Message, 10)
+	}
+	var processed int64
+	a := newAutoscaler(minPipelines, targetRate, inputs, atomic.NewUint32(uint32(minPipelines)))
+	a.processed = func() int64 { return processed }
+	return a, inputs, &processed
+}
+
+// fill fills the input channels up to the given number of messages
+func fill(inputs []chan *message.Message, count int) {
+	for _, input := range inputs {
+		for len(input) < count {
+			input <- &message.Message{}
+		}
+	}
+}
+
+func TestAutoscalerScalesUpOnSaturation(t *testing.T) {
+	a, inputs, _ := newTestAutoscaler(1, 3, 0)
+
+	fill(inputs[:1], 9)
+	a.sample()
+	a.evaluate(time.Second)
+	assert.Equal(t, uint32(2), a.active.Load())
+
+	// the saturation is averaged over the active pipelines
+	a.sample()
+	a.evaluate(time.Second)
+	assert.Equal(t, uint32(2), a.active.Load())
+
+	fill(inputs[:2], 9)
+	a.sample()
+	a.evaluate(time.Second)
+	assert.Equal(t, uint32(3), a.active.Load())
+
+	// bounded by the maximum number of pipelines
+	fill(inputs, 10)
+	a.sample()
+	a.evaluate(time.Second)
+	assert.Equal(t, uint32(3), a.active.Load())
+}
+
+func TestAutoscalerScalesUpOnIngestionRate(t *testing.T) {
+	a, _, processed := newTestAutoscaler(1, 3, 100)
+
+	*processed = 50
+	a.sample()
+	a.evaluate(time.Second)
+	assert.Equal(t, uint32(1), a.active.Load())
+
+	*processed += 150
+	a.sample()
+	a.evaluate(time.Second)
+	assert.Equal(t, uint32(2), a.active.Load())
+}
+
+func TestAutoscalerScalesDownWhenIdle(t *testing.T) {
+	a, _, processed := newTestAutoscaler(1, 3, 100)
+	a.active.Store(3)
+
+	// the remaining pipelines would be too busy
+	for range scaleDownEvaluations {
+		*processed += 200
+		a.sample()
+		a.evaluate(time.Second)
+	}
+	assert.Equal(t, uint32(3), a.active.Load())
+
+	for i := range scaleDownEvaluations {
+		a.sample()
+		a.evaluate(time.Second)
+		if i < scaleDownEvaluations-1 {
+			assert.Equal(t, uint32(3), a.active.Load(), "scaling down requires consecutive idle evaluations")
+		}
+	}
+	assert.Equal(t, uint32(2), a.active.Load())
+
+	for range 2 * scaleDownEvaluations {
+		a.sample()
+		a.evaluate(time.Second)
+	}
+	assert.Equal(t, uint32(1), a.active.Load(), "bounded by the minimum number of pipelines")
+}
+
+func TestAutoscalingMaxPipelines(t *testing.T) {
+	cfg := configmock.New(t)
+	assert.Equal(t, 0, autoscalingMaxPipelines(cfg, 2))
+
+	cfg.SetWithoutSource("logs_config.pipelines_autoscaling.enabled", true)
+	assert.Equal(t, max(runtime.GOMAXPROCS(0), 2), autoscalingMaxPipelines(cfg, 2))
+
+	cfg.SetWithoutSource("logs_config.pipelines_autoscaling.max_pipelines", runtime.GOMAXPROCS(0)+10)
+	assert.Equal(t, max(runtime.GOMAXPROCS(0), 2), autoscalingMaxPipelines(cfg, 2), "bounded by the available CPUs")
+
+	cfg.SetWithoutSource("logs_config.pipelines_autoscaling.max_pipelines", 1)
+	assert.Equal(t, 2, autoscalingMaxPipelines(cfg, 2), "never below the configured number of pipelines")
+}
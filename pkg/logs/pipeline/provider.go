@@ -25,6 +25,7 @@ import (
 	httpsender "github.com/DataDog/datadog-agent/pkg/logs/sender/http"
 	tcpsender "github.com/DataDog/datadog-agent/pkg/logs/sender/tcp"
 	"github.com/DataDog/datadog-agent/pkg/logs/status/statusinterface"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
 	"github.com/DataDog/datadog-agent/pkg/util/startstop"
 )
 
@@ -36,7 +37,7 @@ const (
 	maxConcurrencyPerPipeline = 10
 
 	// componentName is the name used for destination telemetry
-	componentName = "logs"
+	componentName = sender.LogsComponentName
 )
 
 var httpSenderFactory = httpsender.NewHTTPSender
@@ -65,6 +66,12 @@ type provider struct {
 	currentPipelineIndex *atomic.Uint32
 	serverlessMeta       sender.ServerlessMeta
 
+	// when the pipelines are autoscaled, maxPipelines pipelines are started and
+	// new sources are assigned to the first activePipelines ones
+	maxPipelines    int
+	activePipelines *atomic.Uint32
+	autoscaler      *autoscaler
+
 	hostname    hostnameinterface.Component
 	cfg         pkgconfigmodel.Reader
 	compression logscompression.Component
@@ -88,13 +95,19 @@ func NewProvider(
 	var senderImpl sender.PipelineComponent
 	serverlessMeta := sender.NewServerlessMeta(serverless)
 
+	maxPipelines := numberOfPipelines
+	if !legacyMode && !serverless {
+		maxPipelines = max(autoscalingMaxPipelines(cfg, numberOfPipelines), numberOfPipelines)
+	}
+
+	// the senders are sized for the maximum number of pipelines
 	if endpoints.UseHTTP {
-		senderImpl = httpSender(numberOfPipelines, cfg, sink, endpoints, destinationsContext, serverlessMeta, legacyMode)
+		senderImpl = httpSender(maxPipelines, cfg, sink, endpoints, destinationsContext, serverlessMeta, legacyMode)
 	} else {
-		senderImpl = tcpSender(numberOfPipelines, cfg, sink, endpoints, destinationsContext, status, serverlessMeta, legacyMode)
+		senderImpl = tcpSender(maxPipelines, cfg, sink, endpoints, destinationsContext, status, serverlessMeta, legacyMode)
 	}
 
-	return newProvider(
+	p := newProvider(
 		numberOfPipelines,
 		diagnosticMessageReceiver,
 		processingRules,
@@ -105,6 +118,8 @@ func NewProvider(
 		serverlessMeta,
 		senderImpl,
 	)
+	p.maxPipelines = maxPipelines
+	return p
 }
 
 // NewMockProvider creates a new provider that will not provide any pipelines.
@@ -211,7 +226,7 @@ func newProvider(
 	compression logscompression.Component,
 	serverlessMeta sender.ServerlessMeta,
 	senderImpl sender.PipelineComponent,
-) Provider {
+) *provider {
 	return &provider{
 		numberOfPipelines:         numberOfPipelines,
 		diagnosticMessageReceiver: diagnosticMessageReceiver,
@@ -221,6 +236,8 @@ func newProvider(
 		pipelines:                 []*Pipeline{},
 		currentPipelineIndex:      atomic.NewUint32(0),
 		serverlessMeta:            serverlessMeta,
+		maxPipelines:              numberOfPipelines,
+		activePipelines:           atomic.NewUint32(uint32(numberOfPipelines)),
 		hostname:                  hostname,
 		cfg:                       cfg,
 		compression:               compression,
@@ -231,7 +248,7 @@ func newProvider(
 func (p *provider) Start() {
 	p.sender.Start()
 
-	for i := 0; i < p.numberOfPipelines; i++ {
+	for i := 0; i < p.maxPipelines; i++ {
 		pipeline := NewPipeline(
 			p.processingRules,
 			p.endpoints,
@@ -246,11 +263,27 @@ func (p *provider) Start() {
 		pipeline.Start()
 		p.pipelines = append(p.pipelines, pipeline)
 	}
+
+	if p.maxPipelines > p.numberOfPipelines {
+		inputs := make([]chan *message.Message, 0, len(p.pipelines))
+		for _, pipeline := range p.pipelines {
+			inputs = append(inputs, pipeline.InputChan)
+		}
+		log.Infof("Autoscaling the logs pipelines between %d and %d", p.numberOfPipelines, p.maxPipelines)
+		p.activePipelines.Store(uint32(p.numberOfPipelines))
+		p.autoscaler = newAutoscaler(p.numberOfPipelines, p.cfg.GetFloat64("logs_config.pipelines_autoscaling.target_rate_per_pipeline"), inputs, p.activePipelines)
+		p.autoscaler.start()
+	}
 }
 
 // Stop stops all pipelines in parallel,
 // this call blocks until all pipelines are stopped
 func (p *provider) Stop() {
+	if p.autoscaler != nil {
+		p.autoscaler.stopAndWait()
+		p.autoscaler = nil
+	}
+
 	stopper := startstop.NewParallelStopper()
 
 	// close the pipelines
@@ -265,7 +298,7 @@ func (p *provider) Stop() {
 
 // NextPipelineChan returns the next pipeline input channel
 func (p *provider) NextPipelineChan() chan *message.Message {
-	pipelinesLen := len(p.pipelines)
+	pipelinesLen := p.activePipelinesLen()
 	if pipelinesLen == 0 {
 		return nil
 	}
@@ -274,13 +307,21 @@ func (p *provider) NextPipelineChan() chan *message.Message {
 	return nextPipeline.InputChan
 }
 
+// activePipelinesLen returns the number of pipelines new sources are assigned to.
+func (p *provider) activePipelinesLen() int {
+	if p.activePipelines == nil {
+		return len(p.pipelines)
+	}
+	return min(len(p.pipelines), int(p.activePipelines.Load()))
+}
+
 func (p *provider) GetOutputChan() chan *message.Message {
 	return nil
 }
 
 // NextPipelineChanWithMonitor returns the next pipeline input channel with it's monitor.
 func (p *provider) NextPipelineChanWithMonitor() (chan *message.Message, *metrics.CapacityMonitor) {
-	pipelinesLen := len(p.pipelines)
+	pipelinesLen := p.activePipelinesLen()
 	if pipelinesLen == 0 {
 		return nil, nil
 	}
@@ -330,3 +330,50 @@ func TestPipelineChannelDistribution(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineChannelDistributionWithAutoscaling(t *testing.T) {
+	cfg := configmock.New(t)
+	cfg.SetWithoutSource("logs_config.pipelines_autoscaling.enabled", true)
+	cfg.SetWithoutSource("logs_config.pipelines_autoscaling.max_pipelines", 4)
+	endpoints := config.NewMockEndpointsWithOptions([]config.Endpoint{config.NewMockEndpoint()}, map[string]interface{}{
+		"use_http": true,
+	})
+
+	providerImpl := NewProvider(
+		1,
+		&sender.NoopSink{},
+		&diagnostic.BufferedMessageReceiver{},
+		nil, // processing rules
+		endpoints,
+		&client.DestinationsContext{},
+		statusinterface.NewStatusProviderMock(),
+		nil, // hostname
+		cfg,
+		compressionfx.NewMockCompressor(),
+		false, // legacy mode
+		false, // serverless
+	)
+	require.NotNil(t, providerImpl)
+	p := providerImpl.(*provider)
+
+	// the maximum number of pipelines is started, sources are assigned to the active ones
+	p.Start()
+	maxPipelines := autoscalingMaxPipelines(cfg, 1)
+	assert.Equal(t, maxPipelines, len(p.pipelines))
+	assert.Equal(t, maxPipelines > 1, p.autoscaler != nil)
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, p.pipelines[0].InputChan, p.NextPipelineChan())
+	}
+
+	p.activePipelines.Store(uint32(maxPipelines))
+	seenChannels := make(map[chan *message.Message]struct{})
+	for i := 0; i < maxPipelines*2; i++ {
+		seenChannels[p.NextPipelineChan()] = struct{}{}
+	}
+	assert.Equal(t, maxPipelines, len(seenChannels))
+
+	p.Stop()
+	assert.Nil(t, p.autoscaler)
+	assert.Empty(t, p.pipelines)
+}
@@ -276,6 +276,12 @@ func (p *Processor) applyRedactingRules(msg *message.Message) bool {
 				msg.RecordProcessingRule(rule.Type, rule.Name)
 				return false
 			}
+		case config.SensitiveDataScanner:
+			var matched bool
+			if content, matched = applySDSRule(rule, content); matched {
+				msg.RecordProcessingRule(rule.Type, rule.Name)
+				msg.ProcessingTags = append(msg.ProcessingTags, rule.SDS.Tags...)
+			}
 
 		}
 	}
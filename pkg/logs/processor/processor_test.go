@@ -450,3 +450,97 @@ func TestExcludeTruncated(t *testing.T) {
 	assert.False(shouldProcess2)
 	assert.Equal(int64(1), msg2.Origin.LogSource.ProcessingInfo.GetCount(ruleType+":"+ruleName))
 }
+
+func newSDSRule(action config.SDSMatchAction, keywords config.SDSKeywords) *config.ProcessingRule {
+	return &config.ProcessingRule{
+		Type:    config.SensitiveDataScanner,
+		Name:    "card",
+		Pattern: `\d{16}`,
+		Regex:   regexp.MustCompile(`\d{16}`),
+		SDS: &config.SDSRule{
+			Name:             "card",
+			Pattern:          `\d{16}`,
+			IncludedKeywords: keywords,
+			MatchAction:      action,
+			Tags:             []string{"sensitive:card"},
+		},
+	}
+}
+
+func TestSensitiveDataScanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *config.ProcessingRule
+		input   string
+		output  string
+		matched bool
+	}{
+		{
+			name:    "redact",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionRedact, Placeholder: "[card]"}, config.SDSKeywords{}),
+			input:   "card 4111111111111111 and 5500000000000004",
+			output:  "card [card] and [card]",
+			matched: true,
+		},
+		{
+			name:    "partial redact of the last characters",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionPartialRedact, Direction: config.SDSPartialRedactLastCharacters, CharacterCount: 12}, config.SDSKeywords{}),
+			input:   "card 4111111111111111",
+			output:  "card 4111************",
+			matched: true,
+		},
+		{
+			name:    "partial redact of the first characters",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionPartialRedact, Direction: config.SDSPartialRedactFirstCharacters, CharacterCount: 12}, config.SDSKeywords{}),
+			input:   "card 4111111111111111",
+			output:  "card ************1111",
+			matched: true,
+		},
+		{
+			name:    "hash",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionHash}, config.SDSKeywords{}),
+			input:   "card 4111111111111111",
+			output:  "card c500421738618c10",
+			matched: true,
+		},
+		{
+			name:    "none only tags the message",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionNone}, config.SDSKeywords{}),
+			input:   "card 4111111111111111",
+			output:  "card 4111111111111111",
+			matched: true,
+		},
+		{
+			name:    "keyword close to the match",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionRedact, Placeholder: "[card]"}, config.SDSKeywords{Keywords: []string{"card"}, CharacterCount: 10}),
+			input:   "Card: 4111111111111111, id: 5500000000000004",
+			output:  "Card: [card], id: 5500000000000004",
+			matched: true,
+		},
+		{
+			name:    "no keyword close to the match",
+			rule:    newSDSRule(config.SDSMatchAction{Type: config.SDSMatchActionRedact, Placeholder: "[card]"}, config.SDSKeywords{Keywords: []string{"card"}, CharacterCount: 10}),
+			input:   "id: 4111111111111111",
+			output:  "id: 4111111111111111",
+			matched: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Processor{processingRules: []*config.ProcessingRule{test.rule}}
+			source := sources.NewLogSource("", &config.LogsConfig{})
+			msg := newMessage([]byte(test.input), source, "")
+
+			assert.True(t, p.applyRedactingRules(msg))
+			assert.Equal(t, test.output, string(msg.GetContent()))
+			if test.matched {
+				assert.Equal(t, int64(1), source.ProcessingInfo.GetCount(config.SensitiveDataScanner+":card"))
+				assert.Equal(t, []string{"sensitive:card"}, msg.ProcessingTags)
+			} else {
+				assert.Equal(t, int64(0), source.ProcessingInfo.GetCount(config.SensitiveDataScanner+":card"))
+				assert.Empty(t, msg.ProcessingTags)
+			}
+		})
+	}
+}
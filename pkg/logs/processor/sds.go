@@ -0,0 +1,84 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package processor
+
+import (
+	"bytes"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+)
+
+// applySDSRule scans the content with a Sensitive Data Scanner rule. It returns
+// the content with the match action of the rule applied and whether the
+// content matched the rule.
+func applySDSRule(rule *config.ProcessingRule, content []byte) ([]byte, bool) {
+	if !isMatchingLiteralPrefix(rule.Regex, content) {
+		return content, false
+	}
+
+	var scanned []byte
+	matched := false
+	last := 0
+	for _, match := range rule.Regex.FindAllIndex(content, -1) {
+		start, end := match[0], match[1]
+		if start == end || !hasIncludedKeyword(rule.SDS.IncludedKeywords, content, start) {
+			continue
+		}
+		matched = true
+		if rule.SDS.MatchAction.Type == config.SDSMatchActionNone {
+			continue
+		}
+		scanned = append(scanned, content[last:start]...)
+		scanned = append(scanned, sdsReplacement(rule.SDS.MatchAction, content[start:end])...)
+		last = end
+	}
+
+	if scanned == nil {
+		return content, matched
+	}
+	return append(scanned, content[last:]...), true
+}
+
+// hasIncludedKeyword returns true if the rule has no included keywords or if
+// one of them is found in the characters preceding a match.
+func hasIncludedKeyword(keywords config.SDSKeywords, content []byte, start int) bool {
+	if len(keywords.Keywords) == 0 {
+		return true
+	}
+	window := bytes.ToLower(content[max(0, start-keywords.CharacterCount):start])
+	for _, keyword := range keywords.Keywords {
+		if bytes.Contains(window, []byte(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sdsReplacement returns the replacement of a match for a match action.
+func sdsReplacement(action config.SDSMatchAction, match []byte) []byte {
+	switch action.Type {
+	case config.SDSMatchActionRedact:
+		return []byte(action.Placeholder)
+	case config.SDSMatchActionHash:
+		h := fnv.New64a()
+		h.Write(match)
+		return strconv.AppendUint(nil, h.Sum64(), 16)
+	case config.SDSMatchActionPartialRedact:
+		runes := bytes.Runes(match)
+		count := min(action.CharacterCount, len(runes))
+		redacted := runes[len(runes)-count:]
+		if action.Direction == config.SDSPartialRedactFirstCharacters {
+			redacted = runes[:count]
+		}
+		for i := range redacted {
+			redacted[i] = '*'
+		}
+		return []byte(string(runes))
+	}
+	return match
+}
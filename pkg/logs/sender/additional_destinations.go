@@ -0,0 +1,64 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sender
+
+import (
+	"slices"
+	"sync"
+
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
+	"github.com/DataDog/datadog-agent/pkg/logs/client"
+	"github.com/DataDog/datadog-agent/pkg/logs/client/otlp"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// LogsComponentName is the name of the logs-agent pipelines, the only ones
+// whose payloads are sent to the additional destinations.
+const LogsComponentName = "logs"
+
+// AdditionalDestinationsFactory returns destinations a logs-agent sender worker
+// sends its payloads to in addition to the Datadog intake.
+type AdditionalDestinationsFactory func(cfg pkgconfigmodel.Reader, instanceID string) []client.Destination
+
+var (
+	additionalDestinationsMutex     sync.RWMutex
+	additionalDestinationsFactories []AdditionalDestinationsFactory
+)
+
+// RegisterAdditionalDestinations registers a factory of additional destinations
+// of the logs-agent sender workers. The logs agent registers the destinations
+// only it ships, like the archive, so that their dependencies are not pulled by
+// the other users of the senders.
+func RegisterAdditionalDestinations(factory AdditionalDestinationsFactory) {
+	additionalDestinationsMutex.Lock()
+	defer additionalDestinationsMutex.Unlock()
+	additionalDestinationsFactories = append(additionalDestinationsFactories, factory)
+}
+
+// AdditionalDestinations returns the destinations a logs-agent sender worker
+// sends its payloads to in addition to the Datadog intake: the destinations of
+// the registered factories and the OTLP receiver, when enabled.
+func AdditionalDestinations(cfg pkgconfigmodel.Reader, instanceID string) []client.Destination {
+	additionalDestinationsMutex.RLock()
+	factories := slices.Clone(additionalDestinationsFactories)
+	additionalDestinationsMutex.RUnlock()
+
+	var destinations []client.Destination
+	for _, factory := range factories {
+		destinations = append(destinations, factory(cfg, instanceID)...)
+	}
+
+	if otlpConfig, enabled := otlp.NewConfig(cfg); enabled {
+		destination, err := otlp.NewDestination(otlpConfig, cfg)
+		if err != nil {
+			log.Errorf("Logs OTLP export is disabled: %v", err)
+		} else {
+			destinations = append(destinations, destination)
+		}
+	}
+
+	return destinations
+}
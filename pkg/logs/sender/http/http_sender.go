@@ -100,6 +100,9 @@ func httpDestinationFactory(
 				additionals = append(additionals, http.NewDestination(endpoint, contentyType, destinationsContext, false, destMeta, cfg, minConcurrency, maxConcurrency, pipelineMonitor, instanceID))
 			}
 		}
+		if componentName == sender.LogsComponentName {
+			additionals = append(additionals, sender.AdditionalDestinations(cfg, instanceID)...)
+		}
 		return client.NewDestinations(reliable, additionals)
 	}
 }
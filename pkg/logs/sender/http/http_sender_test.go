@@ -12,8 +12,10 @@ import (
 
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
 	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
+	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
 	"github.com/DataDog/datadog-agent/pkg/logs/client"
 	"github.com/DataDog/datadog-agent/pkg/logs/client/http"
+	"github.com/DataDog/datadog-agent/pkg/logs/client/otlp"
 	"github.com/DataDog/datadog-agent/pkg/logs/metrics"
 	"github.com/DataDog/datadog-agent/pkg/logs/sender"
 )
@@ -147,3 +149,57 @@ func TestHttpDestinationFactory(t *testing.T) {
 		})
 	}
 }
+
+// fakeArchiveDestination stands for the archive destination registered by the
+// logs agent
+type fakeArchiveDestination struct {
+	client.Destination
+}
+
+func init() {
+	sender.RegisterAdditionalDestinations(func(cfg pkgconfigmodel.Reader, _ string) []client.Destination {
+		if !cfg.GetBool("logs_config.archive.enabled") {
+			return nil
+		}
+		return []client.Destination{&fakeArchiveDestination{}}
+	})
+}
+
+func TestHttpDestinationFactoryAdditionalDestinations(t *testing.T) {
+	endpoints := config.NewMockEndpoints([]config.Endpoint{
+		config.NewMockEndpointWithOptions(map[string]interface{}{
+			"host":        "localhost:8080",
+			"is_reliable": true,
+		}),
+	})
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("logs_config.archive.enabled", true)
+	mockConfig.SetWithoutSource("logs_config.otlp_export.enabled", true)
+	mockConfig.SetWithoutSource("logs_config.otlp_export.endpoint", "http://localhost:4318")
+
+	for _, componentName := range []string{sender.LogsComponentName, "test-component"} {
+		factory := httpDestinationFactory(
+			endpoints,
+			client.NewDestinationsContext(),
+			metrics.NewNoopPipelineMonitor("test"),
+			sender.NewMockServerlessMeta(false),
+			mockConfig,
+			componentName,
+			"application/json",
+			"",
+			1,
+			10,
+		)
+
+		destinations := factory("test")
+		assert.Len(t, destinations.Reliable, 1)
+		if componentName == sender.LogsComponentName {
+			if assert.Len(t, destinations.Unreliable, 2) {
+				assert.IsType(t, &fakeArchiveDestination{}, destinations.Unreliable[0])
+				assert.IsType(t, &otlp.Destination{}, destinations.Unreliable[1])
+			}
+		} else {
+			assert.Empty(t, destinations.Unreliable)
+		}
+	}
+}
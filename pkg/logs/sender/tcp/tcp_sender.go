@@ -33,7 +33,7 @@ func NewTCPSender(
 	log.Debugf("Creating a new sender for component %s with %d queues, %d tcp workers", componentName, queueCount, workersPerQueue)
 	pipelineMonitor := metrics.NewTelemetryPipelineMonitor()
 
-	destinationFactory := tcpDestinationFactory(endpoints, destinationsCtx, serverlessMeta, status)
+	destinationFactory := tcpDestinationFactory(config, endpoints, destinationsCtx, serverlessMeta, status)
 
 	return sender.NewSender(
 		config,
@@ -48,13 +48,14 @@ func NewTCPSender(
 }
 
 func tcpDestinationFactory(
+	cfg pkgconfigmodel.Reader,
 	endpoints *config.Endpoints,
 	destinationsContext *client.DestinationsContext,
 	serverlessMeta sender.ServerlessMeta,
 	status statusinterface.Status,
 ) sender.DestinationFactory {
 	isServerless := serverlessMeta != nil
-	return func(_ string) *client.Destinations {
+	return func(instanceID string) *client.Destinations {
 		reliable := []client.Destination{}
 		additionals := []client.Destination{}
 		for _, endpoint := range endpoints.GetReliableEndpoints() {
@@ -63,6 +64,7 @@ func tcpDestinationFactory(
 		for _, endpoint := range endpoints.GetUnReliableEndpoints() {
 			additionals = append(additionals, tcp.NewDestination(endpoint, endpoints.UseProto, destinationsContext, false, status))
 		}
+		additionals = append(additionals, sender.AdditionalDestinations(cfg, instanceID)...)
 
 		return client.NewDestinations(reliable, additionals)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
 	"github.com/DataDog/datadog-agent/pkg/logs/client"
 	"github.com/DataDog/datadog-agent/pkg/logs/client/tcp"
 	"github.com/DataDog/datadog-agent/pkg/logs/sender"
@@ -89,6 +90,7 @@ func TestTCPDestinationFactory(t *testing.T) {
 			status := statusinterface.NewStatusProviderMock()
 
 			factory := tcpDestinationFactory(
+				configmock.New(t),
 				endpoints,
 				destinationsCtx,
 				sender.NewMockServerlessMeta(tc.serverless),
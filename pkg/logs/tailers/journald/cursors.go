@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build systemd
+
+package journald
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/sdjournal"
+
+	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
+)
+
+// unitCursorsInfoKey is the key of the unit cursors in the logs-agent status
+const unitCursorsInfoKey = "Journald Cursors"
+
+// unitCursor is the position of the last entry read for a unit
+type unitCursor struct {
+	cursor string
+	// lag is the delay between the time the entry was written to the journal
+	// and the time it was read by the tailer
+	lag time.Duration
+}
+
+// unitCursors keeps the cursor of the last entry read for each unit of a
+// journal, and exposes them in the logs-agent status.
+type unitCursors struct {
+	mu      sync.Mutex
+	cursors map[string]unitCursor
+}
+
+func newUnitCursors() *unitCursors {
+	return &unitCursors{
+		cursors: make(map[string]unitCursor),
+	}
+}
+
+// set records the cursor of the last entry read for a unit
+func (u *unitCursors) set(unit string, cursor unitCursor) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.cursors[unit] = cursor
+}
+
+// InfoKey returns the key of the unit cursors in the status
+func (u *unitCursors) InfoKey() string {
+	return unitCursorsInfoKey
+}
+
+// Info returns the cursor and the lag of each unit, sorted by unit
+func (u *unitCursors) Info() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	units := make([]string, 0, len(u.cursors))
+	for unit := range u.cursors {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+
+	info := make([]string, 0, len(units))
+	for _, unit := range units {
+		cursor := u.cursors[unit]
+		if cursor.lag == 0 {
+			info = append(info, fmt.Sprintf("%s: cursor %s", unit, cursor.cursor))
+			continue
+		}
+		info = append(info, fmt.Sprintf("%s: cursor %s, lag %s", unit, cursor.cursor, cursor.lag))
+	}
+	return info
+}
+
+// entryUnit returns the system or user unit of a journal entry.
+func entryUnit(entry *sdjournal.JournalEntry) (string, bool) {
+	if unit, exists := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_USER_UNIT]; exists {
+		return unit, true
+	}
+	unit, exists := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT]
+	return unit, exists
+}
+
+// entryLag returns the delay between the time an entry was written to the
+// journal and now.
+func entryLag(entry *sdjournal.JournalEntry, now time.Time) time.Duration {
+	if entry.RealtimeTimestamp == 0 {
+		return 0
+	}
+	lag := now.Sub(time.UnixMicro(int64(entry.RealtimeTimestamp)))
+	if lag < 0 {
+		return 0
+	}
+	return lag
+}
+
+// UnitIdentifier returns the identifier under which the cursor of a unit of
+// the journal of a config is stored in the registry.
+func UnitIdentifier(config *config.LogsConfig, unit string) string {
+	return Identifier(config) + ":unit:" + unit
+}
+
+// includedUnits returns the system and user units collected by a config.
+func includedUnits(config *config.LogsConfig) map[string]bool {
+	units := make(map[string]bool, len(config.IncludeSystemUnits)+len(config.IncludeUserUnits))
+	for _, unit := range config.IncludeSystemUnits {
+		units[unit] = true
+	}
+	for _, unit := range config.IncludeUserUnits {
+		units[unit] = true
+	}
+	return units
+}
@@ -19,6 +19,7 @@ import (
 	tagger "github.com/DataDog/datadog-agent/comp/core/tagger/def"
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
 	auditor "github.com/DataDog/datadog-agent/comp/logs/auditor/def"
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
 	"github.com/DataDog/datadog-agent/pkg/logs/internal/decoder"
 	"github.com/DataDog/datadog-agent/pkg/logs/internal/tag"
 	"github.com/DataDog/datadog-agent/pkg/logs/message"
@@ -56,6 +57,11 @@ type Tailer struct {
 	tagProvider tag.Provider
 	tagger      tagger.Component
 	registry    auditor.Registry
+
+	// unitCursors are the cursors of the last entries read for each unit,
+	// the cursors of the included units are also stored in the registry.
+	unitCursors   *unitCursors
+	includedUnits map[string]bool
 }
 
 // NewTailer returns a new tailer.
@@ -78,6 +84,8 @@ func NewTailer(source *sources.LogSource, outputChan chan *message.Message, jour
 		tagProvider:       tag.NewLocalProvider(source.Config.Tags),
 		tagger:            tagger,
 		registry:          registry,
+		unitCursors:       newUnitCursors(),
+		includedUnits:     includedUnits(source.Config),
 	}
 }
 
@@ -91,6 +99,12 @@ func (t *Tailer) Start(cursor string) error {
 		t.source.Status.Error(err)
 		return err
 	}
+	for unit := range t.includedUnits {
+		if offset := t.registry.GetOffset(UnitIdentifier(t.source.Config, unit)); offset != "" {
+			t.unitCursors.set(unit, unitCursor{cursor: offset})
+		}
+	}
+	t.source.RegisterInfo(t.unitCursors)
 	t.source.Status.Success()
 	t.source.AddInput(t.Identifier())
 	t.registry.SetTailed(t.Identifier(), true)
@@ -213,7 +227,11 @@ func (t *Tailer) forwardMessages() {
 // seek seeks to the cursor if it is not empty or the end of the journal,
 // returns an error if the operation failed.
 func (t *Tailer) seek(cursor string) error {
-	mode, _ := config.TailingModeFromString(t.source.Config.TailingMode)
+	tailingMode := t.source.Config.TailingMode
+	if tailingMode == "" {
+		tailingMode = pkgconfigsetup.Datadog().GetString("logs_config.journald_start_position")
+	}
+	mode, _ := config.TailingModeFromString(tailingMode)
 
 	seekHead := func() error {
 		if err := t.journal.SeekHead(); err != nil {
@@ -250,9 +268,19 @@ func (t *Tailer) seek(cursor string) error {
 
 	// If there is no cursor and an option is not forced, use the config setting
 	if mode == config.Beginning {
+		log.Infof("No cursor found for journal %s, start tailing from the beginning", t.journalPath())
 		return seekHead()
 	}
-	return seekTail()
+	log.Infof("No cursor found for journal %s, start tailing from the end", t.journalPath())
+	if err := seekTail(); err != nil {
+		return err
+	}
+	// Store the initial cursor right away so that the entries written until the
+	// first log is sent are not skipped if the agent restarts in the meantime
+	if initialCursor, err := t.journal.GetCursor(); err == nil && initialCursor != "" {
+		t.registry.SetOffset(t.Identifier(), initialCursor)
+	}
+	return nil
 }
 
 // tail tails the journal until a message stop is received.
@@ -287,6 +315,7 @@ func (t *Tailer) tail() {
 			if t.shouldDrop(entry) {
 				continue
 			}
+			t.updateUnitCursor(entry)
 
 			structuredContent, jsonMarshaled := t.getContent(entry)
 			var msg *message.Message
@@ -315,6 +344,23 @@ func (t *Tailer) tail() {
 	}
 }
 
+// updateUnitCursor records the cursor of the entry for its unit, and stores it
+// in the registry if the unit is included in the configuration.
+func (t *Tailer) updateUnitCursor(entry *sdjournal.JournalEntry) {
+	unit, exists := entryUnit(entry)
+	if !exists {
+		return
+	}
+	cursor, err := t.journal.GetCursor()
+	if err != nil || cursor == "" {
+		return
+	}
+	t.unitCursors.set(unit, unitCursor{cursor: cursor, lag: entryLag(entry, time.Now())})
+	if t.includedUnits[unit] {
+		t.registry.SetOffset(UnitIdentifier(t.source.Config, unit), cursor)
+	}
+}
+
 // shouldDrop returns true if the entry should be dropped,
 // returns false otherwise.
 func (t *Tailer) shouldDrop(entry *sdjournal.JournalEntry) bool {
@@ -32,6 +32,7 @@ type MockJournal struct {
 	next     int
 	previous int
 	cursor   string
+	current  string
 	entries  []*sdjournal.JournalEntry
 }
 
@@ -100,7 +101,7 @@ func (m *MockJournal) GetEntry() (*sdjournal.JournalEntry, error) {
 }
 
 func (m *MockJournal) GetCursor() (string, error) {
-	return "", nil
+	return m.current, nil
 }
 
 func TestIdentifier(t *testing.T) {
@@ -626,3 +627,79 @@ func TestExpectedTagDuration(t *testing.T) {
 	tailer.Stop()
 
 }
+
+func TestDefaultStartPosition(t *testing.T) {
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("logs_config.journald_start_position", "beginning")
+
+	m := &sync.Mutex{}
+	fakeTagger := taggerfxmock.SetupFakeTagger(t)
+
+	// the default start position applies when there is no cursor
+	mockJournal := &MockJournal{m: m}
+	tailer := NewTailer(sources.NewLogSource("", &config.LogsConfig{}), nil, mockJournal, true, fakeTagger, auditorMock.NewMockAuditor())
+	tailer.Start("")
+	mockJournal.m.Lock()
+	assert.Equal(t, 1, mockJournal.seekHead)
+	assert.Equal(t, 0, mockJournal.seekTail)
+	mockJournal.m.Unlock()
+	tailer.Stop()
+
+	// the start position of the source overrides the default one
+	mockJournal = &MockJournal{m: m}
+	tailer = NewTailer(sources.NewLogSource("", &config.LogsConfig{TailingMode: "end"}), nil, mockJournal, true, fakeTagger, auditorMock.NewMockAuditor())
+	tailer.Start("")
+	mockJournal.m.Lock()
+	assert.Equal(t, 0, mockJournal.seekHead)
+	assert.Equal(t, 1, mockJournal.seekTail)
+	mockJournal.m.Unlock()
+	tailer.Stop()
+}
+
+func TestInitialCursorIsStored(t *testing.T) {
+	fakeTagger := taggerfxmock.SetupFakeTagger(t)
+
+	// the cursor of the end of the journal is stored when there is no cursor
+	fakeRegistry := auditorMock.NewMockAuditor()
+	mockJournal := &MockJournal{m: &sync.Mutex{}, current: "tail"}
+	tailer := NewTailer(sources.NewLogSource("", &config.LogsConfig{}), nil, mockJournal, true, fakeTagger, fakeRegistry)
+	tailer.Start("")
+	tailer.Stop()
+	assert.Equal(t, "tail", fakeRegistry.GetOffset(tailer.Identifier()))
+
+	// the stored cursor is left untouched when the tailer resumes from it
+	fakeRegistry = auditorMock.NewMockAuditor()
+	fakeRegistry.SetOffset("journald:default", "123")
+	mockJournal = &MockJournal{m: &sync.Mutex{}, current: "tail"}
+	tailer = NewTailer(sources.NewLogSource("", &config.LogsConfig{}), nil, mockJournal, true, fakeTagger, fakeRegistry)
+	tailer.Start("123")
+	tailer.Stop()
+	assert.Equal(t, "123", fakeRegistry.GetOffset(tailer.Identifier()))
+}
+
+func TestUnitCursors(t *testing.T) {
+	fakeTagger := taggerfxmock.SetupFakeTagger(t)
+	fakeRegistry := auditorMock.NewMockAuditor()
+	fakeRegistry.SetOffset("journald:default:unit:bar.service", "456")
+
+	mockJournal := &MockJournal{m: &sync.Mutex{}, current: "123"}
+	source := sources.NewLogSource("", &config.LogsConfig{IncludeSystemUnits: []string{"foo.service", "bar.service"}})
+	tailer := NewTailer(source, make(chan *message.Message, 1), mockJournal, true, fakeTagger, fakeRegistry)
+	mockJournal.entries = append(mockJournal.entries, &sdjournal.JournalEntry{Fields: map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE:      "foobar",
+		sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT: "foo.service",
+	}})
+
+	tailer.Start("")
+	<-tailer.outputChan
+	tailer.Stop()
+
+	// the cursor of the included units are stored in the registry
+	assert.Equal(t, "123", fakeRegistry.GetOffset("journald:default:unit:foo.service"))
+	assert.Equal(t, "456", fakeRegistry.GetOffset("journald:default:unit:bar.service"))
+
+	// and exposed in the status
+	info := source.GetInfo(unitCursorsInfoKey)
+	assert.NotNil(t, info)
+	assert.Equal(t, []string{"bar.service: cursor 456", "foo.service: cursor 123"}, info.Info())
+}
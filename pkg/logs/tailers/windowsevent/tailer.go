@@ -38,6 +38,8 @@ type Config struct {
 	Query       string
 	// See LogsConfig.ShouldProcessRawMessage() comment.
 	ProcessRawMessage bool
+	// RenderXML adds the XML rendering of the events to the messages
+	RenderXML bool
 }
 
 // Tailer collects logs from Windows Event Log using a pull subscription
@@ -300,6 +302,12 @@ func (t *Tailer) handleEvent(eventRecordHandle evtapi.EventRecordHandle) {
 		// continue to submit the event even if we failed to enrich it
 	}
 
+	if t.config.RenderXML {
+		if err := m.SetXML(xml); err != nil {
+			log.Warnf("Failed to add the xml to the event: %v", err)
+		}
+	}
+
 	err = t.bookmark.Update(eventRecordHandle)
 	if err != nil {
 		log.Warnf("Failed to update bookmark: %v, to event %s", err, xml)
@@ -70,6 +70,19 @@ func (m *Map) SetMessage(message string) error {
 	return m.Map.SetValueForPath(message, "message")
 }
 
+// SetXML sets the xml field in the map to the XML rendering of the event. This field is a DD field not a Windows Event Log field.
+// The XML is truncated if it is bigger than 128kB to prevent the message from being dropped.
+func (m *Map) SetXML(xml string) error {
+	if xml == "" {
+		return nil
+	}
+	if len(xml) > maxMessageBytes {
+		xml = stringUtil.TruncateUTF8(xml, maxMessageBytes)
+		xml = xml + truncatedFlag
+	}
+	return m.Map.SetValueForPath(xml, "xml")
+}
+
 // SetLevel sets the level field in the map. This field is a DD field not a Windows Event Log field.
 func (m *Map) SetLevel(level string) error {
 	if level == "" {
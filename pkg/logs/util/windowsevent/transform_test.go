@@ -6,6 +6,7 @@
 package windowsevent
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,3 +175,19 @@ func TestTransformToMessage(t *testing.T) {
 func richEventFromXML(xml string) *richEvent {
 	return &richEvent{xmlEvent: xml}
 }
+
+func TestSetXML(t *testing.T) {
+	evt := `<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><EventID>7036</EventID><Channel>System</Channel></System></Event>`
+	m, err := NewMapXML([]byte(evt))
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.SetXML(evt))
+	assert.Equal(t, evt, m.Map["xml"])
+
+	// large XML renderings are truncated
+	large := "<Event>" + strings.Repeat("a", maxMessageBytes) + "</Event>"
+	assert.NoError(t, m.SetXML(large))
+	xml := m.Map["xml"].(string)
+	assert.Len(t, xml, maxMessageBytes+len(truncatedFlag))
+	assert.True(t, strings.HasSuffix(xml, truncatedFlag))
+}
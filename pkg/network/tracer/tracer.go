@@ -12,6 +12,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -737,6 +739,29 @@ func (t *Tracer) DebugNetworkMaps() (*network.Connections, error) {
 
 }
 
+// GetUDPSourcePIDs returns the PIDs of the processes owning the active UDP
+// connections to the given destination port, by source address. It allows
+// identifying the senders of the UDP traffic received on this port.
+func (t *Tracer) GetUDPSourcePIDs(port uint16) (map[string]uint32, error) {
+	// the lock is only held to serialize the eBPF map iteration, the lookup
+	// is read-only and leaves the connection state and caches untouched
+	t.bufferLock.Lock()
+	defer t.bufferLock.Unlock()
+
+	pids := make(map[string]uint32)
+	err := t.ebpfTracer.GetConnections(network.NewConnectionBuffer(0, 0), func(c *network.ConnectionStats) bool {
+		if c.Type == network.UDP && c.DPort == port && c.Pid != 0 {
+			pids[net.JoinHostPort(c.Source.String(), strconv.Itoa(int(c.SPort)))] = c.Pid
+		}
+		// never keep the connection, nothing is written to the buffer
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving connections: %s", err)
+	}
+	return pids, nil
+}
+
 // DebugEBPFMaps returns all maps registered in the eBPF manager
 func (t *Tracer) DebugEBPFMaps(w io.Writer, maps ...string) error {
 	io.WriteString(w, "tracer:\n")
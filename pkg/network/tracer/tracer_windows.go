@@ -292,6 +292,11 @@ func (t *Tracer) DebugNetworkMaps() (*network.Connections, error) {
 	return nil, ebpf.ErrNotImplemented
 }
 
+// GetUDPSourcePIDs is not implemented on this OS for Tracer
+func (t *Tracer) GetUDPSourcePIDs(_ uint16) (map[string]uint32, error) {
+	return nil, ebpf.ErrNotImplemented
+}
+
 // DebugEBPFMaps is not implemented on this OS for Tracer
 func (t *Tracer) DebugEBPFMaps(_ io.Writer, _ ...string) error {
 	return ebpf.ErrNotImplemented
@@ -101,6 +101,11 @@ func (oc *OrchestratorConfig) Load() error {
 		oc.Scrubber.AddCustomSensitiveWords(pkgconfigsetup.Datadog().GetStringSlice(k))
 	}
 
+	// The custom regular expressions of the process collection also scrub the container commands
+	if patterns := pkgconfigsetup.Datadog().GetStringSlice("process_config.custom_sensitive_regex"); len(patterns) > 0 {
+		oc.Scrubber.AddCustomSensitiveCaptureRegex(patterns)
+	}
+
 	if k := OrchestratorNSKey("custom_sensitive_annotations_labels"); pkgconfigsetup.Datadog().IsSet(k) {
 		redact.UpdateSensitiveAnnotationsAndLabels(pkgconfigsetup.Datadog().GetStringSlice(k))
 	}
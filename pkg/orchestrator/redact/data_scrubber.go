@@ -38,6 +38,9 @@ type DataScrubber struct {
 	Enabled bool
 	// RegexSensitivePatterns are custom regex patterns which are currently not exposed externally
 	RegexSensitivePatterns []*regexp.Regexp
+	// CaptureRegexSensitivePatterns are custom regular expressions, the parts of
+	// their matches outside of their capture groups are redacted
+	CaptureRegexSensitivePatterns []*regexp.Regexp
 	// LiteralSensitivePatterns are custom words which use to match against
 	LiteralSensitivePatterns         []string
 	regexSensitiveWordsInAnnotations []*regexp.Regexp
@@ -110,6 +113,12 @@ func (ds *DataScrubber) ScrubSimpleCommand(cmd, args []string) ([]string, []stri
 			rawCmdline = pattern.ReplaceAllString(rawCmdline, fmt.Sprintf(`${key}${delimiter}%s`, redactedSecret))
 		}
 	}
+	for _, pattern := range ds.CaptureRegexSensitivePatterns {
+		if scrubbed, ok := redactRegexMatches(pattern, rawCmdline); ok {
+			regexChanged = true
+			rawCmdline = scrubbed
+		}
+	}
 
 	// Regex tokenizes by capturing non-whitespace terms as tokens EX: "agent --secret" > ["agent", "--secret"]
 	// and non-whitespace terms followed by quotation enclosed subcomponents as tokens EX: "agent --pass="secret house"" > ["agent", "--pass="secret house""]
@@ -204,6 +213,60 @@ func (ds *DataScrubber) AddCustomSensitiveRegex(words []string) {
 	ds.RegexSensitivePatterns = append(ds.RegexSensitivePatterns, r...)
 }
 
+// AddCustomSensitiveCaptureRegex adds custom regular expressions on the
+// DataScrubber object. The parts of their matches outside of their capture
+// groups are redacted. Invalid regular expressions are logged and skipped.
+func (ds *DataScrubber) AddCustomSensitiveCaptureRegex(patterns []string) {
+	for _, pattern := range patterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Warnf("data scrubber: %s skipped. It couldn't be compiled into a regex expression: %v", pattern, err)
+			continue
+		}
+		ds.CaptureRegexSensitivePatterns = append(ds.CaptureRegexSensitivePatterns, r)
+	}
+}
+
+// redactRegexMatches replaces the matches of a regular expression in s,
+// preserving the text of their capture groups (originally imported from
+// pkg/process/procutil). It returns the redacted string and whether it was changed.
+func redactRegexMatches(re *regexp.Regexp, s string) (string, bool) {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, false
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(s[last:match[0]])
+		pos := match[0]
+		for group := 1; group < len(match)/2; group++ {
+			start, end := match[2*group], match[2*group+1]
+			// skip the groups which did not participate in the match and the nested ones
+			if start < pos {
+				continue
+			}
+			if start > pos {
+				b.WriteString(redactedSecret)
+			}
+			b.WriteString(s[start:end])
+			pos = end
+		}
+		if pos < match[1] {
+			b.WriteString(redactedSecret)
+		}
+		last = match[1]
+	}
+	b.WriteString(s[last:])
+
+	scrubbed := b.String()
+	return scrubbed, scrubbed != s
+}
+
 // compileStringsToRegex compile each word in the slice into a regex pattern to match
 // against the cmdline arguments (originally imported from pkg/process/config)
 // The word must contain only word characters ([a-zA-z0-9_]) or wildcards *
@@ -72,6 +72,22 @@ func TestMatchSimpleCommandScrubRegex(t *testing.T) {
 	}
 }
 
+func TestMatchSimpleCommandScrubCaptureRegex(t *testing.T) {
+	scrubber := NewDefaultDataScrubber()
+	scrubber.AddCustomSensitiveCaptureRegex([]string{`(--token[= ])\S+`, `(invalid`})
+	assert.Len(t, scrubber.CaptureRegexSensitivePatterns, 1)
+
+	cmd, args, changed := scrubber.ScrubSimpleCommand([]string{"agent", "--token=abcd"}, []string{"--token", "abcd", "--verbose"})
+	assert.True(t, changed)
+	assert.Equal(t, []string{"agent", "--token=********"}, cmd)
+	assert.Equal(t, []string{"--token", "********", "--verbose"}, args)
+
+	cmd, args, changed = scrubber.ScrubSimpleCommand([]string{"agent"}, []string{"--tokens"})
+	assert.False(t, changed)
+	assert.Equal(t, []string{"agent"}, cmd)
+	assert.Equal(t, []string{"--tokens"}, args)
+}
+
 func BenchmarkEnvScrubbing1(b *testing.B)    { benchmarkEnvScrubbing(1, b) }
 func BenchmarkEnvScrubbing10(b *testing.B)   { benchmarkEnvScrubbing(10, b) }
 func BenchmarkEnvScrubbing100(b *testing.B)  { benchmarkEnvScrubbing(100, b) }
@@ -12,6 +12,7 @@ import (
 	"math"
 	"net/http"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -43,10 +44,13 @@ const (
 	emptyCtrID                 = ""
 	configPrefix               = "process_config."
 	configCustomSensitiveWords = configPrefix + "custom_sensitive_words"
+	configCustomSensitiveRegex = configPrefix + "custom_sensitive_regex"
 	configScrubArgs            = configPrefix + "scrub_args"
 	configStripProcArgs        = configPrefix + "strip_proc_arguments"
 	configDisallowList         = configPrefix + "blacklist_patterns"
 	configIgnoreZombies        = configPrefix + "ignore_zombie_processes"
+	configAncestryDepth        = configPrefix + "process_collection.ancestry_depth"
+	configWindowsProcMetrics   = configPrefix + "windows.process_metrics.enabled"
 )
 
 // NewProcessCheck returns an instance of the ProcessCheck.
@@ -95,6 +99,12 @@ type ProcessCheck struct {
 	// determine if zombies process will be collected
 	ignoreZombieProcesses bool
 
+	// number of ancestors of the processes added to their tags, 0 to disable
+	ancestryDepth int
+
+	// determine if the handle, thread and page fault metrics of the processes are sent, only on Windows
+	reportResourceMetrics bool
+
 	// TODO: process_config.process_collection.use_wlm is a temporary configuration for refactoring purposes
 	// that determines if linux process collection will use WLM
 	useWLMProcessCollection bool
@@ -190,6 +200,10 @@ func (p *ProcessCheck) Init(syscfg *SysProbeConfig, info *HostInfo, oneShot bool
 
 	p.ignoreZombieProcesses = p.config.GetBool(configIgnoreZombies)
 
+	p.ancestryDepth = p.config.GetInt(configAncestryDepth)
+
+	p.reportResourceMetrics = runtime.GOOS == "windows" && p.config.GetBool(configWindowsProcMetrics)
+
 	p.extractors = append(p.extractors, p.serviceExtractor)
 
 	if !oneShot && workloadmeta.Enabled(p.config) && !p.useWLMCollection() {
@@ -320,6 +334,10 @@ func (p *ProcessCheck) run(groupID int32, collectRealTime bool) (RunResult, erro
 	pidToGPUTags := p.gpuSubscriber.GetGPUTags()
 
 	procsByCtr := fmtProcesses(p.scrubber, p.disallowList, procs, p.lastProcs, pidToCid, cpuTimes[0], p.lastCPUTime, p.lastRun, p.lookupIdProbe, p.ignoreZombieProcesses, p.serviceExtractor, pidToGPUTags, p.tagger, time.Now())
+	addAncestryTags(procsByCtr, procs, p.ancestryDepth)
+	if p.reportResourceMetrics {
+		reportProcessResourceMetrics(p.statsd, procs, p.disallowList)
+	}
 	messages, totalProcs, totalContainers := createProcCtrMessages(p.hostInfo, procsByCtr, containers, p.maxBatchSize, p.maxBatchBytes, groupID, p.networkID, collectorProcHints)
 
 	// Store the last state for comparison on the next run.
@@ -709,6 +727,12 @@ func initScrubber(config pkgconfigmodel.Reader, scrubber *procutil.DataScrubber)
 		log.Debug("Adding custom sensitives words to Scrubber:", words)
 	}
 
+	// Custom regular expressions, the parts of their matches outside of their capture groups are redacted
+	if patterns := config.GetStringSlice(configCustomSensitiveRegex); len(patterns) > 0 {
+		scrubber.AddCustomSensitiveRegex(patterns)
+		log.Debug("Adding custom sensitive regular expressions to Scrubber:", patterns)
+	}
+
 	// Strips all process arguments
 	if config.GetBool(configStripProcArgs) {
 		log.Debug("Strip all process arguments enabled")
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checks
+
+import (
+	"fmt"
+	"path/filepath"
+
+	model "github.com/DataDog/agent-payload/v5/process"
+
+	"github.com/DataDog/datadog-agent/pkg/process/procutil"
+)
+
+// ancestryTagName is the name of the tags describing the ancestors of a
+// process, formatted as `process_ancestry:<depth>:<pid>:<command name>`, where
+// the depth of the parent of the process is 1.
+const ancestryTagName = "process_ancestry"
+
+// addAncestryTags adds the parent chain of each process, up to maxDepth
+// ancestors, to its tags, so that process trees can be rendered without
+// joining the processes of the payloads.
+func addAncestryTags(procsByCtr map[string][]*model.Process, procs map[int32]*procutil.Process, maxDepth int) {
+	if maxDepth <= 0 {
+		return
+	}
+	for _, ctrProcs := range procsByCtr {
+		for _, proc := range ctrProcs {
+			proc.Tags = append(proc.Tags, ancestryTags(proc.Pid, procs, maxDepth)...)
+		}
+	}
+}
+
+// ancestryTags returns the tags describing the ancestors of a process, from its
+// parent up to maxDepth ancestors or to the first ancestor that is unknown.
+func ancestryTags(pid int32, procs map[int32]*procutil.Process, maxDepth int) []string {
+	var tags []string
+	seen := map[int32]struct{}{pid: {}}
+	for depth := 1; depth <= maxDepth; depth++ {
+		proc, ok := procs[pid]
+		if !ok || proc.Ppid <= 0 {
+			break
+		}
+		// protect against the loops of the pid reuse
+		if _, ok := seen[proc.Ppid]; ok {
+			break
+		}
+		parent, ok := procs[proc.Ppid]
+		if !ok {
+			break
+		}
+		tags = append(tags, fmt.Sprintf("%s:%d:%d:%s", ancestryTagName, depth, parent.Pid, commandName(parent)))
+		seen[parent.Pid] = struct{}{}
+		pid = parent.Pid
+	}
+	return tags
+}
+
+// commandName returns the name of the command of a process.
+func commandName(proc *procutil.Process) string {
+	if proc.Comm != "" {
+		return proc.Comm
+	}
+	if proc.Exe != "" {
+		return filepath.Base(proc.Exe)
+	}
+	if len(proc.Cmdline) > 0 {
+		return filepath.Base(proc.Cmdline[0])
+	}
+	return ""
+}
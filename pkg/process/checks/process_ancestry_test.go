@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checks
+
+import (
+	"testing"
+
+	model "github.com/DataDog/agent-payload/v5/process"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/process/procutil"
+)
+
+func makeProcessWithParent(pid int32, ppid int32, comm string) *procutil.Process {
+	p := makeProcess(pid, "/usr/bin/"+comm+" --flag")
+	p.Ppid = ppid
+	p.Comm = comm
+	return p
+}
+
+func TestAncestryTags(t *testing.T) {
+	procs := map[int32]*procutil.Process{
+		1:  makeProcessWithParent(1, 0, "systemd"),
+		10: makeProcessWithParent(10, 1, "containerd"),
+		20: makeProcessWithParent(20, 10, "bash"),
+		30: makeProcessWithParent(30, 20, ""),
+		40: makeProcessWithParent(40, 99, "orphan"),
+		50: makeProcessWithParent(50, 51, "loop"),
+		51: makeProcessWithParent(51, 50, "loop"),
+	}
+	procs[30].Cmdline = []string{"/usr/bin/python3", "app.py"}
+
+	assert.Equal(t, []string{
+		"process_ancestry:1:20:bash",
+		"process_ancestry:2:10:containerd",
+		"process_ancestry:3:1:systemd",
+	}, ancestryTags(30, procs, 5))
+
+	assert.Equal(t, []string{
+		"process_ancestry:1:20:bash",
+		"process_ancestry:2:10:containerd",
+	}, ancestryTags(30, procs, 2), "bounded by the depth")
+
+	assert.Equal(t, []string{"process_ancestry:1:30:python3"}, ancestryTags(31, map[int32]*procutil.Process{
+		30: procs[30],
+		31: makeProcessWithParent(31, 30, "worker"),
+	}, 5), "the command name falls back to the command line")
+
+	assert.Empty(t, ancestryTags(1, procs, 5), "no parent")
+	assert.Empty(t, ancestryTags(40, procs, 5), "unknown parent")
+	assert.Equal(t, []string{"process_ancestry:1:51:loop"}, ancestryTags(50, procs, 5), "loops are stopped")
+}
+
+func TestAddAncestryTags(t *testing.T) {
+	procs := map[int32]*procutil.Process{
+		1:  makeProcessWithParent(1, 0, "systemd"),
+		10: makeProcessWithParent(10, 1, "containerd"),
+		20: makeProcessWithParent(20, 10, "nginx"),
+	}
+	procsByCtr := map[string][]*model.Process{
+		"":    {{Pid: 1}, {Pid: 10, Tags: []string{"foo:bar"}}},
+		"ctr": {{Pid: 20}},
+	}
+
+	addAncestryTags(procsByCtr, procs, 0)
+	assert.Empty(t, procsByCtr[""][0].Tags)
+	assert.Equal(t, []string{"foo:bar"}, procsByCtr[""][1].Tags)
+	assert.Empty(t, procsByCtr["ctr"][0].Tags)
+
+	addAncestryTags(procsByCtr, procs, 2)
+	assert.Empty(t, procsByCtr[""][0].Tags)
+	assert.Equal(t, []string{"foo:bar", "process_ancestry:1:1:systemd"}, procsByCtr[""][1].Tags)
+	assert.Equal(t, []string{"process_ancestry:1:10:containerd", "process_ancestry:2:1:systemd"}, procsByCtr["ctr"][0].Tags)
+}
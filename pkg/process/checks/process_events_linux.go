@@ -66,7 +66,15 @@ func (e *ProcessEventsCheck) Init(_ *SysProbeConfig, info *HostInfo, _ bool) err
 	}
 	e.store = store
 
+	sampleRate := e.config.GetFloat64("process_config.event_collection.sample_rate")
+	if sampleRate < 1 {
+		log.Infof("Collecting the lifecycle events of %.1f%% of the processes", max(sampleRate, 0)*100)
+	}
+
 	listener, err := events.NewListener(func(e *model.ProcessEvent) {
+		if !isProcessSampled(e.Pid, sampleRate) {
+			return
+		}
 		// push events to the store asynchronously without checking for errors
 		_ = store.Push(e, nil)
 	})
@@ -153,6 +161,22 @@ func (e *ProcessEventsCheck) isCheckCorrectlySetup() bool {
 	return e.store != nil && e.listener != nil
 }
 
+// isProcessSampled returns whether the lifecycle events of a process are
+// collected with the given sample rate. The decision only depends on the PID
+// so that the exec and exit events of a process are either both kept or both dropped.
+func isProcessSampled(pid uint32, sampleRate float64) bool {
+	if sampleRate >= 1 {
+		return true
+	}
+	if sampleRate <= 0 {
+		return false
+	}
+
+	// Knuth's multiplicative hash spreads consecutive PIDs
+	const sampleRateScale = 1 << 32
+	return float64(pid*2654435761) < sampleRate*sampleRateScale
+}
+
 // chunkProcessEvents splits a list of ProcessEvents into chunks according to the given chunk size
 // TODO: Move it to chunker
 func chunkProcessEvents(events []*payload.ProcessEvent, size int) [][]*payload.ProcessEvent {
@@ -288,3 +288,26 @@ func TestProcessEventsChunking(t *testing.T) {
 		assert.Len(t, chunks, tc.chunkCount)
 	}
 }
+
+func TestIsProcessSampled(t *testing.T) {
+	const pids = 100000
+	for _, tc := range []struct {
+		sampleRate float64
+		expected   float64
+	}{
+		{1, 1},
+		{2, 1},
+		{0, 0},
+		{-1, 0},
+		{0.5, 0.5},
+		{0.1, 0.1},
+	} {
+		sampled := 0
+		for pid := uint32(1); pid <= pids; pid++ {
+			if isProcessSampled(pid, tc.sampleRate) {
+				sampled++
+			}
+		}
+		assert.InDelta(t, tc.expected, float64(sampled)/pids, 0.01, "sample rate %f", tc.sampleRate)
+	}
+}
@@ -0,0 +1,53 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checks
+
+import (
+	"regexp"
+
+	"github.com/DataDog/datadog-go/v5/statsd"
+
+	"github.com/DataDog/datadog-agent/pkg/process/procutil"
+)
+
+// processResourceMetrics holds the handle, thread and page fault metrics of the
+// processes sharing a name.
+type processResourceMetrics struct {
+	handles    float64
+	threads    float64
+	pageFaults float64
+}
+
+// aggregateProcessResourceMetrics sums the handle, thread and page fault
+// metrics of the processes by process name, skipping the disallow-listed processes.
+func aggregateProcessResourceMetrics(procs map[int32]*procutil.Process, disallowList []*regexp.Regexp) map[string]*processResourceMetrics {
+	metrics := make(map[string]*processResourceMetrics)
+	for _, proc := range procs {
+		if proc.Stats == nil || isDisallowListed(proc.Cmdline, disallowList) {
+			continue
+		}
+		m, ok := metrics[proc.Name]
+		if !ok {
+			m = &processResourceMetrics{}
+			metrics[proc.Name] = m
+		}
+		m.handles += float64(proc.Stats.OpenFdCount)
+		m.threads += float64(proc.Stats.NumThreads)
+		m.pageFaults += proc.Stats.PageFaultsRate
+	}
+	return metrics
+}
+
+// reportProcessResourceMetrics sends the handle, thread and page fault metrics
+// of the processes, summed by process name, which help diagnosing leaky Windows services.
+func reportProcessResourceMetrics(client statsd.ClientInterface, procs map[int32]*procutil.Process, disallowList []*regexp.Regexp) {
+	for name, m := range aggregateProcessResourceMetrics(procs, disallowList) {
+		tags := []string{"process_name:" + name}
+		_ = client.Gauge("process.windows.handle_count", m.handles, tags, 1)
+		_ = client.Gauge("process.windows.thread_count", m.threads, tags, 1)
+		_ = client.Gauge("process.windows.page_faults", m.pageFaults, tags, 1)
+	}
+}
@@ -0,0 +1,31 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checks
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/process/procutil"
+)
+
+func TestAggregateProcessResourceMetrics(t *testing.T) {
+	procs := map[int32]*procutil.Process{
+		1: {Pid: 1, Name: "svchost.exe", Stats: &procutil.Stats{OpenFdCount: 100, NumThreads: 10, PageFaultsRate: 1.5}},
+		2: {Pid: 2, Name: "svchost.exe", Stats: &procutil.Stats{OpenFdCount: 200, NumThreads: 5, PageFaultsRate: 0.5}},
+		3: {Pid: 3, Name: "leaky.exe", Stats: &procutil.Stats{OpenFdCount: 50000, NumThreads: 300}},
+		4: {Pid: 4, Name: "nostats.exe"},
+		5: {Pid: 5, Name: "hidden.exe", Cmdline: []string{"hidden.exe"}, Stats: &procutil.Stats{OpenFdCount: 10}},
+	}
+	disallowList := []*regexp.Regexp{regexp.MustCompile("hidden")}
+
+	assert.Equal(t, map[string]*processResourceMetrics{
+		"svchost.exe": {handles: 300, threads: 15, pageFaults: 2},
+		"leaky.exe":   {handles: 50000, threads: 300},
+	}, aggregateProcessResourceMetrics(procs, disallowList))
+}
@@ -16,6 +16,7 @@ import (
 
 const (
 	defaultCacheMaxCycles = 25
+	redactedValue         = "********"
 )
 
 type processCacheKey struct {
@@ -35,6 +36,7 @@ type DataScrubber struct {
 	Enabled           bool
 	StripAllArguments bool
 	SensitivePatterns []DataScrubberPattern
+	SensitiveRegexps  []*regexp.Regexp // custom regular expressions, see RedactRegexMatches
 	seenProcess       map[processCacheKey]struct{}
 	scrubbedCmdlines  map[processCacheKey][]string
 	cacheCycles       uint32 // used to control the cache age
@@ -176,7 +178,14 @@ func (ds *DataScrubber) ScrubCommand(cmdline []string) ([]string, bool) {
 
 		if pattern.Re.MatchString(rawCmdline) {
 			changed = true
-			rawCmdline = pattern.Re.ReplaceAllString(rawCmdline, "${key}${delimiter}********")
+			rawCmdline = pattern.Re.ReplaceAllString(rawCmdline, "${key}${delimiter}"+redactedValue)
+		}
+	}
+
+	for _, re := range ds.SensitiveRegexps {
+		if scrubbed, ok := RedactRegexMatches(re, rawCmdline, redactedValue); ok {
+			changed = true
+			rawCmdline = scrubbed
 		}
 	}
 
@@ -192,6 +201,60 @@ func (ds *DataScrubber) AddCustomSensitiveWords(words []string) {
 	ds.SensitivePatterns = append(ds.SensitivePatterns, newPatterns...)
 }
 
+// AddCustomSensitiveRegex adds custom regular expressions on the DataScrubber
+// object. Invalid regular expressions are logged and skipped.
+func (ds *DataScrubber) AddCustomSensitiveRegex(patterns []string) {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Warnf("data scrubber: %s skipped. It couldn't be compiled into a regex expression: %v", pattern, err)
+			continue
+		}
+		ds.SensitiveRegexps = append(ds.SensitiveRegexps, re)
+	}
+}
+
+// RedactRegexMatches replaces the matches of a regular expression in s with
+// the redacted value, preserving the text of their capture groups. For
+// example, `(--token[= ])\S+` turns "--token=abc" into "--token=********".
+// It returns the redacted string and whether it was changed.
+func RedactRegexMatches(re *regexp.Regexp, s, redacted string) (string, bool) {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, false
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(s[last:match[0]])
+		pos := match[0]
+		for group := 1; group < len(match)/2; group++ {
+			start, end := match[2*group], match[2*group+1]
+			// skip the groups which did not participate in the match and the nested ones
+			if start < pos {
+				continue
+			}
+			if start > pos {
+				b.WriteString(redacted)
+			}
+			b.WriteString(s[start:end])
+			pos = end
+		}
+		if pos < match[1] {
+			b.WriteString(redacted)
+		}
+		last = match[1]
+	}
+	b.WriteString(s[last:])
+
+	scrubbed := b.String()
+	return scrubbed, scrubbed != s
+}
+
 // wordToFastChecker returns a string that can be used to do a first fast lookup before doing the full
 // regex search
 // for example `wordToFastChecker("*aa*bbb*") = "bbb"`
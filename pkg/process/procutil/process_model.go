@@ -130,6 +130,8 @@ type Stats struct {
 	IOStat      *IOCountersStat
 	IORateStat  *IOCountersRateStat
 	CtxSwitches *NumCtxSwitchesStat
+	// PageFaultsRate is the number of page faults per second, only collected on Windows
+	PageFaultsRate float64
 }
 
 // Service holds service discovery data for a process
@@ -157,11 +159,12 @@ type Service struct {
 func (s *Stats) DeepCopy() *Stats {
 	//nolint:revive // TODO(PROC) Fix revive linter
 	copy := &Stats{
-		CreateTime:  s.CreateTime,
-		Status:      s.Status,
-		Nice:        s.Nice,
-		OpenFdCount: s.OpenFdCount,
-		NumThreads:  s.NumThreads,
+		CreateTime:     s.CreateTime,
+		Status:         s.Status,
+		Nice:           s.Nice,
+		OpenFdCount:    s.OpenFdCount,
+		NumThreads:     s.NumThreads,
+		PageFaultsRate: s.PageFaultsRate,
 	}
 	if s.CPUTime != nil {
 		copy.CPUTime = &CPUTimesStat{}
@@ -29,6 +29,7 @@ var counterPaths = []string{
 	pdhutil.CounterAllProcessPoolPagedBytes,
 	pdhutil.CounterAllProcessThreadCount,
 	pdhutil.CounterAllProcessHandleCount,
+	pdhutil.CounterAllProcessPageFaultsPerSec,
 	pdhutil.CounterAllProcessIOReadOpsPerSec,
 	pdhutil.CounterAllProcessIOWriteOpsPerSec,
 	pdhutil.CounterAllProcessIOReadBytesPerSec,
@@ -130,6 +131,10 @@ func (p *probe) initEnumSpecs() {
 			format:   pdhutil.PDH_FMT_LARGE,
 			enumFunc: valueToUint64(p.mapHandleCount),
 		},
+		pdhutil.CounterAllProcessPageFaultsPerSec: {
+			format:   pdhutil.PDH_FMT_DOUBLE,
+			enumFunc: valueToFloat64(p.mapPageFaultsPerSec),
+		},
 		pdhutil.CounterAllProcessIOReadOpsPerSec: {
 			format:   pdhutil.PDH_FMT_DOUBLE,
 			enumFunc: valueToFloat64(p.mapIOReadOpsPerSec),
@@ -371,6 +376,17 @@ func (p *probe) mapThreadCount(instance string, v uint64) {
 	p.mapToStatUint64(instance, v, p.setProcNumThreads)
 }
 
+func (p *probe) setProcPageFaultsRate(pid int32, stats *Stats, instance string, v float64) {
+	if p.traceStats(pid) {
+		log.Tracef("PageFaultsRate[%s,pid=%d] %f", instance, pid, v)
+	}
+	stats.PageFaultsRate = v
+}
+
+func (p *probe) mapPageFaultsPerSec(instance string, v float64) {
+	p.mapToStatFloat64(instance, v, p.setProcPageFaultsRate)
+}
+
 func (p *probe) setProcCPUTimeUser(pid int32, stats *Stats, instance string, v float64) {
 	if p.traceStats(pid) {
 		log.Tracef("CPU.User[%s,pid=%d] %f", instance, pid, v)
@@ -113,7 +113,7 @@ func (p *containerProvider) GetContainers(cacheValidity time.Duration, previousC
 
 	processContainers := make([]*model.Container, 0)
 	rateStats := make(map[string]*ContainerRateMetrics)
-	pidToCid := make(map[int]string)
+	pidToCid := newPidToCidBuilder()
 	for _, container := range containersMetadata {
 		var annotations map[string]string
 		if pod, err := p.metadataStore.GetKubernetesPodForContainer(container.ID); err == nil {
@@ -179,9 +179,7 @@ func (p *containerProvider) GetContainers(cacheValidity time.Duration, previousC
 		// Building PID to CID mapping for NPM
 		pids, err := collector.GetPIDs(container.Namespace, container.ID, cacheValidity)
 		if err == nil && pids != nil {
-			for _, pid := range pids {
-				pidToCid[pid] = container.ID
-			}
+			pidToCid.add(container.ID, pids)
 		} else {
 			log.Debugf("PIDs for: %+v not available, err: %v", container, err)
 		}
@@ -196,13 +194,13 @@ func (p *containerProvider) GetContainers(cacheValidity time.Duration, previousC
 		rateStats[processContainer.Id] = &outPreviousStats
 	}
 
-	return processContainers, rateStats, pidToCid, nil
+	return processContainers, rateStats, pidToCid.build(), nil
 }
 
 // GetPidToCid returns containers found on the machine
 func (p *containerProvider) GetPidToCid(cacheValidity time.Duration) map[int]string {
 	containersMetadata := p.metadataStore.ListContainersWithFilter(workloadmeta.GetRunningContainers)
-	pidToCid := make(map[int]string)
+	pidToCid := newPidToCidBuilder()
 	for _, container := range containersMetadata {
 		var annotations map[string]string
 		if pod, err := p.metadataStore.GetKubernetesPodForContainer(container.ID); err == nil {
@@ -225,15 +223,13 @@ func (p *containerProvider) GetPidToCid(cacheValidity time.Duration) map[int]str
 		// Building PID to CID mapping for NPM and Language Detection
 		pids, err := collector.GetPIDs(container.Namespace, container.ID, cacheValidity)
 		if err == nil && pids != nil {
-			for _, pid := range pids {
-				pidToCid[pid] = container.ID
-			}
+			pidToCid.add(container.ID, pids)
 		} else {
 			log.Debugf("PIDs for: %+v not available, err: %v", container, err)
 		}
 	}
 
-	return pidToCid
+	return pidToCid.build()
 }
 
 func computeContainerStats(container *workloadmeta.Container, inStats *metrics.ContainerStats, previousStats, outPreviousStats *ContainerRateMetrics, outStats *model.Container) {
@@ -0,0 +1,131 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package containers
+
+import (
+	"bufio"
+	"bytes"
+	"slices"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// Resolutions of the processes claimed by several containers
+const (
+	resolvedByCgroupPath     = "cgroup_path"
+	resolvedByPidHierarchy   = "pid_hierarchy"
+	unresolvedPidConflict    = "unresolved"
+	pidConflictResolutionTag = "resolution"
+)
+
+var tlmPidConflicts = telemetry.NewCounter("process", "container_pid_conflicts",
+	[]string{pidConflictResolutionTag}, "Count of processes claimed by several containers, by resolution")
+
+// pidToCidBuilder builds the PID to container ID mapping. Processes of nested
+// containers (docker-in-docker, kata, ...) are reported by the inner container
+// and by all the containers around it, they are attributed to the innermost
+// one.
+type pidToCidBuilder struct {
+	pidToCid map[int]string
+	// candidates holds the containers of the processes claimed by several containers
+	candidates map[int][]string
+	pidCounts  map[string]int
+	readCgroup func(pid int) ([]byte, error)
+}
+
+func newPidToCidBuilder() *pidToCidBuilder {
+	return &pidToCidBuilder{
+		pidToCid:   make(map[int]string),
+		candidates: make(map[int][]string),
+		pidCounts:  make(map[string]int),
+		readCgroup: readProcCgroup,
+	}
+}
+
+// add records the processes of a container.
+func (b *pidToCidBuilder) add(containerID string, pids []int) {
+	b.pidCounts[containerID] = len(pids)
+	for _, pid := range pids {
+		if cid, found := b.pidToCid[pid]; found && cid != containerID {
+			if _, conflict := b.candidates[pid]; !conflict {
+				b.candidates[pid] = []string{cid}
+			}
+			b.candidates[pid] = append(b.candidates[pid], containerID)
+			continue
+		}
+		b.pidToCid[pid] = containerID
+	}
+}
+
+// build returns the PID to container ID mapping, attributing the processes
+// claimed by several containers to the innermost one.
+func (b *pidToCidBuilder) build() map[int]string {
+	for pid, candidates := range b.candidates {
+		cid, resolution := b.innermostContainer(pid, candidates)
+		if resolution == unresolvedPidConflict {
+			log.Debugf("Unable to find the innermost container of pid %d among %v, attributing it to %s", pid, candidates, cid)
+		}
+		tlmPidConflicts.Inc(resolution)
+		b.pidToCid[pid] = cid
+	}
+	return b.pidToCid
+}
+
+// innermostContainer returns the innermost of the containers claiming a
+// process and how it was found:
+//   - the container ID found last in the cgroup path of the process, since
+//     the cgroups of nested containers are created below the ones of the
+//     containers around them
+//   - otherwise, the container with the fewest processes, since the processes
+//     of a nested container are a subset of the ones of the containers around it
+func (b *pidToCidBuilder) innermostContainer(pid int, candidates []string) (string, string) {
+	if cgroups, err := b.readCgroup(pid); err == nil {
+		if cid := innermostContainerInCgroups(cgroups, candidates); cid != "" {
+			return cid, resolvedByCgroupPath
+		}
+	} else {
+		log.Tracef("Unable to read the cgroups of pid %d: %v", pid, err)
+	}
+
+	// sorting the candidates keeps the attribution stable when it cannot be resolved
+	slices.Sort(candidates)
+	innermost := candidates[0]
+	resolution := resolvedByPidHierarchy
+	for _, cid := range candidates[1:] {
+		switch {
+		case b.pidCounts[cid] < b.pidCounts[innermost]:
+			innermost = cid
+			resolution = resolvedByPidHierarchy
+		case b.pidCounts[cid] == b.pidCounts[innermost]:
+			resolution = unresolvedPidConflict
+		}
+	}
+	return innermost, resolution
+}
+
+// innermostContainerInCgroups returns the candidate container ID found last in
+// the cgroup paths of the content of a /proc/<pid>/cgroup file.
+func innermostContainerInCgroups(cgroups []byte, candidates []string) string {
+	innermost := ""
+	innermostIndex := -1
+	scanner := bufio.NewScanner(bytes.NewReader(cgroups))
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		for _, cid := range candidates {
+			if index := strings.LastIndex(parts[2], cid); index > innermostIndex {
+				innermost = cid
+				innermostIndex = index
+			}
+		}
+	}
+	return innermost
+}
@@ -0,0 +1,20 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package containers
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/DataDog/datadog-agent/pkg/util/kernel"
+)
+
+// readProcCgroup returns the content of the /proc/<pid>/cgroup file of a process.
+func readProcCgroup(pid int) ([]byte, error) {
+	return os.ReadFile(kernel.HostProc(strconv.Itoa(pid), "cgroup"))
+}
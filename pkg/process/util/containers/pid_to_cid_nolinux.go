@@ -0,0 +1,15 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !linux
+
+package containers
+
+import "errors"
+
+// readProcCgroup is only supported on Linux.
+func readProcCgroup(_ int) ([]byte, error) {
+	return nil, errors.New("cgroups are only supported on Linux")
+}
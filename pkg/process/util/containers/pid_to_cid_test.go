@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package containers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	outerCID = "0c3f1e5a9a2b7d4e6f8a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e"
+	innerCID = "9f8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c5b4a39281706f5e4d3c2b1a0"
+)
+
+func TestPidToCidBuilder(t *testing.T) {
+	cgroups := map[int]string{
+		// nested container, cgroupv2
+		3: "0::/system.slice/docker-" + outerCID + ".scope/docker/" + innerCID + "\n",
+		// nested container, cgroupv1
+		4: "12:memory:/docker/" + outerCID + "/docker/" + innerCID + "\n11:cpu,cpuacct:/docker/" + outerCID + "/docker/" + innerCID + "\n",
+	}
+
+	builder := newPidToCidBuilder()
+	builder.readCgroup = func(pid int) ([]byte, error) {
+		if content, found := cgroups[pid]; found {
+			return []byte(content), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	builder.add(innerCID, []int{3, 4, 5})
+	builder.add(outerCID, []int{1, 2, 3, 4, 5, 6})
+	builder.add("other", []int{7})
+
+	assert.Equal(t, map[int]string{
+		1: outerCID,
+		2: outerCID,
+		3: innerCID,
+		4: innerCID,
+		// cgroups not readable, resolved by the number of processes of the containers
+		5: innerCID,
+		6: outerCID,
+		7: "other",
+	}, builder.build())
+}
+
+func TestPidToCidBuilderUnresolved(t *testing.T) {
+	builder := newPidToCidBuilder()
+	builder.readCgroup = func(int) ([]byte, error) {
+		return []byte("0::/\n"), nil
+	}
+
+	builder.add("b", []int{1})
+	builder.add("a", []int{1})
+
+	cid, resolution := builder.innermostContainer(1, builder.candidates[1])
+	assert.Equal(t, "a", cid)
+	assert.Equal(t, unresolvedPidConflict, resolution)
+	assert.Equal(t, map[int]string{1: "a"}, builder.build())
+}
+
+func TestInnermostContainerInCgroups(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cgroups  string
+		expected string
+	}{
+		{
+			name:     "nested",
+			cgroups:  "0::/kubepods/pod1/" + outerCID + "/docker/" + innerCID,
+			expected: innerCID,
+		},
+		{
+			name:     "outer only",
+			cgroups:  "0::/kubepods/pod1/" + outerCID + "/init.scope",
+			expected: outerCID,
+		},
+		{
+			name:     "no container",
+			cgroups:  "0::/system.slice/sshd.service",
+			expected: "",
+		},
+		{
+			name:     "malformed",
+			cgroups:  "garbage\n",
+			expected: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, innermostContainerInCgroups([]byte(tc.cgroups), []string{outerCID, innerCID}))
+		})
+	}
+}
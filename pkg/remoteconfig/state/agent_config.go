@@ -27,6 +27,9 @@ type AgentConfig struct {
 // ConfigContent contains the configurations set by remote-config
 type ConfigContent struct {
 	LogLevel string `json:"log_level"`
+	// LogLevelTTL is the number of seconds after which the log level is
+	// reverted to its previous value. It is never reverted when zero.
+	LogLevelTTL int64 `json:"log_level_ttl,omitempty"`
 }
 
 type agentConfigData struct {
@@ -146,12 +149,14 @@ func MergeRCAgentConfig(applyStatus func(cfgPath string, status ApplyStatus), up
 	for i := len(orderFile.Config.Order) - 1; i >= 0; i-- {
 		if layer, found := parsedLayers[orderFile.Config.Order[i]]; found {
 			mergedConfig.LogLevel = layer.Config.Config.LogLevel
+			mergedConfig.LogLevelTTL = layer.Config.Config.LogLevelTTL
 		}
 	}
 	// Same for internal config
 	for i := len(orderFile.Config.InternalOrder) - 1; i >= 0; i-- {
 		if layer, found := parsedLayers[orderFile.Config.InternalOrder[i]]; found {
 			mergedConfig.LogLevel = layer.Config.Config.LogLevel
+			mergedConfig.LogLevelTTL = layer.Config.Config.LogLevelTTL
 		}
 	}
 
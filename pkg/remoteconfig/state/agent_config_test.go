@@ -17,3 +17,23 @@ func TestMergeRCConfigWithEmptyData(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, ConfigContent{}, content)
 }
+
+func TestMergeRCConfigLogLevelTTL(t *testing.T) {
+	emptyUpdateStatus := func(_ string, _ ApplyStatus) {}
+
+	content, err := MergeRCAgentConfig(emptyUpdateStatus, map[string]RawConfig{
+		"datadog/2/AGENT_CONFIG/layer1/configname":              {Config: []byte(`{"name": "layer1", "config": {"log_level": "info"}}`)},
+		"datadog/2/AGENT_CONFIG/layer2/configname":              {Config: []byte(`{"name": "layer2", "config": {"log_level": "debug", "log_level_ttl": 1800}}`)},
+		"datadog/2/AGENT_CONFIG/configuration_order/configname": {Config: []byte(`{"order": ["layer1", "layer2"]}`)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ConfigContent{LogLevel: "info"}, content)
+
+	content, err = MergeRCAgentConfig(emptyUpdateStatus, map[string]RawConfig{
+		"datadog/2/AGENT_CONFIG/layer1/configname":              {Config: []byte(`{"name": "layer1", "config": {"log_level": "info"}}`)},
+		"datadog/2/AGENT_CONFIG/layer2/configname":              {Config: []byte(`{"name": "layer2", "config": {"log_level": "debug", "log_level_ttl": 1800}}`)},
+		"datadog/2/AGENT_CONFIG/configuration_order/configname": {Config: []byte(`{"order": ["layer2", "layer1"]}`)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ConfigContent{LogLevel: "debug", LogLevelTTL: 1800}, content)
+}
@@ -20,6 +20,7 @@ var validProducts = map[string]struct{}{
 	ProductCWSCustom:                    {},
 	ProductCWSProfiles:                  {},
 	ProductCSMSideScanning:              {},
+	ProductComplianceCustom:             {},
 	ProductASM:                          {},
 	ProductASMFeatures:                  {},
 	ProductASMDD:                        {},
@@ -73,6 +74,8 @@ const (
 	ProductCWSProfiles = "CWS_SECURITY_PROFILES"
 	// ProductCSMSideScanning is the side scanning product
 	ProductCSMSideScanning = "CSM_SIDE_SCANNING"
+	// ProductComplianceCustom is the compliance product receiving benchmarks managed by datadog customers
+	ProductComplianceCustom = "COMPLIANCE_CUSTOM"
 	// ProductASM is the ASM product used by customers to issue rules configurations
 	ProductASM = "ASM"
 	// ProductASMFeatures is the ASM product used form ASM activation through remote config
@@ -193,6 +193,8 @@ type RuntimeSecurityConfig struct {
 	SelfTestEnabled bool
 	// SelfTestSendReport defines if a self test event will be emitted
 	SelfTestSendReport bool
+	// SelfTestSchedule defines the period at which the self tests are run once they passed at startup
+	SelfTestSchedule time.Duration
 	// RemoteConfigurationEnabled defines whether to use remote monitoring
 	RemoteConfigurationEnabled bool
 	// RemoteConfigurationDumpPolicies defines whether to dump remote config policy
@@ -356,6 +358,9 @@ type RuntimeSecurityConfig struct {
 	HashResolverCacheSize int
 	// HashResolverReplace is used to apply specific hash to specific file path
 	HashResolverReplace map[string]string
+	// HashResolverAsyncQueueSize defines the number of hash actions that can wait to be computed outside of the event
+	// path. When set to 0, the hash actions are computed synchronously
+	HashResolverAsyncQueueSize int
 
 	// SysCtlEnabled defines if the sysctl event should be enabled
 	SysCtlEnabled bool
@@ -370,6 +375,8 @@ type RuntimeSecurityConfig struct {
 
 	// UserSessionsCacheSize defines the size of the User Sessions cache size
 	UserSessionsCacheSize int
+	// UserSessionsLoginSessionsEnabled defines if the sshd, sudo and su login sessions should be tracked
+	UserSessionsLoginSessionsEnabled bool
 
 	// EBPFLessEnabled enables the ebpfless probe
 	EBPFLessEnabled bool
@@ -418,6 +425,19 @@ type RuntimeSecurityConfig struct {
 	// IMDSIPv4 is used to provide a custom IP address for the IMDS endpoint
 	IMDSIPv4 uint32
 
+	// DNSExfiltrationEnabled defines if the DNS exfiltration heuristics should be computed
+	DNSExfiltrationEnabled bool
+	// DNSExfiltrationEntropyThreshold defines the subdomain entropy, in bits per character, above which the entropy heuristic fires
+	DNSExfiltrationEntropyThreshold float64
+	// DNSExfiltrationLabelLengthThreshold defines the label length above which the label length heuristic fires
+	DNSExfiltrationLabelLengthThreshold int
+	// DNSExfiltrationQueryRateThreshold defines the number of DNS requests per process and per window above which the query rate heuristic fires
+	DNSExfiltrationQueryRateThreshold int
+	// DNSExfiltrationWindow defines the window used to compute the DNS query rate of a process
+	DNSExfiltrationWindow time.Duration
+	// DNSExfiltrationMinScore defines the number of heuristics that need to fire for a DNS exfiltration event to be sent
+	DNSExfiltrationMinScore int
+
 	// SendPayloadsFromSystemProbe defines when the event and activity dumps are sent directly from system-probe
 	SendPayloadsFromSystemProbe bool
 
@@ -509,6 +529,7 @@ func NewRuntimeSecurityConfig() (*RuntimeSecurityConfig, error) {
 
 		SelfTestEnabled:                 pkgconfigsetup.SystemProbe().GetBool("runtime_security_config.self_test.enabled"),
 		SelfTestSendReport:              pkgconfigsetup.SystemProbe().GetBool("runtime_security_config.self_test.send_report"),
+		SelfTestSchedule:                pkgconfigsetup.SystemProbe().GetDuration("runtime_security_config.self_test.schedule"),
 		RemoteConfigurationEnabled:      isRemoteConfigEnabled(),
 		RemoteConfigurationDumpPolicies: pkgconfigsetup.SystemProbe().GetBool("runtime_security_config.remote_configuration.dump_policies"),
 
@@ -569,6 +590,7 @@ func NewRuntimeSecurityConfig() (*RuntimeSecurityConfig, error) {
 		HashResolverHashAlgorithms: parseHashAlgorithmStringSlice(pkgconfigsetup.SystemProbe().GetStringSlice("runtime_security_config.hash_resolver.hash_algorithms")),
 		HashResolverMaxHashRate:    pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.hash_resolver.max_hash_rate"),
 		HashResolverCacheSize:      pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.hash_resolver.cache_size"),
+		HashResolverAsyncQueueSize: pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.hash_resolver.async_queue_size"),
 		HashResolverReplace:        pkgconfigsetup.SystemProbe().GetStringMapString("runtime_security_config.hash_resolver.replace"),
 
 		// SysCtl config parameter
@@ -619,7 +641,8 @@ func NewRuntimeSecurityConfig() (*RuntimeSecurityConfig, error) {
 		EnforcementDisarmerExecutablePeriod:     pkgconfigsetup.SystemProbe().GetDuration("runtime_security_config.enforcement.disarmer.executable.period"),
 
 		// User Sessions
-		UserSessionsCacheSize: pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.user_sessions.cache_size"),
+		UserSessionsCacheSize:            pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.user_sessions.cache_size"),
+		UserSessionsLoginSessionsEnabled: pkgconfigsetup.SystemProbe().GetBool("runtime_security_config.user_sessions.login_sessions.enabled"),
 
 		// ebpf less
 		EBPFLessEnabled: IsEBPFLessModeEnabled(),
@@ -628,6 +651,14 @@ func NewRuntimeSecurityConfig() (*RuntimeSecurityConfig, error) {
 		// IMDS
 		IMDSIPv4: parseIMDSIPv4(),
 
+		// DNS exfiltration
+		DNSExfiltrationEnabled:              pkgconfigsetup.SystemProbe().GetBool("runtime_security_config.dns_exfiltration.enabled"),
+		DNSExfiltrationEntropyThreshold:     pkgconfigsetup.SystemProbe().GetFloat64("runtime_security_config.dns_exfiltration.entropy_threshold"),
+		DNSExfiltrationLabelLengthThreshold: pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.dns_exfiltration.label_length_threshold"),
+		DNSExfiltrationQueryRateThreshold:   pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.dns_exfiltration.query_rate_threshold"),
+		DNSExfiltrationWindow:               pkgconfigsetup.SystemProbe().GetDuration("runtime_security_config.dns_exfiltration.window"),
+		DNSExfiltrationMinScore:             pkgconfigsetup.SystemProbe().GetInt("runtime_security_config.dns_exfiltration.min_score"),
+
 		// direct sender
 		SendPayloadsFromSystemProbe: pkgconfigsetup.SystemProbe().GetBool("runtime_security_config.direct_send_from_system_probe"),
 
@@ -78,6 +78,11 @@ const (
 	FailedDNSRuleID = "failed_dns"
 	// FailedDNSRuleDesc is the rule description for raw packet action events
 	FailedDNSRuleDesc = "Failed DNS"
+
+	// DNSExfiltrationRuleID is the rule ID for the dns_exfiltration events
+	DNSExfiltrationRuleID = "dns_exfiltration"
+	// DNSExfiltrationRuleDesc is the rule description for the dns_exfiltration events
+	DNSExfiltrationRuleDesc = "DNS exfiltration heuristics fired"
 )
 
 // AgentContainerContext is like model.ContainerContext, but without event based resolvers
@@ -44,6 +44,7 @@ var (
 		EBPFLessHelloMessageRuleID:      rate.Inf, // No limit on hello message
 		InternalCoreDumpRuleID:          rate.Every(30 * time.Second),
 		FailedDNSRuleID:                 rate.Every(30 * time.Second),
+		DNSExfiltrationRuleID:           rate.Every(30 * time.Second),
 	}
 )
 
@@ -412,6 +412,9 @@ var (
 	// MetricSelfTest is the name of the metric used to report that a self test was performed
 	// Tags: - success, fails
 	MetricSelfTest = newRuntimeMetric(".self_test")
+	// MetricSelfTestHealth is the name of the metric used to report the result of each self test, 1 if it passed, 0 otherwise
+	// Tags: rule_id
+	MetricSelfTestHealth = newRuntimeMetric(".self_test.health")
 	// MetricTCProgram is the name of the metric used to report the count of active TC programs
 	// Tags: -
 	MetricTCProgram = newRuntimeMetric(".tc_program")
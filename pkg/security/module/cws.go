@@ -38,10 +38,9 @@ import (
 
 const (
 	// selftest
-	selftestMaxRetry    = 25 // more than 5 minutes so that we can get host tags
-	selftestStartAfter  = 15 * time.Second
-	selftestDelay       = 15 * time.Second
-	selftestPassedDelay = 60 * time.Minute
+	selftestMaxRetry   = 25 // more than 5 minutes so that we can get host tags
+	selftestStartAfter = 15 * time.Second
+	selftestDelay      = 15 * time.Second
 )
 
 // CWSConsumer represents the system-probe module for the runtime security agent
@@ -126,7 +125,7 @@ func NewCWSConsumer(evm *eventmonitor.EventMonitor, cfg *config.RuntimeSecurityC
 		listeners = append(listeners, selfTester)
 	}
 
-	c.ruleEngine, err = rulesmodule.NewRuleEngine(evm, cfg, evm.Probe, c.rateLimiter, c.apiServer, c, c.statsdClient, ipc, listeners...)
+	c.ruleEngine, err = rulesmodule.NewRuleEngine(evm, cfg, evm.Probe, c.rateLimiter, c.apiServer, c, c.statsdClient, ipc, wmeta, listeners...)
 	if err != nil {
 		return nil, err
 	}
@@ -201,21 +200,27 @@ func (c *CWSConsumer) Start() error {
 
 		seclog.Debugf("self-test results : success : %v, failed : %v, run %d", success, fails, c.selfTestCount)
 
-		delay := selftestDelay
-		if c.selfTestPassed {
-			delay = selftestPassedDelay
+		if len(fails) == 0 {
+			c.selfTestPassed = true
 		}
 
-		if !c.selfTestPassed && c.selfTestCount == selftestMaxRetry {
+		// retry quickly until the self tests pass once, so that we can get host tags,
+		// then follow the configured schedule
+		delay := selftestDelay
+		if c.selfTestPassed || c.selfTestCount >= selftestMaxRetry {
 			c.reportSelfTest(success, fails)
-		} else if len(fails) == 0 {
-			c.selfTestPassed = true
 
-			c.reportSelfTest(success, fails)
-			delay = selftestPassedDelay
+			if c.config.SelfTestSchedule <= 0 {
+				return
+			}
+			delay = c.config.SelfTestSchedule
 		}
 
-		time.Sleep(delay)
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
 
 		if _, err := c.RunSelfTest(false); err != nil {
 			seclog.Errorf("self-test error: %s", err)
@@ -276,18 +281,33 @@ func (c *CWSConsumer) reportSelfTest(success []eval.RuleID, fails []eval.RuleID)
 		return
 	}
 
-	// send metric with number of success and fails
-	tags := []string{
-		fmt.Sprintf("success:%d", len(success)),
-		fmt.Sprintf("fails:%d", len(fails)),
+	commonTags := []string{
 		fmt.Sprintf("os:%s", runtime.GOOS),
 		fmt.Sprintf("arch:%s", utils.RuntimeArch()),
 		fmt.Sprintf("origin:%s", c.probe.Origin()),
 	}
+
+	// send metric with number of success and fails
+	tags := append([]string{
+		fmt.Sprintf("success:%d", len(success)),
+		fmt.Sprintf("fails:%d", len(fails)),
+	}, commonTags...)
 	if err := c.statsdClient.Gauge(metrics.MetricSelfTest, 1.0, tags, 1.0); err != nil {
 		seclog.Errorf("failed to send self_test metric: %s", err)
 	}
 
+	// send the health of each self test, 1 if the event was detected, 0 otherwise
+	sendHealth := func(ruleIDs []eval.RuleID, value float64) {
+		for _, ruleID := range ruleIDs {
+			tags := append([]string{"rule_id:" + ruleID}, commonTags...)
+			if err := c.statsdClient.Gauge(metrics.MetricSelfTestHealth, value, tags, 1.0); err != nil {
+				seclog.Errorf("failed to send self_test health metric: %s", err)
+			}
+		}
+	}
+	sendHealth(success, 1.0)
+	sendHealth(fails, 0.0)
+
 	// send the custom event with the list of succeed and failed self tests
 	rule, event := selftests.NewSelfTestEvent(c.probe.GetAgentContainerContext(), success, fails)
 	c.SendEvent(rule, event, nil, "")
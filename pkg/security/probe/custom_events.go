@@ -50,6 +50,18 @@ func (e FailedDNSEvent) ToJSON() ([]byte, error) {
 	return utils.MarshalEasyJSON(e)
 }
 
+// DNSExfiltrationEvent is used to report a DNS request for which the DNS exfiltration heuristics fired
+// easyjson:json
+type DNSExfiltrationEvent struct {
+	events.CustomEventCommonFields
+	Event *serializers.EventSerializer `json:"triggering_event"`
+}
+
+// ToJSON marshal using json format
+func (e DNSExfiltrationEvent) ToJSON() ([]byte, error) {
+	return utils.MarshalEasyJSON(e)
+}
+
 // NewAbnormalEvent returns the rule and a populated custom event for an abnormal event
 func NewAbnormalEvent(acc *events.AgentContainerContext, id string, description string, event *model.Event, err error) (*rules.Rule, *events.CustomEvent) {
 	marshalerCtor := func() events.EventMarshaler {
@@ -83,6 +95,23 @@ func NewFailedDNSEvent(acc *events.AgentContainerContext, id string, description
 	return events.NewCustomRule(id, description), events.NewCustomEventLazy(model.CustomEventType, marshalerCtor)
 }
 
+// NewDNSExfiltrationEvent returns the rule and a populated custom event for a DNS request for which the DNS
+// exfiltration heuristics fired
+func NewDNSExfiltrationEvent(acc *events.AgentContainerContext, event *model.Event) (*rules.Rule, *events.CustomEvent) {
+	marshalerCtor := func() events.EventMarshaler {
+		evt := DNSExfiltrationEvent{
+			Event: serializers.NewEventSerializer(event, nil),
+		}
+		evt.FillCustomEventCommonFields(acc)
+		// Overwrite common timestamp with event timestamp
+		evt.Timestamp = event.ResolveEventTime()
+
+		return evt
+	}
+
+	return events.NewCustomRule(events.DNSExfiltrationRuleID, events.DNSExfiltrationRuleDesc), events.NewCustomEventLazy(model.CustomEventType, marshalerCtor)
+}
+
 // EBPFLessHelloMsgEvent defines a hello message
 // easyjson:json
 type EBPFLessHelloMsgEvent struct {
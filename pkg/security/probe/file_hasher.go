@@ -9,6 +9,7 @@
 package probe
 
 import (
+	"context"
 	"slices"
 	"sync"
 	"time"
@@ -33,14 +34,45 @@ type FileHasher struct {
 	resolver *hash.Resolver
 
 	pendingReports []*HashActionReport
+
+	// queue of the reports hashed outside of the event path, nil when hashing synchronously
+	hashQueue chan *HashActionReport
 }
 
 // NewFileHasher returns a new FileHasher
 func NewFileHasher(cfg *config.Config, resolver *hash.Resolver) *FileHasher {
-	return &FileHasher{
+	fh := &FileHasher{
 		cfg:      cfg,
 		resolver: resolver,
 	}
+	if cfg.RuntimeSecurity.HashResolverAsyncQueueSize > 0 {
+		fh.hashQueue = make(chan *HashActionReport, cfg.RuntimeSecurity.HashResolverAsyncQueueSize)
+	}
+	return fh
+}
+
+// Start starts the goroutine computing the hashes outside of the event path
+func (p *FileHasher) Start(ctx context.Context, wg *sync.WaitGroup) {
+	if p.hashQueue == nil {
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case report := <-p.hashQueue:
+				report.Lock()
+				p.resolver.HashFileEvent(report.eventType, report.crtID, report.pid, &report.fileEvent)
+				report.resolved = true
+				report.Unlock()
+			}
+		}
+	}()
 }
 
 // AddPendingReports add a pending reports
@@ -51,9 +83,22 @@ func (p *FileHasher) AddPendingReports(report *HashActionReport) {
 	p.pendingReports = append(p.pendingReports, report)
 }
 
+// hash computes the hashes of the report, or queues it to be hashed outside of the event path. The report must be
+// locked by the caller.
 func (p *FileHasher) hash(report *HashActionReport) {
-	p.resolver.HashFileEvent(report.eventType, report.crtID, report.pid, &report.fileEvent)
-	report.resolved = true
+	if p.hashQueue == nil {
+		p.resolver.HashFileEvent(report.eventType, report.crtID, report.pid, &report.fileEvent)
+		report.resolved = true
+		return
+	}
+
+	select {
+	case p.hashQueue <- report:
+	default:
+		// the queue is full, never block the event path
+		report.fileEvent.HashState = model.HashWasRateLimited
+		report.resolved = true
+	}
 }
 
 // FlushPendingReports flush pending reports
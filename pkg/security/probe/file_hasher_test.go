@@ -0,0 +1,74 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package probe holds probe related files
+package probe
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/security/config"
+	"github.com/DataDog/datadog-agent/pkg/security/resolvers/hash"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+)
+
+func newTestFileHasher(t *testing.T, queueSize int) *FileHasher {
+	cfg := &config.Config{
+		RuntimeSecurity: &config.RuntimeSecurityConfig{
+			HashResolverAsyncQueueSize: queueSize,
+		},
+	}
+
+	resolver, err := hash.NewResolver(cfg.RuntimeSecurity, nil, nil)
+	require.NoError(t, err)
+
+	return NewFileHasher(cfg, resolver)
+}
+
+func TestFileHasherSync(t *testing.T) {
+	fh := newTestFileHasher(t, 0)
+
+	report := &HashActionReport{}
+	report.Lock()
+	fh.hash(report)
+	report.Unlock()
+
+	assert.NoError(t, report.IsResolved())
+}
+
+func TestFileHasherAsync(t *testing.T) {
+	fh := newTestFileHasher(t, 1)
+
+	queued, dropped := &HashActionReport{}, &HashActionReport{}
+	for _, report := range []*HashActionReport{queued, dropped} {
+		report.Lock()
+		fh.hash(report)
+		report.Unlock()
+	}
+
+	// the first report waits in the queue, the second one doesn't fit in it and is marked as rate limited
+	assert.Error(t, queued.IsResolved())
+	assert.NoError(t, dropped.IsResolved())
+	assert.Equal(t, model.HashWasRateLimited, dropped.fileEvent.HashState)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	fh.Start(ctx, &wg)
+
+	assert.Eventually(t, func() bool {
+		return queued.IsResolved() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+}
@@ -61,6 +61,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/security/probe/managerhelper"
 	"github.com/DataDog/datadog-agent/pkg/security/probe/sysctl"
 	"github.com/DataDog/datadog-agent/pkg/security/resolvers"
+	dnsresolver "github.com/DataDog/datadog-agent/pkg/security/resolvers/dns"
 	"github.com/DataDog/datadog-agent/pkg/security/resolvers/mount"
 	"github.com/DataDog/datadog-agent/pkg/security/resolvers/netns"
 	"github.com/DataDog/datadog-agent/pkg/security/resolvers/path"
@@ -177,6 +178,9 @@ type EBPFProbe struct {
 	// hash action
 	fileHasher *FileHasher
 
+	// dns exfiltration heuristics
+	dnsExfiltrationDetector *dnsresolver.ExfiltrationDetector
+
 	// snapshot
 	ruleSetVersion    uint64
 	playSnapShotState *atomic.Bool
@@ -775,6 +779,9 @@ func (p *EBPFProbe) Start() error {
 	// Apply rules to the snapshotted data before starting the event stream to avoid concurrency issues
 	p.playSnapshot(true)
 
+	// start the asynchronous hash computation
+	p.fileHasher.Start(p.ctx, &p.wg)
+
 	// start new tc classifier loop
 	go p.startSetupNewTCClassifierLoop()
 
@@ -1424,6 +1431,13 @@ func (p *EBPFProbe) handleRegularEvent(event *model.Event, offset int, dataLen u
 			}
 		}
 
+		if p.dnsExfiltrationDetector != nil && event.Error == nil {
+			p.dnsExfiltrationDetector.Compute(event.PIDContext.Pid, &event.DNS, event.ResolveEventTime())
+			if int(event.DNS.Exfiltration.Score) >= p.config.RuntimeSecurity.DNSExfiltrationMinScore {
+				p.probe.DispatchCustomEvent(NewDNSExfiltrationEvent(p.GetAgentContainerContext(), event))
+			}
+		}
+
 	case model.FullDNSResponseEventType:
 		if p.config.Probe.DNSResolutionEnabled {
 			if read, err = event.NetworkContext.UnmarshalBinary(data[offset:]); err != nil {
@@ -2885,6 +2899,18 @@ func NewEBPFProbe(probe *Probe, config *config.Config, ipc ipc.Component, opts O
 	}
 	p.processKiller = processKiller
 
+	if config.RuntimeSecurity.DNSExfiltrationEnabled {
+		p.dnsExfiltrationDetector, err = dnsresolver.NewExfiltrationDetector(dnsresolver.ExfiltrationOpts{
+			EntropyThreshold:     config.RuntimeSecurity.DNSExfiltrationEntropyThreshold,
+			LabelLengthThreshold: config.RuntimeSecurity.DNSExfiltrationLabelLengthThreshold,
+			QueryRateThreshold:   config.RuntimeSecurity.DNSExfiltrationQueryRateThreshold,
+			Window:               config.RuntimeSecurity.DNSExfiltrationWindow,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	p.monitors = NewEBPFMonitors(p)
 
 	p.numCPU, err = utils.NumCPU()
@@ -551,6 +551,9 @@ func (p *EBPFLessProbe) Start() error {
 		return err
 	}
 
+	// start the asynchronous hash computation
+	p.fileHasher.Start(p.ctx, &p.wg)
+
 	ch := make(chan clientMsg, 100)
 
 	p.wg.Add(1)
@@ -0,0 +1,61 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package selftests holds selftests related files
+package selftests
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/compiler/eval"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+)
+
+// WindowsExecSelfTest defines a windows process execution self test
+type WindowsExecSelfTest struct {
+	ruleID    eval.RuleID
+	isSuccess bool
+}
+
+// GetRuleDefinition returns the rule
+func (o *WindowsExecSelfTest) GetRuleDefinition() *rules.RuleDefinition {
+	o.ruleID = fmt.Sprintf("%s_windows_exec", ruleIDPrefix)
+
+	return &rules.RuleDefinition{
+		ID:         o.ruleID,
+		Expression: fmt.Sprintf(`exec.file.name == "cmd.exe" && process.ppid == %d`, os.Getpid()),
+		Silent:     true,
+	}
+}
+
+// GenerateEvent generate an event
+func (o *WindowsExecSelfTest) GenerateEvent(ctx context.Context) error {
+	o.isSuccess = false
+
+	cmd := exec.CommandContext(ctx,
+		"cmd.exe",
+		"/c",
+		"exit",
+		"0",
+	)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error executing process: %w", err)
+	}
+
+	return nil
+}
+
+// HandleEvent handles self test events
+func (o *WindowsExecSelfTest) HandleEvent(event selfTestEvent) {
+	o.isSuccess = event.RuleID == o.ruleID
+}
+
+// IsSuccess return the state of the test
+func (o *WindowsExecSelfTest) IsSuccess() bool {
+	return o.isSuccess
+}
@@ -22,31 +22,37 @@ import (
 // NewSelfTester returns a new SelfTester, enabled or not
 func NewSelfTester(cfg *config.RuntimeSecurityConfig, probe *probe.Probe) (*SelfTester, error) {
 
-	if !cfg.FIMEnabled {
-		return nil, fmt.Errorf("FIM is disabled")
+	if !cfg.FIMEnabled && !cfg.RuntimeEnabled {
+		return nil, fmt.Errorf("FIM and runtime are disabled")
 	}
 	var (
 		selfTests []SelfTest
 		tmpDir    string
 	)
 
-	dir, err := CreateTargetDir()
-	if err != nil {
-		return nil, err
-	}
-	tmpDir = dir
-	fileToCreate := "file.txt"
+	if cfg.FIMEnabled {
+		dir, err := CreateTargetDir()
+		if err != nil {
+			return nil, err
+		}
+		tmpDir = dir
+		fileToCreate := "file.txt"
+
+		keyPath := "HKLM:\\SOFTWARE\\Microsoft\\Windows\\CurrentVersion"
 
-	keyPath := "HKLM:\\SOFTWARE\\Microsoft\\Windows\\CurrentVersion"
+		dirLongPath, err := pathutils.GetLongPathName(dir)
+		if err != nil {
+			return nil, err
+		}
 
-	dirLongPath, err := pathutils.GetLongPathName(dir)
-	if err != nil {
-		return nil, err
+		selfTests = append(selfTests,
+			&WindowsCreateFileSelfTest{filename: filepath.Join(dirLongPath, fileToCreate)},
+			&WindowsOpenRegistryKeyTest{keyPath: keyPath},
+		)
 	}
 
-	selfTests = []SelfTest{
-		&WindowsCreateFileSelfTest{filename: filepath.Join(dirLongPath, fileToCreate)},
-		&WindowsOpenRegistryKeyTest{keyPath: keyPath},
+	if cfg.RuntimeEnabled {
+		selfTests = append(selfTests, &WindowsExecSelfTest{})
 	}
 
 	s := &SelfTester{
@@ -27,6 +27,9 @@ func (ctx *CWSPtracerCtx) sendSyscallMsg(process *Process, msg *ebpfless.Syscall
 	msg.PID = uint32(process.Tgid)
 	msg.Timestamp = uint64(time.Now().UnixNano())
 	msg.ContainerID = ctx.containerID
+	if process.ContainerID != "" {
+		msg.ContainerID = process.ContainerID
+	}
 	_ = ctx.sendMsg(&ebpfless.Message{
 		Type:    ebpfless.MessageTypeSyscall,
 		Syscall: msg,
@@ -120,7 +123,24 @@ func (ctx *CWSPtracerCtx) handleClone(flags uint64, process *Process, ppid int)
 	}
 }
 
+// resolveContainerID resolves the container of the process when it executes a new binary, so that the processes started
+// in nested containers are attributed to their own container instead of the traced one
+func (ctx *CWSPtracerCtx) resolveContainerID(process *Process) {
+	containerID, err := getProcContainerID(process.Tgid)
+	if err != nil || containerID == "" {
+		return
+	}
+	if containerID == ctx.containerID {
+		containerID = ""
+	}
+	process.ContainerID = containerID
+}
+
 func (ctx *CWSPtracerCtx) handlePostHooks(nr int, ppid int, regs syscall.PtraceRegs, process *Process, handler syscallHandler) {
+	if nr == ExecveNr || nr == ExecveatNr {
+		ctx.resolveContainerID(process)
+	}
+
 	syscallMsg, msgExists := process.Nr[nr]
 	if msgExists {
 		if handler.RetFunc != nil {
@@ -154,6 +174,7 @@ func (ctx *CWSPtracerCtx) handlePostHooks(nr int, ppid int, regs syscall.PtraceR
 		ctx.handleClone(binary.NativeEndian.Uint64(data), process, ppid)
 	case ForkNr, VforkNr:
 		if parent := ctx.processCache.Get(ppid); parent != nil {
+			process.ContainerID = parent.ContainerID
 			ctx.sendSyscallMsg(process, &ebpfless.SyscallMsg{
 				Type: ebpfless.SyscallTypeFork,
 				Fork: &ebpfless.ForkSyscallMsg{
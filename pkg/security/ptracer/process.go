@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/DataDog/datadog-agent/pkg/security/proto/ebpfless"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/containerutils"
 	"golang.org/x/sys/unix"
 )
 
@@ -69,6 +70,8 @@ type Process struct {
 	FdRes      *FdResources
 	FsRes      *FSResources
 	FdToSocket map[int32]SocketInfo
+	// ContainerID is the container of the process when it differs from the one of the tracer, a nested container
+	ContainerID containerutils.ContainerID
 }
 
 // NewProcess returns a new process
@@ -173,6 +176,7 @@ func (tc *ProcessCache) shareResources(process *Process, ppid int, cloneFlags ui
 	} else {
 		process.FsRes = parent.FsRes.clone()
 	}
+	process.ContainerID = parent.ContainerID
 
 	// re-add to update the caches
 	tc.Add(process.Pid, process)
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -89,20 +90,57 @@ func getContainerIDFromCgroupData(data []byte) (containerutils.ContainerID, erro
 	return "", nil
 }
 
-func getCurrentProcContainerID() (containerutils.ContainerID, error) {
-	data, err := os.ReadFile("/proc/self/cgroup")
+// mountInfoContainerFiles lists the files bind mounted by the container runtimes from the directory of the container
+var mountInfoContainerFiles = []string{"/etc/hostname", "/etc/hosts", "/etc/resolv.conf"}
+
+func getContainerIDFromMountInfoData(data []byte) containerutils.ContainerID {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// 3371 3361 259:1 /var/lib/docker/containers/<id>/hostname /etc/hostname rw,relatime - ext4 /dev/root rw
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || !slices.Contains(mountInfoContainerFiles, fields[4]) {
+			continue
+		}
+
+		// the files of the kubernetes pods are bind mounted from the sandbox, which has its own ID
+		if strings.Contains(fields[3], "/sandboxes/") {
+			continue
+		}
+
+		if cid := containerutils.FindContainerID(containerutils.CGroupID(fields[3])); cid != "" {
+			return cid
+		}
+	}
+	return ""
+}
+
+// getContainerIDFromProcDir resolves the container ID of a process from its cgroups. When the process runs in its own
+// cgroup namespace, with user namespaces for example, its cgroup paths are relative to the namespace and don't contain
+// the container ID anymore: the mount points are used as a fallback.
+func getContainerIDFromProcDir(procDir string) (containerutils.ContainerID, error) {
+	data, err := os.ReadFile(filepath.Join(procDir, "cgroup"))
 	if err != nil {
 		return "", err
 	}
-	return getContainerIDFromCgroupData(data)
-}
 
-func getProcContainerID(pid int) (containerutils.ContainerID, error) {
-	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	cid, err := getContainerIDFromCgroupData(data)
+	if err != nil || cid != "" {
+		return cid, err
+	}
+
+	data, err = os.ReadFile(filepath.Join(procDir, "mountinfo"))
 	if err != nil {
 		return "", err
 	}
-	return getContainerIDFromCgroupData(data)
+	return getContainerIDFromMountInfoData(data), nil
+}
+
+func getCurrentProcContainerID() (containerutils.ContainerID, error) {
+	return getContainerIDFromProcDir("/proc/self")
+}
+
+func getProcContainerID(pid int) (containerutils.ContainerID, error) {
+	return getContainerIDFromProcDir(fmt.Sprintf("/proc/%d", pid))
 }
 
 func getNSID() uint64 {
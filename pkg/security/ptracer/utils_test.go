@@ -45,3 +45,20 @@ func TestContainerID(t *testing.T) {
 		assert.Equal(t, containerutils.ContainerID("8a28a84664034325be01ca46b33d1dd3-4092616770"), cid)
 	})
 }
+
+func TestContainerIDFromMountInfo(t *testing.T) {
+	t.Run("docker", func(t *testing.T) {
+		mountInfo := `3360 3359 0:322 / / rw,relatime - overlay overlay rw,lowerdir=/var/lib/docker/overlay2/l/ABC
+3371 3360 259:1 /var/lib/docker/containers/7022ec9d5774c69f38feddd6460373c4681ef72a4e03bc6f2d374387e9bde981/resolv.conf /etc/resolv.conf rw,relatime - ext4 /dev/root rw
+3372 3360 259:1 /var/lib/docker/containers/7022ec9d5774c69f38feddd6460373c4681ef72a4e03bc6f2d374387e9bde981/hostname /etc/hostname rw,relatime - ext4 /dev/root rw`
+
+		assert.Equal(t, containerutils.ContainerID("7022ec9d5774c69f38feddd6460373c4681ef72a4e03bc6f2d374387e9bde981"), getContainerIDFromMountInfoData([]byte(mountInfo)))
+	})
+
+	t.Run("kubernetes-sandbox", func(t *testing.T) {
+		mountInfo := `1402 1384 259:1 /var/lib/kubelet/pods/c00eb3e2-d6c0-4eb6-9e58-fe539629263f/etc-hosts /etc/hosts rw,relatime - ext4 /dev/root rw
+1403 1384 259:1 /var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/7022ec9d5774c69f38feddd6460373c4681ef72a4e03bc6f2d374387e9bde981/hostname /etc/hostname rw,relatime - ext4 /dev/root rw`
+
+		assert.Empty(t, getContainerIDFromMountInfoData([]byte(mountInfo)))
+	})
+}
@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package dns resolves ip addresses to hostnames
+package dns
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+)
+
+const exfiltrationQueryRateCacheSize = 4096
+
+// ExfiltrationOpts defines the tunables of the DNS exfiltration heuristics
+type ExfiltrationOpts struct {
+	// EntropyThreshold is the subdomain entropy, in bits per character, above which the entropy heuristic fires
+	EntropyThreshold float64
+	// LabelLengthThreshold is the label length above which the label length heuristic fires
+	LabelLengthThreshold int
+	// QueryRateThreshold is the number of requests per process and per window above which the query rate heuristic fires
+	QueryRateThreshold int
+	// Window is the window used to compute the query rate of a process
+	Window time.Duration
+}
+
+type queryRate struct {
+	windowStart time.Time
+	count       uint32
+}
+
+// ExfiltrationDetector computes the DNS exfiltration heuristics of the DNS requests
+type ExfiltrationDetector struct {
+	opts  ExfiltrationOpts
+	rates *lru.Cache[uint32, *queryRate]
+}
+
+// NewExfiltrationDetector returns a new DNS exfiltration detector
+func NewExfiltrationDetector(opts ExfiltrationOpts) (*ExfiltrationDetector, error) {
+	if opts.Window <= 0 {
+		return nil, fmt.Errorf("invalid DNS exfiltration window: %s", opts.Window)
+	}
+
+	rates, err := lru.New[uint32, *queryRate](exfiltrationQueryRateCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize DNS query rate cache: %w", err)
+	}
+
+	return &ExfiltrationDetector{
+		opts:  opts,
+		rates: rates,
+	}, nil
+}
+
+// Compute fills the DNS exfiltration heuristics of a DNS request sent by the given process
+func (d *ExfiltrationDetector) Compute(pid uint32, event *model.DNSEvent, now time.Time) {
+	labels := strings.Split(strings.TrimSuffix(event.Question.Name, "."), ".")
+
+	var maxLabelLength int
+	for _, label := range labels {
+		maxLabelLength = max(maxLabelLength, len(label))
+	}
+
+	// only the subdomain is considered, the registered domain is usually not controlled by the sender
+	var subdomain string
+	if len(labels) > 2 {
+		subdomain = strings.Join(labels[:len(labels)-2], "")
+	}
+	entropy := shannonEntropy(subdomain)
+
+	rate, ok := d.rates.Get(pid)
+	if !ok || now.Sub(rate.windowStart) >= d.opts.Window {
+		rate = &queryRate{windowStart: now}
+		d.rates.Add(pid, rate)
+	}
+	rate.count++
+
+	var score uint8
+	if entropy >= d.opts.EntropyThreshold {
+		score++
+	}
+	if maxLabelLength >= d.opts.LabelLengthThreshold {
+		score++
+	}
+	if int(rate.count) >= d.opts.QueryRateThreshold {
+		score++
+	}
+
+	event.Exfiltration = model.DNSExfiltration{
+		Entropy:        uint16(entropy * 1000),
+		MaxLabelLength: uint16(maxLabelLength),
+		QueryRate:      rate.count,
+		Score:          score,
+	}
+}
+
+// shannonEntropy returns the Shannon entropy of the given string, in bits per character
+func shannonEntropy(s string) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(len(s))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
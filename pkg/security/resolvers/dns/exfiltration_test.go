@@ -0,0 +1,69 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+)
+
+func TestShannonEntropy(t *testing.T) {
+	assert.Equal(t, 0.0, shannonEntropy(""))
+	assert.Equal(t, 0.0, shannonEntropy("aaaa"))
+	assert.Equal(t, 1.0, shannonEntropy("abab"))
+	assert.Equal(t, 2.0, shannonEntropy("abcd"))
+}
+
+func TestExfiltrationDetector(t *testing.T) {
+	detector, err := NewExfiltrationDetector(ExfiltrationOpts{
+		EntropyThreshold:     3.5,
+		LabelLengthThreshold: 40,
+		QueryRateThreshold:   3,
+		Window:               time.Minute,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	t.Run("regular", func(t *testing.T) {
+		event := &model.DNSEvent{Question: model.DNSQuestion{Name: "www.datadoghq.com"}}
+		detector.Compute(1, event, now)
+
+		assert.Equal(t, uint16(9), event.Exfiltration.MaxLabelLength)
+		assert.Equal(t, uint32(1), event.Exfiltration.QueryRate)
+		assert.Zero(t, event.Exfiltration.Score)
+	})
+
+	t.Run("encoded payload", func(t *testing.T) {
+		event := &model.DNSEvent{Question: model.DNSQuestion{Name: "mzxw6ytboi2dkmrtgq3dcnzxhe4tambrgiztinjwg4ydsmbr.exfil.example.com"}}
+		detector.Compute(2, event, now)
+
+		assert.Equal(t, uint16(48), event.Exfiltration.MaxLabelLength)
+		assert.Greater(t, event.Exfiltration.Entropy, uint16(3500))
+		assert.Equal(t, uint8(2), event.Exfiltration.Score)
+	})
+
+	t.Run("query rate", func(t *testing.T) {
+		event := &model.DNSEvent{Question: model.DNSQuestion{Name: "www.datadoghq.com"}}
+		for i := 0; i < 3; i++ {
+			detector.Compute(3, event, now)
+		}
+		assert.Equal(t, uint32(3), event.Exfiltration.QueryRate)
+		assert.Equal(t, uint8(1), event.Exfiltration.Score)
+
+		// a new window resets the rate
+		detector.Compute(3, event, now.Add(time.Minute))
+		assert.Equal(t, uint32(1), event.Exfiltration.QueryRate)
+		assert.Zero(t, event.Exfiltration.Score)
+	})
+}
@@ -0,0 +1,103 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package process holds process related files
+package process
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model/usersession"
+)
+
+const sshConnectionEnv = "SSH_CONNECTION="
+
+// loginSessionLeaders maps the comm of the processes opening login sessions to the type of the session they open
+var loginSessionLeaders = map[string]string{
+	"sshd":         "ssh",
+	"sshd-session": "ssh",
+	"sudo":         "sudo",
+	"su":           "su",
+}
+
+// setLoginSession attaches a new login session to the provided exec entry if its parent opened one. sudo and su
+// sessions started from an existing session, an ssh session for example, keep the session of their parent so that
+// the privileged commands can still be attributed to the user that originally logged in.
+func (p *EBPFResolver) setLoginSession(entry *model.ProcessCacheEntry) {
+	if !p.opts.loginSessionsEnabled {
+		return
+	}
+
+	leader := entry.Ancestor
+	if leader == nil {
+		return
+	}
+
+	// ignore the helpers executed by the session leaders themselves, sshd-session for example
+	sessionType, found := loginSessionLeaders[leader.Comm]
+	if !found || loginSessionLeaders[entry.Comm] == sessionType {
+		return
+	}
+
+	if sessionType != "ssh" && entry.UserSession.ID != 0 {
+		return
+	}
+
+	session := model.UserSessionContext{
+		ID:          newLoginSessionID(sessionType, entry),
+		SessionType: int(usersession.UserSessionTypes[sessionType]),
+		Resolved:    true,
+	}
+
+	if sessionType == "ssh" {
+		session.LoginUser = entry.User
+		if entry.EnvsEntry != nil {
+			session.SourceIP, session.SourcePort = parseSSHConnection(entry.EnvsEntry.Values)
+		}
+	} else {
+		// the real uid of sudo and su is the one of the user that invoked them
+		session.LoginUser = leader.User
+	}
+
+	entry.UserSession = session
+}
+
+// newLoginSessionID returns a stable identifier for the login session opened by the provided entry
+func newLoginSessionID(sessionType string, entry *model.ProcessCacheEntry) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(sessionType))
+	_, _ = h.Write(binary.NativeEndian.AppendUint32(nil, entry.Pid))
+	_, _ = h.Write(binary.NativeEndian.AppendUint64(nil, uint64(entry.ExecTime.UnixNano())))
+	return h.Sum64()
+}
+
+// parseSSHConnection returns the client IP and port from the SSH_CONNECTION environment variable set by sshd
+func parseSSHConnection(envs []string) (string, int) {
+	for _, env := range envs {
+		value, found := strings.CutPrefix(env, sshConnectionEnv)
+		if !found {
+			continue
+		}
+
+		// SSH_CONNECTION="<client ip> <client port> <server ip> <server port>"
+		fields := strings.Fields(value)
+		if len(fields) < 2 {
+			return "", 0
+		}
+
+		port, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return fields[0], 0
+		}
+		return fields[0], port
+	}
+	return "", 0
+}
@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package process holds process related files
+package process
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model/usersession"
+)
+
+func TestLoginSessions(t *testing.T) {
+	resolver, err := newResolver()
+	if err != nil {
+		t.Fatal()
+	}
+	resolver.opts.WithLoginSessionsEnabled()
+
+	sshd := newFakeForkEvent(0, 3, 123, resolver)
+	sshd.ProcessCacheEntry.Comm = "sshd"
+	sshdSession := newFakeForkEvent(3, 4, 123, resolver)
+	shell := newFakeExecEvent(3, 4, 456, resolver)
+	shell.ProcessCacheEntry.Comm = "bash"
+	shell.ProcessCacheEntry.User = "alice"
+	shell.ProcessCacheEntry.EnvsEntry = &model.EnvsEntry{
+		Values: []string{"HOME=/home/alice", "SSH_CONNECTION=10.0.0.1 51234 10.0.0.2 22"},
+	}
+
+	// sshd(pid:3)
+	//    |
+	// sshd(pid:4) -- bash(pid:4)
+	resolver.AddForkEntry(sshd, nil)
+	resolver.AddForkEntry(sshdSession, nil)
+	resolver.AddExecEntry(shell)
+
+	session := shell.ProcessCacheEntry.UserSession
+	assert.NotZero(t, session.ID)
+	assert.Equal(t, int(usersession.UserSessionTypes["ssh"]), session.SessionType)
+	assert.Equal(t, "alice", session.LoginUser)
+	assert.Equal(t, "10.0.0.1", session.SourceIP)
+	assert.Equal(t, 51234, session.SourcePort)
+	assert.True(t, session.Resolved)
+	assert.Zero(t, sshdSession.ProcessCacheEntry.UserSession.ID)
+
+	// bash(pid:4)
+	//    |
+	// bash(pid:5) -- sudo(pid:5)
+	//                  |
+	//                sudo(pid:6) -- id(pid:6)
+	shellChild := newFakeForkEvent(4, 5, 456, resolver)
+	sudo := newFakeExecEvent(4, 5, 789, resolver)
+	sudo.ProcessCacheEntry.Comm = "sudo"
+	sudoChild := newFakeForkEvent(5, 6, 789, resolver)
+	id := newFakeExecEvent(5, 6, 1011, resolver)
+	id.ProcessCacheEntry.Comm = "id"
+	id.ProcessCacheEntry.User = "root"
+
+	resolver.AddForkEntry(shellChild, nil)
+	resolver.AddExecEntry(sudo)
+	resolver.AddForkEntry(sudoChild, nil)
+	resolver.AddExecEntry(id)
+
+	assert.Equal(t, session, sudo.ProcessCacheEntry.UserSession)
+	assert.Equal(t, session, sudoChild.ProcessCacheEntry.UserSession)
+	assert.Equal(t, session, id.ProcessCacheEntry.UserSession)
+}
+
+func TestLocalSudoSession(t *testing.T) {
+	resolver, err := newResolver()
+	if err != nil {
+		t.Fatal()
+	}
+	resolver.opts.WithLoginSessionsEnabled()
+
+	sudo := newFakeForkEvent(0, 3, 123, resolver)
+	sudo.ProcessCacheEntry.Comm = "sudo"
+	sudo.ProcessCacheEntry.User = "bob"
+	sudoChild := newFakeForkEvent(3, 4, 123, resolver)
+	id := newFakeExecEvent(3, 4, 456, resolver)
+	id.ProcessCacheEntry.Comm = "id"
+	id.ProcessCacheEntry.User = "root"
+
+	resolver.AddForkEntry(sudo, nil)
+	resolver.AddForkEntry(sudoChild, nil)
+	resolver.AddExecEntry(id)
+
+	session := id.ProcessCacheEntry.UserSession
+	assert.NotZero(t, session.ID)
+	assert.Equal(t, int(usersession.UserSessionTypes["sudo"]), session.SessionType)
+	assert.Equal(t, "bob", session.LoginUser)
+	assert.Empty(t, session.SourceIP)
+}
+
+func TestParseSSHConnection(t *testing.T) {
+	ip, port := parseSSHConnection([]string{"SSH_CONNECTION=fe80::1 2222 fe80::2 22"})
+	assert.Equal(t, "fe80::1", ip)
+	assert.Equal(t, 2222, port)
+
+	ip, port = parseSSHConnection([]string{"SSH_CLIENT=10.0.0.1 51234 22"})
+	assert.Empty(t, ip)
+	assert.Zero(t, port)
+}
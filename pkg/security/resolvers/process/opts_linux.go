@@ -11,6 +11,7 @@ type ResolverOpts struct {
 	ttyFallbackEnabled    bool
 	envsResolutionEnabled bool
 	envsWithValue         map[string]bool
+	loginSessionsEnabled  bool
 }
 
 // WithEnvsValue specifies envs with value
@@ -33,6 +34,12 @@ func (o *ResolverOpts) WithEnvsResolutionEnabled() *ResolverOpts {
 	return o
 }
 
+// WithLoginSessionsEnabled enables the tracking of the sshd, sudo and su login sessions
+func (o *ResolverOpts) WithLoginSessionsEnabled() *ResolverOpts {
+	o.loginSessionsEnabled = true
+	return o
+}
+
 // NewResolverOpts returns a new set of process resolver options
 func NewResolverOpts() *ResolverOpts {
 	return &ResolverOpts{
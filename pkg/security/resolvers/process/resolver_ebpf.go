@@ -656,6 +656,7 @@ func (p *EBPFResolver) insertExecEntry(entry *model.ProcessCacheEntry, inode uin
 			return
 		}
 		prev.Exec(entry)
+		p.setLoginSession(entry)
 	} else {
 		entry.IsParentMissing = true
 	}
@@ -163,6 +163,9 @@ func NewEBPFResolvers(config *config.Config, manager *manager.Manager, statsdCli
 	if opts.EnvVarsResolutionEnabled {
 		processOpts.WithEnvsResolutionEnabled()
 	}
+	if config.RuntimeSecurity.UserSessionsLoginSessionsEnabled {
+		processOpts.WithLoginSessionsEnabled()
+	}
 
 	var envVarsResolver *envvars.Resolver
 	if opts.EnvVarsResolutionEnabled {
@@ -7,14 +7,19 @@
 package autosuppression
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
 	"slices"
 	"strconv"
 	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 
 	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
 	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+	"github.com/DataDog/datadog-agent/pkg/security/seclog"
 )
 
 // booleanTagEquals returns true if the given rule has the given tag set to a boolean and its value matches the given value
@@ -27,7 +32,24 @@ func booleanTagEquals(rule *rules.Rule, tag string, value bool) bool {
 }
 
 func isAllowAutosuppressionRule(rule *rules.Rule) bool {
-	return booleanTagEquals(rule, "allow_autosuppression", true)
+	return rule.Def.AnomalyDetection != nil || booleanTagEquals(rule, "allow_autosuppression", true)
+}
+
+// isProfileStableFor returns true if the profile of the event has been stable long enough for the given rule
+func isProfileStableFor(rule *rules.Rule, event *model.Event) bool {
+	if rule.Def.AnomalyDetection == nil {
+		return true
+	}
+
+	minimumStablePeriod := rule.Def.AnomalyDetection.GetMinimumStablePeriod(event.GetEventType().String())
+	if minimumStablePeriod == 0 {
+		return true
+	}
+
+	if event.SecurityProfileContext.EventTypeState != model.StableEventType || event.TimestampRaw < event.SecurityProfileContext.LastAnomalyNano {
+		return false
+	}
+	return time.Duration(event.TimestampRaw-event.SecurityProfileContext.LastAnomalyNano) >= minimumStablePeriod
 }
 
 const (
@@ -43,6 +65,8 @@ type Opts struct {
 	ActivityDumpEnabled                   bool
 	ActivityDumpAutoSuppressionEnabled    bool
 	EventTypes                            []model.EventType
+	// StateFile is the file used to persist the per-rule auto suppression state across restarts
+	StateFile string
 }
 
 // StatsTags holds tags for auto suppression stats
@@ -57,6 +81,10 @@ type AutoSuppression struct {
 	opts      Opts
 	statsLock sync.RWMutex
 	stats     map[StatsTags]*atomic.Int64
+
+	// firstSeen holds, for each rule defining an auto suppression window, when the rule was first loaded
+	firstSeenLock sync.RWMutex
+	firstSeen     map[string]time.Time
 }
 
 // Init initializes the auto suppression with the given options
@@ -64,12 +92,62 @@ func (as *AutoSuppression) Init(opts Opts) {
 	as.once.Do(func() {
 		as.opts = opts
 		as.stats = make(map[StatsTags]*atomic.Int64)
+		as.firstSeen = make(map[string]time.Time)
+
+		if err := as.loadState(); err != nil {
+			seclog.Warnf("failed to load auto suppression state: %v", err)
+		}
 	})
 }
 
+func (as *AutoSuppression) loadState() error {
+	if as.opts.StateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(as.opts.StateFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, &as.firstSeen)
+}
+
+// saveState (thread unsafe) persists the per-rule auto suppression state
+func (as *AutoSuppression) saveState() error {
+	if as.opts.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(as.firstSeen)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(as.opts.StateFile, data, 0600)
+}
+
+// isInSuppressionWindow returns true if the auto suppression window of the rule, if any, hasn't elapsed yet. The window
+// starts when the rule is first loaded and is not reset by later reloads or restarts: once it has elapsed, the rule is
+// never auto suppressed again.
+func (as *AutoSuppression) isInSuppressionWindow(rule *rules.Rule, now time.Time) bool {
+	if rule.Def.AnomalyDetection == nil || rule.Def.AnomalyDetection.AutoSuppressionWindow.GetDuration() == 0 {
+		return true
+	}
+
+	as.firstSeenLock.RLock()
+	firstSeen, ok := as.firstSeen[rule.ID]
+	as.firstSeenLock.RUnlock()
+
+	return !ok || now.Before(firstSeen.Add(rule.Def.AnomalyDetection.AutoSuppressionWindow.GetDuration()))
+}
+
 // Suppresses returns true if the event should be suppressed for the given rule, false otherwise. It also counts statistics depending on this result
 func (as *AutoSuppression) Suppresses(rule *rules.Rule, event *model.Event) bool {
-	if isAllowAutosuppressionRule(rule) && event.ContainerContext.ContainerID != "" && slices.Contains(as.opts.EventTypes, event.GetEventType()) {
+	if isAllowAutosuppressionRule(rule) && event.ContainerContext.ContainerID != "" && slices.Contains(as.opts.EventTypes, event.GetEventType()) && as.isInSuppressionWindow(rule, time.Now()) {
 		if as.opts.ActivityDumpEnabled && as.opts.ActivityDumpAutoSuppressionEnabled {
 			if event.HasActiveActivityDump() {
 				as.count(rule.ID, activityDumpSuppressionType)
@@ -80,7 +158,7 @@ func (as *AutoSuppression) Suppresses(rule *rules.Rule, event *model.Event) bool
 			}
 		}
 		if as.opts.SecurityProfileEnabled && as.opts.SecurityProfileAutoSuppressionEnabled {
-			if event.IsInProfile() {
+			if event.IsInProfile() && isProfileStableFor(rule, event) {
 				as.count(rule.ID, securityProfileSuppressionType)
 				return true
 			}
@@ -89,6 +167,32 @@ func (as *AutoSuppression) Suppresses(rule *rules.Rule, event *model.Event) bool
 	return false
 }
 
+func (as *AutoSuppression) count(ruleID string, suppressionType string) {
+	as.statsLock.RLock()
+	defer as.statsLock.RUnlock()
+
+	tags := StatsTags{
+		RuleID:          ruleID,
+		SuppressionType: suppressionType,
+	}
+
+	if stat, ok := as.stats[tags]; ok {
+		stat.Inc()
+	}
+}
+
+// GetStats returns the auto suppressions stats
+func (as *AutoSuppression) GetStats() map[StatsTags]int64 {
+	as.statsLock.RLock()
+	defer as.statsLock.RUnlock()
+
+	stats := make(map[StatsTags]int64, len(as.stats))
+	for tags, stat := range as.stats {
+		stats[tags] = stat.Swap(0)
+	}
+	return stats
+}
+
 // Apply resets the auto suppression stats based on the given ruleset
 func (as *AutoSuppression) Apply(ruleSet *rules.RuleSet) {
 	var enabledSuppressionTypes []string
@@ -115,30 +219,31 @@ func (as *AutoSuppression) Apply(ruleSet *rules.RuleSet) {
 	as.statsLock.Lock()
 	as.stats = newStats
 	as.statsLock.Unlock()
+
+	as.applySuppressionWindows(ruleSet, time.Now())
 }
 
-func (as *AutoSuppression) count(ruleID string, suppressionType string) {
-	as.statsLock.RLock()
-	defer as.statsLock.RUnlock()
+// applySuppressionWindows records when the rules defining an auto suppression window were first loaded. The state of
+// rules that are not loaded anymore is kept so that their window isn't reset if they are loaded again later on.
+func (as *AutoSuppression) applySuppressionWindows(ruleSet *rules.RuleSet, now time.Time) {
+	as.firstSeenLock.Lock()
+	defer as.firstSeenLock.Unlock()
 
-	tags := StatsTags{
-		RuleID:          ruleID,
-		SuppressionType: suppressionType,
-	}
+	updated := false
+	for _, rule := range ruleSet.GetRules() {
+		if rule.Def.AnomalyDetection == nil || rule.Def.AnomalyDetection.AutoSuppressionWindow.GetDuration() == 0 {
+			continue
+		}
 
-	if stat, ok := as.stats[tags]; ok {
-		stat.Inc()
+		if _, ok := as.firstSeen[rule.ID]; !ok {
+			as.firstSeen[rule.ID] = now
+			updated = true
+		}
 	}
-}
 
-// GetStats returns the auto suppressions stats
-func (as *AutoSuppression) GetStats() map[StatsTags]int64 {
-	as.statsLock.RLock()
-	defer as.statsLock.RUnlock()
-
-	stats := make(map[StatsTags]int64, len(as.stats))
-	for tags, stat := range as.stats {
-		stats[tags] = stat.Swap(0)
+	if updated {
+		if err := as.saveState(); err != nil {
+			seclog.Warnf("failed to persist auto suppression state: %v", err)
+		}
 	}
-	return stats
 }
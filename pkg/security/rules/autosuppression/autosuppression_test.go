@@ -0,0 +1,122 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package autosuppression holds auto suppression related files
+package autosuppression
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/compiler/eval"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+)
+
+func newTestRule(id string, def *rules.AnomalyDetectionDefinition) *rules.Rule {
+	return &rules.Rule{
+		PolicyRule: &rules.PolicyRule{
+			Def: &rules.RuleDefinition{
+				ID:               id,
+				AnomalyDetection: def,
+			},
+		},
+		Rule: &eval.Rule{
+			ID: id,
+		},
+	}
+}
+
+func TestIsProfileStableFor(t *testing.T) {
+	rule := newTestRule("test_rule", &rules.AnomalyDetectionDefinition{
+		MinimumStablePeriod: &rules.HumanReadableDuration{Duration: time.Hour},
+		MinimumStablePeriods: map[string]*rules.HumanReadableDuration{
+			model.ExecEventType.String(): {Duration: time.Minute},
+		},
+	})
+
+	event := model.NewFakeEvent()
+	event.Type = uint32(model.FileOpenEventType)
+	event.SecurityProfileContext.EventTypeState = model.StableEventType
+	event.SecurityProfileContext.LastAnomalyNano = uint64(time.Second)
+
+	event.TimestampRaw = uint64(30 * time.Minute)
+	assert.False(t, isProfileStableFor(rule, event))
+
+	event.TimestampRaw = uint64(2 * time.Hour)
+	assert.True(t, isProfileStableFor(rule, event))
+
+	event.SecurityProfileContext.EventTypeState = model.AutoLearning
+	assert.False(t, isProfileStableFor(rule, event))
+
+	// the event type specific period takes precedence
+	event.Type = uint32(model.ExecEventType)
+	event.SecurityProfileContext.EventTypeState = model.StableEventType
+	event.TimestampRaw = uint64(2 * time.Minute)
+	assert.True(t, isProfileStableFor(rule, event))
+
+	// rules without anomaly detection settings don't wait for the profile to be stable
+	assert.True(t, isProfileStableFor(newTestRule("other_rule", nil), event))
+}
+
+func TestSuppressionWindowPersistence(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	rule := newTestRule("test_rule", &rules.AnomalyDetectionDefinition{
+		AutoSuppressionWindow: &rules.HumanReadableDuration{Duration: time.Hour},
+	})
+
+	firstSeen := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+
+	var as AutoSuppression
+	as.Init(Opts{StateFile: stateFile})
+	as.firstSeen[rule.ID] = firstSeen
+	require.NoError(t, as.saveState())
+
+	// a new instance, as after a restart, should reload the first seen timestamps
+	var restarted AutoSuppression
+	restarted.Init(Opts{StateFile: stateFile})
+	assert.True(t, firstSeen.Equal(restarted.firstSeen[rule.ID]))
+
+	assert.False(t, restarted.isInSuppressionWindow(rule, time.Now()))
+	assert.True(t, restarted.isInSuppressionWindow(rule, firstSeen.Add(time.Minute)))
+}
+
+func TestSuppressionWindowElapsed(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	opts := Opts{
+		ActivityDumpEnabled:                true,
+		ActivityDumpAutoSuppressionEnabled: true,
+		EventTypes:                         []model.EventType{model.ExecEventType},
+		StateFile:                          stateFile,
+	}
+	rule := newTestRule("test_rule", &rules.AnomalyDetectionDefinition{
+		AutoSuppressionWindow: &rules.HumanReadableDuration{Duration: time.Hour},
+	})
+	ruleWithoutWindow := newTestRule("other_rule", &rules.AnomalyDetectionDefinition{})
+
+	event := model.NewFakeEvent()
+	event.Type = uint32(model.ExecEventType)
+	event.ContainerContext.ContainerID = "0123456789abcdef"
+	event.SecurityProfileContext.EventTypeState = model.NoProfile
+
+	var as AutoSuppression
+	as.Init(opts)
+	as.firstSeen[rule.ID] = time.Now().Add(-time.Minute)
+	require.NoError(t, as.saveState())
+	assert.True(t, as.Suppresses(rule, event))
+
+	// the window started when the rule was first loaded, it isn't reset by a restart
+	as.firstSeen[rule.ID] = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, as.saveState())
+
+	var restarted AutoSuppression
+	restarted.Init(opts)
+	assert.False(t, restarted.Suppresses(rule, event))
+	assert.True(t, restarted.Suppresses(ruleWithoutWindow, event))
+}
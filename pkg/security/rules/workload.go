@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package rules holds rules related files
+package rules
+
+import (
+	workloadmeta "github.com/DataDog/datadog-agent/comp/core/workloadmeta/def"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+	"github.com/DataDog/datadog-agent/pkg/security/secl/rules"
+)
+
+// isWorkloadSelected returns whether the event originates from a workload selected by the rule
+func (e *RuleEngine) isWorkloadSelected(rule *rules.Rule, ev *model.Event) bool {
+	selector := rule.Def.Workload
+	if selector == nil {
+		return true
+	}
+
+	containerID := ev.FieldHandlers.ResolveContainerID(ev, ev.ContainerContext)
+	if containerID == "" || e.wmeta == nil {
+		return false
+	}
+
+	return selector.Matches(resolveWorkloadAttributes(e.wmeta, containerID))
+}
+
+// resolveWorkloadAttributes returns the workload attributes of the given container, as known by workloadmeta
+func resolveWorkloadAttributes(wmeta workloadmeta.Component, containerID string) *rules.WorkloadAttributes {
+	container, err := wmeta.GetContainer(containerID)
+	if err != nil {
+		return nil
+	}
+
+	attrs := &rules.WorkloadAttributes{
+		ImageName: container.Image.ShortName,
+		ImageTag:  container.Image.Tag,
+	}
+
+	if pod, err := wmeta.GetKubernetesPodForContainer(containerID); err == nil {
+		attrs.KubeNamespace = pod.Namespace
+		attrs.PodLabels = pod.Labels
+	}
+
+	return attrs
+}
@@ -1911,6 +1911,50 @@ func (_ *Model) GetEvaluator(field eval.Field, regID eval.RegisterID, offset int
 			Weight: 9999 * eval.HandlerWeight,
 			Offset: offset,
 		}, nil
+	case "dns.exfiltration.entropy":
+		return &eval.IntEvaluator{
+			EvalFnc: func(ctx *eval.Context) int {
+				ctx.AppendResolvedField(field)
+				ev := ctx.Event.(*Event)
+				return int(ev.DNS.Exfiltration.Entropy)
+			},
+			Field:  field,
+			Weight: eval.FunctionWeight,
+			Offset: offset,
+		}, nil
+	case "dns.exfiltration.max_label_length":
+		return &eval.IntEvaluator{
+			EvalFnc: func(ctx *eval.Context) int {
+				ctx.AppendResolvedField(field)
+				ev := ctx.Event.(*Event)
+				return int(ev.DNS.Exfiltration.MaxLabelLength)
+			},
+			Field:  field,
+			Weight: eval.FunctionWeight,
+			Offset: offset,
+		}, nil
+	case "dns.exfiltration.query_rate":
+		return &eval.IntEvaluator{
+			EvalFnc: func(ctx *eval.Context) int {
+				ctx.AppendResolvedField(field)
+				ev := ctx.Event.(*Event)
+				return int(ev.DNS.Exfiltration.QueryRate)
+			},
+			Field:  field,
+			Weight: eval.FunctionWeight,
+			Offset: offset,
+		}, nil
+	case "dns.exfiltration.score":
+		return &eval.IntEvaluator{
+			EvalFnc: func(ctx *eval.Context) int {
+				ctx.AppendResolvedField(field)
+				ev := ctx.Event.(*Event)
+				return int(ev.DNS.Exfiltration.Score)
+			},
+			Field:  field,
+			Weight: eval.FunctionWeight,
+			Offset: offset,
+		}, nil
 	case "dns.id":
 		return &eval.IntEvaluator{
 			EvalFnc: func(ctx *eval.Context) int {
@@ -34227,6 +34271,10 @@ func (ev *Event) GetFields() []eval.Field {
 		"container.created_at",
 		"container.id",
 		"container.tags",
+		"dns.exfiltration.entropy",
+		"dns.exfiltration.max_label_length",
+		"dns.exfiltration.query_rate",
+		"dns.exfiltration.score",
 		"dns.id",
 		"dns.question.class",
 		"dns.question.count",
@@ -36520,6 +36568,14 @@ func (ev *Event) GetFieldMetadata(field eval.Field) (eval.EventType, reflect.Kin
 		return "", reflect.String, "string", nil
 	case "container.tags":
 		return "", reflect.String, "string", nil
+	case "dns.exfiltration.entropy":
+		return "dns", reflect.Int, "int", nil
+	case "dns.exfiltration.max_label_length":
+		return "dns", reflect.Int, "int", nil
+	case "dns.exfiltration.query_rate":
+		return "dns", reflect.Int, "int", nil
+	case "dns.exfiltration.score":
+		return "dns", reflect.Int, "int", nil
 	case "dns.id":
 		return "dns", reflect.Int, "int", nil
 	case "dns.question.class":
@@ -40836,6 +40892,14 @@ func (ev *Event) SetFieldValue(field eval.Field, value interface{}) error {
 			ev.BaseEvent.ContainerContext = &ContainerContext{}
 		}
 		return ev.setStringArrayFieldValue("container.tags", &ev.BaseEvent.ContainerContext.Tags, value)
+	case "dns.exfiltration.entropy":
+		return ev.setUint16FieldValue("dns.exfiltration.entropy", &ev.DNS.Exfiltration.Entropy, value)
+	case "dns.exfiltration.max_label_length":
+		return ev.setUint16FieldValue("dns.exfiltration.max_label_length", &ev.DNS.Exfiltration.MaxLabelLength, value)
+	case "dns.exfiltration.query_rate":
+		return ev.setUint32FieldValue("dns.exfiltration.query_rate", &ev.DNS.Exfiltration.QueryRate, value)
+	case "dns.exfiltration.score":
+		return ev.setUint8FieldValue("dns.exfiltration.score", &ev.DNS.Exfiltration.Score, value)
 	case "dns.id":
 		return ev.setUint16FieldValue("dns.id", &ev.DNS.ID, value)
 	case "dns.question.class":
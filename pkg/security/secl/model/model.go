@@ -66,11 +66,12 @@ func (c *ContainerContext) ParentScope() (eval.VariableScope, bool) {
 
 // SecurityProfileContext holds the security context of the profile
 type SecurityProfileContext struct {
-	Name           string                     `field:"name"`        // SECLDoc[name] Definition:`Name of the security profile`
-	Version        string                     `field:"version"`     // SECLDoc[version] Definition:`Version of the security profile`
-	Tags           []string                   `field:"tags"`        // SECLDoc[tags] Definition:`Tags of the security profile`
-	EventTypes     []EventType                `field:"event_types"` // SECLDoc[event_types] Definition:`Event types enabled for the security profile`
-	EventTypeState EventFilteringProfileState `field:"-"`           // State of the event type in this profile
+	Name            string                     `field:"name"`        // SECLDoc[name] Definition:`Name of the security profile`
+	Version         string                     `field:"version"`     // SECLDoc[version] Definition:`Version of the security profile`
+	Tags            []string                   `field:"tags"`        // SECLDoc[tags] Definition:`Tags of the security profile`
+	EventTypes      []EventType                `field:"event_types"` // SECLDoc[event_types] Definition:`Event types enabled for the security profile`
+	EventTypeState  EventFilteringProfileState `field:"-"`           // State of the event type in this profile
+	LastAnomalyNano uint64                     `field:"-"`           // Timestamp of the last anomaly of the event type in this profile
 }
 
 // IPPortContext is used to hold an IP and Port
@@ -306,6 +307,10 @@ type UserSessionContext struct {
 	K8SUID      string              `field:"k8s_uid,handler:ResolveK8SUID" json:"uid,omitempty"`                // SECLDoc[k8s_uid] Definition:`Kubernetes UID of the user that executed the process`
 	K8SGroups   []string            `field:"k8s_groups,handler:ResolveK8SGroups" json:"groups,omitempty"`       // SECLDoc[k8s_groups] Definition:`Kubernetes groups of the user that executed the process`
 	K8SExtra    map[string][]string `json:"extra,omitempty"`
+	// Login User Session context, tracked in user space for sshd, sudo and su sessions
+	LoginUser  string `field:"-" json:"-"`
+	SourceIP   string `field:"-" json:"-"`
+	SourcePort int    `field:"-" json:"-"`
 }
 
 // MatchedRule contains the identification of one rule that has match
@@ -579,11 +584,20 @@ type DNSQuestion struct {
 	Count uint16 `field:"count"`                                                  // SECLDoc[count] Definition:`the total count of questions in the DNS request`
 }
 
+// DNSExfiltration represents the DNS exfiltration heuristics computed for a DNS request
+type DNSExfiltration struct {
+	Entropy        uint16 `field:"entropy"`          // SECLDoc[entropy] Definition:`[Experimental] Shannon entropy of the subdomain of the queried domain name, in thousandths of bit per character`
+	MaxLabelLength uint16 `field:"max_label_length"` // SECLDoc[max_label_length] Definition:`[Experimental] Length of the longest label of the queried domain name`
+	QueryRate      uint32 `field:"query_rate"`       // SECLDoc[query_rate] Definition:`[Experimental] Number of DNS requests sent by the process during the current heuristics window`
+	Score          uint8  `field:"score"`            // SECLDoc[score] Definition:`[Experimental] Number of DNS exfiltration heuristics that fired for the DNS request`
+}
+
 // DNSEvent represents a DNS request event
 type DNSEvent struct {
-	ID       uint16       `field:"id"` // SECLDoc[id] Definition:`[Experimental] the DNS request ID`
-	Question DNSQuestion  `field:"question"`
-	Response *DNSResponse `field:"response,check:HasResponse"`
+	ID           uint16          `field:"id"` // SECLDoc[id] Definition:`[Experimental] the DNS request ID`
+	Question     DNSQuestion     `field:"question"`
+	Response     *DNSResponse    `field:"response,check:HasResponse"`
+	Exfiltration DNSExfiltration `field:"exfiltration"`
 }
 
 // FailedDNSEvent represents a DNS packet that was failed to be decoded (inbound or outbound)
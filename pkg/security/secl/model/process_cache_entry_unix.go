@@ -90,6 +90,16 @@ func copyProcessContext(parent, child *ProcessCacheEntry) {
 
 	// AUIDs should be inherited just like container IDs
 	child.Credentials.AUID = parent.Credentials.AUID
+
+	inheritUserSession(parent, child)
+}
+
+// inheritUserSession propagates the user session of the parent to the child. The login sessions are tracked in user
+// space only, the kernel doesn't know about them and will report an empty user session for the child.
+func inheritUserSession(parent, child *ProcessCacheEntry) {
+	if parent.UserSession.ID != 0 && child.UserSession.ID == 0 {
+		child.UserSession = parent.UserSession
+	}
 }
 
 // ApplyExecTimeOf replace previous entry values by the given one
@@ -174,6 +184,7 @@ func (pc *ProcessCacheEntry) Fork(childEntry *ProcessCacheEntry) {
 	childEntry.Credentials = pc.Credentials
 	childEntry.LinuxBinprm = pc.LinuxBinprm
 	childEntry.Cookie = pc.Cookie
+	inheritUserSession(pc, childEntry)
 
 	childEntry.SetForkParent(pc)
 }
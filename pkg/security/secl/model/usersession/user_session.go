@@ -12,6 +12,8 @@ var (
 		"unknown": 0,
 		"k8s":     1,
 		"ssh":     2,
+		"sudo":    3,
+		"su":      4,
 	}
 
 	// UserSessionTypeStrings is used to
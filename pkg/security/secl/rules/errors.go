@@ -23,6 +23,9 @@ var (
 	// ErrRuleIDPattern is returned when there is no expression
 	ErrRuleIDPattern = errors.New("rule ID pattern error")
 
+	// ErrRuleEmptyWorkloadSelector is returned when a workload selector doesn't define any constraint
+	ErrRuleEmptyWorkloadSelector = errors.New("empty workload selector")
+
 	// ErrRuleWithoutEvent is returned when no event type was inferred from the rule
 	ErrRuleWithoutEvent = errors.New("no event in the rule definition")
 
@@ -70,22 +70,24 @@ type RuleID = string
 
 // RuleDefinition holds the definition of a rule
 type RuleDefinition struct {
-	ID                     RuleID                 `yaml:"id,omitempty" json:"id"`
-	Version                string                 `yaml:"version,omitempty" json:"version,omitempty"`
-	Expression             string                 `yaml:"expression" json:"expression,omitempty"`
-	Description            string                 `yaml:"description,omitempty" json:"description,omitempty"`
-	Tags                   map[string]string      `yaml:"tags,omitempty" json:"tags,omitempty"`
-	ProductTags            []string               `yaml:"product_tags,omitempty" json:"product_tags,omitempty"`
-	AgentVersionConstraint string                 `yaml:"agent_version,omitempty" json:"agent_version,omitempty"`
-	Filters                []string               `yaml:"filters,omitempty" json:"filters,omitempty"`
-	Disabled               bool                   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
-	Combine                CombinePolicy          `yaml:"combine,omitempty" json:"combine,omitempty" jsonschema:"enum=override"`
-	OverrideOptions        OverrideOptions        `yaml:"override_options,omitempty" json:"override_options,omitempty"`
-	Actions                []*ActionDefinition    `yaml:"actions,omitempty" json:"actions,omitempty"`
-	Every                  *HumanReadableDuration `yaml:"every,omitempty" json:"every,omitempty"`
-	RateLimiterToken       []string               `yaml:"limiter_token,omitempty" json:"limiter_token,omitempty"`
-	Silent                 bool                   `yaml:"silent,omitempty" json:"silent,omitempty"`
-	GroupID                string                 `yaml:"group_id,omitempty" json:"group_id,omitempty"`
+	ID                     RuleID                      `yaml:"id,omitempty" json:"id"`
+	Version                string                      `yaml:"version,omitempty" json:"version,omitempty"`
+	Expression             string                      `yaml:"expression" json:"expression,omitempty"`
+	Description            string                      `yaml:"description,omitempty" json:"description,omitempty"`
+	Tags                   map[string]string           `yaml:"tags,omitempty" json:"tags,omitempty"`
+	ProductTags            []string                    `yaml:"product_tags,omitempty" json:"product_tags,omitempty"`
+	AgentVersionConstraint string                      `yaml:"agent_version,omitempty" json:"agent_version,omitempty"`
+	Filters                []string                    `yaml:"filters,omitempty" json:"filters,omitempty"`
+	Disabled               bool                        `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Combine                CombinePolicy               `yaml:"combine,omitempty" json:"combine,omitempty" jsonschema:"enum=override"`
+	OverrideOptions        OverrideOptions             `yaml:"override_options,omitempty" json:"override_options,omitempty"`
+	Actions                []*ActionDefinition         `yaml:"actions,omitempty" json:"actions,omitempty"`
+	Every                  *HumanReadableDuration      `yaml:"every,omitempty" json:"every,omitempty"`
+	RateLimiterToken       []string                    `yaml:"limiter_token,omitempty" json:"limiter_token,omitempty"`
+	Silent                 bool                        `yaml:"silent,omitempty" json:"silent,omitempty"`
+	GroupID                string                      `yaml:"group_id,omitempty" json:"group_id,omitempty"`
+	Workload               *WorkloadSelector           `yaml:"workload,omitempty" json:"workload,omitempty"`
+	AnomalyDetection       *AnomalyDetectionDefinition `yaml:"anomaly_detection,omitempty" json:"anomaly_detection,omitempty"`
 }
 
 // GetTag returns the tag value associated with a tag key
@@ -97,6 +99,21 @@ func (rd *RuleDefinition) GetTag(tagKey string) (string, bool) {
 	return "", false
 }
 
+// AnomalyDetectionDefinition holds the anomaly detection and auto suppression settings of a rule
+type AnomalyDetectionDefinition struct {
+	MinimumStablePeriod   *HumanReadableDuration            `yaml:"minimum_stable_period,omitempty" json:"minimum_stable_period,omitempty"`
+	MinimumStablePeriods  map[string]*HumanReadableDuration `yaml:"minimum_stable_periods,omitempty" json:"minimum_stable_periods,omitempty"`
+	AutoSuppressionWindow *HumanReadableDuration            `yaml:"auto_suppression_window,omitempty" json:"auto_suppression_window,omitempty"`
+}
+
+// GetMinimumStablePeriod returns the minimum stable period of the given event type, falling back to the default one
+func (ad *AnomalyDetectionDefinition) GetMinimumStablePeriod(eventType string) time.Duration {
+	if period, ok := ad.MinimumStablePeriods[eventType]; ok {
+		return period.GetDuration()
+	}
+	return ad.MinimumStablePeriod.GetDuration()
+}
+
 // ActionName defines an action name
 type ActionName = string
 
@@ -362,6 +362,12 @@ RULES:
 			errs = multierror.Append(errs, rule.Error)
 			continue
 		}
+
+		if ruleDef.Workload != nil && ruleDef.Workload.IsEmpty() {
+			rule.Error = &ErrRuleLoad{Rule: rule, Err: ErrRuleEmptyWorkloadSelector}
+			errs = multierror.Append(errs, rule.Error)
+			continue
+		}
 	}
 
 	return errs.ErrorOrNil()
@@ -0,0 +1,57 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package rules holds rules related files
+package rules
+
+import (
+	"slices"
+)
+
+// WorkloadSelector restricts a rule to the workloads matching all the specified attributes
+type WorkloadSelector struct {
+	ImageNames     []string          `yaml:"image_name,omitempty" json:"image_name,omitempty"`
+	ImageTags      []string          `yaml:"image_tag,omitempty" json:"image_tag,omitempty"`
+	KubeNamespaces []string          `yaml:"kube_namespace,omitempty" json:"kube_namespace,omitempty"`
+	PodLabels      map[string]string `yaml:"pod_labels,omitempty" json:"pod_labels,omitempty"`
+}
+
+// WorkloadAttributes holds the workload attributes a WorkloadSelector is matched against
+type WorkloadAttributes struct {
+	ImageName     string
+	ImageTag      string
+	KubeNamespace string
+	PodLabels     map[string]string
+}
+
+// IsEmpty returns whether the selector doesn't define any constraint
+func (ws *WorkloadSelector) IsEmpty() bool {
+	return len(ws.ImageNames) == 0 && len(ws.ImageTags) == 0 && len(ws.KubeNamespaces) == 0 && len(ws.PodLabels) == 0
+}
+
+// Matches returns whether the given workload attributes are selected. A nil attributes value, used
+// for events that don't originate from a workload, never matches.
+func (ws *WorkloadSelector) Matches(attrs *WorkloadAttributes) bool {
+	if attrs == nil {
+		return false
+	}
+
+	if len(ws.ImageNames) > 0 && !slices.Contains(ws.ImageNames, attrs.ImageName) {
+		return false
+	}
+	if len(ws.ImageTags) > 0 && !slices.Contains(ws.ImageTags, attrs.ImageTag) {
+		return false
+	}
+	if len(ws.KubeNamespaces) > 0 && !slices.Contains(ws.KubeNamespaces, attrs.KubeNamespace) {
+		return false
+	}
+	for key, value := range ws.PodLabels {
+		if label, ok := attrs.PodLabels[key]; !ok || label != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,89 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package rules holds rules related files
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadSelectorMatches(t *testing.T) {
+	attrs := &WorkloadAttributes{
+		ImageName:     "nginx",
+		ImageTag:      "1.27",
+		KubeNamespace: "frontend",
+		PodLabels: map[string]string{
+			"app":  "web",
+			"tier": "edge",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		selector WorkloadSelector
+		attrs    *WorkloadAttributes
+		expected bool
+	}{
+		{
+			name:     "image name",
+			selector: WorkloadSelector{ImageNames: []string{"redis", "nginx"}},
+			attrs:    attrs,
+			expected: true,
+		},
+		{
+			name:     "image name mismatch",
+			selector: WorkloadSelector{ImageNames: []string{"redis"}},
+			attrs:    attrs,
+			expected: false,
+		},
+		{
+			name: "all attributes",
+			selector: WorkloadSelector{
+				ImageNames:     []string{"nginx"},
+				ImageTags:      []string{"1.27"},
+				KubeNamespaces: []string{"frontend"},
+				PodLabels:      map[string]string{"app": "web"},
+			},
+			attrs:    attrs,
+			expected: true,
+		},
+		{
+			name: "namespace mismatch",
+			selector: WorkloadSelector{
+				ImageNames:     []string{"nginx"},
+				KubeNamespaces: []string{"backend"},
+			},
+			attrs:    attrs,
+			expected: false,
+		},
+		{
+			name:     "pod label value mismatch",
+			selector: WorkloadSelector{PodLabels: map[string]string{"app": "api"}},
+			attrs:    attrs,
+			expected: false,
+		},
+		{
+			name:     "missing pod label",
+			selector: WorkloadSelector{PodLabels: map[string]string{"team": "sec"}},
+			attrs:    attrs,
+			expected: false,
+		},
+		{
+			name:     "no workload",
+			selector: WorkloadSelector{ImageNames: []string{"nginx"}},
+			attrs:    nil,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.selector.Matches(test.attrs))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package activitytree holds activitytree related files
+package activitytree
+
+import (
+	"slices"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+)
+
+// Drift describes the activity added and removed between two activity trees
+type Drift struct {
+	AddedProcesses   []string `json:"added_processes,omitempty"`
+	RemovedProcesses []string `json:"removed_processes,omitempty"`
+	AddedFiles       []string `json:"added_files,omitempty"`
+	RemovedFiles     []string `json:"removed_files,omitempty"`
+	AddedSyscalls    []string `json:"added_syscalls,omitempty"`
+	RemovedSyscalls  []string `json:"removed_syscalls,omitempty"`
+}
+
+// IsEmpty returns true if no drift was detected
+func (d *Drift) IsEmpty() bool {
+	return len(d.AddedProcesses) == 0 && len(d.RemovedProcesses) == 0 &&
+		len(d.AddedFiles) == 0 && len(d.RemovedFiles) == 0 &&
+		len(d.AddedSyscalls) == 0 && len(d.RemovedSyscalls) == 0
+}
+
+type activitySet struct {
+	processes map[string]bool
+	files     map[string]bool
+	syscalls  map[string]bool
+}
+
+func (at *ActivityTree) collectActivity() *activitySet {
+	set := &activitySet{
+		processes: make(map[string]bool),
+		files:     make(map[string]bool),
+		syscalls:  make(map[string]bool),
+	}
+
+	at.visit(func(processNode *ProcessNode) {
+		if path := processNode.Process.FileEvent.PathnameStr; path != "" {
+			set.processes[path] = true
+		}
+
+		for _, file := range processNode.Files {
+			at.visitFileNode(file, func(fileNode *FileNode) {
+				if fileNode.File != nil && fileNode.File.PathnameStr != "" {
+					set.files[fileNode.File.PathnameStr] = true
+				}
+			})
+		}
+
+		for _, s := range processNode.Syscalls {
+			set.syscalls[model.Syscall(s.Syscall).String()] = true
+		}
+	})
+
+	return set
+}
+
+// diffKeys returns the sorted keys of a that are not in b
+func diffKeys(a, b map[string]bool) []string {
+	var keys []string
+	for key := range a {
+		if !b[key] {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// ComputeDrift returns the processes, files and syscalls present in the target tree but not in the origin
+// tree, and the other way around. Trees can come from activity dumps as well as from security profiles.
+func ComputeDrift(origin, target *ActivityTree) *Drift {
+	originActivity := origin.collectActivity()
+	targetActivity := target.collectActivity()
+
+	return &Drift{
+		AddedProcesses:   diffKeys(targetActivity.processes, originActivity.processes),
+		RemovedProcesses: diffKeys(originActivity.processes, targetActivity.processes),
+		AddedFiles:       diffKeys(targetActivity.files, originActivity.files),
+		RemovedFiles:     diffKeys(originActivity.files, targetActivity.files),
+		AddedSyscalls:    diffKeys(targetActivity.syscalls, originActivity.syscalls),
+		RemovedSyscalls:  diffKeys(originActivity.syscalls, targetActivity.syscalls),
+	}
+}
@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+// Package activitytree holds activitytree related files
+package activitytree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/security/secl/model"
+)
+
+func newDriftTestProcessNode(path string, files []string, syscalls []model.Syscall) *ProcessNode {
+	pn := &ProcessNode{
+		NodeBase: NewNodeBase(),
+		Files:    make(map[string]*FileNode),
+	}
+	pn.Process.FileEvent.PathnameStr = path
+
+	for _, file := range files {
+		pn.Files[file] = &FileNode{
+			Name: file,
+			File: &model.FileEvent{PathnameStr: file},
+		}
+	}
+	for _, syscall := range syscalls {
+		pn.Syscalls = append(pn.Syscalls, &SyscallNode{Syscall: int(syscall)})
+	}
+
+	return pn
+}
+
+func TestComputeDrift(t *testing.T) {
+	origin := &ActivityTree{
+		ProcessNodes: []*ProcessNode{
+			newDriftTestProcessNode("/usr/bin/nginx", []string{"/etc/nginx/nginx.conf", "/var/log/nginx/access.log"}, []model.Syscall{model.SysOpenat, model.SysExecve}),
+		},
+	}
+	origin.ProcessNodes[0].Children = []*ProcessNode{
+		newDriftTestProcessNode("/bin/sh", nil, nil),
+	}
+
+	target := &ActivityTree{
+		ProcessNodes: []*ProcessNode{
+			newDriftTestProcessNode("/usr/bin/nginx", []string{"/etc/nginx/nginx.conf", "/etc/shadow"}, []model.Syscall{model.SysOpenat, model.SysFchmod}),
+		},
+	}
+	target.ProcessNodes[0].Children = []*ProcessNode{
+		newDriftTestProcessNode("/usr/bin/curl", nil, nil),
+	}
+
+	drift := ComputeDrift(origin, target)
+	assert.Equal(t, []string{"/usr/bin/curl"}, drift.AddedProcesses)
+	assert.Equal(t, []string{"/bin/sh"}, drift.RemovedProcesses)
+	assert.Equal(t, []string{"/etc/shadow"}, drift.AddedFiles)
+	assert.Equal(t, []string{"/var/log/nginx/access.log"}, drift.RemovedFiles)
+	assert.Equal(t, []string{model.SysFchmod.String()}, drift.AddedSyscalls)
+	assert.Equal(t, []string{model.SysExecve.String()}, drift.RemovedSyscalls)
+	assert.False(t, drift.IsEmpty())
+
+	assert.True(t, ComputeDrift(target, target).IsEmpty())
+}
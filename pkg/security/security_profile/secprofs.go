@@ -152,7 +152,7 @@ func (m *Manager) LookupEventInProfiles(event *model.Event) {
 		return
 	case model.AutoLearning, model.WorkloadWarmup:
 		// the event was either already in the profile, or has just been inserted
-		fillProfileContextFromProfile(&event.SecurityProfileContext, profile, imageTag, profileState)
+		fillProfileContextFromProfile(&event.SecurityProfileContext, profile, imageTag, event.GetEventType(), profileState)
 		event.AddToFlags(model.EventFlagsSecurityProfileInProfile)
 
 		return
@@ -175,7 +175,7 @@ func (m *Manager) LookupEventInProfiles(event *model.Event) {
 			event.ResetAnomalyDetectionEvent()
 			return
 		}
-		fillProfileContextFromProfile(&event.SecurityProfileContext, profile, imageTag, profileState)
+		fillProfileContextFromProfile(&event.SecurityProfileContext, profile, imageTag, event.GetEventType(), profileState)
 		if found {
 			event.AddToFlags(model.EventFlagsSecurityProfileInProfile)
 			m.incrementEventFilteringStat(event.GetEventType(), profileState, InProfile)
@@ -248,7 +248,7 @@ func (m *Manager) tryAutolearn(profile *profile.Profile, ctx *profile.VersionCon
 }
 
 // fillProfileContextFromProfile fills the given ctx with profile infos
-func fillProfileContextFromProfile(ctx *model.SecurityProfileContext, p *profile.Profile, imageTag string, state model.EventFilteringProfileState) {
+func fillProfileContextFromProfile(ctx *model.SecurityProfileContext, p *profile.Profile, imageTag string, eventType model.EventType, state model.EventFilteringProfileState) {
 	ctx.Name = p.Metadata.Name
 	if ctx.Name == "" {
 		ctx.Name = DefaultProfileName
@@ -259,6 +259,9 @@ func fillProfileContextFromProfile(ctx *model.SecurityProfileContext, p *profile
 	profileContext, ok := p.GetVersionContext(imageTag)
 	if ok { // should always be the case
 		ctx.Tags = profileContext.Tags
+		if eventTypeState, found := profileContext.EventTypeState[eventType]; found {
+			ctx.LastAnomalyNano = eventTypeState.LastAnomalyNano
+		}
 	}
 }
 
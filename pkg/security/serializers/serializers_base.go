@@ -208,6 +208,21 @@ type DNSEventSerializer struct {
 	Question DNSQuestionSerializer `json:"question"`
 	// response is a DNS response for the DNS request
 	Response *DNSResponseEventSerializer `json:"response"`
+	// exfiltration holds the DNS exfiltration heuristics computed for the DNS request
+	Exfiltration *DNSExfiltrationSerializer `json:"exfiltration,omitempty"`
+}
+
+// DNSExfiltrationSerializer serializes the DNS exfiltration heuristics of a DNS request to JSON
+// easyjson:json
+type DNSExfiltrationSerializer struct {
+	// entropy is the Shannon entropy of the subdomain of the queried domain name, in bits per character
+	Entropy float64 `json:"entropy"`
+	// max_label_length is the length of the longest label of the queried domain name
+	MaxLabelLength uint16 `json:"max_label_length"`
+	// query_rate is the number of DNS requests sent by the process during the current heuristics window
+	QueryRate uint32 `json:"query_rate"`
+	// score is the number of DNS exfiltration heuristics that fired for the DNS request
+	Score uint8 `json:"score"`
 }
 
 // DNSResponseEventSerializer serializes a DNS response event to JSON
@@ -364,6 +379,16 @@ func newDNSEventSerializer(d *model.DNSEvent) *DNSEventSerializer {
 		}
 	}
 
+	// the query rate is at least one once the heuristics are computed
+	if d.Exfiltration.QueryRate > 0 {
+		ret.Exfiltration = &DNSExfiltrationSerializer{
+			Entropy:        float64(d.Exfiltration.Entropy) / 1000,
+			MaxLabelLength: d.Exfiltration.MaxLabelLength,
+			QueryRate:      d.Exfiltration.QueryRate,
+			Score:          d.Exfiltration.Score,
+		}
+	}
+
 	return ret
 }
 
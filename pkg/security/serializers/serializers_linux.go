@@ -227,6 +227,12 @@ type UserSessionContextSerializer struct {
 	K8SGroups []string `json:"k8s_groups,omitempty"`
 	// Extra of the Kubernetes "kubectl exec" session
 	K8SExtra map[string][]string `json:"k8s_extra,omitempty"`
+	// Username of the user that opened the sshd, sudo or su session
+	LoginUser string `json:"login_user,omitempty"`
+	// IP address of the client of the ssh session
+	SourceIP string `json:"source_ip,omitempty"`
+	// Port of the client of the ssh session
+	SourcePort int `json:"source_port,omitempty"`
 }
 
 // ProcessSerializer serializes a process to JSON
@@ -985,6 +991,9 @@ func newUserSessionContextSerializer(ctx *model.UserSessionContext, e *model.Eve
 		K8SUID:      ctx.K8SUID,
 		K8SGroups:   ctx.K8SGroups,
 		K8SExtra:    ctx.K8SExtra,
+		LoginUser:   ctx.LoginUser,
+		SourceIP:    ctx.SourceIP,
+		SourcePort:  ctx.SourcePort,
 	}
 }
 
@@ -59,10 +59,15 @@ func withModule(name sysconfigtypes.ModuleName, fn func()) {
 }
 
 // Register a set of modules, which involves:
+// * Validation of the config namespaces of the modules;
 // * Initialization using the provided Factory;
 // * Registering the HTTP endpoints of each module;
 // * Register the gRPC server;
 func Register(cfg *sysconfigtypes.Config, httpMux *mux.Router, factories []*Factory, rcclient rcclient.Component, deps FactoryDependencies) error {
+	if err := ValidateConfigNamespaces(factories); err != nil {
+		return fmt.Errorf("invalid module config namespaces: %w", err)
+	}
+
 	var enabledModulesFactories []*Factory
 	for _, factory := range factories {
 		if !cfg.ModuleIsEnabled(factory.Name) {
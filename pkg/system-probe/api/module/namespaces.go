@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package module
+
+import (
+	"fmt"
+	"strings"
+
+	sysconfigtypes "github.com/DataDog/datadog-agent/pkg/system-probe/config/types"
+)
+
+// OwnsSetting returns whether a setting belongs to one of the configuration
+// namespaces of the module, either as the namespace itself or nested in it.
+func (f *Factory) OwnsSetting(key string) bool {
+	key = strings.ToLower(key)
+	for _, ns := range f.ConfigNamespaces {
+		if key == ns || strings.HasPrefix(key, ns+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateConfigNamespaces checks that the configuration namespaces of the
+// modules are isolated from each other: a namespace must be a lowercase
+// setting name, and it cannot be registered by two modules or nested in the
+// namespace of another module. Modules sharing a namespace would see the
+// changes of each other's settings.
+func ValidateConfigNamespaces(factories []*Factory) error {
+	owners := make(map[string]sysconfigtypes.ModuleName)
+	for _, factory := range factories {
+		for _, ns := range factory.ConfigNamespaces {
+			if ns == "" || ns != strings.ToLower(ns) || strings.HasPrefix(ns, ".") || strings.HasSuffix(ns, ".") {
+				return fmt.Errorf("module %s: invalid config namespace %q", factory.Name, ns)
+			}
+			for owned, owner := range owners {
+				if ns == owned || strings.HasPrefix(ns, owned+".") || strings.HasPrefix(owned, ns+".") {
+					return fmt.Errorf("module %s: config namespace %q overlaps with namespace %q of module %s", factory.Name, ns, owned, owner)
+				}
+			}
+			owners[ns] = factory.Name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnsSetting(t *testing.T) {
+	factory := &Factory{Name: "network_tracer", ConfigNamespaces: []string{"network_config", "service_monitoring_config"}}
+
+	assert.True(t, factory.OwnsSetting("network_config"))
+	assert.True(t, factory.OwnsSetting("network_config.enabled"))
+	assert.True(t, factory.OwnsSetting("Service_Monitoring_Config.tls.native.enabled"))
+	assert.False(t, factory.OwnsSetting("network_config_extra.enabled"))
+	assert.False(t, factory.OwnsSetting("system_probe_config.log_level"))
+
+	assert.False(t, (&Factory{Name: "ebpf"}).OwnsSetting("ebpf_check.enabled"))
+}
+
+func TestValidateConfigNamespaces(t *testing.T) {
+	testCases := []struct {
+		name      string
+		factories []*Factory
+		err       string
+	}{
+		{
+			name: "isolated namespaces",
+			factories: []*Factory{
+				{Name: "network_tracer", ConfigNamespaces: []string{"network_config", "service_monitoring_config"}},
+				{Name: "ping", ConfigNamespaces: []string{"ping"}},
+				{Name: "ebpf", ConfigNamespaces: []string{}},
+			},
+		},
+		{
+			name: "shared namespace",
+			factories: []*Factory{
+				{Name: "ping", ConfigNamespaces: []string{"ping"}},
+				{Name: "traceroute", ConfigNamespaces: []string{"ping"}},
+			},
+			err: `module traceroute: config namespace "ping" overlaps with namespace "ping" of module ping`,
+		},
+		{
+			name: "nested namespace",
+			factories: []*Factory{
+				{Name: "network_tracer", ConfigNamespaces: []string{"network_config"}},
+				{Name: "traceroute", ConfigNamespaces: []string{"network_config.traceroute"}},
+			},
+			err: `module traceroute: config namespace "network_config.traceroute" overlaps with namespace "network_config" of module network_tracer`,
+		},
+		{
+			name: "invalid namespace",
+			factories: []*Factory{
+				{Name: "ping", ConfigNamespaces: []string{"Ping"}},
+			},
+			err: `module ping: invalid config namespace "Ping"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateConfigNamespaces(tc.factories)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
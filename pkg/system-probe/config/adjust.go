@@ -41,7 +41,7 @@ func Adjust(cfg model.Config) {
 		cfg.Set(netNS("enabled"), true, model.SourceAgentRuntime)
 	}
 
-	validateString(cfg, spNS("sysprobe_socket"), setup.DefaultSystemProbeAddress, ValidateSocketAddress)
+	adjustSysprobeSocket(cfg)
 
 	deprecateBool(cfg, spNS("allow_precompiled_fallback"), spNS("allow_prebuilt_fallback"))
 	allowPrebuiltEbpfFallback(cfg)
@@ -60,6 +60,25 @@ func Adjust(cfg model.Config) {
 	cfg.Set(spNS("adjusted"), true, model.SourceAgentRuntime)
 }
 
+// adjustSysprobeSocket validates the address of the system-probe socket. Its
+// default value is derived from `system_probe_socket` when it is set and valid.
+func adjustSysprobeSocket(cfg model.Config) {
+	defaultSocket := setup.DefaultSystemProbeAddress
+	if socket := cfg.GetString("system_probe_socket"); socket != "" {
+		if err := ValidateSocketAddress(socket); err != nil {
+			log.Errorf("error validating `system_probe_socket`: %s, using default value of `%s`", err, defaultSocket)
+		} else {
+			defaultSocket = socket
+		}
+	}
+
+	if !cfg.IsConfigured(spNS("sysprobe_socket")) {
+		cfg.Set(spNS("sysprobe_socket"), defaultSocket, model.SourceDefault)
+		return
+	}
+	validateString(cfg, spNS("sysprobe_socket"), defaultSocket, ValidateSocketAddress)
+}
+
 // validateString validates the string configuration value at `key` using a custom provided function `valFn`.
 // If `key` is not set or `valFn` returns an error, the `defaultVal` is used instead.
 func validateString(cfg model.Config, key string, defaultVal string, valFn func(string) error) {
@@ -12,7 +12,6 @@ import (
 	"os"
 	"path"
 	"runtime"
-	"strings"
 
 	pkgconfigmodel "github.com/DataDog/datadog-agent/pkg/config/model"
 	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
@@ -59,7 +58,7 @@ func newSysprobeConfig(configPath string, fleetPoliciesDirPath string) (*types.C
 		// add that first, so it's first in line
 		cfg.AddConfigPath(configPath)
 		// If they set a config file directly, let's try to honor that
-		if strings.HasSuffix(configPath, ".yaml") {
+		if pkgconfigmodel.HasConfigFileExtension(configPath) {
 			cfg.SetConfigFile(configPath)
 		}
 	} else {
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/pkg/config/mock"
+	"github.com/DataDog/datadog-agent/pkg/config/setup"
 )
 
 func TestEventMonitor(t *testing.T) {
@@ -96,3 +97,34 @@ func TestEnableDiscovery(t *testing.T) {
 		assert.False(t, cfg.GetBool(discoveryNS("enabled")))
 	})
 }
+
+func TestAdjustSysprobeSocket(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("socket paths are only valid on linux")
+	}
+
+	t.Run("derived from system_probe_socket", func(t *testing.T) {
+		cfg := mock.NewSystemProbe(t)
+		cfg.SetWithoutSource("system_probe_socket", "/custom/run/sysprobe.sock")
+		Adjust(cfg)
+
+		assert.Equal(t, "/custom/run/sysprobe.sock", cfg.GetString(spNS("sysprobe_socket")))
+	})
+
+	t.Run("invalid socket falls back to system_probe_socket", func(t *testing.T) {
+		cfg := mock.NewSystemProbe(t)
+		cfg.SetWithoutSource("system_probe_socket", "/custom/run/sysprobe.sock")
+		cfg.SetWithoutSource(spNS("sysprobe_socket"), "relative/sysprobe.sock")
+		Adjust(cfg)
+
+		assert.Equal(t, "/custom/run/sysprobe.sock", cfg.GetString(spNS("sysprobe_socket")))
+	})
+
+	t.Run("invalid system_probe_socket is ignored", func(t *testing.T) {
+		cfg := mock.NewSystemProbe(t)
+		cfg.SetWithoutSource("system_probe_socket", "relative/sysprobe.sock")
+		Adjust(cfg)
+
+		assert.Equal(t, setup.DefaultSystemProbeAddress, cfg.GetString(spNS("sysprobe_socket")))
+	})
+}
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	pkgconfigsetup "github.com/DataDog/datadog-agent/pkg/config/setup"
+	"github.com/DataDog/datadog-agent/pkg/util/cache"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+var (
+	tagsCacheKey = cache.BuildAgentKey("azure", "GetTags")
+)
+
+type azureTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type azureTagsMetadata struct {
+	TagsList []azureTag `json:"tagsList"`
+}
+
+func getCachedTags(err error) ([]string, error) {
+	if azureTags, found := cache.Cache.Get(tagsCacheKey); found {
+		log.Infof("unable to get tags from azure, returning cached tags: %s", err)
+		return azureTags.([]string), nil
+	}
+	return nil, fmt.Errorf("unable to get tags from azure and cache is empty: %s", err)
+}
+
+// GetTags gets the tags of the virtual machine from the Azure instance
+// metadata service. Reading the tags from the instance metadata service does
+// not require any role assignment.
+func GetTags(ctx context.Context) ([]string, error) {
+	metadataJSON, err := getResponse(ctx,
+		metadataURL+"/metadata/instance/compute?api-version=2021-02-01")
+	if err != nil {
+		return getCachedTags(err)
+	}
+
+	var metadata azureTagsMetadata
+	if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+		return getCachedTags(err)
+	}
+
+	tags := []string{}
+	for _, tag := range metadata.TagsList {
+		if !isTagCollected(tag.Name) {
+			continue
+		}
+		tags = append(tags, fmt.Sprintf("%s:%s", tag.Name, tag.Value))
+	}
+
+	// save tags to the cache in case we exceed quotas later
+	cache.Cache.Set(tagsCacheKey, tags, cache.NoExpiration)
+
+	return tags, nil
+}
+
+// isTagCollected returns whether the tag should be converted into a host tag:
+// the tag must not be excluded and, when an allowlist is set, be part of it.
+func isTagCollected(name string) bool {
+	if slices.Contains(pkgconfigsetup.Datadog().GetStringSlice("exclude_azure_tags"), name) {
+		return false
+	}
+
+	includedTags := pkgconfigsetup.Datadog().GetStringSlice("include_azure_tags")
+	return len(includedTags) == 0 || slices.Contains(includedTags, name)
+}
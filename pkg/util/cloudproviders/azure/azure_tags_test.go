@@ -0,0 +1,88 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package azure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configmock "github.com/DataDog/datadog-agent/pkg/config/mock"
+	"github.com/DataDog/datadog-agent/pkg/util/cache"
+)
+
+const computeMetadata = `{
+	"name": "my-vm",
+	"tagsList": [
+		{"name": "team", "value": "containers"},
+		{"name": "env", "value": "staging"},
+		{"name": "secret", "value": "hunter2"}
+	]
+}`
+
+func mockComputeMetadataRequest(t *testing.T, status int) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/metadata/instance/compute", r.URL.Path)
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, computeMetadata)
+	}))
+	metadataURL = ts.URL
+	return ts
+}
+
+func TestGetTags(t *testing.T) {
+	configmock.New(t)
+	server := mockComputeMetadataRequest(t, http.StatusOK)
+	defer server.Close()
+	defer cache.Cache.Delete(tagsCacheKey)
+
+	tags, err := GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team:containers", "env:staging", "secret:hunter2"}, tags)
+}
+
+func TestGetTagsWithTagFilters(t *testing.T) {
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("exclude_azure_tags", []string{"secret"})
+	mockConfig.SetWithoutSource("include_azure_tags", []string{"team", "secret"})
+	server := mockComputeMetadataRequest(t, http.StatusOK)
+	defer server.Close()
+	defer cache.Cache.Delete(tagsCacheKey)
+
+	tags, err := GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team:containers"}, tags)
+}
+
+func TestGetTagsSuccessThenError(t *testing.T) {
+	configmock.New(t)
+	server := mockComputeMetadataRequest(t, http.StatusOK)
+	_, err := GetTags(context.Background())
+	require.NoError(t, err)
+	server.Close()
+
+	server = mockComputeMetadataRequest(t, http.StatusInternalServerError)
+	defer server.Close()
+	defer cache.Cache.Delete(tagsCacheKey)
+
+	tags, err := GetTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team:containers", "env:staging", "secret:hunter2"}, tags)
+
+	cache.Cache.Delete(tagsCacheKey)
+	_, err = GetTags(context.Background())
+	assert.Error(t, err)
+}
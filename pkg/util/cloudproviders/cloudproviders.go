@@ -69,6 +69,64 @@ func DetectCloudProvider(ctx context.Context, collectAccountID bool, l logcomp.C
 	return "", ""
 }
 
+// InstanceMetadata describes the cloud instance the agent is running on.
+type InstanceMetadata struct {
+	CloudProvider    string
+	Region           string
+	AvailabilityZone string
+	InstanceType     string
+}
+
+type cloudProviderMetadataDetector struct {
+	name             string
+	callback         func(context.Context) bool
+	region           func(context.Context) (string, error)
+	availabilityZone func(context.Context) (string, error)
+	instanceType     func(context.Context) (string, error)
+}
+
+var instanceMetadataDetectors = []cloudProviderMetadataDetector{
+	{
+		name:             ec2.CloudProviderName,
+		callback:         ec2.IsRunningOn,
+		region:           ec2.GetRegion,
+		availabilityZone: ec2.GetAvailabilityZone,
+		instanceType:     ec2.GetInstanceType,
+	},
+	{
+		name:             gce.CloudProviderName,
+		callback:         gce.IsRunningOn,
+		region:           gce.GetRegion,
+		availabilityZone: gce.GetZone,
+		instanceType:     gce.GetMachineType,
+	},
+}
+
+// GetInstanceMetadata returns the metadata of the cloud instance the agent is
+// running on, from the first cloud provider detected. Only EC2 and GCE are
+// supported. Metadata that cannot be fetched is left empty.
+func GetInstanceMetadata(ctx context.Context) (*InstanceMetadata, error) {
+	for _, detector := range instanceMetadataDetectors {
+		if !detector.callback(ctx) {
+			continue
+		}
+
+		metadata := &InstanceMetadata{CloudProvider: detector.name}
+		fetch := func(name string, fetcher func(context.Context) (string, error)) string {
+			value, err := fetcher(ctx)
+			if err != nil {
+				log.Debugf("Could not fetch %s %s: %s", detector.name, name, err)
+			}
+			return value
+		}
+		metadata.Region = fetch("region", detector.region)
+		metadata.AvailabilityZone = fetch("availability zone", detector.availabilityZone)
+		metadata.InstanceType = fetch("instance type", detector.instanceType)
+		return metadata, nil
+	}
+	return nil, errors.New("no supported cloud provider detected")
+}
+
 type cloudProviderNTPDetector struct {
 	name     string
 	callback func(context.Context) []string
@@ -154,6 +154,57 @@ func GetPublicIPv4(ctx context.Context) (string, error) {
 	return publicIPv4Fetcher.FetchString(ctx)
 }
 
+var zoneFetcher = cachedfetch.Fetcher{
+	Name: "GCP Zone",
+	Attempt: func(ctx context.Context) (interface{}, error) {
+		zone, err := getResponse(ctx, metadataURL+"/instance/zone")
+		if err != nil {
+			return "", fmt.Errorf("unable to retrieve zone from GCE: %s", err)
+		}
+		// zone is in the format of 'projects/PROJECT_NUM/zones/ZONE'
+		zoneSplit := strings.Split(zone, "/")
+		return zoneSplit[len(zoneSplit)-1], nil
+	},
+}
+
+// GetZone returns the zone of the current GCE instance
+func GetZone(ctx context.Context) (string, error) {
+	return zoneFetcher.FetchString(ctx)
+}
+
+// GetRegion returns the region of the current GCE instance, derived from its
+// zone
+func GetRegion(ctx context.Context) (string, error) {
+	zone, err := GetZone(ctx)
+	if err != nil {
+		return "", err
+	}
+	// zones are named after their region, e.g. 'us-central1-a'
+	idx := strings.LastIndex(zone, "-")
+	if idx <= 0 {
+		return "", fmt.Errorf("unknown zone name from GCE: %q", zone)
+	}
+	return zone[:idx], nil
+}
+
+var machineTypeFetcher = cachedfetch.Fetcher{
+	Name: "GCP Machine Type",
+	Attempt: func(ctx context.Context) (interface{}, error) {
+		machineType, err := getResponse(ctx, metadataURL+"/instance/machine-type")
+		if err != nil {
+			return "", fmt.Errorf("unable to retrieve machine type from GCE: %s", err)
+		}
+		// machine type is in the format of 'projects/PROJECT_NUM/machineTypes/TYPE'
+		machineTypeSplit := strings.Split(machineType, "/")
+		return machineTypeSplit[len(machineTypeSplit)-1], nil
+	},
+}
+
+// GetMachineType returns the machine type of the current GCE instance
+func GetMachineType(ctx context.Context) (string, error) {
+	return machineTypeFetcher.FetchString(ctx)
+}
+
 var networkIDFetcher = cachedfetch.Fetcher{
 	Name: "GCP Network ID",
 	Attempt: func(ctx context.Context) (interface{}, error) {
@@ -111,5 +111,11 @@ func GetTags(ctx context.Context) ([]string, error) {
 func isAttributeExcluded(attr string) bool {
 
 	excludedAttributes := pkgconfigsetup.Datadog().GetStringSlice("exclude_gce_tags")
-	return slices.Contains(excludedAttributes, attr)
+	if slices.Contains(excludedAttributes, attr) {
+		return true
+	}
+
+	// when set, only the attributes of the allowlist are converted into tags
+	includedAttributes := pkgconfigsetup.Datadog().GetStringSlice("include_gce_tags")
+	return len(includedAttributes) > 0 && !slices.Contains(includedAttributes, attr)
 }
@@ -141,3 +141,28 @@ func TestGetHostTagsWithNonDefaultTagFilters(t *testing.T) {
 	require.NoError(t, err)
 	testTags(t, tags, expectedExcludedTags)
 }
+
+func TestGetHostTagsWithIncludedTags(t *testing.T) {
+	ctx := context.Background()
+	mockConfig := configmock.New(t)
+	mockConfig.SetWithoutSource("include_gce_tags", []string{"cluster-name", "kube-env"})
+
+	server := mockMetadataRequest(t)
+	defer server.Close()
+	defer cache.Cache.Delete(tagsCacheKey)
+
+	// the allowlist only applies to the metadata attributes, and the
+	// excluded attributes stay excluded
+	tags, err := GetTags(ctx)
+	require.NoError(t, err)
+	testTags(t, tags, []string{
+		"tag",
+		"zone:us-east1-b",
+		"instance-type:n1-standard-1",
+		"internal-hostname:dd-test.c.datadog-dd-test.internal",
+		"instance-id:1111111111111111111",
+		"project:test-project",
+		"numeric_project_id:111111111111",
+		"cluster-name:test-cluster",
+	})
+}
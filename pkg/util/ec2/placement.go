@@ -0,0 +1,37 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/DataDog/datadog-agent/pkg/util/cachedfetch"
+	ec2internal "github.com/DataDog/datadog-agent/pkg/util/ec2/internal"
+)
+
+var availabilityZoneFetcher = cachedfetch.Fetcher{
+	Name: "EC2 Availability Zone",
+	Attempt: func(ctx context.Context) (interface{}, error) {
+		return ec2internal.GetMetadataItemWithMaxLength(ctx, "/placement/availability-zone", ec2internal.UseIMDSv2(), false)
+	},
+}
+
+// GetAvailabilityZone returns the AWS availability zone as reported by EC2 IMDS.
+func GetAvailabilityZone(ctx context.Context) (string, error) {
+	return availabilityZoneFetcher.FetchString(ctx)
+}
+
+var instanceTypeFetcher = cachedfetch.Fetcher{
+	Name: "EC2 Instance Type",
+	Attempt: func(ctx context.Context) (interface{}, error) {
+		return ec2internal.GetMetadataItemWithMaxLength(ctx, "/instance-type", ec2internal.UseIMDSv2(), false)
+	},
+}
+
+// GetInstanceType returns the EC2 instance type as reported by EC2 IMDS.
+func GetInstanceType(ctx context.Context) (string, error) {
+	return instanceTypeFetcher.FetchString(ctx)
+}
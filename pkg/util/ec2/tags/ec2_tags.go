@@ -48,6 +48,16 @@ func isTagExcluded(tag string) bool {
 	return false
 }
 
+// isInstanceTagExcluded returns whether the EC2 instance tag should be excluded
+// from the host tags. When an allowlist is set, only its tags are collected.
+func isInstanceTagExcluded(tag string) bool {
+	if isTagExcluded(tag) {
+		return true
+	}
+	includedTags := pkgconfigsetup.Datadog().GetStringSlice("include_ec2_tags")
+	return len(includedTags) > 0 && !slices.Contains(includedTags, tag)
+}
+
 // GetInstanceInfo collects information about the EC2 instance as host tags. This mimic the tags set by the AWS
 // integration in Datadog backend allowing customer to collect those information without having to enable the crawler.
 func GetInstanceInfo(ctx context.Context) ([]string, error) {
@@ -141,7 +151,7 @@ func fetchEc2TagsFromIMDS(ctx context.Context) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		if isTagExcluded(key) {
+		if isInstanceTagExcluded(key) {
 			continue
 		}
 
@@ -207,7 +217,7 @@ func getTagsWithCreds(ctx context.Context, instanceIdentity *ec2internal.EC2Iden
 
 	tags := []string{}
 	for _, tag := range ec2Tags.Tags {
-		if isTagExcluded(*tag.Key) {
+		if isInstanceTagExcluded(*tag.Key) {
 			continue
 		}
 		tags = append(tags, fmt.Sprintf("%s:%s", *tag.Key, *tag.Value))
@@ -105,6 +105,37 @@ func TestFetchEc2TagsFromIMDS(t *testing.T) {
 	}, tags)
 }
 
+func TestFetchEc2TagsFromIMDSIncludedTags(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		switch r.RequestURI {
+		case "/tags/instance":
+			io.WriteString(w, "Name\nPurpose\nExcludedTag")
+		case "/tags/instance/Name":
+			io.WriteString(w, "some-vm")
+		case "/tags/instance/Purpose":
+			io.WriteString(w, "mining")
+		case "/tags/instance/ExcludedTag":
+			io.WriteString(w, "testing")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	ec2internal.MetadataURL = ts.URL
+	conf := configmock.New(t)
+	conf.SetWithoutSource("ec2_metadata_timeout", 1000)
+	conf.SetWithoutSource("exclude_ec2_tags", []string{"ExcludedTag"})
+	conf.SetWithoutSource("include_ec2_tags", []string{"Purpose", "ExcludedTag"})
+
+	tags, err := fetchEc2TagsFromIMDS(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Purpose:mining",
+	}, tags)
+}
+
 func TestFetchEc2TagsFromIMDSError(t *testing.T) {
 	ctx := context.Background()
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
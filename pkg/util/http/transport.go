@@ -247,3 +247,20 @@ func MaxConnsPerHost(maxConns int) func(*http.Transport) {
 		transport.MaxConnsPerHost = maxConns
 	}
 }
+
+// MaxIdleConnsPerHost configures the maximum number of idle connections kept
+// per host on the http transport
+func MaxIdleConnsPerHost(maxIdleConns int) func(*http.Transport) {
+	return func(transport *http.Transport) {
+		transport.MaxIdleConnsPerHost = maxIdleConns
+		transport.MaxIdleConns = max(transport.MaxIdleConns, maxIdleConns)
+	}
+}
+
+// IdleConnTimeout configures how long an idle connection is kept on the http
+// transport before being closed
+func IdleConnTimeout(timeout time.Duration) func(*http.Transport) {
+	return func(transport *http.Transport) {
+		transport.IdleConnTimeout = timeout
+	}
+}
@@ -215,6 +215,17 @@ func TestCreateHTTP2Transport(t *testing.T) {
 	assert.Contains(t, transport.TLSClientConfig.NextProtos, "http/1.1", "NextProtos should allow fallback to HTTP/1.1")
 }
 
+func TestCreateTransportConnectionPool(t *testing.T) {
+	c := configmock.New(t)
+
+	transport := CreateHTTPTransport(c, MaxIdleConnsPerHost(200), IdleConnTimeout(time.Minute))
+	require.NotNil(t, transport)
+
+	assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, transport.MaxIdleConns, "MaxIdleConns should not be lower than MaxIdleConnsPerHost")
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+}
+
 func TestNoProxyWarningMap(t *testing.T) {
 	setupTest(t)
 
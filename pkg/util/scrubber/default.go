@@ -158,6 +158,14 @@ func AddDefaultReplacers(scrubber *Scrubber) {
 	)
 	secretReplacer.LastUpdated = parseVersion("7.70.0") // https://github.com/DataDog/datadog-agent/pull/40345
 
+	// secret keys of S3/GCS-compatible object stores, such as the logs archive
+	secretAccessKeyReplacer := matchYAMLKey(
+		`secret_access_key`,
+		[]string{"secret_access_key"},
+		[]byte(`$1 "********"`),
+	)
+	secretAccessKeyReplacer.LastUpdated = parseVersion("7.73.0")
+
 	// OAuth credentials scrubbers for continuous_ai_netsuite and similar integrations
 	consumerKeyAndTokenIDReplacer := matchYAMLKey(
 		`(consumer_key|token_id)`,
@@ -291,6 +299,7 @@ func AddDefaultReplacers(scrubber *Scrubber) {
 	scrubber.AddReplacer(SingleLine, tokenReplacer)
 	scrubber.AddReplacer(SingleLine, consumerKeyAndTokenIDReplacer)
 	scrubber.AddReplacer(SingleLine, secretReplacer)
+	scrubber.AddReplacer(SingleLine, secretAccessKeyReplacer)
 	scrubber.AddReplacer(SingleLine, snmpReplacer)
 
 	scrubber.AddReplacer(SingleLine, apiKeyYaml)
@@ -472,6 +481,15 @@ func HideKeyExceptLastFiveChars(key string) string {
 	return strings.Repeat("*", len(key)-5) + key[len(key)-5:]
 }
 
+// NewYAMLKeysReplacer returns a replacer stripping the values of the given YAML keys.
+func NewYAMLKeysReplacer(keys []string) Replacer {
+	return matchYAMLKey(
+		fmt.Sprintf("(%s)", strings.Join(keys, "|")),
+		keys,
+		[]byte(`$1 "********"`),
+	)
+}
+
 // AddStrippedKeys adds to the set of YAML keys that will be recognized and have their values stripped. This modifies
 // the DefaultScrubber directly and be added to any created scrubbers.
 func AddStrippedKeys(strippedKeys []string) {
@@ -482,11 +500,7 @@ func AddStrippedKeys(strippedKeys []string) {
 	})
 
 	if len(strippedKeys) > 0 {
-		replacer := matchYAMLKey(
-			fmt.Sprintf("(%s)", strings.Join(strippedKeys, "|")),
-			strippedKeys,
-			[]byte(`$1 "********"`),
-		)
+		replacer := NewYAMLKeysReplacer(strippedKeys)
 		// We add the new replacer to the default scrubber and to the list of dynamicReplacers so any new
 		// scubber will inherit it.
 		DefaultScrubber.AddReplacer(SingleLine, replacer)
@@ -644,6 +644,14 @@ func TestOAuthCredentials(t *testing.T) {
 		`  token_id: "my_token_id_789"`,
 		`  token_id: "********"`)
 
+	// Test secret_access_key
+	assertClean(t,
+		`secret_access_key: my_secret_access_key`,
+		`secret_access_key: "********"`)
+	assertClean(t,
+		`    secret_access_key: "my_secret_access_key"`,
+		`    secret_access_key: "********"`)
+
 	// Test token_secret
 	assertClean(t,
 		`token_secret: my_token_secret_abc`,
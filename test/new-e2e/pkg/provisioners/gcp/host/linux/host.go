@@ -7,6 +7,8 @@
 package gcphost
 
 import (
+	"strings"
+
 	"github.com/DataDog/test-infra-definitions/resources/gcp"
 	"github.com/DataDog/test-infra-definitions/scenarios/gcp/compute"
 	"github.com/DataDog/test-infra-definitions/scenarios/gcp/fakeintake"
@@ -17,12 +19,18 @@ import (
 	"github.com/DataDog/test-infra-definitions/components/datadog/agent"
 	"github.com/DataDog/test-infra-definitions/components/datadog/agentparams"
 	"github.com/DataDog/test-infra-definitions/components/datadog/updater"
+	gcpcompute "github.com/pulumi/pulumi-gcp/sdk/v7/go/gcp/compute"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
 const (
 	provisionerBaseID = "gcp-vm-"
 	defaultVMName     = "vm"
+
+	defaultARM64InstanceType          = "t2a-standard-2"
+	defaultConfidentialVMInstanceType = "n2d-standard-2"
+
+	gcpInstanceResourceType = "gcp:compute/instance:Instance"
 )
 
 // Provisioner creates a VM environment with an VM, a FakeIntake and a Host Agent configured to talk to each other.
@@ -55,6 +63,13 @@ func Run(ctx *pulumi.Context, env *environments.Host, runParams RunParams) error
 	}
 	params := runParams.ProvisionerParams
 
+	if params.confidentialVM {
+		err := ctx.RegisterStackTransformation(confidentialVMTransformation(gcpEnv.Namer.ResourceName(params.name)))
+		if err != nil {
+			return err
+		}
+	}
+
 	host, err := compute.NewVM(gcpEnv, params.name, params.instanceOptions...)
 	if err != nil {
 		return err
@@ -122,3 +137,32 @@ func Run(ctx *pulumi.Context, env *environments.Host, runParams RunParams) error
 
 	return nil
 }
+
+// confidentialVMTransformation enables Confidential Computing on the instance of the VM. The instance options don't
+// expose it, the instance arguments are thus patched before the instance is created.
+func confidentialVMTransformation(vmResourceName string) pulumi.ResourceTransformation {
+	return func(args *pulumi.ResourceTransformationArgs) *pulumi.ResourceTransformationResult {
+		// the FakeIntake is also a compute instance, only patch the VM
+		if args.Type != gcpInstanceResourceType || !strings.HasPrefix(args.Name, vmResourceName) {
+			return nil
+		}
+
+		instanceArgs, ok := args.Props.(*gcpcompute.InstanceArgs)
+		if !ok {
+			return nil
+		}
+
+		instanceArgs.ConfidentialInstanceConfig = gcpcompute.InstanceConfidentialInstanceConfigArgs{
+			EnableConfidentialCompute: pulumi.Bool(true),
+		}
+		// Confidential VMs can't be live migrated
+		instanceArgs.Scheduling = gcpcompute.InstanceSchedulingArgs{
+			OnHostMaintenance: pulumi.String("TERMINATE"),
+		}
+
+		return &pulumi.ResourceTransformationResult{
+			Props: instanceArgs,
+			Opts:  args.Opts,
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/DataDog/test-infra-definitions/components/datadog/agentparams"
+	"github.com/DataDog/test-infra-definitions/components/os"
 	"github.com/DataDog/test-infra-definitions/resources/gcp"
 	"github.com/DataDog/test-infra-definitions/scenarios/gcp/compute"
 	"github.com/DataDog/test-infra-definitions/scenarios/gcp/fakeintake"
@@ -30,6 +31,7 @@ type ProvisionerParams struct {
 	fakeintakeOptions  []fakeintake.Option
 	extraConfigParams  runner.ConfigMap
 	installUpdater     bool
+	confidentialVM     bool
 }
 
 func newProvisionerParams() *ProvisionerParams {
@@ -129,6 +131,21 @@ func WithUpdater() ProvisionerOption {
 	}
 }
 
+// WithARM64 provisions an arm64 VM running the given OS, on a T2A machine type.
+func WithARM64(osDesc os.Descriptor) ProvisionerOption {
+	return WithInstanceOptions(compute.WithOSArch(osDesc, os.ARM64Arch), compute.WithInstanceType(defaultARM64InstanceType))
+}
+
+// WithConfidentialVM provisions a Confidential VM, using AMD SEV on a N2D machine type. The OS of the VM must
+// provide an image supporting Confidential Computing, which is the case of the default Ubuntu image.
+func WithConfidentialVM() ProvisionerOption {
+	return func(params *ProvisionerParams) error {
+		params.confidentialVM = true
+		params.instanceOptions = append(params.instanceOptions, compute.WithInstanceType(defaultConfidentialVMInstanceType))
+		return nil
+	}
+}
+
 // ProvisionerNoAgentNoFakeIntake wraps Provisioner with hardcoded WithoutAgent and WithoutFakeIntake options.
 func ProvisionerNoAgentNoFakeIntake(opts ...ProvisionerOption) provisioners.TypedProvisioner[environments.Host] {
 	mergedOpts := make([]ProvisionerOption, 0, len(opts)+2)
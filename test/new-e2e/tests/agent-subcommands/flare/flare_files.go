@@ -17,6 +17,7 @@ var defaultFlareFiles = []string{
 	"permissions.log",
 	"process_agent_runtime_config_dump.yaml",
 	"runtime_config_dump.yaml",
+	"runtime_config_provenance.yaml",
 	"secrets.log",
 	"status.log",
 	"system_probe_runtime_config_dump.yaml",
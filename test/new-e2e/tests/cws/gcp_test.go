@@ -17,6 +17,7 @@ import (
 	gcphost "github.com/DataDog/datadog-agent/test/new-e2e/pkg/provisioners/gcp/host/linux"
 	"github.com/DataDog/datadog-agent/test/new-e2e/tests/cws/config"
 	"github.com/DataDog/test-infra-definitions/components/datadog/agentparams"
+	"github.com/DataDog/test-infra-definitions/components/os"
 )
 
 const (
@@ -25,21 +26,33 @@ const (
 )
 
 func TestAgentSuiteGCP(t *testing.T) {
+	runAgentSuiteGCP(t, "cws-agentSuite-gcp")
+}
+
+func TestAgentSuiteGCPARM64(t *testing.T) {
+	runAgentSuiteGCP(t, "cws-agentSuite-gcp-arm64", gcphost.WithARM64(os.UbuntuDefault))
+}
+
+func TestAgentSuiteGCPConfidentialVM(t *testing.T) {
+	runAgentSuiteGCP(t, "cws-agentSuite-gcp-cvm", gcphost.WithConfidentialVM())
+}
+
+func runAgentSuiteGCP(t *testing.T, stackName string, opts ...gcphost.ProvisionerOption) {
 	testID := uuid.NewString()[:4]
 	ddHostname := fmt.Sprintf("%s-%s", gcpHostnamePrefix, testID)
 	agentConfig := config.GenDatadogAgentConfig(ddHostname, "tag1", "tag2")
 	t.Logf("Running testsuite with DD_HOSTNAME=%s", ddHostname)
-	e2e.Run[environments.Host](t, &agentSuite{testID: testID},
-		e2e.WithStackName("cws-agentSuite-gcp"),
-		e2e.WithProvisioner(
-			gcphost.ProvisionerNoFakeIntake(
-				gcphost.WithAgentOptions(
-
-					agentparams.WithAgentConfig(agentConfig),
-					agentparams.WithSecurityAgentConfig(securityAgentConfig),
-					agentparams.WithSystemProbeConfig(systemProbeConfig),
-				),
-			),
+
+	provisionerOpts := append([]gcphost.ProvisionerOption{
+		gcphost.WithAgentOptions(
+			agentparams.WithAgentConfig(agentConfig),
+			agentparams.WithSecurityAgentConfig(securityAgentConfig),
+			agentparams.WithSystemProbeConfig(systemProbeConfig),
 		),
+	}, opts...)
+
+	e2e.Run[environments.Host](t, &agentSuite{testID: testID},
+		e2e.WithStackName(stackName),
+		e2e.WithProvisioner(gcphost.ProvisionerNoFakeIntake(provisionerOpts...)),
 	)
 }